@@ -28,6 +28,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/storobj"
 	"github.com/semi-technologies/weaviate/usecases/objects"
@@ -330,10 +331,13 @@ func (c *RemoteIndex) MultiGetObjects(ctx context.Context, hostName, indexName,
 }
 
 func (c *RemoteIndex) SearchShard(ctx context.Context, hostName, indexName,
-	shardName string, vector []float32, limit int, filters *filters.LocalFilter,
+	shardName string, vector []float32, targetVector string,
+	waitForIndexing bool, limit int,
+	filters *filters.LocalFilter, geoSort *filters.GeoSort,
 	additional additional.Properties) ([]*storobj.Object, []float32, error) {
 	paramsBytes, err := clusterapi.IndicesPayloads.SearchParams.
-		Marshal(vector, limit, filters, additional)
+		Marshal(vector, targetVector, waitForIndexing, limit, filters,
+			geoSort, additional)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "marshal request payload")
 	}
@@ -378,6 +382,58 @@ func (c *RemoteIndex) SearchShard(ctx context.Context, hostName, indexName,
 	return objs, dists, nil
 }
 
+func (c *RemoteIndex) BatchSearchShard(ctx context.Context, hostName, indexName,
+	shardName string, vectors [][]float32, targetVector string,
+	waitForIndexing bool, limit int,
+	filters *filters.LocalFilter,
+	additional additional.Properties) ([][]*storobj.Object, [][]float32, error) {
+	paramsBytes, err := clusterapi.IndicesPayloads.BatchSearchParams.
+		Marshal(vectors, targetVector, waitForIndexing, limit, filters,
+			additional)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "marshal request payload")
+	}
+
+	path := fmt.Sprintf("/indices/%s/shards/%s/objects/_batch_search", indexName, shardName)
+	method := http.MethodPost
+	url := url.URL{Scheme: "http", Host: hostName, Path: path}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(),
+		bytes.NewReader(paramsBytes))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "open http request")
+	}
+
+	clusterapi.IndicesPayloads.BatchSearchParams.SetContentTypeHeaderReq(req)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "send http request")
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return nil, nil, errors.Errorf("unexpected status code %d (%s)", res.StatusCode,
+			body)
+	}
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read body")
+	}
+
+	ct, ok := clusterapi.IndicesPayloads.BatchSearchResults.CheckContentTypeHeader(res)
+	if !ok {
+		return nil, nil, errors.Errorf("unexpected content type: %s", ct)
+	}
+
+	objs, dists, err := clusterapi.IndicesPayloads.BatchSearchResults.Unmarshal(resBytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshal body")
+	}
+	return objs, dists, nil
+}
+
 func (c *RemoteIndex) Aggregate(ctx context.Context, hostName, indexName,
 	shardName string, params aggregation.Params) (*aggregation.Result, error) {
 	paramsBytes, err := clusterapi.IndicesPayloads.AggregationParams.
@@ -426,3 +482,44 @@ func (c *RemoteIndex) Aggregate(ctx context.Context, hostName, indexName,
 
 	return aggRes, nil
 }
+
+func (c *RemoteIndex) ShardStorageStatistics(ctx context.Context, hostName,
+	indexName, shardName string) (*models.ShardStorageStatistics, error) {
+	path := fmt.Sprintf("/indices/%s/shards/%s/storage", indexName, shardName)
+	method := http.MethodGet
+	url := url.URL{Scheme: "http", Host: hostName, Path: path}
+
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "open http request")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send http request")
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return nil, errors.Errorf("unexpected status code %d (%s)", res.StatusCode,
+			body)
+	}
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read body")
+	}
+
+	ct, ok := clusterapi.IndicesPayloads.ShardStorageStatistics.CheckContentTypeHeader(res)
+	if !ok {
+		return nil, errors.Errorf("unexpected content type: %s", ct)
+	}
+
+	stats, err := clusterapi.IndicesPayloads.ShardStorageStatistics.Unmarshal(resBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+
+	return stats, nil
+}
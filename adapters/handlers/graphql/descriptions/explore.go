@@ -26,4 +26,5 @@ const (
 	ID                   = "Concept identifier in the uuid format"
 	Beacon               = "Concept identifier in the beacon format, such as weaviate://<hostname>/<kind>/id"
 	Distance             = "Normalized Distance between the result item and the search vector. Normalized to be between 0 (identical vectors) and 1 (perfect opposite)."
+	TargetVector         = "Name of the named vector to search, as defined in the vectorConfig of the class. Searches the class-level vector if not set"
 )
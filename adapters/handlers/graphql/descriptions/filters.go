@@ -66,6 +66,13 @@ const (
 	WhereValueRangeGeoCoordinatesLongitude = "The longitude (in decimal format) of the geoCoordinates to search around."
 	WhereValueRangeDistance                = "The distance from the point specified via geoCoordinates."
 	WhereValueRangeDistanceMax             = "The maximum distance from the point specified geoCoordinates."
+	WhereValueGeoBoundingBox               = "Specify the top left and bottom right corners of a box. The search will return any result which is located within the box. If the longitude of the top left corner is larger than the one of the bottom right corner, the box crosses the antimeridian."
+	WhereValueGeoBoundingBoxTopLeft        = "The top left (north west) corner of the box."
+	WhereValueGeoBoundingBoxBottomRight    = "The bottom right (south east) corner of the box."
+	WhereValueGeoPolygon                   = "Specify the outline of a polygon. The search will return any result which is located within the polygon."
+	WhereValueGeoPolygonVertices           = "At least 3 vertices of the outline of the polygon, in order. The last vertex is connected to the first one."
+	WhereValueGeoCoordinatesLatitude       = "The latitude (in decimal format) of the point."
+	WhereValueGeoCoordinatesLongitude      = "The longitude (in decimal format) of the point."
 	WhereValueText                         = "Specify a Text value that the target property will be compared to"
 	WhereValueDate                         = "Specify a Date value that the target property will be compared to"
 )
@@ -26,6 +26,14 @@ const GetObjectsActionsObj = "An object used to get %ss on a local Weaviate"
 
 const GetClassUUID = "The UUID of a Object, assigned by its local Weaviate"
 
+const GetWaitForIndexing = "Wait until all vectors written before this query are part of the vector index. Only has an effect if vectors are indexed asynchronously"
+
+const (
+	GetGeoSort               = "Order the results by their distance to a point, nearest first. The distance in meters is available as _additional { distance }"
+	GetGeoSortPath           = "Path to the geoCoordinates property to sort by"
+	GetGeoSortGeoCoordinates = "The point to measure the distance to"
+)
+
 // Network
 const (
 	NetworkGet    = "Get Objects from a Weaviate in a network"
@@ -34,5 +34,10 @@ func ExtractNearObject(source map[string]interface{}) traverser.NearObjectParams
 		args.Certainty = certainty.(float64)
 	}
 
+	targetVector, ok := source["targetVector"]
+	if ok {
+		args.TargetVector = targetVector.(string)
+	}
+
 	return args
 }
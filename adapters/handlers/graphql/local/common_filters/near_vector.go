@@ -31,5 +31,10 @@ func ExtractNearVector(source map[string]interface{}) traverser.NearVectorParams
 		args.Certainty = certainty.(float64)
 	}
 
+	targetVector, ok := source["targetVector"]
+	if ok {
+		args.TargetVector = targetVector.(string)
+	}
+
 	return args
 }
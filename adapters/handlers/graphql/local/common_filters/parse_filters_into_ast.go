@@ -54,7 +54,7 @@ func parseClause(args map[string]interface{}, rootClass string) (*filters.Clause
 	case "Or":
 		clause, err = parseOperandsOp(args, filters.OperatorOr, rootClass)
 	case "Not":
-		clause, err = parseOperandsOp(args, filters.OperatorOr, rootClass)
+		clause, err = parseOperandsOp(args, filters.OperatorNot, rootClass)
 	case "Equal":
 		clause, err = parseCompareOp(args, filters.OperatorEqual, rootClass)
 	case "Like":
@@ -71,6 +71,10 @@ func parseClause(args map[string]interface{}, rootClass string) (*filters.Clause
 		clause, err = parseCompareOp(args, filters.OperatorLessThanEqual, rootClass)
 	case "WithinGeoRange":
 		clause, err = parseCompareOp(args, filters.OperatorWithinGeoRange, rootClass)
+	case "WithinGeoBoundingBox":
+		clause, err = parseCompareOp(args, filters.OperatorWithinGeoBoundingBox, rootClass)
+	case "WithinGeoPolygon":
+		clause, err = parseCompareOp(args, filters.OperatorWithinGeoPolygon, rootClass)
 	default:
 		err = fmt.Errorf("Unknown operator '%s' in clause %s", operator, jsonify(args))
 	}
@@ -320,6 +324,47 @@ var valueExtractors [](func(args map[string]interface{}) (*filters.Value, error)
 			},
 		}, nil
 	},
+	func(args map[string]interface{}) (*filters.Value, error) {
+		rawVal, ok := args["valueGeoBoundingBox"]
+		if !ok {
+			return nil, nil
+		}
+
+		boxMap, ok := rawVal.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the provided valueGeoBoundingBox is not a map")
+		}
+
+		return &filters.Value{
+			Type: schema.DataTypeGeoCoordinates,
+			Value: filters.GeoBoundingBox{
+				TopLeft:     parseGeoCoordinates(boxMap["topLeft"]),
+				BottomRight: parseGeoCoordinates(boxMap["bottomRight"]),
+			},
+		}, nil
+	},
+	func(args map[string]interface{}) (*filters.Value, error) {
+		rawVal, ok := args["valueGeoPolygon"]
+		if !ok {
+			return nil, nil
+		}
+
+		polygonMap, ok := rawVal.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("the provided valueGeoPolygon is not a map")
+		}
+
+		rawVertices := polygonMap["vertices"].([]interface{})
+		vertices := make([]*models.GeoCoordinates, len(rawVertices))
+		for i, vertex := range rawVertices {
+			vertices[i] = parseGeoCoordinates(vertex)
+		}
+
+		return &filters.Value{
+			Type:  schema.DataTypeGeoCoordinates,
+			Value: filters.GeoPolygon{Vertices: vertices},
+		}, nil
+	},
 	// Dates
 	func(args map[string]interface{}) (*filters.Value, error) {
 		rawVal, ok := args["valueDate"]
@@ -344,6 +389,19 @@ var valueExtractors [](func(args map[string]interface{}) (*filters.Value, error)
 	},
 }
 
+// parseGeoCoordinates expects the latitude and longitude to be set, which is
+// guaranteed by the graphql schema
+func parseGeoCoordinates(in interface{}) *models.GeoCoordinates {
+	c9s := in.(map[string]interface{})
+	lat := c9s["latitude"].(float64)
+	lon := c9s["longitude"].(float64)
+
+	return &models.GeoCoordinates{
+		Latitude:  ptFloat32(float32(lat)),
+		Longitude: ptFloat32(float32(lon)),
+	}
+}
+
 func ptFloat32(in float32) *float32 {
 	return &in
 }
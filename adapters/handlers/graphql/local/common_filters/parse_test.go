@@ -180,6 +180,82 @@ func TestExtractFilterGeoLocation(t *testing.T) {
 	})
 }
 
+func TestExtractFilterGeoShapes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with a bounding box", func(t *testing.T) {
+		resolver := newMockResolver()
+		expectedParams := &filters.LocalFilter{Root: &filters.Clause{
+			Operator: filters.OperatorWithinGeoBoundingBox,
+			On: &filters.Path{
+				Class:    schema.AssertValidClassName("SomeAction"),
+				Property: schema.AssertValidPropertyName("location"),
+			},
+			Value: &filters.Value{
+				Value: filters.GeoBoundingBox{
+					TopLeft: &models.GeoCoordinates{
+						Latitude:  ptFloat32(50),
+						Longitude: ptFloat32(5),
+					},
+					BottomRight: &models.GeoCoordinates{
+						Latitude:  ptFloat32(45),
+						Longitude: ptFloat32(15),
+					},
+				},
+				Type: schema.DataTypeGeoCoordinates,
+			},
+		}}
+
+		resolver.On("ReportFilters", expectedParams).
+			Return(test_helper.EmptyList(), nil).Once()
+
+		query := `{ SomeAction(where: {
+			path: ["location"],
+			operator: WithinGeoBoundingBox,
+			valueGeoBoundingBox: {
+				topLeft: { latitude: 50, longitude: 5 },
+				bottomRight: { latitude: 45, longitude: 15 }
+			}
+		}) }`
+		resolver.AssertResolve(t, query)
+	})
+
+	t.Run("with a polygon", func(t *testing.T) {
+		resolver := newMockResolver()
+		expectedParams := &filters.LocalFilter{Root: &filters.Clause{
+			Operator: filters.OperatorWithinGeoPolygon,
+			On: &filters.Path{
+				Class:    schema.AssertValidClassName("SomeAction"),
+				Property: schema.AssertValidPropertyName("location"),
+			},
+			Value: &filters.Value{
+				Value: filters.GeoPolygon{
+					Vertices: []*models.GeoCoordinates{
+						{Latitude: ptFloat32(0), Longitude: ptFloat32(0)},
+						{Latitude: ptFloat32(0), Longitude: ptFloat32(1)},
+						{Latitude: ptFloat32(1), Longitude: ptFloat32(0.5)},
+					},
+				},
+				Type: schema.DataTypeGeoCoordinates,
+			},
+		}}
+
+		resolver.On("ReportFilters", expectedParams).
+			Return(test_helper.EmptyList(), nil).Once()
+
+		query := `{ SomeAction(where: {
+			path: ["location"],
+			operator: WithinGeoPolygon,
+			valueGeoPolygon: { vertices: [
+				{ latitude: 0, longitude: 0 },
+				{ latitude: 0, longitude: 1 },
+				{ latitude: 1, longitude: 0.5 }
+			] }
+		}) }`
+		resolver.AssertResolve(t, query)
+	})
+}
+
 func TestExtractFilterNestedField(t *testing.T) {
 	t.Parallel()
 
@@ -124,6 +124,7 @@ func (b *classBuilder) additionalFields(classProperties graphql.Fields, class *m
 	additionalProperties := graphql.Fields{}
 	additionalProperties["classification"] = b.additionalClassificationField(class)
 	additionalProperties["certainty"] = b.additionalCertaintyField(class)
+	additionalProperties["distance"] = b.additionalDistanceField(class)
 	additionalProperties["vector"] = b.additionalVectorField(class)
 	additionalProperties["id"] = b.additionalIDField()
 	// module specific additional properties
@@ -168,6 +169,12 @@ func (b *classBuilder) additionalCertaintyField(class *models.Class) *graphql.Fi
 	}
 }
 
+func (b *classBuilder) additionalDistanceField(class *models.Class) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.Float,
+	}
+}
+
 func (b *classBuilder) additionalVectorField(class *models.Class) *graphql.Field {
 	return &graphql.Field{
 		Type: graphql.NewList(graphql.Float),
@@ -209,11 +209,16 @@ func buildGetClassField(classObject *graphql.Object,
 				Description: descriptions.After,
 				Type:        graphql.Int,
 			},
+			"waitForIndexing": &graphql.ArgumentConfig{
+				Description: descriptions.GetWaitForIndexing,
+				Type:        graphql.Boolean,
+			},
 
 			"nearVector": nearVectorArgument(class.Class),
 			"nearObject": nearObjectArgument(class.Class),
 			"where":      whereArgument(class.Class),
 			"group":      groupArgument(class.Class),
+			"geoSort":    geoSortArgument(class.Class),
 		},
 		Resolve: newResolver(modulesProvider).makeResolveGetClass(class.Class),
 	}
@@ -343,6 +348,13 @@ func (r *resolver) makeResolveGetClass(className string) graphql.FieldResolveFn
 
 		group := extractGroup(p.Args)
 
+		geoSort, err := extractGeoSort(p.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		waitForIndexing, _ := p.Args["waitForIndexing"].(bool)
+
 		params := traverser.GetParams{
 			Filters:              filters,
 			ClassName:            className,
@@ -353,6 +365,8 @@ func (r *resolver) makeResolveGetClass(className string) graphql.FieldResolveFn
 			Group:                group,
 			ModuleParams:         moduleParams,
 			AdditionalProperties: additional,
+			WaitForIndexing:      waitForIndexing,
+			GeoSort:              geoSort,
 		}
 
 		return func() (interface{}, error) {
@@ -409,7 +423,8 @@ type additionalCheck struct {
 }
 
 func (ac *additionalCheck) isAdditional(name string) bool {
-	if name == "classification" || name == "certainty" || name == "id" || name == "vector" {
+	if name == "classification" || name == "certainty" || name == "distance" ||
+		name == "id" || name == "vector" {
 		return true
 	}
 	if ac.isModuleAdditional(name) {
@@ -478,6 +493,10 @@ func extractProperties(className string, selections *ast.SelectionSet,
 							additionalProps.Certainty = true
 							continue
 						}
+						if additionalProperty == "distance" {
+							additionalProps.Distance = true
+							continue
+						}
 						if additionalProperty == "id" {
 							additionalProps.ID = true
 							continue
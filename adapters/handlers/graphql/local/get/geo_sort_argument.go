@@ -0,0 +1,93 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package get
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/semi-technologies/weaviate/adapters/handlers/graphql/descriptions"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+func geoSortArgument(className string) *graphql.ArgumentConfig {
+	prefix := fmt.Sprintf("GetObjects%s", className)
+	return &graphql.ArgumentConfig{
+		Description: descriptions.GetGeoSort,
+		Type: graphql.NewInputObject(
+			graphql.InputObjectConfig{
+				Name:        fmt.Sprintf("%sGeoSortInpObj", prefix),
+				Fields:      geoSortFields(prefix),
+				Description: descriptions.GetGeoSort,
+			},
+		),
+	}
+}
+
+func geoSortFields(prefix string) graphql.InputObjectConfigFieldMap {
+	return graphql.InputObjectConfigFieldMap{
+		"path": &graphql.InputObjectFieldConfig{
+			Description: descriptions.GetGeoSortPath,
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.String)),
+		},
+		"geoCoordinates": &graphql.InputObjectFieldConfig{
+			Description: descriptions.GetGeoSortGeoCoordinates,
+			Type: graphql.NewNonNull(graphql.NewInputObject(
+				graphql.InputObjectConfig{
+					Name: fmt.Sprintf("%sGeoSortGeoCoordinatesInpObj", prefix),
+					Fields: graphql.InputObjectConfigFieldMap{
+						"latitude": &graphql.InputObjectFieldConfig{
+							Description: descriptions.WhereValueGeoCoordinatesLatitude,
+							Type:        graphql.NewNonNull(graphql.Float),
+						},
+						"longitude": &graphql.InputObjectFieldConfig{
+							Description: descriptions.WhereValueGeoCoordinatesLongitude,
+							Type:        graphql.NewNonNull(graphql.Float),
+						},
+					},
+				},
+			)),
+		},
+	}
+}
+
+func extractGeoSort(args map[string]interface{}) (*filters.GeoSort, error) {
+	geoSort, ok := args["geoSort"]
+	if !ok {
+		return nil, nil
+	}
+
+	asMap := geoSort.(map[string]interface{}) // guaranteed by graphql
+	path := asMap["path"].([]interface{})
+	if len(path) != 1 {
+		return nil, fmt.Errorf("geoSort: path must contain exactly one " +
+			"property, sorting by properties of referenced objects is not supported")
+	}
+
+	property, ok := path[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("geoSort: path must contain a property name")
+	}
+
+	coordinates := asMap["geoCoordinates"].(map[string]interface{})
+	lat := float32(coordinates["latitude"].(float64))
+	lon := float32(coordinates["longitude"].(float64))
+
+	return &filters.GeoSort{
+		Property: property,
+		GeoCoordinates: &models.GeoCoordinates{
+			Latitude:  &lat,
+			Longitude: &lon,
+		},
+	}, nil
+}
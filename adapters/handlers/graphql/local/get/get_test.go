@@ -315,6 +315,28 @@ func TestExtractAdditionalFields(t *testing.T) {
 				},
 			},
 		},
+		test{
+			name:  "with _additional distance",
+			query: "{ Get { SomeAction { _additional { distance } } } }",
+			expectedParams: traverser.GetParams{
+				ClassName: "SomeAction",
+				AdditionalProperties: additional.Properties{
+					Distance: true,
+				},
+			},
+			resolverReturn: []interface{}{
+				map[string]interface{}{
+					"_additional": map[string]interface{}{
+						"distance": -12.5,
+					},
+				},
+			},
+			expectedResult: map[string]interface{}{
+				"_additional": map[string]interface{}{
+					"distance": -12.5,
+				},
+			},
+		},
 		test{
 			name:  "with _additional vector",
 			query: "{ Get { SomeAction { _additional { vector } } } }",
@@ -809,6 +831,45 @@ func TestNearVectorRanker(t *testing.T) {
 
 		resolver.AssertResolve(t, query)
 	})
+
+	t.Run("for things with a target vector set", func(t *testing.T) {
+		query := `{ Get { SomeThing(nearVector: {
+							  vector: [0.123, 0.984]
+								targetVector: "title"
+        			}) { intField } } }`
+
+		expectedParams := traverser.GetParams{
+			ClassName:  "SomeThing",
+			Properties: []search.SelectProperty{{Name: "intField", IsPrimitive: true}},
+			NearVector: &traverser.NearVectorParams{
+				Vector:       []float32{0.123, 0.984},
+				TargetVector: "title",
+			},
+		}
+		resolver.On("GetClass", expectedParams).
+			Return([]interface{}{}, nil).Once()
+
+		resolver.AssertResolve(t, query)
+	})
+
+	t.Run("for things waiting for the vector index", func(t *testing.T) {
+		query := `{ Get { SomeThing(waitForIndexing: true, nearVector: {
+							  vector: [0.123, 0.984]
+        			}) { intField } } }`
+
+		expectedParams := traverser.GetParams{
+			ClassName:  "SomeThing",
+			Properties: []search.SelectProperty{{Name: "intField", IsPrimitive: true}},
+			NearVector: &traverser.NearVectorParams{
+				Vector: []float32{0.123, 0.984},
+			},
+			WaitForIndexing: true,
+		}
+		resolver.On("GetClass", expectedParams).
+			Return([]interface{}{}, nil).Once()
+
+		resolver.AssertResolve(t, query)
+	})
 }
 
 func TestExtractPagination(t *testing.T) {
@@ -894,6 +955,49 @@ func TestExtractGroupParams(t *testing.T) {
 	resolver.AssertResolve(t, query)
 }
 
+func TestExtractGeoSortParams(t *testing.T) {
+	t.Parallel()
+
+	t.Run("on a geo property", func(t *testing.T) {
+		resolver := newMockResolver()
+
+		lat, lon := float32(52.366667), float32(4.9)
+		expectedParams := traverser.GetParams{
+			ClassName:  "SomeAction",
+			Properties: []search.SelectProperty{{Name: "intField", IsPrimitive: true}},
+			GeoSort: &filters.GeoSort{
+				Property: "location",
+				GeoCoordinates: &models.GeoCoordinates{
+					Latitude:  &lat,
+					Longitude: &lon,
+				},
+			},
+		}
+
+		resolver.On("GetClass", expectedParams).
+			Return(test_helper.EmptyList(), nil).Once()
+
+		query := `{ Get { SomeAction(geoSort: {path: ["location"],
+			geoCoordinates: {latitude: 52.366667, longitude: 4.9}}) { intField } } }`
+		resolver.AssertResolve(t, query)
+	})
+
+	t.Run("with a path to a referenced property", func(t *testing.T) {
+		resolver := newMockResolver()
+
+		query := `{ Get { SomeAction(geoSort: {path: ["hasAction", "SomeAction", "location"],
+			geoCoordinates: {latitude: 52.366667, longitude: 4.9}}) { intField } } }`
+		resolver.AssertFailToResolve(t, query)
+	})
+
+	t.Run("without coordinates", func(t *testing.T) {
+		resolver := newMockResolver()
+
+		query := `{ Get { SomeAction(geoSort: {path: ["location"]}) { intField } } }`
+		resolver.AssertFailToResolve(t, query)
+	})
+}
+
 func TestGetRelation(t *testing.T) {
 	t.Parallel()
 
@@ -50,6 +50,10 @@ func (f fakeVectorConfig) IndexType() string {
 	return "fake"
 }
 
+func (f fakeVectorConfig) DistanceName() string {
+	return "fake"
+}
+
 func dummyParseVectorConfig(in interface{}) (schemaent.VectorIndexConfig, error) {
 	return fakeVectorConfig(in.(map[string]interface{})), nil
 }
@@ -164,3 +168,31 @@ func (n *NilMigrator) ValidateVectorIndexConfigUpdate(ctx context.Context, old,
 func (n *NilMigrator) UpdateVectorIndexConfig(ctx context.Context, className string, updated schemaent.VectorIndexConfig) error {
 	return nil
 }
+
+func (n *NilMigrator) UpdateNamedVectorIndexConfigs(ctx context.Context, className string, updated map[string]schemaent.VectorIndexConfig) error {
+	return nil
+}
+
+func (n *NilMigrator) GetShardsStatus(ctx context.Context, className string) (models.ShardStatusList, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) InspectVectorIndex(ctx context.Context, className, shardName, targetVector string) (*models.VectorIndexGraphReport, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) RepairVectorIndex(ctx context.Context, className, shardName, targetVector string) (*models.VectorIndexRepairResult, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) EvaluateVectorIndexRecall(ctx context.Context, className, shardName, targetVector string, params *models.VectorIndexRecallRequest) (*models.VectorIndexRecallReport, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) ScrubShard(ctx context.Context, className, shardName string, quarantine bool) (*models.ShardScrubReport, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) GetStorageStatistics(ctx context.Context, className string) (*models.StorageStatistics, error) {
+	return nil, nil
+}
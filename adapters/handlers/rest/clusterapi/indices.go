@@ -25,6 +25,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/storobj"
 	"github.com/semi-technologies/weaviate/usecases/objects"
@@ -34,9 +35,11 @@ type indices struct {
 	shards                    shards
 	regexpObjects             *regexp.Regexp
 	regexpObjectsSearch       *regexp.Regexp
+	regexpObjectsBatchSearch  *regexp.Regexp
 	regexpObjectsAggregations *regexp.Regexp
 	regexpObject              *regexp.Regexp
 	regexpReferences          *regexp.Regexp
+	regexpStorage             *regexp.Regexp
 }
 
 const (
@@ -44,12 +47,16 @@ const (
 		`\/shards\/([A-Za-z0-9]+)\/objects`
 	urlPatternObjectsSearch = `\/indices\/([A-Za-z0-9_+-]+)` +
 		`\/shards\/([A-Za-z0-9]+)\/objects\/_search`
+	urlPatternObjectsBatchSearch = `\/indices\/([A-Za-z0-9_+-]+)` +
+		`\/shards\/([A-Za-z0-9]+)\/objects\/_batch_search`
 	urlPatternObjectsAggregations = `\/indices\/([A-Za-z0-9_+-]+)` +
 		`\/shards\/([A-Za-z0-9]+)\/objects\/_aggregations`
 	urlPatternObject = `\/indices\/([A-Za-z0-9_+-]+)` +
 		`\/shards\/([A-Za-z0-9]+)\/objects\/([A-Za-z0-9_+-]+)`
 	urlPatternReferences = `\/indices\/([A-Za-z0-9_+-]+)` +
 		`\/shards\/([A-Za-z0-9]+)\/references`
+	urlPatternStorage = `\/indices\/([A-Za-z0-9_+-]+)` +
+		`\/shards\/([A-Za-z0-9]+)\/storage`
 )
 
 type shards interface {
@@ -67,19 +74,28 @@ type shards interface {
 	MultiGetObjects(ctx context.Context, indexName, shardName string,
 		id []strfmt.UUID) ([]*storobj.Object, error)
 	Search(ctx context.Context, indexName, shardName string,
-		vector []float32, limit int, filters *filters.LocalFilter,
+		vector []float32, targetVector string, waitForIndexing bool, limit int,
+		filters *filters.LocalFilter, geoSort *filters.GeoSort,
 		additional additional.Properties) ([]*storobj.Object, []float32, error)
+	BatchSearch(ctx context.Context, indexName, shardName string,
+		vectors [][]float32, targetVector string, waitForIndexing bool, limit int,
+		filters *filters.LocalFilter,
+		additional additional.Properties) ([][]*storobj.Object, [][]float32, error)
 	Aggregate(ctx context.Context, indexName, shardName string,
 		params aggregation.Params) (*aggregation.Result, error)
+	ShardStorageStatistics(ctx context.Context, indexName,
+		shardName string) (*models.ShardStorageStatistics, error)
 }
 
 func NewIndices(shards shards) *indices {
 	return &indices{
 		regexpObjects:             regexp.MustCompile(urlPatternObjects),
 		regexpObjectsSearch:       regexp.MustCompile(urlPatternObjectsSearch),
+		regexpObjectsBatchSearch:  regexp.MustCompile(urlPatternObjectsBatchSearch),
 		regexpObjectsAggregations: regexp.MustCompile(urlPatternObjectsAggregations),
 		regexpObject:              regexp.MustCompile(urlPatternObject),
 		regexpReferences:          regexp.MustCompile(urlPatternReferences),
+		regexpStorage:             regexp.MustCompile(urlPatternStorage),
 		shards:                    shards,
 	}
 }
@@ -96,6 +112,14 @@ func (i *indices) Indices() http.Handler {
 
 			i.postSearchObjects().ServeHTTP(w, r)
 			return
+		case i.regexpObjectsBatchSearch.MatchString(path):
+			if r.Method != http.MethodPost {
+				http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			i.postBatchSearchObjects().ServeHTTP(w, r)
+			return
 		case i.regexpObjectsAggregations.MatchString(path):
 			if r.Method != http.MethodPost {
 				http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
@@ -134,6 +158,15 @@ func (i *indices) Indices() http.Handler {
 			i.postReferences().ServeHTTP(w, r)
 			return
 
+		case i.regexpStorage.MatchString(path):
+			if r.Method != http.MethodGet {
+				http.Error(w, "405 Method not Allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			i.getShardStorageStatistics().ServeHTTP(w, r)
+			return
+
 		default:
 			http.NotFound(w, r)
 			return
@@ -388,8 +421,8 @@ func (i *indices) postSearchObjects() http.Handler {
 			return
 		}
 
-		vector, limit, filters, additional, err := IndicesPayloads.SearchParams.
-			Unmarshal(reqPayload)
+		vector, targetVector, waitForIndexing, limit, filters, geoSort, additional,
+			err := IndicesPayloads.SearchParams.Unmarshal(reqPayload)
 		if err != nil {
 			http.Error(w, "unmarshal search params from json: "+err.Error(),
 				http.StatusBadRequest)
@@ -397,7 +430,7 @@ func (i *indices) postSearchObjects() http.Handler {
 		}
 
 		results, dists, err := i.shards.Search(r.Context(), index, shard,
-			vector, limit, filters, additional)
+			vector, targetVector, waitForIndexing, limit, filters, geoSort, additional)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -414,6 +447,56 @@ func (i *indices) postSearchObjects() http.Handler {
 	})
 }
 
+func (i *indices) postBatchSearchObjects() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		args := i.regexpObjectsBatchSearch.FindStringSubmatch(r.URL.Path)
+		if len(args) != 3 {
+			http.Error(w, "invalid URI", http.StatusBadRequest)
+			return
+		}
+
+		index, shard := args[1], args[2]
+
+		defer r.Body.Close()
+		reqPayload, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "read request body: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		ct, ok := IndicesPayloads.BatchSearchParams.CheckContentTypeHeaderReq(r)
+		if !ok {
+			http.Error(w, errors.Errorf("unexpected content type: %s", ct).Error(),
+				http.StatusUnsupportedMediaType)
+			return
+		}
+
+		vectors, targetVector, waitForIndexing, limit, filters, additional, err :=
+			IndicesPayloads.BatchSearchParams.Unmarshal(reqPayload)
+		if err != nil {
+			http.Error(w, "unmarshal batch search params from json: "+err.Error(),
+				http.StatusBadRequest)
+			return
+		}
+
+		results, dists, err := i.shards.BatchSearch(r.Context(), index, shard,
+			vectors, targetVector, waitForIndexing, limit, filters, additional)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		resBytes, err := IndicesPayloads.BatchSearchResults.Marshal(results, dists)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		IndicesPayloads.BatchSearchResults.SetContentTypeHeader(w)
+		w.Write(resBytes)
+	})
+}
+
 func (i *indices) postReferences() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		args := i.regexpReferences.FindStringSubmatch(r.URL.Path)
@@ -506,3 +589,30 @@ func (i *indices) postAggregateObjects() http.Handler {
 		w.Write(aggResBytes)
 	})
 }
+
+func (i *indices) getShardStorageStatistics() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		args := i.regexpStorage.FindStringSubmatch(r.URL.Path)
+		if len(args) != 3 {
+			http.Error(w, "invalid URI", http.StatusBadRequest)
+			return
+		}
+
+		index, shard := args[1], args[2]
+
+		stats, err := i.shards.ShardStorageStatistics(r.Context(), index, shard)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		statsBytes, err := IndicesPayloads.ShardStorageStatistics.Marshal(stats)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		IndicesPayloads.ShardStorageStatistics.SetContentTypeHeader(w)
+		w.Write(statsBytes)
+	})
+}
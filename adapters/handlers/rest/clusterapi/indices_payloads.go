@@ -23,6 +23,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/storobj"
 	"github.com/semi-technologies/weaviate/usecases/objects"
 )
@@ -30,14 +31,18 @@ import (
 var IndicesPayloads = indicesPayloads{}
 
 type indicesPayloads struct {
-	ErrorList         errorListPayload
-	SingleObject      singleObjectPayload
-	ObjectList        objectListPayload
-	SearchResults     searchResultsPayload
-	SearchParams      searchParamsPayload
-	ReferenceList     referenceListPayload
-	AggregationParams aggregationParamsPayload
-	AggregationResult aggregationResultPayload
+	ErrorList          errorListPayload
+	SingleObject       singleObjectPayload
+	ObjectList         objectListPayload
+	SearchResults      searchResultsPayload
+	SearchParams       searchParamsPayload
+	BatchSearchResults batchSearchResultsPayload
+	BatchSearchParams  batchSearchParamsPayload
+	ReferenceList      referenceListPayload
+	AggregationParams  aggregationParamsPayload
+	AggregationResult  aggregationResultPayload
+
+	ShardStorageStatistics shardStorageStatisticsPayload
 }
 
 type errorListPayload struct{}
@@ -187,30 +192,38 @@ func (p objectListPayload) Unmarshal(in []byte) ([]*storobj.Object, error) {
 
 type searchParamsPayload struct{}
 
-func (p searchParamsPayload) Marshal(vector []float32, limit int,
-	filter *filters.LocalFilter, addP additional.Properties) ([]byte, error) {
+func (p searchParamsPayload) Marshal(vector []float32, targetVector string,
+	waitForIndexing bool, limit int, filter *filters.LocalFilter,
+	geoSort *filters.GeoSort, addP additional.Properties) ([]byte, error) {
 	type params struct {
-		SearchVector []float32             `json:"searchVector"`
-		Limit        int                   `json:"limit"`
-		Filters      *filters.LocalFilter  `json:"filters"`
-		Additional   additional.Properties `json:"additional"`
+		SearchVector    []float32             `json:"searchVector"`
+		TargetVector    string                `json:"targetVector,omitempty"`
+		WaitForIndexing bool                  `json:"waitForIndexing,omitempty"`
+		Limit           int                   `json:"limit"`
+		Filters         *filters.LocalFilter  `json:"filters"`
+		GeoSort         *filters.GeoSort      `json:"geoSort,omitempty"`
+		Additional      additional.Properties `json:"additional"`
 	}
 
-	par := params{vector, limit, filter, addP}
+	par := params{vector, targetVector, waitForIndexing, limit, filter, geoSort, addP}
 	return json.Marshal(par)
 }
 
-func (p searchParamsPayload) Unmarshal(in []byte) ([]float32, int,
-	*filters.LocalFilter, additional.Properties, error) {
+func (p searchParamsPayload) Unmarshal(in []byte) ([]float32, string, bool,
+	int, *filters.LocalFilter, *filters.GeoSort, additional.Properties, error) {
 	type searchParametersPayload struct {
-		SearchVector []float32             `json:"searchVector"`
-		Limit        int                   `json:"limit"`
-		Filters      *filters.LocalFilter  `json:"filters"`
-		Additional   additional.Properties `json:"additional"`
+		SearchVector    []float32             `json:"searchVector"`
+		TargetVector    string                `json:"targetVector,omitempty"`
+		WaitForIndexing bool                  `json:"waitForIndexing,omitempty"`
+		Limit           int                   `json:"limit"`
+		Filters         *filters.LocalFilter  `json:"filters"`
+		GeoSort         *filters.GeoSort      `json:"geoSort,omitempty"`
+		Additional      additional.Properties `json:"additional"`
 	}
 	var par searchParametersPayload
 	err := json.Unmarshal(in, &par)
-	return par.SearchVector, par.Limit, par.Filters, par.Additional, err
+	return par.SearchVector, par.TargetVector, par.WaitForIndexing, par.Limit,
+		par.Filters, par.GeoSort, par.Additional, err
 }
 
 func (p searchParamsPayload) MIME() string {
@@ -298,6 +311,132 @@ func (p searchResultsPayload) CheckContentTypeHeader(r *http.Response) (string,
 	return ct, ct == p.MIME()
 }
 
+type batchSearchParamsPayload struct{}
+
+func (p batchSearchParamsPayload) Marshal(vectors [][]float32,
+	targetVector string, waitForIndexing bool, limit int,
+	filter *filters.LocalFilter, addP additional.Properties) ([]byte, error) {
+	type params struct {
+		SearchVectors   [][]float32           `json:"searchVectors"`
+		TargetVector    string                `json:"targetVector,omitempty"`
+		WaitForIndexing bool                  `json:"waitForIndexing,omitempty"`
+		Limit           int                   `json:"limit"`
+		Filters         *filters.LocalFilter  `json:"filters"`
+		Additional      additional.Properties `json:"additional"`
+	}
+
+	par := params{vectors, targetVector, waitForIndexing, limit, filter, addP}
+	return json.Marshal(par)
+}
+
+func (p batchSearchParamsPayload) Unmarshal(in []byte) ([][]float32, string,
+	bool, int, *filters.LocalFilter, additional.Properties, error) {
+	type batchSearchParametersPayload struct {
+		SearchVectors   [][]float32           `json:"searchVectors"`
+		TargetVector    string                `json:"targetVector,omitempty"`
+		WaitForIndexing bool                  `json:"waitForIndexing,omitempty"`
+		Limit           int                   `json:"limit"`
+		Filters         *filters.LocalFilter  `json:"filters"`
+		Additional      additional.Properties `json:"additional"`
+	}
+	var par batchSearchParametersPayload
+	err := json.Unmarshal(in, &par)
+	return par.SearchVectors, par.TargetVector, par.WaitForIndexing, par.Limit,
+		par.Filters, par.Additional, err
+}
+
+func (p batchSearchParamsPayload) MIME() string {
+	return "vnd.weaviate.batchsearchparams+json"
+}
+
+func (p batchSearchParamsPayload) CheckContentTypeHeaderReq(r *http.Request) (string, bool) {
+	ct := r.Header.Get("content-type")
+	return ct, ct == p.MIME()
+}
+
+func (p batchSearchParamsPayload) SetContentTypeHeaderReq(r *http.Request) {
+	r.Header.Set("content-type", p.MIME())
+}
+
+// batchSearchResultsPayload contains one search results payload per query
+// vector, each prefixed with its length
+type batchSearchResultsPayload struct{}
+
+func (p batchSearchResultsPayload) Unmarshal(in []byte) ([][]*storobj.Object,
+	[][]float32, error) {
+	if len(in) < 8 {
+		return nil, nil, errors.Errorf("corrupt read: payload too short")
+	}
+
+	read := uint64(0)
+	count := binary.LittleEndian.Uint64(in[read : read+8])
+	read += 8
+
+	objs := make([][]*storobj.Object, count)
+	dists := make([][]float32, count)
+	for i := range objs {
+		if read+8 > uint64(len(in)) {
+			return nil, nil, errors.Errorf("corrupt read: missing results %d", i)
+		}
+
+		length := binary.LittleEndian.Uint64(in[read : read+8])
+		read += 8
+
+		if read+length > uint64(len(in)) {
+			return nil, nil, errors.Errorf("corrupt read: results %d exceed payload", i)
+		}
+
+		var err error
+		objs[i], dists[i], err = IndicesPayloads.SearchResults.
+			Unmarshal(in[read : read+length])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "results %d", i)
+		}
+		read += length
+	}
+
+	if read != uint64(len(in)) {
+		return nil, nil, errors.Errorf("corrupt read: %d != %d", read, len(in))
+	}
+
+	return objs, dists, nil
+}
+
+func (p batchSearchResultsPayload) Marshal(objs [][]*storobj.Object,
+	dists [][]float32) ([]byte, error) {
+	reusableLengthBuf := make([]byte, 8)
+	var out []byte
+
+	binary.LittleEndian.PutUint64(reusableLengthBuf, uint64(len(objs)))
+	out = append(out, reusableLengthBuf...)
+
+	for i := range objs {
+		resBytes, err := IndicesPayloads.SearchResults.Marshal(objs[i], dists[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "results %d", i)
+		}
+
+		binary.LittleEndian.PutUint64(reusableLengthBuf, uint64(len(resBytes)))
+		out = append(out, reusableLengthBuf...)
+		out = append(out, resBytes...)
+	}
+
+	return out, nil
+}
+
+func (p batchSearchResultsPayload) MIME() string {
+	return "application/vnd.weaviate.shardbatchsearchresults+octet-stream"
+}
+
+func (p batchSearchResultsPayload) SetContentTypeHeader(w http.ResponseWriter) {
+	w.Header().Set("content-type", p.MIME())
+}
+
+func (p batchSearchResultsPayload) CheckContentTypeHeader(r *http.Response) (string, bool) {
+	ct := r.Header.Get("content-type")
+	return ct, ct == p.MIME()
+}
+
 type referenceListPayload struct{}
 
 func (p referenceListPayload) MIME() string {
@@ -381,3 +520,28 @@ func (p aggregationResultPayload) Unmarshal(in []byte) (*aggregation.Result, err
 	err := json.Unmarshal(in, &out)
 	return &out, err
 }
+
+type shardStorageStatisticsPayload struct{}
+
+func (p shardStorageStatisticsPayload) MIME() string {
+	return "application/vnd.weaviate.shard.storage+json"
+}
+
+func (p shardStorageStatisticsPayload) CheckContentTypeHeader(res *http.Response) (string, bool) {
+	ct := res.Header.Get("content-type")
+	return ct, ct == p.MIME()
+}
+
+func (p shardStorageStatisticsPayload) SetContentTypeHeader(w http.ResponseWriter) {
+	w.Header().Set("content-type", p.MIME())
+}
+
+func (p shardStorageStatisticsPayload) Marshal(in *models.ShardStorageStatistics) ([]byte, error) {
+	return json.Marshal(in)
+}
+
+func (p shardStorageStatisticsPayload) Unmarshal(in []byte) (*models.ShardStorageStatistics, error) {
+	var out models.ShardStorageStatistics
+	err := json.Unmarshal(in, &out)
+	return &out, err
+}
@@ -160,7 +160,7 @@ func newSchemaManagerWithClusterStateAndClient(clusterState *fakeClusterState,
 	}
 	sm, err := schemauc.NewManager(&NilMigrator{}, newFakeRepo(), logger, &fakeAuthorizer{},
 		config.Config{DefaultVectorizerModule: config.VectorizerModuleNone},
-		dummyParseVectorConfig, // only option for now
+		map[string]schemauc.VectorConfigParser{"hnsw": dummyParseVectorConfig},
 		vectorizerValidator, &fakeModuleConfig{}, clusterState, client,
 	)
 	if err != nil {
@@ -31,6 +31,8 @@ import (
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/state"
 	"github.com/semi-technologies/weaviate/adapters/repos/classifications"
 	"github.com/semi-technologies/weaviate/adapters/repos/db"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	modulestorage "github.com/semi-technologies/weaviate/adapters/repos/modules"
 	schemarepo "github.com/semi-technologies/weaviate/adapters/repos/schema"
@@ -79,6 +81,7 @@ type vectorRepo interface {
 type explorer interface {
 	GetClass(ctx context.Context, params traverser.GetParams) ([]interface{}, error)
 	Concepts(ctx context.Context, params traverser.ExploreParams) ([]search.Result, error)
+	BatchSearch(ctx context.Context, params traverser.BatchSearchParams) ([][]search.Result, error)
 	SetSchemaGetter(schemaUC.SchemaGetter)
 }
 
@@ -130,10 +133,25 @@ func configureAPI(api *operations.WeaviateAPI) http.Handler {
 
 	// TODO: configure http transport for efficient intra-cluster comm
 	remoteIndexClient := clients.NewRemoteIndex(clusterHttpClient)
+	walSyncPolicy, err := durability.NewPolicy(
+		appState.ServerConfig.Config.Persistence.WALSyncMode,
+		time.Duration(appState.ServerConfig.Config.Persistence.WALSyncIntervalMilliseconds)*time.Millisecond)
+	if err != nil {
+		appState.Logger.
+			WithField("action", "startup").WithError(err).
+			Fatal("invalid wal sync config")
+	}
+
 	repo := db.New(appState.Logger, db.Config{
-		RootPath:            appState.ServerConfig.Config.Persistence.DataPath,
-		QueryLimit:          appState.ServerConfig.Config.QueryDefaults.Limit,
-		QueryMaximumResults: appState.ServerConfig.Config.QueryMaximumResults,
+		RootPath:                    appState.ServerConfig.Config.Persistence.DataPath,
+		QueryLimit:                  appState.ServerConfig.Config.QueryDefaults.Limit,
+		QueryMaximumResults:         appState.ServerConfig.Config.QueryMaximumResults,
+		VectorCacheMaxBytes:         appState.ServerConfig.Config.Persistence.VectorCacheMaxBytes,
+		AsyncVectorIndexing:         appState.ServerConfig.Config.Persistence.AsyncVectorIndexing,
+		CompressObjectSegments:      appState.ServerConfig.Config.Persistence.CompressObjectSegments,
+		CompactionPolicy:            appState.ServerConfig.Config.Persistence.LSMCompactionPolicy,
+		CompactionMaxBytesPerSecond: appState.ServerConfig.Config.Persistence.LSMCompactionMaxBytesPerSecond,
+		WALSyncPolicy:               walSyncPolicy,
 	}, remoteIndexClient, appState.Cluster) // TODO client
 	vectorMigrator = db.NewMigrator(repo, appState.Logger)
 	vectorRepo = repo
@@ -168,8 +186,10 @@ func configureAPI(api *operations.WeaviateAPI) http.Handler {
 	schemaTxClient := clients.NewClusterSchema(clusterHttpClient)
 	schemaManager, err := schemaUC.NewManager(migrator, schemaRepo,
 		appState.Logger, appState.Authorizer, appState.ServerConfig.Config,
-		hnsw.ParseUserConfig, appState.Modules, appState.Modules, appState.Cluster,
-		schemaTxClient)
+		map[string]schemaUC.VectorConfigParser{
+			"hnsw": hnsw.ParseUserConfig,
+			"flat": flat.ParseUserConfig,
+		}, appState.Modules, appState.Modules, appState.Cluster, schemaTxClient)
 	if err != nil {
 		appState.Logger.
 			WithField("action", "startup").WithError(err).
@@ -213,7 +233,7 @@ func configureAPI(api *operations.WeaviateAPI) http.Handler {
 
 	setupSchemaHandlers(api, schemaManager)
 	setupKindHandlers(api, kindsManager, appState.ServerConfig.Config, appState.Logger, appState.Modules)
-	setupKindBatchHandlers(api, batchKindsManager)
+	setupKindBatchHandlers(api, batchKindsManager, kindsTraverser)
 	setupGraphQLHandlers(api, appState)
 	setupMiscHandlers(api, appState.ServerConfig, schemaManager, appState.Modules)
 	setupClassificationHandlers(api, classifier)
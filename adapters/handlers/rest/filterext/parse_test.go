@@ -175,6 +175,60 @@ func Test_ExtractFlatFilters(t *testing.T) {
 					},
 				}},
 			},
+			test{
+				name: "valid geo bounding box filter",
+				input: &models.WhereFilter{
+					Operator: "WithinGeoBoundingBox",
+					ValueGeoBoundingBox: &models.WhereFilterGeoBoundingBox{
+						TopLeft:     inputGeoCoordinates(50, 5),
+						BottomRight: inputGeoCoordinates(45, 15),
+					},
+					Path: []string{"geoField"},
+				},
+				expectedFilter: &filters.LocalFilter{Root: &filters.Clause{
+					Operator: filters.OperatorWithinGeoBoundingBox,
+					On: &filters.Path{
+						Class:    schema.AssertValidClassName("Todo"),
+						Property: schema.AssertValidPropertyName("geoField"),
+					},
+					Value: &filters.Value{
+						Value: filters.GeoBoundingBox{
+							TopLeft:     inputGeoCoordinates(50, 5),
+							BottomRight: inputGeoCoordinates(45, 15),
+						},
+						Type: schema.DataTypeGeoCoordinates,
+					},
+				}},
+			},
+			test{
+				name: "valid geo polygon filter",
+				input: &models.WhereFilter{
+					Operator: "WithinGeoPolygon",
+					ValueGeoPolygon: &models.WhereFilterGeoPolygon{
+						Vertices: []*models.GeoCoordinates{
+							inputGeoCoordinates(0, 0), inputGeoCoordinates(0, 1),
+							inputGeoCoordinates(1, 0.5),
+						},
+					},
+					Path: []string{"geoField"},
+				},
+				expectedFilter: &filters.LocalFilter{Root: &filters.Clause{
+					Operator: filters.OperatorWithinGeoPolygon,
+					On: &filters.Path{
+						Class:    schema.AssertValidClassName("Todo"),
+						Property: schema.AssertValidPropertyName("geoField"),
+					},
+					Value: &filters.Value{
+						Value: filters.GeoPolygon{
+							Vertices: []*models.GeoCoordinates{
+								inputGeoCoordinates(0, 0), inputGeoCoordinates(0, 1),
+								inputGeoCoordinates(1, 0.5),
+							},
+						},
+						Type: schema.DataTypeGeoCoordinates,
+					},
+				}},
+			},
 		}
 
 		for _, test := range tests {
@@ -235,6 +289,32 @@ func Test_ExtractFlatFilters(t *testing.T) {
 				expectedErr: fmt.Errorf("invalid where filter: valueGeoRange: " +
 					"field 'distance.max' must be a positive number"),
 			},
+			test{
+				name: "geo bounding box missing a corner",
+				input: &models.WhereFilter{
+					Operator: "WithinGeoBoundingBox",
+					ValueGeoBoundingBox: &models.WhereFilterGeoBoundingBox{
+						TopLeft: inputGeoCoordinates(50, 5),
+					},
+					Path: []string{"geoField"},
+				},
+				expectedErr: fmt.Errorf("invalid where filter: valueGeoBoundingBox: " +
+					"field 'bottomRight' must be set"),
+			},
+			test{
+				name: "geo polygon with too few vertices",
+				input: &models.WhereFilter{
+					Operator: "WithinGeoPolygon",
+					ValueGeoPolygon: &models.WhereFilterGeoPolygon{
+						Vertices: []*models.GeoCoordinates{
+							inputGeoCoordinates(0, 0), inputGeoCoordinates(0, 1),
+						},
+					},
+					Path: []string{"geoField"},
+				},
+				expectedErr: fmt.Errorf("invalid where filter: valueGeoPolygon: " +
+					"field 'vertices' must contain at least 3 elements"),
+			},
 			test{
 				name: "and operator and path set",
 				input: &models.WhereFilter{
@@ -514,6 +594,13 @@ func inputGeoRangeFilter(lat, lon, max float64) *models.WhereFilterGeoRange {
 	}
 }
 
+func inputGeoCoordinates(lat, lon float32) *models.GeoCoordinates {
+	return &models.GeoCoordinates{
+		Latitude:  ptFloat32(lat),
+		Longitude: ptFloat32(lon),
+	}
+}
+
 func ptFloat32(in float32) *float32 {
 	return &in
 }
@@ -124,6 +124,39 @@ var valueExtractors = []valueExtractorFunc{
 			},
 		}, schema.DataTypeGeoCoordinates), nil
 	},
+	// geo bounding box
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueGeoBoundingBox == nil {
+			return nil, nil
+		}
+
+		if in.ValueGeoBoundingBox.TopLeft == nil {
+			return nil, fmt.Errorf("valueGeoBoundingBox: field 'topLeft' must be set")
+		}
+
+		if in.ValueGeoBoundingBox.BottomRight == nil {
+			return nil, fmt.Errorf("valueGeoBoundingBox: field 'bottomRight' must be set")
+		}
+
+		return valueFilter(filters.GeoBoundingBox{
+			TopLeft:     in.ValueGeoBoundingBox.TopLeft,
+			BottomRight: in.ValueGeoBoundingBox.BottomRight,
+		}, schema.DataTypeGeoCoordinates), nil
+	},
+	// geo polygon
+	func(in *models.WhereFilter) (*filters.Value, error) {
+		if in.ValueGeoPolygon == nil {
+			return nil, nil
+		}
+
+		if len(in.ValueGeoPolygon.Vertices) < 3 {
+			return nil, fmt.Errorf("valueGeoPolygon: field 'vertices' must contain at least 3 elements")
+		}
+
+		return valueFilter(filters.GeoPolygon{
+			Vertices: in.ValueGeoPolygon.Vertices,
+		}, schema.DataTypeGeoCoordinates), nil
+	},
 }
 
 func valueFilter(value interface{}, dt schema.DataType) *filters.Value {
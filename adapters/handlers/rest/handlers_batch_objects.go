@@ -12,17 +12,23 @@
 package rest
 
 import (
+	"fmt"
+
 	middleware "github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/handlers/rest/filterext"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/batch"
 	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/usecases/auth/authorization/errors"
 	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
 )
 
 type batchObjectHandlers struct {
-	manager *objects.BatchManager
+	manager   *objects.BatchManager
+	traverser *traverser.Traverser
 }
 
 func (h *batchObjectHandlers) addObjects(params batch.BatchObjectsCreateParams,
@@ -115,11 +121,88 @@ func (h *batchObjectHandlers) referencesResponse(input objects.BatchReferences)
 	return response
 }
 
-func setupKindBatchHandlers(api *operations.WeaviateAPI, manager *objects.BatchManager) {
-	h := &batchObjectHandlers{manager}
+func (h *batchObjectHandlers) search(params batch.BatchSearchParams,
+	principal *models.Principal) middleware.Responder {
+	if params.Body.Class == "" {
+		return batch.NewBatchSearchUnprocessableEntity().
+			WithPayload(errPayloadFromSingleErr(fmt.Errorf("class is required")))
+	}
+
+	if len(params.Body.Vectors) == 0 {
+		return batch.NewBatchSearchUnprocessableEntity().
+			WithPayload(errPayloadFromSingleErr(fmt.Errorf("at least one vector is required")))
+	}
+
+	where, err := filterext.Parse(params.Body.Where)
+	if err != nil {
+		return batch.NewBatchSearchUnprocessableEntity().
+			WithPayload(errPayloadFromSingleErr(err))
+	}
+
+	limit := -1
+	if params.Body.Limit > 0 {
+		limit = int(params.Body.Limit)
+	}
+
+	vectors := make([][]float32, len(params.Body.Vectors))
+	for i, vector := range params.Body.Vectors {
+		vectors[i] = vector
+	}
+
+	res, err := h.traverser.BatchSearch(params.HTTPRequest.Context(), principal,
+		traverser.BatchSearchParams{
+			ClassName:       params.Body.Class,
+			SearchVectors:   vectors,
+			TargetVector:    params.Body.TargetVector,
+			Filters:         where,
+			Limit:           limit,
+			WaitForIndexing: params.Body.WaitForIndexing,
+		})
+	if err != nil {
+		switch err.(type) {
+		case errors.Forbidden:
+			return batch.NewBatchSearchForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return batch.NewBatchSearchInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return batch.NewBatchSearchOK().
+		WithPayload(h.searchResponse(res))
+}
+
+// searchResponse contains the distance of every result as an additional
+// property, as the objects are ordered by it
+func (h *batchObjectHandlers) searchResponse(input [][]search.Result) *models.BatchSearchResponse {
+	response := &models.BatchSearchResponse{
+		Results: make([]*models.BatchSearchResult, len(input)),
+	}
+
+	for i, results := range input {
+		objects := search.Results(results).ObjectsWithVector(false)
+		for j, object := range objects {
+			if object.Additional == nil {
+				object.Additional = models.AdditionalProperties{}
+			}
+			object.Additional["distance"] = results[j].Dist
+		}
+
+		response.Results[i] = &models.BatchSearchResult{Objects: objects}
+	}
+
+	return response
+}
+
+func setupKindBatchHandlers(api *operations.WeaviateAPI, manager *objects.BatchManager,
+	traverser *traverser.Traverser) {
+	h := &batchObjectHandlers{manager, traverser}
 
 	api.BatchBatchObjectsCreateHandler = batch.
 		BatchObjectsCreateHandlerFunc(h.addObjects)
 	api.BatchBatchReferencesCreateHandler = batch.
 		BatchReferencesCreateHandlerFunc(h.addReferences)
+	api.BatchBatchSearchHandler = batch.
+		BatchSearchHandlerFunc(h.search)
 }
@@ -12,6 +12,8 @@
 package rest
 
 import (
+	"fmt"
+
 	middleware "github.com/go-openapi/runtime/middleware"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations"
 	"github.com/semi-technologies/weaviate/adapters/handlers/rest/operations/schema"
@@ -85,6 +87,159 @@ func (s *schemaHandlers) getClass(params schema.SchemaObjectsGetParams,
 	return schema.NewSchemaObjectsGetOK().WithPayload(class)
 }
 
+func (s *schemaHandlers) getShardsStatus(params schema.SchemaObjectsShardsGetParams,
+	principal *models.Principal) middleware.Responder {
+	status, err := s.manager.GetShardsStatus(params.HTTPRequest.Context(), principal,
+		params.ClassName)
+	if err != nil {
+		if err == schemaUC.ErrNotFound {
+			return schema.NewSchemaObjectsShardsGetNotFound()
+		}
+
+		switch err.(type) {
+		case errors.Forbidden:
+			return schema.NewSchemaObjectsShardsGetForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return schema.NewSchemaObjectsShardsGetInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return schema.NewSchemaObjectsShardsGetOK().WithPayload(status)
+}
+
+func (s *schemaHandlers) inspectVectorIndex(params schema.SchemaObjectsShardsVectorIndexGetParams,
+	principal *models.Principal) middleware.Responder {
+	report, err := s.manager.InspectVectorIndex(params.HTTPRequest.Context(),
+		principal, params.ClassName, params.ShardName, targetVectorParam(params.TargetVector))
+	if err != nil {
+		if err == schemaUC.ErrNotFound {
+			return schema.NewSchemaObjectsShardsVectorIndexGetNotFound()
+		}
+
+		switch err.(type) {
+		case errors.Forbidden:
+			return schema.NewSchemaObjectsShardsVectorIndexGetForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return schema.NewSchemaObjectsShardsVectorIndexGetInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return schema.NewSchemaObjectsShardsVectorIndexGetOK().WithPayload(report)
+}
+
+func (s *schemaHandlers) repairVectorIndex(params schema.SchemaObjectsShardsVectorIndexRepairParams,
+	principal *models.Principal) middleware.Responder {
+	res, err := s.manager.RepairVectorIndex(params.HTTPRequest.Context(),
+		principal, params.ClassName, params.ShardName, targetVectorParam(params.TargetVector))
+	if err != nil {
+		if err == schemaUC.ErrNotFound {
+			return schema.NewSchemaObjectsShardsVectorIndexRepairNotFound()
+		}
+
+		switch err.(type) {
+		case errors.Forbidden:
+			return schema.NewSchemaObjectsShardsVectorIndexRepairForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return schema.NewSchemaObjectsShardsVectorIndexRepairInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return schema.NewSchemaObjectsShardsVectorIndexRepairOK().WithPayload(res)
+}
+
+func (s *schemaHandlers) evaluateVectorIndexRecall(params schema.SchemaObjectsShardsVectorIndexRecallParams,
+	principal *models.Principal) middleware.Responder {
+	if params.Body.K < 0 || params.Body.Ef < 0 || params.Body.SampleSize < 0 {
+		return schema.NewSchemaObjectsShardsVectorIndexRecallUnprocessableEntity().
+			WithPayload(errPayloadFromSingleErr(fmt.Errorf(
+				"k, ef and sampleSize must not be negative")))
+	}
+
+	res, err := s.manager.EvaluateVectorIndexRecall(params.HTTPRequest.Context(),
+		principal, params.ClassName, params.ShardName,
+		targetVectorParam(params.TargetVector), *params.Body)
+	if err != nil {
+		if err == schemaUC.ErrNotFound {
+			return schema.NewSchemaObjectsShardsVectorIndexRecallNotFound()
+		}
+
+		switch err.(type) {
+		case errors.Forbidden:
+			return schema.NewSchemaObjectsShardsVectorIndexRecallForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return schema.NewSchemaObjectsShardsVectorIndexRecallInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return schema.NewSchemaObjectsShardsVectorIndexRecallOK().WithPayload(res)
+}
+
+func (s *schemaHandlers) scrubShard(params schema.SchemaObjectsShardsScrubParams,
+	principal *models.Principal) middleware.Responder {
+	quarantine := params.Quarantine != nil && *params.Quarantine
+	res, err := s.manager.ScrubShard(params.HTTPRequest.Context(),
+		principal, params.ClassName, params.ShardName, quarantine)
+	if err != nil {
+		if err == schemaUC.ErrNotFound {
+			return schema.NewSchemaObjectsShardsScrubNotFound()
+		}
+
+		switch err.(type) {
+		case errors.Forbidden:
+			return schema.NewSchemaObjectsShardsScrubForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return schema.NewSchemaObjectsShardsScrubInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return schema.NewSchemaObjectsShardsScrubOK().WithPayload(res)
+}
+
+func (s *schemaHandlers) getStorageStatistics(params schema.SchemaStorageParams,
+	principal *models.Principal) middleware.Responder {
+	className := ""
+	if params.ClassName != nil {
+		className = *params.ClassName
+	}
+
+	stats, err := s.manager.GetStorageStatistics(params.HTTPRequest.Context(),
+		principal, className)
+	if err != nil {
+		if err == schemaUC.ErrNotFound {
+			return schema.NewSchemaStorageNotFound()
+		}
+
+		switch err.(type) {
+		case errors.Forbidden:
+			return schema.NewSchemaStorageForbidden().
+				WithPayload(errPayloadFromSingleErr(err))
+		default:
+			return schema.NewSchemaStorageInternalServerError().
+				WithPayload(errPayloadFromSingleErr(err))
+		}
+	}
+
+	return schema.NewSchemaStorageOK().WithPayload(stats)
+}
+
+func targetVectorParam(param *string) string {
+	if param == nil {
+		return ""
+	}
+
+	return *param
+}
+
 func (s *schemaHandlers) deleteClass(params schema.SchemaObjectsDeleteParams, principal *models.Principal) middleware.Responder {
 	err := s.manager.DeleteClass(params.HTTPRequest.Context(), principal, params.ClassName)
 	if err != nil {
@@ -151,4 +306,16 @@ func setupSchemaHandlers(api *operations.WeaviateAPI, manager *schemaUC.Manager)
 		SchemaObjectsGetHandlerFunc(h.getClass)
 	api.SchemaSchemaDumpHandler = schema.
 		SchemaDumpHandlerFunc(h.getSchema)
+	api.SchemaSchemaObjectsShardsGetHandler = schema.
+		SchemaObjectsShardsGetHandlerFunc(h.getShardsStatus)
+	api.SchemaSchemaObjectsShardsVectorIndexGetHandler = schema.
+		SchemaObjectsShardsVectorIndexGetHandlerFunc(h.inspectVectorIndex)
+	api.SchemaSchemaObjectsShardsVectorIndexRepairHandler = schema.
+		SchemaObjectsShardsVectorIndexRepairHandlerFunc(h.repairVectorIndex)
+	api.SchemaSchemaObjectsShardsScrubHandler = schema.
+		SchemaObjectsShardsScrubHandlerFunc(h.scrubShard)
+	api.SchemaSchemaObjectsShardsVectorIndexRecallHandler = schema.
+		SchemaObjectsShardsVectorIndexRecallHandlerFunc(h.evaluateVectorIndexRecall)
+	api.SchemaSchemaStorageHandler = schema.
+		SchemaStorageHandlerFunc(h.getStorageStatistics)
 }
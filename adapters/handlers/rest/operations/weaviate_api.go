@@ -72,6 +72,9 @@ func NewWeaviateAPI(spec *loads.Document) *WeaviateAPI {
 		BatchBatchReferencesCreateHandler: batch.BatchReferencesCreateHandlerFunc(func(params batch.BatchReferencesCreateParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation batch.BatchReferencesCreate has not yet been implemented")
 		}),
+		BatchBatchSearchHandler: batch.BatchSearchHandlerFunc(func(params batch.BatchSearchParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation batch.BatchSearch has not yet been implemented")
+		}),
 		ClassificationsClassificationsGetHandler: classifications.ClassificationsGetHandlerFunc(func(params classifications.ClassificationsGetParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation classifications.ClassificationsGet has not yet been implemented")
 		}),
@@ -132,9 +135,27 @@ func NewWeaviateAPI(spec *loads.Document) *WeaviateAPI {
 		SchemaSchemaObjectsPropertiesAddHandler: schema.SchemaObjectsPropertiesAddHandlerFunc(func(params schema.SchemaObjectsPropertiesAddParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation schema.SchemaObjectsPropertiesAdd has not yet been implemented")
 		}),
+		SchemaSchemaObjectsShardsGetHandler: schema.SchemaObjectsShardsGetHandlerFunc(func(params schema.SchemaObjectsShardsGetParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation schema.SchemaObjectsShardsGet has not yet been implemented")
+		}),
+		SchemaSchemaObjectsShardsScrubHandler: schema.SchemaObjectsShardsScrubHandlerFunc(func(params schema.SchemaObjectsShardsScrubParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation schema.SchemaObjectsShardsScrub has not yet been implemented")
+		}),
+		SchemaSchemaObjectsShardsVectorIndexGetHandler: schema.SchemaObjectsShardsVectorIndexGetHandlerFunc(func(params schema.SchemaObjectsShardsVectorIndexGetParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation schema.SchemaObjectsShardsVectorIndexGet has not yet been implemented")
+		}),
+		SchemaSchemaObjectsShardsVectorIndexRecallHandler: schema.SchemaObjectsShardsVectorIndexRecallHandlerFunc(func(params schema.SchemaObjectsShardsVectorIndexRecallParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation schema.SchemaObjectsShardsVectorIndexRecall has not yet been implemented")
+		}),
+		SchemaSchemaObjectsShardsVectorIndexRepairHandler: schema.SchemaObjectsShardsVectorIndexRepairHandlerFunc(func(params schema.SchemaObjectsShardsVectorIndexRepairParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation schema.SchemaObjectsShardsVectorIndexRepair has not yet been implemented")
+		}),
 		SchemaSchemaObjectsUpdateHandler: schema.SchemaObjectsUpdateHandlerFunc(func(params schema.SchemaObjectsUpdateParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation schema.SchemaObjectsUpdate has not yet been implemented")
 		}),
+		SchemaSchemaStorageHandler: schema.SchemaStorageHandlerFunc(func(params schema.SchemaStorageParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation schema.SchemaStorage has not yet been implemented")
+		}),
 		WeaviateRootHandler: WeaviateRootHandlerFunc(func(params WeaviateRootParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation WeaviateRoot has not yet been implemented")
 		}),
@@ -199,6 +220,8 @@ type WeaviateAPI struct {
 	BatchBatchObjectsCreateHandler batch.BatchObjectsCreateHandler
 	// BatchBatchReferencesCreateHandler sets the operation handler for the batch references create operation
 	BatchBatchReferencesCreateHandler batch.BatchReferencesCreateHandler
+	// BatchBatchSearchHandler sets the operation handler for the batch search operation
+	BatchBatchSearchHandler batch.BatchSearchHandler
 	// ClassificationsClassificationsGetHandler sets the operation handler for the classifications get operation
 	ClassificationsClassificationsGetHandler classifications.ClassificationsGetHandler
 	// ClassificationsClassificationsPostHandler sets the operation handler for the classifications post operation
@@ -239,8 +262,20 @@ type WeaviateAPI struct {
 	SchemaSchemaObjectsGetHandler schema.SchemaObjectsGetHandler
 	// SchemaSchemaObjectsPropertiesAddHandler sets the operation handler for the schema objects properties add operation
 	SchemaSchemaObjectsPropertiesAddHandler schema.SchemaObjectsPropertiesAddHandler
+	// SchemaSchemaObjectsShardsGetHandler sets the operation handler for the schema objects shards get operation
+	SchemaSchemaObjectsShardsGetHandler schema.SchemaObjectsShardsGetHandler
+	// SchemaSchemaObjectsShardsScrubHandler sets the operation handler for the schema objects shards scrub operation
+	SchemaSchemaObjectsShardsScrubHandler schema.SchemaObjectsShardsScrubHandler
+	// SchemaSchemaObjectsShardsVectorIndexGetHandler sets the operation handler for the schema objects shards vector index get operation
+	SchemaSchemaObjectsShardsVectorIndexGetHandler schema.SchemaObjectsShardsVectorIndexGetHandler
+	// SchemaSchemaObjectsShardsVectorIndexRecallHandler sets the operation handler for the schema objects shards vector index recall operation
+	SchemaSchemaObjectsShardsVectorIndexRecallHandler schema.SchemaObjectsShardsVectorIndexRecallHandler
+	// SchemaSchemaObjectsShardsVectorIndexRepairHandler sets the operation handler for the schema objects shards vector index repair operation
+	SchemaSchemaObjectsShardsVectorIndexRepairHandler schema.SchemaObjectsShardsVectorIndexRepairHandler
 	// SchemaSchemaObjectsUpdateHandler sets the operation handler for the schema objects update operation
 	SchemaSchemaObjectsUpdateHandler schema.SchemaObjectsUpdateHandler
+	// SchemaSchemaStorageHandler sets the operation handler for the schema storage operation
+	SchemaSchemaStorageHandler schema.SchemaStorageHandler
 	// WeaviateRootHandler sets the operation handler for the weaviate root operation
 	WeaviateRootHandler WeaviateRootHandler
 	// WeaviateWellknownLivenessHandler sets the operation handler for the weaviate wellknown liveness operation
@@ -329,6 +364,9 @@ func (o *WeaviateAPI) Validate() error {
 	if o.BatchBatchReferencesCreateHandler == nil {
 		unregistered = append(unregistered, "batch.BatchReferencesCreateHandler")
 	}
+	if o.BatchBatchSearchHandler == nil {
+		unregistered = append(unregistered, "batch.BatchSearchHandler")
+	}
 	if o.ClassificationsClassificationsGetHandler == nil {
 		unregistered = append(unregistered, "classifications.ClassificationsGetHandler")
 	}
@@ -389,9 +427,27 @@ func (o *WeaviateAPI) Validate() error {
 	if o.SchemaSchemaObjectsPropertiesAddHandler == nil {
 		unregistered = append(unregistered, "schema.SchemaObjectsPropertiesAddHandler")
 	}
+	if o.SchemaSchemaObjectsShardsGetHandler == nil {
+		unregistered = append(unregistered, "schema.SchemaObjectsShardsGetHandler")
+	}
+	if o.SchemaSchemaObjectsShardsScrubHandler == nil {
+		unregistered = append(unregistered, "schema.SchemaObjectsShardsScrubHandler")
+	}
+	if o.SchemaSchemaObjectsShardsVectorIndexGetHandler == nil {
+		unregistered = append(unregistered, "schema.SchemaObjectsShardsVectorIndexGetHandler")
+	}
+	if o.SchemaSchemaObjectsShardsVectorIndexRecallHandler == nil {
+		unregistered = append(unregistered, "schema.SchemaObjectsShardsVectorIndexRecallHandler")
+	}
+	if o.SchemaSchemaObjectsShardsVectorIndexRepairHandler == nil {
+		unregistered = append(unregistered, "schema.SchemaObjectsShardsVectorIndexRepairHandler")
+	}
 	if o.SchemaSchemaObjectsUpdateHandler == nil {
 		unregistered = append(unregistered, "schema.SchemaObjectsUpdateHandler")
 	}
+	if o.SchemaSchemaStorageHandler == nil {
+		unregistered = append(unregistered, "schema.SchemaStorageHandler")
+	}
 	if o.WeaviateRootHandler == nil {
 		unregistered = append(unregistered, "WeaviateRootHandler")
 	}
@@ -513,6 +569,10 @@ func (o *WeaviateAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/batch/references"] = batch.NewBatchReferencesCreate(o.context, o.BatchBatchReferencesCreateHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/batch/search"] = batch.NewBatchSearch(o.context, o.BatchBatchSearchHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -593,6 +653,26 @@ func (o *WeaviateAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/schema/{className}/properties"] = schema.NewSchemaObjectsPropertiesAdd(o.context, o.SchemaSchemaObjectsPropertiesAddHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/schema/{className}/shards"] = schema.NewSchemaObjectsShardsGet(o.context, o.SchemaSchemaObjectsShardsGetHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/schema/{className}/shards/{shardName}/scrub"] = schema.NewSchemaObjectsShardsScrub(o.context, o.SchemaSchemaObjectsShardsScrubHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/schema/{className}/shards/{shardName}/vector-index"] = schema.NewSchemaObjectsShardsVectorIndexGet(o.context, o.SchemaSchemaObjectsShardsVectorIndexGetHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/schema/{className}/shards/{shardName}/vector-index/recall"] = schema.NewSchemaObjectsShardsVectorIndexRecall(o.context, o.SchemaSchemaObjectsShardsVectorIndexRecallHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/schema/{className}/shards/{shardName}/vector-index/repair"] = schema.NewSchemaObjectsShardsVectorIndexRepair(o.context, o.SchemaSchemaObjectsShardsVectorIndexRepairHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -600,6 +680,10 @@ func (o *WeaviateAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/schema/storage"] = schema.NewSchemaStorage(o.context, o.SchemaSchemaStorageHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"][""] = NewWeaviateRoot(o.context, o.WeaviateRootHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -17,6 +17,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/storobj"
@@ -34,7 +35,7 @@ func (ua unfilteredAggregator) boolArrayProperty(ctx context.Context,
 
 func (ua unfilteredAggregator) parseBoolProp(ctx context.Context,
 	prop aggregation.ParamProperty,
-	parseFn func(agg *boolAggregator, k []byte, v [][]byte) error) (*aggregation.Property, error) {
+	parseFn func(agg *boolAggregator, k []byte, count uint64) error) (*aggregation.Property, error) {
 	out := aggregation.Property{
 		Type: aggregation.PropertyTypeBoolean,
 	}
@@ -46,14 +47,11 @@ func (ua unfilteredAggregator) parseBoolProp(ctx context.Context,
 
 	agg := newBoolAggregator()
 
-	c := b.SetCursor() // bool never has a frequency, so it's always a Set
-	defer c.Close()
-
-	for k, v := c.First(); k != nil; k, v = c.Next() {
-		err := parseFn(agg, k, v)
-		if err != nil {
-			return nil, err
-		}
+	// bool never has a frequency, so it's always a Set or RoaringSet
+	if err := forEachRowCount(b, func(k []byte, count uint64) error {
+		return parseFn(agg, k, count)
+	}); err != nil {
+		return nil, err
 	}
 
 	out.BooleanAggregation = agg.Res()
@@ -61,21 +59,21 @@ func (ua unfilteredAggregator) parseBoolProp(ctx context.Context,
 	return &out, nil
 }
 
-func (ua unfilteredAggregator) parseAndAddBoolRow(agg *boolAggregator, k []byte, v [][]byte) error {
+func (ua unfilteredAggregator) parseAndAddBoolRow(agg *boolAggregator, k []byte, count uint64) error {
 	if len(k) != 1 {
 		// we expect to see a single byte for a marshalled bool
 		return fmt.Errorf("unexpected key length on inverted index, "+
 			"expected 1: got %d", len(k))
 	}
 
-	if err := agg.AddBoolRow(k, uint64(len(v))); err != nil {
+	if err := agg.AddBoolRow(k, count); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (ua unfilteredAggregator) parseAndAddBoolArrayRow(agg *boolAggregator, k []byte, v [][]byte) error {
+func (ua unfilteredAggregator) parseAndAddBoolArrayRow(agg *boolAggregator, k []byte, count uint64) error {
 	values := make([][]byte, len(k))
 	for i := range k {
 		values[i] = []byte{k[i]}
@@ -104,13 +102,11 @@ func (ua unfilteredAggregator) floatProperty(ctx context.Context,
 
 	agg := newNumericalAggregator()
 
-	c := b.SetCursor() // flat never has a frequency, so it's always a Set
-	defer c.Close()
-
-	for k, v := c.First(); k != nil; k, v = c.Next() {
-		if err := ua.parseAndAddFloatRow(agg, k, v); err != nil {
-			return nil, err
-		}
+	// flat never has a frequency, so it's always a Set or RoaringSet
+	if err := forEachRowCount(b, func(k []byte, count uint64) error {
+		return ua.parseAndAddFloatRow(agg, k, count)
+	}); err != nil {
+		return nil, err
 	}
 
 	addNumericalAggregations(&out, prop.Aggregators, agg)
@@ -132,13 +128,11 @@ func (ua unfilteredAggregator) intProperty(ctx context.Context,
 
 	agg := newNumericalAggregator()
 
-	c := b.SetCursor() // int never has a frequency, so it's always a Set
-	defer c.Close()
-
-	for k, v := c.First(); k != nil; k, v = c.Next() {
-		if err := ua.parseAndAddIntRow(agg, k, v); err != nil {
-			return nil, err
-		}
+	// int never has a frequency, so it's always a Set or RoaringSet
+	if err := forEachRowCount(b, func(k []byte, count uint64) error {
+		return ua.parseAndAddIntRow(agg, k, count)
+	}); err != nil {
+		return nil, err
 	}
 
 	addNumericalAggregations(&out, prop.Aggregators, agg)
@@ -147,7 +141,7 @@ func (ua unfilteredAggregator) intProperty(ctx context.Context,
 }
 
 func (ua unfilteredAggregator) parseAndAddFloatRow(agg *numericalAggregator, k []byte,
-	v [][]byte) error {
+	count uint64) error {
 	if len(k) != 8 {
 		// we expect to see either an int64 or a float64, so any non-8 length
 		// is unexpected
@@ -155,7 +149,7 @@ func (ua unfilteredAggregator) parseAndAddFloatRow(agg *numericalAggregator, k [
 			"expected 8: got %d", len(k))
 	}
 
-	if err := agg.AddFloat64Row(k, uint64(len(v))); err != nil {
+	if err := agg.AddFloat64Row(k, count); err != nil {
 		return err
 	}
 
@@ -163,7 +157,7 @@ func (ua unfilteredAggregator) parseAndAddFloatRow(agg *numericalAggregator, k [
 }
 
 func (ua unfilteredAggregator) parseAndAddIntRow(agg *numericalAggregator, k []byte,
-	v [][]byte) error {
+	count uint64) error {
 	if len(k) != 8 {
 		// we expect to see either an int64 or a float64, so any non-8 length
 		// is unexpected
@@ -171,13 +165,41 @@ func (ua unfilteredAggregator) parseAndAddIntRow(agg *numericalAggregator, k []b
 			"expected 8: got %d", len(k))
 	}
 
-	if err := agg.AddInt64Row(k, uint64(len(v))); err != nil {
+	if err := agg.AddInt64Row(k, count); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// forEachRowCount calls fn with the number of doc ids of every row of an
+// inverted bucket without frequencies
+func forEachRowCount(b *lsmkv.Bucket, fn func(k []byte, count uint64) error) error {
+	if b.Strategy() == lsmkv.StrategyRoaringSet {
+		c := b.CursorRoaringSet()
+		defer c.Close()
+
+		for k, bm := c.First(); k != nil; k, bm = c.Next() {
+			if err := fn(k, uint64(bm.GetCardinality())); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	c := b.SetCursor()
+	defer c.Close()
+
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(k, uint64(len(v))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (ua unfilteredAggregator) parseAndAddNumberArrayRow(agg *numericalAggregator,
 	v []byte, propName schema.PropertyName) error {
 	items, ok, err := storobj.ParseAndExtractNumberArrayProp(v, propName.String())
@@ -0,0 +1,373 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncVectorIndexing(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	logger, _ := test.NewNullLogger()
+	class := &models.Class{
+		Class:               "AsyncIndexedClass",
+		VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: invertedConfig(),
+		VectorConfig: map[string]models.VectorConfig{
+			"title": {
+				Vectorizer:        "none",
+				VectorIndexType:   "flat",
+				VectorIndexConfig: flat.UserConfig{Distance: "l2-squared"},
+			},
+		},
+		Properties: []*models.Property{{
+			Name:     "stringProp",
+			DataType: []string{string(schema.DataTypeString)},
+		}},
+	}
+	schemaGetter := &fakeSchemaGetter{shardState: singleShardState()}
+	newRepo := func() *DB {
+		repo := New(logger, Config{
+			RootPath:            dirName,
+			QueryMaximumResults: 10000,
+			AsyncVectorIndexing: true,
+		}, &fakeRemoteClient{}, &fakeNodeResolver{})
+		repo.SetSchemaGetter(schemaGetter)
+		require.Nil(t, repo.WaitForStartup(testCtx()))
+		return repo
+	}
+
+	repo := newRepo()
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("creating the class", func(t *testing.T) {
+		require.Nil(t,
+			migrator.AddClass(context.Background(), class, schemaGetter.shardState))
+
+		// update schema getter so it's in sync with class
+		schemaGetter.schema = schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{class},
+			},
+		}
+	})
+
+	const size = 200
+	ids := make([]strfmt.UUID, size)
+	vectors := make([][]float32, size)
+	for i := range ids {
+		ids[i] = strfmt.UUID(uuid.New().String())
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32()}
+	}
+
+	search := func(t *testing.T, vector []float32,
+		targetVector string) []strfmt.UUID {
+		res, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector:    vector,
+			TargetVector:    targetVector,
+			WaitForIndexing: true,
+			ClassName:       class.Class,
+			Pagination:      &filters.Pagination{Limit: size},
+		})
+		require.Nil(t, err)
+
+		out := make([]strfmt.UUID, len(res))
+		for i := range res {
+			out[i] = res[i].ID
+		}
+		return out
+	}
+
+	t.Run("importing objects", func(t *testing.T) {
+		for i := range ids {
+			err := repo.PutObject(context.Background(), &models.Object{
+				ID:         ids[i],
+				Class:      class.Class,
+				Properties: map[string]interface{}{"stringProp": "value"},
+				Vectors:    models.Vectors{"title": vectors[i]},
+			}, vectors[i])
+			require.Nil(t, err)
+		}
+	})
+
+	t.Run("waiting for indexing makes every object searchable", func(t *testing.T) {
+		assert.ElementsMatch(t, ids, search(t, []float32{0, 0, 0}, ""))
+		assert.ElementsMatch(t, ids, search(t, []float32{0, 0, 0}, "title"))
+	})
+
+	t.Run("the queues are drained", func(t *testing.T) {
+		status, err := migrator.GetShardsStatus(context.Background(), class.Class)
+		require.Nil(t, err)
+		require.Len(t, status, 1)
+		require.Len(t, status[0].VectorIndexes, 2)
+		for _, index := range status[0].VectorIndexes {
+			assert.Equal(t, int64(0), index.QueueLength)
+		}
+	})
+
+	t.Run("updates and deletes are applied in order", func(t *testing.T) {
+		err := repo.PutObject(context.Background(), &models.Object{
+			ID:         ids[0],
+			Class:      class.Class,
+			Properties: map[string]interface{}{"stringProp": "updated"},
+			Vectors:    models.Vectors{"title": {5, 5, 5}},
+		}, []float32{5, 5, 5})
+		require.Nil(t, err)
+		require.Nil(t, repo.DeleteObject(context.Background(), class.Class, ids[1]))
+
+		for _, target := range []string{"", "title"} {
+			res := search(t, []float32{5, 5, 5}, target)
+			require.Len(t, res, size-1)
+			assert.Equal(t, ids[0], res[0])
+			assert.NotContains(t, res, ids[1])
+		}
+	})
+
+	t.Run("restarting keeps the indexes intact", func(t *testing.T) {
+		require.Nil(t, repo.Shutdown(context.Background()))
+		repo = newRepo()
+
+		for _, target := range []string{"", "title"} {
+			res := search(t, []float32{5, 5, 5}, target)
+			require.Len(t, res, size-1)
+			assert.Equal(t, ids[0], res[0])
+		}
+	})
+
+	t.Run("the queue log is removed with the class", func(t *testing.T) {
+		migrator := NewMigrator(repo, logger)
+		require.Nil(t, migrator.DropClass(context.Background(), class.Class))
+
+		shard := fmt.Sprintf("%s_%s", indexID(schema.ClassName(class.Class)),
+			schemaGetter.shardState.AllPhysicalShards()[0])
+		_, err := os.Stat(fmt.Sprintf("%s/%s.vectorqueue", dirName, shard))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestVectorIndexQueue_Replay(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	logger, _ := test.NewNullLogger()
+	path := fmt.Sprintf("%s/replay.vectorqueue", dirName)
+
+	// the log contains the operations of a previous run: doc id 1 was never
+	// applied, doc id 2 was already part of the index. The last operation was
+	// only partially written.
+	f, err := os.Create(path)
+	require.Nil(t, err)
+	w := bufio.NewWriter(f)
+	require.Nil(t, writeVectorIndexQueueOp(w, vectorIndexQueueOp{id: 1, vector: []float32{1, 2}}))
+	require.Nil(t, writeVectorIndexQueueOp(w, vectorIndexQueueOp{id: 2, vector: []float32{3, 4}}))
+	require.Nil(t, writeVectorIndexQueueOp(w, vectorIndexQueueOp{id: 1, delete: true}))
+	require.Nil(t, w.Flush())
+	_, err = f.Write([]byte{vectorIndexQueueOpAdd, 3, 0, 0})
+	require.Nil(t, err)
+	require.Nil(t, f.Close())
+
+	index := newRecordingVectorIndex(2)
+	policy := durability.Policy{Mode: durability.ModeEveryWrite}
+	queue, err := newVectorIndexQueue(path, index, policy, logger)
+	require.Nil(t, err)
+	defer queue.Drop()
+
+	require.Nil(t, queue.Wait(context.Background()))
+	assert.Equal(t, int64(0), queue.Length())
+	assert.Equal(t, []string{"add 1", "delete 1"}, index.operations())
+
+	t.Run("the log is empty after the queue drained", func(t *testing.T) {
+		info, err := os.Stat(path)
+		require.Nil(t, err)
+		assert.Equal(t, int64(0), info.Size())
+		assert.Greater(t, index.flushes(), 0)
+	})
+
+	t.Run("new operations are applied", func(t *testing.T) {
+		require.Nil(t, queue.Add(3, []float32{5, 6}))
+		require.Nil(t, queue.Delete(2))
+		require.Nil(t, queue.Wait(context.Background()))
+
+		assert.ElementsMatch(t, []string{"add 1", "delete 1", "add 3", "delete 2"},
+			index.operations())
+	})
+
+	t.Run("waiting respects the context", func(t *testing.T) {
+		index.block()
+		require.Nil(t, queue.Add(4, []float32{7, 8}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.NotNil(t, queue.Wait(ctx))
+		assert.Equal(t, int64(1), queue.Length())
+
+		index.unblock()
+		require.Nil(t, queue.Wait(context.Background()))
+		assert.Equal(t, int64(0), queue.Length())
+	})
+
+	t.Run("writes don't wait for the flush of the index", func(t *testing.T) {
+		index.blockFlush()
+		flushes := index.flushes()
+		require.Nil(t, queue.Add(5, []float32{9, 10}))
+		assert.Eventually(t, func() bool { return index.flushes() > flushes },
+			time.Second, time.Millisecond)
+
+		added := make(chan error, 1)
+		go func() { added <- queue.Add(6, []float32{11, 12}) }()
+		select {
+		case err := <-added:
+			assert.Nil(t, err)
+		case <-time.After(time.Second):
+			t.Error("write blocked by the flush of the index")
+		}
+
+		index.unblockFlush()
+		require.Nil(t, queue.Wait(context.Background()))
+		assert.Contains(t, index.operations(), "add 6")
+	})
+}
+
+// recordingVectorIndex records the operations applied to it and can be
+// blocked to simulate a slow vector index
+type recordingVectorIndex struct {
+	sync.Mutex
+	existing map[uint64]struct{}
+	ops      []string
+	flushed  int
+	blocked  sync.WaitGroup
+
+	flushBlocked sync.WaitGroup
+}
+
+func newRecordingVectorIndex(existing ...uint64) *recordingVectorIndex {
+	index := &recordingVectorIndex{existing: map[uint64]struct{}{}}
+	for _, id := range existing {
+		index.existing[id] = struct{}{}
+	}
+	return index
+}
+
+func (r *recordingVectorIndex) block() {
+	r.blocked.Add(1)
+}
+
+func (r *recordingVectorIndex) unblock() {
+	r.blocked.Done()
+}
+
+func (r *recordingVectorIndex) blockFlush() {
+	r.flushBlocked.Add(1)
+}
+
+func (r *recordingVectorIndex) unblockFlush() {
+	r.flushBlocked.Done()
+}
+
+func (r *recordingVectorIndex) Add(id uint64, vector []float32) error {
+	r.blocked.Wait()
+	r.Lock()
+	defer r.Unlock()
+	r.ops = append(r.ops, fmt.Sprintf("add %d", id))
+	r.existing[id] = struct{}{}
+	return nil
+}
+
+func (r *recordingVectorIndex) Delete(id uint64) error {
+	r.blocked.Wait()
+	r.Lock()
+	defer r.Unlock()
+	r.ops = append(r.ops, fmt.Sprintf("delete %d", id))
+	return nil
+}
+
+func (r *recordingVectorIndex) ContainsNode(id uint64) bool {
+	r.Lock()
+	defer r.Unlock()
+	_, ok := r.existing[id]
+	return ok
+}
+
+func (r *recordingVectorIndex) SearchByVector(vector []float32, k int,
+	allow helpers.AllowList) ([]uint64, []float32, error) {
+	return nil, nil, nil
+}
+
+func (r *recordingVectorIndex) SearchByVectorBatch(vectors [][]float32, k int,
+	allow helpers.AllowList) ([][]uint64, [][]float32, error) {
+	return make([][]uint64, len(vectors)), make([][]float32, len(vectors)), nil
+}
+
+func (r *recordingVectorIndex) UpdateUserConfig(updated schema.VectorIndexConfig) error {
+	return nil
+}
+
+func (r *recordingVectorIndex) Drop() error {
+	return nil
+}
+
+func (r *recordingVectorIndex) Flush() error {
+	r.Lock()
+	r.flushed++
+	r.Unlock()
+
+	r.flushBlocked.Wait()
+	return nil
+}
+
+func (r *recordingVectorIndex) operations() []string {
+	r.Lock()
+	defer r.Unlock()
+	return append([]string(nil), r.ops...)
+}
+
+func (r *recordingVectorIndex) flushes() int {
+	r.Lock()
+	defer r.Unlock()
+	return r.flushed
+}
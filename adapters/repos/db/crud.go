@@ -82,7 +82,7 @@ func (d *DB) MultiGet(ctx context.Context,
 
 	out := make(search.Results, len(query))
 	for indexID, queries := range byIndex {
-		indexRes, err := d.indices[indexID].multiObjectByID(ctx, queries)
+		indexRes, err := d.indices[indexID].multiObjectByID(ctx, queries, additional)
 		if err != nil {
 			return nil, errors.Wrapf(err, "index %q", indexID)
 		}
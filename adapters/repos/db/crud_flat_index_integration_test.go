@@ -0,0 +1,132 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRUD_FlatVectorIndex(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	logger, _ := test.NewNullLogger()
+	class := &models.Class{
+		Class:               "FlatIndexedClass",
+		VectorIndexType:     "flat",
+		VectorIndexConfig:   flat.UserConfig{Distance: "l2-squared"},
+		InvertedIndexConfig: invertedConfig(),
+		Properties: []*models.Property{{
+			Name:     "stringProp",
+			DataType: []string{string(schema.DataTypeString)},
+		}},
+	}
+	schemaGetter := &fakeSchemaGetter{shardState: singleShardState()}
+	repo := New(logger, Config{RootPath: dirName, QueryMaximumResults: 10000}, &fakeRemoteClient{},
+		&fakeNodeResolver{})
+	repo.SetSchemaGetter(schemaGetter)
+	err := repo.WaitForStartup(testCtx())
+	require.Nil(t, err)
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("creating the class", func(t *testing.T) {
+		require.Nil(t,
+			migrator.AddClass(context.Background(), class, schemaGetter.shardState))
+
+		// update schema getter so it's in sync with class
+		schemaGetter.schema = schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{class},
+			},
+		}
+	})
+
+	ids := []strfmt.UUID{
+		"f3a0e5a4-5b4d-4d4b-8ae0-0b8c4f1b8a01",
+		"f3a0e5a4-5b4d-4d4b-8ae0-0b8c4f1b8a02",
+		"f3a0e5a4-5b4d-4d4b-8ae0-0b8c4f1b8a03",
+	}
+	vectors := [][]float32{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	values := []string{"first", "second", "third"}
+
+	t.Run("importing objects", func(t *testing.T) {
+		for i := range ids {
+			err := repo.PutObject(context.Background(), &models.Object{
+				ID:         ids[i],
+				Class:      class.Class,
+				Properties: map[string]interface{}{"stringProp": values[i]},
+			}, vectors[i])
+			require.Nil(t, err)
+		}
+	})
+
+	t.Run("searching by vector", func(t *testing.T) {
+		res, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector: []float32{0.1, 0.9, 0.2},
+			ClassName:    class.Class,
+			Pagination:   &filters.Pagination{Limit: 10},
+		})
+		require.Nil(t, err)
+		require.Len(t, res, 3)
+		assert.Equal(t, ids[1], res[0].ID)
+		assert.Equal(t, ids[2], res[1].ID)
+		assert.Equal(t, ids[0], res[2].ID)
+	})
+
+	t.Run("searching by vector with a filter", func(t *testing.T) {
+		res, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector: []float32{0.1, 0.9, 0.2},
+			ClassName:    class.Class,
+			Pagination:   &filters.Pagination{Limit: 10},
+			Filters:      buildFilter("stringProp", "first", eq, dtString),
+		})
+		require.Nil(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, ids[0], res[0].ID)
+	})
+
+	t.Run("deleted objects are not returned", func(t *testing.T) {
+		require.Nil(t, repo.DeleteObject(context.Background(), class.Class, ids[1]))
+
+		res, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector: []float32{0.1, 0.9, 0.2},
+			ClassName:    class.Class,
+			Pagination:   &filters.Pagination{Limit: 10},
+		})
+		require.Nil(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, ids[2], res[0].ID)
+	})
+}
@@ -0,0 +1,350 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/usecases/objects"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRUD_NamedVectors(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	titleConfig := hnsw.NewDefaultUserConfig()
+	titleConfig.Distance = hnsw.DistanceL2Squared
+
+	logger, _ := test.NewNullLogger()
+	class := &models.Class{
+		Class:               "ClassWithNamedVectors",
+		VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: invertedConfig(),
+		VectorConfig: map[string]models.VectorConfig{
+			"title": {
+				Vectorizer:        "none",
+				VectorIndexType:   "hnsw",
+				VectorIndexConfig: titleConfig,
+			},
+			"description": {
+				Vectorizer:        "none",
+				VectorIndexType:   "flat",
+				VectorIndexConfig: flat.UserConfig{Distance: "l2-squared"},
+			},
+		},
+		Properties: []*models.Property{{
+			Name:     "stringProp",
+			DataType: []string{string(schema.DataTypeString)},
+		}},
+	}
+	schemaGetter := &fakeSchemaGetter{shardState: singleShardState()}
+	repo := New(logger, Config{RootPath: dirName, QueryMaximumResults: 10000}, &fakeRemoteClient{},
+		&fakeNodeResolver{})
+	repo.SetSchemaGetter(schemaGetter)
+	err := repo.WaitForStartup(testCtx())
+	require.Nil(t, err)
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("creating the class", func(t *testing.T) {
+		require.Nil(t,
+			migrator.AddClass(context.Background(), class, schemaGetter.shardState))
+
+		// update schema getter so it's in sync with class
+		schemaGetter.schema = schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{class},
+			},
+		}
+	})
+
+	ids := []strfmt.UUID{
+		"8a5e3c56-59a5-4a3b-9e6c-7f0d1c2b3a01",
+		"8a5e3c56-59a5-4a3b-9e6c-7f0d1c2b3a02",
+		"8a5e3c56-59a5-4a3b-9e6c-7f0d1c2b3a03",
+	}
+	// the title and description vectors are deliberately ordered differently,
+	// so that searching the same query against either of them produces a
+	// different ranking
+	classVectors := [][]float32{{1, 0}, {0, 1}, {1, 1}}
+	titleVectors := [][]float32{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	descriptionVectors := [][]float32{{0, 0, 1}, {0, 1, 0}, {1, 0, 0}}
+
+	search := func(t *testing.T, vector []float32,
+		targetVector string) ([]strfmt.UUID, error) {
+		res, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector: vector,
+			TargetVector: targetVector,
+			ClassName:    class.Class,
+			Pagination:   &filters.Pagination{Limit: 10},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]strfmt.UUID, len(res))
+		for i := range res {
+			out[i] = res[i].ID
+		}
+		return out, nil
+	}
+
+	t.Run("importing objects", func(t *testing.T) {
+		for i := range ids {
+			err := repo.PutObject(context.Background(), &models.Object{
+				ID:         ids[i],
+				Class:      class.Class,
+				Properties: map[string]interface{}{"stringProp": "value"},
+				Vectors: models.Vectors{
+					"title":       titleVectors[i],
+					"description": descriptionVectors[i],
+				},
+			}, classVectors[i])
+			require.Nil(t, err)
+		}
+	})
+
+	t.Run("searching each named vector", func(t *testing.T) {
+		res, err := search(t, []float32{0.9, 0.1, 0}, "title")
+		require.Nil(t, err)
+		require.Len(t, res, 3)
+		assert.Equal(t, ids[0], res[0])
+
+		res, err = search(t, []float32{0.9, 0.1, 0}, "description")
+		require.Nil(t, err)
+		require.Len(t, res, 3)
+		assert.Equal(t, ids[2], res[0])
+	})
+
+	t.Run("searching the class-level vector", func(t *testing.T) {
+		res, err := search(t, []float32{0, 1}, "")
+		require.Nil(t, err)
+		require.Len(t, res, 3)
+		assert.Equal(t, ids[1], res[0])
+	})
+
+	t.Run("searching an unknown named vector", func(t *testing.T) {
+		_, err := search(t, []float32{1, 0, 0}, "unknown")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("named vectors are returned with the object", func(t *testing.T) {
+		res, err := repo.ObjectByID(context.Background(), ids[0], nil,
+			additional.Properties{Vector: true})
+		require.Nil(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, models.Vectors{
+			"title":       titleVectors[0],
+			"description": descriptionVectors[0],
+		}, res.Vectors)
+	})
+
+	t.Run("merging a single named vector", func(t *testing.T) {
+		err := repo.Merge(context.Background(), objects.MergeDocument{
+			Class:           class.Class,
+			ID:              ids[1],
+			PrimitiveSchema: map[string]interface{}{"stringProp": "updated"},
+			Vectors:         models.Vectors{"title": {1, 0.1, 0}},
+			UpdateTime:      time.Now().UnixNano() / int64(time.Millisecond),
+		})
+		require.Nil(t, err)
+
+		res, err := search(t, []float32{1, 0.2, 0}, "title")
+		require.Nil(t, err)
+		require.Len(t, res, 3)
+		assert.Equal(t, ids[1], res[0])
+
+		// the other named vector is untouched
+		obj, err := repo.ObjectByID(context.Background(), ids[1], nil,
+			additional.Properties{Vector: true})
+		require.Nil(t, err)
+		require.NotNil(t, obj)
+		assert.Equal(t, models.C11yVector(descriptionVectors[1]),
+			obj.Vectors["description"])
+	})
+
+	t.Run("deleted objects are removed from every named vector", func(t *testing.T) {
+		require.Nil(t, repo.DeleteObject(context.Background(), class.Class, ids[2]))
+
+		res, err := search(t, []float32{0, 0, 1}, "title")
+		require.Nil(t, err)
+		assert.NotContains(t, res, ids[2])
+
+		res, err = search(t, []float32{1, 0, 0}, "description")
+		require.Nil(t, err)
+		assert.NotContains(t, res, ids[2])
+	})
+
+	t.Run("shards status lists every vector index", func(t *testing.T) {
+		status, err := migrator.GetShardsStatus(context.Background(), class.Class)
+		require.Nil(t, err)
+		require.Len(t, status, 1)
+
+		indexes := status[0].VectorIndexes
+		require.Len(t, indexes, 3)
+		assert.Equal(t, "", indexes[0].TargetVector)
+		assert.Equal(t, "description", indexes[1].TargetVector)
+		assert.Equal(t, "title", indexes[2].TargetVector)
+		for _, index := range indexes {
+			assert.Equal(t, models.VectorIndexRebuildStatusStatusIdle,
+				index.Rebuild.Status)
+		}
+
+		// only the hnsw indexes hold vectors in memory
+		require.NotNil(t, indexes[0].Cache)
+		assert.Greater(t, indexes[0].Cache.Entries, int64(0))
+		assert.Greater(t, indexes[0].Cache.SizeBytes, int64(0))
+		assert.Nil(t, indexes[1].Cache)
+		require.NotNil(t, indexes[2].Cache)
+		assert.Greater(t, indexes[2].Cache.Hits+indexes[2].Cache.Misses, int64(0))
+	})
+
+	t.Run("changing max connections rebuilds the named vector index", func(t *testing.T) {
+		updated := titleConfig
+		updated.MaxConnections = titleConfig.MaxConnections * 2
+		err := migrator.UpdateNamedVectorIndexConfigs(context.Background(),
+			class.Class, map[string]schema.VectorIndexConfig{"title": updated})
+		require.Nil(t, err)
+
+		var rebuild *models.VectorIndexRebuildStatus
+		for i := 0; i < 100; i++ {
+			status, err := migrator.GetShardsStatus(context.Background(), class.Class)
+			require.Nil(t, err)
+			rebuild = status[0].VectorIndexes[2].Rebuild
+			if rebuild.Status != models.VectorIndexRebuildStatusStatusRunning {
+				break
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		assert.Equal(t, models.VectorIndexRebuildStatusStatusCompleted, rebuild.Status)
+		assert.Equal(t, int64(2), rebuild.Total)
+		assert.Equal(t, rebuild.Total, rebuild.Processed)
+
+		res, err := search(t, []float32{1, 0.2, 0}, "title")
+		require.Nil(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, ids[1], res[0])
+	})
+
+	t.Run("inspecting and repairing the named vector index", func(t *testing.T) {
+		shardName := schemaGetter.shardState.AllPhysicalShards()[0]
+
+		report, err := migrator.InspectVectorIndex(context.Background(),
+			class.Class, shardName, "title")
+		require.Nil(t, err)
+		assert.Equal(t, "title", report.TargetVector)
+		assert.Equal(t, int64(2), report.Nodes)
+		assert.Equal(t, int64(0), report.Unreachable)
+		require.NotEmpty(t, report.Levels)
+		assert.Equal(t, int64(2), report.Levels[0].Nodes)
+
+		res, err := migrator.RepairVectorIndex(context.Background(),
+			class.Class, shardName, "title")
+		require.Nil(t, err)
+		assert.Equal(t, int64(0), res.Reconnected)
+		require.NotNil(t, res.Graph)
+		assert.Equal(t, int64(0), res.Graph.Tombstones)
+
+		_, err = migrator.InspectVectorIndex(context.Background(),
+			class.Class, shardName, "description")
+		assert.NotNil(t, err, "flat indexes have no graph")
+
+		_, err = migrator.InspectVectorIndex(context.Background(),
+			class.Class, "unknown", "title")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("scrubbing the shard", func(t *testing.T) {
+		shardName := schemaGetter.shardState.AllPhysicalShards()[0]
+		shard := repo.GetIndex(schema.ClassName(class.Class)).Shards[shardName]
+		require.Nil(t, shard.store.Bucket(helpers.ObjectsBucketLSM).FlushAndSwitch())
+
+		report, err := migrator.ScrubShard(context.Background(), class.Class,
+			shardName, true)
+		require.Nil(t, err)
+		assert.Equal(t, int64(0), report.Corrupt)
+
+		found := false
+		for _, segment := range report.Segments {
+			assert.Equal(t, lsmkv.ScrubStatusOK, segment.Status)
+			assert.False(t, segment.Quarantined)
+			if segment.Bucket == helpers.ObjectsBucketLSM {
+				found = true
+			}
+		}
+		assert.True(t, found, "the flushed objects segment was verified")
+
+		_, err = migrator.ScrubShard(context.Background(), class.Class,
+			"unknown", false)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("evaluating the recall of the named vector index", func(t *testing.T) {
+		shardName := schemaGetter.shardState.AllPhysicalShards()[0]
+
+		report, err := migrator.EvaluateVectorIndexRecall(context.Background(),
+			class.Class, shardName, "title",
+			&models.VectorIndexRecallRequest{K: 2, SampleSize: 10})
+		require.Nil(t, err)
+		assert.Equal(t, "title", report.TargetVector)
+		assert.Equal(t, int64(2), report.Queries, "only two objects are left")
+		assert.Equal(t, int64(2), report.K)
+		assert.Equal(t, 1.0, report.Recall)
+		assert.Equal(t, 1.0, report.MinRecall)
+		require.NotNil(t, report.Latency)
+
+		report, err = migrator.EvaluateVectorIndexRecall(context.Background(),
+			class.Class, shardName, "title", &models.VectorIndexRecallRequest{
+				K:       1,
+				Queries: []models.C11yVector{{1, 0.2, 0}},
+			})
+		require.Nil(t, err)
+		assert.Equal(t, int64(1), report.Queries)
+		assert.Equal(t, 1.0, report.Recall)
+
+		_, err = migrator.EvaluateVectorIndexRecall(context.Background(),
+			class.Class, shardName, "description",
+			&models.VectorIndexRecallRequest{K: 1, SampleSize: 1})
+		assert.NotNil(t, err, "flat indexes are exact")
+	})
+
+	t.Run("shards status of an unknown class", func(t *testing.T) {
+		_, err := migrator.GetShardsStatus(context.Background(), "UnknownClass")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+// Package durability decides when the write-ahead-logs of a node are fsynced
+// and thus what an acknowledged write survives. With ModeNone a write survives
+// a crash of the process, as it has been handed to the operating system before
+// it is acknowledged, but it may be lost on a power loss or kernel crash. With
+// ModeEveryWrite it survives both. ModeInterval is in between: at most the
+// writes of the last interval can be lost on a power loss.
+package durability
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ModeNone never fsyncs the logs explicitly, this is the default
+	ModeNone = "none"
+
+	// ModeInterval fsyncs the logs in the background at most once per
+	// interval if there were writes since the last fsync
+	ModeInterval = "interval"
+
+	// ModeEveryWrite fsyncs the logs before a write is acknowledged.
+	// Concurrent writes to the same log share a single fsync (group commit).
+	ModeEveryWrite = "every-write"
+)
+
+// DefaultInterval is used by ModeInterval if no interval is set
+const DefaultInterval = time.Second
+
+type Policy struct {
+	Mode     string
+	Interval time.Duration
+}
+
+// NewPolicy validates the mode and interval, an empty mode selects ModeNone
+// and a zero interval selects the DefaultInterval
+func NewPolicy(mode string, interval time.Duration) (Policy, error) {
+	if mode == "" {
+		mode = ModeNone
+	}
+
+	switch mode {
+	case ModeNone, ModeInterval, ModeEveryWrite:
+	default:
+		return Policy{}, errors.Errorf("unrecognized durability mode %q, must "+
+			"be one of %q, %q or %q", mode, ModeNone, ModeInterval, ModeEveryWrite)
+	}
+
+	if interval < 0 {
+		return Policy{}, errors.Errorf("durability interval must not be negative")
+	}
+
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	return Policy{Mode: mode, Interval: interval}, nil
+}
+
+// SyncsFiles is true if the policy fsyncs at all. Files that replace a log,
+// such as the disk segment a log is flushed into, must then be fsynced as
+// well before the log can be deleted.
+func (p Policy) SyncsFiles() bool {
+	return p.Mode == ModeInterval || p.Mode == ModeEveryWrite
+}
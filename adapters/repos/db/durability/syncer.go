@@ -0,0 +1,203 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package durability
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// File is the part of an *os.File a Syncer needs
+type File interface {
+	Name() string
+	Sync() error
+}
+
+// Syncer fsyncs a single log file according to a Policy. The owner of the
+// file writes to it as usual and calls Commit once a write has reached the
+// file (i.e. has left all user-space buffers) and is about to be
+// acknowledged. Commit must not be called while holding a lock that other
+// writers need, otherwise concurrent writes can't share an fsync.
+//
+// The first fsync also fsyncs the parent directory, so that a newly created
+// log can be found again after a power loss.
+type Syncer struct {
+	policy Policy
+	file   File
+
+	sync.Mutex
+	cond *sync.Cond
+
+	// requested counts the commits, synced the commits covered by the last
+	// successful fsync
+	requested uint64
+	synced    uint64
+	syncing   bool
+	closed    bool
+	dirSynced bool
+	timer     *time.Timer
+
+	// err is sticky, after a failed fsync it is unknown which writes have
+	// reached the disk, so no later commit can succeed either
+	err error
+}
+
+func NewSyncer(policy Policy, file File) *Syncer {
+	s := &Syncer{policy: policy, file: file}
+	s.cond = sync.NewCond(&s.Mutex)
+	return s
+}
+
+// Commit returns once the writes that have reached the file so far are as
+// durable as the policy promises. With ModeInterval it returns right away
+// and schedules an fsync, the error of an earlier background fsync is
+// returned if there was one.
+func (s *Syncer) Commit() error {
+	switch s.policy.Mode {
+	case ModeEveryWrite:
+		return s.commitAndWait()
+	case ModeInterval:
+		return s.commitAndSchedule()
+	default:
+		return nil
+	}
+}
+
+func (s *Syncer) commitAndWait() error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.requested++
+	ticket := s.requested
+
+	for s.synced < ticket && s.err == nil && !s.closed {
+		if s.syncing {
+			// an fsync is already in flight, but it might have started before
+			// this write reached the file. Wait for it and, unless another
+			// waiter takes over, start the next one which then covers all writes
+			// that have queued up in the meantime.
+			s.cond.Wait()
+			continue
+		}
+
+		s.syncLocked()
+	}
+
+	return s.err
+}
+
+func (s *Syncer) commitAndSchedule() error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.requested++
+	if s.timer == nil && !s.closed {
+		s.timer = time.AfterFunc(s.policy.Interval, s.syncDue)
+	}
+
+	return s.err
+}
+
+func (s *Syncer) syncDue() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.timer = nil
+	for s.syncing {
+		s.cond.Wait()
+	}
+
+	if s.closed || s.err != nil || s.synced >= s.requested {
+		return
+	}
+
+	s.syncLocked()
+}
+
+// syncLocked must be called with the lock held, the lock is released for
+// the duration of the fsync itself
+func (s *Syncer) syncLocked() {
+	target := s.requested
+	syncDir := !s.dirSynced
+	s.syncing = true
+	s.Unlock()
+
+	err := s.file.Sync()
+	if err == nil && syncDir {
+		err = syncDirOf(s.file.Name())
+	}
+
+	s.Lock()
+	s.syncing = false
+	if err != nil {
+		s.err = errors.Wrapf(err, "fsync log %q", s.file.Name())
+	} else {
+		s.synced = target
+		s.dirSynced = true
+	}
+	s.cond.Broadcast()
+}
+
+// Close fsyncs the file, unless the policy is ModeNone, and stops the
+// background fsyncs. The file must be flushed before and closed only after
+// calling Close. As the final fsync covers everything written to the file, a
+// Commit that races with Close, e.g. because the owner switched to a new log,
+// succeeds without an fsync of its own.
+func (s *Syncer) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed {
+		return s.err
+	}
+
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	for s.syncing {
+		s.cond.Wait()
+	}
+
+	if s.policy.SyncsFiles() && s.err == nil {
+		s.syncLocked()
+	}
+
+	s.closed = true
+	s.cond.Broadcast()
+	return s.err
+}
+
+func syncDirOf(path string) error {
+	return SyncDir(filepath.Dir(path))
+}
+
+// SyncDir fsyncs the directory itself, which makes files created in or
+// renamed into it durable
+func SyncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	if err := dir.Sync(); err != nil {
+		dir.Close()
+		return err
+	}
+
+	return dir.Close()
+}
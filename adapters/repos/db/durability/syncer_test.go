@@ -0,0 +1,192 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package durability
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFile struct {
+	sync.Mutex
+	name  string
+	delay time.Duration
+	err   error
+	syncs int
+}
+
+func (f *fakeFile) Name() string {
+	return f.name
+}
+
+func (f *fakeFile) Sync() error {
+	time.Sleep(f.delay)
+
+	f.Lock()
+	defer f.Unlock()
+	f.syncs++
+	return f.err
+}
+
+func (f *fakeFile) syncCount() int {
+	f.Lock()
+	defer f.Unlock()
+	return f.syncs
+}
+
+func newFakeFile(t *testing.T) *fakeFile {
+	return &fakeFile{name: filepath.Join(t.TempDir(), "log")}
+}
+
+func TestNewPolicy(t *testing.T) {
+	p, err := NewPolicy("", 0)
+	require.Nil(t, err)
+	assert.Equal(t, Policy{Mode: ModeNone, Interval: DefaultInterval}, p)
+	assert.False(t, p.SyncsFiles())
+
+	p, err = NewPolicy(ModeInterval, 50*time.Millisecond)
+	require.Nil(t, err)
+	assert.Equal(t, 50*time.Millisecond, p.Interval)
+	assert.True(t, p.SyncsFiles())
+
+	_, err = NewPolicy("always", 0)
+	assert.NotNil(t, err)
+
+	_, err = NewPolicy(ModeInterval, -time.Second)
+	assert.NotNil(t, err)
+}
+
+func TestSyncerModeNone(t *testing.T) {
+	f := newFakeFile(t)
+	s := NewSyncer(Policy{Mode: ModeNone}, f)
+
+	for i := 0; i < 10; i++ {
+		require.Nil(t, s.Commit())
+	}
+	require.Nil(t, s.Close())
+
+	assert.Equal(t, 0, f.syncCount())
+}
+
+func TestSyncerModeEveryWrite(t *testing.T) {
+	t.Run("sequential commits are fsynced individually", func(t *testing.T) {
+		f := newFakeFile(t)
+		s := NewSyncer(Policy{Mode: ModeEveryWrite}, f)
+
+		for i := 0; i < 10; i++ {
+			require.Nil(t, s.Commit())
+			assert.Equal(t, i+1, f.syncCount())
+		}
+
+		// close always ends with an fsync
+		require.Nil(t, s.Close())
+		assert.Equal(t, 11, f.syncCount())
+	})
+
+	t.Run("concurrent commits share fsyncs", func(t *testing.T) {
+		f := newFakeFile(t)
+		f.delay = 20 * time.Millisecond
+		s := NewSyncer(Policy{Mode: ModeEveryWrite}, f)
+
+		writers := 50
+		wg := sync.WaitGroup{}
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.Nil(t, s.Commit())
+			}()
+		}
+		wg.Wait()
+
+		// every commit waits for at most the fsync in flight and the one after
+		// it, so the writers can't need more than a handful of fsyncs
+		assert.Greater(t, f.syncCount(), 0)
+		assert.Less(t, f.syncCount(), writers/2)
+		require.Nil(t, s.Close())
+	})
+
+	t.Run("a failed fsync fails all later commits", func(t *testing.T) {
+		f := newFakeFile(t)
+		s := NewSyncer(Policy{Mode: ModeEveryWrite}, f)
+
+		require.Nil(t, s.Commit())
+
+		f.err = errors.New("disk on fire")
+		assert.NotNil(t, s.Commit())
+
+		f.err = nil
+		assert.NotNil(t, s.Commit())
+		assert.NotNil(t, s.Close())
+	})
+
+	t.Run("commits after close are covered by the final fsync", func(t *testing.T) {
+		f := newFakeFile(t)
+		s := NewSyncer(Policy{Mode: ModeEveryWrite}, f)
+
+		require.Nil(t, s.Close())
+		assert.Equal(t, 1, f.syncCount())
+
+		assert.Nil(t, s.Commit())
+		assert.Equal(t, 1, f.syncCount())
+	})
+}
+
+func TestSyncerModeInterval(t *testing.T) {
+	t.Run("commits are fsynced once per interval", func(t *testing.T) {
+		f := newFakeFile(t)
+		s := NewSyncer(Policy{Mode: ModeInterval, Interval: 50 * time.Millisecond}, f)
+
+		for i := 0; i < 10; i++ {
+			require.Nil(t, s.Commit())
+		}
+		assert.Equal(t, 0, f.syncCount())
+
+		assert.Eventually(t, func() bool {
+			return f.syncCount() == 1
+		}, time.Second, 5*time.Millisecond)
+
+		// without new commits there is nothing to fsync
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, 1, f.syncCount())
+		require.Nil(t, s.Close())
+	})
+
+	t.Run("commits are fsynced on close", func(t *testing.T) {
+		f := newFakeFile(t)
+		s := NewSyncer(Policy{Mode: ModeInterval, Interval: time.Hour}, f)
+
+		require.Nil(t, s.Commit())
+		assert.Equal(t, 0, f.syncCount())
+
+		require.Nil(t, s.Close())
+		assert.Equal(t, 1, f.syncCount())
+	})
+
+	t.Run("the error of a background fsync is returned", func(t *testing.T) {
+		f := newFakeFile(t)
+		f.err = errors.New("disk on fire")
+		s := NewSyncer(Policy{Mode: ModeInterval, Interval: 10 * time.Millisecond}, f)
+
+		require.Nil(t, s.Commit())
+		assert.Eventually(t, func() bool {
+			return s.Commit() != nil
+		}, time.Second, 5*time.Millisecond)
+		assert.NotNil(t, s.Close())
+	})
+}
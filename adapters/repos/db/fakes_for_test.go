@@ -22,6 +22,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/storobj"
@@ -114,16 +115,31 @@ func (f *fakeRemoteClient) MultiGetObjects(ctx context.Context, hostName, indexN
 }
 
 func (f *fakeRemoteClient) SearchShard(ctx context.Context, hostName, indexName,
-	shardName string, vector []float32, limit int, filters *filters.LocalFilter,
+	shardName string, vector []float32, targetVector string,
+	waitForIndexing bool, limit int,
+	filters *filters.LocalFilter, geoSort *filters.GeoSort,
 	additional additional.Properties) ([]*storobj.Object, []float32, error) {
 	return nil, nil, nil
 }
 
+func (f *fakeRemoteClient) BatchSearchShard(ctx context.Context, hostName, indexName,
+	shardName string, vectors [][]float32, targetVector string,
+	waitForIndexing bool, limit int,
+	filters *filters.LocalFilter,
+	additional additional.Properties) ([][]*storobj.Object, [][]float32, error) {
+	return nil, nil, nil
+}
+
 func (f *fakeRemoteClient) Aggregate(ctx context.Context, hostName, indexName,
 	shardName string, params aggregation.Params) (*aggregation.Result, error) {
 	return nil, nil
 }
 
+func (f *fakeRemoteClient) ShardStorageStatistics(ctx context.Context,
+	hostName, indexName, shardName string) (*models.ShardStorageStatistics, error) {
+	return nil, nil
+}
+
 func (f *fakeRemoteClient) BatchAddReferences(ctx context.Context, hostName,
 	indexName, shardName string, refs objects.BatchReferences) []error {
 	return nil
@@ -25,6 +25,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/filters"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
@@ -63,6 +64,9 @@ func TestFilters(t *testing.T) {
 
 	t.Run("chained primitive props",
 		testChainedPrimitiveProps(repo, migrator))
+
+	t.Run("sorted by geo distance",
+		testGeoSort(repo))
 }
 
 var (
@@ -75,6 +79,8 @@ var (
 	gt   = filters.OperatorGreaterThan
 	gte  = filters.OperatorGreaterThanEqual
 	wgr  = filters.OperatorWithinGeoRange
+	wgbb = filters.OperatorWithinGeoBoundingBox
+	wgp  = filters.OperatorWithinGeoPolygon
 	and  = filters.OperatorAnd
 	or   = filters.OperatorOr
 
@@ -316,6 +322,32 @@ func testPrimitiveProps(repo *DB) func(t *testing.T) {
 				}, wgr, dtGeoCoordinates),
 				expectedIDs: []strfmt.UUID{carSprinterID},
 			},
+			{
+				name: "within a bounding box around California",
+				filter: buildFilter("parkedAt", filters.GeoBoundingBox{
+					TopLeft: &models.GeoCoordinates{
+						Latitude:  ptFloat32(42.0),
+						Longitude: ptFloat32(-124.4),
+					},
+					BottomRight: &models.GeoCoordinates{
+						Latitude:  ptFloat32(32.5),
+						Longitude: ptFloat32(-114.1),
+					},
+				}, wgbb, dtGeoCoordinates),
+				expectedIDs: []strfmt.UUID{carSprinterID},
+			},
+			{
+				name: "within a polygon around New York City",
+				filter: buildFilter("parkedAt", filters.GeoPolygon{
+					Vertices: []*models.GeoCoordinates{
+						{Latitude: ptFloat32(40.9), Longitude: ptFloat32(-74.3)},
+						{Latitude: ptFloat32(40.9), Longitude: ptFloat32(-73.7)},
+						{Latitude: ptFloat32(40.5), Longitude: ptFloat32(-73.7)},
+						{Latitude: ptFloat32(40.5), Longitude: ptFloat32(-74.3)},
+					},
+				}, wgp, dtGeoCoordinates),
+				expectedIDs: []strfmt.UUID{carE63sID},
+			},
 			// {
 			// 	name:        "by id like",
 			// 	filter:      buildFilter("id", carPoloID.String(), like, dtString),
@@ -380,6 +412,70 @@ func testPrimitivePropsWithLimit(repo *DB) func(t *testing.T) {
 	}
 }
 
+func testGeoSort(repo *DB) func(t *testing.T) {
+	return func(t *testing.T) {
+		sanFrancisco := &filters.GeoSort{
+			Property: "parkedAt",
+			GeoCoordinates: &models.GeoCoordinates{
+				Latitude:  ptFloat32(37.733795),
+				Longitude: ptFloat32(-122.446747),
+			},
+		}
+
+		type test struct {
+			name          string
+			filter        *filters.LocalFilter
+			limit         int
+			expectedIDs   []strfmt.UUID
+			expectedDists []float32
+		}
+
+		km := float32(1000)
+		tests := []test{
+			{
+				name: "nearest to San Francisco first",
+				// the polo is not parked anywhere and therefore not contained
+				limit:         100,
+				expectedIDs:   []strfmt.UUID{carSprinterID, carE63sID},
+				expectedDists: []float32{560 * km, 4130 * km},
+			},
+			{
+				name:          "nearest to San Francisco with a limit",
+				limit:         1,
+				expectedIDs:   []strfmt.UUID{carSprinterID},
+				expectedDists: []float32{560 * km},
+			},
+			{
+				name:          "nearest to San Francisco with a filter",
+				filter:        buildFilter("horsepower", 300, gt, dtInt),
+				limit:         100,
+				expectedIDs:   []strfmt.UUID{carE63sID},
+				expectedDists: []float32{4130 * km},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				params := traverser.GetParams{
+					ClassName:            carClass.Class,
+					Pagination:           &filters.Pagination{Limit: test.limit},
+					Filters:              test.filter,
+					GeoSort:              sanFrancisco,
+					AdditionalProperties: additional.Properties{Distance: true},
+				}
+				res, err := repo.ClassSearch(context.Background(), params)
+				require.Nil(t, err)
+				require.Len(t, res, len(test.expectedIDs))
+
+				for pos := range res {
+					assert.Equal(t, test.expectedIDs[pos], res[pos].ID)
+					assert.InDelta(t, test.expectedDists[pos], res[pos].Dist, float64(10*km))
+				}
+			})
+		}
+	}
+}
+
 func testChainedPrimitiveProps(repo *DB,
 	migrator *Migrator) func(t *testing.T) {
 	return func(t *testing.T) {
@@ -406,22 +502,45 @@ func testChainedPrimitiveProps(repo *DB,
 				),
 				expectedIDs: []strfmt.UUID{carSprinterID, carE63sID},
 			},
-			// test{
-			// 	name: "NOT modelName == sprinter, modelName == e63s",
-			// 	filter: filterNot(
-			// 		buildFilter("modelName", "sprinter", eq, dtString),
-			// 		buildFilter("modelName", "e63s", eq, dtString),
-			// 	),
-			// 	expectedIDs: []strfmt.UUID{carPoloID},
-			// },
-			// test{
-			// 	name: "NOT horsepower < 200 , weight > 3000",
-			// 	filter: filterNot(
-			// 		buildFilter("horsepower", 200, lt, dtNumber),
-			// 		buildFilter("weight", 3000, gt, dtNumber),
-			// 	),
-			// 	expectedIDs: []strfmt.UUID{carE63sID},
-			// },
+			test{
+				name: "NOT modelName == sprinter",
+				filter: filterNot(
+					buildFilter("modelName", "sprinter", eq, dtString),
+				),
+				expectedIDs: []strfmt.UUID{carE63sID, carPoloID},
+			},
+			test{
+				name: "NOT (modelName == sprinter OR modelName == e63s)",
+				filter: filterNot(
+					filterOr(
+						buildFilter("modelName", "sprinter", eq, dtString),
+						buildFilter("modelName", "e63s", eq, dtString),
+					),
+				),
+				expectedIDs: []strfmt.UUID{carPoloID},
+			},
+			test{
+				name: "NOT modelName == sprinter OR NOT modelName == polo",
+				filter: filterOr(
+					filterNot(
+						buildFilter("modelName", "sprinter", eq, dtString),
+					),
+					filterNot(
+						buildFilter("modelName", "polo", eq, dtString),
+					),
+				),
+				expectedIDs: []strfmt.UUID{carSprinterID, carE63sID, carPoloID},
+			},
+			test{
+				name: "weight > 1000 AND NOT horsepower < 200",
+				filter: filterAnd(
+					buildFilter("weight", float64(1000), gt, dtNumber),
+					filterNot(
+						buildFilter("horsepower", 200, lt, dtInt),
+					),
+				),
+				expectedIDs: []strfmt.UUID{carE63sID},
+			},
 			test{
 				name: "(heavy AND powerful) OR light",
 				filter: filterOr(
@@ -464,6 +583,25 @@ func testChainedPrimitiveProps(repo *DB,
 				}
 				assert.ElementsMatch(t, ids, test.expectedIDs, "ids dont match")
 			})
+
+			// a vector search uses the filter to build an allow list, which is
+			// merged from bitmaps instead
+			t.Run(test.name+" (as allow list)", func(t *testing.T) {
+				params := traverser.GetParams{
+					SearchVector: []float32{0.1, 0.1, 0.1, 1.1, 0.1},
+					ClassName:    carClass.Class,
+					Pagination:   &filters.Pagination{Limit: 100},
+					Filters:      test.filter,
+				}
+				res, err := repo.VectorClassSearch(context.Background(), params)
+				require.Nil(t, err)
+
+				ids := make([]strfmt.UUID, len(res))
+				for pos, concept := range res {
+					ids[pos] = concept.ID
+				}
+				assert.ElementsMatch(t, test.expectedIDs, ids, "ids dont match")
+			})
 		}
 	}
 }
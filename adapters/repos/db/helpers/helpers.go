@@ -22,8 +22,16 @@ var (
 	ObjectsBucketLSM        = "objects"
 	DocIDBucket      []byte = []byte("doc_ids")
 	DocIDBucketLSM          = "doc_ids"
+	VectorsBucketLSM        = "vectors"
 )
 
+// NamedVectorsBucketLSM is the name of the bucket which holds the vectors of
+// the named vector with the given name, VectorsBucketLSM holds the vectors of
+// the class-level vector. See VectorKey for their layout.
+func NamedVectorsBucketLSM(name string) string {
+	return fmt.Sprintf("%s_%s", VectorsBucketLSM, name)
+}
+
 // BucketFromPropName creates the byte-representation used as the bucket name
 // for a partiular prop in the inverted index
 func BucketFromPropName(propName string) []byte {
@@ -0,0 +1,64 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package helpers
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// The vector buckets of a shard hold one vector per doc id. Values are the
+// raw float32 components in little endian without any header, so all values
+// of a bucket have the same width of 4 bytes per dimension.
+
+// VectorKey is the key of a doc id in a vector bucket. Keys are big endian,
+// so that a cursor iterates in doc id order.
+func VectorKey(docID uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, docID)
+	return key
+}
+
+// DocIDFromVectorKey is the inverse of VectorKey
+func DocIDFromVectorKey(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key)
+}
+
+// VectorToBytes creates the value of a vector in a vector bucket
+func VectorToBytes(vector []float32) []byte {
+	out := make([]byte, len(vector)*4)
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(out[i*4:], math.Float32bits(v))
+	}
+	return out
+}
+
+// VectorFromBytes decodes the value of a vector bucket into a new vector
+func VectorFromBytes(in []byte) []float32 {
+	return VectorFromBytesInto(make([]float32, len(in)/4), in)
+}
+
+// VectorFromBytesInto decodes the value of a vector bucket into dst, which is
+// grown if it is too small. The returned vector shares its memory with dst,
+// which allows scans to reuse a single buffer.
+func VectorFromBytesInto(dst []float32, in []byte) []float32 {
+	dims := len(in) / 4
+	if cap(dst) < dims {
+		dst = make([]float32, dims)
+	}
+	dst = dst[:dims]
+
+	for i := range dst {
+		dst[i] = math.Float32frombits(binary.LittleEndian.Uint32(in[i*4:]))
+	}
+	return dst
+}
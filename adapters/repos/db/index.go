@@ -21,7 +21,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/aggregator"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
 	"github.com/semi-technologies/weaviate/entities/filters"
@@ -49,6 +52,10 @@ type Index struct {
 	getSchema             schemaUC.SchemaGetter
 	logger                logrus.FieldLogger
 	remote                *sharding.RemoteIndex
+
+	// namedVectorIndexUserConfigs contains the index config of every named
+	// vector of the class, keyed by the name of the vector
+	namedVectorIndexUserConfigs map[string]schema.VectorIndexConfig
 }
 
 func (i Index) ID() string {
@@ -62,7 +69,9 @@ type nodeResolver interface {
 // NewIndex - for now - always creates a single-shard index
 func NewIndex(ctx context.Context, config IndexConfig,
 	shardState *sharding.State, invertedIndexConfig *models.InvertedIndexConfig,
-	vectorIndexUserConfig schema.VectorIndexConfig, sg schemaUC.SchemaGetter,
+	vectorIndexUserConfig schema.VectorIndexConfig,
+	namedVectorIndexUserConfigs map[string]schema.VectorIndexConfig,
+	sg schemaUC.SchemaGetter,
 	cs inverted.ClassSearcher, logger logrus.FieldLogger,
 	nodeResolver nodeResolver, remoteClient sharding.RemoteIndexClient) (*Index, error) {
 	index := &Index{
@@ -73,6 +82,8 @@ func NewIndex(ctx context.Context, config IndexConfig,
 		classSearcher:         cs,
 		vectorIndexUserConfig: vectorIndexUserConfig,
 		invertedIndexConfig:   invertedIndexConfig,
+
+		namedVectorIndexUserConfigs: namedVectorIndexUserConfigs,
 		remote: sharding.NewRemoteIndex(config.ClassName.String(), sg,
 			nodeResolver, remoteClient),
 	}
@@ -135,9 +146,26 @@ func (i *Index) updateVectorIndexConfig(ctx context.Context,
 	return nil
 }
 
+func (i *Index) updateNamedVectorIndexConfigs(ctx context.Context,
+	updated map[string]schema.VectorIndexConfig) error {
+	for name, shard := range i.Shards {
+		if err := shard.updateNamedVectorIndexConfigs(ctx, updated); err != nil {
+			return errors.Wrapf(err, "shard %s", name)
+		}
+	}
+
+	return nil
+}
+
 type IndexConfig struct {
-	RootPath  string
-	ClassName schema.ClassName
+	RootPath               string
+	ClassName              schema.ClassName
+	VectorCacheBudget      *hnsw.CacheBudget
+	AsyncVectorIndexing    bool
+	CompressObjectSegments bool
+	CompactionPolicy       string
+	CompactionLimiter      *lsmkv.CompactionRateLimiter
+	WALSyncPolicy          durability.Policy
 }
 
 func indexID(class schema.ClassName) string {
@@ -377,7 +405,10 @@ func (i *Index) IncomingMultiGetObjects(ctx context.Context, shardName string,
 		return nil, errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
-	objs, err := shard.multiObjectByID(ctx, wrapIDsInMulti(ids))
+	// the remote node doesn't tell which additional properties it needs, so
+	// the vectors are always included
+	objs, err := shard.multiObjectByID(ctx, wrapIDsInMulti(ids),
+		additional.Properties{Vector: true})
 	if err != nil {
 		return nil, errors.Wrapf(err, "shard %s", shard.ID())
 	}
@@ -386,7 +417,8 @@ func (i *Index) IncomingMultiGetObjects(ctx context.Context, shardName string,
 }
 
 func (i *Index) multiObjectByID(ctx context.Context,
-	query []multi.Identifier) ([]*storobj.Object, error) {
+	query []multi.Identifier,
+	additional additional.Properties) ([]*storobj.Object, error) {
 	type idsAndPos struct {
 		ids []multi.Identifier
 		pos []int
@@ -418,7 +450,7 @@ func (i *Index) multiObjectByID(ctx context.Context,
 
 		if local {
 			shard := i.Shards[shardName]
-			objects, err = shard.multiObjectByID(ctx, group.ids)
+			objects, err = shard.multiObjectByID(ctx, group.ids, additional)
 			if err != nil {
 				return nil, errors.Wrapf(err, "shard %s", shard.ID())
 			}
@@ -520,7 +552,8 @@ func (i *Index) objectSearch(ctx context.Context, limit int,
 			}
 
 		} else {
-			res, _, err = i.remote.SearchShard(ctx, shardName, nil, limit, filters, additional)
+			res, _, err = i.remote.SearchShard(ctx, shardName, nil, "", false,
+				limit, filters, nil, additional)
 			if err != nil {
 				return nil, errors.Wrapf(err, "remote shard %s", shardName)
 			}
@@ -536,7 +569,75 @@ func (i *Index) objectSearch(ctx context.Context, limit int,
 }
 
 func (i *Index) objectVectorSearch(ctx context.Context, searchVector []float32,
-	limit int, filters *filters.LocalFilter,
+	targetVector string, waitForIndexing bool, limit int,
+	filters *filters.LocalFilter,
+	additional additional.Properties) ([]*storobj.Object, []float32, error) {
+	shardNames := i.getSchema.ShardingState(i.Config.ClassName.String()).
+		AllPhysicalShards()
+
+	errgrp := &errgroup.Group{}
+	m := &sync.Mutex{}
+
+	out := make([]*storobj.Object, 0, len(shardNames)*limit)
+	dists := make([]float32, 0, len(shardNames)*limit)
+	for _, shardName := range shardNames {
+		shardName := shardName
+		errgrp.Go(func() error {
+			local := i.getSchema.
+				ShardingState(i.Config.ClassName.String()).
+				IsShardLocal(shardName)
+
+			var res []*storobj.Object
+			var resDists []float32
+			var err error
+
+			if local {
+				shard := i.Shards[shardName]
+				res, resDists, err = shard.objectVectorSearch(ctx, searchVector,
+					targetVector, waitForIndexing, limit, filters, additional)
+				if err != nil {
+					return errors.Wrapf(err, "shard %s", shard.ID())
+				}
+
+			} else {
+				res, resDists, err = i.remote.SearchShard(ctx, shardName, searchVector,
+					targetVector, waitForIndexing, limit, filters, nil, additional)
+				if err != nil {
+					return errors.Wrapf(err, "remote shard %s", shardName)
+				}
+			}
+
+			m.Lock()
+			out = append(out, res...)
+			dists = append(dists, resDists...)
+			m.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := errgrp.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(shardNames) == 1 {
+		return out, dists, nil
+	}
+
+	sbd := sortObjsByDist{out, dists}
+	sort.Sort(sbd)
+	if len(sbd.objects) > limit {
+		sbd.objects = sbd.objects[:limit]
+		sbd.distances = sbd.distances[:limit]
+	}
+
+	return sbd.objects, sbd.distances, nil
+}
+
+// objectGeoSortSearch returns the objects nearest to the coordinates of the
+// geo sort across all shards, the per-shard results are merged by distance
+func (i *Index) objectGeoSortSearch(ctx context.Context,
+	geoSort *filters.GeoSort, limit int, filters *filters.LocalFilter,
 	additional additional.Properties) ([]*storobj.Object, []float32, error) {
 	shardNames := i.getSchema.ShardingState(i.Config.ClassName.String()).
 		AllPhysicalShards()
@@ -559,13 +660,15 @@ func (i *Index) objectVectorSearch(ctx context.Context, searchVector []float32,
 
 			if local {
 				shard := i.Shards[shardName]
-				res, resDists, err = shard.objectVectorSearch(ctx, searchVector, limit, filters, additional)
+				res, resDists, err = shard.objectGeoSortSearch(ctx, geoSort, limit,
+					filters, additional)
 				if err != nil {
 					return errors.Wrapf(err, "shard %s", shard.ID())
 				}
 
 			} else {
-				res, resDists, err = i.remote.SearchShard(ctx, shardName, searchVector, limit, filters, additional)
+				res, resDists, err = i.remote.SearchShard(ctx, shardName, nil, "",
+					false, limit, filters, geoSort, additional)
 				if err != nil {
 					return errors.Wrapf(err, "remote shard %s", shardName)
 				}
@@ -598,14 +701,105 @@ func (i *Index) objectVectorSearch(ctx context.Context, searchVector []float32,
 	return sbd.objects, sbd.distances, nil
 }
 
+// objectVectorSearchBatch is the batched equivalent of objectVectorSearch.
+// Each shard runs all searches in a single request, the per-shard results are
+// merged separately for each search vector.
+func (i *Index) objectVectorSearchBatch(ctx context.Context,
+	searchVectors [][]float32, targetVector string, waitForIndexing bool,
+	limit int, filters *filters.LocalFilter,
+	additional additional.Properties) ([][]*storobj.Object, [][]float32, error) {
+	shardNames := i.getSchema.ShardingState(i.Config.ClassName.String()).
+		AllPhysicalShards()
+
+	errgrp := &errgroup.Group{}
+	m := &sync.Mutex{}
+
+	out := make([][]*storobj.Object, len(searchVectors))
+	dists := make([][]float32, len(searchVectors))
+	for _, shardName := range shardNames {
+		shardName := shardName
+		errgrp.Go(func() error {
+			local := i.getSchema.
+				ShardingState(i.Config.ClassName.String()).
+				IsShardLocal(shardName)
+
+			var res [][]*storobj.Object
+			var resDists [][]float32
+			var err error
+
+			if local {
+				shard := i.Shards[shardName]
+				res, resDists, err = shard.objectVectorSearchBatch(ctx, searchVectors,
+					targetVector, waitForIndexing, limit, filters, additional)
+				if err != nil {
+					return errors.Wrapf(err, "shard %s", shard.ID())
+				}
+
+			} else {
+				res, resDists, err = i.remote.BatchSearchShard(ctx, shardName,
+					searchVectors, targetVector, waitForIndexing, limit, filters,
+					additional)
+				if err != nil {
+					return errors.Wrapf(err, "remote shard %s", shardName)
+				}
+			}
+
+			if len(res) != len(searchVectors) || len(resDists) != len(searchVectors) {
+				return errors.Errorf("shard %s: expected %d result lists, got %d",
+					shardName, len(searchVectors), len(res))
+			}
+
+			m.Lock()
+			for pos := range res {
+				out[pos] = append(out[pos], res[pos]...)
+				dists[pos] = append(dists[pos], resDists[pos]...)
+			}
+			m.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := errgrp.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(shardNames) == 1 {
+		return out, dists, nil
+	}
+
+	for pos := range out {
+		sbd := sortObjsByDist{out[pos], dists[pos]}
+		sort.Sort(sbd)
+		if len(sbd.objects) > limit {
+			sbd.objects = sbd.objects[:limit]
+			sbd.distances = sbd.distances[:limit]
+		}
+		out[pos], dists[pos] = sbd.objects, sbd.distances
+	}
+
+	return out, dists, nil
+}
+
 func (i *Index) IncomingSearch(ctx context.Context, shardName string,
-	searchVector []float32, limit int, filters *filters.LocalFilter,
+	searchVector []float32, targetVector string, waitForIndexing bool,
+	limit int, filters *filters.LocalFilter, geoSort *filters.GeoSort,
 	additional additional.Properties) ([]*storobj.Object, []float32, error) {
 	shard, ok := i.Shards[shardName]
 	if !ok {
 		return nil, nil, errors.Errorf("shard %q does not exist locally", shardName)
 	}
 
+	if geoSort != nil {
+		res, resDists, err := shard.objectGeoSortSearch(ctx, geoSort, limit,
+			filters, additional)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "shard %s", shard.ID())
+		}
+
+		return res, resDists, nil
+	}
+
 	if searchVector == nil {
 		res, err := shard.objectSearch(ctx, limit, filters, additional)
 		if err != nil {
@@ -616,7 +810,26 @@ func (i *Index) IncomingSearch(ctx context.Context, shardName string,
 		return res, nil, nil
 	}
 
-	res, resDists, err := shard.objectVectorSearch(ctx, searchVector, limit, filters, additional)
+	res, resDists, err := shard.objectVectorSearch(ctx, searchVector,
+		targetVector, waitForIndexing, limit, filters, additional)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "shard %s", shard.ID())
+	}
+
+	return res, resDists, nil
+}
+
+func (i *Index) IncomingBatchSearch(ctx context.Context, shardName string,
+	searchVectors [][]float32, targetVector string, waitForIndexing bool,
+	limit int, filters *filters.LocalFilter,
+	additional additional.Properties) ([][]*storobj.Object, [][]float32, error) {
+	shard, ok := i.Shards[shardName]
+	if !ok {
+		return nil, nil, errors.Errorf("shard %q does not exist locally", shardName)
+	}
+
+	res, resDists, err := shard.objectVectorSearchBatch(ctx, searchVectors,
+		targetVector, waitForIndexing, limit, filters, additional)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "shard %s", shard.ID())
 	}
@@ -46,10 +46,17 @@ func (d *DB) init(ctx context.Context) error {
 			}
 
 			idx, err := NewIndex(ctx, IndexConfig{
-				ClassName: schema.ClassName(class.Class),
-				RootPath:  d.config.RootPath,
+				ClassName:              schema.ClassName(class.Class),
+				RootPath:               d.config.RootPath,
+				VectorCacheBudget:      d.vectorCacheBudget,
+				AsyncVectorIndexing:    d.config.AsyncVectorIndexing,
+				CompressObjectSegments: d.config.CompressObjectSegments,
+				CompactionPolicy:       d.config.CompactionPolicy,
+				CompactionLimiter:      d.compactionLimiter,
+				WALSyncPolicy:          d.config.WALSyncPolicy,
 			}, d.schemaGetter.ShardingState(class.Class), invertedConfig,
 				class.VectorIndexConfig.(schema.VectorIndexConfig),
+				schema.NamedVectorIndexConfigs(class),
 				d.schemaGetter, d, d.logger, d.nodeResolver, d.remoteClient)
 			if err != nil {
 				return errors.Wrap(err, "create index")
@@ -25,15 +25,17 @@ type propValuePair struct {
 	operator filters.Operator
 
 	// set for all values that can be served by an inverted index, i.e. anything
-	// that's not a geoRange
+	// that's not a geo filter
 	value []byte
 
-	// only set if operator=OperatorWithinGeoRange, as that cannot be served by a
-	// byte value from an inverted index
-	valueGeoRange *filters.GeoRange
-	hasFrequency  bool
-	docIDs        docPointers
-	children      []*propValuePair
+	// only one of them is set if operator.OnGeo(), as those cannot be served by
+	// a byte value from an inverted index
+	valueGeoRange       *filters.GeoRange
+	valueGeoBoundingBox *filters.GeoBoundingBox
+	valueGeoPolygon     *filters.GeoPolygon
+	hasFrequency        bool
+	docIDs              docPointers
+	children            []*propValuePair
 }
 
 func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
@@ -47,8 +49,8 @@ func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
 			pv.hasFrequency = false
 		}
 		b := s.store.Bucket(id)
-		if b == nil && pv.operator != filters.OperatorWithinGeoRange {
-			// a nil bucket is ok for a WithinGeoRange filter, as this query is not
+		if b == nil && !pv.operator.OnGeo() {
+			// a nil bucket is ok for a geo filter, as this query is not
 			// served by the inverted index, but propagated to a secondary index in
 			// .docPointers()
 			return errors.Errorf("bucket for prop %s not found - is it indexed?", pv.prop)
@@ -60,6 +62,20 @@ func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
 		}
 
 		pv.docIDs = pointers
+	} else if pv.operator == filters.OperatorNot {
+		// there is no merge of doc pointers for a negation, it is calculated on
+		// bitmaps instead
+		bm, err := pv.docBitmap(s)
+		if err != nil {
+			return err
+		}
+
+		pv.docIDs = pointersFromBitmap(bm)
+		checksum, err := docPointerChecksum(bm.ToArray())
+		if err != nil {
+			return errors.Wrap(err, "calculate checksum")
+		}
+		pv.docIDs.checksum = checksum
 	} else {
 		for i, child := range pv.children {
 			// Explicitly set the limit to 0 (=unlimited) as this is a nested filter,
@@ -79,7 +95,7 @@ func (pv *propValuePair) fetchDocIDs(s *Searcher, limit int,
 // if duplicates are acceptable, simpler (and faster) algorithms can be used
 // for merging
 func (pv *propValuePair) mergeDocIDs(acceptDuplicates bool) (*docPointers, error) {
-	if pv.operator.OnValue() {
+	if pv.operator.OnValue() || pv.operator == filters.OperatorNot {
 		return &pv.docIDs, nil
 	}
 
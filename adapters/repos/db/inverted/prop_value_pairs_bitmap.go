@@ -0,0 +1,177 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"encoding/binary"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/entities/filters"
+)
+
+// docBitmap resolves the filter into a bitmap of matching doc ids. As opposed
+// to fetchDocIDs and mergeDocIDs no intermediary lists of doc pointers are
+// built for roaring set buckets and And, Or and Not are calculated as bitmap
+// operations.
+func (pv *propValuePair) docBitmap(s *Searcher) (*roaring64.Bitmap, error) {
+	if pv.operator.OnValue() {
+		return pv.docBitmapOnValue(s)
+	}
+
+	children := make([]*roaring64.Bitmap, len(pv.children))
+	for i, child := range pv.children {
+		bm, err := child.docBitmap(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "nested child %d", i)
+		}
+		children[i] = bm
+	}
+
+	switch pv.operator {
+	case filters.OperatorAnd:
+		if len(children) == 0 {
+			return roaring64.New(), nil
+		}
+
+		out := children[0]
+		for _, bm := range children[1:] {
+			out.And(bm)
+		}
+		return out, nil
+	case filters.OperatorOr:
+		out := roaring64.New()
+		for _, bm := range children {
+			out.Or(bm)
+		}
+		return out, nil
+	case filters.OperatorNot:
+		if len(children) != 1 {
+			return nil, errors.Errorf("operator %s requires exactly one operand, got %d",
+				pv.operator.Name(), len(children))
+		}
+
+		out, err := s.allDocIDs()
+		if err != nil {
+			return nil, errors.Wrap(err, "retrieve all doc ids")
+		}
+
+		out.AndNot(children[0])
+		return out, nil
+	default:
+		return nil, errors.Errorf("unsupported operator: %s", pv.operator.Name())
+	}
+}
+
+func (pv *propValuePair) docBitmapOnValue(s *Searcher) (*roaring64.Bitmap, error) {
+	if pv.operator.OnGeo() {
+		// served by the geo index, not by the inverted index
+		pointers, err := s.docPointersGeo(pv)
+		if err != nil {
+			return nil, err
+		}
+
+		return bitmapFromPointers(pointers), nil
+	}
+
+	if pv.prop == "id" {
+		// the user-specified ID prop has a special internal name
+		pv.prop = helpers.PropertyNameID
+		pv.hasFrequency = false
+	}
+
+	bucketName := helpers.BucketFromPropNameLSM(pv.prop)
+	b := s.store.Bucket(bucketName)
+	if b == nil {
+		return nil, errors.Errorf("bucket for prop %s not found - is it indexed?", pv.prop)
+	}
+
+	if !pv.hasFrequency && b.Strategy() == lsmkv.StrategyRoaringSet {
+		return s.docBitmapInvertedRoaringSet(b, pv)
+	}
+
+	pointers, err := s.docPointersInverted(bucketName, b, -1, pv, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return bitmapFromPointers(pointers), nil
+}
+
+// allDocIDs is the universe used for negations. Every object has an entry in
+// the internal id property, so the union of all its rows contains every doc
+// id which is currently in use. The rows are read on the first negation of
+// the query only, every call returns a copy the caller may modify.
+func (s *Searcher) allDocIDs() (*roaring64.Bitmap, error) {
+	if s.universe == nil {
+		all, err := s.readAllDocIDs()
+		if err != nil {
+			return nil, err
+		}
+		s.universe = all
+	}
+
+	return s.universe.Clone(), nil
+}
+
+func (s *Searcher) readAllDocIDs() (*roaring64.Bitmap, error) {
+	b := s.store.Bucket(helpers.BucketFromPropNameLSM(helpers.PropertyNameID))
+	if b == nil {
+		return nil, errors.Errorf("bucket for internal prop %s not found",
+			helpers.PropertyNameID)
+	}
+
+	out := roaring64.New()
+	if b.Strategy() == lsmkv.StrategyRoaringSet {
+		c := b.CursorRoaringSet()
+		defer c.Close()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			out.Or(v)
+		}
+
+		return out, nil
+	}
+
+	c := b.SetCursor()
+	defer c.Close()
+
+	for k, ids := c.First(); k != nil; k, ids = c.Next() {
+		for _, id := range ids {
+			out.Add(binary.LittleEndian.Uint64(id))
+		}
+	}
+
+	return out, nil
+}
+
+func bitmapFromPointers(pointers docPointers) *roaring64.Bitmap {
+	out := roaring64.New()
+	for _, p := range pointers.docIDs {
+		out.Add(p.id)
+	}
+	return out
+}
+
+func pointersFromBitmap(bm *roaring64.Bitmap) docPointers {
+	ids := bm.ToArray()
+	out := docPointers{
+		count:  uint64(len(ids)),
+		docIDs: make([]docPointer, len(ids)),
+	}
+	for i, id := range ids {
+		out.docIDs[i].id = id
+	}
+	return out
+}
@@ -14,6 +14,7 @@ package inverted
 import (
 	"context"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
@@ -47,7 +48,7 @@ func (pv *propValuePair) fetchHashes(s *Searcher) error {
 
 		bucketName := helpers.HashBucketFromPropNameLSM(pv.prop)
 		b := s.store.Bucket(bucketName)
-		if b == nil && pv.operator != filters.OperatorWithinGeoRange {
+		if b == nil && !pv.operator.OnGeo() {
 			return errors.Errorf("hash bucket for prop %s not found - is it indexed?", pv.prop)
 		}
 
@@ -87,7 +88,7 @@ func (pv *propValuePair) hashForNonEqualOp(store *lsmkv.Store,
 	hashBucket *lsmkv.Bucket) ([]byte, error) {
 	bucketName := helpers.BucketFromPropNameLSM(pv.prop)
 	propBucket := store.Bucket(bucketName)
-	if propBucket == nil && pv.operator != filters.OperatorWithinGeoRange {
+	if propBucket == nil && !pv.operator.OnGeo() {
 		return nil, errors.Errorf("bucket for prop %s not found - is it indexed?", pv.prop)
 	}
 
@@ -99,13 +100,22 @@ func (pv *propValuePair) hashForNonEqualOp(store *lsmkv.Store,
 
 func (pv *propValuePair) hashForNonEqualOpWithoutFrequency(propBucket,
 	hashBucket *lsmkv.Bucket) ([]byte, error) {
-	rr := NewRowReader(propBucket, pv.value, pv.operator, true)
-
 	var keys [][]byte
-	if err := rr.Read(context.TODO(), func(k []byte, ids [][]byte) (bool, error) {
-		keys = append(keys, k)
-		return true, nil
-	}); err != nil {
+	var err error
+	if propBucket.Strategy() == lsmkv.StrategyRoaringSet {
+		rr := NewRowReaderRoaringSet(propBucket, pv.value, pv.operator, true)
+		err = rr.Read(context.TODO(), func(k []byte, _ *roaring64.Bitmap) (bool, error) {
+			keys = append(keys, k)
+			return true, nil
+		})
+	} else {
+		rr := NewRowReader(propBucket, pv.value, pv.operator, true)
+		err = rr.Read(context.TODO(), func(k []byte, ids [][]byte) (bool, error) {
+			keys = append(keys, k)
+			return true, nil
+		})
+	}
+	if err != nil {
 		return nil, errors.Wrap(err, "read row")
 	}
 
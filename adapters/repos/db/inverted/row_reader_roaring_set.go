@@ -0,0 +1,230 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package inverted
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/notimplemented"
+	"github.com/semi-technologies/weaviate/entities/filters"
+)
+
+// RowReaderRoaringSet reads one or many row(s) depending on the specified
+// operator from a bucket with the roaring set strategy
+type RowReaderRoaringSet struct {
+	value    []byte
+	bucket   *lsmkv.Bucket
+	operator filters.Operator
+
+	keyOnly bool
+}
+
+func NewRowReaderRoaringSet(bucket *lsmkv.Bucket, value []byte,
+	operator filters.Operator, keyOnly bool) *RowReaderRoaringSet {
+	return &RowReaderRoaringSet{
+		bucket:   bucket,
+		value:    value,
+		operator: operator,
+		keyOnly:  keyOnly,
+	}
+}
+
+// ReadFnRoaringSet is called for every matching row. If the reader is keyOnly,
+// the bitmap is nil
+type ReadFnRoaringSet func(k []byte, v *roaring64.Bitmap) (bool, error)
+
+func (rr *RowReaderRoaringSet) Read(ctx context.Context, readFn ReadFnRoaringSet) error {
+	switch rr.operator {
+	case filters.OperatorEqual:
+		return rr.equal(ctx, readFn)
+	case filters.OperatorNotEqual:
+		return rr.notEqual(ctx, readFn)
+	case filters.OperatorGreaterThan:
+		return rr.greaterThan(ctx, readFn, false)
+	case filters.OperatorGreaterThanEqual:
+		return rr.greaterThan(ctx, readFn, true)
+	case filters.OperatorLessThan:
+		return rr.lessThan(ctx, readFn, false)
+	case filters.OperatorLessThanEqual:
+		return rr.lessThan(ctx, readFn, true)
+	case filters.OperatorLike:
+		return rr.like(ctx, readFn)
+	default:
+		return fmt.Errorf("operator not supported in standalone "+
+			"mode, see %s for details", notimplemented.Link)
+	}
+}
+
+func (rr *RowReaderRoaringSet) equal(ctx context.Context,
+	readFn ReadFnRoaringSet) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	v, err := rr.bucket.RoaringSetGet(rr.value)
+	if err != nil {
+		return err
+	}
+
+	_, err = readFn(rr.value, v)
+	return err
+}
+
+func (rr *RowReaderRoaringSet) greaterThan(ctx context.Context,
+	readFn ReadFnRoaringSet, allowEqual bool) error {
+	c := rr.newCursor()
+	defer c.Close()
+
+	for k, v := c.Seek(rr.value); k != nil; k, v = c.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if bytes.Equal(k, rr.value) && !allowEqual {
+			continue
+		}
+
+		continueReading, err := readFn(k, v)
+		if err != nil {
+			return err
+		}
+
+		if !continueReading {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (rr *RowReaderRoaringSet) lessThan(ctx context.Context,
+	readFn ReadFnRoaringSet, allowEqual bool) error {
+	c := rr.newCursor()
+	defer c.Close()
+
+	for k, v := c.First(); k != nil && bytes.Compare(k, rr.value) != 1; k, v = c.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if bytes.Equal(k, rr.value) && !allowEqual {
+			continue
+		}
+
+		continueReading, err := readFn(k, v)
+		if err != nil {
+			return err
+		}
+
+		if !continueReading {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (rr *RowReaderRoaringSet) notEqual(ctx context.Context,
+	readFn ReadFnRoaringSet) error {
+	c := rr.newCursor()
+	defer c.Close()
+
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if bytes.Equal(k, rr.value) {
+			continue
+		}
+
+		continueReading, err := readFn(k, v)
+		if err != nil {
+			return err
+		}
+
+		if !continueReading {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (rr *RowReaderRoaringSet) like(ctx context.Context,
+	readFn ReadFnRoaringSet) error {
+	like, err := parseLikeRegexp(rr.value)
+	if err != nil {
+		return errors.Wrapf(err, "parse like value")
+	}
+
+	c := rr.newCursor()
+	defer c.Close()
+
+	var (
+		initialK []byte
+		initialV *roaring64.Bitmap
+	)
+
+	if like.optimizable {
+		initialK, initialV = c.Seek(like.min)
+	} else {
+		initialK, initialV = c.First()
+	}
+
+	for k, v := initialK, initialV; k != nil; k, v = c.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if like.optimizable {
+			// if the query is optimizable, i.e. it doesn't start with a wildcard, we
+			// can abort once we've moved past the point where the fixed characters
+			// no longer match
+			if len(k) < len(like.min) {
+				break
+			}
+
+			if bytes.Compare(like.min, k[:len(like.min)]) == -1 {
+				break
+			}
+		}
+
+		if !like.regexp.Match(k) {
+			continue
+		}
+
+		continueReading, err := readFn(k, v)
+		if err != nil {
+			return err
+		}
+
+		if !continueReading {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (rr *RowReaderRoaringSet) newCursor() *lsmkv.CursorRoaringSet {
+	if rr.keyOnly {
+		return rr.bucket.CursorRoaringSetKeyOnly()
+	}
+
+	return rr.bucket.CursorRoaringSet()
+}
@@ -17,6 +17,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
@@ -35,6 +36,10 @@ type Searcher struct {
 	classSearcher ClassSearcher // to allow recursive searches on ref-props
 	propIndices   propertyspecific.Indices
 	deletedDocIDs DeletedDocIDChecker
+
+	// universe holds all doc ids for negations. It is read once, as a Searcher
+	// only serves a single query, see (*Searcher).allDocIDs
+	universe *roaring64.Bitmap
 }
 
 type cacher interface {
@@ -160,20 +165,17 @@ func (f *Searcher) DocIDs(ctx context.Context, filter *filters.LocalFilter,
 		}
 	}
 
-	// when building an allow list (which is a set anyway) we can skip the costly
-	// deduplication, as it doesn't matter
-	if err := pv.fetchDocIDs(f, -1, true); err != nil {
-		return nil, errors.Wrap(err, "fetch doc ids for prop/value pair")
-	}
-
-	pointers, err := pv.mergeDocIDs(true)
+	// an allow list is a set anyway, so the ids can be merged as bitmaps
+	// without building any intermediary lists
+	bm, err := pv.docBitmap(f)
 	if err != nil {
-		return nil, errors.Wrap(err, "merge doc ids by operator")
+		return nil, errors.Wrap(err, "fetch and merge doc ids for prop/value pair")
 	}
 
-	out := make(helpers.AllowList, len(pointers.docIDs))
-	for _, p := range pointers.docIDs {
-		out.Insert(p.id)
+	ids := bm.ToArray()
+	out := make(helpers.AllowList, len(ids))
+	for _, id := range ids {
+		out.Insert(id)
 	}
 
 	if cacheable {
@@ -311,18 +313,44 @@ func (fs *Searcher) extractGeoFilter(propName string, value interface{},
 	valueType schema.DataType, operator filters.Operator) (*propValuePair, error) {
 	if valueType != schema.DataTypeGeoCoordinates {
 		return nil, fmt.Errorf("prop %q is of type geoCoordinates, it can only"+
-			"be used with geoRange filters", propName)
+			"be used with geo filters", propName)
 	}
 
-	parsed := value.(filters.GeoRange)
+	out := &propValuePair{
+		value:        nil, // not going to be served by an inverted index
+		hasFrequency: false,
+		prop:         propName,
+		operator:     operator,
+	}
 
-	return &propValuePair{
-		value:         nil, // not going to be served by an inverted index
-		valueGeoRange: &parsed,
-		hasFrequency:  false,
-		prop:          propName,
-		operator:      operator,
-	}, nil
+	switch operator {
+	case filters.OperatorWithinGeoRange:
+		parsed, ok := value.(filters.GeoRange)
+		if !ok {
+			return nil, fmt.Errorf("operator %s requires a geoRange value, got %T",
+				operator.Name(), value)
+		}
+		out.valueGeoRange = &parsed
+	case filters.OperatorWithinGeoBoundingBox:
+		parsed, ok := value.(filters.GeoBoundingBox)
+		if !ok {
+			return nil, fmt.Errorf("operator %s requires a geoBoundingBox value, got %T",
+				operator.Name(), value)
+		}
+		out.valueGeoBoundingBox = &parsed
+	case filters.OperatorWithinGeoPolygon:
+		parsed, ok := value.(filters.GeoPolygon)
+		if !ok {
+			return nil, fmt.Errorf("operator %s requires a geoPolygon value, got %T",
+				operator.Name(), value)
+		}
+		out.valueGeoPolygon = &parsed
+	default:
+		return nil, fmt.Errorf("prop %q is of type geoCoordinates, it can not be "+
+			"used with operator %s", propName, operator.Name())
+	}
+
+	return out, nil
 }
 
 func (fs *Searcher) extractIDProp(value interface{},
@@ -18,6 +18,7 @@ import (
 	"hash/crc64"
 	"math"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
@@ -26,7 +27,7 @@ import (
 
 func (fs *Searcher) docPointers(prop string, b *lsmkv.Bucket, limit int,
 	pv *propValuePair, tolerateDuplicates bool) (docPointers, error) {
-	if pv.operator == filters.OperatorWithinGeoRange {
+	if pv.operator.OnGeo() {
 		// geo props cannot be served by the inverted index and they require an
 		// external index. So, instead of trying to serve this chunk of the filter
 		// request internally, we can pass it to an external geo index
@@ -49,6 +50,10 @@ func (fs *Searcher) docPointersInverted(prop string, b *lsmkv.Bucket, limit int,
 
 func (fs *Searcher) docPointersInvertedNoFrequency(prop string, b *lsmkv.Bucket, limit int,
 	pv *propValuePair, tolerateDuplicates bool) (docPointers, error) {
+	if b.Strategy() == lsmkv.StrategyRoaringSet {
+		return fs.docPointersInvertedRoaringSet(b, limit, pv, tolerateDuplicates)
+	}
+
 	rr := NewRowReader(b, pv.value, pv.operator, false)
 
 	var pointers docPointers
@@ -91,6 +96,66 @@ func (fs *Searcher) docPointersInvertedNoFrequency(prop string, b *lsmkv.Bucket,
 	return pointers, nil
 }
 
+// docPointersInvertedRoaringSet keeps the order of the rows, i.e. the ids
+// are sorted by the indexed value first and by doc id second
+func (fs *Searcher) docPointersInvertedRoaringSet(b *lsmkv.Bucket, limit int,
+	pv *propValuePair, tolerateDuplicates bool) (docPointers, error) {
+	rr := NewRowReaderRoaringSet(b, pv.value, pv.operator, false)
+
+	var pointers docPointers
+	var hashes [][]byte
+
+	if err := rr.Read(context.TODO(), func(k []byte, v *roaring64.Bitmap) (bool, error) {
+		for _, id := range v.ToArray() {
+			pointers.docIDs = append(pointers.docIDs, docPointer{id: id})
+		}
+		pointers.count += uint64(v.GetCardinality())
+
+		hashBucket := fs.store.Bucket(helpers.HashBucketFromPropNameLSM(pv.prop))
+		if hashBucket == nil {
+			return false, errors.Errorf("no hash bucket for prop '%s' found", pv.prop)
+		}
+
+		currHash, err := hashBucket.Get(k)
+		if err != nil {
+			return false, errors.Wrap(err, "get hash")
+		}
+
+		hashes = append(hashes, currHash)
+		if limit > 0 && pointers.count >= uint64(limit) {
+			return false, nil
+		}
+
+		return true, nil
+	}); err != nil {
+		return pointers, errors.Wrap(err, "read row")
+	}
+
+	pointers.checksum = combineChecksums(hashes, pv.operator)
+	if !tolerateDuplicates {
+		pointers.removeDuplicates()
+	}
+
+	return pointers, nil
+}
+
+// docBitmapInvertedRoaringSet merges all rows matching the operator into a
+// single bitmap
+func (fs *Searcher) docBitmapInvertedRoaringSet(b *lsmkv.Bucket,
+	pv *propValuePair) (*roaring64.Bitmap, error) {
+	rr := NewRowReaderRoaringSet(b, pv.value, pv.operator, false)
+
+	out := roaring64.New()
+	if err := rr.Read(context.TODO(), func(k []byte, v *roaring64.Bitmap) (bool, error) {
+		out.Or(v)
+		return true, nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "read row")
+	}
+
+	return out, nil
+}
+
 func (fs *Searcher) docPointersInvertedFrequency(prop string, b *lsmkv.Bucket, limit int,
 	pv *propValuePair, tolerateDuplicates bool) (docPointers, error) {
 	rr := NewRowReaderFrequency(b, pv.value, pv.operator, false)
@@ -154,9 +219,19 @@ func (fs *Searcher) docPointersGeo(pv *propValuePair) (docPointers, error) {
 	}
 
 	ctx := context.TODO() // TODO: pass through instead of spawning new
-	res, err := propIndex.GeoIndex.WithinRange(ctx, *pv.valueGeoRange)
+	var res []uint64
+	var err error
+	switch pv.operator {
+	case filters.OperatorWithinGeoBoundingBox:
+		res, err = propIndex.GeoIndex.WithinBoundingBox(ctx, *pv.valueGeoBoundingBox)
+	case filters.OperatorWithinGeoPolygon:
+		res, err = propIndex.GeoIndex.WithinPolygon(ctx, *pv.valueGeoPolygon)
+	default:
+		res, err = propIndex.GeoIndex.WithinRange(ctx, *pv.valueGeoRange)
+	}
 	if err != nil {
-		return out, errors.Wrapf(err, "geo index range search on prop %q", pv.prop)
+		return out, errors.Wrapf(err, "geo index %s search on prop %q",
+			pv.operator.Name(), pv.prop)
 	}
 
 	out.docIDs = make([]docPointer, len(res))
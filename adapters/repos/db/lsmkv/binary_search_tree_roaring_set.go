@@ -0,0 +1,128 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"bytes"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
+)
+
+type binarySearchTreeRoaringSet struct {
+	root *binarySearchNodeRoaringSet
+}
+
+// insert applies the change to the layer of the key. The bitmaps of the
+// change are never retained, so the caller is free to reuse them
+func (t *binarySearchTreeRoaringSet) insert(key []byte,
+	change roaringset.BitmapLayer) {
+	if t.root == nil {
+		t.root = newBinarySearchNodeRoaringSet(key, change)
+		return
+	}
+
+	t.root.insert(key, change)
+}
+
+func (t *binarySearchTreeRoaringSet) get(key []byte) (roaringset.BitmapLayer, error) {
+	if t.root == nil {
+		return roaringset.BitmapLayer{}, NotFound
+	}
+
+	return t.root.get(key)
+}
+
+func (t *binarySearchTreeRoaringSet) flattenInOrder() []*binarySearchNodeRoaringSet {
+	if t.root == nil {
+		return nil
+	}
+
+	return t.root.flattenInOrder()
+}
+
+type binarySearchNodeRoaringSet struct {
+	key   []byte
+	value roaringset.BitmapLayer
+	left  *binarySearchNodeRoaringSet
+	right *binarySearchNodeRoaringSet
+}
+
+func newBinarySearchNodeRoaringSet(key []byte,
+	change roaringset.BitmapLayer) *binarySearchNodeRoaringSet {
+	n := &binarySearchNodeRoaringSet{
+		key:   key,
+		value: roaringset.NewBitmapLayer(),
+	}
+	n.value.Apply(change)
+	return n
+}
+
+func (n *binarySearchNodeRoaringSet) insert(key []byte,
+	change roaringset.BitmapLayer) {
+	if bytes.Equal(key, n.key) {
+		n.value.Apply(change)
+		return
+	}
+
+	if bytes.Compare(key, n.key) < 0 {
+		if n.left != nil {
+			n.left.insert(key, change)
+			return
+		}
+
+		n.left = newBinarySearchNodeRoaringSet(key, change)
+		return
+	}
+
+	if n.right != nil {
+		n.right.insert(key, change)
+		return
+	}
+
+	n.right = newBinarySearchNodeRoaringSet(key, change)
+}
+
+func (n *binarySearchNodeRoaringSet) get(key []byte) (roaringset.BitmapLayer, error) {
+	if bytes.Equal(n.key, key) {
+		return n.value, nil
+	}
+
+	if bytes.Compare(key, n.key) < 0 {
+		if n.left == nil {
+			return roaringset.BitmapLayer{}, NotFound
+		}
+
+		return n.left.get(key)
+	}
+
+	if n.right == nil {
+		return roaringset.BitmapLayer{}, NotFound
+	}
+
+	return n.right.get(key)
+}
+
+func (n *binarySearchNodeRoaringSet) flattenInOrder() []*binarySearchNodeRoaringSet {
+	var left []*binarySearchNodeRoaringSet
+	var right []*binarySearchNodeRoaringSet
+
+	if n.left != nil {
+		left = n.left.flattenInOrder()
+	}
+
+	if n.right != nil {
+		right = n.right.flattenInOrder()
+	}
+
+	right = append([]*binarySearchNodeRoaringSet{n}, right...)
+	return append(left, right...)
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
 	"github.com/sirupsen/logrus"
 )
 
@@ -34,10 +35,26 @@ type Bucket struct {
 	// normal operation
 	flushLock sync.RWMutex
 
+	// switchLock is held for the entire duration of a FlushAndSwitch, so while
+	// it is held there is no memtable in the middle of being flushed. It must
+	// be obtained before the flushLock.
+	switchLock sync.Mutex
+
 	memTableThreshold uint64
 	strategy          string
 	secondaryIndices  uint16
 
+	// blockCompression controls whether new segments (flushes and compactions)
+	// are written block-compressed. Existing segments are read in whatever
+	// format they were written in.
+	blockCompression bool
+
+	compactionPolicy  CompactionPolicy
+	compactionLimiter *CompactionRateLimiter
+
+	// walSyncPolicy decides when the WAL of the active memtable is fsynced
+	walSyncPolicy durability.Policy
+
 	stopFlushCycle chan struct{}
 }
 
@@ -50,14 +67,8 @@ func NewBucket(ctx context.Context, dir string, logger logrus.FieldLogger,
 		return nil, err
 	}
 
-	sg, err := newSegmentGroup(dir, 3*time.Second, logger)
-	if err != nil {
-		return nil, errors.Wrap(err, "init disk segments")
-	}
-
 	b := &Bucket{
 		dir:               dir,
-		disk:              sg,
 		memTableThreshold: defaultThreshold,
 		strategy:          defaultStrategy,
 		stopFlushCycle:    make(chan struct{}),
@@ -70,6 +81,19 @@ func NewBucket(ctx context.Context, dir string, logger logrus.FieldLogger,
 		}
 	}
 
+	// the options need to be applied first, as the segment group starts its
+	// compaction cycle right away
+	sg, err := newSegmentGroup(dir, 3*time.Second, logger, segmentGroupConfig{
+		compressed:        b.blockCompression,
+		compactionPolicy:  b.compactionPolicy,
+		compactionLimiter: b.compactionLimiter,
+		syncSegments:      b.walSyncPolicy.SyncsFiles(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "init disk segments")
+	}
+	b.disk = sg
+
 	if err := b.setNewActiveMemtable(); err != nil {
 		return nil, err
 	}
@@ -128,6 +152,42 @@ func (b *Bucket) Get(key []byte) ([]byte, error) {
 	return b.disk.get(key)
 }
 
+// Count returns the number of keys of a bucket with the replace strategy.
+// The segments are not read, they keep their share of the count, see
+// segment_count.go. Only the keys of the memtables are looked up in the
+// segments.
+func (b *Bucket) Count() (int64, error) {
+	if b.strategy != StrategyReplace {
+		return 0, errors.Errorf("count only possible with strategy %q",
+			StrategyReplace)
+	}
+
+	b.flushLock.RLock()
+	defer b.flushLock.RUnlock()
+
+	return b.disk.count(func(onDisk existsFn) (int64, error) {
+		var count int64
+		below := onDisk
+
+		if b.flushing != nil {
+			additions, err := b.flushing.countNetAdditions(onDisk)
+			if err != nil {
+				return 0, errors.Wrap(err, "flushing memtable")
+			}
+
+			count += additions
+			below = b.flushing.existsOr(onDisk)
+		}
+
+		additions, err := b.active.countNetAdditions(below)
+		if err != nil {
+			return 0, errors.Wrap(err, "active memtable")
+		}
+
+		return count + additions, nil
+	})
+}
+
 func (b *Bucket) GetBySecondary(pos int, key []byte) ([]byte, error) {
 	b.flushLock.RLock()
 	defer b.flushLock.RUnlock()
@@ -343,7 +403,8 @@ func (b *Bucket) Delete(key []byte, opts ...SecondaryKeyOption) error {
 // lock on its own
 func (b *Bucket) setNewActiveMemtable() error {
 	mt, err := newMemtable(filepath.Join(b.dir, fmt.Sprintf("segment-%d",
-		time.Now().UnixNano())), b.strategy, b.secondaryIndices)
+		time.Now().UnixNano())), b.strategy, b.secondaryIndices,
+		b.blockCompression, b.walSyncPolicy)
 	if err != nil {
 		return err
 	}
@@ -413,6 +474,9 @@ func (b *Bucket) initFlushCycle() {
 // calling, but there are some situations where this might be intended, such as
 // in test scenarios or when a force flush is desired.
 func (b *Bucket) FlushAndSwitch() error {
+	b.switchLock.Lock()
+	defer b.switchLock.Unlock()
+
 	before := time.Now()
 
 	b.logger.WithField("action", "lsm_memtable_flush_start").
@@ -447,6 +511,12 @@ func (b *Bucket) atomicallyAddDiskSegmentAndRemoveFlushing() error {
 	b.flushLock.Lock()
 	defer b.flushLock.Unlock()
 
+	if b.flushing.Size() == 0 {
+		// an empty memtable is not written to disk, there is no segment to add
+		b.flushing = nil
+		return nil
+	}
+
 	path := b.flushing.path
 	if err := b.disk.add(path + ".db"); err != nil {
 		return err
@@ -468,12 +538,25 @@ func (b *Bucket) Strategy() string {
 	return b.strategy
 }
 
+// CompactionDebt estimates how much compaction work is pending on the disk
+// segments of this bucket
+func (b *Bucket) CompactionDebt() CompactionDebt {
+	return b.disk.compactionDebt()
+}
+
+// Scrub verifies all disk segments of this bucket against their checksums,
+// see SegmentGroup.scrub for details. The memtables are not part of a scrub,
+// they are protected by the WAL.
+func (b *Bucket) Scrub(quarantine bool) ([]SegmentScrubResult, error) {
+	return b.disk.scrub(quarantine)
+}
+
 // the WAL uses a buffer and isn't written until the buffer size is crossed or
 // this function explicitly called. This allows to safge unnecessary disk
 // writes in larger operations, such as batches. It is sufficient to call write
 // on the WAL just once. This does not make a batch atomic, but it guarantees
 // that the WAL is written before a successful response is returned to the
-// user.
+// user. Whether it is also fsynced depends on the WithWALSyncPolicy option.
 func (b *Bucket) WriteWAL() error {
 	b.flushLock.RLock()
 	defer b.flushLock.RUnlock()
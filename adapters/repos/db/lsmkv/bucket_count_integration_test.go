@@ -0,0 +1,118 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketCount(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer os.RemoveAll(dirName)
+
+	open := func() *Bucket {
+		b, err := NewBucket(testCtx(), dirName, nullLogger(),
+			WithStrategy(StrategyReplace))
+		require.Nil(t, err)
+		b.SetMemtableThreshold(1e9)
+		return b
+	}
+
+	b := open()
+
+	// the keys which are expected to be in the bucket
+	keys := map[int]struct{}{}
+	assertCount := func(t *testing.T) {
+		count, err := b.Count()
+		require.Nil(t, err)
+		assert.Equal(t, int64(len(keys)), count)
+	}
+
+	// writes a mix of new keys, updates and deletes, some of which delete keys
+	// that don't exist
+	write := func(t *testing.T, n int) {
+		for i := 0; i < n; i++ {
+			key := rand.Intn(500)
+			if rand.Intn(3) == 0 {
+				require.Nil(t, b.Delete([]byte(fmt.Sprintf("key-%03d", key))))
+				delete(keys, key)
+			} else {
+				require.Nil(t, b.Put([]byte(fmt.Sprintf("key-%03d", key)),
+					[]byte(fmt.Sprintf("value-%d", i))))
+				keys[key] = struct{}{}
+			}
+		}
+	}
+
+	t.Run("an empty bucket", func(t *testing.T) {
+		assertCount(t)
+	})
+
+	t.Run("only in the memtable", func(t *testing.T) {
+		write(t, 200)
+		assertCount(t)
+	})
+
+	t.Run("across segments and the memtable", func(t *testing.T) {
+		for i := 0; i < 6; i++ {
+			require.Nil(t, b.FlushAndSwitch())
+			assertCount(t)
+			write(t, 200)
+			assertCount(t)
+		}
+	})
+
+	t.Run("after compactions", func(t *testing.T) {
+		require.Nil(t, b.FlushAndSwitch())
+		for b.disk.eligbleForCompaction() {
+			require.Nil(t, b.disk.compactOnce())
+			assertCount(t)
+		}
+	})
+
+	t.Run("after a restart", func(t *testing.T) {
+		write(t, 200)
+		require.Nil(t, b.Shutdown(testCtx()))
+		b = open()
+		assertCount(t)
+	})
+
+	t.Run("after a restart without the count files", func(t *testing.T) {
+		write(t, 200)
+		require.Nil(t, b.Shutdown(testCtx()))
+
+		files, err := filepath.Glob(filepath.Join(dirName, "*.cna"))
+		require.Nil(t, err)
+		require.NotEmpty(t, files)
+		for _, file := range files {
+			require.Nil(t, os.Remove(file))
+		}
+
+		b = open()
+		assertCount(t)
+	})
+
+	require.Nil(t, b.Shutdown(testCtx()))
+}
@@ -11,14 +11,18 @@
 
 package lsmkv
 
-import "github.com/pkg/errors"
+import (
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
+)
 
 type BucketOption func(b *Bucket) error
 
 func WithStrategy(strategy string) BucketOption {
 	return func(b *Bucket) error {
 		switch strategy {
-		case StrategyReplace, StrategyMapCollection, StrategySetCollection:
+		case StrategyReplace, StrategyMapCollection, StrategySetCollection,
+			StrategyRoaringSet:
 		default:
 			return errors.Errorf("unrecognized strategy %q", strategy)
 		}
@@ -42,6 +46,50 @@ func WithSecondaryIndicies(count uint16) BucketOption {
 	}
 }
 
+// WithBlockCompression writes the data area of new disk segments as
+// individually compressed blocks. This trades some CPU on reads and writes for
+// considerably less disk usage on buckets with large, repetitive values, such
+// as objects. It can be used with every strategy and can be turned on or off
+// for an existing bucket: segments are always read in the format they were
+// written in, compactions write the format configured on the bucket.
+func WithBlockCompression() BucketOption {
+	return func(b *Bucket) error {
+		b.blockCompression = true
+		return nil
+	}
+}
+
+// WithCompactionPolicy sets the policy which decides which disk segments are
+// merged next. Without this option the SameLevelCompactionPolicy is used.
+func WithCompactionPolicy(policy CompactionPolicy) BucketOption {
+	return func(b *Bucket) error {
+		b.compactionPolicy = policy
+		return nil
+	}
+}
+
+// WithCompactionRateLimiter throttles the disk writes of compactions. Share
+// the same limiter across buckets to limit the compaction IO of all of them
+// combined.
+func WithCompactionRateLimiter(limiter *CompactionRateLimiter) BucketOption {
+	return func(b *Bucket) error {
+		b.compactionLimiter = limiter
+		return nil
+	}
+}
+
+// WithWALSyncPolicy decides when the WAL is fsynced and thus whether a write
+// acknowledged by WriteWAL survives a power loss, see the durability package.
+// Without this option the WAL is never fsynced explicitly. With any other
+// policy, disk segments are fsynced before they replace the WAL or the
+// segments they were compacted from.
+func WithWALSyncPolicy(policy durability.Policy) BucketOption {
+	return func(b *Bucket) error {
+		b.walSyncPolicy = policy
+		return nil
+	}
+}
+
 type secondaryIndexKeys [][]byte
 
 type SecondaryKeyOption func(s secondaryIndexKeys) error
@@ -0,0 +1,74 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"github.com/RoaringBitmap/roaring/roaring64"
+)
+
+// RoaringSetGet returns the current bitmap for the key. If the key does not
+// exist an empty bitmap is returned. The returned bitmap is owned by the
+// caller and can be modified freely.
+func (b *Bucket) RoaringSetGet(key []byte) (*roaring64.Bitmap, error) {
+	b.flushLock.RLock()
+	defer b.flushLock.RUnlock()
+
+	layers, err := b.disk.roaringSetGet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.flushing != nil {
+		layer, err := b.flushing.roaringSetGet(key)
+		if err != nil && err != NotFound {
+			return nil, err
+		}
+		if err == nil {
+			layers = append(layers, layer)
+		}
+	}
+
+	layer, err := b.active.roaringSetGet(key)
+	if err != nil && err != NotFound {
+		return nil, err
+	}
+	if err == nil {
+		layers = append(layers, layer)
+	}
+
+	return layers.Flatten(), nil
+}
+
+func (b *Bucket) RoaringSetAddOne(key []byte, value uint64) error {
+	return b.RoaringSetAddList(key, []uint64{value})
+}
+
+func (b *Bucket) RoaringSetAddList(key []byte, values []uint64) error {
+	b.flushLock.RLock()
+	defer b.flushLock.RUnlock()
+
+	return b.active.roaringSetAddList(key, values)
+}
+
+func (b *Bucket) RoaringSetAddBitmap(key []byte, bm *roaring64.Bitmap) error {
+	b.flushLock.RLock()
+	defer b.flushLock.RUnlock()
+
+	return b.active.roaringSetAddBitmap(key, bm)
+}
+
+func (b *Bucket) RoaringSetRemoveOne(key []byte, value uint64) error {
+	b.flushLock.RLock()
+	defer b.flushLock.RUnlock()
+
+	return b.active.roaringSetRemoveList(key, []uint64{value})
+}
@@ -0,0 +1,117 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// BucketStats describes how much memory and disk space a bucket currently
+// occupies
+type BucketStats struct {
+	Strategy string
+
+	// Segments are the disk segments from old to new
+	Segments     []SegmentStats
+	SegmentBytes int64
+
+	// MemtableBytes and WALBytes include the memtable which is currently being
+	// flushed, if any. WALBytes is what has been written to disk, the write
+	// buffers are not included.
+	MemtableBytes int64
+	WALBytes      int64
+
+	// BloomFilterBytes is the memory held by the bloom filters of the primary
+	// and secondary indexes of all segments
+	BloomFilterBytes int64
+
+	CompactionDebt CompactionDebt
+}
+
+type SegmentStats struct {
+	Level uint16
+	Size  int64
+}
+
+// Stats returns a snapshot of the current size of the bucket. The memtables
+// and the segments are measured one after another, a flush which completes
+// in between can be counted twice or not at all.
+func (b *Bucket) Stats() (BucketStats, error) {
+	out := BucketStats{Strategy: b.strategy}
+
+	if err := b.memtableStats(&out); err != nil {
+		return out, err
+	}
+
+	b.disk.stats(&out)
+	out.CompactionDebt = b.disk.compactionDebt()
+	return out, nil
+}
+
+func (b *Bucket) memtableStats(out *BucketStats) error {
+	b.flushLock.RLock()
+	defer b.flushLock.RUnlock()
+
+	for _, memtable := range []*Memtable{b.active, b.flushing} {
+		if memtable == nil {
+			continue
+		}
+
+		out.MemtableBytes += int64(memtable.Size())
+
+		// the commit log of a flushing memtable is closed and deleted
+		// concurrently, so it is measured by its path rather than its file
+		size, err := fileSize(memtable.commitlog.path)
+		if err != nil {
+			return errors.Wrap(err, "commit log")
+		}
+		out.WALBytes += size
+	}
+
+	return nil
+}
+
+func (ig *SegmentGroup) stats(out *BucketStats) {
+	ig.maintenanceLock.RLock()
+	defer ig.maintenanceLock.RUnlock()
+
+	out.Segments = make([]SegmentStats, len(ig.segments))
+	for i, seg := range ig.segments {
+		size := int64(len(seg.contents))
+		out.Segments[i] = SegmentStats{Level: seg.level, Size: size}
+		out.SegmentBytes += size
+
+		if seg.bloomFilter != nil {
+			out.BloomFilterBytes += int64(seg.bloomFilter.Cap() / 8)
+		}
+		for _, bf := range seg.secondaryBloomFilters {
+			if bf != nil {
+				out.BloomFilterBytes += int64(bf.Cap() / 8)
+			}
+		}
+	}
+}
+
+// fileSize returns 0 for a file that does not exist (anymore)
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
@@ -0,0 +1,83 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketStats(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer os.RemoveAll(dirName)
+
+	b, err := NewBucket(testCtx(), dirName, nullLogger(),
+		WithStrategy(StrategyReplace))
+	require.Nil(t, err)
+	defer b.Shutdown(testCtx())
+	b.SetMemtableThreshold(1e9)
+
+	put := func(from, to int) {
+		for i := from; i < to; i++ {
+			require.Nil(t, b.Put([]byte(fmt.Sprintf("key-%03d", i)),
+				[]byte(fmt.Sprintf("value-%03d", i))))
+		}
+	}
+
+	t.Run("an empty bucket", func(t *testing.T) {
+		stats, err := b.Stats()
+		require.Nil(t, err)
+
+		assert.Equal(t, StrategyReplace, stats.Strategy)
+		assert.Len(t, stats.Segments, 0)
+		assert.Equal(t, int64(0), stats.SegmentBytes)
+		assert.Equal(t, int64(0), stats.MemtableBytes)
+		assert.Equal(t, int64(0), stats.BloomFilterBytes)
+	})
+
+	t.Run("with two segments and a commit log", func(t *testing.T) {
+		put(0, 50)
+		require.Nil(t, b.FlushAndSwitch())
+		put(50, 100)
+		require.Nil(t, b.FlushAndSwitch())
+		put(100, 110)
+		require.Nil(t, b.WriteWAL())
+
+		stats, err := b.Stats()
+		require.Nil(t, err)
+
+		require.Len(t, stats.Segments, 2)
+		assert.Equal(t, stats.Segments[0].Size+stats.Segments[1].Size,
+			stats.SegmentBytes)
+		for _, seg := range stats.Segments {
+			assert.Equal(t, uint16(0), seg.Level)
+			assert.True(t, seg.Size > 0)
+		}
+
+		// 10 keys and values of 7 and 9 bytes each
+		assert.Equal(t, int64(160), stats.MemtableBytes)
+		assert.True(t, stats.WALBytes > stats.MemtableBytes)
+		assert.True(t, stats.BloomFilterBytes > 0)
+		assert.Equal(t, 1, stats.CompactionDebt.PendingCompactions)
+	})
+}
@@ -17,12 +17,14 @@ import (
 	"os"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
 )
 
 type commitLogger struct {
 	file   *os.File
 	writer *bufio.Writer
 	path   string
+	syncer *durability.Syncer
 
 	// e.g. when recovering from an existing log, we do not want to write into a
 	// new log again
@@ -37,9 +39,14 @@ const (
 	// collection strategy - this can handle all cases as updates and deletes are
 	// only appends in a collection strategy
 	CommitTypeCollection
+
+	// roaring set strategy - each entry contains the additions and deletions
+	// for a single key
+	CommitTypeRoaringSet
 )
 
-func newCommitLogger(path string) (*commitLogger, error) {
+func newCommitLogger(path string,
+	syncPolicy durability.Policy) (*commitLogger, error) {
 	out := &commitLogger{
 		path: path + ".wal",
 	}
@@ -50,6 +57,7 @@ func newCommitLogger(path string) (*commitLogger, error) {
 	}
 
 	out.file = f
+	out.syncer = durability.NewSyncer(syncPolicy, f)
 
 	out.writer = bufio.NewWriter(f)
 	return out, nil
@@ -89,6 +97,22 @@ func (cl *commitLogger) append(node segmentCollectionNode) error {
 	return nil
 }
 
+func (cl *commitLogger) roaringSet(node segmentRoaringSetNode) error {
+	if cl.paused {
+		return nil
+	}
+
+	if err := binary.Write(cl.writer, binary.LittleEndian, CommitTypeRoaringSet); err != nil {
+		return err
+	}
+
+	if _, err := node.KeyIndexAndWriteTo(cl.writer); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (cl *commitLogger) close() error {
 	if cl.paused {
 		return errors.Errorf("attempting to close a paused commit logger")
@@ -98,6 +122,10 @@ func (cl *commitLogger) close() error {
 		return err
 	}
 
+	if err := cl.syncer.Close(); err != nil {
+		return err
+	}
+
 	return cl.file.Close()
 }
 
@@ -116,3 +144,21 @@ func (cl *commitLogger) delete() error {
 func (cl *commitLogger) flushBuffers() error {
 	return cl.writer.Flush()
 }
+
+// commit makes everything written to the file so far as durable as the sync
+// policy promises, the buffers must have been flushed before. It does not
+// need the memtable lock, concurrent commits share an fsync.
+func (cl *commitLogger) commit() error {
+	return cl.syncer.Commit()
+}
+
+// size is the number of bytes written to the file so far, i.e. excluding
+// the buffer
+func (cl *commitLogger) size() (int64, error) {
+	info, err := cl.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
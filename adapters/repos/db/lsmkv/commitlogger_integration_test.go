@@ -0,0 +1,288 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tornWriteScenario struct {
+	name     string
+	strategy string
+	write    func(b *Bucket, i int) error
+	contains func(t *testing.T, b *Bucket, i int) bool
+}
+
+func tornWriteKey(i int) []byte {
+	return []byte(fmt.Sprintf("key-%03d", i))
+}
+
+func tornWriteValue(i int) []byte {
+	return []byte(fmt.Sprintf("value-%03d", i))
+}
+
+func tornWriteScenarios() []tornWriteScenario {
+	return []tornWriteScenario{
+		{
+			name:     "replace",
+			strategy: StrategyReplace,
+			write: func(b *Bucket, i int) error {
+				return b.Put(tornWriteKey(i), tornWriteValue(i))
+			},
+			contains: func(t *testing.T, b *Bucket, i int) bool {
+				v, err := b.Get(tornWriteKey(i))
+				require.Nil(t, err)
+				if v == nil {
+					return false
+				}
+				assert.Equal(t, tornWriteValue(i), v)
+				return true
+			},
+		},
+		{
+			name:     "set collection",
+			strategy: StrategySetCollection,
+			write: func(b *Bucket, i int) error {
+				return b.SetAdd(tornWriteKey(i), [][]byte{tornWriteValue(i)})
+			},
+			contains: func(t *testing.T, b *Bucket, i int) bool {
+				v, err := b.SetList(tornWriteKey(i))
+				require.Nil(t, err)
+				if len(v) == 0 {
+					return false
+				}
+				assert.Equal(t, [][]byte{tornWriteValue(i)}, v)
+				return true
+			},
+		},
+		{
+			name:     "map collection",
+			strategy: StrategyMapCollection,
+			write: func(b *Bucket, i int) error {
+				return b.MapSet(tornWriteKey(i), MapPair{
+					Key:   tornWriteKey(i),
+					Value: tornWriteValue(i),
+				})
+			},
+			contains: func(t *testing.T, b *Bucket, i int) bool {
+				v, err := b.MapList(tornWriteKey(i))
+				require.Nil(t, err)
+				if len(v) == 0 {
+					return false
+				}
+				require.Len(t, v, 1)
+				assert.Equal(t, tornWriteValue(i), v[0].Value)
+				return true
+			},
+		},
+		{
+			name:     "roaring set",
+			strategy: StrategyRoaringSet,
+			write: func(b *Bucket, i int) error {
+				return b.RoaringSetAddOne(tornWriteKey(i), uint64(i))
+			},
+			contains: func(t *testing.T, b *Bucket, i int) bool {
+				v, err := b.RoaringSetGet(tornWriteKey(i))
+				require.Nil(t, err)
+				if v.IsEmpty() {
+					return false
+				}
+				assert.Equal(t, []uint64{uint64(i)}, v.ToArray())
+				return true
+			},
+		},
+	}
+}
+
+// TestTornWritesAtTheEndOfTheWAL simulates a crash while the last write was
+// only partially persisted: the WAL of a bucket is cut off at every position
+// within its last record. All writes acknowledged before must be recovered,
+// the torn one must be dropped without failing the startup and the recovered
+// bucket must continue to work normally.
+func TestTornWritesAtTheEndOfTheWAL(t *testing.T) {
+	for _, scenario := range tornWriteScenarios() {
+		t.Run(scenario.name, func(t *testing.T) {
+			testTornWritesAtTheEndOfTheWAL(t, scenario)
+		})
+	}
+}
+
+func testTornWritesAtTheEndOfTheWAL(t *testing.T, scenario tornWriteScenario) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	policy := durability.Policy{Mode: durability.ModeEveryWrite}
+	opts := []BucketOption{WithStrategy(scenario.strategy), WithWALSyncPolicy(policy)}
+	acknowledged := 10
+
+	var wal []byte
+	var lastRecordStart int
+
+	t.Run("write to a bucket and capture its WAL", func(t *testing.T) {
+		originalDir := filepath.Join(dirName, "original")
+		b, err := NewBucket(testCtx(), originalDir, nullLogger(), opts...)
+		require.Nil(t, err)
+
+		// so big it effectively never triggers as part of this test
+		b.SetMemtableThreshold(1e9)
+
+		for i := 0; i < acknowledged; i++ {
+			require.Nil(t, scenario.write(b, i))
+			require.Nil(t, b.WriteWAL())
+		}
+
+		size, err := b.active.commitlog.size()
+		require.Nil(t, err)
+		lastRecordStart = int(size)
+
+		require.Nil(t, scenario.write(b, acknowledged))
+		require.Nil(t, b.WriteWAL())
+
+		// read the WAL without shutting down, as if the process had crashed
+		wal, err = ioutil.ReadFile(b.active.commitlog.path)
+		require.Nil(t, err)
+		require.Greater(t, len(wal), lastRecordStart)
+
+		require.Nil(t, b.Shutdown(testCtx()))
+	})
+
+	for cut := lastRecordStart; cut <= len(wal); cut++ {
+		dir := filepath.Join(dirName, fmt.Sprintf("cut-%d", cut))
+		require.Nil(t, os.MkdirAll(dir, 0o777))
+		require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "segment-123.wal"),
+			wal[:cut], 0o666))
+
+		b, err := NewBucket(testCtx(), dir, nullLogger(), opts...)
+		require.Nil(t, err, "recover WAL cut at %d of %d bytes", cut, len(wal))
+		b.SetMemtableThreshold(1e9)
+
+		for i := 0; i < acknowledged; i++ {
+			assert.True(t, scenario.contains(t, b, i),
+				"acknowledged write %d with WAL cut at %d", i, cut)
+		}
+
+		// only a complete last record may be recovered
+		assert.Equal(t, cut == len(wal), scenario.contains(t, b, acknowledged),
+			"last write with WAL cut at %d of %d bytes", cut, len(wal))
+
+		// the recovered bucket accepts new writes which survive a restart
+		require.Nil(t, scenario.write(b, acknowledged+1))
+		require.Nil(t, b.WriteWAL())
+		require.Nil(t, b.Shutdown(testCtx()))
+
+		b, err = NewBucket(testCtx(), dir, nullLogger(), opts...)
+		require.Nil(t, err)
+		for i := 0; i < acknowledged; i++ {
+			assert.True(t, scenario.contains(t, b, i))
+		}
+		assert.True(t, scenario.contains(t, b, acknowledged+1))
+		require.Nil(t, b.Shutdown(testCtx()))
+	}
+}
+
+func TestWALSyncPoliciesWithConcurrentWritesAndFlushes(t *testing.T) {
+	policies := []durability.Policy{
+		{Mode: durability.ModeNone},
+		{Mode: durability.ModeInterval, Interval: 5 * time.Millisecond},
+		{Mode: durability.ModeEveryWrite},
+	}
+
+	for _, policy := range policies {
+		t.Run(policy.Mode, func(t *testing.T) {
+			rand.Seed(time.Now().UnixNano())
+			dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+			os.MkdirAll(dirName, 0o777)
+			defer func() {
+				err := os.RemoveAll(dirName)
+				fmt.Println(err)
+			}()
+
+			opts := []BucketOption{
+				WithStrategy(StrategyReplace),
+				WithWALSyncPolicy(policy),
+			}
+
+			b, err := NewBucket(testCtx(), dirName, nullLogger(), opts...)
+			require.Nil(t, err)
+
+			// so big it effectively never triggers as part of this test
+			b.SetMemtableThreshold(1e9)
+
+			writers := 8
+			perWriter := 50
+			stop := make(chan struct{})
+			flushed := make(chan struct{})
+			go func() {
+				defer close(flushed)
+				for {
+					select {
+					case <-stop:
+						return
+					case <-time.After(10 * time.Millisecond):
+						assert.Nil(t, b.FlushAndSwitch())
+					}
+				}
+			}()
+
+			wg := sync.WaitGroup{}
+			for w := 0; w < writers; w++ {
+				wg.Add(1)
+				go func(w int) {
+					defer wg.Done()
+					for i := 0; i < perWriter; i++ {
+						key := tornWriteKey(w*perWriter + i)
+						if !assert.Nil(t, b.Put(key, key)) {
+							return
+						}
+						if !assert.Nil(t, b.WriteWAL()) {
+							return
+						}
+					}
+				}(w)
+			}
+			wg.Wait()
+			close(stop)
+			<-flushed
+
+			require.Nil(t, b.Shutdown(testCtx()))
+
+			b, err = NewBucket(testCtx(), dirName, nullLogger(), opts...)
+			require.Nil(t, err)
+			defer b.Shutdown(testCtx())
+
+			for i := 0; i < writers*perWriter; i++ {
+				v, err := b.Get(tornWriteKey(i))
+				require.Nil(t, err)
+				assert.Equal(t, tornWriteKey(i), v)
+			}
+		})
+	}
+}
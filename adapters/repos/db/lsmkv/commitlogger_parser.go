@@ -59,6 +59,12 @@ func (p *commitloggerParser) Do() error {
 			if err := p.parseCollectionNode(); err != nil {
 				return errors.Wrap(err, "read collection node")
 			}
+		case CommitTypeRoaringSet:
+			if err := p.parseRoaringSetNode(); err != nil {
+				return errors.Wrap(err, "read roaring set node")
+			}
+		default:
+			return errors.Errorf("unknown commit type %d", commitType)
 		}
 	}
 
@@ -92,3 +98,12 @@ func (p *commitloggerParser) parseCollectionNode() error {
 
 	return p.memtable.append(n.primaryKey, n.values)
 }
+
+func (p *commitloggerParser) parseRoaringSetNode() error {
+	n, err := ParseRoaringSetNode(p.reader)
+	if err != nil {
+		return err
+	}
+
+	return p.memtable.roaringSetApply(n.primaryKey, n.layer())
+}
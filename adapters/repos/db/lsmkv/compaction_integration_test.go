@@ -629,6 +629,51 @@ func Test_CompactionReplaceStrategy_RemoveUnnecessaryUpdates(t *testing.T) {
 	})
 }
 
+func Test_CompactionReplaceStrategy_DeletedFirstKey(t *testing.T) {
+	// the first node of the newer segment is a tombstone, it must be written
+	// to the compacted segment exactly once
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer os.RemoveAll(dirName)
+
+	bucket, err := NewBucket(testCtx(), dirName, nullLogger(), WithStrategy(StrategyReplace))
+	require.Nil(t, err)
+	defer bucket.Shutdown(testCtx())
+
+	// so big it effectively never triggers as part of this test
+	bucket.SetMemtableThreshold(1e9)
+
+	for _, key := range []string{"key-a", "key-b", "key-c"} {
+		require.Nil(t, bucket.Put([]byte(key), []byte("value")))
+	}
+	require.Nil(t, bucket.FlushAndSwitch())
+
+	require.Nil(t, bucket.Delete([]byte("key-a")))
+	require.Nil(t, bucket.Put([]byte("key-d"), []byte("value")))
+	require.Nil(t, bucket.FlushAndSwitch())
+
+	require.True(t, bucket.disk.eligbleForCompaction())
+	require.Nil(t, bucket.disk.compactOnce())
+	require.Len(t, bucket.disk.segments, 1)
+
+	var keys []string
+	c := bucket.disk.segments[0].newCursor()
+	node, err := c.firstWithAllKeys()
+	for err != NotFound {
+		if err != nil {
+			require.Equal(t, Deleted, err)
+		}
+		keys = append(keys, string(node.primaryKey))
+		node, err = c.nextWithAllKeys()
+	}
+
+	assert.Equal(t, []string{"key-a", "key-b", "key-c", "key-d"}, keys)
+
+	count, err := bucket.Count()
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
 func Test_CompactionSetStrategy(t *testing.T) {
 	size := 30
 
@@ -0,0 +1,181 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	CompactionPolicySameLevel  = "samelevel"
+	CompactionPolicySizeTiered = "sizetiered"
+)
+
+// CompactionPolicy decides which segments of a bucket are compacted next.
+// Compactions always merge two neighboring segments, as only then the newer
+// segment can take precedence over the older one without looking at any of
+// the segments in between.
+type CompactionPolicy interface {
+	// nextPair returns the position of the older of two neighboring segments
+	// which should be merged next, or -1 if there is nothing to compact
+	nextPair(segments []compactionCandidate) int
+}
+
+// compactionCandidate is the information about a disk segment a policy can
+// base its decision on
+type compactionCandidate struct {
+	level uint16
+	size  int64
+}
+
+// SameLevelCompactionPolicy merges two neighboring segments if they are on the
+// same level, starting with the lowest level. The level of a segment is the
+// number of merges it went through, so segments of one level are roughly
+// twice the size of those of the level below. Unlike leveled compaction in
+// other LSM stores there are neither size targets per level nor segments
+// partitioned by key range. This is the default policy.
+type SameLevelCompactionPolicy struct{}
+
+func (p SameLevelCompactionPolicy) nextPair(segments []compactionCandidate) int {
+	pos := -1
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i].level != segments[i+1].level {
+			continue
+		}
+
+		if pos == -1 || segments[i].level < segments[pos].level {
+			pos = i
+		}
+	}
+
+	return pos
+}
+
+// SizeTieredCompactionPolicy merges two neighboring segments if their sizes
+// are similar, regardless of how often they were compacted before. Of all
+// eligible pairs the smallest is merged first, which keeps the number of
+// segments low under heavy ingestion without rewriting large segments over
+// and over.
+type SizeTieredCompactionPolicy struct {
+	// SizeRatio is the maximum ratio between the larger and the smaller
+	// segment of a pair, defaults to 2
+	SizeRatio float64
+
+	// MinSegmentSize is the size below which all segments are considered
+	// similar, so that many small flushes are merged early, defaults to 1MB
+	MinSegmentSize int64
+
+	// MaxSegmentSize is the size after which a segment is no longer compacted,
+	// 0 means unlimited
+	MaxSegmentSize int64
+}
+
+func (p SizeTieredCompactionPolicy) nextPair(segments []compactionCandidate) int {
+	ratio := p.SizeRatio
+	if ratio <= 0 {
+		ratio = 2
+	}
+
+	minSize := p.MinSegmentSize
+	if minSize <= 0 {
+		minSize = 1024 * 1024
+	}
+
+	pos := -1
+	var smallest int64
+	for i := 0; i < len(segments)-1; i++ {
+		small, large := segments[i].size, segments[i+1].size
+		if small > large {
+			small, large = large, small
+		}
+
+		if p.MaxSegmentSize > 0 && large >= p.MaxSegmentSize {
+			continue
+		}
+
+		if large > minSize && float64(large) > float64(small)*ratio {
+			continue
+		}
+
+		if pos == -1 || small+large < smallest {
+			pos = i
+			smallest = small + large
+		}
+	}
+
+	return pos
+}
+
+// CompactionPolicyFromName returns the policy for one of the
+// CompactionPolicy* constants with its default settings. An empty name
+// selects the default policy.
+func CompactionPolicyFromName(name string) (CompactionPolicy, error) {
+	switch name {
+	case "", CompactionPolicySameLevel:
+		return SameLevelCompactionPolicy{}, nil
+	case CompactionPolicySizeTiered:
+		return SizeTieredCompactionPolicy{}, nil
+	default:
+		return nil, errors.Errorf("unrecognized compaction policy %q", name)
+	}
+}
+
+// CompactionDebt is the work a bucket's compaction policy would still do if
+// no more data were written
+type CompactionDebt struct {
+	Segments int
+
+	// PendingCompactions is the number of merges until the policy finds no
+	// more candidates
+	PendingCompactions int
+
+	// PendingBytes is the number of bytes these merges need to read and
+	// write. It is an upper bound, as it assumes no data is removed when
+	// merging.
+	PendingBytes int64
+}
+
+// compactionDebt simulates the policy on the current segments, assuming that
+// a merged segment is as large as the two segments it replaces
+func compactionDebt(policy CompactionPolicy,
+	segments []compactionCandidate) CompactionDebt {
+	debt := CompactionDebt{Segments: len(segments)}
+
+	sim := make([]compactionCandidate, len(segments))
+	copy(sim, segments)
+
+	// every merge removes one segment, so this is guaranteed to terminate
+	for {
+		pos := policy.nextPair(sim)
+		if pos < 0 {
+			return debt
+		}
+
+		merged := compactionCandidate{
+			level: maxLevel(sim[pos].level, sim[pos+1].level) + 1,
+			size:  sim[pos].size + sim[pos+1].size,
+		}
+
+		debt.PendingCompactions++
+		debt.PendingBytes += merged.size
+
+		sim[pos+1] = merged
+		sim = append(sim[:pos], sim[pos+1:]...)
+	}
+}
+
+func maxLevel(a, b uint16) uint16 {
+	if a > b {
+		return a
+	}
+	return b
+}
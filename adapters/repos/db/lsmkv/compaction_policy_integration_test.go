@@ -0,0 +1,115 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactionPolicies(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+
+	policies := []struct {
+		name   string
+		policy CompactionPolicy
+	}{
+		{name: "same-level", policy: SameLevelCompactionPolicy{}},
+		{name: "size-tiered", policy: SizeTieredCompactionPolicy{MinSegmentSize: 1}},
+	}
+
+	for _, p := range policies {
+		t.Run(p.name, func(t *testing.T) {
+			dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+			os.MkdirAll(dirName, 0o777)
+			defer func() {
+				err := os.RemoveAll(dirName)
+				fmt.Println(err)
+			}()
+
+			// 1MB/s is low enough to be measurable, but high enough to keep the
+			// test fast
+			limiter := NewCompactionRateLimiter(1024 * 1024)
+			b, err := NewBucket(testCtx(), dirName, nullLogger(),
+				WithStrategy(StrategyReplace), WithCompactionPolicy(p.policy),
+				WithCompactionRateLimiter(limiter))
+			require.Nil(t, err)
+
+			// so big it effectively never triggers as part of this test
+			b.SetMemtableThreshold(1e9)
+
+			control := map[string]string{}
+			key := func(i int) []byte { return []byte(fmt.Sprintf("key-%05d", i)) }
+
+			// segments of different sizes, so the policies differ in their
+			// decisions
+			for round, size := range []int{800, 400, 200, 200, 100, 100, 50} {
+				for i := 0; i < size; i++ {
+					k := key(rand.Intn(1000))
+					v := fmt.Sprintf("value-%d-%d", round, i)
+					require.Nil(t, b.Put(k, []byte(v)))
+					control[string(k)] = v
+				}
+				require.Nil(t, b.FlushAndSwitch())
+			}
+
+			debt := b.CompactionDebt()
+			assert.Equal(t, 7, debt.Segments)
+			assert.Greater(t, debt.PendingCompactions, 0)
+			assert.Greater(t, debt.PendingBytes, int64(0))
+
+			before := time.Now()
+			compactions := 0
+			for b.disk.eligbleForCompaction() {
+				require.Nil(t, b.disk.compactOnce())
+				compactions++
+			}
+			took := time.Since(before)
+
+			if p.name == "same-level" {
+				// levels don't depend on the actual sizes, so the simulation is
+				// exact. With sizes it is only an estimate, as merged segments are
+				// smaller than assumed.
+				assert.Equal(t, debt.PendingCompactions, compactions,
+					"debt predicted the number of compactions")
+			} else {
+				assert.Greater(t, compactions, 0)
+			}
+			assert.Equal(t, CompactionDebt{Segments: len(b.disk.segments)},
+				b.CompactionDebt())
+
+			// the estimate is an upper bound of the bytes written, the actual
+			// segments are smaller because of overwritten keys
+			minDuration := time.Duration(float64(debt.PendingBytes)/
+				float64(1024*1024)*float64(time.Second)) / 4
+			assert.Greater(t, int64(took), int64(minDuration),
+				"compactions were throttled")
+
+			for k, v := range control {
+				res, err := b.Get([]byte(k))
+				require.Nil(t, err)
+				assert.Equal(t, []byte(v), res)
+			}
+
+			require.Nil(t, b.Shutdown(testCtx()))
+		})
+	}
+}
@@ -0,0 +1,168 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameLevelCompactionPolicy(t *testing.T) {
+	type test struct {
+		name     string
+		segments []compactionCandidate
+		expected int
+	}
+
+	tests := []test{
+		{
+			name:     "no segments",
+			expected: -1,
+		},
+		{
+			name:     "single segment",
+			segments: []compactionCandidate{{level: 0, size: 10}},
+			expected: -1,
+		},
+		{
+			name: "all levels different",
+			segments: []compactionCandidate{
+				{level: 3, size: 80}, {level: 2, size: 40}, {level: 0, size: 10},
+			},
+			expected: -1,
+		},
+		{
+			name: "lowest level is preferred",
+			segments: []compactionCandidate{
+				{level: 2, size: 40}, {level: 2, size: 40},
+				{level: 1, size: 20}, {level: 0, size: 10}, {level: 0, size: 10},
+			},
+			expected: 3,
+		},
+		{
+			name: "segments of the same level which are not neighbors",
+			segments: []compactionCandidate{
+				{level: 1, size: 20}, {level: 0, size: 10}, {level: 1, size: 20},
+			},
+			expected: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected,
+				SameLevelCompactionPolicy{}.nextPair(test.segments))
+		})
+	}
+}
+
+func TestSizeTieredCompactionPolicy(t *testing.T) {
+	policy := SizeTieredCompactionPolicy{
+		SizeRatio:      2,
+		MinSegmentSize: 100,
+		MaxSegmentSize: 10000,
+	}
+
+	type test struct {
+		name     string
+		segments []compactionCandidate
+		expected int
+	}
+
+	tests := []test{
+		{
+			name: "sizes too different",
+			segments: []compactionCandidate{
+				{size: 5000}, {size: 1000}, {size: 300},
+			},
+			expected: -1,
+		},
+		{
+			name: "small segments are always similar",
+			segments: []compactionCandidate{
+				{size: 5000}, {size: 90}, {size: 3},
+			},
+			expected: 1,
+		},
+		{
+			name: "smallest eligible pair wins, regardless of the level",
+			segments: []compactionCandidate{
+				{level: 0, size: 3000}, {level: 0, size: 2000},
+				{level: 5, size: 800}, {level: 0, size: 500},
+			},
+			expected: 2,
+		},
+		{
+			name: "segments above the max size are left alone",
+			segments: []compactionCandidate{
+				{size: 12000}, {size: 9000}, {size: 2000},
+			},
+			expected: -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, policy.nextPair(test.segments))
+		})
+	}
+}
+
+func TestCompactionDebt(t *testing.T) {
+	t.Run("nothing to do", func(t *testing.T) {
+		debt := compactionDebt(SameLevelCompactionPolicy{}, []compactionCandidate{
+			{level: 1, size: 20}, {level: 0, size: 10},
+		})
+
+		assert.Equal(t, CompactionDebt{Segments: 2}, debt)
+	})
+
+	t.Run("merges cascade to higher levels", func(t *testing.T) {
+		// 1+1 -> level 1 (2 bytes), then 2+2 -> level 2 (4 bytes), then 4+4 ->
+		// level 3 (8 bytes)
+		debt := compactionDebt(SameLevelCompactionPolicy{}, []compactionCandidate{
+			{level: 2, size: 4}, {level: 1, size: 2}, {level: 0, size: 1},
+			{level: 0, size: 1},
+		})
+
+		assert.Equal(t, CompactionDebt{
+			Segments:           4,
+			PendingCompactions: 3,
+			PendingBytes:       14,
+		}, debt)
+	})
+}
+
+func TestCompactionRateLimiter(t *testing.T) {
+	t.Run("nil and zero limits never block", func(t *testing.T) {
+		var nilLimiter *CompactionRateLimiter
+		before := time.Now()
+		nilLimiter.wait(1e9)
+		NewCompactionRateLimiter(0).wait(1e9)
+		assert.Less(t, int64(time.Since(before)), int64(100*time.Millisecond))
+	})
+
+	t.Run("writes are spread according to the rate", func(t *testing.T) {
+		l := NewCompactionRateLimiter(1000)
+		before := time.Now()
+		for i := 0; i < 4; i++ {
+			l.wait(50)
+		}
+
+		// 200 bytes at 1000 bytes/s
+		took := time.Since(before)
+		assert.GreaterOrEqual(t, int64(took), int64(190*time.Millisecond))
+		assert.Less(t, int64(took), int64(2*time.Second))
+	})
+}
@@ -0,0 +1,78 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// CompactionRateLimiter limits the rate at which compactions write to disk.
+// A single limiter is meant to be shared by all buckets of a node, so that
+// the limit applies globally, no matter how many buckets compact at the same
+// time.
+type CompactionRateLimiter struct {
+	bytesPerSecond int64
+
+	lock sync.Mutex
+	// next is the point in time at which all bytes written so far are paid
+	// for
+	next time.Time
+}
+
+// NewCompactionRateLimiter creates a limiter for the specified number of
+// bytes per second. 0 or less means unlimited.
+func NewCompactionRateLimiter(bytesPerSecond int64) *CompactionRateLimiter {
+	return &CompactionRateLimiter{bytesPerSecond: bytesPerSecond}
+}
+
+// wait blocks until n more bytes can be written within the limit. Calls are
+// not queued separately, concurrent writers share the rate.
+func (l *CompactionRateLimiter) wait(n int) {
+	if l == nil || l.bytesPerSecond <= 0 || n <= 0 {
+		return
+	}
+
+	l.lock.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		// unused capacity from idle periods is not carried over, otherwise a
+		// long idle period would allow an unlimited burst
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(n) * time.Second /
+		time.Duration(l.bytesPerSecond))
+	wait := l.next.Sub(now)
+	l.lock.Unlock()
+
+	time.Sleep(wait)
+}
+
+// writer wraps w, so that all writes are subject to the limit
+func (l *CompactionRateLimiter) writer(w io.WriteSeeker) io.WriteSeeker {
+	if l == nil || l.bytesPerSecond <= 0 {
+		return w
+	}
+
+	return &rateLimitedWriter{WriteSeeker: w, limiter: l}
+}
+
+type rateLimitedWriter struct {
+	io.WriteSeeker
+	limiter *CompactionRateLimiter
+}
+
+func (w *rateLimitedWriter) Write(p []byte) (int, error) {
+	w.limiter.wait(len(p))
+	return w.WriteSeeker.Write(p)
+}
@@ -33,11 +33,14 @@ type compactorReplace struct {
 	w                io.WriteSeeker
 	bufw             *bufio.Writer
 	scratchSpacePath string
+
+	// compressed controls whether the new segment is block-compressed
+	compressed bool
 }
 
 func newCompactorReplace(w io.WriteSeeker,
 	c1, c2 *segmentCursorReplace, level, secondaryIndexCount uint16,
-	scratchSpacePath string) *compactorReplace {
+	scratchSpacePath string, compressed bool) *compactorReplace {
 	return &compactorReplace{
 		c1:                  c1,
 		c2:                  c2,
@@ -46,6 +49,7 @@ func newCompactorReplace(w io.WriteSeeker,
 		currentLevel:        level,
 		secondaryIndexCount: secondaryIndexCount,
 		scratchSpacePath:    scratchSpacePath,
+		compressed:          compressed,
 	}
 }
 
@@ -54,13 +58,24 @@ func (c *compactorReplace) do() error {
 		return errors.Wrap(err, "init")
 	}
 
-	kis, err := c.writeKeys()
+	// the (dummy) header was already written, this is our initial offset
+	dw := newSegmentDataWriter(c.bufw, SegmentHeaderSize, c.compressed)
+
+	kis, dataEnd, err := c.writeKeys(dw)
 	if err != nil {
 		return errors.Wrap(err, "write keys")
 	}
 
-	if err := c.writeIndices(kis); err != nil {
-		return errors.Wrap(err, "write indices")
+	header := &segmentHeader{
+		level:            c.currentLevel + 1,
+		version:          segmentVersion(c.compressed),
+		secondaryIndices: c.secondaryIndexCount,
+		strategy:         SegmentStrategyReplace,
+		indexStart:       uint64(dataEnd),
+	}
+
+	if err := c.writeIndices(kis, header, dw.checksum()); err != nil {
+		return errors.Wrap(err, "write index")
 	}
 
 	// flush buffered, so we can safely seek on underlying writer
@@ -68,9 +83,7 @@ func (c *compactorReplace) do() error {
 		return errors.Wrap(err, "flush buffered")
 	}
 
-	dataEnd := uint64(kis[len(kis)-1].valueEnd)
-
-	if err := c.writeHeader(c.currentLevel+1, 0, c.secondaryIndexCount, dataEnd); err != nil {
+	if err := c.writeHeader(header); err != nil {
 		return errors.Wrap(err, "write header")
 	}
 
@@ -89,13 +102,10 @@ func (c *compactorReplace) init() error {
 	return nil
 }
 
-func (c *compactorReplace) writeKeys() ([]keyIndex, error) {
+func (c *compactorReplace) writeKeys(dw *segmentDataWriter) ([]keyIndex, int, error) {
 	res1, err1 := c.c1.firstWithAllKeys()
 	res2, err2 := c.c2.firstWithAllKeys()
 
-	// the (dummy) header was already written, this is our initial offset
-	offset := SegmentHeaderSize
-
 	var kis []keyIndex
 
 	for {
@@ -103,13 +113,12 @@ func (c *compactorReplace) writeKeys() ([]keyIndex, error) {
 			break
 		}
 		if bytes.Equal(res1.primaryKey, res2.primaryKey) {
-			ki, err := c.writeIndividualNode(offset, res2.primaryKey, res2.value,
+			ki, err := c.writeIndividualNode(dw, res2.primaryKey, res2.value,
 				res2.secondaryKeys, err2 == Deleted)
 			if err != nil {
-				return nil, errors.Wrap(err, "write individual node (equal keys)")
+				return nil, 0, errors.Wrap(err, "write individual node (equal keys)")
 			}
 
-			offset = ki.valueEnd
 			kis = append(kis, ki)
 
 			// advance both!
@@ -120,37 +129,39 @@ func (c *compactorReplace) writeKeys() ([]keyIndex, error) {
 
 		if (res1.primaryKey != nil && bytes.Compare(res1.primaryKey, res2.primaryKey) == -1) || res2.primaryKey == nil {
 			// key 1 is smaller
-			ki, err := c.writeIndividualNode(offset, res1.primaryKey, res1.value,
+			ki, err := c.writeIndividualNode(dw, res1.primaryKey, res1.value,
 				res1.secondaryKeys, err1 == Deleted)
 			if err != nil {
-				return nil, errors.Wrap(err, "write individual node (res1.primaryKey smaller)")
+				return nil, 0, errors.Wrap(err, "write individual node (res1.primaryKey smaller)")
 			}
 
-			offset = ki.valueEnd
 			kis = append(kis, ki)
 			res1, err1 = c.c1.nextWithAllKeys()
 		} else {
 			// key 2 is smaller
-			ki, err := c.writeIndividualNode(offset, res2.primaryKey, res2.value,
+			ki, err := c.writeIndividualNode(dw, res2.primaryKey, res2.value,
 				res2.secondaryKeys, err2 == Deleted)
 			if err != nil {
-				return nil, errors.Wrap(err, "write individual node (res2.primaryKey smaller)")
+				return nil, 0, errors.Wrap(err, "write individual node (res2.primaryKey smaller)")
 			}
 
-			offset = ki.valueEnd
 			kis = append(kis, ki)
 
 			res2, err2 = c.c2.nextWithAllKeys()
 		}
 	}
 
-	return kis, nil
+	dataEnd, err := dw.close()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "close data area")
+	}
+
+	return kis, dataEnd, nil
 }
 
-func (c *compactorReplace) writeIndividualNode(offset int, key, value []byte,
+func (c *compactorReplace) writeIndividualNode(dw *segmentDataWriter, key, value []byte,
 	secondaryKeys [][]byte, tombstone bool) (keyIndex, error) {
 	segNode := segmentReplaceNode{
-		offset:              offset,
 		tombstone:           tombstone,
 		value:               value,
 		primaryKey:          key,
@@ -158,37 +169,29 @@ func (c *compactorReplace) writeIndividualNode(offset int, key, value []byte,
 		secondaryKeys:       secondaryKeys,
 	}
 
-	return segNode.KeyIndexAndWriteTo(c.bufw)
+	return dw.write(&segNode)
 }
 
-func (c *compactorReplace) writeIndices(keys []keyIndex) error {
+func (c *compactorReplace) writeIndices(keys []keyIndex, header *segmentHeader,
+	dataChecksum uint32) error {
 	indices := &segmentIndices{
 		keys:                keys,
+		indexStart:          header.indexStart,
 		secondaryIndexCount: c.secondaryIndexCount,
 		scratchSpacePath:    c.scratchSpacePath,
 	}
 
-	_, err := indices.WriteTo(c.bufw)
-	return err
+	return writeIndicesAndChecksums(c.bufw, indices, header, dataChecksum)
 }
 
 // writeHeader assumes that everything has been written to the underlying
 // writer and it is now safe to seek to the beginning and override the initial
 // header
-func (c *compactorReplace) writeHeader(level, version, secondaryIndices uint16,
-	startOfIndex uint64) error {
+func (c *compactorReplace) writeHeader(h *segmentHeader) error {
 	if _, err := c.w.Seek(0, io.SeekStart); err != nil {
 		return errors.Wrap(err, "seek to beginning to write header")
 	}
 
-	h := &segmentHeader{
-		level:            level,
-		version:          version,
-		secondaryIndices: secondaryIndices,
-		strategy:         SegmentStrategyReplace,
-		indexStart:       startOfIndex,
-	}
-
 	if _, err := h.WriteTo(c.w); err != nil {
 		return err
 	}
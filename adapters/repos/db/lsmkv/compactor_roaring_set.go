@@ -0,0 +1,193 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
+)
+
+type compactorRoaringSet struct {
+	// c1 is always the older segment, so when there is a conflict c2 wins
+	c1 *segmentCursorRoaringSet
+	c2 *segmentCursorRoaringSet
+
+	// the level matching those of the cursors
+	currentLevel uint16
+
+	w    io.WriteSeeker
+	bufw *bufio.Writer
+
+	scratchSpacePath string
+
+	// compressed controls whether the new segment is block-compressed
+	compressed bool
+}
+
+func newCompactorRoaringSet(w io.WriteSeeker,
+	c1, c2 *segmentCursorRoaringSet, level uint16,
+	scratchSpacePath string, compressed bool) *compactorRoaringSet {
+	return &compactorRoaringSet{
+		c1:               c1,
+		c2:               c2,
+		w:                w,
+		bufw:             bufio.NewWriterSize(w, 256*1024),
+		currentLevel:     level,
+		scratchSpacePath: scratchSpacePath,
+		compressed:       compressed,
+	}
+}
+
+func (c *compactorRoaringSet) do() error {
+	if err := c.init(); err != nil {
+		return errors.Wrap(err, "init")
+	}
+
+	// the (dummy) header was already written, this is our initial offset
+	dw := newSegmentDataWriter(c.bufw, SegmentHeaderSize, c.compressed)
+
+	kis, dataEnd, err := c.writeKeys(dw)
+	if err != nil {
+		return errors.Wrap(err, "write keys")
+	}
+
+	header := &segmentHeader{
+		level:      c.currentLevel + 1,
+		version:    segmentVersion(c.compressed),
+		strategy:   SegmentStrategyRoaringSet,
+		indexStart: uint64(dataEnd),
+	}
+
+	if err := c.writeIndices(kis, header, dw.checksum()); err != nil {
+		return errors.Wrap(err, "write index")
+	}
+
+	// flush buffered, so we can safely seek on underlying writer
+	if err := c.bufw.Flush(); err != nil {
+		return errors.Wrap(err, "flush buffered")
+	}
+
+	if err := c.writeHeader(header); err != nil {
+		return errors.Wrap(err, "write header")
+	}
+
+	return nil
+}
+
+func (c *compactorRoaringSet) init() error {
+	// write a dummy header, we don't know the contents of the actual header yet,
+	// we will seek to the beginning and overwrite the actual header at the very
+	// end
+
+	if _, err := c.bufw.Write(make([]byte, SegmentHeaderSize)); err != nil {
+		return errors.Wrap(err, "write empty header")
+	}
+
+	return nil
+}
+
+func (c *compactorRoaringSet) writeKeys(dw *segmentDataWriter) ([]keyIndex, int, error) {
+	key1, layer1, _ := c.c1.first()
+	key2, layer2, _ := c.c2.first()
+
+	var kis []keyIndex
+
+	for {
+		if key1 == nil && key2 == nil {
+			break
+		}
+		if bytes.Equal(key1, key2) {
+			// deletions need to be retained, as there might be even older
+			// segments which still contain the deleted values
+			merged := roaringset.BitmapLayers{layer1, layer2}.Merge()
+
+			ki, err := c.writeIndividualNode(dw, key2, merged)
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "write individual node (equal keys)")
+			}
+
+			kis = append(kis, ki)
+
+			// advance both!
+			key1, layer1, _ = c.c1.next()
+			key2, layer2, _ = c.c2.next()
+			continue
+		}
+
+		if (key1 != nil && bytes.Compare(key1, key2) == -1) || key2 == nil {
+			// key 1 is smaller
+			ki, err := c.writeIndividualNode(dw, key1, layer1)
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "write individual node (key1 smaller)")
+			}
+
+			kis = append(kis, ki)
+			key1, layer1, _ = c.c1.next()
+		} else {
+			// key 2 is smaller
+			ki, err := c.writeIndividualNode(dw, key2, layer2)
+			if err != nil {
+				return nil, 0, errors.Wrap(err, "write individual node (key2 smaller)")
+			}
+
+			kis = append(kis, ki)
+
+			key2, layer2, _ = c.c2.next()
+		}
+	}
+
+	dataEnd, err := dw.close()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "close data area")
+	}
+
+	return kis, dataEnd, nil
+}
+
+func (c *compactorRoaringSet) writeIndividualNode(dw *segmentDataWriter, key []byte,
+	layer roaringset.BitmapLayer) (keyIndex, error) {
+	return dw.write(&segmentRoaringSetNode{
+		additions:  layer.Additions,
+		deletions:  layer.Deletions,
+		primaryKey: key,
+	})
+}
+
+func (c *compactorRoaringSet) writeIndices(keys []keyIndex, header *segmentHeader,
+	dataChecksum uint32) error {
+	indices := &segmentIndices{
+		keys:             keys,
+		indexStart:       header.indexStart,
+		scratchSpacePath: c.scratchSpacePath,
+	}
+
+	return writeIndicesAndChecksums(c.bufw, indices, header, dataChecksum)
+}
+
+// writeHeader assumes that everything has been written to the underlying
+// writer and it is now safe to seek to the beginning and override the initial
+// header
+func (c *compactorRoaringSet) writeHeader(h *segmentHeader) error {
+	if _, err := c.w.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "seek to beginning to write header")
+	}
+
+	if _, err := h.WriteTo(c.w); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -33,11 +33,14 @@ type compactorSet struct {
 	bufw *bufio.Writer
 
 	scratchSpacePath string
+
+	// compressed controls whether the new segment is block-compressed
+	compressed bool
 }
 
 func newCompactorSetCollection(w io.WriteSeeker,
 	c1, c2 *segmentCursorCollection, level, secondaryIndexCount uint16,
-	scratchSpacePath string) *compactorSet {
+	scratchSpacePath string, compressed bool) *compactorSet {
 	return &compactorSet{
 		c1:                  c1,
 		c2:                  c2,
@@ -46,6 +49,7 @@ func newCompactorSetCollection(w io.WriteSeeker,
 		currentLevel:        level,
 		secondaryIndexCount: secondaryIndexCount,
 		scratchSpacePath:    scratchSpacePath,
+		compressed:          compressed,
 	}
 }
 
@@ -54,12 +58,23 @@ func (c *compactorSet) do() error {
 		return errors.Wrap(err, "init")
 	}
 
-	kis, err := c.writeKeys()
+	// the (dummy) header was already written, this is our initial offset
+	dw := newSegmentDataWriter(c.bufw, SegmentHeaderSize, c.compressed)
+
+	kis, dataEnd, err := c.writeKeys(dw)
 	if err != nil {
 		return errors.Wrap(err, "write keys")
 	}
 
-	if err := c.writeIndices(kis); err != nil {
+	header := &segmentHeader{
+		level:            c.currentLevel + 1,
+		version:          segmentVersion(c.compressed),
+		secondaryIndices: c.secondaryIndexCount,
+		strategy:         SegmentStrategySetCollection,
+		indexStart:       uint64(dataEnd),
+	}
+
+	if err := c.writeIndices(kis, header, dw.checksum()); err != nil {
 		return errors.Wrap(err, "write index")
 	}
 
@@ -68,10 +83,7 @@ func (c *compactorSet) do() error {
 		return errors.Wrap(err, "flush buffered")
 	}
 
-	dataEnd := uint64(kis[len(kis)-1].valueEnd)
-
-	if err := c.writeHeader(c.currentLevel+1, 0, c.secondaryIndexCount,
-		dataEnd); err != nil {
+	if err := c.writeHeader(header); err != nil {
 		return errors.Wrap(err, "write header")
 	}
 
@@ -90,13 +102,10 @@ func (c *compactorSet) init() error {
 	return nil
 }
 
-func (c *compactorSet) writeKeys() ([]keyIndex, error) {
+func (c *compactorSet) writeKeys(dw *segmentDataWriter) ([]keyIndex, int, error) {
 	key1, value1, _ := c.c1.first()
 	key2, value2, _ := c.c2.first()
 
-	// the (dummy) header was already written, this is our initial offset
-	offset := SegmentHeaderSize
-
 	var kis []keyIndex
 
 	for {
@@ -107,12 +116,11 @@ func (c *compactorSet) writeKeys() ([]keyIndex, error) {
 			values := append(value1, value2...)
 			valuesMerged := newSetDecoder().DoPartial(values)
 
-			ki, err := c.writeIndividualNode(offset, key2, valuesMerged)
+			ki, err := c.writeIndividualNode(dw, key2, valuesMerged)
 			if err != nil {
-				return nil, errors.Wrap(err, "write individual node (equal keys)")
+				return nil, 0, errors.Wrap(err, "write individual node (equal keys)")
 			}
 
-			offset = ki.valueEnd
 			kis = append(kis, ki)
 
 			// advance both!
@@ -123,68 +131,62 @@ func (c *compactorSet) writeKeys() ([]keyIndex, error) {
 
 		if (key1 != nil && bytes.Compare(key1, key2) == -1) || key2 == nil {
 			// key 1 is smaller
-			ki, err := c.writeIndividualNode(offset, key1, value1)
+			ki, err := c.writeIndividualNode(dw, key1, value1)
 			if err != nil {
-				return nil, errors.Wrap(err, "write individual node (key1 smaller)")
+				return nil, 0, errors.Wrap(err, "write individual node (key1 smaller)")
 			}
 
-			offset = ki.valueEnd
 			kis = append(kis, ki)
 			key1, value1, _ = c.c1.next()
 		} else {
 			// key 2 is smaller
-			ki, err := c.writeIndividualNode(offset, key2, value2)
+			ki, err := c.writeIndividualNode(dw, key2, value2)
 			if err != nil {
-				return nil, errors.Wrap(err, "write individual node (key2 smaller)")
+				return nil, 0, errors.Wrap(err, "write individual node (key2 smaller)")
 			}
 
-			offset = ki.valueEnd
 			kis = append(kis, ki)
 
 			key2, value2, _ = c.c2.next()
 		}
 	}
 
-	return kis, nil
+	dataEnd, err := dw.close()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "close data area")
+	}
+
+	return kis, dataEnd, nil
 }
 
-func (c *compactorSet) writeIndividualNode(offset int, key []byte,
+func (c *compactorSet) writeIndividualNode(dw *segmentDataWriter, key []byte,
 	values []value) (keyIndex, error) {
-	return (&segmentCollectionNode{
+	return dw.write(&segmentCollectionNode{
 		values:     values,
 		primaryKey: key,
-		offset:     offset,
-	}).KeyIndexAndWriteTo(c.bufw)
+	})
 }
 
-func (c *compactorSet) writeIndices(keys []keyIndex) error {
+func (c *compactorSet) writeIndices(keys []keyIndex, header *segmentHeader,
+	dataChecksum uint32) error {
 	indices := &segmentIndices{
 		keys:                keys,
+		indexStart:          header.indexStart,
 		secondaryIndexCount: c.secondaryIndexCount,
 		scratchSpacePath:    c.scratchSpacePath,
 	}
 
-	_, err := indices.WriteTo(c.bufw)
-	return err
+	return writeIndicesAndChecksums(c.bufw, indices, header, dataChecksum)
 }
 
 // writeHeader assumes that everything has been written to the underlying
 // writer and it is now safe to seek to the beginning and override the initial
 // header
-func (c *compactorSet) writeHeader(level, version, secondaryIndices uint16,
-	startOfIndex uint64) error {
+func (c *compactorSet) writeHeader(h *segmentHeader) error {
 	if _, err := c.w.Seek(0, io.SeekStart); err != nil {
 		return errors.Wrap(err, "seek to beginning to write header")
 	}
 
-	h := &segmentHeader{
-		level:            level,
-		version:          version,
-		secondaryIndices: secondaryIndices,
-		strategy:         SegmentStrategySetCollection,
-		indexStart:       startOfIndex,
-	}
-
 	if _, err := h.WriteTo(c.w); err != nil {
 		return err
 	}
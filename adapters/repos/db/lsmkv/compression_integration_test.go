@@ -0,0 +1,326 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCompression_ReplaceStrategy(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	// starts out uncompressed, so that the bucket ends up with segments of
+	// both formats once compression is turned on
+	b, err := NewBucket(testCtx(), dirName, nullLogger(),
+		WithStrategy(StrategyReplace), WithSecondaryIndicies(1))
+	require.Nil(t, err)
+
+	// so big it effectively never triggers as part of this test
+	b.SetMemtableThreshold(1e9)
+
+	// large enough to be spread across many blocks
+	size := 2000
+	control := map[string][]byte{}
+
+	key := func(i int) []byte { return []byte(fmt.Sprintf("key-%05d", i)) }
+	secondaryKey := func(i int) []byte { return []byte(fmt.Sprintf("secondary-%05d", i)) }
+	value := func(i, round int) []byte {
+		return []byte(fmt.Sprintf("%s-%d-%d", strings.Repeat("value", 40), i, round))
+	}
+
+	put := func(t *testing.T, i, round int) {
+		v := value(i, round)
+		require.Nil(t, b.Put(key(i), v, WithSecondaryKey(0, secondaryKey(i))))
+		control[string(key(i))] = v
+	}
+
+	del := func(t *testing.T, i int) {
+		require.Nil(t, b.Delete(key(i), WithSecondaryKey(0, secondaryKey(i))))
+		delete(control, string(key(i)))
+	}
+
+	verify := func(t *testing.T) {
+		for i := 0; i < size; i++ {
+			expected, ok := control[string(key(i))]
+
+			v, err := b.Get(key(i))
+			require.Nil(t, err)
+			v2, err := b.GetBySecondary(0, secondaryKey(i))
+			require.Nil(t, err)
+
+			if !ok {
+				assert.Nil(t, v, "key %d", i)
+				assert.Nil(t, v2, "secondary key %d", i)
+				continue
+			}
+
+			assert.Equal(t, expected, v, "key %d", i)
+			assert.Equal(t, expected, v2, "secondary key %d", i)
+		}
+
+		var expectedKeys []string
+		for k := range control {
+			expectedKeys = append(expectedKeys, k)
+		}
+		sort.Strings(expectedKeys)
+
+		var keys []string
+		c := b.Cursor()
+		defer c.Close()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			keys = append(keys, string(k))
+			assert.Equal(t, control[string(k)], v)
+		}
+		assert.Equal(t, expectedKeys, keys)
+
+		// seek into the middle of a block
+		k, v := c.Seek(key(size/2 + 1))
+		require.NotNil(t, k)
+		assert.True(t, bytes.Compare(k, key(size/2+1)) >= 0)
+		assert.Equal(t, control[string(k)], v)
+	}
+
+	t.Run("write and flush an uncompressed segment", func(t *testing.T) {
+		for i := 0; i < size; i++ {
+			put(t, i, 0)
+		}
+		require.Nil(t, b.FlushAndSwitch())
+		verify(t)
+	})
+
+	t.Run("reopen with compression", func(t *testing.T) {
+		require.Nil(t, b.Shutdown(testCtx()))
+
+		b, err = NewBucket(testCtx(), dirName, nullLogger(),
+			WithStrategy(StrategyReplace), WithSecondaryIndicies(1),
+			WithBlockCompression())
+		require.Nil(t, err)
+		b.SetMemtableThreshold(1e9)
+		verify(t)
+	})
+
+	t.Run("write and flush compressed segments", func(t *testing.T) {
+		for round := 1; round < 3; round++ {
+			for i := 0; i < size; i++ {
+				switch rand.Intn(3) {
+				case 0:
+					put(t, i, round)
+				case 1:
+					del(t, i)
+				}
+			}
+			require.Nil(t, b.FlushAndSwitch())
+			verify(t)
+		}
+
+		require.Len(t, b.disk.segments, 3)
+		assert.False(t, b.disk.segments[0].compressed)
+		assert.True(t, b.disk.segments[1].compressed)
+		assert.True(t, b.disk.segments[2].compressed)
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		for b.disk.eligbleForCompaction() {
+			require.Nil(t, b.disk.compactOnce())
+		}
+
+		for _, seg := range b.disk.segments {
+			assert.True(t, seg.compressed)
+		}
+		verify(t)
+	})
+
+	require.Nil(t, b.Shutdown(testCtx()))
+}
+
+func TestBlockCompression_CollectionStrategies(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	set, err := NewBucket(testCtx(), filepath.Join(dirName, "set"), nullLogger(),
+		WithStrategy(StrategySetCollection), WithBlockCompression())
+	require.Nil(t, err)
+	set.SetMemtableThreshold(1e9)
+
+	mapB, err := NewBucket(testCtx(), filepath.Join(dirName, "map"), nullLogger(),
+		WithStrategy(StrategyMapCollection), WithBlockCompression())
+	require.Nil(t, err)
+	mapB.SetMemtableThreshold(1e9)
+
+	roaring, err := NewBucket(testCtx(), filepath.Join(dirName, "roaring"), nullLogger(),
+		WithStrategy(StrategyRoaringSet), WithBlockCompression())
+	require.Nil(t, err)
+	roaring.SetMemtableThreshold(1e9)
+
+	size := 1000
+	rows := 3
+	key := func(i int) []byte { return []byte(fmt.Sprintf("key-%05d", i)) }
+	mapKey := func(round int) []byte { return []byte(fmt.Sprintf("round-%d", round)) }
+	setValue := func(round int) []byte {
+		return []byte(fmt.Sprintf("%s-%d", strings.Repeat("set", 20), round))
+	}
+	mapValue := func(i int) []byte { return []byte(strings.Repeat("map", i%20+1)) }
+
+	verify := func(t *testing.T, rounds int) {
+		var expectedSet [][]byte
+		var expectedMap []MapPair
+		var expectedRoaring []uint64
+		for round := 0; round < rounds; round++ {
+			expectedSet = append(expectedSet, setValue(round))
+			expectedRoaring = append(expectedRoaring, uint64(round))
+		}
+
+		for i := 0; i < size; i++ {
+			v, err := set.SetList(key(i))
+			require.Nil(t, err)
+			assert.Equal(t, expectedSet, v, "set key %d", i)
+
+			expectedMap = nil
+			for round := 0; round < rounds; round++ {
+				expectedMap = append(expectedMap, MapPair{Key: mapKey(round), Value: mapValue(i)})
+			}
+			m, err := mapB.MapList(key(i))
+			require.Nil(t, err)
+			assert.Equal(t, expectedMap, m, "map key %d", i)
+
+			bm, err := roaring.RoaringSetGet(key(i))
+			require.Nil(t, err)
+			assert.Equal(t, expectedRoaring, bm.ToArray(), "roaring key %d", i)
+		}
+
+		count := 0
+		sc := set.SetCursor()
+		for k, v := sc.First(); k != nil; k, v = sc.Next() {
+			assert.Equal(t, key(count), k)
+			assert.Equal(t, expectedSet, v)
+			count++
+		}
+		sc.Close()
+		assert.Equal(t, size, count)
+
+		count = 0
+		mc := mapB.MapCursor()
+		for k, v := mc.First(); k != nil; k, v = mc.Next() {
+			assert.Equal(t, key(count), k)
+			assert.Len(t, v, rounds)
+			count++
+		}
+		mc.Close()
+		assert.Equal(t, size, count)
+
+		count = 0
+		rc := roaring.CursorRoaringSet()
+		for k, bm := rc.First(); k != nil; k, bm = rc.Next() {
+			assert.Equal(t, key(count), k)
+			assert.Equal(t, expectedRoaring, bm.ToArray())
+			count++
+		}
+		rc.Close()
+		assert.Equal(t, size, count)
+	}
+
+	for round := 0; round < rows; round++ {
+		t.Run(fmt.Sprintf("write and flush round %d", round), func(t *testing.T) {
+			for i := 0; i < size; i++ {
+				require.Nil(t, set.SetAdd(key(i), [][]byte{setValue(round)}))
+				require.Nil(t, mapB.MapSet(key(i), MapPair{Key: mapKey(round), Value: mapValue(i)}))
+				require.Nil(t, roaring.RoaringSetAddOne(key(i), uint64(round)))
+			}
+
+			require.Nil(t, set.FlushAndSwitch())
+			require.Nil(t, mapB.FlushAndSwitch())
+			require.Nil(t, roaring.FlushAndSwitch())
+			verify(t, round+1)
+		})
+	}
+
+	t.Run("compact", func(t *testing.T) {
+		for _, b := range []*Bucket{set, mapB, roaring} {
+			for b.disk.eligbleForCompaction() {
+				require.Nil(t, b.disk.compactOnce())
+			}
+
+			for _, seg := range b.disk.segments {
+				assert.True(t, seg.compressed)
+			}
+		}
+
+		verify(t, rows)
+	})
+
+	for _, b := range []*Bucket{set, mapB, roaring} {
+		require.Nil(t, b.Shutdown(testCtx()))
+	}
+}
+
+func TestBlockCompression_SmallerOnDisk(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	segmentSize := func(t *testing.T, opts ...BucketOption) int64 {
+		dir := filepath.Join(dirName, fmt.Sprintf("%d", len(opts)))
+		b, err := NewBucket(testCtx(), dir, nullLogger(), opts...)
+		require.Nil(t, err)
+		b.SetMemtableThreshold(1e9)
+
+		for i := 0; i < 1000; i++ {
+			value := []byte(fmt.Sprintf(`{"id":%d,"text":%q}`, i,
+				strings.Repeat("the quick brown fox jumps over the lazy dog ", 20)))
+			require.Nil(t, b.Put([]byte(fmt.Sprintf("key-%05d", i)), value))
+		}
+		require.Nil(t, b.Shutdown(testCtx()))
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.db"))
+		require.Nil(t, err)
+		require.Len(t, files, 1)
+
+		info, err := os.Stat(files[0])
+		require.Nil(t, err)
+		return info.Size()
+	}
+
+	uncompressed := segmentSize(t, WithStrategy(StrategyReplace))
+	compressed := segmentSize(t, WithStrategy(StrategyReplace), WithBlockCompression())
+
+	assert.Less(t, compressed*5, uncompressed,
+		"compressed: %d, uncompressed: %d", compressed, uncompressed)
+}
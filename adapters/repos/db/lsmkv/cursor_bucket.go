@@ -18,6 +18,7 @@ import (
 )
 
 type CursorReplace struct {
+	cursorRange
 	innerCursors []innerCursorReplace
 	state        []cursorStateReplace
 	unlock       func()
@@ -28,6 +29,9 @@ type innerCursorReplace interface {
 	first() ([]byte, []byte, error)
 	next() ([]byte, []byte, error)
 	seek([]byte) ([]byte, []byte, error)
+	last() ([]byte, []byte, error)
+	prev() ([]byte, []byte, error)
+	seekBefore([]byte) ([]byte, []byte, error)
 }
 
 type cursorStateReplace struct {
@@ -72,9 +76,30 @@ func (c *CursorReplace) Close() {
 }
 
 func (c *CursorReplace) seekAll(target []byte) {
+	c.initState("seek", func(cur innerCursorReplace) ([]byte, []byte, error) {
+		return cur.seek(target)
+	})
+}
+
+func (c *CursorReplace) seekBeforeAll(target []byte) {
+	c.initState("seek before", func(cur innerCursorReplace) ([]byte, []byte, error) {
+		return cur.seekBefore(target)
+	})
+}
+
+func (c *CursorReplace) firstAll() {
+	c.initState("first", innerCursorReplace.first)
+}
+
+func (c *CursorReplace) lastAll() {
+	c.initState("last", innerCursorReplace.last)
+}
+
+func (c *CursorReplace) initState(op string,
+	position func(cur innerCursorReplace) ([]byte, []byte, error)) {
 	state := make([]cursorStateReplace, len(c.innerCursors))
 	for i, cur := range c.innerCursors {
-		key, value, err := cur.seek(target)
+		key, value, err := position(cur)
 		if err == NotFound {
 			state[i].err = err
 			continue
@@ -87,7 +112,7 @@ func (c *CursorReplace) seekAll(target []byte) {
 		}
 
 		if err != nil {
-			panic(errors.Wrap(err, "unexpected error in seek (cursor type 'replace')"))
+			panic(errors.Wrapf(err, "unexpected error in %s (cursor type 'replace')", op))
 		}
 
 		state[i].key = key
@@ -105,6 +130,10 @@ func (c *CursorReplace) serveCurrentStateAndAdvance() ([]byte, []byte) {
 		}
 	}
 
+	if !c.inRange(c.state[id].key) {
+		return nil, nil
+	}
+
 	// check if this is a duplicate key before checking for the remaining errors,
 	// as cases such as 'Deleted' can be better handled inside
 	// mergeDuplicatesInCurrentStateAndAdvance where we can be sure to act on
@@ -149,7 +178,7 @@ func (c *CursorReplace) mergeDuplicatesInCurrentStateAndAdvance(ids []int) ([]by
 
 	if c.serveCache.err == Deleted {
 		// element was deleted, proceed with next round
-		return c.Next()
+		return c.serveCurrentStateAndAdvance()
 	}
 
 	return c.serveCache.key, c.serveCache.value
@@ -174,9 +203,12 @@ func (c *CursorReplace) copyStateIntoServeCache(pos int) {
 	c.serveCache.err = resMut.err
 }
 
+// Seek positions the cursor on the first key which is larger than or equal
+// to the specified key and iterates in ascending order from there
 func (c *CursorReplace) Seek(key []byte) ([]byte, []byte) {
-	c.seekAll(key)
-	return c.serveCurrentStateAndAdvance()
+	c.reverse = false
+	c.seekAll(c.seekTarget(key))
+	return c.serve()
 }
 
 func (c *CursorReplace) cursorWithLowestKey() (int, error) {
@@ -189,7 +221,8 @@ func (c *CursorReplace) cursorWithLowestKey() (int, error) {
 			continue
 		}
 
-		if lowest == nil || bytes.Compare(res.key, lowest) <= 0 {
+		// on equal keys the later, i.e. newer, cursor wins
+		if lowest == nil || !c.precedes(lowest, res.key) {
 			pos = i
 			err = res.err
 			lowest = res.key
@@ -204,7 +237,13 @@ func (c *CursorReplace) cursorWithLowestKey() (int, error) {
 }
 
 func (c *CursorReplace) advanceInner(id int) {
-	k, v, err := c.innerCursors[id].next()
+	var k, v []byte
+	var err error
+	if c.reverse {
+		k, v, err = c.innerCursors[id].prev()
+	} else {
+		k, v, err = c.innerCursors[id].next()
+	}
 	if err == NotFound {
 		c.state[id].err = err
 		c.state[id].key = nil
@@ -228,36 +267,59 @@ func (c *CursorReplace) advanceInner(id int) {
 	c.state[id].err = nil
 }
 
+// Next returns the next key in ascending order. If the cursor was iterating
+// in descending order, it continues with the key after the last key served.
 func (c *CursorReplace) Next() ([]byte, []byte) {
-	return c.serveCurrentStateAndAdvance()
-}
-
-func (c *CursorReplace) firstAll() {
-	state := make([]cursorStateReplace, len(c.innerCursors))
-	for i, cur := range c.innerCursors {
-		key, value, err := cur.first()
-		if err == NotFound {
-			state[i].err = err
-			continue
-		}
-		if err == Deleted {
-			state[i].err = err
-			state[i].key = key
-			continue
+	if c.reverse {
+		if !c.hasLastKey {
+			return c.First()
 		}
 
-		if err != nil {
-			panic(errors.Wrap(err, "unexpected error in first (cursor type 'replace')"))
+		c.reverse = false
+		c.seekAll(c.afterLastKey())
+	}
+
+	return c.serve()
+}
+
+// Prev returns the next key in descending order. If the cursor was iterating
+// in ascending order, it continues with the key before the last key served.
+func (c *CursorReplace) Prev() ([]byte, []byte) {
+	if !c.reverse {
+		if !c.hasLastKey {
+			return c.Last()
 		}
 
-		state[i].key = key
-		state[i].value = value
+		c.reverse = true
+		c.seekBeforeAll(c.lastKey)
 	}
 
-	c.state = state
+	return c.serve()
 }
 
 func (c *CursorReplace) First() ([]byte, []byte) {
-	c.firstAll()
-	return c.serveCurrentStateAndAdvance()
+	c.reverse = false
+	if c.lower != nil {
+		c.seekAll(c.lower)
+	} else {
+		c.firstAll()
+	}
+	return c.serve()
+}
+
+// Last positions the cursor on the largest key and iterates in descending
+// order from there
+func (c *CursorReplace) Last() ([]byte, []byte) {
+	c.reverse = true
+	if c.upper != nil {
+		c.seekBeforeAll(c.upper)
+	} else {
+		c.lastAll()
+	}
+	return c.serve()
+}
+
+func (c *CursorReplace) serve() ([]byte, []byte) {
+	k, v := c.serveCurrentStateAndAdvance()
+	return c.served(k), v
 }
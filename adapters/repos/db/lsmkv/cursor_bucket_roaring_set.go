@@ -0,0 +1,250 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"bytes"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
+)
+
+type CursorRoaringSet struct {
+	cursorRange
+	innerCursors []innerCursorRoaringSet
+	state        []cursorStateRoaringSet
+	unlock       func()
+	keyOnly      bool
+}
+
+type innerCursorRoaringSet interface {
+	first() ([]byte, roaringset.BitmapLayer, error)
+	next() ([]byte, roaringset.BitmapLayer, error)
+	seek([]byte) ([]byte, roaringset.BitmapLayer, error)
+	last() ([]byte, roaringset.BitmapLayer, error)
+	prev() ([]byte, roaringset.BitmapLayer, error)
+	seekBefore([]byte) ([]byte, roaringset.BitmapLayer, error)
+}
+
+type cursorStateRoaringSet struct {
+	key   []byte
+	layer roaringset.BitmapLayer
+	err   error
+}
+
+func (b *Bucket) CursorRoaringSet() *CursorRoaringSet {
+	b.flushLock.RLock()
+
+	if b.strategy != StrategyRoaringSet {
+		panic("CursorRoaringSet() called on strategy other than 'roaringset'")
+	}
+
+	innerCursors, unlockSegmentGroup := b.disk.newRoaringSetCursors()
+
+	// we have a flush-RLock, so we have the guarantee that the flushing state
+	// will not change for the lifetime of the cursor, thus there can only be two
+	// states: either a flushing memtable currently exists - or it doesn't
+	if b.flushing != nil {
+		innerCursors = append(innerCursors, b.flushing.newRoaringSetCursor())
+	}
+
+	innerCursors = append(innerCursors, b.active.newRoaringSetCursor())
+
+	return &CursorRoaringSet{
+		unlock: func() {
+			unlockSegmentGroup()
+			b.flushLock.RUnlock()
+		},
+		// cursor are in order from oldest to newest, with the memtable cursor
+		// being at the very top
+		innerCursors: innerCursors,
+	}
+}
+
+func (b *Bucket) CursorRoaringSetKeyOnly() *CursorRoaringSet {
+	c := b.CursorRoaringSet()
+	c.keyOnly = true
+	return c
+}
+
+// Seek positions the cursor on the first key which is larger than or equal
+// to the specified key and iterates in ascending order from there
+func (c *CursorRoaringSet) Seek(key []byte) ([]byte, *roaring64.Bitmap) {
+	c.reverse = false
+	c.seekAll(c.seekTarget(key))
+	return c.serve()
+}
+
+// Next returns the next key in ascending order. If the cursor was iterating
+// in descending order, it continues with the key after the last key served.
+func (c *CursorRoaringSet) Next() ([]byte, *roaring64.Bitmap) {
+	if c.reverse {
+		if !c.hasLastKey {
+			return c.First()
+		}
+
+		c.reverse = false
+		c.seekAll(c.afterLastKey())
+	}
+
+	return c.serve()
+}
+
+// Prev returns the next key in descending order. If the cursor was iterating
+// in ascending order, it continues with the key before the last key served.
+func (c *CursorRoaringSet) Prev() ([]byte, *roaring64.Bitmap) {
+	if !c.reverse {
+		if !c.hasLastKey {
+			return c.Last()
+		}
+
+		c.reverse = true
+		c.seekBeforeAll(c.lastKey)
+	}
+
+	return c.serve()
+}
+
+func (c *CursorRoaringSet) First() ([]byte, *roaring64.Bitmap) {
+	c.reverse = false
+	if c.lower != nil {
+		c.seekAll(c.lower)
+	} else {
+		c.firstAll()
+	}
+	return c.serve()
+}
+
+// Last positions the cursor on the largest key and iterates in descending
+// order from there
+func (c *CursorRoaringSet) Last() ([]byte, *roaring64.Bitmap) {
+	c.reverse = true
+	if c.upper != nil {
+		c.seekBeforeAll(c.upper)
+	} else {
+		c.lastAll()
+	}
+	return c.serve()
+}
+
+func (c *CursorRoaringSet) serve() ([]byte, *roaring64.Bitmap) {
+	k, v := c.serveCurrentStateAndAdvance()
+	return c.served(k), v
+}
+
+func (c *CursorRoaringSet) Close() {
+	c.unlock()
+}
+
+func (c *CursorRoaringSet) seekAll(target []byte) {
+	c.initState("seek", func(cur innerCursorRoaringSet) ([]byte, roaringset.BitmapLayer, error) {
+		return cur.seek(target)
+	})
+}
+
+func (c *CursorRoaringSet) seekBeforeAll(target []byte) {
+	c.initState("seek before", func(cur innerCursorRoaringSet) ([]byte, roaringset.BitmapLayer, error) {
+		return cur.seekBefore(target)
+	})
+}
+
+func (c *CursorRoaringSet) firstAll() {
+	c.initState("first", innerCursorRoaringSet.first)
+}
+
+func (c *CursorRoaringSet) lastAll() {
+	c.initState("last", innerCursorRoaringSet.last)
+}
+
+func (c *CursorRoaringSet) initState(op string,
+	position func(cur innerCursorRoaringSet) ([]byte, roaringset.BitmapLayer, error)) {
+	state := make([]cursorStateRoaringSet, len(c.innerCursors))
+	for i, cur := range c.innerCursors {
+		key, layer, err := position(cur)
+		if err == NotFound {
+			state[i].err = err
+			continue
+		}
+
+		if err != nil {
+			panic(errors.Wrapf(err, "unexpected error in %s", op))
+		}
+
+		state[i].key = key
+		state[i].layer = layer
+	}
+
+	c.state = state
+}
+
+func (c *CursorRoaringSet) serveCurrentStateAndAdvance() ([]byte, *roaring64.Bitmap) {
+	key := c.lowestKey()
+	if key == nil || !c.inRange(key) {
+		return nil, nil
+	}
+
+	// the inner cursors are ordered from oldest to newest, so collecting the
+	// layers in cursor order yields the correct order for flattening
+	var layers roaringset.BitmapLayers
+	for i := range c.state {
+		if c.state[i].err == NotFound || !bytes.Equal(c.state[i].key, key) {
+			continue
+		}
+
+		layers = append(layers, c.state[i].layer)
+		c.advanceInner(i)
+	}
+
+	if c.keyOnly {
+		return key, nil
+	}
+
+	return key, layers.Flatten()
+}
+
+func (c *CursorRoaringSet) lowestKey() []byte {
+	var lowest []byte
+
+	for _, res := range c.state {
+		if res.err == NotFound {
+			continue
+		}
+
+		if lowest == nil || c.precedes(res.key, lowest) {
+			lowest = res.key
+		}
+	}
+
+	return lowest
+}
+
+func (c *CursorRoaringSet) advanceInner(id int) {
+	var k []byte
+	var layer roaringset.BitmapLayer
+	var err error
+	if c.reverse {
+		k, layer, err = c.innerCursors[id].prev()
+	} else {
+		k, layer, err = c.innerCursors[id].next()
+	}
+	if err == NotFound {
+		c.state[id] = cursorStateRoaringSet{err: err}
+		return
+	}
+
+	if err != nil {
+		panic(errors.Wrap(err, "unexpected error in advance"))
+	}
+
+	c.state[id] = cursorStateRoaringSet{key: k, layer: layer}
+}
@@ -18,6 +18,7 @@ import (
 )
 
 type CursorSet struct {
+	cursorRange
 	innerCursors []innerCursorCollection
 	state        []cursorStateCollection
 	unlock       func()
@@ -28,6 +29,9 @@ type innerCursorCollection interface {
 	first() ([]byte, []value, error)
 	next() ([]byte, []value, error)
 	seek([]byte) ([]byte, []value, error)
+	last() ([]byte, []value, error)
+	prev() ([]byte, []value, error)
+	seekBefore([]byte) ([]byte, []value, error)
 }
 
 type cursorStateCollection struct {
@@ -79,18 +83,69 @@ func (b *Bucket) SetCursorKeyOnly() *CursorSet {
 	return c
 }
 
+// Seek positions the cursor on the first key which is larger than or equal
+// to the specified key and iterates in ascending order from there
 func (c *CursorSet) Seek(key []byte) ([]byte, [][]byte) {
-	c.seekAll(key)
-	return c.serveCurrentStateAndAdvance()
+	c.reverse = false
+	c.seekAll(c.seekTarget(key))
+	return c.serve()
 }
 
+// Next returns the next key in ascending order. If the cursor was iterating
+// in descending order, it continues with the key after the last key served.
 func (c *CursorSet) Next() ([]byte, [][]byte) {
-	return c.serveCurrentStateAndAdvance()
+	if c.reverse {
+		if !c.hasLastKey {
+			return c.First()
+		}
+
+		c.reverse = false
+		c.seekAll(c.afterLastKey())
+	}
+
+	return c.serve()
+}
+
+// Prev returns the next key in descending order. If the cursor was iterating
+// in ascending order, it continues with the key before the last key served.
+func (c *CursorSet) Prev() ([]byte, [][]byte) {
+	if !c.reverse {
+		if !c.hasLastKey {
+			return c.Last()
+		}
+
+		c.reverse = true
+		c.seekBeforeAll(c.lastKey)
+	}
+
+	return c.serve()
 }
 
 func (c *CursorSet) First() ([]byte, [][]byte) {
-	c.firstAll()
-	return c.serveCurrentStateAndAdvance()
+	c.reverse = false
+	if c.lower != nil {
+		c.seekAll(c.lower)
+	} else {
+		c.firstAll()
+	}
+	return c.serve()
+}
+
+// Last positions the cursor on the largest key and iterates in descending
+// order from there
+func (c *CursorSet) Last() ([]byte, [][]byte) {
+	c.reverse = true
+	if c.upper != nil {
+		c.seekBeforeAll(c.upper)
+	} else {
+		c.lastAll()
+	}
+	return c.serve()
+}
+
+func (c *CursorSet) serve() ([]byte, [][]byte) {
+	k, v := c.serveCurrentStateAndAdvance()
+	return c.served(k), v
 }
 
 func (c *CursorSet) Close() {
@@ -98,38 +153,37 @@ func (c *CursorSet) Close() {
 }
 
 func (c *CursorSet) seekAll(target []byte) {
-	state := make([]cursorStateCollection, len(c.innerCursors))
-	for i, cur := range c.innerCursors {
-		key, value, err := cur.seek(target)
-		if err == NotFound {
-			state[i].err = err
-			continue
-		}
+	c.initState("seek", func(cur innerCursorCollection) ([]byte, []value, error) {
+		return cur.seek(target)
+	})
+}
 
-		if err != nil {
-			panic(errors.Wrap(err, "unexpected error in seek"))
-		}
+func (c *CursorSet) seekBeforeAll(target []byte) {
+	c.initState("seek before", func(cur innerCursorCollection) ([]byte, []value, error) {
+		return cur.seekBefore(target)
+	})
+}
 
-		state[i].key = key
-		if !c.keyOnly {
-			state[i].value = value
-		}
-	}
+func (c *CursorSet) firstAll() {
+	c.initState("first", innerCursorCollection.first)
+}
 
-	c.state = state
+func (c *CursorSet) lastAll() {
+	c.initState("last", innerCursorCollection.last)
 }
 
-func (c *CursorSet) firstAll() {
+func (c *CursorSet) initState(op string,
+	position func(cur innerCursorCollection) ([]byte, []value, error)) {
 	state := make([]cursorStateCollection, len(c.innerCursors))
 	for i, cur := range c.innerCursors {
-		key, value, err := cur.first()
+		key, value, err := position(cur)
 		if err == NotFound {
 			state[i].err = err
 			continue
 		}
 
 		if err != nil {
-			panic(errors.Wrap(err, "unexpected error in seek"))
+			panic(errors.Wrapf(err, "unexpected error in %s", op))
 		}
 
 		state[i].key = key
@@ -149,6 +203,10 @@ func (c *CursorSet) serveCurrentStateAndAdvance() ([]byte, [][]byte) {
 		}
 	}
 
+	if !c.inRange(c.state[id].key) {
+		return nil, nil
+	}
+
 	// check if this is a duplicate key before checking for the remaining errors,
 	// as cases such as 'Deleted' can be better handled inside
 	// mergeDuplicatesInCurrentStateAndAdvance where we can be sure to act on
@@ -170,7 +228,7 @@ func (c *CursorSet) cursorWithLowestKey() (int, error) {
 			continue
 		}
 
-		if lowest == nil || bytes.Compare(res.key, lowest) <= 0 {
+		if lowest == nil || !c.precedes(lowest, res.key) {
 			pos = i
 			err = res.err
 			lowest = res.key
@@ -225,7 +283,14 @@ func (c *CursorSet) mergeDuplicatesInCurrentStateAndAdvance(ids []int) ([]byte,
 }
 
 func (c *CursorSet) advanceInner(id int) {
-	k, v, err := c.innerCursors[id].next()
+	var k []byte
+	var v []value
+	var err error
+	if c.reverse {
+		k, v, err = c.innerCursors[id].prev()
+	} else {
+		k, v, err = c.innerCursors[id].next()
+	}
 	if err == NotFound {
 		c.state[id].err = err
 		c.state[id].key = nil
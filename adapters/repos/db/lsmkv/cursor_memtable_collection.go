@@ -93,3 +93,39 @@ func (c *memtableCursorCollection) next() ([]byte, []value, error) {
 	// tombstones
 	return c.data[c.current].key, c.data[c.current].values, nil
 }
+
+func (c *memtableCursorCollection) last() ([]byte, []value, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current = len(c.data) - 1
+	return c.currentReverse()
+}
+
+func (c *memtableCursorCollection) seekBefore(key []byte) ([]byte, []value, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current = posSmallerThan(len(c.data), func(i int) []byte {
+		return c.data[i].key
+	}, key)
+	return c.currentReverse()
+}
+
+func (c *memtableCursorCollection) prev() ([]byte, []value, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current--
+	return c.currentReverse()
+}
+
+// currentReverse serves the current position, which might have moved past
+// the beginning of the data. Must be called while holding the lock.
+func (c *memtableCursorCollection) currentReverse() ([]byte, []value, error) {
+	if c.current < 0 {
+		return nil, nil, NotFound
+	}
+
+	return c.data[c.current].key, c.data[c.current].values, nil
+}
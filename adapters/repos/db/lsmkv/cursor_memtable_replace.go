@@ -98,3 +98,42 @@ func (c *memtableCursor) next() ([]byte, []byte, error) {
 	}
 	return c.data[c.current].key, c.data[c.current].value, nil
 }
+
+func (c *memtableCursor) last() ([]byte, []byte, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current = len(c.data) - 1
+	return c.currentReverse()
+}
+
+func (c *memtableCursor) seekBefore(key []byte) ([]byte, []byte, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current = posSmallerThan(len(c.data), func(i int) []byte {
+		return c.data[i].key
+	}, key)
+	return c.currentReverse()
+}
+
+func (c *memtableCursor) prev() ([]byte, []byte, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current--
+	return c.currentReverse()
+}
+
+// currentReverse serves the current position, which might have moved past
+// the beginning of the data. Must be called while holding the lock.
+func (c *memtableCursor) currentReverse() ([]byte, []byte, error) {
+	if c.current < 0 {
+		return nil, nil, NotFound
+	}
+
+	if c.data[c.current].tombstone {
+		return c.data[c.current].key, nil, Deleted
+	}
+	return c.data[c.current].key, c.data[c.current].value, nil
+}
@@ -0,0 +1,134 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"bytes"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
+)
+
+type memtableCursorRoaringSet struct {
+	data    []*binarySearchNodeRoaringSet
+	current int
+	lock    func()
+	unlock  func()
+}
+
+func (l *Memtable) newRoaringSetCursor() innerCursorRoaringSet {
+	// Same primitive approach as the collection cursor, see
+	// newCollectionCursor for details
+
+	l.RLock()
+	defer l.RUnlock()
+
+	data := l.roaringSet.flattenInOrder()
+
+	return &memtableCursorRoaringSet{
+		data:   data,
+		lock:   l.RLock,
+		unlock: l.RUnlock,
+	}
+}
+
+func (c *memtableCursorRoaringSet) first() ([]byte, roaringset.BitmapLayer, error) {
+	c.lock()
+	defer c.unlock()
+
+	if len(c.data) == 0 {
+		return nil, roaringset.BitmapLayer{}, NotFound
+	}
+
+	c.current = 0
+	return c.data[c.current].key, c.currentLayer(), nil
+}
+
+func (c *memtableCursorRoaringSet) seek(key []byte) ([]byte, roaringset.BitmapLayer, error) {
+	c.lock()
+	defer c.unlock()
+
+	pos := c.posLargerThanEqual(key)
+	if pos == -1 {
+		return nil, roaringset.BitmapLayer{}, NotFound
+	}
+
+	c.current = pos
+	return c.data[pos].key, c.currentLayer(), nil
+}
+
+func (c *memtableCursorRoaringSet) posLargerThanEqual(key []byte) int {
+	for i, node := range c.data {
+		if bytes.Compare(node.key, key) >= 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (c *memtableCursorRoaringSet) next() ([]byte, roaringset.BitmapLayer, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current++
+	if c.current >= len(c.data) {
+		return nil, roaringset.BitmapLayer{}, NotFound
+	}
+
+	return c.data[c.current].key, c.currentLayer(), nil
+}
+
+// currentLayer returns a copy, as the bitmaps of a memtable node keep changing
+// with every write. Must be called while holding the lock.
+func (c *memtableCursorRoaringSet) currentLayer() roaringset.BitmapLayer {
+	layer := c.data[c.current].value
+	return roaringset.BitmapLayer{
+		Additions: layer.Additions.Clone(),
+		Deletions: layer.Deletions.Clone(),
+	}
+}
+
+func (c *memtableCursorRoaringSet) last() ([]byte, roaringset.BitmapLayer, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current = len(c.data) - 1
+	return c.currentReverse()
+}
+
+func (c *memtableCursorRoaringSet) seekBefore(key []byte) ([]byte, roaringset.BitmapLayer, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current = posSmallerThan(len(c.data), func(i int) []byte {
+		return c.data[i].key
+	}, key)
+	return c.currentReverse()
+}
+
+func (c *memtableCursorRoaringSet) prev() ([]byte, roaringset.BitmapLayer, error) {
+	c.lock()
+	defer c.unlock()
+
+	c.current--
+	return c.currentReverse()
+}
+
+// currentReverse serves the current position, which might have moved past
+// the beginning of the data. Must be called while holding the lock.
+func (c *memtableCursorRoaringSet) currentReverse() ([]byte, roaringset.BitmapLayer, error) {
+	if c.current < 0 {
+		return nil, roaringset.BitmapLayer{}, NotFound
+	}
+
+	return c.data[c.current].key, c.currentLayer(), nil
+}
@@ -0,0 +1,124 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"bytes"
+	"sort"
+)
+
+// cursorRange holds the bounds and the direction of a bucket cursor. It is
+// shared by all bucket cursors, regardless of their strategy. The bounds are
+// applied when merging the inner cursors, so the memtables and all segment
+// types behave the same.
+type cursorRange struct {
+	// lower is inclusive, upper is exclusive. nil means unbounded
+	lower []byte
+	upper []byte
+
+	reverse bool
+
+	// lastKey is the last key served, it is required to continue from the
+	// same position when the direction changes
+	lastKey    []byte
+	hasLastKey bool
+}
+
+// SetBounds limits the cursor to keys in the range [lower, upper). A nil bound
+// means the range is unbounded on that side. The bounds are respected by all
+// following calls to First, Last, Seek, Next and Prev.
+func (r *cursorRange) SetBounds(lower, upper []byte) {
+	r.lower = lower
+	r.upper = upper
+}
+
+// SetPrefix limits the cursor to keys starting with the specified prefix. It
+// is a shortcut for SetBounds with the smallest key that no longer has the
+// prefix as an upper bound.
+func (r *cursorRange) SetPrefix(prefix []byte) {
+	r.SetBounds(prefix, prefixUpperBound(prefix))
+}
+
+// prefixUpperBound returns the smallest key which is larger than all keys
+// starting with the prefix, or nil if there is no such key, e.g. because the
+// prefix consists of 0xFF bytes only
+func prefixUpperBound(prefix []byte) []byte {
+	upper := make([]byte, len(prefix))
+	copy(upper, prefix)
+
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i] < 0xFF {
+			upper[i]++
+			return upper[:i+1]
+		}
+	}
+
+	return nil
+}
+
+func (r *cursorRange) inRange(key []byte) bool {
+	if r.lower != nil && bytes.Compare(key, r.lower) < 0 {
+		return false
+	}
+
+	if r.upper != nil && bytes.Compare(key, r.upper) >= 0 {
+		return false
+	}
+
+	return true
+}
+
+// precedes indicates whether a is served before b in the current direction
+func (r *cursorRange) precedes(a, b []byte) bool {
+	if r.reverse {
+		return bytes.Compare(a, b) > 0
+	}
+
+	return bytes.Compare(a, b) < 0
+}
+
+// seekTarget makes sure an ascending iteration does not start below the lower
+// bound
+func (r *cursorRange) seekTarget(key []byte) []byte {
+	if r.lower != nil && bytes.Compare(key, r.lower) < 0 {
+		return r.lower
+	}
+
+	return key
+}
+
+// afterLastKey is the smallest possible key larger than the last key served
+func (r *cursorRange) afterLastKey() []byte {
+	out := make([]byte, len(r.lastKey)+1)
+	copy(out, r.lastKey)
+	return r.seekTarget(out)
+}
+
+// served records the key to be returned to the user, nil keys mark the end of
+// the iteration and are not recorded
+func (r *cursorRange) served(key []byte) []byte {
+	if key != nil {
+		r.lastKey = append(r.lastKey[:0], key...)
+		r.hasLastKey = true
+	}
+
+	return key
+}
+
+// posSmallerThan returns the position of the largest key which is strictly
+// smaller than the specified key in a sorted list of n keys, or -1 if there is
+// none
+func posSmallerThan(n int, keyAt func(i int) []byte, key []byte) int {
+	return sort.Search(n, func(i int) bool {
+		return bytes.Compare(keyAt(i), key) >= 0
+	}) - 1
+}
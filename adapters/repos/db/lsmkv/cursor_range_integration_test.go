@@ -0,0 +1,445 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rangeCursor wraps the strategy-specific bucket cursors, so that the same
+// assertions can be run against all of them. Values are rendered as strings.
+type rangeCursor struct {
+	first, last, next, prev func() ([]byte, string)
+	seek                    func(key []byte) ([]byte, string)
+	setBounds               func(lower, upper []byte)
+	setPrefix               func(prefix []byte)
+	close                   func()
+}
+
+type rangeCursorTestCase struct {
+	name     string
+	strategy string
+	// write stores a value for the key as part of the specified round and
+	// returns the rendered value the cursor is expected to serve from then on
+	write func(t *testing.T, b *Bucket, key []byte, round int) string
+	// del removes the key entirely, strategies without deletions leave it nil
+	del    func(t *testing.T, b *Bucket, key []byte)
+	cursor func(b *Bucket) rangeCursor
+}
+
+func TestCursorRanges(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+
+	for _, tc := range rangeCursorTestCases() {
+		for _, compressed := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s, compressed=%t", tc.name, compressed), func(t *testing.T) {
+				testCursorRanges(t, tc, compressed)
+			})
+		}
+	}
+}
+
+func rangeCursorTestCases() []rangeCursorTestCase {
+	return []rangeCursorTestCase{
+		{
+			name:     "replace",
+			strategy: StrategyReplace,
+			write: func(t *testing.T, b *Bucket, key []byte, round int) string {
+				v := fmt.Sprintf("%s-round-%d", key, round)
+				require.Nil(t, b.Put(key, []byte(v)))
+				return v
+			},
+			del: func(t *testing.T, b *Bucket, key []byte) {
+				require.Nil(t, b.Delete(key))
+			},
+			cursor: func(b *Bucket) rangeCursor {
+				c := b.Cursor()
+				render := func(k, v []byte) ([]byte, string) { return k, string(v) }
+				return rangeCursor{
+					first:     func() ([]byte, string) { return render(c.First()) },
+					last:      func() ([]byte, string) { return render(c.Last()) },
+					next:      func() ([]byte, string) { return render(c.Next()) },
+					prev:      func() ([]byte, string) { return render(c.Prev()) },
+					seek:      func(key []byte) ([]byte, string) { return render(c.Seek(key)) },
+					setBounds: c.SetBounds,
+					setPrefix: c.SetPrefix,
+					close:     c.Close,
+				}
+			},
+		},
+		{
+			name:     "set",
+			strategy: StrategySetCollection,
+			write: func(t *testing.T, b *Bucket, key []byte, round int) string {
+				require.Nil(t, b.SetAdd(key, [][]byte{[]byte(fmt.Sprintf("round-%d", round))}))
+				v, err := b.SetList(key)
+				require.Nil(t, err)
+				return renderSet(v)
+			},
+			cursor: func(b *Bucket) rangeCursor {
+				c := b.SetCursor()
+				render := func(k []byte, v [][]byte) ([]byte, string) { return k, renderSet(v) }
+				return rangeCursor{
+					first:     func() ([]byte, string) { return render(c.First()) },
+					last:      func() ([]byte, string) { return render(c.Last()) },
+					next:      func() ([]byte, string) { return render(c.Next()) },
+					prev:      func() ([]byte, string) { return render(c.Prev()) },
+					seek:      func(key []byte) ([]byte, string) { return render(c.Seek(key)) },
+					setBounds: c.SetBounds,
+					setPrefix: c.SetPrefix,
+					close:     c.Close,
+				}
+			},
+		},
+		{
+			name:     "map",
+			strategy: StrategyMapCollection,
+			write: func(t *testing.T, b *Bucket, key []byte, round int) string {
+				require.Nil(t, b.MapSet(key, MapPair{
+					Key:   []byte(fmt.Sprintf("round-%d", round)),
+					Value: []byte(fmt.Sprintf("%s-%d", key, round)),
+				}))
+				v, err := b.MapList(key)
+				require.Nil(t, err)
+				return renderMap(v)
+			},
+			cursor: func(b *Bucket) rangeCursor {
+				c := b.MapCursor()
+				render := func(k []byte, v []MapPair) ([]byte, string) { return k, renderMap(v) }
+				return rangeCursor{
+					first:     func() ([]byte, string) { return render(c.First()) },
+					last:      func() ([]byte, string) { return render(c.Last()) },
+					next:      func() ([]byte, string) { return render(c.Next()) },
+					prev:      func() ([]byte, string) { return render(c.Prev()) },
+					seek:      func(key []byte) ([]byte, string) { return render(c.Seek(key)) },
+					setBounds: c.SetBounds,
+					setPrefix: c.SetPrefix,
+					close:     c.Close,
+				}
+			},
+		},
+		{
+			name:     "roaringset",
+			strategy: StrategyRoaringSet,
+			write: func(t *testing.T, b *Bucket, key []byte, round int) string {
+				require.Nil(t, b.RoaringSetAddOne(key, uint64(round)))
+				v, err := b.RoaringSetGet(key)
+				require.Nil(t, err)
+				return fmt.Sprint(v.ToArray())
+			},
+			cursor: func(b *Bucket) rangeCursor {
+				c := b.CursorRoaringSet()
+				render := func(k []byte, v *roaring64.Bitmap) ([]byte, string) {
+					if v == nil {
+						return k, ""
+					}
+					return k, fmt.Sprint(v.ToArray())
+				}
+				return rangeCursor{
+					first:     func() ([]byte, string) { return render(c.First()) },
+					last:      func() ([]byte, string) { return render(c.Last()) },
+					next:      func() ([]byte, string) { return render(c.Next()) },
+					prev:      func() ([]byte, string) { return render(c.Prev()) },
+					seek:      func(key []byte) ([]byte, string) { return render(c.Seek(key)) },
+					setBounds: c.SetBounds,
+					setPrefix: c.SetPrefix,
+					close:     c.Close,
+				}
+			},
+		},
+	}
+}
+
+func testCursorRanges(t *testing.T, tc rangeCursorTestCase, compressed bool) {
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	opts := []BucketOption{WithStrategy(tc.strategy)}
+	if compressed {
+		opts = append(opts, WithBlockCompression())
+	}
+	b, err := NewBucket(testCtx(), dirName, nullLogger(), opts...)
+	require.Nil(t, err)
+
+	// so big it effectively never triggers as part of this test
+	b.SetMemtableThreshold(1e9)
+
+	size := 60
+	control := map[string]string{}
+	keys := func(fn func(key []byte)) {
+		for _, prefix := range []string{"a", "b"} {
+			for i := 0; i < size; i++ {
+				fn([]byte(fmt.Sprintf("%s-%03d", prefix, i)))
+			}
+		}
+	}
+	keyNumber := func(key []byte) int {
+		var i int
+		fmt.Sscanf(string(key[2:]), "%d", &i)
+		return i
+	}
+
+	// each round writes a different subset of the keys, so that the keys are
+	// spread across two segments, the flushing and the active memtable with
+	// some keys present in several of them
+	write := func(round, mod, rem int) {
+		keys(func(key []byte) {
+			if keyNumber(key)%mod == rem {
+				control[string(key)] = tc.write(t, b, key, round)
+			}
+		})
+	}
+	del := func(mod, rem int) {
+		if tc.del == nil {
+			return
+		}
+
+		keys(func(key []byte) {
+			if keyNumber(key)%mod == rem {
+				tc.del(t, b, key)
+				delete(control, string(key))
+			}
+		})
+	}
+
+	write(0, 2, 0)
+	require.Nil(t, b.FlushAndSwitch())
+
+	write(1, 3, 0)
+	del(10, 4)
+	require.Nil(t, b.FlushAndSwitch())
+
+	write(2, 5, 1)
+	require.Nil(t, b.atomicallySwitchMemtable())
+
+	write(3, 7, 2)
+	del(10, 6)
+
+	t.Run("with segments, flushing and active memtable", func(t *testing.T) {
+		require.NotNil(t, b.flushing)
+		assertCursorRanges(t, func() rangeCursor { return tc.cursor(b) }, control)
+	})
+
+	require.Nil(t, b.flushing.flush())
+	require.Nil(t, b.atomicallyAddDiskSegmentAndRemoveFlushing())
+	require.Nil(t, b.FlushAndSwitch())
+
+	t.Run("with segments only", func(t *testing.T) {
+		assertCursorRanges(t, func() rangeCursor { return tc.cursor(b) }, control)
+	})
+
+	require.Nil(t, b.Shutdown(testCtx()))
+}
+
+type rangeCursorPair struct {
+	key   string
+	value string
+}
+
+func assertCursorRanges(t *testing.T, newCursor func() rangeCursor,
+	control map[string]string) {
+	var all []rangeCursorPair
+	for k, v := range control {
+		all = append(all, rangeCursorPair{k, v})
+	}
+	sort.Slice(all, func(a, b int) bool { return all[a].key < all[b].key })
+
+	between := func(lower, upper string) []rangeCursorPair {
+		var out []rangeCursorPair
+		for _, pair := range all {
+			if (lower == "" || pair.key >= lower) && (upper == "" || pair.key < upper) {
+				out = append(out, pair)
+			}
+		}
+		return out
+	}
+
+	reversed := func(in []rangeCursorPair) []rangeCursorPair {
+		var out []rangeCursorPair
+		for i := len(in) - 1; i >= 0; i-- {
+			out = append(out, in[i])
+		}
+		return out
+	}
+
+	collect := func(start, step func() ([]byte, string)) []rangeCursorPair {
+		var out []rangeCursorPair
+		for k, v := start(); k != nil; k, v = step() {
+			out = append(out, rangeCursorPair{string(k), v})
+		}
+		return out
+	}
+
+	bytesOrNil := func(in string) []byte {
+		if in == "" {
+			return nil
+		}
+		return []byte(in)
+	}
+
+	bounds := []struct {
+		name         string
+		lower, upper string
+		prefix       string
+	}{
+		{name: "unbounded"},
+		{name: "lower bound only", lower: "a-031"},
+		{name: "upper bound only", upper: "b-017"},
+		{name: "lower and upper bound", lower: "a-017", upper: "b-040"},
+		{name: "bounds between existing keys", lower: "a-0175", upper: "a-0405"},
+		{name: "empty range", lower: "a-0175", upper: "a-0176"},
+		{name: "prefix", prefix: "b-"},
+		{name: "narrow prefix", prefix: "a-02"},
+		{name: "prefix without matches", prefix: "c"},
+	}
+
+	for _, bound := range bounds {
+		lower, upper := bound.lower, bound.upper
+		if bound.prefix != "" {
+			lower, upper = bound.prefix, string(prefixUpperBound([]byte(bound.prefix)))
+		}
+		expected := between(lower, upper)
+
+		open := func() rangeCursor {
+			c := newCursor()
+			if bound.prefix != "" {
+				c.setPrefix([]byte(bound.prefix))
+			} else {
+				c.setBounds(bytesOrNil(bound.lower), bytesOrNil(bound.upper))
+			}
+			return c
+		}
+
+		t.Run(bound.name, func(t *testing.T) {
+			t.Run("ascending", func(t *testing.T) {
+				c := open()
+				defer c.close()
+
+				assert.Equal(t, expected, collect(c.first, c.next))
+			})
+
+			t.Run("descending", func(t *testing.T) {
+				c := open()
+				defer c.close()
+
+				assert.Equal(t, reversed(expected), collect(c.last, c.prev))
+			})
+
+			t.Run("prev on a new cursor starts at the end", func(t *testing.T) {
+				c := open()
+				defer c.close()
+
+				assert.Equal(t, reversed(expected), collect(c.prev, c.prev))
+			})
+
+			t.Run("seek", func(t *testing.T) {
+				for _, target := range []string{"", "a-000", "a-025", "a-0255", "b-059", "c"} {
+					c := open()
+					seek := func() ([]byte, string) { return c.seek([]byte(target)) }
+					assert.Equal(t, between(maxString(lower, target), upper),
+						collect(seek, c.next), target)
+					c.close()
+				}
+			})
+
+			t.Run("random walk in both directions", func(t *testing.T) {
+				c := open()
+				defer c.close()
+
+				assertRandomWalk(t, c, expected)
+			})
+		})
+	}
+}
+
+// assertRandomWalk moves the cursor back and forth and compares every result
+// with the position in the expected list. After running off either end, the
+// walk restarts from a random end.
+func assertRandomWalk(t *testing.T, c rangeCursor, expected []rangeCursorPair) {
+	pos := -1
+	assertAt := func(k []byte, v string) {
+		if pos < 0 || pos >= len(expected) {
+			assert.Nil(t, k)
+			return
+		}
+
+		assert.Equal(t, expected[pos], rangeCursorPair{string(k), v})
+	}
+
+	restart := func() {
+		if rand.Intn(2) == 0 {
+			pos = 0
+			assertAt(c.first())
+		} else {
+			pos = len(expected) - 1
+			assertAt(c.last())
+		}
+	}
+
+	restart()
+	for i := 0; i < 500; i++ {
+		if pos < 0 || pos >= len(expected) {
+			restart()
+			continue
+		}
+
+		if rand.Intn(2) == 0 {
+			pos++
+			assertAt(c.next())
+		} else {
+			pos--
+			assertAt(c.prev())
+		}
+	}
+}
+
+func maxString(a, b string) string {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func renderSet(in [][]byte) string {
+	values := make([]string, len(in))
+	for i := range in {
+		values[i] = string(in[i])
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+func renderMap(in []MapPair) string {
+	values := make([]string, len(in))
+	for i := range in {
+		values[i] = fmt.Sprintf("%s=%s", in[i].Key, in[i].Value)
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
@@ -16,8 +16,12 @@ import (
 )
 
 type segmentCursorCollection struct {
-	segment    *segment
-	nextOffset uint64
+	segment *segment
+	nextPos segmentPos
+
+	// reverseKey is the key of the current node when iterating in descending
+	// order, see segmentCursorReplace
+	reverseKey []byte
 }
 
 func (s *segment) newCollectionCursor() *segmentCursorCollection {
@@ -47,45 +51,109 @@ func (s *segmentCursorCollection) seek(key []byte) ([]byte, []value, error) {
 		return nil, nil, err
 	}
 
-	parsed, err := s.segment.collectionStratParseDataWithKey(
-		s.segment.contents[node.Start:node.End])
+	pos := s.segment.nodePos(node)
+	in, err := s.segment.bytesAt(pos)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := s.segment.collectionStratParseDataWithKey(in)
 	if err != nil {
 		return parsed.primaryKey, nil, err
 	}
 
-	s.nextOffset = node.End
+	s.nextPos, err = s.segment.advance(pos, parsed.offset)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return parsed.primaryKey, parsed.values, nil
 }
 
 func (s *segmentCursorCollection) next() ([]byte, []value, error) {
-	if s.nextOffset >= s.segment.dataEndPos {
+	if s.segment.isEndPos(s.nextPos) {
 		return nil, nil, NotFound
 	}
 
-	parsed, err := s.segment.collectionStratParseDataWithKey(
-		s.segment.contents[s.nextOffset:])
+	in, err := s.segment.bytesAt(s.nextPos)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := s.segment.collectionStratParseDataWithKey(in)
 
 	// make sure to set the next offset before checking the error. The error
 	// could be 'Deleted' which would require that the offset is still advanced
 	// for the next cycle
-	s.nextOffset = s.nextOffset + uint64(parsed.offset)
+	var advanceErr error
+	s.nextPos, advanceErr = s.segment.advance(s.nextPos, parsed.offset)
 	if err != nil {
 		return parsed.primaryKey, nil, err
 	}
+	if advanceErr != nil {
+		return nil, nil, advanceErr
+	}
 
 	return parsed.primaryKey, parsed.values, nil
 }
 
 func (s *segmentCursorCollection) first() ([]byte, []value, error) {
-	s.nextOffset = s.segment.dataStartPos
-	parsed, err := s.segment.collectionStratParseDataWithKey(
-		s.segment.contents[s.nextOffset:])
+	s.nextPos = s.segment.firstPos()
+	in, err := s.segment.bytesAt(s.nextPos)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := s.segment.collectionStratParseDataWithKey(in)
 	if err != nil {
 		return parsed.primaryKey, nil, err
 	}
 
-	s.nextOffset = s.nextOffset + uint64(parsed.offset)
+	s.nextPos, err = s.segment.advance(s.nextPos, parsed.offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return parsed.primaryKey, parsed.values, nil
+}
+
+func (s *segmentCursorCollection) last() ([]byte, []value, error) {
+	return s.reverseAt(s.segment.index.Last())
+}
+
+func (s *segmentCursorCollection) seekBefore(key []byte) ([]byte, []value, error) {
+	return s.reverseAt(s.segment.index.SeekBefore(key))
+}
+
+func (s *segmentCursorCollection) prev() ([]byte, []value, error) {
+	if s.reverseKey == nil {
+		return nil, nil, NotFound
+	}
+
+	return s.seekBefore(s.reverseKey)
+}
+
+func (s *segmentCursorCollection) reverseAt(node segmentindex.Node,
+	err error) ([]byte, []value, error) {
+	if err != nil {
+		s.reverseKey = nil
+		if err == segmentindex.NotFound {
+			return nil, nil, NotFound
+		}
+
+		return nil, nil, err
+	}
+
+	s.reverseKey = node.Key
+	in, err := s.segment.nodeBytes(node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parsed, err := s.segment.collectionStratParseDataWithKey(in)
+	if err != nil {
+		return parsed.primaryKey, nil, err
+	}
 
 	return parsed.primaryKey, parsed.values, nil
 }
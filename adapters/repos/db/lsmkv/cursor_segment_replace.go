@@ -17,8 +17,13 @@ import (
 
 type segmentCursorReplace struct {
 	segment      *segment
-	nextOffset   uint64
+	nextPos      segmentPos
 	reusableNode *segmentReplaceNode
+
+	// reverseKey is the key of the current node when iterating in descending
+	// order. There is no way to walk backwards through the data area, so every
+	// step is a new lookup in the index.
+	reverseKey []byte
 }
 
 func (s *segment) newCursor() *segmentCursorReplace {
@@ -49,77 +54,164 @@ func (s *segmentCursorReplace) seek(key []byte) ([]byte, []byte, error) {
 		return nil, nil, err
 	}
 
-	err = s.segment.replaceStratParseDataWithKeyInto(
-		s.segment.contents[node.Start:node.End], s.reusableNode)
+	pos := s.segment.nodePos(node)
+	in, err := s.segment.bytesAt(pos)
 	if err != nil {
-		return s.reusableNode.primaryKey, nil, err
+		return nil, nil, err
 	}
 
-	s.nextOffset = node.End
+	err = s.segment.replaceStratParseDataWithKeyInto(in, s.reusableNode)
+
+	// same as in next(), the offset needs to be advanced even if the error is
+	// 'Deleted', otherwise the next call would not start at a node boundary
+	var advanceErr error
+	s.nextPos, advanceErr = s.segment.advance(pos, s.reusableNode.offset)
+	if err != nil {
+		return s.reusableNode.primaryKey, nil, err
+	}
+	if advanceErr != nil {
+		return nil, nil, advanceErr
+	}
 
 	return s.reusableNode.primaryKey, s.reusableNode.value, nil
 }
 
 func (s *segmentCursorReplace) next() ([]byte, []byte, error) {
-	if s.nextOffset >= s.segment.dataEndPos {
+	if s.segment.isEndPos(s.nextPos) {
 		return nil, nil, NotFound
 	}
 
-	err := s.segment.replaceStratParseDataWithKeyInto(
-		s.segment.contents[s.nextOffset:], s.reusableNode)
+	in, err := s.segment.bytesAt(s.nextPos)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.segment.replaceStratParseDataWithKeyInto(in, s.reusableNode)
 
 	// make sure to set the next offset before checking the error. The error
 	// could be 'Deleted' which would require that the offset is still advanced
 	// for the next cycle
-	s.nextOffset = s.nextOffset + uint64(s.reusableNode.offset)
+	var advanceErr error
+	s.nextPos, advanceErr = s.segment.advance(s.nextPos, s.reusableNode.offset)
 	if err != nil {
 		return s.reusableNode.primaryKey, nil, err
 	}
+	if advanceErr != nil {
+		return nil, nil, advanceErr
+	}
 
 	return s.reusableNode.primaryKey, s.reusableNode.value, nil
 }
 
 func (s *segmentCursorReplace) first() ([]byte, []byte, error) {
-	s.nextOffset = s.segment.dataStartPos
-	err := s.segment.replaceStratParseDataWithKeyInto(
-		s.segment.contents[s.nextOffset:], s.reusableNode)
+	s.nextPos = s.segment.firstPos()
+	in, err := s.segment.bytesAt(s.nextPos)
 	if err != nil {
-		return s.reusableNode.primaryKey, nil, err
+		return nil, nil, err
 	}
 
-	s.nextOffset = s.nextOffset + uint64(s.reusableNode.offset)
+	err = s.segment.replaceStratParseDataWithKeyInto(in, s.reusableNode)
+
+	// same as in next(), a deleted first node must not be returned again by
+	// the next call
+	var advanceErr error
+	s.nextPos, advanceErr = s.segment.advance(s.nextPos, s.reusableNode.offset)
+	if err != nil {
+		return s.reusableNode.primaryKey, nil, err
+	}
+	if advanceErr != nil {
+		return nil, nil, advanceErr
+	}
 
 	return s.reusableNode.primaryKey, s.reusableNode.value, nil
 }
 
 func (s *segmentCursorReplace) nextWithAllKeys() (segmentReplaceNode, error) {
 	out := segmentReplaceNode{}
-	if s.nextOffset >= s.segment.dataEndPos {
+	if s.segment.isEndPos(s.nextPos) {
 		return out, NotFound
 	}
 
-	parsed, err := s.segment.replaceStratParseDataWithKey(
-		s.segment.contents[s.nextOffset:])
+	in, err := s.segment.bytesAt(s.nextPos)
+	if err != nil {
+		return out, err
+	}
+
+	parsed, err := s.segment.replaceStratParseDataWithKey(in)
 
 	// make sure to set the next offset before checking the error. The error
 	// could be 'Deleted' which would require that the offset is still advanced
 	// for the next cycle
-	s.nextOffset = s.nextOffset + uint64(parsed.offset)
+	var advanceErr error
+	s.nextPos, advanceErr = s.segment.advance(s.nextPos, parsed.offset)
 	if err != nil {
 		return parsed, err
 	}
+	if advanceErr != nil {
+		return out, advanceErr
+	}
 
 	return parsed, nil
 }
 
 func (s *segmentCursorReplace) firstWithAllKeys() (segmentReplaceNode, error) {
-	s.nextOffset = s.segment.dataStartPos
-	parsed, err := s.segment.replaceStratParseDataWithKey(
-		s.segment.contents[s.nextOffset:])
+	s.nextPos = s.segment.firstPos()
+	in, err := s.segment.bytesAt(s.nextPos)
+	if err != nil {
+		return segmentReplaceNode{}, err
+	}
+
+	parsed, err := s.segment.replaceStratParseDataWithKey(in)
+
+	var advanceErr error
+	s.nextPos, advanceErr = s.segment.advance(s.nextPos, parsed.offset)
 	if err != nil {
 		return parsed, err
 	}
-	s.nextOffset = s.nextOffset + uint64(parsed.offset)
+	if advanceErr != nil {
+		return parsed, advanceErr
+	}
 
 	return parsed, nil
 }
+
+func (s *segmentCursorReplace) last() ([]byte, []byte, error) {
+	return s.reverseAt(s.segment.index.Last())
+}
+
+func (s *segmentCursorReplace) seekBefore(key []byte) ([]byte, []byte, error) {
+	return s.reverseAt(s.segment.index.SeekBefore(key))
+}
+
+func (s *segmentCursorReplace) prev() ([]byte, []byte, error) {
+	if s.reverseKey == nil {
+		return nil, nil, NotFound
+	}
+
+	return s.seekBefore(s.reverseKey)
+}
+
+func (s *segmentCursorReplace) reverseAt(node segmentindex.Node,
+	err error) ([]byte, []byte, error) {
+	if err != nil {
+		s.reverseKey = nil
+		if err == segmentindex.NotFound {
+			return nil, nil, NotFound
+		}
+
+		return nil, nil, err
+	}
+
+	s.reverseKey = node.Key
+	in, err := s.segment.nodeBytes(node)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.segment.replaceStratParseDataWithKeyInto(in, s.reusableNode)
+	if err != nil {
+		return s.reusableNode.primaryKey, nil, err
+	}
+
+	return s.reusableNode.primaryKey, s.reusableNode.value, nil
+}
@@ -0,0 +1,160 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/segmentindex"
+)
+
+type segmentCursorRoaringSet struct {
+	segment *segment
+	nextPos segmentPos
+
+	// reverseKey is the key of the current node when iterating in descending
+	// order, see segmentCursorReplace
+	reverseKey []byte
+}
+
+func (s *segment) newRoaringSetCursor() *segmentCursorRoaringSet {
+	return &segmentCursorRoaringSet{
+		segment: s,
+	}
+}
+
+func (s *SegmentGroup) newRoaringSetCursors() ([]innerCursorRoaringSet, func()) {
+	s.maintenanceLock.RLock()
+	out := make([]innerCursorRoaringSet, len(s.segments))
+
+	for i, segment := range s.segments {
+		out[i] = segment.newRoaringSetCursor()
+	}
+
+	return out, s.maintenanceLock.RUnlock
+}
+
+func (s *segmentCursorRoaringSet) seek(key []byte) ([]byte, roaringset.BitmapLayer, error) {
+	node, err := s.segment.index.Seek(key)
+	if err != nil {
+		if err == segmentindex.NotFound {
+			return nil, roaringset.BitmapLayer{}, NotFound
+		}
+
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	pos := s.segment.nodePos(node)
+	in, err := s.segment.bytesAt(pos)
+	if err != nil {
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	parsed, err := s.segment.roaringSetStratParseData(in)
+	if err != nil {
+		return parsed.primaryKey, roaringset.BitmapLayer{}, err
+	}
+
+	s.nextPos, err = s.segment.advance(pos, parsed.offset)
+	if err != nil {
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	return parsed.primaryKey, parsed.layer(), nil
+}
+
+func (s *segmentCursorRoaringSet) next() ([]byte, roaringset.BitmapLayer, error) {
+	if s.segment.isEndPos(s.nextPos) {
+		return nil, roaringset.BitmapLayer{}, NotFound
+	}
+
+	in, err := s.segment.bytesAt(s.nextPos)
+	if err != nil {
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	parsed, err := s.segment.roaringSetStratParseData(in)
+	if err != nil {
+		return parsed.primaryKey, roaringset.BitmapLayer{}, err
+	}
+
+	s.nextPos, err = s.segment.advance(s.nextPos, parsed.offset)
+	if err != nil {
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	return parsed.primaryKey, parsed.layer(), nil
+}
+
+func (s *segmentCursorRoaringSet) first() ([]byte, roaringset.BitmapLayer, error) {
+	s.nextPos = s.segment.firstPos()
+	if s.segment.isEndPos(s.nextPos) {
+		return nil, roaringset.BitmapLayer{}, NotFound
+	}
+
+	in, err := s.segment.bytesAt(s.nextPos)
+	if err != nil {
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	parsed, err := s.segment.roaringSetStratParseData(in)
+	if err != nil {
+		return parsed.primaryKey, roaringset.BitmapLayer{}, err
+	}
+
+	s.nextPos, err = s.segment.advance(s.nextPos, parsed.offset)
+	if err != nil {
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	return parsed.primaryKey, parsed.layer(), nil
+}
+
+func (s *segmentCursorRoaringSet) last() ([]byte, roaringset.BitmapLayer, error) {
+	return s.reverseAt(s.segment.index.Last())
+}
+
+func (s *segmentCursorRoaringSet) seekBefore(key []byte) ([]byte, roaringset.BitmapLayer, error) {
+	return s.reverseAt(s.segment.index.SeekBefore(key))
+}
+
+func (s *segmentCursorRoaringSet) prev() ([]byte, roaringset.BitmapLayer, error) {
+	if s.reverseKey == nil {
+		return nil, roaringset.BitmapLayer{}, NotFound
+	}
+
+	return s.seekBefore(s.reverseKey)
+}
+
+func (s *segmentCursorRoaringSet) reverseAt(node segmentindex.Node,
+	err error) ([]byte, roaringset.BitmapLayer, error) {
+	if err != nil {
+		s.reverseKey = nil
+		if err == segmentindex.NotFound {
+			return nil, roaringset.BitmapLayer{}, NotFound
+		}
+
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	s.reverseKey = node.Key
+	in, err := s.segment.nodeBytes(node)
+	if err != nil {
+		return nil, roaringset.BitmapLayer{}, err
+	}
+
+	parsed, err := s.segment.roaringSetStratParseData(in)
+	if err != nil {
+		return parsed.primaryKey, roaringset.BitmapLayer{}, err
+	}
+
+	return parsed.primaryKey, parsed.layer(), nil
+}
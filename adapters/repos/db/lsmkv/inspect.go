@@ -0,0 +1,312 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// The functions in this file are meant for offline inspection of buckets
+// which are not loaded by a running Store, e.g. in a copy of a data
+// directory. None of them modify the inspected files.
+
+// SegmentInfo describes a single disk segment
+type SegmentInfo struct {
+	Path             string
+	Size             int64
+	Level            uint16
+	Strategy         string
+	SecondaryIndices uint16
+	Compressed       bool
+	Checksummed      bool
+	DataSize         uint64
+	IndexSize        uint64
+	Keys             int
+
+	// BloomFilterBytes is the memory used by the bloom filters of the
+	// segment, they are built from the indexes when the segment is loaded
+	BloomFilterBytes uint64
+}
+
+// FileInfo describes a file which is not a segment, such as a commit log
+type FileInfo struct {
+	Path string
+	Size int64
+}
+
+// BucketInfo describes the files of a bucket
+type BucketInfo struct {
+	Dir      string
+	Strategy string
+	Segments []SegmentInfo
+	WALs     []FileInfo
+}
+
+// InspectBucket lists the segments and commit logs of the bucket in dir in
+// the order a Bucket would load them
+func InspectBucket(dir string, logger logrus.FieldLogger) (*BucketInfo, error) {
+	strategy, err := bucketStrategyOnDisk(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "detect strategy")
+	}
+
+	segments, wals, err := bucketFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &BucketInfo{Dir: dir, Strategy: strategy}
+	for _, path := range segments {
+		info, err := InspectSegment(path, logger)
+		if err != nil {
+			return nil, errors.Wrapf(err, "segment %s", filepath.Base(path))
+		}
+
+		out.Segments = append(out.Segments, info)
+	}
+
+	for _, path := range wals {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		out.WALs = append(out.WALs, FileInfo{Path: path, Size: stat.Size()})
+	}
+
+	return out, nil
+}
+
+// InspectSegment loads the segment at path on its own and describes it
+func InspectSegment(path string, logger logrus.FieldLogger) (SegmentInfo, error) {
+	seg, err := newSegment(path, logger)
+	if err != nil {
+		return SegmentInfo{}, err
+	}
+	defer seg.close()
+
+	keys, err := seg.index.AllKeys()
+	if err != nil {
+		return SegmentInfo{}, errors.Wrap(err, "read primary index")
+	}
+
+	out := SegmentInfo{
+		Path:             path,
+		Size:             int64(len(seg.contents)),
+		Level:            seg.level,
+		Strategy:         seg.strategy.String(),
+		SecondaryIndices: seg.secondaryIndexCount,
+		Compressed:       seg.compressed,
+		Checksummed:      seg.checksummed,
+		DataSize:         seg.dataEndPos - seg.dataStartPos,
+		IndexSize:        seg.indexEndPos - seg.segmentStartPos,
+		Keys:             len(keys),
+		BloomFilterBytes: uint64(seg.bloomFilter.Cap() / 8),
+	}
+
+	for _, bf := range seg.secondaryBloomFilters {
+		out.BloomFilterBytes += uint64(bf.Cap() / 8)
+	}
+
+	return out, nil
+}
+
+// SegmentVerification is the outcome of VerifySegment
+type SegmentVerification struct {
+	Path string
+
+	// Checksums is one of the ScrubStatus values
+	Checksums string
+
+	// BloomFilterKeys is the number of index keys that were confirmed to be
+	// contained in the bloom filters
+	BloomFilterKeys int
+
+	Error error
+}
+
+func (v SegmentVerification) OK() bool {
+	return v.Error == nil
+}
+
+// VerifySegment checks the segment at path against its checksums and makes
+// sure that every key of its indexes is contained in the bloom filter of the
+// index. A bloom filter that misses a key would make reads skip the segment.
+func VerifySegment(path string, logger logrus.FieldLogger) SegmentVerification {
+	out := SegmentVerification{Path: path, Checksums: ScrubStatusOK}
+
+	seg, err := newSegment(path, logger)
+	if err != nil {
+		// the header and indexes are verified on load
+		out.Checksums = ScrubStatusCorrupt
+		out.Error = err
+		return out
+	}
+	defer seg.close()
+
+	if err := seg.verify(nil); err != nil {
+		if err == errSegmentNotChecksummed {
+			out.Checksums = ScrubStatusUnverified
+		} else {
+			out.Checksums = ScrubStatusCorrupt
+			out.Error = err
+			return out
+		}
+	}
+
+	n, err := verifyBloomFilter(seg.index, seg.bloomFilter.Test)
+	out.BloomFilterKeys += n
+	if err != nil {
+		out.Error = errors.Wrap(err, "primary index")
+		return out
+	}
+
+	for i := range seg.secondaryIndices {
+		n, err := verifyBloomFilter(seg.secondaryIndices[i],
+			seg.secondaryBloomFilters[i].Test)
+		out.BloomFilterKeys += n
+		if err != nil {
+			out.Error = errors.Wrapf(err, "secondary index %d", i)
+			return out
+		}
+	}
+
+	return out
+}
+
+func verifyBloomFilter(index diskIndex, test func(key []byte) bool) (int, error) {
+	keys, err := index.AllKeys()
+	if err != nil {
+		return 0, errors.Wrap(err, "read keys")
+	}
+
+	for i, key := range keys {
+		if !test(key) {
+			return i, errors.Errorf("bloom filter is missing key %x", key)
+		}
+	}
+
+	return len(keys), nil
+}
+
+// OpenBucketCopy loads the bucket in dir from a copy in scratchDir, which
+// must not exist yet. Loading a bucket is not read-only: it recovers commit
+// logs into a new segment and compactions may start right away. With the
+// copy none of this affects dir. Segments are hard-linked where possible, as
+// they are never modified in place, commit logs are copied. The caller must
+// shut the bucket down and remove scratchDir.
+//
+// The strategy and the number of secondary indexes are detected from the
+// files, opts are applied afterwards and can override them.
+func OpenBucketCopy(ctx context.Context, dir, scratchDir string,
+	logger logrus.FieldLogger, opts ...BucketOption) (*Bucket, error) {
+	strategy, err := bucketStrategyOnDisk(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "detect strategy")
+	}
+
+	segments, wals, err := bucketFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Mkdir(scratchDir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "create scratch dir")
+	}
+
+	var detected []BucketOption
+	if strategy != "" {
+		detected = append(detected, WithStrategy(strategy))
+	}
+
+	for i, path := range segments {
+		target := filepath.Join(scratchDir, filepath.Base(path))
+		if err := linkOrCopyFile(path, target); err != nil {
+			return nil, errors.Wrapf(err, "copy segment %s", filepath.Base(path))
+		}
+
+		if i == 0 {
+			header, err := readSegmentHeaderFile(path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "segment %s", filepath.Base(path))
+			}
+			detected = append(detected, WithSecondaryIndicies(header.secondaryIndices))
+		}
+	}
+
+	for _, path := range wals {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		target := filepath.Join(scratchDir, filepath.Base(path))
+		if err := copyFile(path, target, stat.Size()); err != nil {
+			return nil, errors.Wrapf(err, "copy commit log %s", filepath.Base(path))
+		}
+	}
+
+	return NewBucket(ctx, scratchDir, logger, append(detected, opts...)...)
+}
+
+// bucketFiles returns the paths of the segments and commit logs in dir,
+// sorted by name which is the order they were created in
+func bucketFiles(dir string) (segments, wals []string, err error) {
+	list, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read bucket dir")
+	}
+
+	for _, fileInfo := range list {
+		switch filepath.Ext(fileInfo.Name()) {
+		case ".db":
+			segments = append(segments, filepath.Join(dir, fileInfo.Name()))
+		case ".wal":
+			wals = append(wals, filepath.Join(dir, fileInfo.Name()))
+		}
+	}
+
+	sort.Strings(segments)
+	sort.Strings(wals)
+	return segments, wals, nil
+}
+
+func readSegmentHeaderFile(path string) (*segmentHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseSegmentHeader(f)
+}
+
+func linkOrCopyFile(src, dst string) error {
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	// most likely a different filesystem, fall back to a copy
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	return copyFile(src, dst, info.Size())
+}
@@ -0,0 +1,139 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectAndVerifyBucketOffline(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer os.RemoveAll(dirName)
+
+	// the bucket ends up with one segment of the keys 0-49 and a commit log
+	// of the keys 50-59
+	b, err := NewBucket(testCtx(), dirName, nullLogger(),
+		WithStrategy(StrategyReplace), WithSecondaryIndicies(1))
+	require.Nil(t, err)
+	// the bucket stays open like in a running process, shutting it down would
+	// flush the commit log
+	defer b.Shutdown(testCtx())
+	b.SetMemtableThreshold(1e9)
+
+	put := func(i int) {
+		secondary := make([]byte, 8)
+		binary.LittleEndian.PutUint64(secondary, uint64(i))
+		require.Nil(t, b.Put([]byte(fmt.Sprintf("key-%03d", i)),
+			[]byte(fmt.Sprintf("value-%03d", i)), WithSecondaryKey(0, secondary)))
+	}
+
+	for i := 0; i < 50; i++ {
+		put(i)
+	}
+	require.Nil(t, b.FlushAndSwitch())
+	for i := 50; i < 60; i++ {
+		put(i)
+	}
+	require.Nil(t, b.WriteWAL())
+	segmentPath := b.disk.segments[0].path
+
+	before := dirContents(t, dirName)
+
+	t.Run("inspect the bucket", func(t *testing.T) {
+		info, err := InspectBucket(dirName, nullLogger())
+		require.Nil(t, err)
+
+		assert.Equal(t, StrategyReplace, info.Strategy)
+		require.Len(t, info.Segments, 1)
+		require.Len(t, info.WALs, 1)
+
+		seg := info.Segments[0]
+		assert.Equal(t, 50, seg.Keys)
+		assert.Equal(t, uint16(1), seg.SecondaryIndices)
+		assert.True(t, seg.Checksummed)
+		assert.True(t, seg.BloomFilterBytes > 0)
+		assert.True(t, info.WALs[0].Size > 0)
+	})
+
+	t.Run("verify the intact segment", func(t *testing.T) {
+		res := VerifySegment(segmentPath, nullLogger())
+		assert.True(t, res.OK())
+		assert.Equal(t, ScrubStatusOK, res.Checksums)
+		// primary and secondary keys
+		assert.Equal(t, 100, res.BloomFilterKeys)
+	})
+
+	t.Run("open a copy of the bucket", func(t *testing.T) {
+		scratch := filepath.Join(dirName+"-scratch", "bucket")
+		require.Nil(t, os.MkdirAll(filepath.Dir(scratch), 0o777))
+		defer os.RemoveAll(filepath.Dir(scratch))
+
+		copied, err := OpenBucketCopy(testCtx(), dirName, scratch, nullLogger())
+		require.Nil(t, err)
+		assert.Equal(t, StrategyReplace, copied.Strategy())
+
+		for _, i := range []int{0, 49, 50, 59} {
+			v, err := copied.Get([]byte(fmt.Sprintf("key-%03d", i)))
+			require.Nil(t, err)
+			assert.Equal(t, []byte(fmt.Sprintf("value-%03d", i)), v)
+		}
+
+		secondary := make([]byte, 8)
+		binary.LittleEndian.PutUint64(secondary, 55)
+		v, err := copied.GetBySecondary(0, secondary)
+		require.Nil(t, err)
+		assert.Equal(t, []byte("value-055"), v)
+
+		require.Nil(t, copied.Shutdown(testCtx()))
+
+		assert.Equal(t, before, dirContents(t, dirName),
+			"the original bucket is unchanged")
+	})
+
+	t.Run("verify a corrupt segment", func(t *testing.T) {
+		f, err := os.OpenFile(segmentPath, os.O_RDWR, 0o666)
+		require.Nil(t, err)
+		_, err = f.WriteAt([]byte{0xFF, 0xFF, 0xFF, 0xFF}, 100)
+		require.Nil(t, err)
+		require.Nil(t, f.Close())
+
+		res := VerifySegment(segmentPath, nullLogger())
+		assert.False(t, res.OK())
+		assert.Equal(t, ScrubStatusCorrupt, res.Checksums)
+	})
+}
+
+func dirContents(t *testing.T, dir string) map[string]int64 {
+	list, err := ioutil.ReadDir(dir)
+	require.Nil(t, err)
+
+	out := map[string]int64{}
+	for _, info := range list {
+		out[info.Name()] = info.Size()
+	}
+	return out
+}
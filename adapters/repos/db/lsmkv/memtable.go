@@ -15,12 +15,14 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
 )
 
 type Memtable struct {
 	sync.RWMutex
 	key                *binarySearchTree
 	keyMulti           *binarySearchTreeMulti
+	roaringSet         *binarySearchTreeRoaringSet
 	primaryIndex       *binarySearchTree
 	commitlog          *commitLogger
 	size               uint64
@@ -28,11 +30,19 @@ type Memtable struct {
 	strategy           string
 	secondaryIndices   uint16
 	secondaryToPrimary []map[string][]byte
+
+	// compressed controls whether the flushed segment is block-compressed
+	compressed bool
+
+	// syncPolicy decides when the commit log is fsynced, if it syncs at all
+	// the flushed segment is fsynced, too, before the commit log is deleted
+	syncPolicy durability.Policy
 }
 
 func newMemtable(path string, strategy string,
-	secondaryIndices uint16) (*Memtable, error) {
-	cl, err := newCommitLogger(path)
+	secondaryIndices uint16, compressed bool,
+	syncPolicy durability.Policy) (*Memtable, error) {
+	cl, err := newCommitLogger(path, syncPolicy)
 	if err != nil {
 		return nil, errors.Wrap(err, "init commit logger")
 	}
@@ -40,11 +50,14 @@ func newMemtable(path string, strategy string,
 	m := &Memtable{
 		key:              &binarySearchTree{},
 		keyMulti:         &binarySearchTreeMulti{},
+		roaringSet:       &binarySearchTreeRoaringSet{},
 		primaryIndex:     &binarySearchTree{}, // todo, sort upfront
 		commitlog:        cl,
 		path:             path,
 		strategy:         strategy,
 		secondaryIndices: secondaryIndices,
+		compressed:       compressed,
+		syncPolicy:       syncPolicy,
 	}
 
 	if m.secondaryIndices > 0 {
@@ -228,9 +241,17 @@ func (l *Memtable) Size() uint64 {
 // on the WAL just once. This does not make a batch atomic, but it guarantees
 // that the WAL is written before a successful response is returned to the
 // user.
+//
+// Depending on the sync policy the WAL is also fsynced before this returns.
+// The fsync happens outside of the memtable lock, so that writers can continue
+// and their own writeWAL calls can share the next fsync.
 func (l *Memtable) writeWAL() error {
 	l.Lock()
-	defer l.Unlock()
+	err := l.commitlog.flushBuffers()
+	l.Unlock()
+	if err != nil {
+		return err
+	}
 
-	return l.commitlog.flushBuffers()
+	return l.commitlog.commit()
 }
@@ -15,6 +15,7 @@ import (
 	"bufio"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 )
@@ -44,27 +45,55 @@ func (l *Memtable) flush() error {
 
 	w := bufio.NewWriterSize(f, int(float64(l.size)*1.3)) // calculate 30% overhead for disk representation
 
+	// write a dummy header, the position of the index is only known once all
+	// nodes have been written. The actual header is written at the very end
+	if _, err := w.Write(make([]byte, SegmentHeaderSize)); err != nil {
+		return errors.Wrap(err, "write empty header")
+	}
+
+	dw := newSegmentDataWriter(w, SegmentHeaderSize, l.compressed)
+
 	var keys []keyIndex
 	switch l.strategy {
 	case StrategyReplace:
-		if keys, err = l.flushDataReplace(w); err != nil {
+		if keys, err = l.flushDataReplace(dw); err != nil {
 			return err
 		}
 
 	case StrategySetCollection, StrategyMapCollection:
-		if keys, err = l.flushDataCollection(w); err != nil {
+		if keys, err = l.flushDataCollection(dw); err != nil {
 			return err
 		}
 
+	case StrategyRoaringSet:
+		if keys, err = l.flushDataRoaringSet(dw); err != nil {
+			return err
+		}
+
+	}
+
+	indexStart, err := dw.close()
+	if err != nil {
+		return errors.Wrap(err, "close data area")
 	}
 
 	indices := &segmentIndices{
 		keys:                keys,
+		indexStart:          uint64(indexStart),
 		secondaryIndexCount: l.secondaryIndices,
 		scratchSpacePath:    l.path + ".scratch.d",
 	}
 
-	if _, err := indices.WriteTo(w); err != nil {
+	header := segmentHeader{
+		indexStart:       uint64(indexStart),
+		level:            0, // always level zero on a new one
+		version:          segmentVersion(l.compressed),
+		secondaryIndices: l.secondaryIndices,
+		strategy:         SegmentStrategyFromString(l.strategy),
+	}
+
+	if err := writeIndicesAndChecksums(w, indices, &header,
+		dw.checksum()); err != nil {
 		return err
 	}
 
@@ -72,10 +101,32 @@ func (l *Memtable) flush() error {
 		return err
 	}
 
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "seek to beginning to write header")
+	}
+
+	if _, err := header.WriteTo(f); err != nil {
+		return errors.Wrap(err, "write header")
+	}
+
+	if l.syncPolicy.SyncsFiles() {
+		// the segment replaces the commit log, it must be as durable as the log
+		// before the log can be deleted
+		if err := f.Sync(); err != nil {
+			return errors.Wrap(err, "fsync segment")
+		}
+	}
+
 	if err := f.Close(); err != nil {
 		return err
 	}
 
+	if l.syncPolicy.SyncsFiles() {
+		if err := syncDir(filepath.Dir(l.path)); err != nil {
+			return errors.Wrap(err, "fsync segment directory")
+		}
+	}
+
 	// only now that the file has been flushed is it safe to delete the commit log
 	// TODO: there might be an interest in keeping the commit logs around for
 	// longer as they might come in handy for replication
@@ -88,113 +139,63 @@ func (l *Memtable) flush() error {
 // for the pointer to the index part
 const SegmentHeaderSize = 16
 
-func (l *Memtable) flushDataReplace(f io.Writer) ([]keyIndex, error) {
+func (l *Memtable) flushDataReplace(dw *segmentDataWriter) ([]keyIndex, error) {
 	flat := l.key.flattenInOrder()
 
-	totalDataLength := totalKeyAndValueSize(flat)
-	perObjectAdditions := len(flat) * (1 + 8 + 4 + int(l.secondaryIndices)*4) // 1 byte for the tombstone, 8 bytes value length encoding, 4 bytes key length encoding, + 4 bytes key encoding for every secondary index
-	headerSize := SegmentHeaderSize
-	header := segmentHeader{
-		indexStart:       uint64(totalDataLength + perObjectAdditions + headerSize),
-		level:            0, // always level zero on a new one
-		version:          0, // always version 0 for now
-		secondaryIndices: l.secondaryIndices,
-		strategy:         SegmentStrategyFromString(l.strategy),
-	}
-
-	n, err := header.WriteTo(f)
-	if err != nil {
-		return nil, err
-	}
-	headerSize = int(n)
 	keys := make([]keyIndex, len(flat))
-
-	totalWritten := headerSize
 	for i, node := range flat {
-		segNode := &segmentReplaceNode{
-			offset:              totalWritten,
+		ki, err := dw.write(&segmentReplaceNode{
 			tombstone:           node.tombstone,
 			value:               node.value,
 			primaryKey:          node.key,
 			secondaryKeys:       node.secondaryKeys,
 			secondaryIndexCount: l.secondaryIndices,
-		}
-
-		ki, err := segNode.KeyIndexAndWriteTo(f)
+		})
 		if err != nil {
 			return nil, errors.Wrapf(err, "write node %d", i)
 		}
 
 		keys[i] = ki
-		totalWritten = ki.valueEnd
 	}
 
 	return keys, nil
 }
 
-func (l *Memtable) flushDataCollection(f io.Writer) ([]keyIndex, error) {
+func (l *Memtable) flushDataCollection(dw *segmentDataWriter) ([]keyIndex, error) {
 	flat := l.keyMulti.flattenInOrder()
 
-	totalDataLength := totalValueSizeCollection(flat)
-	header := segmentHeader{
-		indexStart:       uint64(totalDataLength + SegmentHeaderSize),
-		level:            0, // always level zero on a new one
-		version:          0, // always version 0 for now
-		secondaryIndices: l.secondaryIndices,
-		strategy:         SegmentStrategyFromString(l.strategy),
-	}
-
-	n, err := header.WriteTo(f)
-	if err != nil {
-		return nil, err
-	}
-	headerSize := int(n)
 	keys := make([]keyIndex, len(flat))
-
-	totalWritten := headerSize
 	for i, node := range flat {
-		ki, err := (&segmentCollectionNode{
+		ki, err := dw.write(&segmentCollectionNode{
 			values:     node.values,
 			primaryKey: node.key,
-			offset:     totalWritten,
-		}).KeyIndexAndWriteTo(f)
+		})
 		if err != nil {
 			return nil, errors.Wrapf(err, "write node %d", i)
 		}
 
 		keys[i] = ki
-		totalWritten = ki.valueEnd
 	}
 
 	return keys, nil
 }
 
-func totalKeyAndValueSize(in []*binarySearchNode) int {
-	var sum int
-	for _, n := range in {
-		sum += len(n.value)
-		sum += len(n.key)
-		for _, sec := range n.secondaryKeys {
-			sum += len(sec)
-		}
-	}
-
-	return sum
-}
+func (l *Memtable) flushDataRoaringSet(dw *segmentDataWriter) ([]keyIndex, error) {
+	flat := l.roaringSet.flattenInOrder()
 
-func totalValueSizeCollection(in []*binarySearchNodeMulti) int {
-	var sum int
-	for _, n := range in {
-		sum += 8 // uint64 to indicate array length
-		for _, v := range n.values {
-			sum += 1 // bool to indicate value tombstone
-			sum += 8 // uint64 to indicate value length
-			sum += len(v.value)
+	keys := make([]keyIndex, len(flat))
+	for i, node := range flat {
+		ki, err := dw.write(&segmentRoaringSetNode{
+			additions:  node.value.Additions,
+			deletions:  node.value.Deletions,
+			primaryKey: node.key,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "write node %d", i)
 		}
 
-		sum += 4 // uint32 to indicate key size
-		sum += len(n.key)
+		keys[i] = ki
 	}
 
-	return sum
+	return keys, nil
 }
@@ -0,0 +1,84 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
+)
+
+func (l *Memtable) roaringSetAddList(key []byte, values []uint64) error {
+	change := roaringset.NewBitmapLayer()
+	change.Additions.AddMany(values)
+	return l.roaringSetApply(key, change)
+}
+
+func (l *Memtable) roaringSetAddBitmap(key []byte, bm *roaring64.Bitmap) error {
+	change := roaringset.NewBitmapLayer()
+	change.Additions.Or(bm)
+	return l.roaringSetApply(key, change)
+}
+
+func (l *Memtable) roaringSetRemoveList(key []byte, values []uint64) error {
+	change := roaringset.NewBitmapLayer()
+	change.Deletions.AddMany(values)
+	return l.roaringSetApply(key, change)
+}
+
+func (l *Memtable) roaringSetApply(key []byte,
+	change roaringset.BitmapLayer) error {
+	if l.strategy != StrategyRoaringSet {
+		return errors.Errorf("roaring set changes only possible with strategy %q",
+			StrategyRoaringSet)
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if err := l.commitlog.roaringSet(segmentRoaringSetNode{
+		primaryKey: key,
+		additions:  change.Additions,
+		deletions:  change.Deletions,
+	}); err != nil {
+		return errors.Wrap(err, "write into commit log")
+	}
+
+	l.roaringSet.insert(key, change)
+	l.size += uint64(len(key))
+	l.size += uint64(8 * (change.Additions.GetCardinality() +
+		change.Deletions.GetCardinality()))
+
+	return nil
+}
+
+// roaringSetGet returns a copy of the layer, so it can be used after the
+// memtable lock was released
+func (l *Memtable) roaringSetGet(key []byte) (roaringset.BitmapLayer, error) {
+	if l.strategy != StrategyRoaringSet {
+		return roaringset.BitmapLayer{}, errors.Errorf(
+			"roaringSetGet only possible with strategy %q", StrategyRoaringSet)
+	}
+
+	l.RLock()
+	defer l.RUnlock()
+
+	layer, err := l.roaringSet.get(key)
+	if err != nil {
+		return layer, err
+	}
+
+	return roaringset.BitmapLayer{
+		Additions: layer.Additions.Clone(),
+		Deletions: layer.Deletions.Clone(),
+	}, nil
+}
@@ -0,0 +1,68 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+// Package roaringset contains the layering logic required to store roaring
+// bitmaps of uint64 values in an LSM store
+package roaringset
+
+import "github.com/RoaringBitmap/roaring/roaring64"
+
+// BitmapLayer is the change a single memtable or disk segment makes to the
+// bitmap of a key. Within one layer additions and deletions never overlap,
+// a later write for the same value replaces an earlier one.
+type BitmapLayer struct {
+	Additions *roaring64.Bitmap
+	Deletions *roaring64.Bitmap
+}
+
+func NewBitmapLayer() BitmapLayer {
+	return BitmapLayer{
+		Additions: roaring64.New(),
+		Deletions: roaring64.New(),
+	}
+}
+
+// Apply merges a newer change into the layer in place
+func (l *BitmapLayer) Apply(newer BitmapLayer) {
+	l.Additions.AndNot(newer.Deletions)
+	l.Additions.Or(newer.Additions)
+	l.Deletions.AndNot(newer.Additions)
+	l.Deletions.Or(newer.Deletions)
+}
+
+// BitmapLayers are ordered from oldest to newest
+type BitmapLayers []BitmapLayer
+
+// Flatten applies all layers in order and returns the resulting bitmap. The
+// deletions of a layer only affect older layers, the result therefore does
+// not contain any deletions.
+func (bml BitmapLayers) Flatten() *roaring64.Bitmap {
+	out := roaring64.New()
+	for _, layer := range bml {
+		out.AndNot(layer.Deletions)
+		out.Or(layer.Additions)
+	}
+
+	return out
+}
+
+// Merge combines all layers into a single one which has the same effect as
+// the individual layers when applied on top of older layers. This is what
+// compacting two segments requires: unlike Flatten it has to keep the
+// deletions, as they could still refer to values in even older segments.
+func (bml BitmapLayers) Merge() BitmapLayer {
+	out := NewBitmapLayer()
+	for _, layer := range bml {
+		out.Apply(layer)
+	}
+
+	return out
+}
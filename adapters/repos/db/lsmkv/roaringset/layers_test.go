@@ -0,0 +1,55 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package roaringset
+
+import (
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitmapLayers(t *testing.T) {
+	layers := BitmapLayers{
+		{
+			Additions: roaring64.BitmapOf(1, 2, 3, 4),
+			Deletions: roaring64.BitmapOf(),
+		},
+		{
+			Additions: roaring64.BitmapOf(5),
+			Deletions: roaring64.BitmapOf(2, 3),
+		},
+		{
+			Additions: roaring64.BitmapOf(3),
+			Deletions: roaring64.BitmapOf(4, 5),
+		},
+	}
+
+	t.Run("flatten", func(t *testing.T) {
+		assert.Equal(t, []uint64{1, 3}, layers.Flatten().ToArray())
+	})
+
+	t.Run("merge the newer layers", func(t *testing.T) {
+		merged := layers[1:].Merge()
+		assert.Equal(t, []uint64{3}, merged.Additions.ToArray())
+		assert.Equal(t, []uint64{2, 4, 5}, merged.Deletions.ToArray(),
+			"deletions are kept for the older layers")
+
+		assert.Equal(t, layers.Flatten().ToArray(),
+			BitmapLayers{layers[0], merged}.Flatten().ToArray())
+	})
+
+	t.Run("layers are left untouched", func(t *testing.T) {
+		assert.Equal(t, []uint64{5}, layers[1].Additions.ToArray())
+		assert.Equal(t, []uint64{2, 3}, layers[1].Deletions.ToArray())
+	})
+}
@@ -14,6 +14,7 @@ package lsmkv
 import (
 	"bytes"
 	"os"
+	"sync"
 	"syscall"
 	"time"
 
@@ -44,6 +45,27 @@ type segment struct {
 	index                 diskIndex
 	secondaryIndices      []diskIndex
 	logger                logrus.FieldLogger
+
+	// compressed segments store the data area in blocks, see
+	// segment_compression.go for details
+	compressed bool
+
+	// checksummed segments end with a checksum trailer, see
+	// segment_checksums.go. indexEndPos is the start of the trailer, or the end
+	// of the file for segments without checksums
+	checksummed bool
+	indexEndPos uint64
+	checksums   segmentChecksums
+
+	// countNetAdditions is only maintained for the replace strategy, see
+	// segment_count.go
+	countNetAdditions int64
+
+	blockCache struct {
+		sync.Mutex
+		offset uint64
+		data   []byte
+	}
 }
 
 type diskIndex interface {
@@ -55,6 +77,13 @@ type diskIndex interface {
 	// value (or the exact value if present)
 	Seek(key []byte) (segmentindex.Node, error)
 
+	// SeekBefore returns segmentindex.NotFound in case there is no value which
+	// is smaller than the seek value, otherwise it returns the next lowest value
+	SeekBefore(key []byte) (segmentindex.Node, error)
+
+	// Last returns segmentindex.NotFound in case the collection is empty
+	Last() (segmentindex.Node, error)
+
 	// AllKeys in no specific order, e.g. for building a bloom filter
 	AllKeys() ([][]byte, error)
 }
@@ -75,19 +104,51 @@ func newSegment(path string, logger logrus.FieldLogger) (*segment, error) {
 		return nil, errors.Wrap(err, "mmap file")
 	}
 
+	if len(content) < SegmentHeaderSize {
+		return nil, errors.Errorf("file of %d bytes is too small for a segment",
+			len(content))
+	}
+
 	header, err := parseSegmentHeader(bytes.NewReader(content[:SegmentHeaderSize]))
 	if err != nil {
 		return nil, errors.Wrap(err, "parse header")
 	}
 
+	checksummed := header.version&segmentVersionChecksummed != 0
+	indexEnd := uint64(len(content))
+	if checksummed {
+		if indexEnd < SegmentHeaderSize+segmentChecksumsSize {
+			return nil, errors.Errorf("file of %d bytes is too small for a "+
+				"checksummed segment", len(content))
+		}
+		indexEnd -= segmentChecksumsSize
+	}
+
+	if header.indexStart < SegmentHeaderSize ||
+		header.secondaryIndexOffsetsEnd() > indexEnd {
+		return nil, errors.Errorf("index start %d outside of file of %d bytes",
+			header.indexStart, len(content))
+	}
+
+	var checksums segmentChecksums
+	if checksummed {
+		checksums = parseSegmentChecksums(content[indexEnd:])
+		if err := verifySegmentHeaderAndIndex(header, checksums,
+			content[header.indexStart:indexEnd]); err != nil {
+			return nil, err
+		}
+	}
+
 	switch header.strategy {
 	case SegmentStrategyReplace, SegmentStrategySetCollection,
-		SegmentStrategyMapCollection:
+		SegmentStrategyMapCollection, SegmentStrategyRoaringSet:
 	default:
 		return nil, errors.Errorf("unsupported strategy in segment")
 	}
 
-	primaryIndex, err := header.PrimaryIndex(content)
+	// the indexes are read from the file without the trailer, the last index
+	// ends where the trailer starts
+	primaryIndex, err := header.PrimaryIndex(content[:indexEnd])
 	if err != nil {
 		return nil, errors.Wrap(err, "extract primary index position")
 	}
@@ -107,13 +168,17 @@ func newSegment(path string, logger logrus.FieldLogger) (*segment, error) {
 		dataEndPos:          header.indexStart,
 		index:               primaryDiskIndex,
 		logger:              logger,
+		compressed:          header.version&segmentVersionCompressed != 0,
+		checksummed:         checksummed,
+		indexEndPos:         indexEnd,
+		checksums:           checksums,
 	}
 
 	if ind.secondaryIndexCount > 0 {
 		ind.secondaryIndices = make([]diskIndex, ind.secondaryIndexCount)
 		ind.secondaryBloomFilters = make([]*bloom.BloomFilter, ind.secondaryIndexCount)
 		for i := range ind.secondaryIndices {
-			secondary, err := header.SecondaryIndex(content, uint16(i))
+			secondary, err := header.SecondaryIndex(content[:indexEnd], uint16(i))
 			if err != nil {
 				return nil, errors.Wrapf(err, "get position for secondary index at %d", i)
 			}
@@ -178,5 +243,9 @@ func (ind *segment) close() error {
 }
 
 func (ind *segment) drop() error {
+	if err := ind.dropCountNetAdditions(); err != nil {
+		return errors.Wrap(err, "drop net additions")
+	}
+
 	return os.Remove(ind.path)
 }
@@ -0,0 +1,161 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ErrChecksumMismatch indicates that the contents of a segment do not match
+// the checksums written along with it, i.e. the file is corrupted
+var ErrChecksumMismatch = errors.Errorf("checksum mismatch")
+
+// segmentChecksums is the trailer of a checksummed segment. It holds the
+// crc32 (IEEE) of the header, of the data area and of the index area, which
+// contains the primary and all secondary segmentindex trees. The header and
+// the index are verified when the segment is loaded, as both are small
+// compared to the data and a corrupted index would lead to garbage results
+// for all reads. The data area is verified by a scrub. Additionally the
+// blocks of compressed segments carry their own checksum, which is verified
+// on every read.
+type segmentChecksums struct {
+	header uint32
+	data   uint32
+	index  uint32
+}
+
+const segmentChecksumsSize = 12
+
+func (c segmentChecksums) WriteTo(w io.Writer) (int64, error) {
+	buf := make([]byte, segmentChecksumsSize)
+	binary.LittleEndian.PutUint32(buf[0:4], c.header)
+	binary.LittleEndian.PutUint32(buf[4:8], c.data)
+	binary.LittleEndian.PutUint32(buf[8:12], c.index)
+
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+func parseSegmentChecksums(in []byte) segmentChecksums {
+	return segmentChecksums{
+		header: binary.LittleEndian.Uint32(in[0:4]),
+		data:   binary.LittleEndian.Uint32(in[4:8]),
+		index:  binary.LittleEndian.Uint32(in[8:12]),
+	}
+}
+
+func (h *segmentHeader) checksum() uint32 {
+	buf := bytes.NewBuffer(make([]byte, 0, SegmentHeaderSize))
+	// writing to a bytes.Buffer can't fail
+	h.WriteTo(buf)
+	return crc32.ChecksumIEEE(buf.Bytes())
+}
+
+// writeIndicesAndChecksums writes the index area of a new segment followed by
+// the checksum trailer. It is the last part of the file, only the header at
+// the very beginning is written afterwards.
+func writeIndicesAndChecksums(w io.Writer, indices *segmentIndices,
+	header *segmentHeader, dataChecksum uint32) error {
+	crc := crc32.NewIEEE()
+	if _, err := indices.WriteTo(io.MultiWriter(w, crc)); err != nil {
+		return err
+	}
+
+	checksums := segmentChecksums{
+		header: header.checksum(),
+		data:   dataChecksum,
+		index:  crc.Sum32(),
+	}
+
+	if _, err := checksums.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write checksums")
+	}
+
+	return nil
+}
+
+func verifySegmentHeaderAndIndex(header *segmentHeader,
+	checksums segmentChecksums, index []byte) error {
+	if header.checksum() != checksums.header {
+		return errors.Wrap(ErrChecksumMismatch, "segment header")
+	}
+
+	if crc32.ChecksumIEEE(index) != checksums.index {
+		return errors.Wrap(ErrChecksumMismatch, "segment index")
+	}
+
+	return nil
+}
+
+// scrubChunkSize is the amount of data checksummed at once when verifying
+// the data area. Between chunks a scrub waits for the rate limiter.
+const scrubChunkSize = 1024 * 1024
+
+// verify checks the entire segment against its checksums. Contrary to the
+// checks on load, this also reads the whole data area, so it is throttled by
+// the limiter (if set). Segments without checksums can't be verified, they
+// return errSegmentNotChecksummed.
+func (s *segment) verify(limiter *CompactionRateLimiter) error {
+	if !s.checksummed {
+		return errSegmentNotChecksummed
+	}
+
+	header, err := parseSegmentHeader(bytes.NewReader(s.contents[:SegmentHeaderSize]))
+	if err != nil {
+		return errors.Wrap(err, "parse header")
+	}
+
+	if err := verifySegmentHeaderAndIndex(header, s.checksums,
+		s.contents[s.dataEndPos:s.indexEndPos]); err != nil {
+		return err
+	}
+
+	crc := crc32.NewIEEE()
+	for pos := s.dataStartPos; pos < s.dataEndPos; pos += scrubChunkSize {
+		end := pos + scrubChunkSize
+		if end > s.dataEndPos {
+			end = s.dataEndPos
+		}
+
+		limiter.wait(int(end - pos))
+		crc.Write(s.contents[pos:end])
+	}
+
+	if crc.Sum32() != s.checksums.data {
+		return errors.Wrap(ErrChecksumMismatch, "segment data")
+	}
+
+	if !s.compressed {
+		return nil
+	}
+
+	// the data area as a whole is intact, so this only fails if a block was
+	// already written incorrectly, e.g. because of a bug
+	for pos := s.firstPos(); !s.isEndPos(pos); {
+		if _, err := s.decompressBlock(pos.offset); err != nil {
+			return err
+		}
+
+		compressedLen := binary.LittleEndian.Uint32(
+			s.contents[pos.offset : pos.offset+4])
+		pos.offset += blockHeaderSize + uint64(compressedLen)
+	}
+
+	return nil
+}
+
+var errSegmentNotChecksummed = errors.Errorf("segment has no checksums")
@@ -0,0 +1,296 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentChecksums(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+
+	type test struct {
+		name       string
+		compressed bool
+	}
+
+	tests := []test{
+		{name: "uncompressed", compressed: false},
+		{name: "compressed", compressed: true},
+	}
+
+	// newBucket creates a bucket with two segments, the older one contains
+	// the keys 0-99, the newer one overwrites the keys 50-99
+	newBucket := func(t *testing.T, dirName string, compressed bool) *Bucket {
+		opts := []BucketOption{WithStrategy(StrategyReplace)}
+		if compressed {
+			opts = append(opts, WithBlockCompression())
+		}
+
+		b, err := NewBucket(testCtx(), dirName, nullLogger(), opts...)
+		require.Nil(t, err)
+
+		// so big it effectively never triggers as part of this test
+		b.SetMemtableThreshold(1e9)
+
+		for i := 0; i < 100; i++ {
+			require.Nil(t, b.Put([]byte(fmt.Sprintf("key-%03d", i)),
+				[]byte(fmt.Sprintf("old-value-%03d", i))))
+		}
+		require.Nil(t, b.FlushAndSwitch())
+
+		for i := 50; i < 100; i++ {
+			require.Nil(t, b.Put([]byte(fmt.Sprintf("key-%03d", i)),
+				[]byte(fmt.Sprintf("new-value-%03d", i))))
+		}
+		require.Nil(t, b.FlushAndSwitch())
+
+		require.Len(t, b.disk.segments, 2)
+		return b
+	}
+
+	// corrupt flips a single byte in the file, the segment is mmapped with
+	// MAP_SHARED, so the change is immediately visible to a loaded segment
+	corrupt := func(t *testing.T, path string, offset uint64) {
+		f, err := os.OpenFile(path, os.O_RDWR, 0o666)
+		require.Nil(t, err)
+		defer f.Close()
+
+		b := make([]byte, 1)
+		_, err = f.ReadAt(b, int64(offset))
+		require.Nil(t, err)
+		b[0] ^= 0xFF
+		_, err = f.WriteAt(b, int64(offset))
+		require.Nil(t, err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Run("a scrub of intact segments", func(t *testing.T) {
+				dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+				os.MkdirAll(dirName, 0o777)
+				defer os.RemoveAll(dirName)
+
+				b := newBucket(t, dirName, test.compressed)
+				defer b.Shutdown(testCtx())
+
+				res, err := b.Scrub(true)
+				require.Nil(t, err)
+				require.Len(t, res, 2)
+				for _, r := range res {
+					assert.Equal(t, ScrubStatusOK, r.Status)
+					assert.Nil(t, r.Error)
+					assert.False(t, r.Quarantined)
+				}
+			})
+
+			t.Run("corrupt data is found and quarantined by a scrub", func(t *testing.T) {
+				dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+				os.MkdirAll(dirName, 0o777)
+				defer os.RemoveAll(dirName)
+
+				b := newBucket(t, dirName, test.compressed)
+				defer b.Shutdown(testCtx())
+
+				newer := b.disk.segments[1]
+				corrupt(t, newer.path, (newer.dataStartPos+newer.dataEndPos)/2)
+
+				res, err := b.Scrub(false)
+				require.Nil(t, err)
+				require.Len(t, res, 2)
+				assert.Equal(t, ScrubStatusOK, res[0].Status)
+				assert.Equal(t, ScrubStatusCorrupt, res[1].Status)
+				assert.True(t, errors.Is(res[1].Error, ErrChecksumMismatch))
+				assert.False(t, res[1].Quarantined)
+				require.Len(t, b.disk.segments, 2, "nothing quarantined yet")
+
+				res, err = b.Scrub(true)
+				require.Nil(t, err)
+				require.Len(t, res, 2)
+				assert.Equal(t, ScrubStatusCorrupt, res[1].Status)
+				assert.True(t, res[1].Quarantined)
+
+				require.Len(t, b.disk.segments, 1)
+				_, err = os.Stat(filepath.Join(dirName, quarantineDir,
+					filepath.Base(newer.path)))
+				assert.Nil(t, err, "segment was moved to quarantine")
+
+				// the older versions of the overwritten keys resurface
+				v, err := b.Get([]byte("key-075"))
+				require.Nil(t, err)
+				assert.Equal(t, []byte("old-value-075"), v)
+
+				res, err = b.Scrub(true)
+				require.Nil(t, err)
+				require.Len(t, res, 1)
+				assert.Equal(t, ScrubStatusOK, res[0].Status)
+			})
+
+			t.Run("a quarantined segment is not loaded again", func(t *testing.T) {
+				dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+				os.MkdirAll(dirName, 0o777)
+				defer os.RemoveAll(dirName)
+
+				b := newBucket(t, dirName, test.compressed)
+				newer := b.disk.segments[1]
+				corrupt(t, newer.path, newer.dataStartPos)
+
+				_, err := b.Scrub(true)
+				require.Nil(t, err)
+				require.Nil(t, b.Shutdown(testCtx()))
+
+				b, err = NewBucket(testCtx(), dirName, nullLogger(),
+					WithStrategy(StrategyReplace))
+				require.Nil(t, err)
+				defer b.Shutdown(testCtx())
+
+				assert.Len(t, b.disk.segments, 1)
+			})
+
+			t.Run("corrupt headers and indexes are found on load", func(t *testing.T) {
+				offsets := map[string]func(s *segment) uint64{
+					"header": func(s *segment) uint64 { return 0 },
+					"primary index": func(s *segment) uint64 {
+						return (s.dataEndPos + s.indexEndPos) / 2
+					},
+					"checksums": func(s *segment) uint64 { return s.indexEndPos },
+				}
+
+				for name, offset := range offsets {
+					t.Run(name, func(t *testing.T) {
+						dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+						os.MkdirAll(dirName, 0o777)
+						defer os.RemoveAll(dirName)
+
+						b := newBucket(t, dirName, test.compressed)
+						older := b.disk.segments[0]
+						path, pos := older.path, offset(older)
+						require.Nil(t, b.Shutdown(testCtx()))
+
+						corrupt(t, path, pos)
+
+						_, err := newSegment(path, nullLogger())
+						require.NotNil(t, err)
+						assert.True(t, errors.Is(err, ErrChecksumMismatch))
+					})
+				}
+			})
+		})
+	}
+
+	t.Run("a compressed block is verified on read", func(t *testing.T) {
+		dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+		os.MkdirAll(dirName, 0o777)
+		defer os.RemoveAll(dirName)
+
+		b := newBucket(t, dirName, true)
+		defer b.Shutdown(testCtx())
+
+		older := b.disk.segments[0]
+		pos := older.firstPos()
+		compressedLen := binary.LittleEndian.Uint32(
+			older.contents[pos.offset : pos.offset+4])
+		corrupt(t, older.path, pos.offset+blockHeaderSize+
+			uint64(compressedLen)/2)
+
+		// make sure the block is read from the file
+		older.blockCache.data = nil
+		_, err := older.block(pos.offset)
+		assert.True(t, errors.Is(err, ErrChecksumMismatch))
+	})
+
+	t.Run("compressed segments without checksums are rejected", func(t *testing.T) {
+		dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+		os.MkdirAll(dirName, 0o777)
+		defer os.RemoveAll(dirName)
+
+		b := newBucket(t, dirName, true)
+		path := b.disk.segments[0].path
+		require.Nil(t, b.Shutdown(testCtx()))
+
+		f, err := os.OpenFile(path, os.O_RDWR, 0o666)
+		require.Nil(t, err)
+		version := make([]byte, 2)
+		binary.LittleEndian.PutUint16(version, segmentVersionCompressed)
+		_, err = f.WriteAt(version, 2)
+		require.Nil(t, err)
+		require.Nil(t, f.Close())
+
+		_, err = NewBucket(testCtx(), dirName, nullLogger(),
+			WithStrategy(StrategyReplace))
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unsupported version")
+	})
+
+	t.Run("segments without checksums are still supported", func(t *testing.T) {
+		dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+		os.MkdirAll(dirName, 0o777)
+		defer os.RemoveAll(dirName)
+
+		b := newBucket(t, dirName, false)
+		paths := []string{b.disk.segments[0].path, b.disk.segments[1].path}
+		require.Nil(t, b.Shutdown(testCtx()))
+
+		// turn the segments into the format of previous versions by removing
+		// the trailer and the checksummed flag, the layout is otherwise
+		// identical for uncompressed segments
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			require.Nil(t, err)
+			require.Nil(t, os.Truncate(path, info.Size()-segmentChecksumsSize))
+
+			f, err := os.OpenFile(path, os.O_RDWR, 0o666)
+			require.Nil(t, err)
+			version := make([]byte, 2)
+			binary.LittleEndian.PutUint16(version, segmentVersionUncompressed)
+			_, err = f.WriteAt(version, 2)
+			require.Nil(t, err)
+			require.Nil(t, f.Close())
+		}
+
+		b, err := NewBucket(testCtx(), dirName, nullLogger(),
+			WithStrategy(StrategyReplace))
+		require.Nil(t, err)
+		defer b.Shutdown(testCtx())
+
+		for i := 0; i < 100; i++ {
+			v, err := b.Get([]byte(fmt.Sprintf("key-%03d", i)))
+			require.Nil(t, err)
+			prefix := "old"
+			if i >= 50 {
+				prefix = "new"
+			}
+			assert.Equal(t, []byte(fmt.Sprintf("%s-value-%03d", prefix, i)), v)
+		}
+
+		res, err := b.Scrub(true)
+		require.Nil(t, err)
+		require.Len(t, res, 2)
+		for _, r := range res {
+			assert.Equal(t, ScrubStatusUnverified, r.Status)
+			assert.False(t, r.Quarantined)
+		}
+	})
+}
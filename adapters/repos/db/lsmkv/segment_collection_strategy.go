@@ -39,7 +39,12 @@ func (i *segment) getCollection(key []byte) ([]value, error) {
 		}
 	}
 
-	return i.collectionStratParseData(i.contents[node.Start:node.End])
+	in, err := i.nodeBytes(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.collectionStratParseData(in)
 }
 
 func (i *segment) collectionStratParseData(in []byte) ([]value, error) {
@@ -0,0 +1,315 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/segmentindex"
+)
+
+// The version in the segment header is a set of flags which describe the
+// layout of the segment.
+//
+// In an uncompressed segment the index points at the start and end of a node
+// in the file. In a block-compressed segment nodes are grouped into blocks
+// which are compressed individually. The index then points at the start of
+// the block in the file (Start) and at the position of the node inside the
+// decompressed block (End).
+//
+// A block is encoded as [uint32 compressed len][uint32 decompressed len]
+// [uint32 crc32 of the flate data][flate data]. A node never spans more than
+// one block.
+//
+// Checksummed segments end with a trailer, see segment_checksums.go. New
+// segments are always checksummed. Uncompressed segments written by earlier
+// versions are still read, but can't be verified. Compression was never
+// released without checksums, a compressed segment is therefore always
+// checksummed.
+const (
+	segmentVersionUncompressed uint16 = 0
+	segmentVersionCompressed   uint16 = 1 << 0
+	segmentVersionChecksummed  uint16 = 1 << 1
+
+	segmentVersionAllFlags = segmentVersionCompressed | segmentVersionChecksummed
+)
+
+const (
+	// blockHeaderSize is the size of the lengths and the checksum in front of
+	// a block
+	blockHeaderSize = 12
+
+	// blocks are cut once they contain at least this many uncompressed bytes.
+	// Larger blocks compress better, smaller blocks are cheaper to read for a
+	// single key.
+	blockTargetSize = 32 * 1024
+)
+
+func segmentVersion(compressed bool) uint16 {
+	if compressed {
+		return segmentVersionCompressed | segmentVersionChecksummed
+	}
+
+	return segmentVersionUncompressed | segmentVersionChecksummed
+}
+
+// segmentDataWriter writes the nodes of a segment's data area, starting at
+// the given offset. It takes care of the key index positions, so the offsets
+// set on the individual nodes are ignored.
+type segmentDataWriter struct {
+	// w writes to both the underlying writer and crc
+	w          io.Writer
+	crc        hash.Hash32
+	compressed bool
+
+	// uncompressed: the position of the next node in the file. compressed: the
+	// position of the current block in the file
+	offset int
+
+	block      *bytes.Buffer
+	compressor *flate.Writer
+}
+
+type segmentNodeWriter interface {
+	KeyIndexAndWriteTo(w io.Writer) (keyIndex, error)
+}
+
+func newSegmentDataWriter(w io.Writer, offset int,
+	compressed bool) *segmentDataWriter {
+	crc := crc32.NewIEEE()
+	return &segmentDataWriter{
+		w:          io.MultiWriter(w, crc),
+		crc:        crc,
+		compressed: compressed,
+		offset:     offset,
+		block:      new(bytes.Buffer),
+	}
+}
+
+func (d *segmentDataWriter) write(node segmentNodeWriter) (keyIndex, error) {
+	if !d.compressed {
+		ki, err := node.KeyIndexAndWriteTo(d.w)
+		if err != nil {
+			return ki, err
+		}
+
+		written := ki.valueEnd - ki.valueStart
+		ki.valueStart = d.offset
+		ki.valueEnd = d.offset + written
+		d.offset += written
+		return ki, nil
+	}
+
+	posInBlock := d.block.Len()
+	ki, err := node.KeyIndexAndWriteTo(d.block)
+	if err != nil {
+		return ki, err
+	}
+
+	ki.valueStart = d.offset
+	ki.valueEnd = posInBlock
+
+	if d.block.Len() >= blockTargetSize {
+		if err := d.flushBlock(); err != nil {
+			return ki, errors.Wrap(err, "flush block")
+		}
+	}
+
+	return ki, nil
+}
+
+// close writes any pending block and returns the end of the data area, i.e.
+// the start of the index
+func (d *segmentDataWriter) close() (int, error) {
+	if d.compressed && d.block.Len() > 0 {
+		if err := d.flushBlock(); err != nil {
+			return 0, errors.Wrap(err, "flush block")
+		}
+	}
+
+	return d.offset, nil
+}
+
+// checksum is the crc32 of the data area written so far, as it is on disk
+func (d *segmentDataWriter) checksum() uint32 {
+	return d.crc.Sum32()
+}
+
+func (d *segmentDataWriter) flushBlock() error {
+	compressed := new(bytes.Buffer)
+	if d.compressor == nil {
+		c, err := flate.NewWriter(compressed, flate.DefaultCompression)
+		if err != nil {
+			return err
+		}
+		d.compressor = c
+	} else {
+		d.compressor.Reset(compressed)
+	}
+
+	if _, err := d.compressor.Write(d.block.Bytes()); err != nil {
+		return err
+	}
+
+	if err := d.compressor.Close(); err != nil {
+		return err
+	}
+
+	header := make([]byte, blockHeaderSize)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(compressed.Len()))
+	binary.LittleEndian.PutUint32(header[4:8], uint32(d.block.Len()))
+	binary.LittleEndian.PutUint32(header[8:12], crc32.ChecksumIEEE(compressed.Bytes()))
+	if _, err := d.w.Write(header); err != nil {
+		return err
+	}
+
+	if _, err := d.w.Write(compressed.Bytes()); err != nil {
+		return err
+	}
+
+	d.offset += blockHeaderSize + compressed.Len()
+	d.block.Reset()
+	return nil
+}
+
+// segmentPos is a position in the data area of a segment. In an uncompressed
+// segment inBlock is always zero and offset is the position in the file.
+type segmentPos struct {
+	offset  uint64
+	inBlock uint64
+}
+
+func (s *segment) firstPos() segmentPos {
+	return segmentPos{offset: s.dataStartPos}
+}
+
+func (s *segment) isEndPos(pos segmentPos) bool {
+	return pos.offset >= s.dataEndPos
+}
+
+func (s *segment) nodePos(node segmentindex.Node) segmentPos {
+	if !s.compressed {
+		return segmentPos{offset: node.Start}
+	}
+
+	return segmentPos{offset: node.Start, inBlock: node.End}
+}
+
+// nodeBytes returns the bytes of the node the index points to. For a
+// compressed segment this includes all following nodes of the same block,
+// which is fine, as all node parsers know their own length
+func (s *segment) nodeBytes(node segmentindex.Node) ([]byte, error) {
+	if !s.compressed {
+		return s.contents[node.Start:node.End], nil
+	}
+
+	return s.bytesAt(s.nodePos(node))
+}
+
+// bytesAt returns the bytes starting at pos, until the end of the data area
+// or the end of the block respectively
+func (s *segment) bytesAt(pos segmentPos) ([]byte, error) {
+	if !s.compressed {
+		return s.contents[pos.offset:], nil
+	}
+
+	block, err := s.block(pos.offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if pos.inBlock > uint64(len(block)) {
+		return nil, errors.Errorf("position %d outside of block at %d with length %d",
+			pos.inBlock, pos.offset, len(block))
+	}
+
+	return block[pos.inBlock:], nil
+}
+
+// advance moves pos past a node with a length of n bytes
+func (s *segment) advance(pos segmentPos, n int) (segmentPos, error) {
+	if !s.compressed {
+		pos.offset += uint64(n)
+		return pos, nil
+	}
+
+	block, err := s.block(pos.offset)
+	if err != nil {
+		return pos, err
+	}
+
+	pos.inBlock += uint64(n)
+	if pos.inBlock >= uint64(len(block)) {
+		compressedLen := binary.LittleEndian.Uint32(
+			s.contents[pos.offset : pos.offset+4])
+		pos.offset += blockHeaderSize + uint64(compressedLen)
+		pos.inBlock = 0
+	}
+
+	return pos, nil
+}
+
+// block returns the decompressed block at the offset. The most recently used
+// block is cached, as cursors typically read many nodes from the same block.
+// A cached block is never modified, so callers may keep references to it.
+func (s *segment) block(offset uint64) ([]byte, error) {
+	s.blockCache.Lock()
+	defer s.blockCache.Unlock()
+
+	if s.blockCache.data != nil && s.blockCache.offset == offset {
+		return s.blockCache.data, nil
+	}
+
+	data, err := s.decompressBlock(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	s.blockCache.offset = offset
+	s.blockCache.data = data
+	return data, nil
+}
+
+// decompressBlock reads the block at the offset without using the cache
+func (s *segment) decompressBlock(offset uint64) ([]byte, error) {
+	if offset+blockHeaderSize > s.dataEndPos {
+		return nil, errors.Errorf("block offset %d outside of data area", offset)
+	}
+
+	compressedLen := uint64(binary.LittleEndian.Uint32(s.contents[offset : offset+4]))
+	decompressedLen := binary.LittleEndian.Uint32(s.contents[offset+4 : offset+8])
+	start := offset + blockHeaderSize
+	if start+compressedLen > s.dataEndPos {
+		return nil, errors.Errorf("block at %d exceeds data area", offset)
+	}
+
+	expected := binary.LittleEndian.Uint32(s.contents[offset+8 : offset+12])
+	if crc32.ChecksumIEEE(s.contents[start:start+compressedLen]) != expected {
+		return nil, errors.Wrapf(ErrChecksumMismatch, "block at %d", offset)
+	}
+
+	r := flate.NewReader(bytes.NewReader(s.contents[start : start+compressedLen]))
+	defer r.Close()
+
+	data := make([]byte, decompressedLen)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errors.Wrapf(err, "decompress block at %d", offset)
+	}
+
+	return data, nil
+}
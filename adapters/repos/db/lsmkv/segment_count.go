@@ -0,0 +1,285 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/segmentindex"
+)
+
+// The number of keys of a bucket with the replace strategy is the sum of the
+// net additions of its segments and memtables. The net additions of a
+// segment are the keys it adds to the segments below it minus the keys it
+// deletes from them. They never change as long as the segments below hold
+// the same keys, so they are counted once, when the segment is created or
+// loaded for the first time, and kept next to the segment in a .cna file.
+// Compacting two neighboring segments keeps the keys of the segments below,
+// the compacted segment adds the net additions of both.
+
+// countNetAdditionsFileSize is the int64 count followed by its crc32
+const countNetAdditionsFileSize = 12
+
+// existsFn reports whether a key is present and not deleted
+type existsFn func(key []byte) (bool, error)
+
+func countNetAdditionsPath(segmentPath string) string {
+	return strings.TrimSuffix(segmentPath, ".db") + ".cna"
+}
+
+// initCountNetAdditions reads the net additions of the segment from its .cna
+// file or counts them if the file is missing or invalid. existsBelow looks
+// up keys in the segments below this one.
+func (s *segment) initCountNetAdditions(existsBelow existsFn) error {
+	if s.strategy != SegmentStrategyReplace {
+		return nil
+	}
+
+	count, ok, err := readCountNetAdditions(countNetAdditionsPath(s.path))
+	if err != nil {
+		return errors.Wrap(err, "read net additions")
+	}
+	if ok {
+		s.countNetAdditions = count
+		return nil
+	}
+
+	count, err = s.computeCountNetAdditions(existsBelow)
+	if err != nil {
+		return errors.Wrap(err, "count net additions")
+	}
+
+	return s.setCountNetAdditions(count)
+}
+
+func (s *segment) setCountNetAdditions(count int64) error {
+	s.countNetAdditions = count
+	return writeCountNetAdditions(countNetAdditionsPath(s.path), count)
+}
+
+func (s *segment) computeCountNetAdditions(existsBelow existsFn) (int64, error) {
+	var count int64
+
+	for pos := s.firstPos(); !s.isEndPos(pos); {
+		in, err := s.bytesAt(pos)
+		if err != nil {
+			return 0, err
+		}
+
+		var node segmentReplaceNode
+		err = s.replaceStratParseDataWithKeyInto(in, &node)
+		if err != nil && err != Deleted {
+			return 0, err
+		}
+		deleted := err == Deleted
+
+		existed, err := existsBelow(node.primaryKey)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case deleted && existed:
+			count--
+		case !deleted && !existed:
+			count++
+		}
+
+		pos, err = s.advance(pos, node.offset)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// exists is a cheaper get, which only reads the tombstone of the node
+func (s *segment) exists(key []byte) (bool, error) {
+	if !s.bloomFilter.Test(key) {
+		return false, NotFound
+	}
+
+	node, err := s.index.Get(key)
+	if err != nil {
+		if err == segmentindex.NotFound {
+			return false, NotFound
+		}
+		return false, err
+	}
+
+	in, err := s.nodeBytes(node)
+	if err != nil {
+		return false, err
+	}
+
+	if len(in) == 0 {
+		return false, NotFound
+	}
+
+	// the first byte is the tombstone
+	return in[0] != 0x01, nil
+}
+
+func (s *segment) dropCountNetAdditions() error {
+	err := os.Remove(countNetAdditionsPath(s.path))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func readCountNetAdditions(path string) (int64, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	// a torn or corrupt file is simply counted again
+	if len(data) != countNetAdditionsFileSize ||
+		crc32.ChecksumIEEE(data[:8]) != binary.LittleEndian.Uint32(data[8:]) {
+		return 0, false, nil
+	}
+
+	return int64(binary.LittleEndian.Uint64(data[:8])), true, nil
+}
+
+func writeCountNetAdditions(path string, count int64) error {
+	data := make([]byte, countNetAdditionsFileSize)
+	binary.LittleEndian.PutUint64(data[:8], uint64(count))
+	binary.LittleEndian.PutUint32(data[8:], crc32.ChecksumIEEE(data[:8]))
+
+	return os.WriteFile(path, data, 0o666)
+}
+
+// existsBelow looks up the key in the segments below pos, the newest segment
+// which contains the key decides. The caller must hold the maintenanceLock.
+func (ig *SegmentGroup) existsBelow(pos int) existsFn {
+	return func(key []byte) (bool, error) {
+		for i := pos - 1; i >= 0; i-- {
+			ok, err := ig.segments[i].exists(key)
+			if err == NotFound {
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+
+			return ok, nil
+		}
+
+		return false, nil
+	}
+}
+
+// recountNetAdditions counts the net additions of all segments from pos
+// upwards, the .cna files of those segments are replaced. The caller must
+// hold the maintenanceLock.
+func (ig *SegmentGroup) recountNetAdditions(pos int) error {
+	for i := pos; i < len(ig.segments); i++ {
+		seg := ig.segments[i]
+		if seg.strategy != SegmentStrategyReplace {
+			continue
+		}
+
+		count, err := seg.computeCountNetAdditions(ig.existsBelow(i))
+		if err != nil {
+			return errors.Wrapf(err, "count net additions of segment %s", seg.path)
+		}
+
+		if err := seg.setCountNetAdditions(count); err != nil {
+			return errors.Wrapf(err, "write net additions of segment %s", seg.path)
+		}
+	}
+
+	return nil
+}
+
+// count returns the number of keys in all segments plus the net additions of
+// the memtables, which are counted against the keys of all segments
+func (ig *SegmentGroup) count(memtables func(exists existsFn) (int64, error)) (int64, error) {
+	ig.maintenanceLock.RLock()
+	defer ig.maintenanceLock.RUnlock()
+
+	var count int64
+	for _, seg := range ig.segments {
+		count += seg.countNetAdditions
+	}
+
+	additions, err := memtables(ig.existsBelow(len(ig.segments)))
+	if err != nil {
+		return 0, err
+	}
+
+	return count + additions, nil
+}
+
+// countNetAdditions counts the keys the memtable adds to those below it minus
+// the keys it deletes from them. The keys are copied under the lock, so that
+// writers aren't blocked by the lookups below.
+func (l *Memtable) countNetAdditions(existsBelow existsFn) (int64, error) {
+	type entry struct {
+		key       []byte
+		tombstone bool
+	}
+
+	l.RLock()
+	nodes := l.key.flattenInOrder()
+	entries := make([]entry, len(nodes))
+	for i, node := range nodes {
+		entries[i] = entry{key: node.key, tombstone: node.tombstone}
+	}
+	l.RUnlock()
+
+	var count int64
+	for _, e := range entries {
+		existed, err := existsBelow(e.key)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case e.tombstone && existed:
+			count--
+		case !e.tombstone && !existed:
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// existsOr looks up the key in the memtable and falls back to below if the
+// memtable doesn't contain it
+func (l *Memtable) existsOr(below existsFn) existsFn {
+	return func(key []byte) (bool, error) {
+		_, err := l.get(key)
+		switch err {
+		case nil:
+			return true, nil
+		case Deleted:
+			return false, nil
+		case NotFound:
+			return below(key)
+		default:
+			return false, err
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
 	"github.com/sirupsen/logrus"
 )
 
@@ -34,13 +35,38 @@ type SegmentGroup struct {
 	maintenanceLock sync.RWMutex
 	dir             string
 
+	// compactionLock is held for the entire duration of a compaction or a
+	// scrub, both address segments by their position
+	compactionLock sync.Mutex
+
 	stopCompactionCycle chan struct{}
 
+	// compressed controls whether compacted segments are block-compressed
+	compressed bool
+
+	compactionPolicy CompactionPolicy
+
+	// compactionLimiter is typically shared across buckets, nil means
+	// compactions are not throttled
+	compactionLimiter *CompactionRateLimiter
+
+	// syncSegments fsyncs compacted segments before they replace the
+	// segments they were compacted from
+	syncSegments bool
+
 	logger logrus.FieldLogger
 }
 
+type segmentGroupConfig struct {
+	compressed        bool
+	compactionPolicy  CompactionPolicy
+	compactionLimiter *CompactionRateLimiter
+	syncSegments      bool
+}
+
 func newSegmentGroup(dir string,
-	compactionCycle time.Duration, logger logrus.FieldLogger) (*SegmentGroup, error) {
+	compactionCycle time.Duration, logger logrus.FieldLogger,
+	cfg segmentGroupConfig) (*SegmentGroup, error) {
 	list, err := ioutil.ReadDir(dir)
 	if err != nil {
 		return nil, err
@@ -51,6 +77,14 @@ func newSegmentGroup(dir string,
 		dir:                 dir,
 		logger:              logger,
 		stopCompactionCycle: make(chan struct{}),
+		compressed:          cfg.compressed,
+		compactionPolicy:    cfg.compactionPolicy,
+		compactionLimiter:   cfg.compactionLimiter,
+		syncSegments:        cfg.syncSegments,
+	}
+
+	if out.compactionPolicy == nil {
+		out.compactionPolicy = SameLevelCompactionPolicy{}
 	}
 
 	segmentIndex := 0
@@ -93,6 +127,13 @@ func newSegmentGroup(dir string,
 			return nil, errors.Wrapf(err, "init segment %s", fileInfo.Name())
 		}
 
+		// the segments are listed from old to new, so all segments below this
+		// one are loaded already
+		if err := segment.initCountNetAdditions(
+			out.existsBelow(segmentIndex)); err != nil {
+			return nil, errors.Wrapf(err, "init segment %s", fileInfo.Name())
+		}
+
 		out.segments[segmentIndex] = segment
 		segmentIndex++
 	}
@@ -112,10 +153,28 @@ func (ig *SegmentGroup) add(path string) error {
 		return errors.Wrapf(err, "init segment %s", path)
 	}
 
+	if err := segment.initCountNetAdditions(
+		ig.existsBelow(len(ig.segments))); err != nil {
+		return errors.Wrapf(err, "init segment %s", path)
+	}
+
 	ig.segments = append(ig.segments, segment)
 	return nil
 }
 
+// segmentPaths returns the paths of all segments, oldest first
+func (ig *SegmentGroup) segmentPaths() []string {
+	ig.maintenanceLock.RLock()
+	defer ig.maintenanceLock.RUnlock()
+
+	out := make([]string, len(ig.segments))
+	for i, seg := range ig.segments {
+		out[i] = seg.path
+	}
+
+	return out
+}
+
 func (ig *SegmentGroup) get(key []byte) ([]byte, error) {
 	ig.maintenanceLock.RLock()
 	defer ig.maintenanceLock.RUnlock()
@@ -199,12 +258,38 @@ func (ig *SegmentGroup) getCollection(key []byte) ([]value, error) {
 	return out, nil
 }
 
+// roaringSetGet returns the layers of all segments which contain the key,
+// ordered from oldest to newest
+func (ig *SegmentGroup) roaringSetGet(key []byte) (roaringset.BitmapLayers, error) {
+	ig.maintenanceLock.RLock()
+	defer ig.maintenanceLock.RUnlock()
+
+	var out roaringset.BitmapLayers
+
+	for _, segment := range ig.segments {
+		layer, err := segment.roaringSetGet(key)
+		if err != nil {
+			if err == NotFound {
+				continue
+			}
+
+			return nil, err
+		}
+
+		out = append(out, layer)
+	}
+
+	return out, nil
+}
+
 func (ig *SegmentGroup) shutdown(ctx context.Context) error {
+	// stop the cycle before taking the lock, a running compaction needs to be
+	// able to obtain it to finish
+	ig.stopCompactionCycle <- struct{}{}
+
 	ig.maintenanceLock.Lock()
 	defer ig.maintenanceLock.Unlock()
 
-	ig.stopCompactionCycle <- struct{}{}
-
 	for i, seg := range ig.segments {
 		if err := seg.close(); err != nil {
 			return err
@@ -13,7 +13,6 @@ package lsmkv
 
 import (
 	"fmt"
-	"math"
 	"os"
 	"path/filepath"
 	"time"
@@ -22,66 +21,39 @@ import (
 )
 
 func (ig *SegmentGroup) eligbleForCompaction() bool {
-	ig.maintenanceLock.RLock()
-	defer ig.maintenanceLock.RUnlock()
-
-	// if there are at least two segments of the same level a regular compaction
-	// can be performed
-
-	levels := map[uint16]int{}
+	return ig.bestCompactionCandidatePair() != nil
+}
 
-	for _, segment := range ig.segments {
-		levels[segment.level]++
-		if levels[segment.level] > 1 {
-			return true
-		}
+// bestCompactionCandidatePair asks the compaction policy for the next two
+// segments to merge. The result is either nil or two neighboring positions.
+func (ig *SegmentGroup) bestCompactionCandidatePair() []int {
+	pos := ig.compactionPolicy.nextPair(ig.compactionCandidates())
+	if pos < 0 {
+		return nil
 	}
 
-	return false
+	return []int{pos, pos + 1}
 }
 
-func (ig *SegmentGroup) bestCompactionCandidatePair() []int {
+func (ig *SegmentGroup) compactionCandidates() []compactionCandidate {
 	ig.maintenanceLock.RLock()
 	defer ig.maintenanceLock.RUnlock()
 
-	// first determine the lowest level with candidates
-	levels := map[uint16]int{}
-
-	for _, segment := range ig.segments {
-		levels[segment.level]++
-	}
-
-	currLowestLevel := uint16(math.MaxUint16)
-	found := false
-	for level, count := range levels {
-		if count < 2 {
-			continue
-		}
-
-		if level < currLowestLevel {
-			currLowestLevel = level
-			found = true
-		}
-	}
-
-	if !found {
-		return nil
-	}
-
-	// now pick any two segements which match the level
-	var res []int
-
+	out := make([]compactionCandidate, len(ig.segments))
 	for i, segment := range ig.segments {
-		if len(res) >= 2 {
-			break
-		}
-
-		if segment.level == currLowestLevel {
-			res = append(res, i)
+		out[i] = compactionCandidate{
+			level: segment.level,
+			size:  int64(len(segment.contents)),
 		}
 	}
 
-	return res
+	return out
+}
+
+// compactionDebt estimates the work the compaction policy still needs to do
+// on the current segments
+func (ig *SegmentGroup) compactionDebt() CompactionDebt {
+	return compactionDebt(ig.compactionPolicy, ig.compactionCandidates())
 }
 
 // segmentAtPos retrieves the segment for the given position using a read-lock
@@ -93,6 +65,9 @@ func (ig *SegmentGroup) segmentAtPos(pos int) *segment {
 }
 
 func (ig *SegmentGroup) compactOnce() error {
+	ig.compactionLock.Lock()
+	defer ig.compactionLock.Unlock()
+
 	// Is it safe to only occasionally lock instead of the entire duration? Yes,
 	// because other than compaction the only change to the segments array could
 	// be an append because of a new flush cycle, so we do not need to guarantee
@@ -111,35 +86,47 @@ func (ig *SegmentGroup) compactOnce() error {
 		return err
 	}
 
+	// all writes of the compactors go through w, so they can be throttled
+	w := ig.compactionLimiter.writer(f)
+
 	scratchSpacePath := ig.segmentAtPos(pair[1]).path + "compaction.scratch.d"
 
-	// the assumption is that both pairs are of the same level, so we can just
-	// take either value. If we want to support asymmetric compaction, then we
-	// might have to choose this value more intelligently
-	level := ig.segmentAtPos(pair[0]).level
+	// depending on the policy the segments are not necessarily of the same
+	// level, the compacted segment is placed above the higher of both
+	level := maxLevel(ig.segmentAtPos(pair[0]).level,
+		ig.segmentAtPos(pair[1]).level)
 	secondaryIndices := ig.segmentAtPos(pair[0]).secondaryIndexCount
 
 	strategy := ig.segmentAtPos(pair[0]).strategy
 	switch strategy {
 	case SegmentStrategyReplace:
-		c := newCompactorReplace(f, ig.segmentAtPos(pair[0]).newCursor(),
-			ig.segmentAtPos(pair[1]).newCursor(), level, secondaryIndices, scratchSpacePath)
+		c := newCompactorReplace(w, ig.segmentAtPos(pair[0]).newCursor(),
+			ig.segmentAtPos(pair[1]).newCursor(), level, secondaryIndices, scratchSpacePath,
+			ig.compressed)
 
 		if err := c.do(); err != nil {
 			return err
 		}
 	case SegmentStrategySetCollection:
-		c := newCompactorSetCollection(f, ig.segmentAtPos(pair[0]).newCollectionCursor(),
+		c := newCompactorSetCollection(w, ig.segmentAtPos(pair[0]).newCollectionCursor(),
 			ig.segmentAtPos(pair[1]).newCollectionCursor(), level, secondaryIndices,
-			scratchSpacePath)
+			scratchSpacePath, ig.compressed)
 
 		if err := c.do(); err != nil {
 			return err
 		}
 	case SegmentStrategyMapCollection:
-		c := newCompactorMapCollection(f, ig.segmentAtPos(pair[0]).newCollectionCursor(),
+		c := newCompactorMapCollection(w, ig.segmentAtPos(pair[0]).newCollectionCursor(),
 			ig.segmentAtPos(pair[1]).newCollectionCursor(), level, secondaryIndices,
-			scratchSpacePath)
+			scratchSpacePath, ig.compressed)
+
+		if err := c.do(); err != nil {
+			return err
+		}
+	case SegmentStrategyRoaringSet:
+		c := newCompactorRoaringSet(w, ig.segmentAtPos(pair[0]).newRoaringSetCursor(),
+			ig.segmentAtPos(pair[1]).newRoaringSetCursor(), level, scratchSpacePath,
+			ig.compressed)
 
 		if err := c.do(); err != nil {
 			return err
@@ -149,6 +136,12 @@ func (ig *SegmentGroup) compactOnce() error {
 		return errors.Errorf("unrecognized strategy %v", strategy)
 	}
 
+	if ig.syncSegments {
+		if err := f.Sync(); err != nil {
+			return errors.Wrap(err, "fsync compacted segment file")
+		}
+	}
+
 	if err := f.Close(); err != nil {
 		return errors.Wrap(err, "close compacted segment file")
 	}
@@ -165,6 +158,9 @@ func (ig *SegmentGroup) replaceCompactedSegments(old1, old2 int,
 	ig.maintenanceLock.Lock()
 	defer ig.maintenanceLock.Unlock()
 
+	countNetAdditions := ig.segments[old1].countNetAdditions +
+		ig.segments[old2].countNetAdditions
+
 	if err := ig.segments[old1].close(); err != nil {
 		return errors.Wrap(err, "close disk segment")
 	}
@@ -192,11 +188,25 @@ func (ig *SegmentGroup) replaceCompactedSegments(old1, old2 int,
 		return errors.Wrap(err, "strip .tmp extension of new segment")
 	}
 
+	if ig.syncSegments {
+		if err := syncDir(ig.dir); err != nil {
+			return errors.Wrap(err, "fsync segment directory")
+		}
+	}
+
 	seg, err := newSegment(newPath, ig.logger)
 	if err != nil {
 		return errors.Wrap(err, "create new segment")
 	}
 
+	if seg.strategy == SegmentStrategyReplace {
+		// the segments below are unchanged, so the compacted segment adds what
+		// both of its sources added
+		if err := seg.setCountNetAdditions(countNetAdditions); err != nil {
+			return errors.Wrap(err, "write net additions")
+		}
+	}
+
 	ig.segments[old2] = seg
 
 	ig.segments = append(ig.segments[:old1], ig.segments[old1+1:]...)
@@ -233,19 +243,45 @@ func (ig *SegmentGroup) initCompactionCycle(interval time.Duration) {
 					Debug("stop compaction cycle")
 				return
 			case <-t:
-				if ig.eligbleForCompaction() {
-					if err := ig.compactOnce(); err != nil {
-						ig.logger.WithField("action", "lsm_compaction").
-							WithField("path", ig.dir).
-							WithError(err).
-							Errorf("compaction failed")
-					}
-				} else {
+				if !ig.eligbleForCompaction() {
 					ig.logger.WithField("action", "lsm_compaction").
 						WithField("path", ig.dir).
 						Trace("no segment eligble for compaction")
+					continue
+				}
+
+				// keep compacting as long as there are candidates, otherwise a
+				// bucket under heavy ingestion falls further and further behind.
+				// The IO is bounded by the rate limiter, not by the interval.
+				if stopped := ig.compactWhileEligible(); stopped {
+					return
 				}
 			}
 		}
 	}()
 }
+
+// compactWhileEligible compacts until the policy finds no more candidates or
+// the cycle is stopped, the latter is indicated by the return value
+func (ig *SegmentGroup) compactWhileEligible() bool {
+	for ig.eligbleForCompaction() {
+		if err := ig.compactOnce(); err != nil {
+			ig.logger.WithField("action", "lsm_compaction").
+				WithField("path", ig.dir).
+				WithError(err).
+				Errorf("compaction failed")
+			return false
+		}
+
+		select {
+		case <-ig.stopCompactionCycle:
+			ig.logger.WithField("action", "lsm_compaction_stop_cycle").
+				WithField("path", ig.dir).
+				Debug("stop compaction cycle")
+			return true
+		default:
+		}
+	}
+
+	return false
+}
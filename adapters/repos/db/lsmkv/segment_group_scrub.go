@@ -0,0 +1,146 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ScrubStatusOK means the segment matches its checksums
+	ScrubStatusOK = "ok"
+
+	// ScrubStatusCorrupt means the segment does not match its checksums or
+	// can't be read at all
+	ScrubStatusCorrupt = "corrupt"
+
+	// ScrubStatusUnverified means the segment was written by a version without
+	// checksums, it stays unverified until it is compacted
+	ScrubStatusUnverified = "unverified"
+)
+
+// quarantineDir is the sub directory of a bucket that corrupt segments are
+// moved to. It is ignored when the bucket is loaded.
+const quarantineDir = "quarantine"
+
+// SegmentScrubResult is the outcome of verifying a single disk segment
+type SegmentScrubResult struct {
+	Path        string
+	Status      string
+	Error       error
+	Quarantined bool
+}
+
+// scrub verifies all disk segments against their checksums. The data area is
+// read in full, so the scrub is throttled by the compaction rate limiter, if
+// set.
+//
+// If quarantine is set, corrupt segments are closed and moved to the
+// quarantine dir of the bucket. Note that this removes all data of the
+// segment, so older versions of a key which had been overwritten in the
+// quarantined segment will resurface.
+//
+// A scrub and a compaction never run concurrently, as both rely on stable
+// positions in the segments array. Each segment is verified under a read
+// lock, so flushes are only delayed for the duration of a single segment.
+func (ig *SegmentGroup) scrub(quarantine bool) ([]SegmentScrubResult, error) {
+	ig.compactionLock.Lock()
+	defer ig.compactionLock.Unlock()
+
+	var out []SegmentScrubResult
+	for pos := 0; ; pos++ {
+		res, ok := ig.verifySegmentAtPos(pos)
+		if !ok {
+			break
+		}
+
+		if res.Status == ScrubStatusCorrupt && quarantine {
+			if err := ig.quarantineSegmentAtPos(pos); err != nil {
+				return out, errors.Wrapf(err, "quarantine segment %s", res.Path)
+			}
+
+			res.Quarantined = true
+			// the next segment has moved into the current position
+			pos--
+		}
+
+		out = append(out, res)
+	}
+
+	return out, nil
+}
+
+// verifySegmentAtPos returns false if there is no segment at the position
+func (ig *SegmentGroup) verifySegmentAtPos(pos int) (SegmentScrubResult, bool) {
+	ig.maintenanceLock.RLock()
+	defer ig.maintenanceLock.RUnlock()
+
+	// segments are set to nil on shutdown
+	if pos >= len(ig.segments) || ig.segments[pos] == nil {
+		return SegmentScrubResult{}, false
+	}
+
+	seg := ig.segments[pos]
+	res := SegmentScrubResult{Path: seg.path, Status: ScrubStatusOK}
+	if err := seg.verify(ig.compactionLimiter); err != nil {
+		if err == errSegmentNotChecksummed {
+			res.Status = ScrubStatusUnverified
+		} else {
+			res.Status = ScrubStatusCorrupt
+			res.Error = err
+		}
+	}
+
+	return res, true
+}
+
+func (ig *SegmentGroup) quarantineSegmentAtPos(pos int) error {
+	ig.maintenanceLock.Lock()
+	defer ig.maintenanceLock.Unlock()
+
+	seg := ig.segments[pos]
+
+	dir := filepath.Join(ig.dir, quarantineDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrap(err, "create quarantine dir")
+	}
+
+	if err := seg.close(); err != nil {
+		return errors.Wrap(err, "close disk segment")
+	}
+
+	ig.segments = append(ig.segments[:pos], ig.segments[pos+1:]...)
+
+	newPath := filepath.Join(dir, filepath.Base(seg.path))
+	if err := os.Rename(seg.path, newPath); err != nil {
+		return errors.Wrapf(err, "rename %q -> %q", seg.path, newPath)
+	}
+
+	if err := seg.dropCountNetAdditions(); err != nil {
+		return errors.Wrap(err, "drop net additions")
+	}
+
+	// the segments above no longer shadow the keys of the quarantined segment
+	if err := ig.recountNetAdditions(pos); err != nil {
+		return errors.Wrap(err, "recount net additions")
+	}
+
+	ig.logger.WithField("action", "lsm_segment_quarantine").
+		WithField("path", seg.path).
+		WithField("quarantine_path", newPath).
+		Warn("moved corrupt LSM segment to quarantine")
+
+	return nil
+}
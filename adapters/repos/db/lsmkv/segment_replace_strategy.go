@@ -37,7 +37,12 @@ func (i *segment) get(key []byte) ([]byte, error) {
 		}
 	}
 
-	return i.replaceStratParseData(i.contents[node.Start:node.End])
+	in, err := i.nodeBytes(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.replaceStratParseData(in)
 }
 
 func (i *segment) getBySecondary(pos int, key []byte) ([]byte, error) {
@@ -62,7 +67,12 @@ func (i *segment) getBySecondary(pos int, key []byte) ([]byte, error) {
 		}
 	}
 
-	return i.replaceStratParseData(i.contents[node.Start:node.End])
+	in, err := i.nodeBytes(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.replaceStratParseData(in)
 }
 
 func (i *segment) replaceStratParseData(in []byte) ([]byte, error) {
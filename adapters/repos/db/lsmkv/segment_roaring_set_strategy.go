@@ -0,0 +1,60 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/segmentindex"
+)
+
+func (i *segment) roaringSetGet(key []byte) (roaringset.BitmapLayer, error) {
+	if i.strategy != SegmentStrategyRoaringSet {
+		return roaringset.BitmapLayer{}, errors.Errorf(
+			"roaringSetGet only possible for strategy %q", StrategyRoaringSet)
+	}
+
+	if !i.bloomFilter.Test(key) {
+		return roaringset.BitmapLayer{}, NotFound
+	}
+
+	node, err := i.index.Get(key)
+	if err != nil {
+		if err == segmentindex.NotFound {
+			return roaringset.BitmapLayer{}, NotFound
+		} else {
+			return roaringset.BitmapLayer{}, err
+		}
+	}
+
+	in, err := i.nodeBytes(node)
+	if err != nil {
+		return roaringset.BitmapLayer{}, err
+	}
+
+	parsed, err := i.roaringSetStratParseData(in)
+	if err != nil {
+		return roaringset.BitmapLayer{}, err
+	}
+
+	return parsed.layer(), nil
+}
+
+func (i *segment) roaringSetStratParseData(in []byte) (segmentRoaringSetNode, error) {
+	if len(in) == 0 {
+		return segmentRoaringSetNode{}, NotFound
+	}
+
+	return ParseRoaringSetNode(bytes.NewReader(in))
+}
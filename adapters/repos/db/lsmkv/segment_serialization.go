@@ -57,8 +57,7 @@ func (h *segmentHeader) PrimaryIndex(source []byte) ([]byte, error) {
 		return source[h.indexStart:], nil
 	}
 
-	offsets, err := h.parseSecondaryIndexOffsets(
-		source[h.indexStart:h.secondaryIndexOffsetsEnd()])
+	offsets, err := h.parseSecondaryIndexOffsets(source)
 	if err != nil {
 		return nil, err
 	}
@@ -72,14 +71,26 @@ func (h *segmentHeader) secondaryIndexOffsetsEnd() uint64 {
 	return h.indexStart + (uint64(h.secondaryIndices) * 8)
 }
 
+// parseSecondaryIndexOffsets reads the start positions of the secondary
+// indexes from the beginning of the index area and makes sure they are all
+// within source, which ends with the last secondary index
 func (h *segmentHeader) parseSecondaryIndexOffsets(source []byte) ([]uint64, error) {
-	r := bytes.NewReader(source)
+	r := bytes.NewReader(source[h.indexStart:h.secondaryIndexOffsetsEnd()])
 
 	offsets := make([]uint64, h.secondaryIndices)
 	if err := binary.Read(r, binary.LittleEndian, &offsets); err != nil {
 		return nil, err
 	}
 
+	prev := h.secondaryIndexOffsetsEnd()
+	for i, offset := range offsets {
+		if offset < prev || offset > uint64(len(source)) {
+			return nil, errors.Errorf("secondary index %d at invalid offset %d", i,
+				offset)
+		}
+		prev = offset
+	}
+
 	return offsets, nil
 }
 
@@ -89,8 +100,7 @@ func (h *segmentHeader) SecondaryIndex(source []byte, indexID uint16) ([]byte, e
 			indexID, h.secondaryIndices)
 	}
 
-	offsets, err := h.parseSecondaryIndexOffsets(
-		source[h.indexStart:h.secondaryIndexOffsetsEnd()])
+	offsets, err := h.parseSecondaryIndexOffsets(source)
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +130,9 @@ func parseSegmentHeader(r io.Reader) (*segmentHeader, error) {
 		return nil, err
 	}
 
-	if out.version != 0 {
+	if out.version&^segmentVersionAllFlags != 0 ||
+		out.version == segmentVersionCompressed {
+		// compressed segments are always checksummed
 		return nil, errors.Errorf("unsupported version %d", out.version)
 	}
 
@@ -136,13 +148,17 @@ func parseSegmentHeader(r io.Reader) (*segmentHeader, error) {
 }
 
 type segmentIndices struct {
-	keys                []keyIndex
+	keys []keyIndex
+
+	// indexStart is the end of the data area. It can't be derived from the
+	// keys, as the nodes of a compressed segment are not stored 1:1
+	indexStart          uint64
 	secondaryIndexCount uint16
 	scratchSpacePath    string
 }
 
 func (s segmentIndices) WriteTo(w io.Writer) (int64, error) {
-	currentOffset := uint64(s.keys[len(s.keys)-1].valueEnd)
+	currentOffset := s.indexStart
 	var written int64
 
 	if _, err := os.Stat(s.scratchSpacePath); err == nil {
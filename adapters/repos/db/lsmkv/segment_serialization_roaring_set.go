@@ -0,0 +1,130 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv/roaringset"
+)
+
+// roaring set strategy does not support secondary keys at this time. The
+// additions and deletions of a node are stored as separate serialized bitmaps,
+// so that a node can be applied on top of older segments without knowing
+// their contents.
+//
+// Format: [uint64 additions len][additions][uint64 deletions len][deletions]
+// [uint32 key len][key]
+type segmentRoaringSetNode struct {
+	additions  *roaring64.Bitmap
+	deletions  *roaring64.Bitmap
+	primaryKey []byte
+	offset     int
+}
+
+func (s segmentRoaringSetNode) KeyIndexAndWriteTo(w io.Writer) (keyIndex, error) {
+	out := keyIndex{}
+	written := 0
+	buf := make([]byte, 8)
+
+	for i, bm := range []*roaring64.Bitmap{s.additions, s.deletions} {
+		serialized, err := bm.ToBytes()
+		if err != nil {
+			return out, errors.Wrapf(err, "serialize bitmap %d", i)
+		}
+
+		binary.LittleEndian.PutUint64(buf, uint64(len(serialized)))
+		if _, err := w.Write(buf[0:8]); err != nil {
+			return out, errors.Wrapf(err, "write len of bitmap %d", i)
+		}
+		written += 8
+
+		n, err := w.Write(serialized)
+		if err != nil {
+			return out, errors.Wrapf(err, "write bitmap %d", i)
+		}
+		written += n
+	}
+
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(s.primaryKey)))
+	if _, err := w.Write(buf[0:4]); err != nil {
+		return out, errors.Wrapf(err, "write key length encoding for node")
+	}
+	written += 4
+
+	n, err := w.Write(s.primaryKey)
+	if err != nil {
+		return out, errors.Wrapf(err, "write node")
+	}
+	written += n
+
+	out = keyIndex{
+		valueStart: s.offset,
+		valueEnd:   s.offset + written,
+		key:        s.primaryKey,
+	}
+
+	return out, nil
+}
+
+func (s segmentRoaringSetNode) layer() roaringset.BitmapLayer {
+	return roaringset.BitmapLayer{
+		Additions: s.additions,
+		Deletions: s.deletions,
+	}
+}
+
+func ParseRoaringSetNode(r io.Reader) (segmentRoaringSetNode, error) {
+	out := segmentRoaringSetNode{}
+	tmpBuf := make([]byte, 8)
+
+	bitmaps := make([]*roaring64.Bitmap, 2)
+	for i := range bitmaps {
+		if n, err := io.ReadFull(r, tmpBuf[0:8]); err != nil {
+			return out, errors.Wrapf(err, "read len of bitmap %d", i)
+		} else {
+			out.offset += n
+		}
+
+		serialized := make([]byte, binary.LittleEndian.Uint64(tmpBuf[0:8]))
+		if n, err := io.ReadFull(r, serialized); err != nil {
+			return out, errors.Wrapf(err, "read bitmap %d", i)
+		} else {
+			out.offset += n
+		}
+
+		bm := roaring64.New()
+		if err := bm.UnmarshalBinary(serialized); err != nil {
+			return out, errors.Wrapf(err, "parse bitmap %d", i)
+		}
+		bitmaps[i] = bm
+	}
+	out.additions, out.deletions = bitmaps[0], bitmaps[1]
+
+	if n, err := io.ReadFull(r, tmpBuf[0:4]); err != nil {
+		return out, errors.Wrap(err, "read key len")
+	} else {
+		out.offset += n
+	}
+
+	out.primaryKey = make([]byte, binary.LittleEndian.Uint32(tmpBuf[0:4]))
+	n, err := io.ReadFull(r, out.primaryKey)
+	if err != nil {
+		return out, errors.Wrap(err, "read key")
+	}
+	out.offset += n
+
+	return out, nil
+}
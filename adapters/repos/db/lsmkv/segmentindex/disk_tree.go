@@ -161,6 +161,79 @@ func (t *DiskTree) seekAt(offset int64, key []byte) (Node, error) {
 	}
 }
 
+// SeekBefore returns the node with the largest key that is strictly smaller
+// than the specified key. It is the counterpart to Seek for iterating in
+// descending order.
+func (t *DiskTree) SeekBefore(key []byte) (Node, error) {
+	if len(t.data) == 0 {
+		return Node{}, NotFound
+	}
+
+	return t.seekBeforeAt(0, key)
+}
+
+func (t *DiskTree) seekBeforeAt(offset int64, key []byte) (Node, error) {
+	node, err := t.readNodeAt(offset)
+	if err != nil {
+		return Node{}, err
+	}
+
+	self := Node{
+		Key:   node.key,
+		Start: node.startPos,
+		End:   node.endPos,
+	}
+
+	if bytes.Compare(node.key, key) >= 0 {
+		if node.leftChild < 0 {
+			return Node{}, NotFound
+		}
+
+		return t.seekBeforeAt(node.leftChild, key)
+	}
+
+	// this node is a candidate, but there might be a larger one on the right
+	if node.rightChild < 0 {
+		return self, nil
+	}
+
+	right, err := t.seekBeforeAt(node.rightChild, key)
+	if err == nil {
+		return right, nil
+	}
+
+	if err == NotFound {
+		return self, nil
+	}
+
+	return Node{}, err
+}
+
+// Last returns the node with the largest key
+func (t *DiskTree) Last() (Node, error) {
+	if len(t.data) == 0 {
+		return Node{}, NotFound
+	}
+
+	offset := int64(0)
+	for {
+		node, err := t.readNodeAt(offset)
+		if err != nil {
+			return Node{}, err
+		}
+
+		if node.rightChild < 0 {
+			return Node{
+				Key:   node.key,
+				Start: node.startPos,
+				End:   node.endPos,
+			}, nil
+		}
+
+		offset = node.rightChild
+	}
+}
+
 // AllKeys is a relatively expensive operation as it basically does a full disk
 // read of the index. It is meant for one of operations, such as initializing a
 // segment where we need access to all keys, e.g. to build a bloom filter. This
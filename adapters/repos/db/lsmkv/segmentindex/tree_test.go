@@ -193,6 +193,47 @@ func TestTree(t *testing.T) {
 			assert.Equal(t, NotFound, err)
 		})
 
+		t.Run("seek before", func(t *testing.T) {
+			n, err := dTree.SeekBefore([]byte("foobar"))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("abc"), n.Key)
+			assert.Equal(t, uint64(4), n.Start)
+			assert.Equal(t, uint64(5), n.End)
+
+			n, err = dTree.SeekBefore([]byte("foobaz"))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("foobar"), n.Key)
+
+			n, err = dTree.SeekBefore([]byte("zzzz"))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("zzz"), n.Key)
+
+			n, err = dTree.SeekBefore([]byte("zzzzz"))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("zzzz"), n.Key)
+
+			n, err = dTree.SeekBefore([]byte("abc"))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("aaa"), n.Key)
+
+			_, err = dTree.SeekBefore([]byte("aaa"))
+			assert.Equal(t, NotFound, err)
+
+			_, err = dTree.SeekBefore([]byte("a"))
+			assert.Equal(t, NotFound, err)
+		})
+
+		t.Run("last", func(t *testing.T) {
+			n, err := dTree.Last()
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("zzzz"), n.Key)
+			assert.Equal(t, uint64(100), n.Start)
+			assert.Equal(t, uint64(102), n.End)
+
+			_, err = NewDiskTree(nil).Last()
+			assert.Equal(t, NotFound, err)
+		})
+
 		t.Run("get all keys (for building bloom filters at segment init time)", func(t *testing.T) {
 			expected := [][]byte{
 				[]byte("aaa"),
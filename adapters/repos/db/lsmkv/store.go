@@ -26,13 +26,21 @@ type Store struct {
 	rootDir       string
 	bucketsByName map[string]*Bucket
 	logger        logrus.FieldLogger
+
+	// defaultOpts are applied to every bucket before its own options
+	defaultOpts []BucketOption
 }
 
-func New(rootDir string, logger logrus.FieldLogger) (*Store, error) {
+// New creates a store in rootDir. The optional bucket options are applied to
+// every bucket of the store, before the options of the individual bucket, so
+// the latter can override them.
+func New(rootDir string, logger logrus.FieldLogger,
+	defaultOpts ...BucketOption) (*Store, error) {
 	s := &Store{
 		rootDir:       rootDir,
 		bucketsByName: map[string]*Bucket{},
 		logger:        logger,
+		defaultOpts:   defaultOpts,
 	}
 
 	return s, s.init()
@@ -60,7 +68,11 @@ func (s *Store) CreateOrLoadBucket(ctx context.Context, bucketName string,
 		return nil
 	}
 
-	b, err := NewBucket(ctx, s.bucketDir(bucketName), s.logger, opts...)
+	allOpts := make([]BucketOption, 0, len(s.defaultOpts)+len(opts))
+	allOpts = append(allOpts, s.defaultOpts...)
+	allOpts = append(allOpts, opts...)
+
+	b, err := NewBucket(ctx, s.bucketDir(bucketName), s.logger, allOpts...)
 	if err != nil {
 		return err
 	}
@@ -79,6 +91,47 @@ func (s *Store) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// CompactionDebt returns the pending compaction work of each bucket by name
+func (s *Store) CompactionDebt() map[string]CompactionDebt {
+	out := make(map[string]CompactionDebt, len(s.bucketsByName))
+	for name, bucket := range s.bucketsByName {
+		out[name] = bucket.CompactionDebt()
+	}
+
+	return out
+}
+
+// Stats returns the current size of each bucket by name
+func (s *Store) Stats() (map[string]BucketStats, error) {
+	out := make(map[string]BucketStats, len(s.bucketsByName))
+	for name, bucket := range s.bucketsByName {
+		stats, err := bucket.Stats()
+		if err != nil {
+			return nil, errors.Wrapf(err, "bucket %q", name)
+		}
+
+		out[name] = stats
+	}
+
+	return out, nil
+}
+
+// Scrub verifies the disk segments of all buckets, the results are grouped by
+// bucket name
+func (s *Store) Scrub(quarantine bool) (map[string][]SegmentScrubResult, error) {
+	out := make(map[string][]SegmentScrubResult, len(s.bucketsByName))
+	for name, bucket := range s.bucketsByName {
+		res, err := bucket.Scrub(quarantine)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bucket %q", name)
+		}
+
+		out[name] = res
+	}
+
+	return out, nil
+}
+
 func (s *Store) WriteWALs() error {
 	for name, bucket := range s.bucketsByName {
 		if err := bucket.WriteWAL(); err != nil {
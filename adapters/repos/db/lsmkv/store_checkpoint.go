@@ -0,0 +1,232 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Checkpoint describes a point-in-time copy of a Store created with
+// CreateCheckpoint. The directory has the same layout as the root dir of a
+// store, so it can be opened with New and CreateOrLoadBucket like any other
+// store. The WALs are recovered into new segments on the first load.
+type Checkpoint struct {
+	Dir string
+
+	// Segments and WALs contain the paths of all files of the checkpoint,
+	// relative to Dir
+	Segments []string
+	WALs     []string
+}
+
+// bucketCheckpoint is the state of a single bucket at the time of the
+// checkpoint
+type bucketCheckpoint struct {
+	name     string
+	bucket   *Bucket
+	segments []string
+	wal      string
+	walSize  int64
+}
+
+// CreateCheckpoint creates a consistent point-in-time copy of all buckets of
+// the store in dir, which must not exist yet. Writes are only paused for the
+// short moment it takes to record the state of every bucket:
+//
+//   - Compactions are blocked for the entire duration, so no segment of the
+//     checkpoint can be deleted before it is linked.
+//   - Memtable switches are blocked until the WALs are copied, so no memtable
+//     is flushed in the meantime and the WALs stay in place.
+//   - Writes are blocked while the WAL buffers are flushed and the length of
+//     every WAL and the list of segments are recorded.
+//
+// The memtables are pinned by copying their WALs up to the recorded length,
+// the immutable segments are hard-linked into dir. If dir is on a different
+// filesystem, the segments are copied instead.
+func (s *Store) CreateCheckpoint(dir string) (*Checkpoint, error) {
+	if _, err := os.Stat(dir); err == nil {
+		return nil, errors.Errorf("checkpoint dir %q already exists", dir)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "stat checkpoint dir")
+	}
+
+	// a stable order guarantees that concurrent checkpoints obtain the locks
+	// in the same order
+	names := make([]string, 0, len(s.bucketsByName))
+	for name := range s.bucketsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buckets := make([]*bucketCheckpoint, len(names))
+	for i, name := range names {
+		buckets[i] = &bucketCheckpoint{name: name, bucket: s.bucketsByName[name]}
+	}
+
+	for _, bc := range buckets {
+		bc.bucket.disk.compactionLock.Lock()
+		defer bc.bucket.disk.compactionLock.Unlock()
+	}
+
+	for _, bc := range buckets {
+		bc.bucket.switchLock.Lock()
+	}
+
+	err := s.pauseAndRecordCheckpoint(buckets)
+	if err == nil {
+		err = s.copyCheckpointWALs(dir, buckets)
+	}
+
+	for _, bc := range buckets {
+		bc.bucket.switchLock.Unlock()
+	}
+
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	out, err := s.linkCheckpointSegments(dir, buckets)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// pauseAndRecordCheckpoint blocks all writes and records the WAL and the
+// segments of each bucket. As the switchLocks are held, there can't be a
+// flushing memtable, all data is either in the active memtable or in a
+// segment.
+func (s *Store) pauseAndRecordCheckpoint(buckets []*bucketCheckpoint) error {
+	for _, bc := range buckets {
+		bc.bucket.flushLock.Lock()
+		defer bc.bucket.flushLock.Unlock()
+	}
+
+	for _, bc := range buckets {
+		b := bc.bucket
+		if err := b.active.writeWAL(); err != nil {
+			return errors.Wrapf(err, "bucket %q: flush wal buffer", bc.name)
+		}
+
+		size, err := b.active.commitlog.size()
+		if err != nil {
+			return errors.Wrapf(err, "bucket %q: get wal size", bc.name)
+		}
+
+		bc.wal = b.active.commitlog.path
+		bc.walSize = size
+		bc.segments = b.disk.segmentPaths()
+	}
+
+	return nil
+}
+
+func (s *Store) copyCheckpointWALs(dir string,
+	buckets []*bucketCheckpoint) error {
+	for _, bc := range buckets {
+		bucketDir := filepath.Join(dir, bc.name)
+		if err := os.MkdirAll(bucketDir, 0o700); err != nil {
+			return errors.Wrapf(err, "bucket %q: create checkpoint dir", bc.name)
+		}
+
+		if bc.walSize == 0 {
+			continue
+		}
+
+		target := filepath.Join(bucketDir, filepath.Base(bc.wal))
+		if err := copyFile(bc.wal, target, bc.walSize); err != nil {
+			return errors.Wrapf(err, "bucket %q: copy wal", bc.name)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) linkCheckpointSegments(dir string,
+	buckets []*bucketCheckpoint) (*Checkpoint, error) {
+	out := &Checkpoint{Dir: dir}
+
+	for _, bc := range buckets {
+		for _, path := range bc.segments {
+			rel := filepath.Join(bc.name, filepath.Base(path))
+			target := filepath.Join(dir, rel)
+			if err := os.Link(path, target); err != nil {
+				// most likely a different filesystem, fall back to a copy
+				info, statErr := os.Stat(path)
+				if statErr != nil {
+					return nil, errors.Wrapf(statErr, "bucket %q: stat segment", bc.name)
+				}
+
+				if err := copyFile(path, target, info.Size()); err != nil {
+					return nil, errors.Wrapf(err, "bucket %q: link or copy segment",
+						bc.name)
+				}
+			}
+
+			out.Segments = append(out.Segments, rel)
+		}
+
+		if bc.walSize > 0 {
+			out.WALs = append(out.WALs, filepath.Join(bc.name, filepath.Base(bc.wal)))
+		}
+
+		if err := syncDir(filepath.Join(dir, bc.name)); err != nil {
+			return nil, errors.Wrapf(err, "bucket %q: sync checkpoint dir", bc.name)
+		}
+	}
+
+	return out, nil
+}
+
+// copyFile copies the first n bytes of src to a new file dst and syncs it
+func copyFile(src, dst string, n int64) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.CopyN(out, in, n); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+func syncDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return f.Sync()
+}
@@ -0,0 +1,176 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreCheckpoint(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer os.RemoveAll(dirName)
+
+	storeDir := filepath.Join(dirName, "store")
+	checkpointDir := filepath.Join(dirName, "checkpoint")
+
+	key := func(i int) []byte {
+		out := make([]byte, 8)
+		binary.BigEndian.PutUint64(out, uint64(i))
+		return out
+	}
+
+	store, err := New(storeDir, nullLogger())
+	require.Nil(t, err)
+
+	require.Nil(t, store.CreateOrLoadBucket(testCtx(), "objects",
+		WithStrategy(StrategyReplace)))
+	require.Nil(t, store.CreateOrLoadBucket(testCtx(), "index",
+		WithStrategy(StrategySetCollection)))
+
+	objects := store.Bucket("objects")
+	index := store.Bucket("index")
+
+	// every key is written to the objects bucket first and to the index
+	// bucket second, so a consistent checkpoint can never contain a key in
+	// the index which is not also present in the objects bucket
+	write := func(i int) error {
+		if err := objects.Put(key(i), []byte(fmt.Sprintf("value-%d", i))); err != nil {
+			return err
+		}
+
+		return index.SetAdd([]byte("all"), [][]byte{key(i)})
+	}
+
+	var checkpoint *Checkpoint
+	var writtenBefore int
+
+	t.Run("create a checkpoint while writes and flushes continue", func(t *testing.T) {
+		for i := 0; i < 500; i++ {
+			require.Nil(t, write(i))
+			if i%100 == 99 {
+				require.Nil(t, objects.FlushAndSwitch())
+				require.Nil(t, index.FlushAndSwitch())
+			}
+		}
+
+		stop := make(chan struct{})
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+
+		written := 500
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				if !assert.Nil(t, write(written)) {
+					return
+				}
+				written++
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				if !assert.Nil(t, objects.FlushAndSwitch()) {
+					return
+				}
+				time.Sleep(5 * time.Millisecond)
+			}
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		writtenBefore = 500
+
+		checkpoint, err = store.CreateCheckpoint(checkpointDir)
+		require.Nil(t, err)
+
+		time.Sleep(50 * time.Millisecond)
+		close(stop)
+		wg.Wait()
+
+		assert.Equal(t, checkpointDir, checkpoint.Dir)
+		assert.NotEmpty(t, checkpoint.Segments)
+		for _, path := range append(checkpoint.Segments, checkpoint.WALs...) {
+			_, err := os.Stat(filepath.Join(checkpointDir, path))
+			assert.Nil(t, err)
+		}
+	})
+
+	t.Run("a second checkpoint into the same dir fails", func(t *testing.T) {
+		_, err := store.CreateCheckpoint(checkpointDir)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("compacting and deleting the original does not affect the checkpoint", func(t *testing.T) {
+		for objects.disk.eligbleForCompaction() {
+			require.Nil(t, objects.disk.compactOnce())
+		}
+		for index.disk.eligbleForCompaction() {
+			require.Nil(t, index.disk.compactOnce())
+		}
+
+		require.Nil(t, store.Shutdown(context.Background()))
+		require.Nil(t, os.RemoveAll(storeDir))
+	})
+
+	t.Run("the checkpoint is consistent", func(t *testing.T) {
+		cp, err := New(checkpointDir, nullLogger())
+		require.Nil(t, err)
+		defer cp.Shutdown(context.Background())
+
+		require.Nil(t, cp.CreateOrLoadBucket(testCtx(), "objects",
+			WithStrategy(StrategyReplace)))
+		require.Nil(t, cp.CreateOrLoadBucket(testCtx(), "index",
+			WithStrategy(StrategySetCollection)))
+
+		indexed, err := cp.Bucket("index").SetList([]byte("all"))
+		require.Nil(t, err)
+		require.GreaterOrEqual(t, len(indexed), writtenBefore,
+			"everything written before the checkpoint is contained")
+
+		for _, k := range indexed {
+			i := int(binary.BigEndian.Uint64(k))
+			v, err := cp.Bucket("objects").Get(k)
+			require.Nil(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value-%d", i)), v,
+				"indexed key %d is present in the objects bucket", i)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package lsmkv
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// BucketStrategyOnDisk detects the strategy of an existing bucket without
+// loading it. This allows callers to keep using the strategy a bucket was
+// created with, even if the default strategy for new buckets has changed
+// since. An empty string is returned if there is no data on disk for the
+// bucket.
+//
+// Segments contain their exact strategy. If there are only commit logs, a
+// collection entry is reported as StrategySetCollection, as commit logs do
+// not distinguish between sets and maps.
+func (s *Store) BucketStrategyOnDisk(bucketName string) (string, error) {
+	return bucketStrategyOnDisk(s.bucketDir(bucketName))
+}
+
+func bucketStrategyOnDisk(dir string) (string, error) {
+	list, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "read bucket dir")
+	}
+
+	for _, fileInfo := range list {
+		if filepath.Ext(fileInfo.Name()) != ".db" {
+			continue
+		}
+
+		strategy, err := strategyFromSegmentFile(filepath.Join(dir, fileInfo.Name()))
+		if err != nil {
+			return "", errors.Wrapf(err, "segment %s", fileInfo.Name())
+		}
+		return strategy, nil
+	}
+
+	for _, fileInfo := range list {
+		if filepath.Ext(fileInfo.Name()) != ".wal" {
+			continue
+		}
+
+		strategy, err := strategyFromCommitLogFile(filepath.Join(dir, fileInfo.Name()))
+		if err != nil {
+			return "", errors.Wrapf(err, "commit log %s", fileInfo.Name())
+		}
+		if strategy != "" {
+			return strategy, nil
+		}
+	}
+
+	return "", nil
+}
+
+func strategyFromSegmentFile(path string) (string, error) {
+	header, err := readSegmentHeaderFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "parse header")
+	}
+
+	return header.strategy.String(), nil
+}
+
+func strategyFromCommitLogFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var commitType CommitType
+	if err := binary.Read(f, binary.LittleEndian, &commitType); err != nil {
+		if err == io.EOF {
+			// an empty log, nothing was written yet
+			return "", nil
+		}
+		return "", errors.Wrap(err, "read commit type")
+	}
+
+	switch commitType {
+	case CommitTypeReplace:
+		return StrategyReplace, nil
+	case CommitTypeCollection:
+		return StrategySetCollection, nil
+	case CommitTypeRoaringSet:
+		return StrategyRoaringSet, nil
+	default:
+		return "", errors.Errorf("unknown commit type %d", commitType)
+	}
+}
@@ -16,6 +16,9 @@ const (
 	StrategyReplace       = "replace"
 	StrategySetCollection = "setcollection"
 	StrategyMapCollection = "mapcollection"
+	// StrategyRoaringSet stores a set of uint64 values (e.g. doc ids) per key
+	// as a compressed bitmap
+	StrategyRoaringSet = "roaringset"
 )
 
 type SegmentStrategy uint16
@@ -24,6 +27,7 @@ const (
 	SegmentStrategyReplace SegmentStrategy = iota
 	SegmentStrategySetCollection
 	SegmentStrategyMapCollection
+	SegmentStrategyRoaringSet
 )
 
 func SegmentStrategyFromString(in string) SegmentStrategy {
@@ -34,7 +38,24 @@ func SegmentStrategyFromString(in string) SegmentStrategy {
 		return SegmentStrategySetCollection
 	case StrategyMapCollection:
 		return SegmentStrategyMapCollection
+	case StrategyRoaringSet:
+		return SegmentStrategyRoaringSet
 	default:
 		panic("unsupport strategy")
 	}
 }
+
+func (s SegmentStrategy) String() string {
+	switch s {
+	case SegmentStrategyReplace:
+		return StrategyReplace
+	case SegmentStrategySetCollection:
+		return StrategySetCollection
+	case SegmentStrategyMapCollection:
+		return StrategyMapCollection
+	case SegmentStrategyRoaringSet:
+		return StrategyRoaringSet
+	default:
+		return "unknown"
+	}
+}
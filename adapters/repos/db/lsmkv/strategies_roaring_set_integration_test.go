@@ -0,0 +1,234 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package lsmkv
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoaringSetStrategy(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	b, err := NewBucket(testCtx(), dirName, nullLogger(), WithStrategy(StrategyRoaringSet))
+	require.Nil(t, err)
+
+	// so big it effectively never triggers as part of this test
+	b.SetMemtableThreshold(1e9)
+
+	keys := [][]byte{[]byte("key-a"), []byte("key-b"), []byte("key-c")}
+	control := map[string]map[uint64]struct{}{}
+	for _, key := range keys {
+		control[string(key)] = map[uint64]struct{}{}
+	}
+
+	verify := func(t *testing.T) {
+		for _, key := range keys {
+			bm, err := b.RoaringSetGet(key)
+			require.Nil(t, err)
+			assert.Equal(t, sortedValues(control[string(key)]), bm.ToArray(),
+				"key %s", key)
+		}
+	}
+
+	// every round writes to the memtable, then flushes, so that additions and
+	// deletions of the same value are spread across many segments
+	for round := 0; round < 8; round++ {
+		t.Run(fmt.Sprintf("round %d", round), func(t *testing.T) {
+			for i := 0; i < 500; i++ {
+				key := keys[rand.Intn(len(keys))]
+				value := uint64(rand.Intn(10000))
+				if rand.Intn(3) == 0 {
+					require.Nil(t, b.RoaringSetRemoveOne(key, value))
+					delete(control[string(key)], value)
+				} else {
+					require.Nil(t, b.RoaringSetAddOne(key, value))
+					control[string(key)][value] = struct{}{}
+				}
+			}
+
+			verify(t)
+			require.Nil(t, b.FlushAndSwitch())
+			verify(t)
+		})
+	}
+
+	t.Run("a key which was never written is empty", func(t *testing.T) {
+		bm, err := b.RoaringSetGet([]byte("unknown"))
+		require.Nil(t, err)
+		assert.True(t, bm.IsEmpty())
+	})
+
+	t.Run("compact until no longer eligible", func(t *testing.T) {
+		require.True(t, b.disk.eligbleForCompaction())
+		for b.disk.eligbleForCompaction() {
+			require.Nil(t, b.disk.compactOnce())
+		}
+		verify(t)
+	})
+
+	t.Run("add a bitmap on top of the compacted segments", func(t *testing.T) {
+		bm, err := b.RoaringSetGet(keys[0])
+		require.Nil(t, err)
+		bm.Add(123456)
+		require.Nil(t, b.RoaringSetAddBitmap(keys[1], bm))
+		for _, v := range bm.ToArray() {
+			control[string(keys[1])][v] = struct{}{}
+		}
+		verify(t)
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		c := b.CursorRoaringSet()
+		defer c.Close()
+
+		var retrieved [][]byte
+		for k, bm := c.First(); k != nil; k, bm = c.Next() {
+			retrieved = append(retrieved, k)
+			assert.Equal(t, sortedValues(control[string(k)]), bm.ToArray())
+		}
+		assert.Equal(t, keys, retrieved)
+
+		k, bm := c.Seek([]byte("key-b"))
+		assert.Equal(t, keys[1], k)
+		assert.Equal(t, sortedValues(control[string(k)]), bm.ToArray())
+	})
+
+	t.Run("detect strategy", func(t *testing.T) {
+		s := &Store{rootDir: "./testdata"}
+		strategy, err := s.BucketStrategyOnDisk(dirName[len("./testdata/"):])
+		require.Nil(t, err)
+		assert.Equal(t, StrategyRoaringSet, strategy)
+	})
+
+	require.Nil(t, b.Shutdown(testCtx()))
+}
+
+func TestRoaringSetStrategy_RecoverFromWAL(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirNameOriginal := fmt.Sprintf("./testdata/%d-original", rand.Intn(10000000))
+	dirNameRecovered := fmt.Sprintf("./testdata/%d-recovered", rand.Intn(10000000))
+	os.MkdirAll(dirNameOriginal, 0o777)
+	os.MkdirAll(dirNameRecovered, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirNameOriginal)
+		fmt.Println(err)
+		err = os.RemoveAll(dirNameRecovered)
+		fmt.Println(err)
+	}()
+
+	key := []byte("key-1")
+
+	b, err := NewBucket(testCtx(), dirNameOriginal, nullLogger(), WithStrategy(StrategyRoaringSet))
+	require.Nil(t, err)
+
+	// so big it effectively never triggers as part of this test
+	b.SetMemtableThreshold(1e9)
+
+	require.Nil(t, b.RoaringSetAddList(key, []uint64{1, 2, 3, 70000}))
+	require.Nil(t, b.RoaringSetRemoveOne(key, 2))
+	require.Nil(t, b.WriteWAL())
+
+	cmd := exec.Command("/bin/bash", "-c", fmt.Sprintf("cp -r %s/*.wal %s",
+		dirNameOriginal, dirNameRecovered))
+	var out bytes.Buffer
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		fmt.Println(out.String())
+		t.Fatal(err)
+	}
+
+	s := &Store{rootDir: "./testdata"}
+	strategy, err := s.BucketStrategyOnDisk(dirNameRecovered[len("./testdata/"):])
+	require.Nil(t, err)
+	assert.Equal(t, StrategyRoaringSet, strategy)
+
+	bRec, err := NewBucket(testCtx(), dirNameRecovered, nullLogger(),
+		WithStrategy(StrategyRoaringSet))
+	require.Nil(t, err)
+
+	bm, err := bRec.RoaringSetGet(key)
+	require.Nil(t, err)
+	assert.Equal(t, []uint64{1, 3, 70000}, bm.ToArray())
+}
+
+func TestStore_BucketStrategyOnDisk(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	rootDir := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(rootDir, 0o777)
+	defer func() {
+		err := os.RemoveAll(rootDir)
+		fmt.Println(err)
+	}()
+
+	store, err := New(rootDir, nullLogger())
+	require.Nil(t, err)
+
+	t.Run("a bucket which does not exist yet", func(t *testing.T) {
+		strategy, err := store.BucketStrategyOnDisk("not-created")
+		require.Nil(t, err)
+		assert.Equal(t, "", strategy)
+	})
+
+	t.Run("a set bucket with a segment", func(t *testing.T) {
+		require.Nil(t, store.CreateOrLoadBucket(testCtx(), "set",
+			WithStrategy(StrategySetCollection)))
+		b := store.Bucket("set")
+		require.Nil(t, b.SetAdd([]byte("key"), [][]byte{[]byte("value")}))
+		require.Nil(t, b.FlushAndSwitch())
+
+		strategy, err := store.BucketStrategyOnDisk("set")
+		require.Nil(t, err)
+		assert.Equal(t, StrategySetCollection, strategy)
+	})
+
+	t.Run("a roaring set bucket with only a commit log", func(t *testing.T) {
+		require.Nil(t, store.CreateOrLoadBucket(testCtx(), "roaring",
+			WithStrategy(StrategyRoaringSet)))
+		b := store.Bucket("roaring")
+		require.Nil(t, b.RoaringSetAddOne([]byte("key"), 7))
+		require.Nil(t, b.WriteWAL())
+
+		strategy, err := store.BucketStrategyOnDisk("roaring")
+		require.Nil(t, err)
+		assert.Equal(t, StrategyRoaringSet, strategy)
+	})
+
+	require.Nil(t, store.Shutdown(testCtx()))
+}
+
+func sortedValues(in map[uint64]struct{}) []uint64 {
+	out := make([]uint64, 0, len(in))
+	for v := range in {
+		out = append(out, v)
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a] < out[b] })
+	return out
+}
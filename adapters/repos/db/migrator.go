@@ -13,8 +13,10 @@ package db
 
 import (
 	"context"
+	"sort"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
@@ -31,14 +33,21 @@ func (m *Migrator) AddClass(ctx context.Context, class *models.Class,
 	shardState *sharding.State) error {
 	idx, err := NewIndex(ctx,
 		IndexConfig{
-			ClassName: schema.ClassName(class.Class),
-			RootPath:  m.db.config.RootPath,
+			ClassName:              schema.ClassName(class.Class),
+			RootPath:               m.db.config.RootPath,
+			VectorCacheBudget:      m.db.vectorCacheBudget,
+			AsyncVectorIndexing:    m.db.config.AsyncVectorIndexing,
+			CompressObjectSegments: m.db.config.CompressObjectSegments,
+			CompactionPolicy:       m.db.config.CompactionPolicy,
+			CompactionLimiter:      m.db.compactionLimiter,
+			WALSyncPolicy:          m.db.config.WALSyncPolicy,
 		},
 		shardState,
 		// no backward-compatibility check required, since newly added classes will
 		// always have the field set
 		class.InvertedIndexConfig,
 		class.VectorIndexConfig.(schema.VectorIndexConfig),
+		schema.NamedVectorIndexConfigs(class),
 		m.db.schemaGetter, m.db, m.logger, m.db.nodeResolver, m.db.remoteClient)
 	if err != nil {
 		return errors.Wrap(err, "create index")
@@ -118,10 +127,103 @@ func (m *Migrator) UpdateVectorIndexConfig(ctx context.Context,
 	return idx.updateVectorIndexConfig(ctx, updated)
 }
 
+func (m *Migrator) UpdateNamedVectorIndexConfigs(ctx context.Context,
+	className string, updated map[string]schema.VectorIndexConfig) error {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return errors.Errorf("cannot update vector index configs of non-existing index for %s", className)
+	}
+
+	return idx.updateNamedVectorIndexConfigs(ctx, updated)
+}
+
+func (m *Migrator) GetShardsStatus(ctx context.Context,
+	className string) (models.ShardStatusList, error) {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return nil, errors.Errorf("cannot get shards status of non-existing index for %s", className)
+	}
+
+	return idx.shardsStatus(), nil
+}
+
+func (m *Migrator) InspectVectorIndex(ctx context.Context, className,
+	shardName, targetVector string) (*models.VectorIndexGraphReport, error) {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return nil, errors.Errorf("cannot inspect vector index of non-existing index for %s", className)
+	}
+
+	return idx.inspectVectorIndex(shardName, targetVector)
+}
+
+func (m *Migrator) RepairVectorIndex(ctx context.Context, className,
+	shardName, targetVector string) (*models.VectorIndexRepairResult, error) {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return nil, errors.Errorf("cannot repair vector index of non-existing index for %s", className)
+	}
+
+	return idx.repairVectorIndex(shardName, targetVector)
+}
+
+func (m *Migrator) EvaluateVectorIndexRecall(ctx context.Context, className,
+	shardName, targetVector string,
+	params *models.VectorIndexRecallRequest) (*models.VectorIndexRecallReport, error) {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return nil, errors.Errorf("cannot evaluate vector index of non-existing index for %s", className)
+	}
+
+	return idx.evaluateVectorIndexRecall(ctx, shardName, targetVector, params)
+}
+
+func (m *Migrator) ScrubShard(ctx context.Context, className,
+	shardName string, quarantine bool) (*models.ShardScrubReport, error) {
+	idx := m.db.GetIndex(schema.ClassName(className))
+	if idx == nil {
+		return nil, errors.Errorf("cannot scrub shard of non-existing index for %s", className)
+	}
+
+	return idx.scrubShard(shardName, quarantine)
+}
+
+// GetStorageStatistics lists the memory and disk usage of the shards of
+// className, or of all classes if it is empty
+func (m *Migrator) GetStorageStatistics(ctx context.Context,
+	className string) (*models.StorageStatistics, error) {
+	var indexes []*Index
+	if className != "" {
+		idx := m.db.GetIndex(schema.ClassName(className))
+		if idx == nil {
+			return nil, errors.Errorf("cannot get storage statistics of non-existing index for %s", className)
+		}
+		indexes = append(indexes, idx)
+	} else {
+		for _, idx := range m.db.indices {
+			indexes = append(indexes, idx)
+		}
+		sort.Slice(indexes, func(a, b int) bool {
+			return indexes[a].Config.ClassName < indexes[b].Config.ClassName
+		})
+	}
+
+	return m.db.storageStatistics(ctx, indexes)
+}
+
 func (m *Migrator) ValidateVectorIndexConfigUpdate(ctx context.Context,
 	old, updated schema.VectorIndexConfig) error {
-	// hnsw is the only supported vector index type at the moment, so no need
-	// to check, we can always use that an hnsw-specific validation should be
-	// used for now.
-	return hnsw.ValidateUserConfigUpdate(old, updated)
+	if old.IndexType() != updated.IndexType() {
+		return errors.Errorf("vectorIndexType is immutable: attempted change "+
+			"from %q to %q", old.IndexType(), updated.IndexType())
+	}
+
+	switch old.IndexType() {
+	case "hnsw":
+		return hnsw.ValidateUserConfigUpdate(old, updated)
+	case "flat":
+		return flat.ValidateUserConfigUpdate(old, updated)
+	default:
+		return errors.Errorf("unsupported vector index type %q", old.IndexType())
+	}
 }
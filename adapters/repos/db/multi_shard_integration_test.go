@@ -323,6 +323,67 @@ func makeTestRetrievingBaseClass(repo *DB, data []*models.Object,
 			})
 		})
 
+		t.Run("retrieve through batched class-level vector search", func(t *testing.T) {
+			otherVec := exampleQueryVec()
+			groundTruths := [][]*models.Object{
+				groundTruth, bruteForceObjectsByQuery(data, otherVec),
+			}
+
+			do := func(t *testing.T, limit, expected int) {
+				res, err := repo.BatchVectorClassSearch(context.Background(),
+					traverser.BatchSearchParams{
+						SearchVectors: [][]float32{queryVec, otherVec},
+						Limit:         limit,
+						ClassName:     "TestClass",
+					})
+				require.Nil(t, err)
+				require.Len(t, res, 2)
+				for i := range res {
+					assert.Len(t, res[i], expected)
+					for j, obj := range res[i] {
+						assert.Equal(t, groundTruths[i][j].ID, obj.ID)
+					}
+				}
+			}
+
+			t.Run("with high limit", func(t *testing.T) {
+				do(t, 100, 20)
+			})
+
+			t.Run("with low limit", func(t *testing.T) {
+				do(t, 3, 3)
+			})
+
+			t.Run("with a shared filter", func(t *testing.T) {
+				res, err := repo.BatchVectorClassSearch(context.Background(),
+					traverser.BatchSearchParams{
+						SearchVectors: [][]float32{queryVec, otherVec},
+						Limit:         100,
+						ClassName:     "TestClass",
+						Filters: &filters.LocalFilter{
+							Root: &filters.Clause{
+								Operator: filters.OperatorEqual,
+								Value: &filters.Value{
+									Value: true,
+									Type:  schema.DataTypeBoolean,
+								},
+								On: &filters.Path{
+									Property: "boolProp",
+								},
+							},
+						},
+					})
+				require.Nil(t, err)
+				require.Len(t, res, 2)
+				for i := range res {
+					assert.Len(t, res[i], 10)
+					for _, obj := range res[i] {
+						assert.Equal(t, true, obj.Schema.(map[string]interface{})["boolProp"].(bool))
+					}
+				}
+			})
+		})
+
 		t.Run("retrieve through inter-class vector search", func(t *testing.T) {
 			do := func(t *testing.T, limit, expected int) {
 				res, err := repo.VectorSearch(context.Background(), queryVec, 0, limit, nil)
@@ -446,7 +507,7 @@ func bruteForceObjectsByQuery(objs []*models.Object,
 		obj      *models.Object
 	}
 
-	distProv := distancer.NewDotProductProvider()
+	distProv := distancer.NewCosineDistanceProvider()
 	distances := make([]distanceAndObj, len(objs))
 
 	for i := range objs {
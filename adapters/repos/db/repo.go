@@ -15,6 +15,9 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	schemaUC "github.com/semi-technologies/weaviate/usecases/schema"
 	"github.com/semi-technologies/weaviate/usecases/sharding"
@@ -28,6 +31,12 @@ type DB struct {
 	indices      map[string]*Index
 	remoteClient sharding.RemoteIndexClient
 	nodeResolver nodeResolver
+
+	// vectorCacheBudget is shared by the vector caches of all indexes
+	vectorCacheBudget *hnsw.CacheBudget
+
+	// compactionLimiter is shared by the lsmkv buckets of all shards
+	compactionLimiter *lsmkv.CompactionRateLimiter
 }
 
 func (d *DB) SetSchemaGetter(sg schemaUC.SchemaGetter) {
@@ -46,6 +55,10 @@ func New(logger logrus.FieldLogger, config Config,
 		indices:      map[string]*Index{},
 		remoteClient: remoteClient,
 		nodeResolver: nodeResolver,
+		vectorCacheBudget: hnsw.NewCacheBudget(config.VectorCacheMaxBytes,
+			logger),
+		compactionLimiter: lsmkv.NewCompactionRateLimiter(
+			config.CompactionMaxBytesPerSecond),
 	}
 }
 
@@ -53,6 +66,31 @@ type Config struct {
 	RootPath            string
 	QueryLimit          int64
 	QueryMaximumResults int64
+
+	// VectorCacheMaxBytes is the memory budget shared by the vector caches of
+	// all indexes, 0 means unlimited
+	VectorCacheMaxBytes int64
+
+	// AsyncVectorIndexing acknowledges writes once the object and the inverted
+	// index are stored, vector indexes are updated from a queue in the
+	// background
+	AsyncVectorIndexing bool
+
+	// CompressObjectSegments writes the disk segments of the objects buckets
+	// block-compressed
+	CompressObjectSegments bool
+
+	// CompactionPolicy is the name of the lsmkv compaction policy of all
+	// buckets, empty selects the default
+	CompactionPolicy string
+
+	// CompactionMaxBytesPerSecond limits the disk writes of all lsmkv
+	// compactions combined, 0 means unlimited
+	CompactionMaxBytesPerSecond int64
+
+	// WALSyncPolicy decides when the lsmkv WALs and the hnsw commit logs of
+	// all shards are fsynced
+	WALSyncPolicy durability.Policy
 }
 
 // GetIndex returns the index if it exists or nil if it doesn't
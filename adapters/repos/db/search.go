@@ -58,6 +58,10 @@ func (db *DB) ClassSearch(ctx context.Context,
 		return nil, errors.Wrapf(err, "invalid pagination params")
 	}
 
+	if params.GeoSort != nil {
+		return db.geoSortClassSearch(ctx, idx, totalLimit, params)
+	}
+
 	res, err := idx.objectSearch(ctx, totalLimit,
 		params.Filters, params.AdditionalProperties)
 	if err != nil {
@@ -69,6 +73,21 @@ func (db *DB) ClassSearch(ctx context.Context,
 		params.Properties, params.AdditionalProperties)
 }
 
+// geoSortClassSearch is the equivalent of ClassSearch for searches ordered by
+// the distance to a geo point
+func (db *DB) geoSortClassSearch(ctx context.Context, idx *Index,
+	totalLimit int, params traverser.GetParams) ([]search.Result, error) {
+	res, dists, err := idx.objectGeoSortSearch(ctx, params.GeoSort, totalLimit,
+		params.Filters, params.AdditionalProperties)
+	if err != nil {
+		return nil, errors.Wrapf(err, "geo sort search at index %s", idx.ID())
+	}
+
+	return db.enrichRefsForList(ctx,
+		storobj.SearchResultsWithDists(db.getStoreObjects(res, params.Pagination), params.AdditionalProperties,
+			db.getDists(dists, params.Pagination)), params.Properties, params.AdditionalProperties)
+}
+
 func (db *DB) VectorClassSearch(ctx context.Context,
 	params traverser.GetParams) ([]search.Result, error) {
 	if params.SearchVector == nil {
@@ -86,7 +105,8 @@ func (db *DB) VectorClassSearch(ctx context.Context,
 	}
 
 	res, dists, err := idx.objectVectorSearch(ctx, params.SearchVector,
-		totalLimit, params.Filters, params.AdditionalProperties)
+		params.TargetVector, params.WaitForIndexing, totalLimit, params.Filters,
+		params.AdditionalProperties)
 	if err != nil {
 		return nil, errors.Wrapf(err, "object vector search at index %s", idx.ID())
 	}
@@ -96,6 +116,36 @@ func (db *DB) VectorClassSearch(ctx context.Context,
 			db.getDists(dists, params.Pagination)), params.Properties, params.AdditionalProperties)
 }
 
+// BatchVectorClassSearch runs one vector search per search vector, the
+// searches share the filter and the limit
+func (db *DB) BatchVectorClassSearch(ctx context.Context,
+	params traverser.BatchSearchParams) ([][]search.Result, error) {
+	totalLimit, err := db.getTotalLimit(&filters.Pagination{Limit: params.Limit})
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid limit")
+	}
+
+	idx := db.GetIndex(schema.ClassName(params.ClassName))
+	if idx == nil {
+		return nil, fmt.Errorf("tried to browse non-existing index for %s", params.ClassName)
+	}
+
+	res, dists, err := idx.objectVectorSearchBatch(ctx, params.SearchVectors,
+		params.TargetVector, params.WaitForIndexing, totalLimit, params.Filters,
+		params.AdditionalProperties)
+	if err != nil {
+		return nil, errors.Wrapf(err, "batch vector search at index %s", idx.ID())
+	}
+
+	out := make([][]search.Result, len(res))
+	for i := range res {
+		out[i] = storobj.SearchResultsWithDists(res[i],
+			params.AdditionalProperties, dists[i])
+	}
+
+	return out, nil
+}
+
 func (db *DB) VectorSearch(ctx context.Context, vector []float32, offset, limit int,
 	filters *filters.LocalFilter) ([]search.Result, error) {
 	var found search.Results
@@ -116,7 +166,8 @@ func (db *DB) VectorSearch(ctx context.Context, vector []float32, offset, limit
 		go func(index *Index, wg *sync.WaitGroup) {
 			defer wg.Done()
 
-			res, _, err := index.objectVectorSearch(ctx, vector, totalLimit, filters, emptyAdditional)
+			res, _, err := index.objectVectorSearch(ctx, vector, "", false,
+				totalLimit, filters, emptyAdditional)
 			if err != nil {
 				mutex.Lock()
 				searchErrors = append(searchErrors, errors.Wrapf(err, "search index %s", index.ID()))
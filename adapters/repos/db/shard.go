@@ -24,6 +24,7 @@ import (
 	"github.com/semi-technologies/weaviate/adapters/repos/db/inverted"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/propertyspecific"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/noop"
@@ -47,6 +48,19 @@ type Shard struct {
 	deletedDocIDs    *docid.InMemDeletedTracker
 	cleanupInterval  time.Duration
 	cleanupCancel    chan struct{}
+
+	// namedVectorIndexes contains one vector index per named vector of the
+	// class, keyed by the name of the vector
+	namedVectorIndexes map[string]VectorIndex
+
+	// vectorIndexQueues contains the queue of every vector index, keyed by the
+	// name of the vector ("" for the class-level vector). It is nil unless
+	// vector indexing is asynchronous.
+	vectorIndexQueues map[string]*vectorIndexQueue
+
+	// vectorBuckets contains the bucket which stores the vectors of every
+	// vector of the class, keyed like vectorIndexQueues
+	vectorBuckets map[string]*lsmkv.Bucket
 }
 
 func NewShard(ctx context.Context, shardName string, index *Index) (*Shard, error) {
@@ -61,37 +75,38 @@ func NewShard(ctx context.Context, shardName string, index *Index) (*Shard, erro
 		cleanupCancel: make(chan struct{}),
 	}
 
-	hnswUserConfig, ok := index.vectorIndexUserConfig.(hnsw.UserConfig)
-	if !ok {
-		return nil, errors.Errorf("hnsw vector index: config is not hnsw.UserConfig: %T",
-			index.vectorIndexUserConfig)
+	err := s.initDBFile(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "init shard %q: shard db", s.ID())
 	}
 
-	if hnswUserConfig.Skip {
-		s.vectorIndex = noop.NewIndex()
-	} else {
-		vi, err := hnsw.New(hnsw.Config{
-			Logger:   index.logger,
-			RootPath: s.index.Config.RootPath,
-			ID:       s.ID(),
-			MakeCommitLoggerThunk: func() (hnsw.CommitLogger, error) {
-				return hnsw.NewCommitLogger(s.index.Config.RootPath, s.ID(), 10*time.Second,
-					index.logger)
-			},
-			VectorForIDThunk: s.vectorByIndexID,
-			DistanceProvider: distancer.NewDotProductProvider(),
-		}, hnswUserConfig)
+	if err := s.initVectorBuckets(ctx); err != nil {
+		return nil, errors.Wrapf(err, "init shard %q: vector buckets", s.ID())
+	}
+
+	vectorIndex, err := s.initVectorIndex(s.ID(), helpers.VectorsBucketLSM,
+		index.vectorIndexUserConfig, s.vectorByIndexID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "init shard %q", s.ID())
+	}
+	s.vectorIndex = vectorIndex
+
+	s.namedVectorIndexes = make(map[string]VectorIndex,
+		len(index.namedVectorIndexUserConfigs))
+	for name, userConfig := range index.namedVectorIndexUserConfigs {
+		vectorIndex, err := s.initVectorIndex(s.namedVectorIndexID(name),
+			helpers.NamedVectorsBucketLSM(name), userConfig,
+			s.namedVectorByIndexID(name))
 		if err != nil {
-			return nil, errors.Wrapf(err, "init shard %q: hnsw index", s.ID())
+			return nil, errors.Wrapf(err, "init shard %q: vector %q", s.ID(), name)
 		}
-		s.vectorIndex = vi
-
-		defer vi.PostStartup()
+		s.namedVectorIndexes[name] = vectorIndex
 	}
 
-	err := s.initDBFile(ctx)
-	if err != nil {
-		return nil, errors.Wrapf(err, "init shard %q: shard db", s.ID())
+	if index.Config.AsyncVectorIndexing {
+		if err := s.initVectorIndexQueues(); err != nil {
+			return nil, errors.Wrapf(err, "init shard %q: vector index queue", s.ID())
+		}
 	}
 
 	counter, err := indexcounter.New(s.ID(), index.Config.RootPath)
@@ -108,6 +123,139 @@ func NewShard(ctx context.Context, shardName string, index *Index) (*Shard, erro
 	return s, nil
 }
 
+// initVectorIndex creates the vector index for the given user config. id
+// identifies the index on disk, flat indexes search the vector bucket
+// bucketName and hnsw indexes read the vectors through vectorForID.
+func (s *Shard) initVectorIndex(id, bucketName string,
+	userConfig schema.VectorIndexConfig,
+	vectorForID hnsw.VectorForID) (VectorIndex, error) {
+	switch vectorIndexUserConfig := userConfig.(type) {
+	case hnsw.UserConfig:
+		vi, err := s.initHnswVectorIndex(id, vectorIndexUserConfig, vectorForID)
+		if err != nil {
+			return nil, errors.Wrap(err, "hnsw index")
+		}
+		return vi, nil
+	case flat.UserConfig:
+		vi, err := s.initFlatVectorIndex(id, bucketName, vectorIndexUserConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "flat index")
+		}
+		return vi, nil
+	default:
+		return nil, errors.Errorf("unsupported vector index config: %T",
+			userConfig)
+	}
+}
+
+func (s *Shard) initHnswVectorIndex(id string, hnswUserConfig hnsw.UserConfig,
+	vectorForID hnsw.VectorForID) (VectorIndex, error) {
+	if hnswUserConfig.Skip {
+		return noop.NewIndex(), nil
+	}
+
+	distProv, err := distancerProviderFromConfig(hnswUserConfig.Distance)
+	if err != nil {
+		return nil, err
+	}
+
+	vi, err := hnsw.New(hnsw.Config{
+		Logger:   s.index.logger,
+		RootPath: s.index.Config.RootPath,
+		ID:       id,
+		MakeCommitLoggerThunk: func() (hnsw.CommitLogger, error) {
+			return hnsw.NewCommitLogger(s.index.Config.RootPath, id, 10*time.Second,
+				s.index.logger, hnsw.WithSyncPolicy(s.index.Config.WALSyncPolicy))
+		},
+		VectorForIDThunk: vectorForID,
+		DistanceProvider: distProv,
+		CacheBudget:      s.index.Config.VectorCacheBudget,
+	}, hnswUserConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// the store is already initialized at this point, so the vector cache can
+	// be prefilled in the background
+	vi.PostStartup()
+
+	return vi, nil
+}
+
+func (s *Shard) initFlatVectorIndex(id, bucketName string,
+	flatUserConfig flat.UserConfig) (VectorIndex, error) {
+	distProv, err := distancerProviderFromConfig(flatUserConfig.Distance)
+	if err != nil {
+		return nil, err
+	}
+
+	vi, err := flat.New(flat.Config{
+		ID:               id,
+		Store:            s.store,
+		Logger:           s.index.logger,
+		DistanceProvider: distProv,
+		BucketName:       bucketName,
+	}, flatUserConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return vi, nil
+}
+
+// namedVectorIndexID identifies the vector index of a named vector on disk,
+// e.g. for the commit logs of an hnsw index
+func (s *Shard) namedVectorIndexID(name string) string {
+	return fmt.Sprintf("%s_vector_%s", s.ID(), name)
+}
+
+// vectorIndexFor returns the vector index for the target vector, an empty
+// target vector refers to the class-level vector
+func (s *Shard) vectorIndexFor(targetVector string) (VectorIndex, error) {
+	if targetVector == "" {
+		return s.vectorIndex, nil
+	}
+
+	vectorIndex, ok := s.namedVectorIndexes[targetVector]
+	if !ok {
+		return nil, errors.Errorf("class %s has no vector named %q",
+			s.index.Config.ClassName, targetVector)
+	}
+
+	return vectorIndex, nil
+}
+
+func (s *Shard) flushVectorIndexes() error {
+	if err := s.vectorIndex.Flush(); err != nil {
+		return err
+	}
+
+	for name, vectorIndex := range s.namedVectorIndexes {
+		if err := vectorIndex.Flush(); err != nil {
+			return errors.Wrapf(err, "vector %q", name)
+		}
+	}
+
+	return nil
+}
+
+func distancerProviderFromConfig(distance string) (distancer.Provider, error) {
+	switch distance {
+	case "", hnsw.DistanceCosine:
+		return distancer.NewCosineDistanceProvider(), nil
+	case hnsw.DistanceDot:
+		return distancer.NewDotProductProvider(), nil
+	case hnsw.DistanceL2Squared:
+		return distancer.NewL2SquaredProvider(), nil
+	case hnsw.DistanceManhattan:
+		return distancer.NewManhattanProvider(), nil
+	case hnsw.DistanceHamming:
+		return distancer.NewHammingProvider(), nil
+	default:
+		return nil, errors.Errorf("unrecognized distance metric %q", distance)
+	}
+}
+
 func (s *Shard) ID() string {
 	return fmt.Sprintf("%s_%s", s.index.ID(), s.name)
 }
@@ -122,14 +270,29 @@ func (s *Shard) initDBFile(ctx context.Context) error {
 		"index": s.index.ID(),
 		"class": s.index.Config.ClassName,
 	})
-	store, err := lsmkv.New(s.DBPathLSM(), annotatedLogger)
+	compactionPolicy, err := lsmkv.CompactionPolicyFromName(
+		s.index.Config.CompactionPolicy)
+	if err != nil {
+		return errors.Wrap(err, "init lsmkv store")
+	}
+
+	store, err := lsmkv.New(s.DBPathLSM(), annotatedLogger,
+		lsmkv.WithCompactionPolicy(compactionPolicy),
+		lsmkv.WithCompactionRateLimiter(s.index.Config.CompactionLimiter),
+		lsmkv.WithWALSyncPolicy(s.index.Config.WALSyncPolicy))
 	if err != nil {
 		return errors.Wrapf(err, "init lsmkv store at %s", s.DBPathLSM())
 	}
 
-	err = store.CreateOrLoadBucket(ctx, helpers.ObjectsBucketLSM,
+	objectsOpts := []lsmkv.BucketOption{
 		lsmkv.WithStrategy(lsmkv.StrategyReplace),
-		lsmkv.WithSecondaryIndicies(1))
+		lsmkv.WithSecondaryIndicies(1),
+	}
+	if s.index.Config.CompressObjectSegments {
+		objectsOpts = append(objectsOpts, lsmkv.WithBlockCompression())
+	}
+
+	err = store.CreateOrLoadBucket(ctx, helpers.ObjectsBucketLSM, objectsOpts...)
 	if err != nil {
 		return errors.Wrap(err, "create objects bucket")
 	}
@@ -143,6 +306,10 @@ func (s *Shard) drop() error {
 	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
 	defer cancel()
 
+	if err := s.dropVectorIndexQueues(); err != nil {
+		return errors.Wrap(err, "drop vector index queues")
+	}
+
 	if err := s.store.Shutdown(ctx); err != nil {
 		return errors.Wrap(err, "stop lsmkv store")
 	}
@@ -163,6 +330,11 @@ func (s *Shard) drop() error {
 	if err != nil {
 		return errors.Wrapf(err, "remove vector index at %s", s.DBPathLSM())
 	}
+	for name, vectorIndex := range s.namedVectorIndexes {
+		if err := vectorIndex.Drop(); err != nil {
+			return errors.Wrapf(err, "remove vector index of vector %q", name)
+		}
+	}
 	// TODO: can we remove this?
 	s.deletedDocIDs.BulkRemove(s.deletedDocIDs.GetAll())
 
@@ -174,10 +346,31 @@ func (s *Shard) drop() error {
 	return nil
 }
 
+// noFrequencyStrategy returns the strategy for an inverted bucket which does
+// not need frequencies. New buckets are bitmap-based, but buckets which were
+// created as sets keep their strategy, so existing data stays readable.
+func (s *Shard) noFrequencyStrategy(bucketName string) (string, error) {
+	existing, err := s.store.BucketStrategyOnDisk(bucketName)
+	if err != nil {
+		return "", errors.Wrapf(err, "detect strategy of bucket %q", bucketName)
+	}
+
+	if existing == lsmkv.StrategySetCollection {
+		return existing, nil
+	}
+
+	return lsmkv.StrategyRoaringSet, nil
+}
+
 func (s *Shard) addIDProperty(ctx context.Context) error {
-	err := s.store.CreateOrLoadBucket(ctx,
-		helpers.BucketFromPropNameLSM(helpers.PropertyNameID),
-		lsmkv.WithStrategy(lsmkv.StrategySetCollection))
+	bucketName := helpers.BucketFromPropNameLSM(helpers.PropertyNameID)
+	strategy, err := s.noFrequencyStrategy(bucketName)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.CreateOrLoadBucket(ctx, bucketName,
+		lsmkv.WithStrategy(strategy))
 	if err != nil {
 		return err
 	}
@@ -194,9 +387,15 @@ func (s *Shard) addIDProperty(ctx context.Context) error {
 
 func (s *Shard) addProperty(ctx context.Context, prop *models.Property) error {
 	if schema.IsRefDataType(prop.DataType) {
-		err := s.store.CreateOrLoadBucket(ctx,
-			helpers.BucketFromPropNameLSM(helpers.MetaCountProp(prop.Name)),
-			lsmkv.WithStrategy(lsmkv.StrategySetCollection)) // ref props do not have frequencies -> Set
+		// ref props do not have frequencies
+		bucketName := helpers.BucketFromPropNameLSM(helpers.MetaCountProp(prop.Name))
+		strategy, err := s.noFrequencyStrategy(bucketName)
+		if err != nil {
+			return err
+		}
+
+		err = s.store.CreateOrLoadBucket(ctx, bucketName,
+			lsmkv.WithStrategy(strategy))
 		if err != nil {
 			return err
 		}
@@ -213,12 +412,17 @@ func (s *Shard) addProperty(ctx context.Context, prop *models.Property) error {
 		return s.initGeoProp(prop)
 	}
 
-	strategy := lsmkv.StrategySetCollection
-	if inverted.HasFrequency(schema.DataType(prop.DataType[0])) {
-		strategy = lsmkv.StrategyMapCollection
+	bucketName := helpers.BucketFromPropNameLSM(prop.Name)
+	strategy := lsmkv.StrategyMapCollection
+	if !inverted.HasFrequency(schema.DataType(prop.DataType[0])) {
+		var err error
+		strategy, err = s.noFrequencyStrategy(bucketName)
+		if err != nil {
+			return err
+		}
 	}
 
-	err := s.store.CreateOrLoadBucket(ctx, helpers.BucketFromPropNameLSM(prop.Name),
+	err := s.store.CreateOrLoadBucket(ctx, bucketName,
 		lsmkv.WithStrategy(strategy))
 	if err != nil {
 		return err
@@ -238,6 +442,32 @@ func (s *Shard) updateVectorIndexConfig(ctx context.Context,
 	return s.vectorIndex.UpdateUserConfig(updated)
 }
 
+func (s *Shard) updateNamedVectorIndexConfigs(ctx context.Context,
+	updated map[string]schema.VectorIndexConfig) error {
+	for name, userConfig := range updated {
+		vectorIndex, ok := s.namedVectorIndexes[name]
+		if !ok {
+			return errors.Errorf("no vector index for vector %q", name)
+		}
+
+		if err := vectorIndex.UpdateUserConfig(userConfig); err != nil {
+			return errors.Wrapf(err, "vector %q", name)
+		}
+	}
+
+	return nil
+}
+
 func (s *Shard) shutdown(ctx context.Context) error {
+	// queued operations which were not applied yet remain in the queue log and
+	// are replayed on the next startup
+	if err := s.closeVectorIndexQueues(); err != nil {
+		return errors.Wrap(err, "close vector index queues")
+	}
+
+	if err := s.flushVectorIndexes(); err != nil {
+		return errors.Wrap(err, "flush vector indexes")
+	}
+
 	return s.store.Shutdown(ctx)
 }
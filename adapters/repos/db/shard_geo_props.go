@@ -30,6 +30,7 @@ func (s *Shard) initGeoProp(prop *models.Property) error {
 		CoordinatesForID:   s.makeCoordinatesForID(prop.Name),
 		DisablePersistence: false,
 		Logger:             s.index.logger,
+		SyncPolicy:         s.index.Config.WALSyncPolicy,
 	})
 	if err != nil {
 		return errors.Wrapf(err, "create geo index for prop %q", prop.Name)
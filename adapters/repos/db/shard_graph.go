@@ -0,0 +1,167 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+// graphInspector is implemented by graph-based vector indexes, such as hnsw,
+// which can report on and repair the structure of their graph
+type graphInspector interface {
+	Inspect() hnsw.GraphReport
+	Repair() (hnsw.RepairReport, error)
+}
+
+// recallEvaluator is implemented by approximate vector indexes, such as hnsw,
+// which can compare their results to an exact search
+type recallEvaluator interface {
+	EvaluateRecall(ctx context.Context, queries [][]float32, sampleSize, k,
+		ef int) (hnsw.RecallReport, error)
+}
+
+func (i *Index) inspectVectorIndex(shardName,
+	targetVector string) (*models.VectorIndexGraphReport, error) {
+	inspector, err := i.graphInspector(shardName, targetVector)
+	if err != nil {
+		return nil, err
+	}
+
+	return vectorIndexGraphReport(targetVector, inspector.Inspect()), nil
+}
+
+func (i *Index) repairVectorIndex(shardName,
+	targetVector string) (*models.VectorIndexRepairResult, error) {
+	inspector, err := i.graphInspector(shardName, targetVector)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := inspector.Repair()
+	if err != nil {
+		return nil, errors.Wrapf(err, "repair vector index of shard %s", shardName)
+	}
+
+	return &models.VectorIndexRepairResult{
+		TombstonesRemoved: int64(report.TombstonesRemoved),
+		Reconnected:       int64(report.Reconnected),
+		Graph:             vectorIndexGraphReport(targetVector, inspector.Inspect()),
+	}, nil
+}
+
+func (i *Index) graphInspector(shardName,
+	targetVector string) (graphInspector, error) {
+	shard, ok := i.Shards[shardName]
+	if !ok {
+		return nil, errors.Errorf("shard %s of class %s is not present on this node",
+			shardName, i.Config.ClassName)
+	}
+
+	vectorIndex, err := shard.vectorIndexFor(targetVector)
+	if err != nil {
+		return nil, err
+	}
+
+	inspector, ok := vectorIndex.(graphInspector)
+	if !ok {
+		return nil, errors.Errorf("vector index %q of shard %s is not graph-based "+
+			"and cannot be inspected", targetVector, shardName)
+	}
+
+	return inspector, nil
+}
+
+func (i *Index) evaluateVectorIndexRecall(ctx context.Context, shardName,
+	targetVector string,
+	params *models.VectorIndexRecallRequest) (*models.VectorIndexRecallReport, error) {
+	shard, ok := i.Shards[shardName]
+	if !ok {
+		return nil, errors.Errorf("shard %s of class %s is not present on this node",
+			shardName, i.Config.ClassName)
+	}
+
+	vectorIndex, err := shard.vectorIndexFor(targetVector)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluator, ok := vectorIndex.(recallEvaluator)
+	if !ok {
+		return nil, errors.Errorf("vector index %q of shard %s is exact, "+
+			"there is no recall to evaluate", targetVector, shardName)
+	}
+
+	queries := make([][]float32, len(params.Queries))
+	for pos, query := range params.Queries {
+		queries[pos] = query
+	}
+
+	report, err := evaluator.EvaluateRecall(ctx, queries, int(params.SampleSize),
+		int(params.K), int(params.Ef))
+	if err != nil {
+		return nil, errors.Wrapf(err, "evaluate recall of vector index of shard %s",
+			shardName)
+	}
+
+	return &models.VectorIndexRecallReport{
+		TargetVector:    targetVector,
+		Queries:         int64(report.Queries),
+		K:               int64(report.K),
+		Ef:              int64(report.EF),
+		Recall:          report.Recall,
+		MinRecall:       report.MinRecall,
+		ExactSearchTook: milliseconds(report.ExactTook),
+		Latency: &models.VectorIndexLatency{
+			P50: milliseconds(report.Latency.P50),
+			P90: milliseconds(report.Latency.P90),
+			P99: milliseconds(report.Latency.P99),
+			Max: milliseconds(report.Latency.Max),
+		},
+	}, nil
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func vectorIndexGraphReport(targetVector string,
+	report hnsw.GraphReport) *models.VectorIndexGraphReport {
+	levels := make([]*models.VectorIndexGraphLevel, len(report.Levels))
+	for i, level := range report.Levels {
+		levels[i] = &models.VectorIndexGraphLevel{
+			Level:              int64(level.Level),
+			Nodes:              int64(level.Nodes),
+			AverageConnections: level.AverageConnections,
+		}
+	}
+
+	unreachable := make([]int64, len(report.UnreachableIDs))
+	for i, id := range report.UnreachableIDs {
+		unreachable[i] = int64(id)
+	}
+
+	return &models.VectorIndexGraphReport{
+		TargetVector:   targetVector,
+		Nodes:          int64(report.Nodes),
+		Tombstones:     int64(report.Tombstones),
+		Entrypoint:     int64(report.Entrypoint),
+		MaxLevel:       int64(report.MaxLevel),
+		Levels:         levels,
+		Unreachable:    int64(report.Unreachable),
+		UnreachableIds: unreachable,
+	}
+}
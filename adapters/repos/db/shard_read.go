@@ -25,6 +25,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/filters"
 	"github.com/semi-technologies/weaviate/entities/multi"
+	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/storobj"
 	"github.com/sirupsen/logrus"
@@ -52,11 +53,18 @@ func (s *Shard) objectByID(ctx context.Context, id strfmt.UUID,
 		return nil, errors.Wrap(err, "unmarshal object")
 	}
 
+	if additional.Vector {
+		if err := s.loadVectors(obj); err != nil {
+			return nil, err
+		}
+	}
+
 	return obj, nil
 }
 
 func (s *Shard) multiObjectByID(ctx context.Context,
-	query []multi.Identifier) ([]*storobj.Object, error) {
+	query []multi.Identifier,
+	additional additional.Properties) ([]*storobj.Object, error) {
 	objects := make([]*storobj.Object, len(query))
 
 	ids := make([][]byte, len(query))
@@ -84,6 +92,12 @@ func (s *Shard) multiObjectByID(ctx context.Context,
 		if err != nil {
 			return nil, errors.Wrap(err, "unmarshal kind object")
 		}
+
+		if additional.Vector {
+			if err := s.loadVectors(obj); err != nil {
+				return nil, err
+			}
+		}
 		objects[i] = obj
 	}
 
@@ -137,54 +151,52 @@ func (s *Shard) objectByIndexID(ctx context.Context,
 	return obj, nil
 }
 
-func (s *Shard) vectorByIndexID(ctx context.Context, indexID uint64) ([]float32, error) {
-	keyBuf := make([]byte, 8)
-	binary.LittleEndian.PutUint64(keyBuf, indexID)
-
-	bytes, err := s.store.Bucket(helpers.ObjectsBucketLSM).
-		GetBySecondary(0, keyBuf)
-	if err != nil {
-		return nil, err
-	}
-
-	if bytes == nil {
-		return nil, storobj.NewErrNotFoundf(indexID,
-			"uuid found for docID, but object is nil")
-	}
-
-	return storobj.VectorFromBinary(bytes)
-}
-
 func (s *Shard) objectSearch(ctx context.Context, limit int,
 	filters *filters.LocalFilter, additional additional.Properties) ([]*storobj.Object, error) {
 	if filters == nil {
 		return s.objectList(ctx, limit, additional)
 	}
 
-	return inverted.NewSearcher(s.store, s.index.getSchema.GetSchemaSkipAuth(),
+	objs, err := inverted.NewSearcher(s.store, s.index.getSchema.GetSchemaSkipAuth(),
 		s.invertedRowCache, s.propertyIndices, s.index.classSearcher,
 		s.deletedDocIDs).
 		Object(ctx, limit, filters, additional, s.index.Config.ClassName)
+	if err != nil {
+		return nil, err
+	}
+
+	if additional.Vector {
+		if err := s.loadVectors(objs...); err != nil {
+			return nil, err
+		}
+	}
+
+	return objs, nil
 }
 
 func (s *Shard) objectVectorSearch(ctx context.Context, searchVector []float32,
-	limit int, filters *filters.LocalFilter, additional additional.Properties) ([]*storobj.Object, []float32, error) {
-	var allowList helpers.AllowList
-	beforeAll := time.Now()
-	if filters != nil {
-		list, err := inverted.NewSearcher(s.store, s.index.getSchema.GetSchemaSkipAuth(),
-			s.invertedRowCache, s.propertyIndices, s.index.classSearcher,
-			s.deletedDocIDs).
-			DocIDs(ctx, filters, additional, s.index.Config.ClassName)
-		if err != nil {
-			return nil, nil, errors.Wrap(err, "build inverted filter allow list")
+	targetVector string, waitForIndexing bool, limit int,
+	filters *filters.LocalFilter,
+	additional additional.Properties) ([]*storobj.Object, []float32, error) {
+	vectorIndex, err := s.vectorIndexFor(targetVector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if waitForIndexing {
+		if err := s.waitForVectorIndexQueue(ctx, targetVector); err != nil {
+			return nil, nil, err
 		}
+	}
 
-		allowList = list
+	beforeAll := time.Now()
+	allowList, err := s.buildAllowList(ctx, filters, additional)
+	if err != nil {
+		return nil, nil, err
 	}
 	invertedTook := time.Since(beforeAll)
 	beforeVector := time.Now()
-	ids, dists, err := s.vectorIndex.SearchByVector(searchVector, limit, allowList)
+	ids, dists, err := vectorIndex.SearchByVector(searchVector, limit, allowList)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "vector search")
 	}
@@ -211,6 +223,116 @@ func (s *Shard) objectVectorSearch(ctx context.Context, searchVector []float32,
 	return objs, dists, nil
 }
 
+// objectGeoSortSearch returns the objects nearest to the coordinates of the
+// geo sort, measured on its geoCoordinates property. The distances are in
+// meters.
+func (s *Shard) objectGeoSortSearch(ctx context.Context,
+	geoSort *filters.GeoSort, limit int, filters *filters.LocalFilter,
+	additional additional.Properties) ([]*storobj.Object, []float32, error) {
+	index, ok := s.propertyIndices.ByProp(geoSort.Property)
+	if !ok || index.Type != schema.DataTypeGeoCoordinates {
+		return nil, nil, errors.Errorf("property %q has no geo index",
+			geoSort.Property)
+	}
+
+	allowList, err := s.buildAllowList(ctx, filters, additional)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids, dists, err := index.GeoIndex.Nearest(ctx, geoSort.GeoCoordinates,
+		limit, allowList)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "geo sort on prop %q", geoSort.Property)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+
+	objs, err := s.objectsByDocID(ids, additional)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(objs) < len(ids) {
+		// some objects were deleted in the meantime, keep the distances aligned
+		// with the remaining ones
+		distsByID := make(map[uint64]float32, len(ids))
+		for i, id := range ids {
+			distsByID[id] = dists[i]
+		}
+
+		dists = make([]float32, len(objs))
+		for i, obj := range objs {
+			dists[i] = distsByID[obj.DocID()]
+		}
+	}
+
+	return objs, dists, nil
+}
+
+// objectVectorSearchBatch runs one vector search per search vector. The
+// filter is shared, so its allow list is only built once.
+func (s *Shard) objectVectorSearchBatch(ctx context.Context,
+	searchVectors [][]float32, targetVector string, waitForIndexing bool,
+	limit int, filters *filters.LocalFilter,
+	additional additional.Properties) ([][]*storobj.Object, [][]float32, error) {
+	vectorIndex, err := s.vectorIndexFor(targetVector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if waitForIndexing {
+		if err := s.waitForVectorIndexQueue(ctx, targetVector); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	allowList, err := s.buildAllowList(ctx, filters, additional)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids, dists, err := vectorIndex.SearchByVectorBatch(searchVectors, limit, allowList)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "vector search")
+	}
+
+	objs := make([][]*storobj.Object, len(ids))
+	for i := range ids {
+		if len(ids[i]) == 0 {
+			continue
+		}
+
+		objs[i], err = s.objectsByDocID(ids[i], additional)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return objs, dists, nil
+}
+
+// buildAllowList returns the doc ids matching the filter, or nil if there is
+// no filter
+func (s *Shard) buildAllowList(ctx context.Context, filters *filters.LocalFilter,
+	additional additional.Properties) (helpers.AllowList, error) {
+	if filters == nil {
+		return nil, nil
+	}
+
+	list, err := inverted.NewSearcher(s.store, s.index.getSchema.GetSchemaSkipAuth(),
+		s.invertedRowCache, s.propertyIndices, s.index.classSearcher,
+		s.deletedDocIDs).
+		DocIDs(ctx, filters, additional, s.index.Config.ClassName)
+	if err != nil {
+		return nil, errors.Wrap(err, "build inverted filter allow list")
+	}
+
+	return list, nil
+}
+
 func (s *Shard) objectsByDocID(ids []uint64,
 	additional additional.Properties) ([]*storobj.Object, error) {
 	out := make([]*storobj.Object, len(ids))
@@ -244,7 +366,14 @@ func (s *Shard) objectsByDocID(ids []uint64,
 		i++
 	}
 
-	return out[:i], nil
+	out = out[:i]
+	if additional.Vector {
+		if err := s.loadVectors(out...); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
 }
 
 func (s *Shard) objectList(ctx context.Context, limit int,
@@ -255,7 +384,7 @@ func (s *Shard) objectList(ctx context.Context, limit int,
 	defer cursor.Close()
 
 	for k, v := cursor.First(); k != nil && i < limit; k, v = cursor.Next() {
-		obj, err := storobj.FromBinary(v)
+		obj, err := storobj.FromBinaryOptional(v, additional)
 		if err != nil {
 			return nil, errors.Wrapf(err, "unmarhsal item %d", i)
 		}
@@ -264,5 +393,12 @@ func (s *Shard) objectList(ctx context.Context, limit int,
 		i++
 	}
 
-	return out[:i], nil
+	out = out[:i]
+	if additional.Vector {
+		if err := s.loadVectors(out...); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
 }
@@ -0,0 +1,80 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+func (i *Index) scrubShard(shardName string,
+	quarantine bool) (*models.ShardScrubReport, error) {
+	shard, ok := i.Shards[shardName]
+	if !ok {
+		return nil, errors.Errorf("shard %s of class %s is not present on this node",
+			shardName, i.Config.ClassName)
+	}
+
+	return shard.scrub(quarantine)
+}
+
+// scrub verifies the disk segments of every bucket of the shard, this
+// includes the buckets of the inverted index and of flat vector indexes. The
+// commit logs of hnsw indexes are not covered, they are checked on startup.
+func (s *Shard) scrub(quarantine bool) (*models.ShardScrubReport, error) {
+	results, err := s.store.Scrub(quarantine)
+	if err != nil {
+		return nil, errors.Wrapf(err, "scrub shard %s", s.name)
+	}
+
+	bucketNames := make([]string, 0, len(results))
+	for name := range results {
+		bucketNames = append(bucketNames, name)
+	}
+	sort.Strings(bucketNames)
+
+	report := &models.ShardScrubReport{Segments: []*models.SegmentScrubResult{}}
+	for _, name := range bucketNames {
+		for _, res := range results[name] {
+			segment := &models.SegmentScrubResult{
+				Bucket:      name,
+				Path:        s.relativeSegmentPath(res.Path),
+				Status:      res.Status,
+				Quarantined: res.Quarantined,
+			}
+
+			if res.Status == lsmkv.ScrubStatusCorrupt {
+				report.Corrupt++
+				if res.Error != nil {
+					segment.Error = res.Error.Error()
+				}
+			}
+
+			report.Segments = append(report.Segments, segment)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *Shard) relativeSegmentPath(path string) string {
+	rel, err := filepath.Rel(s.DBPathLSM(), path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
@@ -0,0 +1,122 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"sort"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+// rebuildStatusProvider is implemented by vector indexes which can rebuild
+// themselves in the background, such as hnsw. Indexes which don't implement
+// it are always reported as idle.
+type rebuildStatusProvider interface {
+	RebuildStatus() hnsw.RebuildStatus
+}
+
+// cacheStatsProvider is implemented by vector indexes which hold vectors in
+// an in-memory cache. The status of indexes without a cache omits the cache.
+type cacheStatsProvider interface {
+	CacheStats() hnsw.CacheStats
+}
+
+func (i *Index) shardsStatus() models.ShardStatusList {
+	names := make([]string, 0, len(i.Shards))
+	for name := range i.Shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(models.ShardStatusList, len(names))
+	for pos, name := range names {
+		out[pos] = i.Shards[name].status()
+	}
+
+	return out
+}
+
+func (s *Shard) status() *models.ShardStatus {
+	names := make([]string, 0, len(s.namedVectorIndexes))
+	for name := range s.namedVectorIndexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// the class-level vector is always listed first, followed by the named
+	// vectors in alphabetical order
+	indexes := make([]*models.VectorIndexStatus, 0, len(names)+1)
+	indexes = append(indexes, vectorIndexStatus("", s.vectorIndex))
+	for _, name := range names {
+		indexes = append(indexes, vectorIndexStatus(name, s.namedVectorIndexes[name]))
+	}
+
+	for _, status := range indexes {
+		status.QueueLength = s.vectorIndexQueueLength(status.TargetVector)
+	}
+
+	return &models.ShardStatus{
+		Name:          s.name,
+		VectorIndexes: indexes,
+	}
+}
+
+func vectorIndexStatus(targetVector string,
+	index VectorIndex) *models.VectorIndexStatus {
+	rebuild := hnsw.RebuildStatus{Status: hnsw.RebuildStatusIdle}
+	if provider, ok := index.(rebuildStatusProvider); ok {
+		rebuild = provider.RebuildStatus()
+	}
+
+	out := &models.VectorIndexRebuildStatus{
+		Status:    rebuild.Status,
+		Processed: rebuild.Processed,
+		Total:     rebuild.Total,
+		Error:     rebuild.Error,
+	}
+
+	if !rebuild.StartedAt.IsZero() {
+		out.Started = strfmt.DateTime(rebuild.StartedAt)
+	}
+	if !rebuild.FinishedAt.IsZero() {
+		out.Completed = strfmt.DateTime(rebuild.FinishedAt)
+	}
+
+	status := &models.VectorIndexStatus{
+		TargetVector: targetVector,
+		Rebuild:      out,
+	}
+
+	if provider, ok := index.(cacheStatsProvider); ok {
+		status.Cache = vectorCacheStatus(provider.CacheStats())
+	}
+
+	return status
+}
+
+func vectorCacheStatus(stats hnsw.CacheStats) *models.VectorCacheStatus {
+	out := &models.VectorCacheStatus{
+		Entries:   stats.Entries,
+		SizeBytes: stats.SizeBytes,
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		Evictions: stats.Evictions,
+	}
+
+	if reads := stats.Hits + stats.Misses; reads > 0 {
+		out.HitRate = float32(stats.Hits) / float32(reads)
+	}
+
+	return out
+}
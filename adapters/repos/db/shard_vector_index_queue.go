@@ -0,0 +1,122 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// vectorIndexWriter is the part of a VectorIndex used by the write path. It
+// is implemented by the vector index itself and by its queue, so writes don't
+// need to know whether indexing is synchronous or not.
+type vectorIndexWriter interface {
+	Add(id uint64, vector []float32) error
+	Delete(id uint64) error
+}
+
+// initVectorIndexQueues creates a queue in front of every vector index of the
+// shard. Must be called after the vector indexes were initialized.
+func (s *Shard) initVectorIndexQueues() error {
+	queue, err := s.initVectorIndexQueue(s.ID(), s.vectorIndex)
+	if err != nil {
+		return err
+	}
+
+	s.vectorIndexQueues = map[string]*vectorIndexQueue{"": queue}
+
+	for name, vectorIndex := range s.namedVectorIndexes {
+		queue, err := s.initVectorIndexQueue(s.namedVectorIndexID(name), vectorIndex)
+		if err != nil {
+			return errors.Wrapf(err, "vector %q", name)
+		}
+
+		s.vectorIndexQueues[name] = queue
+	}
+
+	return nil
+}
+
+func (s *Shard) initVectorIndexQueue(id string,
+	vectorIndex VectorIndex) (*vectorIndexQueue, error) {
+	logger := s.index.logger.WithFields(logrus.Fields{
+		"shard": s.name,
+		"index": s.index.ID(),
+		"class": s.index.Config.ClassName,
+	})
+
+	path := fmt.Sprintf("%s/%s.vectorqueue", s.index.Config.RootPath, id)
+	return newVectorIndexQueue(path, vectorIndex, s.index.Config.WALSyncPolicy,
+		logger)
+}
+
+// vectorIndexOrQueue returns the queue of the given vector if indexing is
+// asynchronous and the vector index itself otherwise. The empty string
+// identifies the class-level vector.
+func (s *Shard) vectorIndexOrQueue(targetVector string) vectorIndexWriter {
+	if queue, ok := s.vectorIndexQueues[targetVector]; ok {
+		return queue
+	}
+
+	if targetVector == "" {
+		return s.vectorIndex
+	}
+
+	return s.namedVectorIndexes[targetVector]
+}
+
+// vectorIndexQueueLength returns the number of pending operations of the
+// given vector, it is always 0 if indexing is synchronous
+func (s *Shard) vectorIndexQueueLength(targetVector string) int64 {
+	queue, ok := s.vectorIndexQueues[targetVector]
+	if !ok {
+		return 0
+	}
+
+	return queue.Length()
+}
+
+// waitForVectorIndexQueue blocks until all operations of the given vector
+// which were queued before the call are part of the vector index. It returns
+// immediately if indexing is synchronous.
+func (s *Shard) waitForVectorIndexQueue(ctx context.Context,
+	targetVector string) error {
+	queue, ok := s.vectorIndexQueues[targetVector]
+	if !ok {
+		return nil
+	}
+
+	return queue.Wait(ctx)
+}
+
+func (s *Shard) closeVectorIndexQueues() error {
+	for name, queue := range s.vectorIndexQueues {
+		if err := queue.Close(); err != nil {
+			return errors.Wrapf(err, "close queue of vector %q", name)
+		}
+	}
+
+	return nil
+}
+
+func (s *Shard) dropVectorIndexQueues() error {
+	for name, queue := range s.vectorIndexQueues {
+		if err := queue.Drop(); err != nil {
+			return errors.Wrapf(err, "drop queue of vector %q", name)
+		}
+	}
+
+	return nil
+}
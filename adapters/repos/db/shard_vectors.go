@@ -0,0 +1,403 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+	"github.com/sirupsen/logrus"
+)
+
+// The vectors of a shard are stored in buckets of their own, one per vector
+// of the class, rather than as part of the objects. Reading a vector, e.g. to
+// refill the vector cache of an hnsw index, is then a lookup of a fixed-width
+// value, and reading objects without their vectors skips the vector bytes.
+
+// vectorBucketsMigratedFile marks the lsm directory of a shard whose vectors
+// have been moved from the objects bucket into the vector buckets
+const vectorBucketsMigratedFile = "vector_buckets.migrated"
+
+// vectorBucketsCopiedFile marks a migration which has copied all vectors
+// into the vector buckets, but might not have removed them from all objects
+// yet. From then on the vector buckets are the only complete copy.
+const vectorBucketsCopiedFile = "vector_buckets.copied"
+
+// vectorMigrationBatchSize is the number of objects which are rewritten
+// without their vectors per cursor of the objects bucket
+const vectorMigrationBatchSize = 1000
+
+// vectorBucketNames returns the names of the vector buckets keyed by the name
+// of their vector, "" is the class-level vector
+func (s *Shard) vectorBucketNames() map[string]string {
+	out := map[string]string{"": helpers.VectorsBucketLSM}
+	for name := range s.index.namedVectorIndexUserConfigs {
+		out[name] = helpers.NamedVectorsBucketLSM(name)
+	}
+
+	return out
+}
+
+// initVectorBuckets creates or loads the vector buckets. Shards which were
+// created before the vector buckets existed keep their vectors only in the
+// objects bucket, they are migrated the first time they are loaded.
+func (s *Shard) initVectorBuckets(ctx context.Context) error {
+	marker := filepath.Join(s.DBPathLSM(), vectorBucketsMigratedFile)
+	migrated, err := fileExists(marker)
+	if err != nil {
+		return errors.Wrap(err, "check migration marker")
+	}
+
+	copiedMarker := filepath.Join(s.DBPathLSM(), vectorBucketsCopiedFile)
+	copied, err := fileExists(copiedMarker)
+	if err != nil {
+		return errors.Wrap(err, "check migration marker")
+	}
+
+	names := s.vectorBucketNames()
+	s.vectorBuckets = make(map[string]*lsmkv.Bucket, len(names))
+	for name, bucketName := range names {
+		if err := s.store.CreateOrLoadBucket(ctx, bucketName,
+			lsmkv.WithStrategy(lsmkv.StrategyReplace)); err != nil {
+			return errors.Wrapf(err, "create bucket %q", bucketName)
+		}
+		s.vectorBuckets[name] = s.store.Bucket(bucketName)
+	}
+
+	if migrated {
+		return nil
+	}
+
+	if !copied {
+		if err := s.migrateVectorBuckets(); err != nil {
+			return errors.Wrap(err, "migrate vectors from objects bucket")
+		}
+
+		if err := os.WriteFile(copiedMarker, nil, 0o666); err != nil {
+			return errors.Wrap(err, "write migration marker")
+		}
+	}
+
+	if err := s.removeVectorsFromObjects(); err != nil {
+		return errors.Wrap(err, "remove vectors from objects bucket")
+	}
+
+	if err := os.WriteFile(marker, nil, 0o666); err != nil {
+		return errors.Wrap(err, "write migration marker")
+	}
+
+	if err := os.Remove(copiedMarker); err != nil {
+		return errors.Wrap(err, "remove migration marker")
+	}
+
+	return nil
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// migrateVectorBuckets copies the vectors of all objects into the vector
+// buckets. The objects still contain their vectors afterwards, they are
+// removed by removeVectorsFromObjects once the copy is on disk. A copy which
+// was interrupted is simply repeated, it overwrites whatever it left behind.
+func (s *Shard) migrateVectorBuckets() error {
+	before := time.Now()
+
+	c := s.store.Bucket(helpers.ObjectsBucketLSM).Cursor()
+	defer c.Close()
+
+	count := 0
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		docID, err := storobj.DocIDFromBinary(v)
+		if err != nil {
+			return errors.Wrapf(err, "object %d: doc id", count)
+		}
+
+		vector, err := storobj.VectorFromBinary(v)
+		if err != nil {
+			return errors.Wrapf(err, "object %d: vector", count)
+		}
+
+		vectors := map[string][]float32{"": vector}
+		if len(s.vectorBuckets) > 1 {
+			named, err := storobj.NamedVectorsFromBinary(v)
+			if err != nil {
+				return errors.Wrapf(err, "object %d: named vectors", count)
+			}
+
+			for name, vector := range named {
+				if _, ok := s.vectorBuckets[name]; ok {
+					vectors[name] = vector
+				}
+			}
+		}
+
+		if err := s.putVectorsLSM(docID, vectors); err != nil {
+			return errors.Wrapf(err, "object %d", count)
+		}
+		count++
+	}
+
+	// the migration is only marked as complete once the vectors are in
+	// segments, so that it does not depend on the commit logs
+	for name, bucket := range s.vectorBuckets {
+		if err := bucket.FlushAndSwitch(); err != nil {
+			return errors.Wrapf(err, "flush bucket of vector %q", name)
+		}
+	}
+
+	if count > 0 {
+		s.index.logger.WithFields(logrus.Fields{
+			"action":  "shard_vector_buckets_migrated",
+			"shard":   s.ID(),
+			"objects": count,
+			"took":    time.Since(before),
+		}).Infof("copied the vectors of %d objects of shard %q into vector buckets",
+			count, s.ID())
+	}
+
+	return nil
+}
+
+// removeVectorsFromObjects rewrites every object which still contains its
+// vectors without them. The objects bucket can't be written while a cursor
+// is open, so the objects are rewritten in batches, each of which starts a
+// new cursor after the last key of the previous one. Objects without vectors
+// are left as they are, which makes it safe to resume after a crash.
+func (s *Shard) removeVectorsFromObjects() error {
+	before := time.Now()
+	bucket := s.store.Bucket(helpers.ObjectsBucketLSM)
+
+	count := 0
+	var last []byte
+	for {
+		keys, objects, next, err := s.objectsWithVectors(bucket, last)
+		if err != nil {
+			return err
+		}
+
+		for i, obj := range objects {
+			data, err := objectBytesLSM(obj)
+			if err != nil {
+				return errors.Wrapf(err, "marshal object %s", obj.ID())
+			}
+
+			if err := s.upsertObjectDataLSM(bucket, keys[i], data,
+				obj.DocID()); err != nil {
+				return errors.Wrapf(err, "put object %s", obj.ID())
+			}
+		}
+		count += len(objects)
+
+		if next == nil {
+			break
+		}
+		last = next
+	}
+
+	if err := bucket.FlushAndSwitch(); err != nil {
+		return errors.Wrap(err, "flush objects bucket")
+	}
+
+	if count > 0 {
+		s.index.logger.WithFields(logrus.Fields{
+			"action":  "shard_vector_buckets_migrated",
+			"shard":   s.ID(),
+			"objects": count,
+			"took":    time.Since(before),
+		}).Infof("removed the vectors of %d objects of shard %q from the objects "+
+			"bucket", count, s.ID())
+	}
+
+	return nil
+}
+
+// objectsWithVectors visits up to vectorMigrationBatchSize objects after the
+// given key and returns those which still contain vectors, together with
+// their keys. next is the last key that was visited, it is nil once the
+// cursor is exhausted.
+func (s *Shard) objectsWithVectors(bucket *lsmkv.Bucket,
+	after []byte) (keys [][]byte, objects []*storobj.Object, next []byte, err error) {
+	c := bucket.Cursor()
+	defer c.Close()
+
+	var k, v []byte
+	if after == nil {
+		k, v = c.First()
+	} else {
+		k, v = c.Seek(after)
+		if k != nil && bytes.Equal(k, after) {
+			k, v = c.Next()
+		}
+	}
+
+	for visited := 0; k != nil; k, v = c.Next() {
+		if visited == vectorMigrationBatchSize {
+			return keys, objects, next, nil
+		}
+		visited++
+
+		// the cursor may reuse its buffers, so the key and value are copied
+		next = append([]byte{}, k...)
+		obj, err := storobj.FromBinary(append([]byte{}, v...))
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "unmarshal object %x", k)
+		}
+
+		if len(obj.Vector) == 0 && len(obj.Vectors) == 0 {
+			continue
+		}
+
+		keys = append(keys, next)
+		objects = append(objects, obj)
+	}
+
+	return keys, objects, nil, nil
+}
+
+// putVectorsLSM stores the vectors of a doc id, keyed like vectorBuckets.
+// Vectors of length 0 are skipped.
+func (s *Shard) putVectorsLSM(docID uint64,
+	vectors map[string][]float32) error {
+	key := helpers.VectorKey(docID)
+	for name, vector := range vectors {
+		if len(vector) == 0 {
+			continue
+		}
+
+		bucket, ok := s.vectorBuckets[name]
+		if !ok {
+			return errors.Errorf("class has no vector named %q", name)
+		}
+
+		if err := bucket.Put(key, helpers.VectorToBytes(vector)); err != nil {
+			return errors.Wrapf(err, "put vector %q of doc id %d", name, docID)
+		}
+	}
+
+	return nil
+}
+
+// deleteVectorsLSM removes all vectors of a doc id
+func (s *Shard) deleteVectorsLSM(docID uint64) error {
+	key := helpers.VectorKey(docID)
+	for name, bucket := range s.vectorBuckets {
+		if err := bucket.Delete(key); err != nil {
+			return errors.Wrapf(err, "delete vector %q of doc id %d", name, docID)
+		}
+	}
+
+	return nil
+}
+
+// updateVectorsLSM stores the vectors of an object which was just written to
+// the objects bucket. If the object replaced a previous version, the vectors
+// of the old doc id are removed.
+func (s *Shard) updateVectorsLSM(object *storobj.Object,
+	status objectInsertStatus) error {
+	if status.docIDChanged {
+		if err := s.deleteVectorsLSM(status.oldDocID); err != nil {
+			return err
+		}
+	}
+
+	vectors := make(map[string][]float32, len(object.Vectors)+1)
+	for name, vector := range object.Vectors {
+		vectors[name] = vector
+	}
+	vectors[""] = object.Vector
+
+	return s.putVectorsLSM(status.docID, vectors)
+}
+
+// loadVectors sets the vectors of the objects from the vector buckets. Any
+// vectors contained in the object payloads are outdated and are replaced.
+func (s *Shard) loadVectors(objs ...*storobj.Object) error {
+	for _, obj := range objs {
+		key := helpers.VectorKey(obj.DocID())
+		obj.Vector = nil
+		obj.Vectors = nil
+
+		for name, bucket := range s.vectorBuckets {
+			v, err := bucket.Get(key)
+			if err != nil {
+				return errors.Wrapf(err, "get vector %q of doc id %d", name,
+					obj.DocID())
+			}
+
+			if v == nil {
+				continue
+			}
+
+			if name == "" {
+				obj.Vector = helpers.VectorFromBytes(v)
+				continue
+			}
+
+			if obj.Vectors == nil {
+				obj.Vectors = map[string][]float32{}
+			}
+			obj.Vectors[name] = helpers.VectorFromBytes(v)
+		}
+	}
+
+	return nil
+}
+
+// objectBytesLSM marshals an object for the objects bucket, which does not
+// contain the vectors
+func objectBytesLSM(object *storobj.Object) ([]byte, error) {
+	withoutVectors := *object
+	withoutVectors.Vector = nil
+	withoutVectors.Vectors = nil
+
+	return withoutVectors.MarshalBinary()
+}
+
+// vectorByIndexID is the lookup of the class-level vector for hnsw indexes
+func (s *Shard) vectorByIndexID(ctx context.Context, indexID uint64) ([]float32, error) {
+	return s.namedVectorByIndexID("")(ctx, indexID)
+}
+
+// namedVectorByIndexID returns a lookup function for the vector with the given
+// name, it is the equivalent of vectorByIndexID for named vector indexes
+func (s *Shard) namedVectorByIndexID(name string) hnsw.VectorForID {
+	return func(ctx context.Context, indexID uint64) ([]float32, error) {
+		v, err := s.vectorBuckets[name].Get(helpers.VectorKey(indexID))
+		if err != nil {
+			return nil, err
+		}
+
+		if v == nil {
+			return nil, storobj.NewErrNotFoundf(indexID,
+				"no vector %q for docID", name)
+		}
+
+		return helpers.VectorFromBytes(v), nil
+	}
+}
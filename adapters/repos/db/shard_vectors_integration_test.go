@@ -0,0 +1,325 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/multi"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/semi-technologies/weaviate/entities/search"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+	"github.com/semi-technologies/weaviate/usecases/traverser"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardVectorBuckets(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	logger, _ := test.NewNullLogger()
+	class := &models.Class{
+		Class:               "VectorBucketsClass",
+		VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: invertedConfig(),
+		VectorConfig: map[string]models.VectorConfig{
+			"title": {
+				Vectorizer:        "none",
+				VectorIndexType:   "flat",
+				VectorIndexConfig: flat.UserConfig{Distance: hnsw.DistanceCosine},
+			},
+		},
+		Properties: []*models.Property{{
+			Name:     "stringProp",
+			DataType: []string{string(schema.DataTypeString)},
+		}},
+	}
+	schemaGetter := &fakeSchemaGetter{shardState: singleShardState()}
+	newRepo := func() *DB {
+		repo := New(logger, Config{
+			RootPath:            dirName,
+			QueryMaximumResults: 10000,
+		}, &fakeRemoteClient{}, &fakeNodeResolver{})
+		repo.SetSchemaGetter(schemaGetter)
+		require.Nil(t, repo.WaitForStartup(testCtx()))
+		return repo
+	}
+
+	repo := newRepo()
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("creating the class", func(t *testing.T) {
+		require.Nil(t,
+			migrator.AddClass(context.Background(), class, schemaGetter.shardState))
+
+		// update schema getter so it's in sync with class
+		schemaGetter.schema = schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{class},
+			},
+		}
+	})
+
+	shard := func() *Shard {
+		return repo.GetIndex(schema.ClassName(class.Class)).
+			Shards[schemaGetter.shardState.AllPhysicalShards()[0]]
+	}
+
+	// the migration rewrites the objects in batches, more than two of them
+	// are needed to cover the transition from one batch to the next
+	const size = 2*vectorMigrationBatchSize + 500
+	ids := make([]strfmt.UUID, size)
+	vectors := make([][]float32, size)
+	titles := make([][]float32, size)
+	for i := range ids {
+		ids[i] = strfmt.UUID(uuid.New().String())
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32()}
+		titles[i] = make([]float32, 8)
+		for j := range titles[i] {
+			titles[i][j] = rand.Float32() * 10
+		}
+	}
+
+	t.Run("importing objects", func(t *testing.T) {
+		for i := range ids {
+			err := repo.PutObject(context.Background(), &models.Object{
+				ID:         ids[i],
+				Class:      class.Class,
+				Properties: map[string]interface{}{"stringProp": "value"},
+				Vectors:    models.Vectors{"title": titles[i]},
+			}, vectors[i])
+			require.Nil(t, err)
+		}
+	})
+
+	assertObjectsWithoutVectors := func(t *testing.T, expected int) {
+		c := shard().store.Bucket(helpers.ObjectsBucketLSM).Cursor()
+		defer c.Close()
+
+		count := 0
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			vector, err := storobj.VectorFromBinary(v)
+			require.Nil(t, err)
+			assert.Len(t, vector, 0)
+
+			named, err := storobj.NamedVectorsFromBinary(v)
+			require.Nil(t, err)
+			assert.Len(t, named, 0)
+			count++
+		}
+		assert.Equal(t, expected, count)
+	}
+
+	t.Run("objects are stored without their vectors", func(t *testing.T) {
+		assertObjectsWithoutVectors(t, size)
+	})
+
+	assertVectors := func(t *testing.T) {
+		for i, id := range ids {
+			res, err := repo.ObjectByID(context.Background(), id, nil,
+				additional.Properties{Vector: true})
+			require.Nil(t, err)
+			require.NotNil(t, res)
+			assert.Equal(t, vectors[i], []float32(res.Vector))
+			assert.Equal(t, titles[i], []float32(res.Vectors["title"]))
+		}
+	}
+
+	search := func(t *testing.T, vector []float32, targetVector string,
+		withVector bool) []search.Result {
+		res, err := repo.VectorClassSearch(context.Background(), traverser.GetParams{
+			SearchVector: vector,
+			TargetVector: targetVector,
+			ClassName:    class.Class,
+			Pagination:   &filters.Pagination{Limit: 3},
+			AdditionalProperties: additional.Properties{
+				Vector: withVector,
+			},
+		})
+		require.Nil(t, err)
+		require.Len(t, res, 3)
+		return res
+	}
+
+	assertSearch := func(t *testing.T) {
+		for _, i := range []int{3, 17, 42} {
+			res := search(t, vectors[i], "", true)
+			assert.Equal(t, ids[i], res[0].ID)
+			assert.Equal(t, vectors[i], []float32(res[0].Vector))
+
+			// the flat index uses the cosine distance, so a scaled query
+			// vector finds the same object
+			query := make([]float32, len(titles[i]))
+			for j, v := range titles[i] {
+				query[j] = v * 3
+			}
+			res = search(t, query, "title", true)
+			assert.Equal(t, ids[i], res[0].ID)
+			assert.Equal(t, titles[i], []float32(res[0].Vectors["title"]))
+		}
+	}
+
+	t.Run("vectors are read from the vector buckets", func(t *testing.T) {
+		assertVectors(t)
+		assertSearch(t)
+	})
+
+	t.Run("results without vector do not load it", func(t *testing.T) {
+		res := search(t, vectors[5], "", false)
+		assert.Equal(t, ids[5], res[0].ID)
+		assert.Nil(t, res[0].Vector)
+		assert.Nil(t, res[0].Vectors)
+
+		obj, err := repo.ObjectByID(context.Background(), ids[5], nil,
+			additional.Properties{})
+		require.Nil(t, err)
+		require.NotNil(t, obj)
+		assert.Len(t, obj.Vector, 0)
+		assert.Len(t, obj.Vectors, 0)
+
+		objs, err := repo.MultiGet(context.Background(), []multi.Identifier{{
+			ID: ids[5].String(), ClassName: class.Class,
+		}}, additional.Properties{})
+		require.Nil(t, err)
+		require.Len(t, objs, 1)
+		assert.Equal(t, ids[5], objs[0].ID)
+		assert.Len(t, objs[0].Vector, 0)
+	})
+
+	t.Run("updates and deletes keep the vector buckets in sync", func(t *testing.T) {
+		vectors[0] = []float32{0.5, 0.5, 0.5}
+		err := repo.PutObject(context.Background(), &models.Object{
+			ID:         ids[0],
+			Class:      class.Class,
+			Properties: map[string]interface{}{"stringProp": "updated"},
+			Vectors:    models.Vectors{"title": titles[0]},
+		}, vectors[0])
+		require.Nil(t, err)
+		require.Nil(t, repo.DeleteObject(context.Background(), class.Class, ids[1]))
+		ids, vectors, titles = append(ids[:1], ids[2:]...),
+			append(vectors[:1], vectors[2:]...), append(titles[:1], titles[2:]...)
+
+		// one entry per remaining object, the old doc ids are gone
+		for name, bucket := range shard().vectorBuckets {
+			c := bucket.Cursor()
+			count := 0
+			for k, _ := c.First(); k != nil; k, _ = c.Next() {
+				count++
+			}
+			c.Close()
+			assert.Equal(t, size-1, count, name)
+		}
+
+		assertVectors(t)
+	})
+
+	t.Run("migrating a shard written by an earlier version", func(t *testing.T) {
+		lsmDir := shard().DBPathLSM()
+		require.Nil(t, repo.Shutdown(context.Background()))
+		downgradeVectorBuckets(t, lsmDir, logger)
+
+		repo = newRepo()
+
+		_, err := os.Stat(filepath.Join(lsmDir, vectorBucketsMigratedFile))
+		assert.Nil(t, err)
+		_, err = os.Stat(filepath.Join(lsmDir, vectorBucketsCopiedFile))
+		assert.True(t, os.IsNotExist(err))
+		assertObjectsWithoutVectors(t, size-1)
+		assertVectors(t)
+		assertSearch(t)
+	})
+
+	require.Nil(t, repo.Shutdown(context.Background()))
+}
+
+// downgradeVectorBuckets rewrites the lsm store of a shard into the layout of
+// earlier versions: the objects contain their vectors and there are no vector
+// buckets.
+func downgradeVectorBuckets(t *testing.T, lsmDir string,
+	logger logrus.FieldLogger) {
+	store, err := lsmkv.New(lsmDir, logger)
+	require.Nil(t, err)
+	require.Nil(t, store.CreateOrLoadBucket(context.Background(),
+		helpers.ObjectsBucketLSM, lsmkv.WithStrategy(lsmkv.StrategyReplace),
+		lsmkv.WithSecondaryIndicies(1)))
+	for _, name := range []string{helpers.VectorsBucketLSM,
+		helpers.NamedVectorsBucketLSM("title")} {
+		require.Nil(t, store.CreateOrLoadBucket(context.Background(), name,
+			lsmkv.WithStrategy(lsmkv.StrategyReplace)))
+	}
+
+	objects := store.Bucket(helpers.ObjectsBucketLSM)
+	vectors := store.Bucket(helpers.VectorsBucketLSM)
+	titles := store.Bucket(helpers.NamedVectorsBucketLSM("title"))
+
+	// the cursor may reuse its buffers, so the keys and values are copied
+	var keys, values [][]byte
+	c := objects.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		keys = append(keys, append([]byte{}, k...))
+		values = append(values, append([]byte{}, v...))
+	}
+	c.Close()
+
+	for i := range keys {
+		obj, err := storobj.FromBinary(values[i])
+		require.Nil(t, err)
+
+		key := helpers.VectorKey(obj.DocID())
+		vector, err := vectors.Get(key)
+		require.Nil(t, err)
+		title, err := titles.Get(key)
+		require.Nil(t, err)
+
+		obj.Vector = helpers.VectorFromBytes(vector)
+		obj.Vectors = map[string][]float32{"title": helpers.VectorFromBytes(title)}
+		data, err := obj.MarshalBinary()
+		require.Nil(t, err)
+
+		docID := make([]byte, 8)
+		binary.LittleEndian.PutUint64(docID, obj.DocID())
+		require.Nil(t, objects.Put(keys[i], data, lsmkv.WithSecondaryKey(0, docID)))
+	}
+
+	require.Nil(t, store.Shutdown(context.Background()))
+	require.Nil(t, os.RemoveAll(filepath.Join(lsmDir, helpers.VectorsBucketLSM)))
+	require.Nil(t, os.RemoveAll(filepath.Join(lsmDir,
+		helpers.NamedVectorsBucketLSM("title"))))
+	require.Nil(t, os.Remove(filepath.Join(lsmDir, vectorBucketsMigratedFile)))
+}
@@ -203,6 +203,11 @@ func (b *objectsBatcher) storeSingleObjectInAdditionalStorage(ctx context.Contex
 		return
 	}
 
+	if err := b.shard.updateNamedVectorIndexes(object.Vectors, status); err != nil {
+		b.setErrorAtIndex(errors.Wrap(err, "insert to named vector indexes"), index)
+		return
+	}
+
 	if err := b.shard.updatePropertySpecificIndices(object, status); err != nil {
 		b.setErrorAtIndex(errors.Wrap(err, "update prop-specific indices"), index)
 		return
@@ -253,7 +258,7 @@ func (b *objectsBatcher) flushWALs(ctx context.Context) {
 		}
 	}
 
-	if err := b.shard.vectorIndex.Flush(); err != nil {
+	if err := b.shard.flushVectorIndexes(); err != nil {
 		for i := range b.objects {
 			b.setErrorAtIndex(err, i)
 		}
@@ -56,19 +56,29 @@ func (s *Shard) deleteObject(ctx context.Context, id strfmt.UUID) error {
 		return errors.Wrap(err, "delete object from bucket")
 	}
 
+	if err := s.deleteVectorsLSM(docID); err != nil {
+		return errors.Wrap(err, "delete from vector buckets")
+	}
+
 	// in-mem
 	// TODO: do we still need this?
 	s.deletedDocIDs.Add(docID)
 
-	if err := s.vectorIndex.Delete(docID); err != nil {
+	if err := s.vectorIndexOrQueue("").Delete(docID); err != nil {
 		return errors.Wrap(err, "delete from vector index")
 	}
 
+	for name := range s.namedVectorIndexes {
+		if err := s.vectorIndexOrQueue(name).Delete(docID); err != nil {
+			return errors.Wrapf(err, "delete from index of vector %q", name)
+		}
+	}
+
 	if err := s.store.WriteWALs(); err != nil {
 		return errors.Wrap(err, "flush all buffered WALs")
 	}
 
-	if err := s.vectorIndex.Flush(); err != nil {
+	if err := s.flushVectorIndexes(); err != nil {
 		return errors.Wrap(err, "flush all vector index buffered WALs")
 	}
 
@@ -84,8 +84,9 @@ func (s *Shard) extendInvertedIndexItemWithFrequencyLSM(b, hashBucket *lsmkv.Buc
 
 func (s *Shard) extendInvertedIndexItemLSM(b, hashBucket *lsmkv.Bucket,
 	item inverted.Countable, docID uint64) error {
-	if b.Strategy() != lsmkv.StrategySetCollection {
-		panic("prop has no frequency, but bucket does not have 'Set' strategy")
+	if b.Strategy() != lsmkv.StrategySetCollection &&
+		b.Strategy() != lsmkv.StrategyRoaringSet {
+		panic("prop has no frequency, but bucket does not have 'Set' or 'RoaringSet' strategy")
 	}
 
 	hash, err := generateRowHash()
@@ -97,6 +98,10 @@ func (s *Shard) extendInvertedIndexItemLSM(b, hashBucket *lsmkv.Bucket,
 		return err
 	}
 
+	if b.Strategy() == lsmkv.StrategyRoaringSet {
+		return b.RoaringSetAddOne(item.Data, docID)
+	}
+
 	docIDBytes := make([]byte, 8)
 	binary.LittleEndian.PutUint64(docIDBytes, docID)
 
@@ -105,8 +110,9 @@ func (s *Shard) extendInvertedIndexItemLSM(b, hashBucket *lsmkv.Bucket,
 
 func (s *Shard) batchExtendInvertedIndexItemsLSMNoFrequency(b, hashBucket *lsmkv.Bucket,
 	item inverted.MergeItem) error {
-	if b.Strategy() != lsmkv.StrategySetCollection {
-		panic("prop has no frequency, but bucket does not have 'Set' strategy")
+	if b.Strategy() != lsmkv.StrategySetCollection &&
+		b.Strategy() != lsmkv.StrategyRoaringSet {
+		panic("prop has no frequency, but bucket does not have 'Set' or 'RoaringSet' strategy")
 	}
 
 	hash, err := generateRowHash()
@@ -118,6 +124,15 @@ func (s *Shard) batchExtendInvertedIndexItemsLSMNoFrequency(b, hashBucket *lsmkv
 		return err
 	}
 
+	if b.Strategy() == lsmkv.StrategyRoaringSet {
+		docIDs := make([]uint64, len(item.DocIDs))
+		for i, idTuple := range item.DocIDs {
+			docIDs[i] = idTuple.DocID
+		}
+
+		return b.RoaringSetAddList(item.Data, docIDs)
+	}
+
 	docIDs := make([][]byte, len(item.DocIDs))
 	for i, idTuple := range item.DocIDs {
 		docIDs[i] = make([]byte, 8)
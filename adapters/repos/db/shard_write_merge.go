@@ -37,11 +37,15 @@ func (s *Shard) mergeObject(ctx context.Context, merge objects.MergeDocument) er
 		return errors.Wrap(err, "update vector index")
 	}
 
+	if err := s.updateNamedVectorIndexes(next.Vectors, status); err != nil {
+		return errors.Wrap(err, "update named vector indexes")
+	}
+
 	if err := s.store.WriteWALs(); err != nil {
 		return errors.Wrap(err, "flush all buffered WALs")
 	}
 
-	if err := s.vectorIndex.Flush(); err != nil {
+	if err := s.flushVectorIndexes(); err != nil {
 		return errors.Wrap(err, "flush all vector index buffered WALs")
 	}
 
@@ -56,7 +60,7 @@ func (s *Shard) mergeObjectInStorage(merge objects.MergeDocument,
 		return nil, objectInsertStatus{}, errors.Wrap(err, "get bucket")
 	}
 
-	nextObj, _, err := s.mergeObjectData(previous, merge)
+	nextObj, _, err := s.mergeObjectData(previous, merge, true)
 	if err != nil {
 		return nil, objectInsertStatus{}, errors.Wrap(err, "merge object data")
 	}
@@ -67,7 +71,7 @@ func (s *Shard) mergeObjectInStorage(merge objects.MergeDocument,
 	}
 
 	nextObj.SetDocID(status.docID)
-	nextBytes, err := nextObj.MarshalBinary()
+	nextBytes, err := objectBytesLSM(nextObj)
 	if err != nil {
 		return nil, status, errors.Wrapf(err, "marshal object %s to binary", nextObj.ID())
 	}
@@ -76,6 +80,10 @@ func (s *Shard) mergeObjectInStorage(merge objects.MergeDocument,
 		return nil, status, errors.Wrap(err, "upsert object data")
 	}
 
+	if err := s.updateVectorsLSM(nextObj, status); err != nil {
+		return nil, status, errors.Wrap(err, "update vector buckets")
+	}
+
 	if err := s.updateInvertedIndexLSM(nextObj, status, previous); err != nil {
 		return nil, status, errors.Wrap(err, "udpate inverted indices")
 	}
@@ -112,7 +120,8 @@ func (s *Shard) mutableMergeObjectLSM(merge objects.MergeDocument,
 		return out, err
 	}
 
-	nextObj, previousObj, err := s.mergeObjectData(previous, merge)
+	// the vectors are neither changed nor written, so they aren't loaded
+	nextObj, previousObj, err := s.mergeObjectData(previous, merge, false)
 	if err != nil {
 		return out, errors.Wrap(err, "merge object data")
 	}
@@ -127,7 +136,9 @@ func (s *Shard) mutableMergeObjectLSM(merge objects.MergeDocument,
 	out.status = status
 
 	nextObj.SetDocID(status.docID) // is not changed
-	nextBytes, err := nextObj.MarshalBinary()
+	// the vectors are not changed either, so the vector buckets stay as they
+	// are
+	nextBytes, err := objectBytesLSM(nextObj)
 	if err != nil {
 		return out, errors.Wrapf(err, "marshal object %s to binary", nextObj.ID())
 	}
@@ -148,8 +159,11 @@ type mutableMergeResult struct {
 	status   objectInsertStatus
 }
 
-func (s *Shard) mergeObjectData(previous []byte,
-	merge objects.MergeDocument) (*storobj.Object, *storobj.Object, error) {
+// mergeObjectData applies the merge to the previous object. If loadVectors is
+// set, the vectors of the previous object are read from the vector buckets,
+// so that they are carried over to the merged object.
+func (s *Shard) mergeObjectData(previous []byte, merge objects.MergeDocument,
+	loadVectors bool) (*storobj.Object, *storobj.Object, error) {
 	var previousObj *storobj.Object
 	if len(previous) == 0 {
 		// DocID must be overwriten after status check, simply set to initial
@@ -163,6 +177,12 @@ func (s *Shard) mergeObjectData(previous []byte,
 			return nil, nil, errors.Wrap(err, "unmarshal previous")
 		}
 
+		if loadVectors {
+			if err := s.loadVectors(p); err != nil {
+				return nil, nil, errors.Wrap(err, "load previous vectors")
+			}
+		}
+
 		previousObj = p
 	}
 
@@ -199,6 +219,13 @@ func mergeProps(previous *storobj.Object,
 		next.Vector = merge.Vector
 	}
 
+	for name, vector := range merge.Vectors {
+		if next.Vectors == nil {
+			next.Vectors = map[string][]float32{}
+		}
+		next.Vectors[name] = vector
+	}
+
 	next.SetProperties(properties)
 
 	return next
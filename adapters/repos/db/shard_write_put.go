@@ -41,6 +41,10 @@ func (s *Shard) putObject(ctx context.Context, object *storobj.Object) error {
 		return errors.Wrap(err, "update vector index")
 	}
 
+	if err := s.updateNamedVectorIndexes(object.Vectors, status); err != nil {
+		return errors.Wrap(err, "update named vector indexes")
+	}
+
 	if err := s.updatePropertySpecificIndices(object, status); err != nil {
 		return errors.Wrap(err, "update property-specific indices")
 	}
@@ -49,7 +53,7 @@ func (s *Shard) putObject(ctx context.Context, object *storobj.Object) error {
 		return errors.Wrap(err, "flush all buffered WALs")
 	}
 
-	if err := s.vectorIndex.Flush(); err != nil {
+	if err := s.flushVectorIndexes(); err != nil {
 		return errors.Wrap(err, "flush all vector index buffered WALs")
 	}
 
@@ -58,19 +62,49 @@ func (s *Shard) putObject(ctx context.Context, object *storobj.Object) error {
 
 func (s *Shard) updateVectorIndex(vector []float32,
 	status objectInsertStatus) error {
+	vectorIndex := s.vectorIndexOrQueue("")
+
 	if status.docIDChanged {
-		if err := s.vectorIndex.Delete(status.oldDocID); err != nil {
+		if err := vectorIndex.Delete(status.oldDocID); err != nil {
 			return errors.Wrapf(err, "delete doc id %d from vector index", status.oldDocID)
 		}
 	}
 
-	if err := s.vectorIndex.Add(status.docID, vector); err != nil {
+	if err := vectorIndex.Add(status.docID, vector); err != nil {
 		return errors.Wrapf(err, "insert doc id %d to vector index", status.docID)
 	}
 
 	return nil
 }
 
+// updateNamedVectorIndexes is the equivalent of updateVectorIndex for the
+// named vectors. An object is not required to contain all named vectors, but
+// a changed doc id is removed from every index regardless.
+func (s *Shard) updateNamedVectorIndexes(vectors map[string][]float32,
+	status objectInsertStatus) error {
+	for name := range s.namedVectorIndexes {
+		vectorIndex := s.vectorIndexOrQueue(name)
+		if status.docIDChanged {
+			if err := vectorIndex.Delete(status.oldDocID); err != nil {
+				return errors.Wrapf(err, "delete doc id %d from index of vector %q",
+					status.oldDocID, name)
+			}
+		}
+
+		vector := vectors[name]
+		if len(vector) == 0 {
+			continue
+		}
+
+		if err := vectorIndex.Add(status.docID, vector); err != nil {
+			return errors.Wrapf(err, "insert doc id %d to index of vector %q",
+				status.docID, name)
+		}
+	}
+
+	return nil
+}
+
 func (s *Shard) putObjectLSM(object *storobj.Object,
 	idBytes []byte, skipInverted bool) (objectInsertStatus, error) {
 	before := time.Now()
@@ -88,7 +122,7 @@ func (s *Shard) putObjectLSM(object *storobj.Object,
 	}
 
 	object.SetDocID(status.docID)
-	data, err := object.MarshalBinary()
+	data, err := objectBytesLSM(object)
 	if err != nil {
 		return status, errors.Wrapf(err, "marshal object %s to binary", object.ID())
 	}
@@ -99,6 +133,10 @@ func (s *Shard) putObjectLSM(object *storobj.Object,
 	}
 	s.metrics.PutObjectUpsertObject(before)
 
+	if err := s.updateVectorsLSM(object, status); err != nil {
+		return status, errors.Wrap(err, "update vector buckets")
+	}
+
 	if !skipInverted {
 		before = time.Now()
 		if err := s.updateInvertedIndexLSM(object, status, previous); err != nil {
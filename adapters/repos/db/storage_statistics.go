@@ -0,0 +1,315 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+// diskUsageProvider is implemented by vector indexes which persist their
+// graph in files of their own, such as hnsw. Indexes which store their data
+// in the buckets of the shard, such as flat, are covered by the buckets.
+type diskUsageProvider interface {
+	DiskUsage() (hnsw.DiskUsage, error)
+}
+
+// storageStatistics lists the memory and disk usage of the shards of the
+// given indexes, including the shards held by other nodes, and aggregates it
+// per class and per node
+func (d *DB) storageStatistics(ctx context.Context,
+	indexes []*Index) (*models.StorageStatistics, error) {
+	out := &models.StorageStatistics{
+		Classes: make([]*models.ClassStorageStatistics, 0, len(indexes)),
+		Nodes:   []*models.NodeStorageStatistics{},
+		Totals:  &models.StorageTotals{},
+	}
+
+	nodes := map[string]*models.NodeStorageStatistics{}
+	for _, index := range indexes {
+		class, err := index.storageStatistics(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "class %s", index.Config.ClassName)
+		}
+
+		for _, shard := range class.Shards {
+			node, ok := nodes[shard.Node]
+			if !ok {
+				node = &models.NodeStorageStatistics{
+					Name:   shard.Node,
+					Totals: &models.StorageTotals{},
+				}
+				nodes[shard.Node] = node
+				out.Nodes = append(out.Nodes, node)
+			}
+
+			node.Shards++
+			addStorageTotals(node.Totals, shard.Totals)
+		}
+
+		addStorageTotals(out.Totals, class.Totals)
+		out.Classes = append(out.Classes, class)
+	}
+
+	sort.Slice(out.Nodes, func(a, b int) bool {
+		return out.Nodes[a].Name < out.Nodes[b].Name
+	})
+
+	return out, nil
+}
+
+func (i *Index) storageStatistics(
+	ctx context.Context) (*models.ClassStorageStatistics, error) {
+	shardState := i.getSchema.ShardingState(i.Config.ClassName.String())
+	shardNames := shardState.AllPhysicalShards()
+
+	out := &models.ClassStorageStatistics{
+		Class:  i.Config.ClassName.String(),
+		Shards: make([]*models.ShardStorageStatistics, len(shardNames)),
+		Totals: &models.StorageTotals{},
+	}
+
+	for j, shardName := range shardNames {
+		var err error
+		var stats *models.ShardStorageStatistics
+		if shardState.IsShardLocal(shardName) {
+			shard, ok := i.Shards[shardName]
+			if !ok {
+				return nil, errors.Errorf("shard %s is not loaded", shardName)
+			}
+			stats, err = shard.storageStatistics()
+		} else {
+			stats, err = i.remote.ShardStorageStatistics(ctx, shardName)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "shard %s", shardName)
+		}
+
+		stats.Node = shardState.Physical[shardName].BelongsToNode
+		addStorageTotals(out.Totals, stats.Totals)
+		out.Shards[j] = stats
+	}
+
+	return out, nil
+}
+
+func (i *Index) IncomingShardStorageStatistics(ctx context.Context,
+	shardName string) (*models.ShardStorageStatistics, error) {
+	shard, ok := i.Shards[shardName]
+	if !ok {
+		return nil, errors.Errorf("shard %q does not exist locally", shardName)
+	}
+
+	return shard.storageStatistics()
+}
+
+func (s *Shard) storageStatistics() (*models.ShardStorageStatistics, error) {
+	buckets, err := s.store.Stats()
+	if err != nil {
+		return nil, errors.Wrap(err, "bucket stats")
+	}
+
+	count, err := s.objectCount()
+	if err != nil {
+		return nil, errors.Wrap(err, "count objects")
+	}
+
+	out := &models.ShardStorageStatistics{
+		Name:          s.name,
+		ObjectCount:   count,
+		Buckets:       make([]*models.BucketStorageStatistics, 0, len(buckets)),
+		VectorIndexes: []*models.VectorIndexStorageStatistics{},
+		GeoIndexes:    []*models.GeoIndexStorageStatistics{},
+		Totals:        &models.StorageTotals{ObjectCount: count},
+	}
+
+	bucketNames := make([]string, 0, len(buckets))
+	for name := range buckets {
+		bucketNames = append(bucketNames, name)
+	}
+	sort.Strings(bucketNames)
+
+	for _, name := range bucketNames {
+		bucket := bucketStorageStatistics(name, buckets[name])
+		addBucketToStorageTotals(out.Totals, bucket)
+		out.Buckets = append(out.Buckets, bucket)
+	}
+
+	vectorNames := make([]string, 0, len(s.namedVectorIndexes))
+	for name := range s.namedVectorIndexes {
+		vectorNames = append(vectorNames, name)
+	}
+	sort.Strings(vectorNames)
+
+	// the class-level vector is always listed first, followed by the named
+	// vectors in alphabetical order
+	indexes := append([]VectorIndex{s.vectorIndex}, make([]VectorIndex,
+		len(vectorNames))...)
+	for j, name := range vectorNames {
+		indexes[j+1] = s.namedVectorIndexes[name]
+	}
+
+	for j, index := range indexes {
+		provider, ok := index.(diskUsageProvider)
+		if !ok {
+			continue
+		}
+
+		usage, err := provider.DiskUsage()
+		if err != nil {
+			return nil, errors.Wrap(err, "vector index disk usage")
+		}
+
+		targetVector := ""
+		if j > 0 {
+			targetVector = vectorNames[j-1]
+		}
+
+		vector := &models.VectorIndexStorageStatistics{
+			TargetVector:       targetVector,
+			CommitLogCount:     int64(usage.CommitLogs),
+			CommitLogBytes:     usage.CommitLogBytes,
+			SnapshotCount:      int64(usage.Snapshots),
+			SnapshotBytes:      usage.SnapshotBytes,
+			QuantizedCodeBytes: usage.QuantizedCodeBytes,
+		}
+		addDiskUsageToStorageTotals(out.Totals, usage)
+		out.VectorIndexes = append(out.VectorIndexes, vector)
+	}
+
+	if err := s.geoIndexStorageStatistics(out); err != nil {
+		return nil, err
+	}
+
+	for name, queue := range s.vectorIndexQueues {
+		size, err := queue.Size()
+		if err != nil {
+			return nil, errors.Wrapf(err, "queue of vector %q", name)
+		}
+
+		out.Totals.QueueBytes += size
+		out.Totals.DiskBytes += size
+	}
+
+	return out, nil
+}
+
+func (s *Shard) geoIndexStorageStatistics(
+	out *models.ShardStorageStatistics) error {
+	props := make([]string, 0, len(s.propertyIndices))
+	for prop, index := range s.propertyIndices {
+		if index.GeoIndex != nil {
+			props = append(props, prop)
+		}
+	}
+	sort.Strings(props)
+
+	for _, prop := range props {
+		usage, err := s.propertyIndices[prop].GeoIndex.DiskUsage()
+		if err != nil {
+			return errors.Wrapf(err, "geo index of property %q disk usage", prop)
+		}
+
+		out.GeoIndexes = append(out.GeoIndexes, &models.GeoIndexStorageStatistics{
+			Property:       prop,
+			CommitLogCount: int64(usage.CommitLogs),
+			CommitLogBytes: usage.CommitLogBytes,
+			SnapshotCount:  int64(usage.Snapshots),
+			SnapshotBytes:  usage.SnapshotBytes,
+		})
+		addDiskUsageToStorageTotals(out.Totals, usage)
+	}
+
+	return nil
+}
+
+// objectCount is maintained by the objects bucket, it doesn't read the
+// objects
+func (s *Shard) objectCount() (int64, error) {
+	b := s.store.Bucket(helpers.ObjectsBucketLSM)
+	if b == nil {
+		return 0, errors.Errorf("objects bucket is nil")
+	}
+
+	return b.Count()
+}
+
+func bucketStorageStatistics(name string,
+	stats lsmkv.BucketStats) *models.BucketStorageStatistics {
+	segments := make([]*models.SegmentStorageStatistics, len(stats.Segments))
+	for i, segment := range stats.Segments {
+		segments[i] = &models.SegmentStorageStatistics{
+			Level:     int64(segment.Level),
+			SizeBytes: segment.Size,
+		}
+	}
+
+	return &models.BucketStorageStatistics{
+		Name:                   name,
+		Strategy:               stats.Strategy,
+		SegmentCount:           int64(len(stats.Segments)),
+		SegmentBytes:           stats.SegmentBytes,
+		Segments:               segments,
+		MemtableBytes:          stats.MemtableBytes,
+		WalBytes:               stats.WALBytes,
+		BloomFilterBytes:       stats.BloomFilterBytes,
+		PendingCompactions:     int64(stats.CompactionDebt.PendingCompactions),
+		PendingCompactionBytes: stats.CompactionDebt.PendingBytes,
+	}
+}
+
+func addBucketToStorageTotals(totals *models.StorageTotals,
+	bucket *models.BucketStorageStatistics) {
+	totals.SegmentCount += bucket.SegmentCount
+	totals.SegmentBytes += bucket.SegmentBytes
+	totals.MemtableBytes += bucket.MemtableBytes
+	totals.WalBytes += bucket.WalBytes
+	totals.BloomFilterBytes += bucket.BloomFilterBytes
+	totals.PendingCompactions += bucket.PendingCompactions
+	totals.PendingCompactionBytes += bucket.PendingCompactionBytes
+	totals.DiskBytes += bucket.SegmentBytes + bucket.WalBytes
+}
+
+func addDiskUsageToStorageTotals(totals *models.StorageTotals,
+	usage hnsw.DiskUsage) {
+	totals.CommitLogBytes += usage.CommitLogBytes
+	totals.SnapshotBytes += usage.SnapshotBytes
+	totals.QuantizedCodeBytes += usage.QuantizedCodeBytes
+	totals.DiskBytes += usage.CommitLogBytes + usage.SnapshotBytes +
+		usage.QuantizedCodeBytes
+}
+
+func addStorageTotals(dst, src *models.StorageTotals) {
+	if src == nil {
+		return
+	}
+
+	dst.ObjectCount += src.ObjectCount
+	dst.SegmentCount += src.SegmentCount
+	dst.SegmentBytes += src.SegmentBytes
+	dst.MemtableBytes += src.MemtableBytes
+	dst.WalBytes += src.WalBytes
+	dst.BloomFilterBytes += src.BloomFilterBytes
+	dst.PendingCompactions += src.PendingCompactions
+	dst.PendingCompactionBytes += src.PendingCompactionBytes
+	dst.CommitLogBytes += src.CommitLogBytes
+	dst.SnapshotBytes += src.SnapshotBytes
+	dst.QuantizedCodeBytes += src.QuantizedCodeBytes
+	dst.QueueBytes += src.QueueBytes
+	dst.DiskBytes += src.DiskBytes
+}
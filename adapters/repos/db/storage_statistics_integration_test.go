@@ -0,0 +1,173 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageStatistics(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	logger, _ := test.NewNullLogger()
+	class := &models.Class{
+		Class:               "StorageStatisticsClass",
+		VectorIndexConfig:   hnsw.NewDefaultUserConfig(),
+		InvertedIndexConfig: invertedConfig(),
+		VectorConfig: map[string]models.VectorConfig{
+			"title": {
+				Vectorizer:        "none",
+				VectorIndexType:   "flat",
+				VectorIndexConfig: flat.UserConfig{Distance: "l2-squared"},
+			},
+		},
+		Properties: []*models.Property{{
+			Name:     "stringProp",
+			DataType: []string{string(schema.DataTypeString)},
+		}, {
+			Name:     "location",
+			DataType: []string{string(schema.DataTypeGeoCoordinates)},
+		}},
+	}
+	schemaGetter := &fakeSchemaGetter{shardState: multiShardState()}
+	repo := New(logger, Config{
+		RootPath:            dirName,
+		QueryMaximumResults: 10000,
+	}, &fakeRemoteClient{}, &fakeNodeResolver{})
+	repo.SetSchemaGetter(schemaGetter)
+	require.Nil(t, repo.WaitForStartup(testCtx()))
+	defer repo.Shutdown(context.Background())
+	migrator := NewMigrator(repo, logger)
+
+	t.Run("creating the class", func(t *testing.T) {
+		require.Nil(t,
+			migrator.AddClass(context.Background(), class, schemaGetter.shardState))
+
+		// update schema getter so it's in sync with class
+		schemaGetter.schema = schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{class},
+			},
+		}
+	})
+
+	const size = 30
+	t.Run("importing objects", func(t *testing.T) {
+		for i := 0; i < size; i++ {
+			vector := []float32{rand.Float32(), rand.Float32(), rand.Float32()}
+			err := repo.PutObject(context.Background(), &models.Object{
+				ID:    strfmt.UUID(uuid.New().String()),
+				Class: class.Class,
+				Properties: map[string]interface{}{
+					"stringProp": "value",
+					"location": &models.GeoCoordinates{
+						Latitude:  ptFloat32(rand.Float32() * 90),
+						Longitude: ptFloat32(rand.Float32() * 180),
+					},
+				},
+				Vectors: models.Vectors{"title": vector},
+			}, vector)
+			require.Nil(t, err)
+		}
+	})
+
+	t.Run("statistics of the class", func(t *testing.T) {
+		stats, err := migrator.GetStorageStatistics(context.Background(),
+			class.Class)
+		require.Nil(t, err)
+
+		require.Len(t, stats.Classes, 1)
+		classStats := stats.Classes[0]
+		assert.Equal(t, class.Class, classStats.Class)
+		require.Len(t, classStats.Shards, 3)
+
+		var objects int64
+		for _, shard := range classStats.Shards {
+			assert.Equal(t, "node1", shard.Node)
+			objects += shard.ObjectCount
+
+			names := make([]string, len(shard.Buckets))
+			for i, bucket := range shard.Buckets {
+				names[i] = bucket.Name
+			}
+			assert.Contains(t, names, helpers.ObjectsBucketLSM)
+			assert.IsIncreasing(t, names)
+
+			// only the hnsw index has files of its own, the flat index of the
+			// named vector is stored in buckets
+			require.Len(t, shard.VectorIndexes, 1)
+			assert.Equal(t, "", shard.VectorIndexes[0].TargetVector)
+			assert.True(t, shard.VectorIndexes[0].CommitLogCount > 0)
+
+			require.Len(t, shard.GeoIndexes, 1)
+			assert.Equal(t, "location", shard.GeoIndexes[0].Property)
+			assert.True(t, shard.GeoIndexes[0].CommitLogCount > 0)
+			assert.Equal(t, shard.VectorIndexes[0].CommitLogBytes+
+				shard.GeoIndexes[0].CommitLogBytes, shard.Totals.CommitLogBytes)
+
+			assert.Equal(t, shard.ObjectCount, shard.Totals.ObjectCount)
+			assert.True(t, shard.Totals.MemtableBytes > 0)
+			assert.True(t, shard.Totals.WalBytes > 0)
+			assert.Equal(t, shard.Totals.SegmentBytes+shard.Totals.WalBytes+
+				shard.Totals.CommitLogBytes+shard.Totals.SnapshotBytes+
+				shard.Totals.QuantizedCodeBytes+shard.Totals.QueueBytes,
+				shard.Totals.DiskBytes)
+		}
+		assert.Equal(t, int64(size), objects)
+		assert.Equal(t, int64(size), classStats.Totals.ObjectCount)
+		assert.Equal(t, classStats.Totals, stats.Totals)
+
+		require.Len(t, stats.Nodes, 1)
+		assert.Equal(t, "node1", stats.Nodes[0].Name)
+		assert.Equal(t, int64(3), stats.Nodes[0].Shards)
+		assert.Equal(t, stats.Totals, stats.Nodes[0].Totals)
+	})
+
+	t.Run("statistics of all classes", func(t *testing.T) {
+		stats, err := migrator.GetStorageStatistics(context.Background(), "")
+		require.Nil(t, err)
+
+		require.Len(t, stats.Classes, 1)
+		assert.Equal(t, class.Class, stats.Classes[0].Class)
+		assert.Equal(t, int64(size), stats.Totals.ObjectCount)
+	})
+
+	t.Run("statistics of a class that does not exist", func(t *testing.T) {
+		_, err := migrator.GetStorageStatistics(context.Background(), "Unknown")
+		assert.NotNil(t, err)
+	})
+}
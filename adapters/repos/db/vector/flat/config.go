@@ -0,0 +1,151 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package flat
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/sirupsen/logrus"
+)
+
+// Config for a new flat index, this contains information that is derived
+// internally, e.g. by the shard. All User-settable config is specified in
+// UserConfig
+type Config struct {
+	ID               string
+	Store            *lsmkv.Store
+	Logger           logrus.FieldLogger
+	DistanceProvider distancer.Provider
+
+	// BucketName is the name of the bucket the vectors are read from. It
+	// defaults to helpers.VectorsBucketLSM if not set. The caller keeps the
+	// bucket up to date, like a shard does with its vector buckets.
+	BucketName string
+}
+
+func (c Config) Validate() error {
+	if c.ID == "" {
+		return errors.Errorf("id cannot be empty")
+	}
+
+	if c.Store == nil {
+		return errors.Errorf("store cannot be nil")
+	}
+
+	if c.DistanceProvider == nil {
+		return errors.Errorf("distancerProvider cannot be nil")
+	}
+
+	return nil
+}
+
+const (
+	// the flat index supports the same distance metrics as hnsw, as both use
+	// the same distancer providers
+	DefaultDistanceMetric = hnsw.DistanceCosine
+)
+
+// UserConfig bundles all values settable by a user in the per-class settings
+type UserConfig struct {
+	Distance string `json:"distance"`
+}
+
+// IndexType returns the type of the underlying vector index, thus making sure
+// the schema.VectorIndexConfig interface is implemented
+func (u UserConfig) IndexType() string {
+	return "flat"
+}
+
+// DistanceName returns the distance metric configured for this index, thus
+// making sure the schema.VectorIndexConfig interface is implemented
+func (u UserConfig) DistanceName() string {
+	return u.Distance
+}
+
+// SetDefaults in the user-specifyable part of the config
+func (u *UserConfig) SetDefaults() {
+	u.Distance = DefaultDistanceMetric
+}
+
+// ParseUserConfig from an unknown input value, as this is not further
+// specified in the API to allow of exchanging the index type
+func ParseUserConfig(input interface{}) (schema.VectorIndexConfig, error) {
+	uc := UserConfig{}
+	uc.SetDefaults()
+
+	if input == nil {
+		return uc, nil
+	}
+
+	asMap, ok := input.(map[string]interface{})
+	if !ok || asMap == nil {
+		return uc, fmt.Errorf("input must be a non-nil map")
+	}
+
+	if value, ok := asMap["distance"]; ok {
+		asString, ok := value.(string)
+		if !ok {
+			return uc, errors.Errorf("distance must be a string, got %T", value)
+		}
+		uc.Distance = asString
+	}
+
+	return uc, uc.validate()
+}
+
+func (u UserConfig) validate() error {
+	switch u.Distance {
+	case hnsw.DistanceCosine, hnsw.DistanceDot, hnsw.DistanceL2Squared,
+		hnsw.DistanceManhattan, hnsw.DistanceHamming:
+		return nil
+	default:
+		return errors.Errorf("distance %q is not supported, must be one of "+
+			"[%s, %s, %s, %s, %s]", u.Distance, hnsw.DistanceCosine,
+			hnsw.DistanceDot, hnsw.DistanceL2Squared, hnsw.DistanceManhattan,
+			hnsw.DistanceHamming)
+	}
+}
+
+func NewDefaultUserConfig() UserConfig {
+	uc := UserConfig{}
+	uc.SetDefaults()
+	return uc
+}
+
+// ValidateUserConfigUpdate makes sure that only mutable fields are changed.
+// The flat index currently has no mutable settings.
+func ValidateUserConfigUpdate(initial, updated schema.VectorIndexConfig) error {
+	initialParsed, ok := initial.(UserConfig)
+	if !ok {
+		return errors.Errorf("initial is not UserConfig, but %T", initial)
+	}
+
+	updatedParsed, ok := updated.(UserConfig)
+	if !ok {
+		return errors.Errorf("updated is not UserConfig, but %T", updated)
+	}
+
+	// vectors are normalized on import for some metrics, so changing the
+	// metric would require a re-import
+	if initialParsed.Distance != updatedParsed.Distance {
+		return errors.Errorf("distance is immutable: "+
+			"attempted change from \"%s\" to \"%s\"",
+			initialParsed.Distance, updatedParsed.Distance)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package flat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UserConfig(t *testing.T) {
+	type test struct {
+		name        string
+		input       interface{}
+		expected    UserConfig
+		expectedErr bool
+	}
+
+	tests := []test{
+		{
+			name:     "nothing specified, all defaults",
+			input:    nil,
+			expected: UserConfig{Distance: DefaultDistanceMetric},
+		},
+		{
+			name:     "empty map, all defaults",
+			input:    map[string]interface{}{},
+			expected: UserConfig{Distance: DefaultDistanceMetric},
+		},
+		{
+			name: "with a supported distance",
+			input: map[string]interface{}{
+				"distance": "l2-squared",
+			},
+			expected: UserConfig{Distance: "l2-squared"},
+		},
+		{
+			name: "with an unsupported distance",
+			input: map[string]interface{}{
+				"distance": "euclidean-but-fancy",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "with a distance of the wrong type",
+			input: map[string]interface{}{
+				"distance": 7.0,
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "not a map",
+			input:       "flat",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := ParseUserConfig(test.input)
+			if test.expectedErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, cfg)
+			assert.Equal(t, "flat", cfg.IndexType())
+		})
+	}
+}
+
+func Test_ValidateUserConfigUpdate(t *testing.T) {
+	t.Run("unchanged distance", func(t *testing.T) {
+		err := ValidateUserConfigUpdate(UserConfig{Distance: "dot"},
+			UserConfig{Distance: "dot"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("changing the distance", func(t *testing.T) {
+		err := ValidateUserConfigUpdate(UserConfig{Distance: "dot"},
+			UserConfig{Distance: "cosine"})
+		assert.EqualError(t, err, "distance is immutable: "+
+			"attempted change from \"dot\" to \"cosine\"")
+	})
+}
@@ -0,0 +1,264 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package flat
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
+	"github.com/semi-technologies/weaviate/entities/schema"
+	"github.com/sirupsen/logrus"
+)
+
+// allowListLookupCutoff is the size up to which the vectors of an allow list
+// are looked up individually. Larger allow lists are served by scanning the
+// entire bucket and skipping the ids which are not allowed.
+const allowListLookupCutoff = 10000
+
+// flat is an exact (brute-force) vector index. It does not build any
+// additional structure, instead each search compares the query against every
+// (allowed) vector. This is slower than hnsw on larger data sets, but there is
+// no loss in recall and no memory overhead as the vectors are read from an
+// lsmkv bucket. The bucket is kept up to date by the caller, the index never
+// writes to it.
+type flat struct {
+	id                string
+	bucket            *lsmkv.Bucket
+	distancerProvider distancer.Provider
+	logger            logrus.FieldLogger
+}
+
+// New creates a flat index, the vectors are read from a bucket of the
+// provided store which is created if it does not exist yet
+func New(cfg Config, uc UserConfig) (*flat, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid config")
+	}
+
+	if cfg.Logger == nil {
+		logger := logrus.New()
+		logger.Out = ioutil.Discard
+		cfg.Logger = logger
+	}
+
+	if cfg.BucketName == "" {
+		cfg.BucketName = helpers.VectorsBucketLSM
+	}
+
+	err := cfg.Store.CreateOrLoadBucket(context.Background(),
+		cfg.BucketName, lsmkv.WithStrategy(lsmkv.StrategyReplace))
+	if err != nil {
+		return nil, errors.Wrap(err, "create vectors bucket")
+	}
+
+	return &flat{
+		id:                cfg.ID,
+		bucket:            cfg.Store.Bucket(cfg.BucketName),
+		distancerProvider: cfg.DistanceProvider,
+		logger:            cfg.Logger,
+	}, nil
+}
+
+// Add only validates the vector, it has been stored in the bucket by the
+// caller already
+func (f *flat) Add(id uint64, vector []float32) error {
+	if len(vector) == 0 {
+		return errors.Errorf("insert called with nil-vector")
+	}
+
+	return nil
+}
+
+// Delete is a no-op, the caller removes the vector from the bucket
+func (f *flat) Delete(id uint64) error {
+	return nil
+}
+
+func (f *flat) SearchByVector(vector []float32, k int,
+	allow helpers.AllowList) ([]uint64, []float32, error) {
+	ids, dists, err := f.SearchByVectorBatch([][]float32{vector}, k, allow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ids[0], dists[0], nil
+}
+
+// SearchByVectorBatch compares every query vector against the (allowed)
+// vectors in a single pass over the bucket, so each vector is only read once
+// regardless of the number of queries
+func (f *flat) SearchByVectorBatch(vectors [][]float32, k int,
+	allow helpers.AllowList) ([][]uint64, [][]float32, error) {
+	ids := make([][]uint64, len(vectors))
+	dists := make([][]float32, len(vectors))
+	if k <= 0 || len(vectors) == 0 {
+		return ids, dists, nil
+	}
+
+	distancers := make([]distancer.Distancer, len(vectors))
+	results := make([]*priorityqueue.Queue, len(vectors))
+	for i, vector := range vectors {
+		if f.normalize() {
+			vector = distancer.Normalize(vector)
+		}
+
+		distancers[i] = f.distancerProvider.New(vector)
+		results[i] = priorityqueue.NewMax(k)
+	}
+
+	// the vector passed to insert is only valid for the duration of the call,
+	// the scans reuse its memory for the next vector. The bucket holds the
+	// vectors as they were imported, so they are normalized as they are read.
+	var normalized []float32
+	insert := func(id uint64, vec []float32) error {
+		if f.normalize() {
+			normalized = normalizeInto(normalized, vec)
+			vec = normalized
+		}
+
+		for i, dist := range distancers {
+			d, ok, err := dist.Distance(vec)
+			if err != nil {
+				return errors.Wrapf(err, "calculate distance of docID %d", id)
+			}
+
+			if !ok {
+				continue
+			}
+
+			res := results[i]
+			if res.Len() < k || d < res.Top().Dist {
+				res.Insert(id, d)
+				if res.Len() > k {
+					res.Pop()
+				}
+			}
+		}
+
+		return nil
+	}
+
+	var err error
+	if allow != nil && len(allow) <= allowListLookupCutoff {
+		err = f.searchAllowList(allow, insert)
+	} else {
+		err = f.searchAll(allow, insert)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, res := range results {
+		ids[i] = make([]uint64, res.Len())
+		dists[i] = make([]float32, res.Len())
+		for j := len(ids[i]) - 1; j >= 0; j-- {
+			item := res.Pop()
+			ids[i][j] = item.ID
+			dists[i][j] = item.Dist
+		}
+	}
+
+	return ids, dists, nil
+}
+
+func (f *flat) searchAllowList(allow helpers.AllowList,
+	insert func(id uint64, vec []float32) error) error {
+	var buf []float32
+	for id := range allow {
+		v, err := f.bucket.Get(helpers.VectorKey(id))
+		if err != nil {
+			return errors.Wrapf(err, "get vector of docID %d", id)
+		}
+
+		if v == nil {
+			// the allow list may contain objects without a vector
+			continue
+		}
+
+		buf = helpers.VectorFromBytesInto(buf, v)
+		if err := insert(id, buf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *flat) searchAll(allow helpers.AllowList,
+	insert func(id uint64, vec []float32) error) error {
+	c := f.bucket.Cursor()
+	defer c.Close()
+
+	var buf []float32
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		id := helpers.DocIDFromVectorKey(k)
+		if allow != nil && !allow.Contains(id) {
+			continue
+		}
+
+		buf = helpers.VectorFromBytesInto(buf, v)
+		if err := insert(id, buf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *flat) UpdateUserConfig(updated schema.VectorIndexConfig) error {
+	if _, ok := updated.(UserConfig); !ok {
+		return errors.Errorf("config is not UserConfig, but %T", updated)
+	}
+
+	// there are no mutable settings at the moment
+	return nil
+}
+
+func (f *flat) Drop() error {
+	// the vectors bucket is part of the shard's store, it is removed together
+	// with the store
+	return nil
+}
+
+func (f *flat) Flush() error {
+	return f.bucket.WriteWAL()
+}
+
+func (f *flat) normalize() bool {
+	return f.distancerProvider.Type() == "cosine-dot"
+}
+
+// normalizeInto is distancer.Normalize without an allocation per vector
+func normalizeInto(dst, v []float32) []float32 {
+	if cap(dst) < len(v) {
+		dst = make([]float32, len(v))
+	}
+	dst = dst[:len(v)]
+
+	var norm float32
+	for i := range v {
+		norm += v[i] * v[i]
+	}
+
+	norm = float32(math.Sqrt(float64(norm)))
+	for i := range v {
+		dst[i] = v[i] / norm
+	}
+
+	return dst
+}
@@ -0,0 +1,293 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package flat
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIndex(t *testing.T, provider distancer.Provider) (*flat, *lsmkv.Store) {
+	logger, _ := test.NewNullLogger()
+	store, err := lsmkv.New(t.TempDir(), logger)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		store.Shutdown(context.Background())
+	})
+
+	index, err := New(Config{
+		ID:               "flat-test",
+		Store:            store,
+		Logger:           logger,
+		DistanceProvider: provider,
+	}, NewDefaultUserConfig())
+	require.Nil(t, err)
+
+	return index, store
+}
+
+// putVectors stores the vectors in the bucket of the index, as a shard does
+// for the vectors of its objects
+func putVectors(t *testing.T, index *flat, vectors [][]float32) {
+	for i, vec := range vectors {
+		require.Nil(t, index.bucket.Put(helpers.VectorKey(uint64(i)),
+			helpers.VectorToBytes(vec)))
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+}
+
+func randomVectors(r *rand.Rand, count, dims int) [][]float32 {
+	out := make([][]float32, count)
+	for i := range out {
+		vec := make([]float32, dims)
+		for j := range vec {
+			vec[j] = r.Float32()*2 - 1
+		}
+		out[i] = vec
+	}
+	return out
+}
+
+// exactSearch returns the ids of the k closest vectors for which allowed
+// returns true
+func exactSearch(provider distancer.Provider, vectors [][]float32,
+	query []float32, k int, allowed func(id uint64) bool) []uint64 {
+	var ids []uint64
+	dists := map[uint64]float32{}
+	for i, vec := range vectors {
+		if !allowed(uint64(i)) {
+			continue
+		}
+		ids = append(ids, uint64(i))
+		dists[uint64(i)], _, _ = provider.SingleDist(query, vec)
+	}
+
+	sort.Slice(ids, func(a, b int) bool {
+		return dists[ids[a]] < dists[ids[b]]
+	})
+
+	if len(ids) > k {
+		ids = ids[:k]
+	}
+	return ids
+}
+
+func TestFlat_Search(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	vectors := randomVectors(r, 500, 16)
+	queries := randomVectors(r, 10, 16)
+	provider := distancer.NewL2SquaredProvider()
+	index, _ := testIndex(t, provider)
+	putVectors(t, index, vectors)
+
+	all := func(id uint64) bool { return true }
+
+	t.Run("without an allow list the results are exact", func(t *testing.T) {
+		for _, query := range queries {
+			res, dists, err := index.SearchByVector(query, 10, nil)
+			require.Nil(t, err)
+			assert.Equal(t, exactSearch(provider, vectors, query, 10, all), res)
+			assert.True(t, sort.SliceIsSorted(dists, func(a, b int) bool {
+				return dists[a] < dists[b]
+			}))
+		}
+	})
+
+	t.Run("with an allow list", func(t *testing.T) {
+		allowList := helpers.AllowList{}
+		for i := uint64(0); i < 500; i += 3 {
+			allowList.Insert(i)
+		}
+		// an allowed id without a vector must be ignored
+		allowList.Insert(10000)
+
+		for _, query := range queries {
+			res, _, err := index.SearchByVector(query, 10, allowList)
+			require.Nil(t, err)
+			assert.Equal(t, exactSearch(provider, vectors, query, 10,
+				allowList.Contains), res)
+		}
+	})
+
+	t.Run("with a large allow list the bucket is scanned", func(t *testing.T) {
+		allowList := helpers.AllowList{}
+		for i := uint64(0); i < allowListLookupCutoff+1; i += 2 {
+			allowList.Insert(i)
+		}
+
+		res, _, err := index.SearchByVector(queries[0], 10, allowList)
+		require.Nil(t, err)
+		assert.Equal(t, exactSearch(provider, vectors, queries[0], 10,
+			allowList.Contains), res)
+	})
+
+	t.Run("k larger than the number of vectors", func(t *testing.T) {
+		allowList := helpers.AllowList{}
+		allowList.Insert(3)
+		allowList.Insert(4)
+
+		res, _, err := index.SearchByVector(queries[0], 10, allowList)
+		require.Nil(t, err)
+		assert.ElementsMatch(t, []uint64{3, 4}, res)
+	})
+
+	t.Run("a batch of queries is answered in a single scan", func(t *testing.T) {
+		allowList := helpers.AllowList{}
+		for i := uint64(0); i < 500; i += 3 {
+			allowList.Insert(i)
+		}
+
+		for _, allow := range []helpers.AllowList{nil, allowList} {
+			ids, dists, err := index.SearchByVectorBatch(queries, 10, allow)
+			require.Nil(t, err)
+			require.Len(t, ids, len(queries))
+			require.Len(t, dists, len(queries))
+
+			for i, query := range queries {
+				res, resDists, err := index.SearchByVector(query, 10, allow)
+				require.Nil(t, err)
+				assert.Equal(t, res, ids[i])
+				assert.Equal(t, resDists, dists[i])
+			}
+		}
+	})
+
+	t.Run("deleted vectors are no longer returned", func(t *testing.T) {
+		res, _, err := index.SearchByVector(vectors[42], 1, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{42}, res)
+
+		require.Nil(t, index.bucket.Delete(helpers.VectorKey(42)))
+		require.Nil(t, index.Delete(42))
+
+		res, _, err = index.SearchByVector(vectors[42], 1, nil)
+		require.Nil(t, err)
+		assert.NotEqual(t, []uint64{42}, res)
+	})
+}
+
+func TestFlat_CosineNormalizesVectors(t *testing.T) {
+	provider := distancer.NewCosineDistanceProvider()
+	index, _ := testIndex(t, provider)
+	putVectors(t, index, [][]float32{{1, 0}, {10, 10}, {0, -3}})
+
+	t.Run("vectors are normalized when they are read", func(t *testing.T) {
+		res, dists, err := index.SearchByVector([]float32{4, 5}, 3, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{1, 0, 2}, res)
+		assert.InDelta(t, 1-0.9938837, dists[0], 1e-5)
+
+		res, _, err = index.SearchByVector([]float32{4, 5}, 3,
+			helpers.AllowList{0: {}, 2: {}})
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{0, 2}, res)
+	})
+
+	t.Run("the bucket keeps the vectors as they were imported", func(t *testing.T) {
+		v, err := index.bucket.Get(helpers.VectorKey(1))
+		require.Nil(t, err)
+		assert.Equal(t, []float32{10, 10}, helpers.VectorFromBytes(v))
+	})
+}
+
+func TestFlat_PersistsVectorsInStore(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	dir := t.TempDir()
+	provider := distancer.NewL2SquaredProvider()
+
+	store, err := lsmkv.New(dir, logger)
+	require.Nil(t, err)
+	index, err := New(Config{
+		ID:               "flat-test",
+		Store:            store,
+		DistanceProvider: provider,
+	}, NewDefaultUserConfig())
+	require.Nil(t, err)
+
+	for id, vec := range map[uint64][]float32{7: {1, 2, 3}, 8: {3, 2, 1}} {
+		require.Nil(t, index.bucket.Put(helpers.VectorKey(id),
+			helpers.VectorToBytes(vec)))
+	}
+	require.Nil(t, index.Flush())
+	require.Nil(t, store.Shutdown(context.Background()))
+
+	store, err = lsmkv.New(dir, logger)
+	require.Nil(t, err)
+	defer store.Shutdown(context.Background())
+	index, err = New(Config{
+		ID:               "flat-test",
+		Store:            store,
+		DistanceProvider: provider,
+	}, NewDefaultUserConfig())
+	require.Nil(t, err)
+
+	res, dists, err := index.SearchByVector([]float32{1, 2, 3}, 2, nil)
+	require.Nil(t, err)
+	assert.Equal(t, []uint64{7, 8}, res)
+	assert.Equal(t, []float32{0, 8}, dists)
+}
+
+func TestFlat_SharedBucket(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	store, err := lsmkv.New(t.TempDir(), logger)
+	require.Nil(t, err)
+	defer store.Shutdown(context.Background())
+
+	// the caller owns the bucket and stores the vectors as they were imported
+	require.Nil(t, store.CreateOrLoadBucket(context.Background(), "shared",
+		lsmkv.WithStrategy(lsmkv.StrategyReplace)))
+	bucket := store.Bucket("shared")
+	for id, vec := range [][]float32{{1, 0}, {10, 10}, {0, -3}} {
+		require.Nil(t, bucket.Put(helpers.VectorKey(uint64(id)),
+			helpers.VectorToBytes(vec)))
+	}
+
+	index, err := New(Config{
+		ID:               "flat-test",
+		Store:            store,
+		DistanceProvider: distancer.NewCosineDistanceProvider(),
+		BucketName:       "shared",
+	}, NewDefaultUserConfig())
+	require.Nil(t, err)
+
+	t.Run("vectors are read from the bucket", func(t *testing.T) {
+		res, _, err := index.SearchByVector([]float32{4, 5}, 3, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{1, 0, 2}, res)
+	})
+
+	t.Run("add and delete leave the bucket alone", func(t *testing.T) {
+		require.Nil(t, index.Add(7, []float32{1, 1}))
+		require.Nil(t, index.Delete(0))
+
+		v, err := bucket.Get(helpers.VectorKey(7))
+		require.Nil(t, err)
+		assert.Nil(t, v)
+
+		v, err = bucket.Get(helpers.VectorKey(0))
+		require.Nil(t, err)
+		assert.Equal(t, []float32{1, 0}, helpers.VectorFromBytes(v))
+	})
+
+	t.Run("add rejects empty vectors", func(t *testing.T) {
+		assert.NotNil(t, index.Add(8, nil))
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
@@ -36,14 +37,23 @@ type Index struct {
 	vectorIndex vectorIndex
 }
 
+// shapeSearchEF is the number of nodes outside of the circle around a shape
+// which are visited before the search stops
+const shapeSearchEF = 800
+
 // vectorIndex represents the underlying vector index, typically hnsw
 type vectorIndex interface {
 	Add(id uint64, vector []float32) error
 	KnnSearchByVectorMaxDist(query []float32, dist float32, ef int,
 		allowList helpers.AllowList) ([]uint64, error)
+	SearchByVector(vector []float32, k int,
+		allowList helpers.AllowList) ([]uint64, []float32, error)
+	SearchByVectorWithinDist(query []float32, dist float32,
+		ef int) ([]uint64, [][]float32, error)
 	Delete(id uint64) error
 	Dump(...string)
 	Drop() error
+	DiskUsage() (hnsw.DiskUsage, error)
 }
 
 // Config is passed to the GeoIndex when its created
@@ -53,6 +63,9 @@ type Config struct {
 	DisablePersistence bool
 	RootPath           string
 	Logger             logrus.FieldLogger
+
+	// SyncPolicy decides when the commit log is fsynced
+	SyncPolicy durability.Policy
 }
 
 func NewIndex(config Config) (*Index, error) {
@@ -88,12 +101,17 @@ func (i *Index) Drop() error {
 	return nil
 }
 
+// DiskUsage measures the commit logs and snapshots of the underlying index
+func (i *Index) DiskUsage() (hnsw.DiskUsage, error) {
+	return i.vectorIndex.DiskUsage()
+}
+
 func makeCommitLoggerFromConfig(config Config) hnsw.MakeCommitLogger {
 	makeCL := hnsw.MakeNoopCommitLogger
 	if !config.DisablePersistence {
 		makeCL = func() (hnsw.CommitLogger, error) {
 			return hnsw.NewCommitLogger(config.RootPath, config.ID, 10*time.Second,
-				config.Logger)
+				config.Logger, hnsw.WithSyncPolicy(config.SyncPolicy))
 		}
 	}
 	return makeCL
@@ -126,6 +144,75 @@ func (i *Index) WithinRange(ctx context.Context,
 	return i.vectorIndex.KnnSearchByVectorMaxDist(query, geoRange.Distance, 800, nil)
 }
 
+// WithinBoundingBox returns all ids within the specified box. It is
+// thread-safe and can be called concurrently.
+func (i *Index) WithinBoundingBox(ctx context.Context,
+	box filters.GeoBoundingBox) ([]uint64, error) {
+	parsed, err := newBoundingBox(box)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid arguments")
+	}
+
+	return i.withinShape(ctx, parsed)
+}
+
+// WithinPolygon returns all ids within the specified polygon. It is
+// thread-safe and can be called concurrently.
+func (i *Index) WithinPolygon(ctx context.Context,
+	poly filters.GeoPolygon) ([]uint64, error) {
+	parsed, err := newPolygon(poly)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid arguments")
+	}
+
+	return i.withinShape(ctx, parsed)
+}
+
+// withinShape searches the vector index by a circle around the shape, then
+// drops the candidates outside of the shape. Unlike a range search the
+// number of results is not limited, as a shape such as a map viewport can
+// easily contain many points. The coordinates of the candidates are those
+// held by the vector index, so no object needs to be read.
+func (i *Index) withinShape(ctx context.Context, s shape) ([]uint64, error) {
+	query, radius := boundingCircle(s)
+	candidates, coordinates, err := i.vectorIndex.SearchByVectorWithinDist(query,
+		radius, shapeSearchEF)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	out := candidates[:0]
+	for j, id := range candidates {
+		if s.contains(float64(coordinates[j][0]), float64(coordinates[j][1])) {
+			out = append(out, id)
+		}
+	}
+
+	return out, nil
+}
+
+// Nearest returns up to k ids ordered by their distance to the specified
+// coordinates, nearest first. The distances are returned in meters. If an
+// allow list is set, only the ids contained in it are considered. It is
+// thread-safe and can be called concurrently.
+func (i *Index) Nearest(ctx context.Context, coordinates *models.GeoCoordinates,
+	k int, allowList helpers.AllowList) ([]uint64, []float32, error) {
+	if coordinates == nil {
+		return nil, nil, fmt.Errorf("invalid arguments: coordinates must be set")
+	}
+
+	query, err := geoCoordiantesToVector(coordinates)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "invalid arguments")
+	}
+
+	return i.vectorIndex.SearchByVector(query, k, allowList)
+}
+
 func (i *Index) Delete(id uint64) error {
 	return i.vectorIndex.Delete(id)
 }
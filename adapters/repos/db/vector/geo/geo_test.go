@@ -15,6 +15,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/entities/filters"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/stretchr/testify/assert"
@@ -107,6 +108,35 @@ func TestGeoJourney(t *testing.T) {
 		expectedResults := []uint64{0}
 		assert.Equal(t, expectedResults, results)
 	})
+
+	t.Run("sorting by distance to augsburg", func(t *testing.T) {
+		// augsburg is closer to munich than to stuttgart
+		ids, dists, err := geoIndex.Nearest(context.Background(), &models.GeoCoordinates{
+			Latitude:  ptFloat32(48.37154),
+			Longitude: ptFloat32(10.89851),
+		}, 10, nil)
+		require.Nil(t, err)
+
+		assert.Equal(t, []uint64{0, 1}, ids)
+		require.Len(t, dists, 2)
+		assert.InDelta(t, 57*km, dists[0], float64(2*km))
+		assert.InDelta(t, 130*km, dists[1], float64(5*km))
+	})
+
+	t.Run("sorting by distance with an allow list", func(t *testing.T) {
+		ids, _, err := geoIndex.Nearest(context.Background(), &models.GeoCoordinates{
+			Latitude:  ptFloat32(48.37154),
+			Longitude: ptFloat32(10.89851),
+		}, 10, helpers.AllowList{1: struct{}{}})
+		require.Nil(t, err)
+
+		assert.Equal(t, []uint64{1}, ids)
+	})
+
+	t.Run("sorting by distance without coordinates", func(t *testing.T) {
+		_, _, err := geoIndex.Nearest(context.Background(), nil, 10, nil)
+		assert.Equal(t, "invalid arguments: coordinates must be set", err.Error())
+	})
 }
 
 func ptFloat32(in float32) *float32 {
@@ -0,0 +1,258 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package geo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+)
+
+// samplesPerEdge controls how many points of each edge of a shape are used to
+// determine the circle around the shape
+const samplesPerEdge = 32
+
+// shape is an area on the earth's surface which can't be searched by the
+// underlying vector index directly. Instead, the index is searched by a
+// circle around the shape and every candidate is checked with contains.
+type shape interface {
+	contains(lat, lon float64) bool
+
+	// outline returns the points along the border of the shape
+	outline() [][2]float64
+
+	// center returns the middle of the extent of the shape
+	center() [2]float64
+}
+
+// boundingCircle returns the center and radius (in meters) of a circle which
+// contains the whole shape. Points along the outline are sampled, so a small
+// margin is added to the radius. If the shape covers more than a hemisphere
+// the circle covers the whole earth.
+func boundingCircle(s shape) ([]float32, float32) {
+	c := s.center()
+	center := []float32{float32(c[0]), float32(c[1])}
+
+	var max float64
+	for _, p := range s.outline() {
+		// the distance is periodic in the longitude, so unwrapped longitudes
+		// can be used. It can't fail for vectors of length 2.
+		d, _, _ := distancer.NewGeoProvider().SingleDist(center,
+			[]float32{float32(p[0]), float32(p[1])})
+		if float64(d) > max {
+			max = float64(d)
+		}
+	}
+
+	if max > math.Pi*distancer.EarthRadius/2 {
+		max = math.Pi * distancer.EarthRadius
+	}
+
+	return center, float32(max*1.01 + 1)
+}
+
+type boundingBox struct {
+	top, left, bottom, right float64
+}
+
+func newBoundingBox(in filters.GeoBoundingBox) (*boundingBox, error) {
+	if in.TopLeft == nil || in.BottomRight == nil {
+		return nil, fmt.Errorf("topLeft and bottomRight must be set")
+	}
+
+	topLeft, err := parseCoordinates(in.TopLeft)
+	if err != nil {
+		return nil, fmt.Errorf("topLeft: %v", err)
+	}
+
+	bottomRight, err := parseCoordinates(in.BottomRight)
+	if err != nil {
+		return nil, fmt.Errorf("bottomRight: %v", err)
+	}
+
+	if topLeft[0] < bottomRight[0] {
+		return nil, fmt.Errorf("latitude of topLeft must not be smaller than " +
+			"latitude of bottomRight")
+	}
+
+	return &boundingBox{
+		top:    topLeft[0],
+		left:   topLeft[1],
+		bottom: bottomRight[0],
+		right:  bottomRight[1],
+	}, nil
+}
+
+// width in degrees of longitude, boxes which cross the antimeridian have a
+// left longitude which is larger than the right one
+func (b *boundingBox) width() float64 {
+	if b.left <= b.right {
+		return b.right - b.left
+	}
+
+	return b.right + 360 - b.left
+}
+
+func (b *boundingBox) contains(lat, lon float64) bool {
+	if lat < b.bottom || lat > b.top {
+		return false
+	}
+
+	if b.left <= b.right {
+		return lon >= b.left && lon <= b.right
+	}
+
+	return lon >= b.left || lon <= b.right
+}
+
+func (b *boundingBox) center() [2]float64 {
+	return [2]float64{(b.top + b.bottom) / 2, normalizeLongitude(b.left + b.width()/2)}
+}
+
+func (b *boundingBox) outline() [][2]float64 {
+	topLeft := [2]float64{b.top, b.left}
+	topRight := [2]float64{b.top, b.left + b.width()}
+	bottomRight := [2]float64{b.bottom, b.left + b.width()}
+	bottomLeft := [2]float64{b.bottom, b.left}
+
+	return sampleEdges([][2]float64{topLeft, topRight, bottomRight, bottomLeft})
+}
+
+// polygon treats edges as straight lines in the latitude/longitude plane,
+// which matches how polygons are drawn on a map. Longitudes are unwrapped, so
+// that polygons may cross the antimeridian.
+type polygon struct {
+	vertices [][2]float64
+}
+
+func newPolygon(in filters.GeoPolygon) (*polygon, error) {
+	if len(in.Vertices) < 3 {
+		return nil, fmt.Errorf("a polygon needs at least 3 vertices, got %d",
+			len(in.Vertices))
+	}
+
+	vertices := make([][2]float64, len(in.Vertices))
+	for i, v := range in.Vertices {
+		if v == nil {
+			return nil, fmt.Errorf("vertex %d must be set", i)
+		}
+
+		parsed, err := parseCoordinates(v)
+		if err != nil {
+			return nil, fmt.Errorf("vertex %d: %v", i, err)
+		}
+
+		if i > 0 {
+			// always take the shorter way around the earth from one vertex to the
+			// next one
+			prev := vertices[i-1][1]
+			parsed[1] = prev + normalizeLongitude(parsed[1]-prev)
+		}
+
+		vertices[i] = parsed
+	}
+
+	last := vertices[len(vertices)-1][1]
+	closing := last + normalizeLongitude(vertices[0][1]-last)
+	if math.Abs(closing-vertices[0][1]) > 1e-6 {
+		return nil, fmt.Errorf("polygons which enclose a pole are not supported")
+	}
+
+	return &polygon{vertices: vertices}, nil
+}
+
+func (p *polygon) contains(lat, lon float64) bool {
+	// the unwrapped vertices can extend beyond [-180, 180]
+	return p.containsUnwrapped(lat, lon) ||
+		p.containsUnwrapped(lat, lon+360) ||
+		p.containsUnwrapped(lat, lon-360)
+}
+
+// containsUnwrapped uses the even-odd rule: a ray from the point crosses the
+// outline an odd number of times if the point is inside
+func (p *polygon) containsUnwrapped(lat, lon float64) bool {
+	inside := false
+	for i, j := 0, len(p.vertices)-1; i < len(p.vertices); j, i = i, i+1 {
+		a, b := p.vertices[i], p.vertices[j]
+		if (a[0] > lat) == (b[0] > lat) {
+			continue
+		}
+
+		crossing := a[1] + (lat-a[0])/(b[0]-a[0])*(b[1]-a[1])
+		if lon < crossing {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+func (p *polygon) center() [2]float64 {
+	minLat, maxLat := p.vertices[0][0], p.vertices[0][0]
+	minLon, maxLon := p.vertices[0][1], p.vertices[0][1]
+	for _, v := range p.vertices[1:] {
+		minLat, maxLat = math.Min(minLat, v[0]), math.Max(maxLat, v[0])
+		minLon, maxLon = math.Min(minLon, v[1]), math.Max(maxLon, v[1])
+	}
+
+	return [2]float64{(minLat + maxLat) / 2, normalizeLongitude((minLon + maxLon) / 2)}
+}
+
+func (p *polygon) outline() [][2]float64 {
+	return sampleEdges(p.vertices)
+}
+
+// sampleEdges returns points along the closed path through the vertices
+func sampleEdges(vertices [][2]float64) [][2]float64 {
+	out := make([][2]float64, 0, len(vertices)*samplesPerEdge)
+	for i := range vertices {
+		from, to := vertices[i], vertices[(i+1)%len(vertices)]
+		for s := 0; s < samplesPerEdge; s++ {
+			f := float64(s) / samplesPerEdge
+			out = append(out, [2]float64{
+				from[0] + f*(to[0]-from[0]),
+				from[1] + f*(to[1]-from[1]),
+			})
+		}
+	}
+
+	return out
+}
+
+func parseCoordinates(in *models.GeoCoordinates) ([2]float64, error) {
+	if in.Latitude == nil {
+		return [2]float64{}, fmt.Errorf("latitude must be set")
+	}
+
+	if in.Longitude == nil {
+		return [2]float64{}, fmt.Errorf("longitude must be set")
+	}
+
+	lat, lon := float64(*in.Latitude), float64(*in.Longitude)
+	if lat < -90 || lat > 90 {
+		return [2]float64{}, fmt.Errorf("latitude must be between -90 and 90, got %v", lat)
+	}
+
+	if lon < -180 || lon > 180 {
+		return [2]float64{}, fmt.Errorf("longitude must be between -180 and 180, got %v", lon)
+	}
+
+	return [2]float64{lat, lon}, nil
+}
+
+// normalizeLongitude maps any longitude into the range [-180, 180)
+func normalizeLongitude(lon float64) float64 {
+	return math.Mod(math.Mod(lon+180, 360)+360, 360) - 180
+}
@@ -0,0 +1,191 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package geo
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundingBox(t *testing.T) {
+	t.Run("a regular box", func(t *testing.T) {
+		box, err := newBoundingBox(filters.GeoBoundingBox{
+			TopLeft:     coordinates(50, 5),
+			BottomRight: coordinates(45, 15),
+		})
+		require.Nil(t, err)
+
+		assert.True(t, box.contains(48.13743, 11.57549))  // munich
+		assert.False(t, box.contains(52.52000, 13.40495)) // berlin
+		assert.False(t, box.contains(48.85661, 2.35222))  // paris
+	})
+
+	t.Run("a box crossing the antimeridian", func(t *testing.T) {
+		box, err := newBoundingBox(filters.GeoBoundingBox{
+			TopLeft:     coordinates(-10, 170),
+			BottomRight: coordinates(-20, -170),
+		})
+		require.Nil(t, err)
+
+		assert.True(t, box.contains(-17.71337, 178.06503)) // fiji
+		assert.True(t, box.contains(-15, -175))
+		assert.False(t, box.contains(-15, 0))
+		assert.Equal(t, [2]float64{-15, -180}, box.center())
+	})
+
+	t.Run("corners in the wrong order", func(t *testing.T) {
+		_, err := newBoundingBox(filters.GeoBoundingBox{
+			TopLeft:     coordinates(45, 5),
+			BottomRight: coordinates(50, 15),
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("a corner is missing", func(t *testing.T) {
+		_, err := newBoundingBox(filters.GeoBoundingBox{
+			TopLeft: coordinates(45, 5),
+		})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestPolygon(t *testing.T) {
+	t.Run("a concave polygon", func(t *testing.T) {
+		// a "U" shape open to the north
+		poly, err := newPolygon(filters.GeoPolygon{Vertices: []*models.GeoCoordinates{
+			coordinates(10, 0), coordinates(0, 0), coordinates(0, 10),
+			coordinates(10, 10), coordinates(10, 7), coordinates(3, 7),
+			coordinates(3, 3), coordinates(10, 3),
+		}})
+		require.Nil(t, err)
+
+		assert.True(t, poly.contains(5, 1))
+		assert.True(t, poly.contains(1, 5))
+		assert.True(t, poly.contains(5, 9))
+		assert.False(t, poly.contains(5, 5))
+		assert.False(t, poly.contains(11, 5))
+	})
+
+	t.Run("a polygon crossing the antimeridian", func(t *testing.T) {
+		poly, err := newPolygon(filters.GeoPolygon{Vertices: []*models.GeoCoordinates{
+			coordinates(-10, 170), coordinates(-10, -170),
+			coordinates(-20, -170), coordinates(-20, 170),
+		}})
+		require.Nil(t, err)
+
+		assert.True(t, poly.contains(-17.71337, 178.06503))
+		assert.True(t, poly.contains(-15, -175))
+		assert.False(t, poly.contains(-15, 0))
+	})
+
+	t.Run("a polygon enclosing a pole", func(t *testing.T) {
+		_, err := newPolygon(filters.GeoPolygon{Vertices: []*models.GeoCoordinates{
+			coordinates(80, 0), coordinates(80, 120), coordinates(80, -120),
+		}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("too few vertices", func(t *testing.T) {
+		_, err := newPolygon(filters.GeoPolygon{Vertices: []*models.GeoCoordinates{
+			coordinates(80, 0), coordinates(80, 120),
+		}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestWithinShapes(t *testing.T) {
+	size := 3000
+	elements := make([]*models.GeoCoordinates, size)
+	for i := range elements {
+		elements[i] = coordinates(rand.Float32()*180-90, rand.Float32()*360-180)
+	}
+
+	var reads int64
+	geoIndex, err := NewIndex(Config{
+		ID: "unit-test",
+		CoordinatesForID: func(ctx context.Context, id uint64) (*models.GeoCoordinates, error) {
+			atomic.AddInt64(&reads, 1)
+			return elements[id], nil
+		},
+		DisablePersistence: true,
+		RootPath:           "doesnt-matter-persistence-is-off",
+	})
+	require.Nil(t, err)
+
+	for id, c := range elements {
+		require.Nil(t, geoIndex.Add(uint64(id), c))
+	}
+	atomic.StoreInt64(&reads, 0)
+
+	// the shapes match more objects than a single range search returns, and
+	// some cross the antimeridian
+	boxes := []filters.GeoBoundingBox{
+		{TopLeft: coordinates(60, -30), BottomRight: coordinates(30, 40)},
+		{TopLeft: coordinates(89, -180), BottomRight: coordinates(-89, 0)},
+		{TopLeft: coordinates(20, 150), BottomRight: coordinates(-40, -120)},
+	}
+	polygons := []filters.GeoPolygon{
+		{Vertices: []*models.GeoCoordinates{
+			coordinates(70, -100), coordinates(-60, -60), coordinates(10, 60),
+		}},
+		{Vertices: []*models.GeoCoordinates{
+			coordinates(30, 160), coordinates(30, -150), coordinates(-50, -150),
+			coordinates(0, -175), coordinates(-50, 160),
+		}},
+	}
+
+	verify := func(t *testing.T, s shape, res []uint64) {
+		var expected []uint64
+		for id, c := range elements {
+			if s.contains(float64(*c.Latitude), float64(*c.Longitude)) {
+				expected = append(expected, uint64(id))
+			}
+		}
+
+		matches := 0
+		for _, id := range res {
+			c := elements[id]
+			require.True(t, s.contains(float64(*c.Latitude), float64(*c.Longitude)))
+			matches++
+		}
+		recall := float64(matches) / float64(len(expected))
+		assert.GreaterOrEqual(t, recall, 0.99)
+	}
+
+	for _, box := range boxes {
+		res, err := geoIndex.WithinBoundingBox(context.Background(), box)
+		require.Nil(t, err)
+		parsed, _ := newBoundingBox(box)
+		verify(t, parsed, res)
+	}
+
+	for _, poly := range polygons {
+		res, err := geoIndex.WithinPolygon(context.Background(), poly)
+		require.Nil(t, err)
+		parsed, _ := newPolygon(poly)
+		verify(t, parsed, res)
+	}
+
+	// the coordinates of the candidates are those of the vector cache
+	assert.Equal(t, int64(0), atomic.LoadInt64(&reads))
+}
+
+func coordinates(lat, lon float32) *models.GeoCoordinates {
+	return &models.GeoCoordinates{Latitude: &lat, Longitude: &lon}
+}
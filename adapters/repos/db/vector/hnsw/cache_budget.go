@@ -0,0 +1,176 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CacheBudget is a byte-denominated memory limit which is shared by the
+// vector caches of all indexes on a node. Every cache reports how many bytes
+// it adds or frees. Once the caches together exceed the budget, entries are
+// evicted from the registered caches in turns until the usage is back below
+// the low watermark. Evicted vectors are simply read from disk again on the
+// next access.
+//
+// A budget with a limit of 0 is unlimited, but still tracks the usage.
+type CacheBudget struct {
+	sync.Mutex
+	maxBytes  int64
+	usedBytes int64
+	caches    []evictableCache
+	cursor    int
+	evicting  int32
+	logger    logrus.FieldLogger
+}
+
+// evictableCache is implemented by all caches which take part in a
+// CacheBudget
+type evictableCache interface {
+	// evict frees at least the specified amount of bytes, unless the cache
+	// holds less than that, and returns how many bytes were actually freed
+	evict(bytes int64) int64
+}
+
+// CacheStats contains the usage counters of a single cache or, summed up, of
+// all caches of an index
+type CacheStats struct {
+	Entries   int64
+	SizeBytes int64
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+func (s CacheStats) add(other CacheStats) CacheStats {
+	return CacheStats{
+		Entries:   s.Entries + other.Entries,
+		SizeBytes: s.SizeBytes + other.SizeBytes,
+		Hits:      s.Hits + other.Hits,
+		Misses:    s.Misses + other.Misses,
+		Evictions: s.Evictions + other.Evictions,
+	}
+}
+
+// CacheBudgetStats describes the node-wide usage of a CacheBudget
+type CacheBudgetStats struct {
+	MaxBytes  int64
+	UsedBytes int64
+	Caches    int
+}
+
+func NewCacheBudget(maxBytes int64, logger logrus.FieldLogger) *CacheBudget {
+	return &CacheBudget{
+		maxBytes: maxBytes,
+		logger:   logger,
+	}
+}
+
+func (b *CacheBudget) Stats() CacheBudgetStats {
+	b.Lock()
+	caches := len(b.caches)
+	b.Unlock()
+
+	return CacheBudgetStats{
+		MaxBytes:  b.maxBytes,
+		UsedBytes: atomic.LoadInt64(&b.usedBytes),
+		Caches:    caches,
+	}
+}
+
+func (b *CacheBudget) register(c evictableCache) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.caches = append(b.caches, c)
+}
+
+func (b *CacheBudget) deregister(c evictableCache) {
+	b.Lock()
+	defer b.Unlock()
+
+	for i := range b.caches {
+		if b.caches[i] == c {
+			b.caches = append(b.caches[:i], b.caches[i+1:]...)
+			return
+		}
+	}
+}
+
+// add records a change in the memory usage of one of the caches, a negative
+// delta means memory was freed. If the budget is exceeded, the caller evicts
+// entries until the usage is back below the low watermark. Only one caller
+// evicts at a time, all others return immediately.
+func (b *CacheBudget) add(delta int64) {
+	used := atomic.AddInt64(&b.usedBytes, delta)
+	if b.maxBytes <= 0 || used <= b.maxBytes {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&b.evicting, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&b.evicting, 0)
+
+	b.evict()
+}
+
+func (b *CacheBudget) evict() {
+	b.Lock()
+	caches := make([]evictableCache, len(b.caches))
+	copy(caches, b.caches)
+	b.Unlock()
+
+	if len(caches) == 0 {
+		return
+	}
+
+	target := lowWatermark(b.maxBytes)
+	before := atomic.LoadInt64(&b.usedBytes)
+
+	// every cache gives up a share of the excess in turns. The loop stops
+	// once a full round over all caches could not free anything anymore.
+	idle := 0
+	for idle < len(caches) {
+		excess := atomic.LoadInt64(&b.usedBytes) - target
+		if excess <= 0 {
+			break
+		}
+
+		share := excess/int64(len(caches)) + 1
+		c := caches[b.cursor%len(caches)]
+		b.cursor++
+
+		if c.evict(share) == 0 {
+			idle++
+		} else {
+			idle = 0
+		}
+	}
+
+	b.logger.WithFields(logrus.Fields{
+		"action":    "hnsw_vector_cache_evict",
+		"max_bytes": b.maxBytes,
+		"before":    before,
+		"after":     atomic.LoadInt64(&b.usedBytes),
+	}).Debug("evicted vectors to stay within the vector cache budget")
+}
+
+// lowWatermark is the usage that eviction aims for once a limit is exceeded.
+// Evicting slightly more than strictly necessary means that not every
+// subsequent insert triggers another eviction.
+func lowWatermark(limit int64) int64 {
+	return limit - limit/20
+}
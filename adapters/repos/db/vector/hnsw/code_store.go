@@ -0,0 +1,150 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// codeStoreHeaderSize is the uint32 code length at the start of the file
+const codeStoreHeaderSize = 4
+
+// codeStore persists the codes of a scalar quantized index, so that the
+// compressed cache is filled from them instead of from the full vectors. The
+// code of an id is kept at a fixed position, followed by its crc32. A code
+// which was never written, or was torn by a crash, fails the checksum and is
+// simply encoded again from the full vector. This is also why the store is
+// not fsynced.
+//
+// The codes only fit the quantizer they were encoded with, a store is
+// therefore started anew whenever a quantizer is fitted.
+type codeStore struct {
+	file       *os.File
+	path       string
+	codeLength int
+}
+
+func codeStorePath(rootPath, name string) string {
+	return fmt.Sprintf("%s/%s.hnsw.sqcodes", rootPath, name)
+}
+
+// openCodeStore opens the store at path. An existing store is only kept if
+// fresh is false and its codes are of the same length.
+func openCodeStore(path string, codeLength int, fresh bool) (*codeStore, error) {
+	if codeLength <= 0 {
+		return nil, errors.Errorf("invalid code length %d", codeLength)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open code store %q", path)
+	}
+
+	s := &codeStore{file: file, path: path, codeLength: codeLength}
+
+	if !fresh {
+		header := make([]byte, codeStoreHeaderSize)
+		_, err := file.ReadAt(header, 0)
+		if err == nil && int(binary.LittleEndian.Uint32(header)) == codeLength {
+			return s, nil
+		}
+		if err != nil && err != io.EOF {
+			file.Close()
+			return nil, errors.Wrapf(err, "read header of code store %q", path)
+		}
+	}
+
+	if err := s.reset(); err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "reset code store %q", path)
+	}
+
+	return s, nil
+}
+
+func (s *codeStore) reset() error {
+	if err := s.file.Truncate(0); err != nil {
+		return err
+	}
+
+	header := make([]byte, codeStoreHeaderSize)
+	binary.LittleEndian.PutUint32(header, uint32(s.codeLength))
+	_, err := s.file.WriteAt(header, 0)
+	return err
+}
+
+func (s *codeStore) offset(id uint64) int64 {
+	return codeStoreHeaderSize + int64(id)*int64(s.codeLength+4)
+}
+
+// get returns false if there is no valid code for id
+func (s *codeStore) get(id uint64) ([]byte, bool, error) {
+	slot := make([]byte, s.codeLength+4)
+	if _, err := s.file.ReadAt(slot, s.offset(id)); err != nil {
+		if err == io.EOF {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrapf(err, "read code of docID %d", id)
+	}
+
+	code := slot[:s.codeLength]
+	if crc32.ChecksumIEEE(code) != binary.LittleEndian.Uint32(slot[s.codeLength:]) {
+		return nil, false, nil
+	}
+
+	return code, true, nil
+}
+
+func (s *codeStore) put(id uint64, code []byte) error {
+	if len(code) != s.codeLength {
+		return errors.Errorf("code of docID %d has length %d, expected %d", id,
+			len(code), s.codeLength)
+	}
+
+	slot := make([]byte, s.codeLength+4)
+	copy(slot, code)
+	binary.LittleEndian.PutUint32(slot[s.codeLength:], crc32.ChecksumIEEE(code))
+	if _, err := s.file.WriteAt(slot, s.offset(id)); err != nil {
+		return errors.Wrapf(err, "write code of docID %d", id)
+	}
+
+	return nil
+}
+
+// size is the length of the file. The slots of ids which were never written
+// are holes, which don't take up any space on most file systems.
+func (s *codeStore) size() (int64, error) {
+	info, err := s.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+func (s *codeStore) drop() error {
+	if err := s.file.Close(); err != nil {
+		return errors.Wrapf(err, "close code store %q", s.path)
+	}
+
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "remove code store %q", s.path)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeStore(t *testing.T) {
+	path := codeStorePath(t.TempDir(), "code-store-test")
+
+	s, err := openCodeStore(path, 3, false)
+	require.Nil(t, err)
+
+	require.Nil(t, s.put(0, []byte{1, 2, 3}))
+	require.Nil(t, s.put(7, []byte{4, 5, 6}))
+
+	t.Run("codes are read back", func(t *testing.T) {
+		code, ok, err := s.get(0)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []byte{1, 2, 3}, code)
+
+		code, ok, err = s.get(7)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []byte{4, 5, 6}, code)
+	})
+
+	t.Run("holes and ids past the end are missing", func(t *testing.T) {
+		_, ok, err := s.get(3)
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		_, ok, err = s.get(100)
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("codes of a different length are rejected", func(t *testing.T) {
+		assert.NotNil(t, s.put(1, []byte{1, 2}))
+	})
+
+	t.Run("a corrupt code is missing", func(t *testing.T) {
+		_, err := s.file.WriteAt([]byte{9}, s.offset(7))
+		require.Nil(t, err)
+
+		_, ok, err := s.get(7)
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	require.Nil(t, s.file.Close())
+
+	t.Run("codes survive a reopen", func(t *testing.T) {
+		s, err := openCodeStore(path, 3, false)
+		require.Nil(t, err)
+		defer s.file.Close()
+
+		code, ok, err := s.get(0)
+		require.Nil(t, err)
+		require.True(t, ok)
+		assert.Equal(t, []byte{1, 2, 3}, code)
+	})
+
+	t.Run("a different code length resets the store", func(t *testing.T) {
+		s, err := openCodeStore(path, 4, false)
+		require.Nil(t, err)
+		defer s.file.Close()
+
+		_, ok, err := s.get(0)
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		size, err := s.size()
+		require.Nil(t, err)
+		assert.Equal(t, int64(codeStoreHeaderSize), size)
+	})
+
+	t.Run("a fresh store is empty", func(t *testing.T) {
+		s, err := openCodeStore(path, 4, false)
+		require.Nil(t, err)
+		require.Nil(t, s.put(0, []byte{1, 2, 3, 4}))
+		require.Nil(t, s.file.Close())
+
+		s, err = openCodeStore(path, 4, true)
+		require.Nil(t, err)
+
+		_, ok, err := s.get(0)
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		require.Nil(t, s.drop())
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
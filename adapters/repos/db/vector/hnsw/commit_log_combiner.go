@@ -45,7 +45,12 @@ func (c *CommitLogCombiner) Do() error {
 			return errors.Wrap(err, "obtain files names")
 		}
 
-		ok, err := c.combineFirstMatch(fileNames)
+		snapshots, err := getSnapshotTimestamps(c.rootPath, c.id)
+		if err != nil {
+			return errors.Wrap(err, "obtain snapshots")
+		}
+
+		ok, err := c.combineFirstMatch(fileNames, snapshots)
 		if err != nil {
 			return err
 		}
@@ -59,7 +64,8 @@ func (c *CommitLogCombiner) Do() error {
 	return nil
 }
 
-func (c *CommitLogCombiner) combineFirstMatch(fileNames []string) (bool, error) {
+func (c *CommitLogCombiner) combineFirstMatch(fileNames []string,
+	snapshots []int64) (bool, error) {
 	for i, fileName := range fileNames {
 		if !strings.HasSuffix(fileName, ".condensed") {
 			// not an already condensed file, so no candidate for combining
@@ -77,6 +83,16 @@ func (c *CommitLogCombiner) combineFirstMatch(fileNames []string) (bool, error)
 			continue
 		}
 
+		straddles, err := straddlesSnapshot(snapshots, fileName, fileNames[i+1])
+		if err != nil {
+			return false, err
+		}
+
+		if straddles {
+			// the first file is contained in a snapshot, but the second isn't
+			continue
+		}
+
 		currentStat, err := os.Stat(fileName)
 		if err != nil {
 			return false, errors.Wrapf(err, "stat file %q", fileName)
@@ -0,0 +1,260 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// CommitLogStats summarizes the state that one or more commit logs
+// deserialize into. It is meant for offline inspection of an index that is
+// not loaded, e.g. on a copy of a data directory.
+type CommitLogStats struct {
+	// Size is the size of all deserialized files, ValidSize the part of it
+	// that could be deserialized. They differ if a log ends in a torn write.
+	Size      int64
+	ValidSize int64
+
+	Nodes      int
+	Links      int
+	Tombstones int
+	MaxLevel   int
+	Entrypoint uint64
+
+	// Compressed is set if a product or scalar quantizer is persisted
+	Compressed bool
+}
+
+func (s CommitLogStats) Torn() bool {
+	return s.ValidSize < s.Size
+}
+
+// SummarizeCommitLog deserializes a single commit log on its own. As every
+// log builds upon the ones before it, the result describes the changes
+// contained in this log rather than the whole graph. The file is not modified.
+func SummarizeCommitLog(fileName string,
+	logger logrus.FieldLogger) (CommitLogStats, error) {
+	state, stats, err := deserializeCommitLogReadOnly(fileName, nil, logger)
+	if err != nil {
+		return stats, err
+	}
+
+	stats.addState(state)
+	return stats, nil
+}
+
+// CommitLogReplay is the result of ReplayCommitLogs
+type CommitLogReplay struct {
+	// Snapshot is the timestamp of the snapshot the replay started from, 0 if
+	// there was none
+	Snapshot int64
+	Files    []string
+	Stats    CommitLogStats
+
+	state *DeserializationResult
+}
+
+// ReplayedNode is a node of a replayed graph
+type ReplayedNode struct {
+	ID          uint64
+	Level       int
+	Tombstone   bool
+	Connections map[int][]uint64
+}
+
+// ReplayCommitLogs deserializes the newest snapshot of an index and all of
+// its commit logs that are not covered by it, in the same order as a startup
+// would. Contrary to a startup no file is modified: torn logs are not
+// truncated and leftovers of interrupted maintenance are skipped instead of
+// deleted.
+func ReplayCommitLogs(rootPath, name string,
+	logger logrus.FieldLogger) (*CommitLogReplay, error) {
+	fileNames, err := ListCommitLogs(rootPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	state, snapshotTs, err := loadNewestSnapshot(rootPath, name, logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "load snapshot")
+	}
+
+	out := &CommitLogReplay{Snapshot: snapshotTs}
+	for _, fileName := range fileNames {
+		if state != nil {
+			covered, err := snapshotCovers(snapshotTs, fileName)
+			if err != nil {
+				return nil, err
+			}
+
+			if covered {
+				continue
+			}
+		}
+
+		var stats CommitLogStats
+		state, stats, err = deserializeCommitLogReadOnly(fileName, state, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Files = append(out.Files, fileName)
+		out.Stats.Size += stats.Size
+		out.Stats.ValidSize += stats.ValidSize
+	}
+
+	if state != nil {
+		out.Stats.addState(state)
+	}
+	out.state = state
+	return out, nil
+}
+
+// ForEachNode calls fn for every node of the replayed graph in order of
+// their ids
+func (r *CommitLogReplay) ForEachNode(fn func(node ReplayedNode) error) error {
+	if r.state == nil {
+		return nil
+	}
+
+	for _, node := range r.state.Nodes {
+		if node == nil {
+			continue
+		}
+
+		_, tombstone := r.state.Tombstones[node.id]
+		if err := fn(ReplayedNode{
+			ID:          node.id,
+			Level:       node.level,
+			Tombstone:   tombstone,
+			Connections: node.connections,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *CommitLogStats) addState(state *DeserializationResult) {
+	for _, node := range state.Nodes {
+		if node == nil {
+			continue
+		}
+
+		s.Nodes++
+		if node.level > s.MaxLevel {
+			s.MaxLevel = node.level
+		}
+		for _, conns := range node.connections {
+			s.Links += len(conns)
+		}
+	}
+
+	s.Tombstones = len(state.Tombstones)
+	s.Entrypoint = state.Entrypoint
+	s.Compressed = state.Compressed || state.ScalarQuantized
+}
+
+// deserializeCommitLogReadOnly tolerates a torn write at the end of the log
+// like a startup does, but does not truncate the file
+func deserializeCommitLogReadOnly(fileName string,
+	state *DeserializationResult,
+	logger logrus.FieldLogger) (*DeserializationResult, CommitLogStats, error) {
+	var stats CommitLogStats
+
+	fd, err := os.Open(fileName)
+	if err != nil {
+		return nil, stats, errors.Wrapf(err, "open commit log %q", fileName)
+	}
+	defer fd.Close()
+
+	info, err := fd.Stat()
+	if err != nil {
+		return nil, stats, errors.Wrapf(err, "stat commit log %q", fileName)
+	}
+	stats.Size = info.Size()
+
+	out, valid, err := NewDeserializer2(logger).Do(bufio.NewReaderSize(fd,
+		256*1024), state, false)
+	stats.ValidSize = int64(valid)
+	if err != nil && !errors.Is(err, io.EOF) &&
+		!errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, stats, errors.Wrapf(err, "deserialize commit log %q", fileName)
+	}
+
+	return out, stats, nil
+}
+
+// ListCommitLogs returns the commit logs of an index from old to new. These
+// are the same files in the same order as a startup would read them, but
+// leftovers of interrupted maintenance are skipped instead of deleted.
+func ListCommitLogs(rootPath, name string) ([]string, error) {
+	files, err := ioutil.ReadDir(commitLogDirectory(rootPath, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "browse commit logger directory")
+	}
+
+	present := map[string]struct{}{}
+	for _, file := range files {
+		present[file.Name()] = struct{}{}
+	}
+
+	type candidate struct {
+		name string
+		ts   int64
+	}
+
+	var candidates []candidate
+	for _, file := range removeTmpScratchFiles(files) {
+		fileName := file.Name()
+		if strings.HasSuffix(fileName, ".combined.tmp") {
+			continue
+		}
+
+		if strings.HasSuffix(fileName, ".condensed") {
+			// an incomplete condensing, the original is still present
+			if _, ok := present[strings.TrimSuffix(fileName, ".condensed")]; ok {
+				continue
+			}
+		}
+
+		ts, err := asTimeStamp(fileName)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate{name: fileName, ts: ts})
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		return candidates[a].ts < candidates[b].ts
+	})
+
+	out := make([]string, len(candidates))
+	for i, c := range candidates {
+		out[i] = filepath.Join(commitLogDirectory(rootPath, name), c.name)
+	}
+
+	return out, nil
+}
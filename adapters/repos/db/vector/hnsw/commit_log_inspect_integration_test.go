@@ -0,0 +1,121 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package hnsw
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayCommitLogs(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	indexID := "integrationtest_replay"
+	defer os.RemoveAll(dirName)
+
+	vectors := make([][]float32, 30)
+	for i := range vectors {
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32()}
+	}
+
+	logger, _ := test.NewNullLogger()
+	cl, err := NewCommitLogger(dirName, indexID, 0, logger)
+	require.Nil(t, err)
+
+	index, err := New(Config{
+		RootPath: dirName,
+		ID:       indexID,
+		MakeCommitLoggerThunk: func() (CommitLogger, error) {
+			return cl, nil
+		},
+		DistanceProvider: distancer.NewL2SquaredProvider(),
+		VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
+			return vectors[id], nil
+		},
+		Logger: logger,
+	}, UserConfig{
+		MaxConnections: 8,
+		EFConstruction: 32,
+	})
+	require.Nil(t, err)
+
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+	require.Nil(t, index.Delete(7))
+	require.Nil(t, index.Flush())
+
+	t.Run("replay the logs of the running index", func(t *testing.T) {
+		replay, err := ReplayCommitLogs(dirName, indexID, logger)
+		require.Nil(t, err)
+
+		require.Len(t, replay.Files, 1)
+		assert.Equal(t, int64(0), replay.Snapshot)
+		assert.False(t, replay.Stats.Torn())
+		assert.Equal(t, len(vectors), replay.Stats.Nodes)
+		assert.Equal(t, 1, replay.Stats.Tombstones)
+		assert.Equal(t, index.entryPointID, replay.Stats.Entrypoint)
+
+		var ids []uint64
+		err = replay.ForEachNode(func(node ReplayedNode) error {
+			ids = append(ids, node.ID)
+			assert.Equal(t, node.ID == 7, node.Tombstone)
+			assert.NotEmpty(t, node.Connections[0])
+			return nil
+		})
+		require.Nil(t, err)
+		assert.Len(t, ids, len(vectors))
+	})
+
+	t.Run("replay a torn log without repairing it", func(t *testing.T) {
+		logs, err := ListCommitLogs(dirName, indexID)
+		require.Nil(t, err)
+		require.Len(t, logs, 1)
+
+		log, err := ioutil.ReadFile(logs[0])
+		require.Nil(t, err)
+
+		rootPath := filepath.Join(dirName, "torn")
+		tornLog := commitLogFileName(rootPath, indexID, filepath.Base(logs[0]))
+		require.Nil(t, os.MkdirAll(filepath.Dir(tornLog), 0o777))
+		require.Nil(t, ioutil.WriteFile(tornLog, log[:len(log)-3], 0o666))
+
+		stats, err := SummarizeCommitLog(tornLog, logger)
+		require.Nil(t, err)
+		assert.True(t, stats.Torn())
+		assert.Equal(t, int64(len(log)-3), stats.Size)
+
+		replay, err := ReplayCommitLogs(rootPath, indexID, logger)
+		require.Nil(t, err)
+		assert.True(t, replay.Stats.Torn())
+		assert.Equal(t, len(vectors), replay.Stats.Nodes)
+
+		info, err := os.Stat(tornLog)
+		require.Nil(t, err)
+		assert.Equal(t, int64(len(log)-3), info.Size(), "the log was not truncated")
+	})
+}
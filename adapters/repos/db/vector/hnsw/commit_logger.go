@@ -24,7 +24,9 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/commitlog"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 	"github.com/sirupsen/logrus"
 )
 
@@ -38,9 +40,20 @@ func commitLogDirectory(rootPath, name string) string {
 	return fmt.Sprintf("%s/%s.hnsw.commitlog.d", rootPath, name)
 }
 
+type CommitLoggerOption func(l *hnswCommitLogger)
+
+// WithSyncPolicy decides when the commit log is fsynced and thus whether a
+// change acknowledged by Flush survives a power loss, see the durability
+// package. Without this option the log is never fsynced explicitly.
+func WithSyncPolicy(policy durability.Policy) CommitLoggerOption {
+	return func(l *hnswCommitLogger) {
+		l.syncPolicy = policy
+	}
+}
+
 func NewCommitLogger(rootPath, name string,
-	maintainenceInterval time.Duration,
-	logger logrus.FieldLogger) (*hnswCommitLogger, error) {
+	maintainenceInterval time.Duration, logger logrus.FieldLogger,
+	opts ...CommitLoggerOption) (*hnswCommitLogger, error) {
 	l := &hnswCommitLogger{
 		cancel:               make(chan struct{}),
 		rootPath:             rootPath,
@@ -50,6 +63,11 @@ func NewCommitLogger(rootPath, name string,
 		logger:               logger,
 		maxSizeIndividual:    maxUncondensedCommitLogSize / 5, // TODO: make configurable
 		maxSizeCombining:     maxUncondensedCommitLogSize,     // TODO: make configurable
+		snapshotThreshold:    defaultSnapshotThreshold,
+	}
+
+	for _, opt := range opts {
+		opt(l)
 	}
 
 	fd, err := getLatestCommitFileOrCreate(rootPath, name)
@@ -57,7 +75,7 @@ func NewCommitLogger(rootPath, name string,
 		return nil, err
 	}
 
-	l.commitLogger = commitlog.NewLoggerWithFile(fd)
+	l.useLog(fd)
 	l.StartLogging()
 	return l, nil
 }
@@ -224,6 +242,11 @@ type condensor interface {
 
 type hnswCommitLogger struct {
 	sync.Mutex
+
+	// maintenanceLock prevents the background combining, condensing and
+	// snapshotting from operating on files that are being replaced
+	maintenanceLock sync.Mutex
+
 	cancel               chan struct{}
 	rootPath             string
 	id                   string
@@ -232,7 +255,13 @@ type hnswCommitLogger struct {
 	logger               logrus.FieldLogger
 	maxSizeIndividual    int64
 	maxSizeCombining     int64
+	snapshotThreshold    int64
 	commitLogger         *commitlog.Logger
+
+	// syncer fsyncs the file of the current commitLogger according to the
+	// syncPolicy, it is replaced together with the file
+	syncPolicy durability.Policy
+	syncer     *durability.Syncer
 }
 
 type HnswCommitType uint8 // 256 options, plenty of room for future extensions
@@ -249,6 +278,9 @@ const (
 	ResetIndex
 	ClearLinksAtLevel // added in v1.8.0-rc.1, see https://github.com/semi-technologies/weaviate/issues/1701
 	AddLinksAtLevel   // added in v1.8.0-rc.1, see https://github.com/semi-technologies/weaviate/issues/1705
+	AddPQ
+	SetConstructionParams
+	AddSQ
 )
 
 func (t HnswCommitType) String() string {
@@ -275,6 +307,12 @@ func (t HnswCommitType) String() string {
 		return "ResetIndex"
 	case ClearLinksAtLevel:
 		return "ClearLinksAtLevel"
+	case AddPQ:
+		return "AddProductQuantizer"
+	case SetConstructionParams:
+		return "SetConstructionParams"
+	case AddSQ:
+		return "AddScalarQuantizer"
 	}
 	return "unknown commit type"
 }
@@ -344,6 +382,28 @@ func (l *hnswCommitLogger) DeleteNode(nodeid uint64) error {
 	return l.commitLogger.DeleteNode(nodeid)
 }
 
+func (l *hnswCommitLogger) AddPQ(data compressionhelpers.PQData) error {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.commitLogger.AddPQ(data)
+}
+
+func (l *hnswCommitLogger) AddSQ(data compressionhelpers.SQData) error {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.commitLogger.AddSQ(data)
+}
+
+func (l *hnswCommitLogger) SetConstructionParams(maxConnections,
+	efConstruction int) error {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.commitLogger.SetConstructionParams(maxConnections, efConstruction)
+}
+
 func (l *hnswCommitLogger) Reset() error {
 	l.Lock()
 	defer l.Unlock()
@@ -351,6 +411,104 @@ func (l *hnswCommitLogger) Reset() error {
 	return l.commitLogger.Reset()
 }
 
+// ReplaceState persists state as the complete index, superseding all
+// previous commit logs and snapshots. It is used once a rebuilt graph replaces
+// the current one. State is written as a snapshot covering all existing logs,
+// new writes go to a fresh log. The superseded files are deleted afterwards,
+// failing to do so is logged, but does not fail the replacement, as the
+// snapshot already takes precedence over them.
+func (l *hnswCommitLogger) ReplaceState(state *DeserializationResult) error {
+	l.maintenanceLock.Lock()
+	defer l.maintenanceLock.Unlock()
+
+	l.Lock()
+	defer l.Unlock()
+
+	oldFileName, err := l.commitLogger.FileName()
+	if err != nil {
+		return err
+	}
+
+	covered, err := asTimeStamp(oldFileName)
+	if err != nil {
+		return err
+	}
+
+	if err := l.closeLog(); err != nil {
+		return err
+	}
+
+	// the new log must sort after the covered ones, even if the previous log
+	// was only created within the same second
+	ts := time.Now().Unix()
+	if ts <= covered {
+		ts = covered + 1
+	}
+
+	fd, err := os.OpenFile(commitLogFileName(l.rootPath, l.id, fmt.Sprintf("%d", ts)),
+		os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
+	if err != nil {
+		return errors.Wrap(err, "create commit log file")
+	}
+
+	l.useLog(fd)
+
+	if err := writeSnapshot(snapshotFileName(l.rootPath, l.id, covered),
+		state); err != nil {
+		return errors.Wrap(err, "write snapshot")
+	}
+
+	if err := l.removeSupersededFiles(covered); err != nil {
+		l.logger.WithField("action", "hnsw_replace_state").
+			WithField("id", l.id).
+			WithError(err).
+			Warn("could not remove superseded commit logs")
+	}
+
+	return nil
+}
+
+// removeSupersededFiles deletes all commit logs contained in the snapshot
+// with the given timestamp as well as all older snapshots
+func (l *hnswCommitLogger) removeSupersededFiles(covered int64) error {
+	fileNames, err := getCommitFileNames(l.rootPath, l.id)
+	if err != nil {
+		return err
+	}
+
+	for _, fileName := range fileNames {
+		isCovered, err := snapshotCovers(covered, fileName)
+		if err != nil {
+			return err
+		}
+
+		if !isCovered {
+			continue
+		}
+
+		if err := os.Remove(fileName); err != nil {
+			return errors.Wrap(err, "remove commit log")
+		}
+	}
+
+	timestamps, err := getSnapshotTimestamps(l.rootPath, l.id)
+	if err != nil {
+		return err
+	}
+
+	for _, ts := range timestamps {
+		if ts >= covered {
+			continue
+		}
+
+		if err := os.Remove(snapshotFileName(l.rootPath, l.id, ts)); err != nil {
+			return errors.Wrap(err, "remove old snapshot")
+		}
+	}
+
+	return nil
+}
+
 func (l *hnswCommitLogger) StartLogging() {
 	// switch log job
 	cancelSwitchLog := l.startSwitchLogs()
@@ -408,6 +566,7 @@ func (l *hnswCommitLogger) startCombineAndCondenseLogs() chan struct{} {
 			case <-cancel:
 				return
 			case <-maintenance:
+				l.maintenanceLock.Lock()
 				if err := l.combineLogs(); err != nil {
 					l.logger.WithError(err).
 						WithField("action", "hsnw_commit_log_combining").
@@ -419,6 +578,15 @@ func (l *hnswCommitLogger) startCombineAndCondenseLogs() chan struct{} {
 						WithField("action", "hsnw_commit_log_condensing").
 						Error("hnsw commit log maintenance (condensing) failed")
 				}
+
+				// snapshotting runs in the same routine as combining, so that no
+				// logs can be combined across a snapshot that is being written
+				if err := l.snapshot(); err != nil {
+					l.logger.WithError(err).
+						WithField("action", "hsnw_commit_log_snapshot").
+						Error("hnsw commit log maintenance (snapshot) failed")
+				}
+				l.maintenanceLock.Unlock()
 			}
 		}
 	}(cancelFromOutside)
@@ -444,7 +612,7 @@ func (l *hnswCommitLogger) maintenance() error {
 		return err
 	}
 
-	if err := l.commitLogger.Close(); err != nil {
+	if err := l.closeLog(); err != nil {
 		return err
 	}
 
@@ -464,11 +632,32 @@ func (l *hnswCommitLogger) maintenance() error {
 		return errors.Wrap(err, "create commit log file")
 	}
 
-	l.commitLogger = commitlog.NewLoggerWithFile(fd)
+	l.useLog(fd)
 
 	return nil
 }
 
+// useLog makes fd the current commit log, the previous one must have been
+// closed with closeLog
+func (l *hnswCommitLogger) useLog(fd *os.File) {
+	l.commitLogger = commitlog.NewLoggerWithFile(fd)
+	l.syncer = durability.NewSyncer(l.syncPolicy, fd)
+}
+
+// closeLog flushes the current commit log and, depending on the sync policy,
+// fsyncs it before closing the file
+func (l *hnswCommitLogger) closeLog() error {
+	if err := l.commitLogger.Flush(); err != nil {
+		return err
+	}
+
+	if err := l.syncer.Close(); err != nil {
+		return err
+	}
+
+	return l.commitLogger.Close()
+}
+
 func (l *hnswCommitLogger) condenseOldLogs() error {
 	files, err := getCommitFileNames(l.rootPath, l.id)
 	if err != nil {
@@ -506,8 +695,14 @@ func (l *hnswCommitLogger) combineLogs() error {
 	return NewCommitLogCombiner(l.rootPath, l.id, threshold, l.logger).Do()
 }
 
+func (l *hnswCommitLogger) snapshot() error {
+	_, err := NewCommitLogSnapshotter(l.rootPath, l.id, l.snapshotThreshold,
+		l.logger).Do()
+	return err
+}
+
 func (l *hnswCommitLogger) Drop() error {
-	if err := l.commitLogger.Close(); err != nil {
+	if err := l.closeLog(); err != nil {
 		return errors.Wrap(err, "close hnsw commit logger prior to delete")
 	}
 
@@ -521,12 +716,29 @@ func (l *hnswCommitLogger) Drop() error {
 			return errors.Wrap(err, "delete commit files directory")
 		}
 	}
+	// remove snapshot directory if exists
+	dir = snapshotDirectory(l.rootPath, l.id)
+	if _, err := os.Stat(dir); err == nil {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			return errors.Wrap(err, "delete snapshot directory")
+		}
+	}
 	return nil
 }
 
+// Flush writes the buffered changes to the current log and, depending on the
+// sync policy, fsyncs it. The fsync happens outside of the lock, so that
+// changes of concurrent writers can share it. Should the log be switched in
+// the meantime, closing the old log has already fsynced it.
 func (l *hnswCommitLogger) Flush() error {
 	l.Lock()
-	defer l.Unlock()
+	err := l.commitLogger.Flush()
+	syncer := l.syncer
+	l.Unlock()
+	if err != nil {
+		return err
+	}
 
-	return l.commitLogger.Flush()
+	return syncer.Commit()
 }
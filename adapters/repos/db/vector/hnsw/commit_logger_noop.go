@@ -11,6 +11,8 @@
 
 package hnsw
 
+import "github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
+
 // NoopCommitLogger implements the CommitLogger interface, but does not
 // actually write anything to disk
 type NoopCommitLogger struct{}
@@ -55,6 +57,23 @@ func (n *NoopCommitLogger) ClearLinksAtLevel(nodeid uint64, level uint16) error
 	return nil
 }
 
+func (n *NoopCommitLogger) AddPQ(data compressionhelpers.PQData) error {
+	return nil
+}
+
+func (n *NoopCommitLogger) AddSQ(data compressionhelpers.SQData) error {
+	return nil
+}
+
+func (n *NoopCommitLogger) SetConstructionParams(maxConnections,
+	efConstruction int) error {
+	return nil
+}
+
+func (n *NoopCommitLogger) ReplaceState(state *DeserializationResult) error {
+	return nil
+}
+
 func (n *NoopCommitLogger) Reset() error {
 	return nil
 }
@@ -13,9 +13,11 @@ package commitlog
 
 import (
 	"encoding/binary"
+	"math"
 	"os"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 )
 
 type Logger struct {
@@ -39,6 +41,9 @@ const (
 	ResetIndex
 	ClearLinksAtLevel // added in v1.8.0-rc.1, see https://github.com/semi-technologies/weaviate/issues/1701
 	AddLinksAtLevel   // added in v1.8.0-rc.1, see https://github.com/semi-technologies/weaviate/issues/1705
+	AddPQ
+	SetConstructionParams
+	AddSQ
 )
 
 func NewLogger(fileName string) *Logger {
@@ -185,6 +190,52 @@ func (l *Logger) DeleteNode(id uint64) error {
 	return err
 }
 
+// AddPQ persists the codebooks of a trained product quantizer, the vectors
+// themselves are not part of the commit log, they are re-encoded on demand
+func (l *Logger) AddPQ(data compressionhelpers.PQData) error {
+	toWrite := make([]byte, 7)
+	toWrite[0] = byte(AddPQ)
+	binary.LittleEndian.PutUint16(toWrite[1:3], data.Dimensions)
+	binary.LittleEndian.PutUint16(toWrite[3:5], data.Segments)
+	binary.LittleEndian.PutUint16(toWrite[5:7], data.Centroids)
+	for _, centroid := range data.Codebooks {
+		for _, v := range centroid {
+			toWrite = append(toWrite, 0, 0, 0, 0)
+			binary.LittleEndian.PutUint32(toWrite[len(toWrite)-4:], math.Float32bits(v))
+		}
+	}
+	_, err := l.bufw.Write(toWrite)
+	return err
+}
+
+// AddSQ persists a fitted scalar quantizer, the value ranges of int8 are
+// needed to decode the codes persisted next to the commit log
+func (l *Logger) AddSQ(data compressionhelpers.SQData) error {
+	toWrite := make([]byte, 4)
+	toWrite[0] = byte(AddSQ)
+	toWrite[1] = data.Kind
+	binary.LittleEndian.PutUint16(toWrite[2:4], data.Dimensions)
+	for _, values := range [][]float32{data.Mins, data.Scales} {
+		for _, v := range values {
+			toWrite = append(toWrite, 0, 0, 0, 0)
+			binary.LittleEndian.PutUint32(toWrite[len(toWrite)-4:], math.Float32bits(v))
+		}
+	}
+	_, err := l.bufw.Write(toWrite)
+	return err
+}
+
+// SetConstructionParams records the maxConnections and efConstruction the
+// graph is built with
+func (l *Logger) SetConstructionParams(maxConnections, efConstruction int) error {
+	toWrite := make([]byte, 9)
+	toWrite[0] = byte(SetConstructionParams)
+	binary.LittleEndian.PutUint32(toWrite[1:5], uint32(maxConnections))
+	binary.LittleEndian.PutUint32(toWrite[5:9], uint32(efConstruction))
+	_, err := l.bufw.Write(toWrite)
+	return err
+}
+
 func (l *Logger) Reset() error {
 	toWrite := make([]byte, 1)
 	toWrite[0] = byte(ResetIndex)
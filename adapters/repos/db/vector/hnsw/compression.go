@@ -0,0 +1,437 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// rescoreFactor is the multiple of k that is retrieved using the compressed
+// vectors before the candidates are rescored using the full vectors
+const rescoreFactor = 4
+
+var errNotEnoughVectorsToCompress = errors.New("not enough vectors to train " +
+	"quantizer")
+
+// sqMinTrainingVectors is the minimum number of vectors required to learn the
+// value ranges of an int8 scalar quantizer. Ranges learned on fewer vectors
+// would clamp too many of the vectors imported later.
+const sqMinTrainingVectors = 100
+
+func (h *hnsw) isCompressed() bool {
+	return atomic.LoadInt32(&h.compressed) == 1
+}
+
+func (h *hnsw) isCompressionPending() bool {
+	return atomic.LoadInt32(&h.compressPending) == 1
+}
+
+// shouldRescore indicates whether the final candidates of a search are
+// rescored using the full vectors
+func (h *hnsw) shouldRescore() bool {
+	return h.isCompressed() && atomic.LoadInt32(&h.rescoreCompressed) == 1
+}
+
+func rescoreCompressedFromConfig(uc UserConfig) int32 {
+	if uc.SQ.Enabled && !uc.SQ.Rescore {
+		return 0
+	}
+
+	return 1
+}
+
+// startCompression trains the product quantizer in the background, as
+// training on a large sample can take considerable time. The index stays fully
+// usable on the uncompressed vectors in the meantime.
+func (h *hnsw) startCompression(cfg PQConfig) {
+	h.startQuantization(func() error { return h.compress(cfg) },
+		cfg.TrainingLimit)
+}
+
+// startScalarQuantization switches the index to scalar quantized vectors in
+// the background. Float16 quantization only needs to know the dimensions of
+// the vectors, int8 quantization first learns the value range of every
+// dimension.
+func (h *hnsw) startScalarQuantization(cfg SQConfig) {
+	h.startQuantization(func() error { return h.compressScalar(cfg) },
+		sqRequiredVectors(cfg))
+}
+
+// startQuantization runs train in the background. If there are not enough
+// vectors to train on yet, the attempt is repeated after retryAfter more
+// inserts.
+func (h *hnsw) startQuantization(train func() error, retryAfter int) {
+	go func() {
+		before := time.Now()
+		err := train()
+		if err == nil {
+			h.logger.WithField("action", "hnsw_compress").
+				WithField("index_id", h.id).
+				WithField("took", time.Since(before)).
+				Info("switched vector index to compressed vectors")
+			return
+		}
+
+		if errors.Is(err, errNotEnoughVectorsToCompress) {
+			h.logger.WithField("action", "hnsw_compress").
+				WithField("index_id", h.id).
+				WithField("retry_after_inserts", retryAfter).
+				Info("not enough vectors to train quantizer, " +
+					"compression will be retried once more vectors are imported")
+			h.deferCompression(train, retryAfter)
+			return
+		}
+
+		h.logger.WithField("action", "hnsw_compress").
+			WithField("index_id", h.id).
+			WithError(err).
+			Error("compress vector index")
+	}()
+}
+
+// deferCompression makes sure compression is retried after the next
+// retryAfter inserts. This is the case if compression was enabled on a
+// (nearly) empty index.
+func (h *hnsw) deferCompression(train func() error, retryAfter int) {
+	h.compressLock.Lock()
+	h.pendingCompression = train
+	h.pendingCompressionInserts = retryAfter
+	h.compressLock.Unlock()
+
+	atomic.StoreInt64(&h.insertsSinceCompressAttempt, 0)
+	atomic.StoreInt32(&h.compressPending, 1)
+}
+
+// compressIfDue is called after every insert and is a no-op unless a deferred
+// compression is waiting for more vectors to be imported
+func (h *hnsw) compressIfDue() {
+	if !h.isCompressionPending() {
+		return
+	}
+
+	inserts := atomic.AddInt64(&h.insertsSinceCompressAttempt, 1)
+
+	h.compressLock.Lock()
+	train := h.pendingCompression
+	retryAfter := h.pendingCompressionInserts
+	h.compressLock.Unlock()
+
+	if inserts < int64(retryAfter) {
+		return
+	}
+
+	if atomic.CompareAndSwapInt32(&h.compressPending, 1, 0) {
+		h.startQuantization(train, retryAfter)
+	}
+}
+
+// compress trains a product quantizer on a sample of the vectors already
+// present in the index, persists its codebooks in the commit log and switches
+// the index over to the compressed vectors. From then on the graph is
+// traversed using the codes and only the final candidates are rescored using
+// the full vectors.
+func (h *hnsw) compress(cfg PQConfig) error {
+	h.compressLock.Lock()
+	defer h.compressLock.Unlock()
+
+	if h.isCompressed() {
+		return nil
+	}
+
+	sample, err := h.pqTrainingSample(cfg.TrainingLimit)
+	if err != nil {
+		return errors.Wrap(err, "sample training vectors")
+	}
+
+	if len(sample) < cfg.Centroids {
+		return errNotEnoughVectorsToCompress
+	}
+
+	dims := len(sample[0])
+	segments := cfg.Segments
+	if segments == 0 {
+		segments = defaultPQSegments(dims)
+	}
+
+	pq, err := compressionhelpers.NewProductQuantizer(segments, cfg.Centroids,
+		h.distancerProvider, dims)
+	if err != nil {
+		return errors.Wrap(err, "init product quantizer")
+	}
+
+	if err := pq.Fit(sample); err != nil {
+		return errors.Wrap(err, "train product quantizer")
+	}
+
+	if err := h.commitLog.AddPQ(pq.ExposeFields()); err != nil {
+		return errors.Wrap(err, "persist product quantizer")
+	}
+
+	h.switchToCompressed(pq, nil)
+
+	// the full vectors are no longer needed in memory, from now on they are
+	// only read from disk to rescore the final candidates
+	h.cache.purge()
+
+	return nil
+}
+
+// compressScalar fits a scalar quantizer, persists it in the commit log and
+// switches the index over to the scalar quantized vectors. Unlike those of
+// the product quantizer, the codes are persisted as well, see codeStore. The
+// codes of the vectors already present are written in the background.
+func (h *hnsw) compressScalar(cfg SQConfig) error {
+	h.compressLock.Lock()
+	defer h.compressLock.Unlock()
+
+	if h.isCompressed() {
+		return nil
+	}
+
+	limit := cfg.TrainingLimit
+	if cfg.Type == compressionhelpers.ScalarQuantizationFloat16 {
+		// only the dimensions need to be known
+		limit = 1
+	}
+
+	sample, err := h.pqTrainingSample(limit)
+	if err != nil {
+		return errors.Wrap(err, "sample training vectors")
+	}
+
+	if len(sample) == 0 || len(sample) < sqRequiredVectors(cfg) {
+		return errNotEnoughVectorsToCompress
+	}
+
+	sq, err := compressionhelpers.NewScalarQuantizer(cfg.Type,
+		h.distancerProvider, len(sample[0]))
+	if err != nil {
+		return errors.Wrap(err, "init scalar quantizer")
+	}
+
+	if err := sq.Fit(sample); err != nil {
+		return errors.Wrap(err, "train scalar quantizer")
+	}
+
+	// the store is emptied before the quantizer is persisted, so that it never
+	// contains codes of an earlier quantizer
+	codes, err := openCodeStore(codeStorePath(h.rootPath, h.id),
+		sq.CodeLength(), true)
+	if err != nil {
+		return err
+	}
+
+	if err := h.commitLog.AddSQ(sq.ExposeFields()); err != nil {
+		codes.drop()
+		return errors.Wrap(err, "persist scalar quantizer")
+	}
+
+	h.switchToCompressed(sq, codes)
+	h.cache.purge()
+
+	return h.encodeAll(codes)
+}
+
+// encodeAll persists the codes of all vectors which don't have one yet.
+// Vectors inserted in the meantime are persisted by the insert itself.
+func (h *hnsw) encodeAll(codes *codeStore) error {
+	ids := h.nodeIDs()
+
+	for _, id := range ids {
+		if _, ok, err := codes.get(id); err != nil {
+			return err
+		} else if ok {
+			continue
+		}
+
+		vec, err := h.fullVectorForID(context.Background(), id)
+		if err != nil {
+			var e storobj.ErrNotFound
+			if errors.As(err, &e) {
+				continue
+			}
+			return errors.Wrapf(err, "get vector of docID %d", id)
+		}
+
+		if err := codes.put(id, h.quantizer.Encode(vec)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sqRequiredVectors is the number of vectors that need to be present before
+// scalar quantization can be started
+func sqRequiredVectors(cfg SQConfig) int {
+	if cfg.Type == compressionhelpers.ScalarQuantizationFloat16 {
+		return 1
+	}
+
+	if cfg.TrainingLimit < sqMinTrainingVectors {
+		return cfg.TrainingLimit
+	}
+
+	return sqMinTrainingVectors
+}
+
+// switchToCompressed installs the compressed cache. It holds the graph lock,
+// so that no concurrent insert can grow the index without also growing the
+// compressed cache. codes is nil if the codes are not persisted.
+func (h *hnsw) switchToCompressed(q compressionhelpers.Quantizer,
+	codes *codeStore) {
+	h.Lock()
+	defer h.Unlock()
+
+	cache := newCompressedShardedLockCache(h.vectorForIDThunk, q, codes,
+		int(h.cache.copyMaxSize()), h.cacheBudget, h.logger,
+		h.distancerProvider.Type() == "cosine-dot")
+	cache.grow(uint64(len(h.nodes)))
+
+	if pq, ok := q.(*compressionhelpers.ProductQuantizer); ok {
+		h.pq = pq
+	}
+	h.quantizer = q
+	h.compressedVectorsCache = cache
+	atomic.StoreInt32(&h.compressed, 1)
+}
+
+// pqTrainingSample returns up to limit randomly chosen vectors of the
+// (non-deleted) nodes in the index
+func (h *hnsw) pqTrainingSample(limit int) ([][]float32, error) {
+	h.Lock()
+	ids := make([]uint64, 0, len(h.nodes))
+	for _, node := range h.nodes {
+		if node != nil {
+			ids = append(ids, node.id)
+		}
+	}
+	h.Unlock()
+
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+
+	sample := make([][]float32, 0, limit)
+	for _, id := range ids {
+		if len(sample) >= limit {
+			break
+		}
+
+		if h.hasTombstone(id) {
+			continue
+		}
+
+		vec, err := h.cache.get(context.Background(), id)
+		if err != nil {
+			var e storobj.ErrNotFound
+			if errors.As(err, &e) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "get vector of docID %d", id)
+		}
+
+		if len(sample) > 0 && len(vec) != len(sample[0]) {
+			return nil, errors.Errorf("vector of docID %d has %d dimensions, "+
+				"expected %d", id, len(vec), len(sample[0]))
+		}
+
+		sample = append(sample, vec)
+	}
+
+	return sample, nil
+}
+
+// defaultPQSegments picks segments of 4 dimensions each if possible, which
+// compresses each vector by a factor of 16. If the dimensions are not
+// divisible by 4, smaller segments are used.
+func defaultPQSegments(dims int) int {
+	for _, ds := range []int{4, 3, 2} {
+		if dims%ds == 0 {
+			return dims / ds
+		}
+	}
+
+	return dims
+}
+
+// fullVectorForID bypasses the caches and reads the uncompressed vector from
+// the underlying store
+func (h *hnsw) fullVectorForID(ctx context.Context, id uint64) ([]float32, error) {
+	vec, err := h.vectorForIDThunk(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.distancerProvider.Type() == "cosine-dot" {
+		vec = distancer.Normalize(vec)
+	}
+
+	return vec, nil
+}
+
+// nodeVector returns the full vector of a node, on an uncompressed index it is
+// served from the vector cache
+func (h *hnsw) nodeVector(ctx context.Context, id uint64) ([]float32, error) {
+	if h.isCompressed() {
+		return h.fullVectorForID(ctx, id)
+	}
+
+	return h.vectorForID(ctx, id)
+}
+
+// rescore recalculates the distances of the candidates in res using the full
+// vectors and returns the closest k in ascending order. res is drained in the
+// process.
+func (h *hnsw) rescore(queryVector []float32, res *priorityqueue.Queue,
+	k int) ([]uint64, []float32, error) {
+	rescored := priorityqueue.NewMax(k)
+	for res.Len() > 0 {
+		cand := res.Pop()
+		vec, err := h.fullVectorForID(context.Background(), cand.ID)
+		if err != nil {
+			var e storobj.ErrNotFound
+			if errors.As(err, &e) {
+				h.handleDeletedNode(e.DocID)
+				continue
+			}
+			return nil, nil, errors.Wrapf(err, "get vector of docID %d", cand.ID)
+		}
+
+		dist, _, err := h.distancerProvider.SingleDist(queryVector, vec)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "rescore candidate")
+		}
+
+		rescored.Insert(cand.ID, dist)
+		if rescored.Len() > k {
+			rescored.Pop()
+		}
+	}
+
+	ids := make([]uint64, rescored.Len())
+	dists := make([]float32, rescored.Len())
+	for i := len(ids) - 1; i >= 0; i-- {
+		item := rescored.Pop()
+		ids[i] = item.ID
+		dists[i] = item.Dist
+	}
+
+	return ids, dists, nil
+}
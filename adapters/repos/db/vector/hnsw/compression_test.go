@@ -0,0 +1,549 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/commitlog"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomCompressionTestVectors(r *rand.Rand, count, dims int) [][]float32 {
+	out := make([][]float32, count)
+	for i := range out {
+		vec := make([]float32, dims)
+		for j := range vec {
+			vec[j] = r.Float32()*2 - 1
+		}
+		out[i] = vec
+	}
+	return out
+}
+
+func bruteForceWithProvider(provider distancer.Provider, vectors [][]float32,
+	query []float32, k int) []uint64 {
+	ids := make([]uint64, len(vectors))
+	dists := make([]float32, len(vectors))
+	for i, vec := range vectors {
+		ids[i] = uint64(i)
+		dists[i], _, _ = provider.SingleDist(query, vec)
+	}
+
+	sort.Slice(ids, func(a, b int) bool {
+		return dists[ids[a]] < dists[ids[b]]
+	})
+
+	return ids[:k]
+}
+
+func compressionTestIndex(t *testing.T, provider distancer.Provider,
+	vectors *[][]float32) *hnsw {
+	index, err := New(Config{
+		RootPath:              t.TempDir(),
+		ID:                    "compression-test",
+		MakeCommitLoggerThunk: MakeNoopCommitLogger,
+		DistanceProvider:      provider,
+		VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
+			return (*vectors)[int(id)], nil
+		},
+	}, UserConfig{
+		MaxConnections:        30,
+		EFConstruction:        64,
+		EF:                    64,
+		VectorCacheMaxObjects: 1e6,
+	})
+	require.Nil(t, err)
+	return index
+}
+
+func TestCompression_Search(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	dims := 32
+	k := 10
+	vectors := randomCompressionTestVectors(r, 1000, dims)
+	queries := randomCompressionTestVectors(r, 20, dims)
+
+	providers := []distancer.Provider{
+		distancer.NewL2SquaredProvider(),
+		distancer.NewCosineDistanceProvider(),
+	}
+
+	for _, provider := range providers {
+		t.Run(provider.Type(), func(t *testing.T) {
+			index := compressionTestIndex(t, provider, &vectors)
+			for i, vec := range vectors {
+				require.Nil(t, index.Add(uint64(i), vec))
+			}
+
+			require.Nil(t, index.compress(PQConfig{
+				Enabled:       true,
+				Centroids:     64,
+				TrainingLimit: 1000,
+			}))
+			require.True(t, index.isCompressed())
+			assert.Equal(t, 8, index.pq.Segments())
+
+			normalized := vectors
+			if provider.Type() == "cosine-dot" {
+				normalized = make([][]float32, len(vectors))
+				for i := range vectors {
+					normalized[i] = distancer.Normalize(vectors[i])
+				}
+			}
+
+			hits := 0
+			for _, query := range queries {
+				res, dists, err := index.SearchByVector(query, k, nil)
+				require.Nil(t, err)
+				require.Len(t, res, k)
+				assert.True(t, sort.SliceIsSorted(dists, func(a, b int) bool {
+					return dists[a] < dists[b]
+				}), "results must be sorted by their rescored distance")
+
+				if provider.Type() == "cosine-dot" {
+					query = distancer.Normalize(query)
+				}
+
+				// rescoring must return the exact distances
+				expected, _, err := provider.SingleDist(query, normalized[res[0]])
+				require.Nil(t, err)
+				assert.InDelta(t, expected, dists[0], 1e-5)
+
+				truth := bruteForceWithProvider(provider, normalized, query, k)
+				hits += matchesInTruth(truth, res)
+			}
+
+			recall := float32(hits) / float32(k*len(queries))
+			assert.GreaterOrEqual(t, recall, float32(0.9))
+		})
+	}
+}
+
+func TestCompression_InsertDeleteAndFlatSearchAfterCompressing(t *testing.T) {
+	r := rand.New(rand.NewSource(23))
+	dims := 16
+	vectors := randomCompressionTestVectors(r, 600, dims)
+	provider := distancer.NewL2SquaredProvider()
+	index := compressionTestIndex(t, provider, &vectors)
+
+	for i, vec := range vectors[:300] {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	require.Nil(t, index.compress(PQConfig{
+		Enabled:       true,
+		Segments:      8,
+		Centroids:     64,
+		TrainingLimit: 300,
+	}))
+	require.True(t, index.isCompressed())
+
+	t.Run("import more vectors into the compressed index", func(t *testing.T) {
+		for i, vec := range vectors[300:] {
+			require.Nil(t, index.Add(uint64(i+300), vec))
+		}
+	})
+
+	t.Run("newly imported vectors can be found", func(t *testing.T) {
+		for _, id := range []uint64{300, 450, 599} {
+			res, _, err := index.SearchByVector(vectors[id], 1, nil)
+			require.Nil(t, err)
+			assert.Equal(t, []uint64{id}, res)
+		}
+	})
+
+	t.Run("delete every even vector", func(t *testing.T) {
+		for i := range vectors {
+			if i%2 == 0 {
+				require.Nil(t, index.Delete(uint64(i)))
+			}
+		}
+		require.Nil(t, index.CleanUpTombstonedNodes())
+
+		for _, id := range []uint64{1, 301, 599} {
+			res, _, err := index.SearchByVector(vectors[id], 5, nil)
+			require.Nil(t, err)
+			require.True(t, len(res) > 0)
+			assert.Equal(t, id, res[0])
+			for _, elem := range res {
+				assert.True(t, elem%2 == 1, "result must not contain deleted ids")
+			}
+		}
+	})
+
+	t.Run("flat search with an allow list", func(t *testing.T) {
+		// make sure the allow list is small enough to not be served by the graph
+		atomic.StoreInt64(&index.flatSearchCutoff, 1000)
+
+		allowList := helpers.AllowList{}
+		for i := 1; i < 100; i += 2 {
+			allowList.Insert(uint64(i))
+		}
+
+		res, dists, err := index.SearchByVector(vectors[51], 3, allowList)
+		require.Nil(t, err)
+		require.Len(t, res, 3)
+		assert.Equal(t, uint64(51), res[0])
+		assert.Equal(t, float32(0), dists[0])
+
+		truth := bruteForceWithProvider(provider, vectors, vectors[51], len(vectors))
+		var expected []uint64
+		for _, id := range truth {
+			if allowList.Contains(id) {
+				expected = append(expected, id)
+			}
+		}
+		assert.Equal(t, expected[:3], res)
+	})
+}
+
+func TestCompression_EnableThroughUserConfigUpdate(t *testing.T) {
+	r := rand.New(rand.NewSource(29))
+	vectors := randomCompressionTestVectors(r, 300, 8)
+	index := compressionTestIndex(t, distancer.NewL2SquaredProvider(), &vectors)
+
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	uc := NewDefaultUserConfig()
+	uc.PQ = PQConfig{
+		Enabled:       true,
+		Centroids:     16,
+		TrainingLimit: 300,
+	}
+	require.Nil(t, index.UpdateUserConfig(uc))
+
+	assert.Eventually(t, index.isCompressed, 5*time.Second, 10*time.Millisecond)
+
+	res, _, err := index.SearchByVector(vectors[42], 1, nil)
+	require.Nil(t, err)
+	assert.Equal(t, []uint64{42}, res)
+}
+
+func TestCompression_DeferredUntilEnoughVectors(t *testing.T) {
+	r := rand.New(rand.NewSource(31))
+	vectors := randomCompressionTestVectors(r, 200, 8)
+	index := compressionTestIndex(t, distancer.NewL2SquaredProvider(), &vectors)
+
+	for i, vec := range vectors[:10] {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	uc := NewDefaultUserConfig()
+	uc.PQ = PQConfig{
+		Enabled:       true,
+		Centroids:     16,
+		TrainingLimit: 100,
+	}
+	require.Nil(t, index.UpdateUserConfig(uc))
+
+	assert.Eventually(t, func() bool {
+		return index.isCompressionPending()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, index.isCompressed())
+
+	for i, vec := range vectors[10:] {
+		require.Nil(t, index.Add(uint64(i+10), vec))
+	}
+
+	assert.Eventually(t, index.isCompressed, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCompression_CommitLog(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	data := randomCompressionTestVectors(r, 100, 12)
+	pq, err := compressionhelpers.NewProductQuantizer(3, 8,
+		distancer.NewL2SquaredProvider(), 12)
+	require.Nil(t, err)
+	require.Nil(t, pq.Fit(data))
+	expected := pq.ExposeFields()
+
+	logger, _ := test.NewNullLogger()
+	fileName := filepath.Join(t.TempDir(), "commitlog")
+
+	l := commitlog.NewLogger(fileName)
+	require.Nil(t, l.AddNode(3, 0))
+	require.Nil(t, l.AddPQ(expected))
+	require.Nil(t, l.SetEntryPointWithMaxLayer(3, 0))
+	require.Nil(t, l.Close())
+
+	read := func(t *testing.T, fileName string) *DeserializationResult {
+		fd, err := os.Open(fileName)
+		require.Nil(t, err)
+		defer fd.Close()
+
+		res, _, err := NewDeserializer2(logger).Do(bufio.NewReader(fd), nil, false)
+		require.Nil(t, err)
+		return res
+	}
+
+	t.Run("deserialize the product quantizer", func(t *testing.T) {
+		res := read(t, fileName)
+		require.True(t, res.Compressed)
+		assert.Equal(t, expected, res.PQData)
+		assert.Equal(t, uint64(3), res.Entrypoint)
+
+		restored, err := compressionhelpers.RestoreProductQuantizer(res.PQData,
+			distancer.NewL2SquaredProvider())
+		require.Nil(t, err)
+		for _, vec := range data[:10] {
+			assert.Equal(t, pq.Encode(vec), restored.Encode(vec))
+		}
+	})
+
+	t.Run("the condensor keeps the product quantizer", func(t *testing.T) {
+		require.Nil(t, NewMemoryCondensor2(logger).Do(fileName))
+
+		res := read(t, fileName+".condensed")
+		require.True(t, res.Compressed)
+		assert.Equal(t, expected, res.PQData)
+	})
+}
+
+func TestScalarQuantization_CommitLog(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	data := randomCompressionTestVectors(r, 100, 12)
+	sq, err := compressionhelpers.NewScalarQuantizer(
+		compressionhelpers.ScalarQuantizationInt8,
+		distancer.NewL2SquaredProvider(), 12)
+	require.Nil(t, err)
+	require.Nil(t, sq.Fit(data))
+	expected := sq.ExposeFields()
+
+	logger, _ := test.NewNullLogger()
+	fileName := filepath.Join(t.TempDir(), "commitlog")
+
+	l := commitlog.NewLogger(fileName)
+	require.Nil(t, l.AddNode(3, 0))
+	require.Nil(t, l.AddSQ(expected))
+	require.Nil(t, l.SetEntryPointWithMaxLayer(3, 0))
+	require.Nil(t, l.Close())
+
+	read := func(t *testing.T, fileName string) *DeserializationResult {
+		fd, err := os.Open(fileName)
+		require.Nil(t, err)
+		defer fd.Close()
+
+		res, _, err := NewDeserializer2(logger).Do(bufio.NewReader(fd), nil, false)
+		require.Nil(t, err)
+		return res
+	}
+
+	t.Run("deserialize the scalar quantizer", func(t *testing.T) {
+		res := read(t, fileName)
+		require.True(t, res.ScalarQuantized)
+		assert.False(t, res.Compressed)
+		assert.Equal(t, expected, res.SQData)
+		assert.Equal(t, uint64(3), res.Entrypoint)
+
+		restored, err := compressionhelpers.RestoreScalarQuantizer(res.SQData,
+			distancer.NewL2SquaredProvider())
+		require.Nil(t, err)
+		for _, vec := range data[:10] {
+			assert.Equal(t, sq.Encode(vec), restored.Encode(vec))
+		}
+	})
+
+	t.Run("the condensor keeps the scalar quantizer", func(t *testing.T) {
+		require.Nil(t, NewMemoryCondensor2(logger).Do(fileName))
+
+		res := read(t, fileName+".condensed")
+		require.True(t, res.ScalarQuantized)
+		assert.Equal(t, expected, res.SQData)
+	})
+}
+
+func TestScalarQuantization_Search(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	dims := 64
+	k := 10
+	vectors := randomCompressionTestVectors(r, 1000, dims)
+	queries := randomCompressionTestVectors(r, 20, dims)
+
+	providers := []distancer.Provider{
+		distancer.NewL2SquaredProvider(),
+		distancer.NewCosineDistanceProvider(),
+	}
+
+	configs := []struct {
+		cfg SQConfig
+		// the memory of a cached entry compared to the full vector
+		maxSizeRatio float32
+	}{
+		{
+			cfg: SQConfig{
+				Enabled: true, Type: compressionhelpers.ScalarQuantizationFloat16,
+				Rescore: true, TrainingLimit: 1000,
+			},
+			maxSizeRatio: 0.6,
+		},
+		{
+			cfg: SQConfig{
+				Enabled: true, Type: compressionhelpers.ScalarQuantizationFloat16,
+				Rescore: false, TrainingLimit: 1000,
+			},
+			maxSizeRatio: 0.6,
+		},
+		{
+			cfg: SQConfig{
+				Enabled: true, Type: compressionhelpers.ScalarQuantizationInt8,
+				Rescore: true, TrainingLimit: 1000,
+			},
+			maxSizeRatio: 0.35,
+		},
+		{
+			cfg: SQConfig{
+				Enabled: true, Type: compressionhelpers.ScalarQuantizationInt8,
+				Rescore: false, TrainingLimit: 1000,
+			},
+			maxSizeRatio: 0.35,
+		},
+	}
+
+	for _, provider := range providers {
+		for _, test := range configs {
+			name := fmt.Sprintf("%s/%s/rescore=%t", provider.Type(),
+				test.cfg.Type, test.cfg.Rescore)
+			t.Run(name, func(t *testing.T) {
+				index := compressionTestIndex(t, provider, &vectors)
+				for i, vec := range vectors {
+					require.Nil(t, index.Add(uint64(i), vec))
+				}
+
+				before := index.CacheStats()
+				require.Greater(t, before.Entries, int64(0))
+
+				atomic.StoreInt32(&index.rescoreCompressed,
+					rescoreCompressedFromConfig(UserConfig{SQ: test.cfg}))
+				require.Nil(t, index.compressScalar(test.cfg))
+				require.True(t, index.isCompressed())
+				assert.Nil(t, index.pq, "pq is only set for product quantization")
+
+				normalized := vectors
+				if provider.Type() == "cosine-dot" {
+					normalized = make([][]float32, len(vectors))
+					for i := range vectors {
+						normalized[i] = distancer.Normalize(vectors[i])
+					}
+				}
+
+				hits := 0
+				for _, query := range queries {
+					res, dists, err := index.SearchByVector(query, k, nil)
+					require.Nil(t, err)
+					require.Len(t, res, k)
+
+					if provider.Type() == "cosine-dot" {
+						query = distancer.Normalize(query)
+					}
+
+					expected, _, err := provider.SingleDist(query, normalized[res[0]])
+					require.Nil(t, err)
+					if test.cfg.Rescore {
+						assert.InDelta(t, expected, dists[0], 1e-5)
+					} else {
+						// without rescoring the distances are approximate
+						assert.InDelta(t, expected, dists[0], 0.05)
+					}
+
+					truth := bruteForceWithProvider(provider, normalized, query, k)
+					hits += matchesInTruth(truth, res)
+				}
+
+				recall := float32(hits) / float32(k*len(queries))
+				assert.GreaterOrEqual(t, recall, float32(0.9))
+
+				after := index.CacheStats()
+				require.Greater(t, after.Entries, int64(0))
+				sizeRatio := (float32(after.SizeBytes) / float32(after.Entries)) /
+					(float32(before.SizeBytes) / float32(before.Entries))
+				assert.Less(t, sizeRatio, test.maxSizeRatio)
+			})
+		}
+	}
+}
+
+func TestScalarQuantization_EnableThroughUserConfigUpdate(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	vectors := randomCompressionTestVectors(r, 300, 8)
+	index := compressionTestIndex(t, distancer.NewL2SquaredProvider(), &vectors)
+
+	for i, vec := range vectors[:50] {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	uc := NewDefaultUserConfig()
+	uc.SQ = SQConfig{
+		Enabled:       true,
+		Type:          compressionhelpers.ScalarQuantizationInt8,
+		Rescore:       false,
+		TrainingLimit: 1000,
+	}
+	require.Nil(t, index.UpdateUserConfig(uc))
+	assert.False(t, index.shouldRescore())
+
+	t.Run("int8 waits for enough training vectors", func(t *testing.T) {
+		assert.Eventually(t, index.isCompressionPending, 5*time.Second,
+			10*time.Millisecond)
+		assert.False(t, index.isCompressed())
+
+		for i, vec := range vectors[50:] {
+			require.Nil(t, index.Add(uint64(i+50), vec))
+		}
+
+		assert.Eventually(t, index.isCompressed, 5*time.Second, 10*time.Millisecond)
+		assert.False(t, index.shouldRescore())
+	})
+
+	t.Run("rescoring can be toggled", func(t *testing.T) {
+		uc.SQ.Rescore = true
+		require.Nil(t, index.UpdateUserConfig(uc))
+		assert.True(t, index.shouldRescore())
+
+		res, dists, err := index.SearchByVector(vectors[42], 1, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{42}, res)
+		assert.Equal(t, float32(0), dists[0])
+	})
+}
+
+func matchesInTruth(truth []uint64, results []uint64) int {
+	desired := map[uint64]struct{}{}
+	for _, relevant := range truth {
+		desired[relevant] = struct{}{}
+	}
+
+	var matches int
+	for _, candidate := range results {
+		if _, ok := desired[candidate]; ok {
+			matches++
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,191 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package compressionhelpers
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+)
+
+const (
+	// DefaultKMeansIterationThreshold is the maximum number of lloyd iterations
+	// a single Fit will run
+	DefaultKMeansIterationThreshold = 100
+
+	// DefaultKMeansDeltaThreshold stops the iterations early once less than
+	// this fraction of points changed their assigned centroid in a single round
+	DefaultKMeansDeltaThreshold = 0.01
+)
+
+// KMeans clusters a single segment of the input vectors. A segment is the
+// range [segment*dimensions, (segment+1)*dimensions) of each vector, this way
+// the product quantizer can run one KMeans per segment on the same input
+// without having to copy the subvectors first.
+type KMeans struct {
+	K                  int
+	IterationThreshold int
+	DeltaThreshold     float32
+
+	dimensions int
+	segment    int
+	centers    [][]float32
+	distance   distancer.Provider
+	rand       *rand.Rand
+}
+
+func NewKMeans(k int, dimensions int, segment int) *KMeans {
+	return &KMeans{
+		K:                  k,
+		IterationThreshold: DefaultKMeansIterationThreshold,
+		DeltaThreshold:     DefaultKMeansDeltaThreshold,
+		dimensions:         dimensions,
+		segment:            segment,
+		// centroids are always assigned using euclidean distance, regardless of
+		// the distance metric of the index. This is what minimizes the
+		// reconstruction error of the encoded vectors.
+		distance: distancer.NewL2SquaredProvider(),
+		// a fixed seed makes training reproducible for the same input
+		rand: rand.New(rand.NewSource(int64(segment) + 1)),
+	}
+}
+
+// NewKMeansWithCenters restores a previously fitted KMeans, e.g. when reading
+// an index from disk
+func NewKMeansWithCenters(dimensions int, segment int,
+	centers [][]float32) *KMeans {
+	km := NewKMeans(len(centers), dimensions, segment)
+	km.centers = centers
+	return km
+}
+
+func (m *KMeans) subVector(v []float32) []float32 {
+	return v[m.segment*m.dimensions : (m.segment+1)*m.dimensions]
+}
+
+// Nearest returns the index of the centroid closest to the segment of the
+// given (full) vector
+func (m *KMeans) Nearest(point []float32) uint64 {
+	return m.nearest(m.subVector(point))
+}
+
+func (m *KMeans) nearest(sub []float32) uint64 {
+	minDist := float32(math.MaxFloat32)
+	idx := 0
+	for i, c := range m.centers {
+		dist, _, _ := m.distance.SingleDist(sub, c)
+		if dist < minDist {
+			minDist = dist
+			idx = i
+		}
+	}
+
+	return uint64(idx)
+}
+
+// Centroid returns the i-th centroid, it is only valid after calling Fit or
+// when the KMeans was restored with NewKMeansWithCenters
+func (m *KMeans) Centroid(i uint64) []float32 {
+	return m.centers[i]
+}
+
+// Centers exposes all centroids, so that they can be persisted
+func (m *KMeans) Centers() [][]float32 {
+	return m.centers
+}
+
+// Fit runs lloyd's algorithm on the segment of the provided vectors. If there
+// are fewer distinct input vectors than K, K is lowered accordingly.
+func (m *KMeans) Fit(data [][]float32) error {
+	if len(data) == 0 {
+		return errors.Errorf("cannot fit kmeans on an empty data set")
+	}
+
+	for i, v := range data {
+		if len(v) < (m.segment+1)*m.dimensions {
+			return errors.Errorf("vector at position %d has length %d, "+
+				"but segment %d requires at least %d dimensions",
+				i, len(v), m.segment, (m.segment+1)*m.dimensions)
+		}
+	}
+
+	m.initCenters(data)
+
+	assignments := make([]int, len(data))
+	for i := range assignments {
+		assignments[i] = -1
+	}
+
+	for iteration := 0; iteration < m.IterationThreshold; iteration++ {
+		changed := 0
+		for i, v := range data {
+			c := int(m.Nearest(v))
+			if c != assignments[i] {
+				assignments[i] = c
+				changed++
+			}
+		}
+
+		m.recalculateCenters(data, assignments)
+
+		if float32(changed)/float32(len(data)) < m.DeltaThreshold {
+			break
+		}
+	}
+
+	return nil
+}
+
+// initCenters picks K random input vectors as initial centroids
+func (m *KMeans) initCenters(data [][]float32) {
+	k := m.K
+	if k > len(data) {
+		k = len(data)
+	}
+
+	m.centers = make([][]float32, k)
+	for i, pos := range m.rand.Perm(len(data))[:k] {
+		center := make([]float32, m.dimensions)
+		copy(center, m.subVector(data[pos]))
+		m.centers[i] = center
+	}
+	m.K = k
+}
+
+func (m *KMeans) recalculateCenters(data [][]float32, assignments []int) {
+	sums := make([][]float32, len(m.centers))
+	counts := make([]int, len(m.centers))
+	for i := range sums {
+		sums[i] = make([]float32, m.dimensions)
+	}
+
+	for i, v := range data {
+		c := assignments[i]
+		counts[c]++
+		for j, x := range m.subVector(v) {
+			sums[c][j] += x
+		}
+	}
+
+	for c := range m.centers {
+		if counts[c] == 0 {
+			// an empty cluster keeps its previous center
+			continue
+		}
+
+		for j := range sums[c] {
+			m.centers[c][j] = sums[c][j] / float32(counts[c])
+		}
+	}
+}
@@ -0,0 +1,62 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package compressionhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKMeans(t *testing.T) {
+	// two obvious clusters in the second segment, the first segment is noise
+	// that must be ignored
+	data := [][]float32{
+		{100, -3, 0, 0},
+		{-7, 20, 0.1, 0.1},
+		{13, 5, 0.2, 0},
+		{0, 0, 10, 10},
+		{42, 1, 10.1, 9.9},
+		{-1, -1, 9.8, 10.2},
+	}
+
+	km := NewKMeans(2, 2, 1)
+	require.Nil(t, km.Fit(data))
+
+	first := km.Nearest(data[0])
+	second := km.Nearest(data[3])
+	assert.NotEqual(t, first, second)
+
+	for _, v := range data[:3] {
+		assert.Equal(t, first, km.Nearest(v))
+	}
+	for _, v := range data[3:] {
+		assert.Equal(t, second, km.Nearest(v))
+	}
+
+	assert.InDeltaSlice(t, []float32{0.1, 0.033}, km.Centroid(first), 0.01)
+	assert.InDeltaSlice(t, []float32{9.967, 10.033}, km.Centroid(second), 0.01)
+}
+
+func TestKMeans_FewerPointsThanCentroids(t *testing.T) {
+	data := [][]float32{{1, 2}, {3, 4}}
+
+	km := NewKMeans(256, 2, 0)
+	require.Nil(t, km.Fit(data))
+	assert.Len(t, km.Centers(), 2)
+}
+
+func TestKMeans_EmptyInput(t *testing.T) {
+	km := NewKMeans(2, 2, 0)
+	assert.NotNil(t, km.Fit(nil))
+}
@@ -0,0 +1,293 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package compressionhelpers
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+)
+
+// MaxCentroids is the upper bound for centroids per segment, as each segment
+// is encoded into a single byte
+const MaxCentroids = 256
+
+// ProductQuantizer splits each vector into a fixed number of segments and
+// replaces each segment with the id of the closest of its centroids. A vector
+// of d float32 dimensions is thus compressed into m bytes.
+type ProductQuantizer struct {
+	dimensions int
+	segments   int
+	centroids  int
+	// dimensions per segment
+	ds       int
+	distance distancer.Provider
+	kms      []*KMeans
+
+	// each partial distance of a cosine-dot provider is 1-dot(a_i,b_i), the sum
+	// over all segments is therefore off by segments-1 compared to the distance
+	// between the full vectors
+	correction float32
+}
+
+// PQData contains everything that is required to restore a trained
+// ProductQuantizer, it is what gets persisted in the commit log
+type PQData struct {
+	Dimensions uint16
+	Segments   uint16
+	Centroids  uint16
+
+	// Codebooks contains the centroids of all segments, ordered by segment, so
+	// the c-th centroid of segment s is at position s*Centroids+c
+	Codebooks [][]float32
+}
+
+func NewProductQuantizer(segments int, centroids int,
+	distance distancer.Provider, dimensions int) (*ProductQuantizer, error) {
+	if dimensions <= 0 {
+		return nil, errors.Errorf("dimensions must be greater than 0, got %d",
+			dimensions)
+	}
+
+	if segments <= 0 || dimensions%segments != 0 {
+		return nil, errors.Errorf("segments must be a divisor of the "+
+			"dimensions (%d), got %d", dimensions, segments)
+	}
+
+	if centroids <= 0 || centroids > MaxCentroids {
+		return nil, errors.Errorf("centroids must be between 1 and %d, got %d",
+			MaxCentroids, centroids)
+	}
+
+	pq := &ProductQuantizer{
+		dimensions: dimensions,
+		segments:   segments,
+		centroids:  centroids,
+		ds:         dimensions / segments,
+		distance:   distance,
+		kms:        make([]*KMeans, segments),
+	}
+
+	if distance.Type() == "cosine-dot" {
+		pq.correction = -float32(segments - 1)
+	}
+
+	return pq, nil
+}
+
+// RestoreProductQuantizer creates an already trained ProductQuantizer from
+// previously persisted data
+func RestoreProductQuantizer(data PQData,
+	distance distancer.Provider) (*ProductQuantizer, error) {
+	pq, err := NewProductQuantizer(int(data.Segments), int(data.Centroids),
+		distance, int(data.Dimensions))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data.Codebooks) != pq.segments*pq.centroids {
+		return nil, errors.Errorf("expected %d centroids in codebooks, got %d",
+			pq.segments*pq.centroids, len(data.Codebooks))
+	}
+
+	for s := 0; s < pq.segments; s++ {
+		pq.kms[s] = NewKMeansWithCenters(pq.ds, s,
+			data.Codebooks[s*pq.centroids:(s+1)*pq.centroids])
+	}
+
+	return pq, nil
+}
+
+// Fit trains one codebook per segment on the provided sample. Segments are
+// independent of each other, so they are trained concurrently.
+func (pq *ProductQuantizer) Fit(data [][]float32) error {
+	if len(data) < pq.centroids {
+		return errors.Errorf("need at least %d vectors to train %d centroids, "+
+			"got %d", pq.centroids, pq.centroids, len(data))
+	}
+
+	for i, v := range data {
+		if len(v) != pq.dimensions {
+			return errors.Errorf("vector at position %d has %d dimensions, "+
+				"expected %d", i, len(v), pq.dimensions)
+		}
+	}
+
+	errs := make([]error, pq.segments)
+	segmentsCh := make(chan int)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range segmentsCh {
+				km := NewKMeans(pq.centroids, pq.ds, s)
+				errs[s] = km.Fit(data)
+				pq.kms[s] = km
+			}
+		}()
+	}
+
+	for s := 0; s < pq.segments; s++ {
+		segmentsCh <- s
+	}
+	close(segmentsCh)
+	wg.Wait()
+
+	for s, err := range errs {
+		if err != nil {
+			return errors.Wrapf(err, "fit segment %d", s)
+		}
+	}
+
+	return nil
+}
+
+// Encode compresses the vector into one byte per segment
+func (pq *ProductQuantizer) Encode(vec []float32) []byte {
+	code := make([]byte, pq.segments)
+	for s := 0; s < pq.segments; s++ {
+		code[s] = byte(pq.kms[s].Nearest(vec))
+	}
+	return code
+}
+
+// Decode reconstructs an approximation of the original vector from its code
+func (pq *ProductQuantizer) Decode(code []byte) []float32 {
+	vec := make([]float32, 0, pq.dimensions)
+	for s, c := range code {
+		vec = append(vec, pq.kms[s].Centroid(uint64(c))...)
+	}
+	return vec
+}
+
+// DistanceBetweenCompressedVectors approximates the distance between two
+// vectors using their codes only (symmetric distance)
+func (pq *ProductQuantizer) DistanceBetweenCompressedVectors(x,
+	y []byte) (float32, error) {
+	if len(x) != pq.segments || len(y) != pq.segments {
+		return 0, errors.Errorf("code lengths %d and %d do not match "+
+			"segments %d", len(x), len(y), pq.segments)
+	}
+
+	dist := pq.correction
+	for s := 0; s < pq.segments; s++ {
+		d, _, err := pq.distance.SingleDist(
+			pq.kms[s].Centroid(uint64(x[s])), pq.kms[s].Centroid(uint64(y[s])))
+		if err != nil {
+			return 0, errors.Wrapf(err, "segment %d", s)
+		}
+		dist += d
+	}
+
+	return dist, nil
+}
+
+// DistanceBetweenCompressedAndUncompressedVectors approximates the distance
+// between a full vector and a code (asymmetric distance)
+func (pq *ProductQuantizer) DistanceBetweenCompressedAndUncompressedVectors(
+	x []float32, code []byte) (float32, error) {
+	if len(x) != pq.dimensions {
+		return 0, errors.Errorf("vector has %d dimensions, expected %d",
+			len(x), pq.dimensions)
+	}
+
+	if len(code) != pq.segments {
+		return 0, errors.Errorf("code length %d does not match segments %d",
+			len(code), pq.segments)
+	}
+
+	dist := pq.correction
+	for s := 0; s < pq.segments; s++ {
+		d, _, err := pq.distance.SingleDist(x[s*pq.ds:(s+1)*pq.ds],
+			pq.kms[s].Centroid(uint64(code[s])))
+		if err != nil {
+			return 0, errors.Wrapf(err, "segment %d", s)
+		}
+		dist += d
+	}
+
+	return dist, nil
+}
+
+// ExposeFields returns the persistable state of a trained ProductQuantizer
+func (pq *ProductQuantizer) ExposeFields() PQData {
+	codebooks := make([][]float32, 0, pq.segments*pq.centroids)
+	for _, km := range pq.kms {
+		codebooks = append(codebooks, km.Centers()...)
+	}
+
+	return PQData{
+		Dimensions: uint16(pq.dimensions),
+		Segments:   uint16(pq.segments),
+		Centroids:  uint16(pq.centroids),
+		Codebooks:  codebooks,
+	}
+}
+
+func (pq *ProductQuantizer) Dimensions() int {
+	return pq.dimensions
+}
+
+func (pq *ProductQuantizer) Segments() int {
+	return pq.segments
+}
+
+func (pq *ProductQuantizer) Centroids() int {
+	return pq.centroids
+}
+
+// PQDistancer calculates asymmetric distances between a single query and any
+// number of codes. The partial distances between each query segment and all
+// centroids of that segment are calculated once, so that calculating the
+// distance to a code is reduced to one table lookup per segment.
+type PQDistancer struct {
+	pq     *ProductQuantizer
+	lookup []float32
+}
+
+func (pq *ProductQuantizer) NewDistancer(query []float32) (*PQDistancer, error) {
+	if len(query) != pq.dimensions {
+		return nil, errors.Errorf("query has %d dimensions, expected %d",
+			len(query), pq.dimensions)
+	}
+
+	lookup := make([]float32, pq.segments*pq.centroids)
+	for s := 0; s < pq.segments; s++ {
+		sub := query[s*pq.ds : (s+1)*pq.ds]
+		for c, center := range pq.kms[s].Centers() {
+			d, _, err := pq.distance.SingleDist(sub, center)
+			if err != nil {
+				return nil, errors.Wrapf(err, "segment %d, centroid %d", s, c)
+			}
+			lookup[s*pq.centroids+c] = d
+		}
+	}
+
+	return &PQDistancer{pq: pq, lookup: lookup}, nil
+}
+
+func (d *PQDistancer) Distance(code []byte) (float32, bool, error) {
+	if len(code) != d.pq.segments {
+		return 0, false, errors.Errorf("code length %d does not match "+
+			"segments %d", len(code), d.pq.segments)
+	}
+
+	dist := d.pq.correction
+	for s, c := range code {
+		dist += d.lookup[s*d.pq.centroids+int(c)]
+	}
+
+	return dist, true, nil
+}
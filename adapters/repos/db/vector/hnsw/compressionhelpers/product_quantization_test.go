@@ -0,0 +1,206 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package compressionhelpers
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomVectors(r *rand.Rand, count, dims int) [][]float32 {
+	out := make([][]float32, count)
+	for i := range out {
+		vec := make([]float32, dims)
+		for j := range vec {
+			vec[j] = r.Float32()
+		}
+		out[i] = vec
+	}
+	return out
+}
+
+func TestProductQuantizer_Validation(t *testing.T) {
+	provider := distancer.NewL2SquaredProvider()
+
+	_, err := NewProductQuantizer(3, 256, provider, 16)
+	assert.NotNil(t, err, "segments must divide dimensions")
+
+	_, err = NewProductQuantizer(4, 257, provider, 16)
+	assert.NotNil(t, err, "centroids must fit into a byte")
+
+	_, err = NewProductQuantizer(4, 256, provider, 0)
+	assert.NotNil(t, err, "dimensions must be set")
+
+	pq, err := NewProductQuantizer(4, 16, provider, 16)
+	require.Nil(t, err)
+	err = pq.Fit(randomVectors(rand.New(rand.NewSource(1)), 8, 16))
+	assert.NotNil(t, err, "too few training vectors")
+}
+
+func TestProductQuantizer_EncodeDecode(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	data := randomVectors(r, 1000, 32)
+
+	pq, err := NewProductQuantizer(8, 64, distancer.NewL2SquaredProvider(), 32)
+	require.Nil(t, err)
+	require.Nil(t, pq.Fit(data))
+
+	for _, vec := range data[:50] {
+		code := pq.Encode(vec)
+		require.Len(t, code, 8)
+
+		decoded := pq.Decode(code)
+		require.Len(t, decoded, 32)
+
+		// the reconstruction must be closer than a random other vector
+		reconstructionErr, _, _ := distancer.NewL2SquaredProvider().
+			SingleDist(vec, decoded)
+		randomErr, _, _ := distancer.NewL2SquaredProvider().
+			SingleDist(vec, data[r.Intn(len(data))])
+		assert.Less(t, reconstructionErr, randomErr)
+	}
+}
+
+func TestProductQuantizer_Distances(t *testing.T) {
+	providers := []distancer.Provider{
+		distancer.NewL2SquaredProvider(),
+		distancer.NewDotProductProvider(),
+		distancer.NewCosineDistanceProvider(),
+		distancer.NewManhattanProvider(),
+	}
+
+	for _, provider := range providers {
+		t.Run(provider.Type(), func(t *testing.T) {
+			r := rand.New(rand.NewSource(3))
+			data := randomVectors(r, 500, 24)
+			if provider.Type() == "cosine-dot" {
+				for i := range data {
+					data[i] = distancer.Normalize(data[i])
+				}
+			}
+
+			pq, err := NewProductQuantizer(6, 32, provider, 24)
+			require.Nil(t, err)
+			require.Nil(t, pq.Fit(data))
+
+			query := data[0]
+			d, err := pq.NewDistancer(query)
+			require.Nil(t, err)
+
+			for _, vec := range data[1:20] {
+				code := pq.Encode(vec)
+
+				// the lookup table based distance must be identical to the
+				// asymmetric distance calculated on the decoded vector
+				expected, _, err := provider.SingleDist(query, pq.Decode(code))
+				require.Nil(t, err)
+
+				actual, ok, err := d.Distance(code)
+				require.Nil(t, err)
+				require.True(t, ok)
+				assert.InDelta(t, expected, actual, 1e-4)
+
+				asymmetric, err := pq.DistanceBetweenCompressedAndUncompressedVectors(
+					query, code)
+				require.Nil(t, err)
+				assert.InDelta(t, expected, asymmetric, 1e-4)
+
+				expectedSymmetric, _, err := provider.SingleDist(
+					pq.Decode(pq.Encode(query)), pq.Decode(code))
+				require.Nil(t, err)
+				symmetric, err := pq.DistanceBetweenCompressedVectors(
+					pq.Encode(query), code)
+				require.Nil(t, err)
+				assert.InDelta(t, expectedSymmetric, symmetric, 1e-4)
+			}
+		})
+	}
+}
+
+func TestProductQuantizer_Recall(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	dims := 32
+	data := randomVectors(r, 1000, dims)
+	queries := randomVectors(r, 20, dims)
+	provider := distancer.NewL2SquaredProvider()
+	k := 10
+
+	pq, err := NewProductQuantizer(16, 64, provider, dims)
+	require.Nil(t, err)
+	require.Nil(t, pq.Fit(data))
+
+	codes := make([][]byte, len(data))
+	for i, vec := range data {
+		codes[i] = pq.Encode(vec)
+	}
+
+	hits := 0
+	for _, query := range queries {
+		truth := bruteForce(len(data), k, func(i int) float32 {
+			d, _, _ := provider.SingleDist(query, data[i])
+			return d
+		})
+
+		d, err := pq.NewDistancer(query)
+		require.Nil(t, err)
+
+		// rescoring a larger candidate set with the full vectors is what the
+		// hnsw index does as well
+		candidates := bruteForce(len(data), 4*k, func(i int) float32 {
+			dist, _, _ := d.Distance(codes[i])
+			return dist
+		})
+		sort.Slice(candidates, func(a, b int) bool {
+			da, _, _ := provider.SingleDist(query, data[candidates[a]])
+			db, _, _ := provider.SingleDist(query, data[candidates[b]])
+			return da < db
+		})
+
+		hits += matches(truth, candidates[:k])
+	}
+
+	recall := float32(hits) / float32(k*len(queries))
+	assert.GreaterOrEqual(t, recall, float32(0.9))
+}
+
+func bruteForce(n, k int, dist func(i int) float32) []int {
+	ids := make([]int, n)
+	dists := make([]float32, n)
+	for i := range ids {
+		ids[i] = i
+		dists[i] = dist(i)
+	}
+
+	sort.Slice(ids, func(a, b int) bool {
+		return dists[ids[a]] < dists[ids[b]]
+	})
+
+	return ids[:k]
+}
+
+func matches(truth, results []int) int {
+	count := 0
+	for _, r := range results {
+		for _, t := range truth {
+			if r == t {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,39 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package compressionhelpers
+
+// Quantizer encodes vectors into the compact codes that a compressed index
+// keeps in memory and calculates distances on those codes. It is implemented
+// by the ProductQuantizer and the ScalarQuantizer.
+type Quantizer interface {
+	Encode(vec []float32) []byte
+	DistanceBetweenCompressedVectors(x, y []byte) (float32, error)
+	DistanceBetweenCompressedAndUncompressedVectors(x []float32,
+		code []byte) (float32, error)
+	NewQuantizerDistancer(query []float32) (QuantizerDistancer, error)
+}
+
+// QuantizerDistancer calculates the distances between a single query and any
+// number of codes
+type QuantizerDistancer interface {
+	Distance(code []byte) (float32, bool, error)
+}
+
+func (pq *ProductQuantizer) NewQuantizerDistancer(
+	query []float32) (QuantizerDistancer, error) {
+	d, err := pq.NewDistancer(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
@@ -0,0 +1,364 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package compressionhelpers
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+)
+
+const (
+	// ScalarQuantizationFloat16 stores every dimension as a half precision
+	// float, which halves the memory of a vector
+	ScalarQuantizationFloat16 = "float16"
+
+	// ScalarQuantizationInt8 maps every dimension linearly onto a single byte,
+	// which quarters the memory of a vector. The value range of each dimension
+	// is learned from a sample of the vectors.
+	ScalarQuantizationInt8 = "int8"
+)
+
+// The ids the quantization types are persisted with, see SQData
+const (
+	SQKindFloat16 uint8 = 0
+	SQKindInt8    uint8 = 1
+)
+
+// SQData contains everything that is required to restore a fitted
+// ScalarQuantizer, it is what gets persisted in the commit log
+type SQData struct {
+	Kind       uint8
+	Dimensions uint16
+
+	// int8 only, the value range learned for every dimension
+	Mins   []float32
+	Scales []float32
+}
+
+// ScalarQuantizer compresses each dimension of a vector independently.
+// Unlike the ProductQuantizer it does not require large codebooks and keeps
+// considerably more precision, at the cost of a lower compression ratio.
+type ScalarQuantizer struct {
+	kind       string
+	dimensions int
+	distance   distancer.Provider
+
+	// int8 only, the value of dimension i is mins[i] + code[i]*scales[i]
+	mins   []float32
+	scales []float32
+}
+
+func NewScalarQuantizer(kind string, distance distancer.Provider,
+	dimensions int) (*ScalarQuantizer, error) {
+	if dimensions <= 0 {
+		return nil, errors.Errorf("dimensions must be greater than 0, got %d",
+			dimensions)
+	}
+
+	switch kind {
+	case ScalarQuantizationFloat16, ScalarQuantizationInt8:
+	default:
+		return nil, errors.Errorf("unsupported scalar quantization type %q", kind)
+	}
+
+	if !scalarQuantizationSupports(distance.Type()) {
+		return nil, errors.Errorf("scalar quantization does not support "+
+			"distance %q", distance.Type())
+	}
+
+	return &ScalarQuantizer{
+		kind:       kind,
+		dimensions: dimensions,
+		distance:   distance,
+	}, nil
+}
+
+// RestoreScalarQuantizer recreates a quantizer from the data persisted with
+// ExposeFields, it does not need to be fitted again
+func RestoreScalarQuantizer(data SQData,
+	distance distancer.Provider) (*ScalarQuantizer, error) {
+	var kind string
+	switch data.Kind {
+	case SQKindFloat16:
+		kind = ScalarQuantizationFloat16
+	case SQKindInt8:
+		kind = ScalarQuantizationInt8
+	default:
+		return nil, errors.Errorf("unsupported scalar quantization kind %d",
+			data.Kind)
+	}
+
+	sq, err := NewScalarQuantizer(kind, distance, int(data.Dimensions))
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == ScalarQuantizationInt8 {
+		if len(data.Mins) != sq.dimensions || len(data.Scales) != sq.dimensions {
+			return nil, errors.Errorf("expected value ranges of %d dimensions, "+
+				"got %d mins and %d scales", sq.dimensions, len(data.Mins),
+				len(data.Scales))
+		}
+
+		sq.mins = data.Mins
+		sq.scales = data.Scales
+	}
+
+	return sq, nil
+}
+
+func scalarQuantizationSupports(distanceType string) bool {
+	switch distanceType {
+	case "l2-squared", "dot", "cosine-dot", "manhattan":
+		return true
+	default:
+		return false
+	}
+}
+
+// Fit learns the value range of every dimension from the sample. It is a
+// no-op for float16, which does not depend on the data.
+func (sq *ScalarQuantizer) Fit(data [][]float32) error {
+	if sq.kind != ScalarQuantizationInt8 {
+		return nil
+	}
+
+	if len(data) == 0 {
+		return errors.Errorf("need at least one vector to fit int8 quantizer")
+	}
+
+	mins := make([]float32, sq.dimensions)
+	maxs := make([]float32, sq.dimensions)
+	for i := range mins {
+		mins[i] = math.MaxFloat32
+		maxs[i] = -math.MaxFloat32
+	}
+
+	for pos, v := range data {
+		if len(v) != sq.dimensions {
+			return errors.Errorf("vector at position %d has %d dimensions, "+
+				"expected %d", pos, len(v), sq.dimensions)
+		}
+
+		for i, x := range v {
+			if x < mins[i] {
+				mins[i] = x
+			}
+			if x > maxs[i] {
+				maxs[i] = x
+			}
+		}
+	}
+
+	scales := make([]float32, sq.dimensions)
+	for i := range scales {
+		scales[i] = (maxs[i] - mins[i]) / math.MaxUint8
+	}
+
+	sq.mins = mins
+	sq.scales = scales
+	return nil
+}
+
+func (sq *ScalarQuantizer) ExposeFields() SQData {
+	if sq.kind == ScalarQuantizationFloat16 {
+		return SQData{Kind: SQKindFloat16, Dimensions: uint16(sq.dimensions)}
+	}
+
+	return SQData{
+		Kind:       SQKindInt8,
+		Dimensions: uint16(sq.dimensions),
+		Mins:       sq.mins,
+		Scales:     sq.scales,
+	}
+}
+
+func (sq *ScalarQuantizer) Kind() string {
+	return sq.kind
+}
+
+func (sq *ScalarQuantizer) Dimensions() int {
+	return sq.dimensions
+}
+
+// Encode compresses the vector into two bytes (float16) or one byte (int8)
+// per dimension. With int8, values outside of the range seen during Fit are
+// clamped to the closest boundary.
+func (sq *ScalarQuantizer) Encode(vec []float32) []byte {
+	if sq.kind == ScalarQuantizationFloat16 {
+		code := make([]byte, 2*len(vec))
+		for i, x := range vec {
+			h := distancer.Float32ToFloat16(x)
+			code[2*i] = byte(h)
+			code[2*i+1] = byte(h >> 8)
+		}
+		return code
+	}
+
+	code := make([]byte, len(vec))
+	for i, x := range vec {
+		if i >= sq.dimensions || sq.scales[i] == 0 {
+			continue
+		}
+
+		scaled := math.Round(float64((x - sq.mins[i]) / sq.scales[i]))
+		if scaled < 0 {
+			scaled = 0
+		} else if scaled > math.MaxUint8 {
+			scaled = math.MaxUint8
+		}
+		code[i] = byte(scaled)
+	}
+	return code
+}
+
+// Decode reconstructs an approximation of the original vector from its code
+func (sq *ScalarQuantizer) Decode(code []byte) []float32 {
+	if sq.kind == ScalarQuantizationFloat16 {
+		vec := make([]float32, len(code)/2)
+		for i := range vec {
+			vec[i] = distancer.Float16ToFloat32(uint16(code[2*i]) |
+				uint16(code[2*i+1])<<8)
+		}
+		return vec
+	}
+
+	vec := make([]float32, len(code))
+	for i, c := range code {
+		vec[i] = sq.mins[i] + float32(c)*sq.scales[i]
+	}
+	return vec
+}
+
+// CodeLength is the size of the code of a single vector in bytes
+func (sq *ScalarQuantizer) CodeLength() int {
+	if sq.kind == ScalarQuantizationFloat16 {
+		return 2 * sq.dimensions
+	}
+
+	return sq.dimensions
+}
+
+// DistanceBetweenCompressedVectors calculates the distance between two
+// vectors using their codes only
+func (sq *ScalarQuantizer) DistanceBetweenCompressedVectors(x,
+	y []byte) (float32, error) {
+	if len(x) != sq.CodeLength() || len(y) != sq.CodeLength() {
+		return 0, errors.Errorf("code lengths %d and %d do not match "+
+			"expected length %d", len(x), len(y), sq.CodeLength())
+	}
+
+	if sq.kind == ScalarQuantizationFloat16 {
+		switch sq.distance.Type() {
+		case "l2-squared":
+			return distancer.L2SquaredFloat16(x, y), nil
+		case "manhattan":
+			return distancer.ManhattanFloat16(x, y), nil
+		default:
+			return sq.fromDotProduct(distancer.DotProductFloat16(x, y)), nil
+		}
+	}
+
+	switch sq.distance.Type() {
+	case "l2-squared":
+		return distancer.L2SquaredInt8(x, y, sq.scales), nil
+	case "manhattan":
+		return distancer.ManhattanInt8(x, y, sq.scales), nil
+	default:
+		return sq.fromDotProduct(
+			distancer.DotProductInt8(x, y, sq.mins, sq.scales)), nil
+	}
+}
+
+// DistanceBetweenCompressedAndUncompressedVectors calculates the distance
+// between a full vector and a code
+func (sq *ScalarQuantizer) DistanceBetweenCompressedAndUncompressedVectors(
+	x []float32, code []byte) (float32, error) {
+	if len(x) != sq.dimensions {
+		return 0, errors.Errorf("vector has %d dimensions, expected %d",
+			len(x), sq.dimensions)
+	}
+
+	if len(code) != sq.CodeLength() {
+		return 0, errors.Errorf("code length %d does not match expected "+
+			"length %d", len(code), sq.CodeLength())
+	}
+
+	if sq.kind == ScalarQuantizationFloat16 {
+		switch sq.distance.Type() {
+		case "l2-squared":
+			return distancer.L2SquaredFloat16Query(x, code), nil
+		case "manhattan":
+			return distancer.ManhattanFloat16Query(x, code), nil
+		default:
+			return sq.fromDotProduct(distancer.DotProductFloat16Query(x, code)), nil
+		}
+	}
+
+	switch sq.distance.Type() {
+	case "l2-squared":
+		return distancer.L2SquaredInt8Query(x, code, sq.mins, sq.scales), nil
+	case "manhattan":
+		return distancer.ManhattanInt8Query(x, code, sq.mins, sq.scales), nil
+	default:
+		return sq.fromDotProduct(
+			distancer.DotProductInt8Query(x, code, sq.mins, sq.scales)), nil
+	}
+}
+
+// fromDotProduct turns the pure product into the distance of the configured
+// provider, see distancer.DotProduct and distancer.CosineDistance
+func (sq *ScalarQuantizer) fromDotProduct(prod float32) float32 {
+	if sq.distance.Type() == "cosine-dot" {
+		return 1 - prod
+	}
+
+	return -prod
+}
+
+// SQDistancer calculates distances between a single query and any number of
+// codes
+type SQDistancer struct {
+	sq    *ScalarQuantizer
+	query []float32
+}
+
+func (sq *ScalarQuantizer) NewDistancer(query []float32) (*SQDistancer, error) {
+	if len(query) != sq.dimensions {
+		return nil, errors.Errorf("query has %d dimensions, expected %d",
+			len(query), sq.dimensions)
+	}
+
+	return &SQDistancer{sq: sq, query: query}, nil
+}
+
+func (sq *ScalarQuantizer) NewQuantizerDistancer(
+	query []float32) (QuantizerDistancer, error) {
+	d, err := sq.NewDistancer(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *SQDistancer) Distance(code []byte) (float32, bool, error) {
+	dist, err := d.sq.DistanceBetweenCompressedAndUncompressedVectors(d.query,
+		code)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return dist, true, nil
+}
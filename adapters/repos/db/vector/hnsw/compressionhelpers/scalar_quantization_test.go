@@ -0,0 +1,177 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package compressionhelpers
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarQuantizer_Validation(t *testing.T) {
+	provider := distancer.NewL2SquaredProvider()
+
+	_, err := NewScalarQuantizer("int4", provider, 16)
+	assert.NotNil(t, err, "unsupported type")
+
+	_, err = NewScalarQuantizer(ScalarQuantizationInt8, provider, 0)
+	assert.NotNil(t, err, "dimensions must be set")
+
+	_, err = NewScalarQuantizer(ScalarQuantizationFloat16,
+		distancer.NewHammingProvider(), 16)
+	assert.NotNil(t, err, "unsupported distance")
+
+	sq, err := NewScalarQuantizer(ScalarQuantizationInt8, provider, 16)
+	require.Nil(t, err)
+	assert.NotNil(t, sq.Fit(nil), "no training vectors")
+	assert.NotNil(t, sq.Fit([][]float32{{1, 2, 3}}), "dimensions mismatch")
+}
+
+func TestScalarQuantizer_EncodeDecode(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	data := randomVectors(r, 500, 32)
+
+	tests := []struct {
+		kind       string
+		codeLength int
+		// the maximum error of a single dimension, values are in [0, 1)
+		maxError float64
+	}{
+		{kind: ScalarQuantizationFloat16, codeLength: 64, maxError: 1e-3},
+		{kind: ScalarQuantizationInt8, codeLength: 32, maxError: 1.0 / 255},
+	}
+
+	for _, test := range tests {
+		t.Run(test.kind, func(t *testing.T) {
+			sq, err := NewScalarQuantizer(test.kind,
+				distancer.NewL2SquaredProvider(), 32)
+			require.Nil(t, err)
+			require.Nil(t, sq.Fit(data))
+
+			for _, vec := range data[:50] {
+				code := sq.Encode(vec)
+				require.Len(t, code, test.codeLength)
+
+				decoded := sq.Decode(code)
+				require.Len(t, decoded, 32)
+				for i := range vec {
+					assert.InDelta(t, vec[i], decoded[i], test.maxError)
+				}
+			}
+		})
+	}
+}
+
+func TestScalarQuantizer_Restore(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	data := randomVectors(r, 200, 16)
+
+	for _, kind := range []string{ScalarQuantizationFloat16, ScalarQuantizationInt8} {
+		t.Run(kind, func(t *testing.T) {
+			sq, err := NewScalarQuantizer(kind, distancer.NewDotProductProvider(), 16)
+			require.Nil(t, err)
+			require.Nil(t, sq.Fit(data))
+
+			restored, err := RestoreScalarQuantizer(sq.ExposeFields(),
+				distancer.NewDotProductProvider())
+			require.Nil(t, err)
+			assert.Equal(t, kind, restored.Kind())
+			assert.Equal(t, sq.CodeLength(), restored.CodeLength())
+
+			for _, vec := range data[:20] {
+				assert.Equal(t, sq.Encode(vec), restored.Encode(vec))
+			}
+		})
+	}
+
+	t.Run("int8 without value ranges", func(t *testing.T) {
+		_, err := RestoreScalarQuantizer(SQData{Kind: SQKindInt8, Dimensions: 16},
+			distancer.NewDotProductProvider())
+		assert.NotNil(t, err)
+	})
+}
+
+func TestScalarQuantizer_Int8Clamping(t *testing.T) {
+	sq, err := NewScalarQuantizer(ScalarQuantizationInt8,
+		distancer.NewL2SquaredProvider(), 3)
+	require.Nil(t, err)
+	require.Nil(t, sq.Fit([][]float32{{0, 0, 5}, {1, 2, 5}}))
+
+	// values outside of the trained range are clamped to the boundaries and
+	// a dimension without any spread is always decoded to its only value
+	decoded := sq.Decode(sq.Encode([]float32{-3, 7, 9}))
+	assert.InDeltaSlice(t, []float32{0, 2, 5}, decoded, 1e-6)
+}
+
+func TestScalarQuantizer_Distances(t *testing.T) {
+	providers := []distancer.Provider{
+		distancer.NewL2SquaredProvider(),
+		distancer.NewDotProductProvider(),
+		distancer.NewCosineDistanceProvider(),
+		distancer.NewManhattanProvider(),
+	}
+
+	for _, kind := range []string{ScalarQuantizationFloat16, ScalarQuantizationInt8} {
+		for _, provider := range providers {
+			t.Run(kind+"/"+provider.Type(), func(t *testing.T) {
+				r := rand.New(rand.NewSource(9))
+				data := randomVectors(r, 200, 24)
+				if provider.Type() == "cosine-dot" {
+					for i := range data {
+						data[i] = distancer.Normalize(data[i])
+					}
+				}
+
+				sq, err := NewScalarQuantizer(kind, provider, 24)
+				require.Nil(t, err)
+				require.Nil(t, sq.Fit(data))
+
+				query := data[0]
+				d, err := sq.NewDistancer(query)
+				require.Nil(t, err)
+
+				for _, vec := range data[1:20] {
+					code := sq.Encode(vec)
+
+					// the kernels must match the provider applied to the
+					// decoded vectors
+					expected, _, err := provider.SingleDist(query, sq.Decode(code))
+					require.Nil(t, err)
+
+					actual, ok, err := d.Distance(code)
+					require.Nil(t, err)
+					require.True(t, ok)
+					assert.InDelta(t, expected, actual, 1e-3)
+
+					expectedSymmetric, _, err := provider.SingleDist(
+						sq.Decode(sq.Encode(query)), sq.Decode(code))
+					require.Nil(t, err)
+					symmetric, err := sq.DistanceBetweenCompressedVectors(
+						sq.Encode(query), code)
+					require.Nil(t, err)
+					assert.InDelta(t, expectedSymmetric, symmetric, 1e-3)
+
+					// and stay close to the exact distance
+					exact, _, err := provider.SingleDist(query, vec)
+					require.Nil(t, err)
+					assert.InDelta(t, exact, actual, 0.05)
+				}
+
+				_, err = sq.DistanceBetweenCompressedVectors([]byte{1}, []byte{2})
+				assert.NotNil(t, err, "code length mismatch")
+			})
+		}
+	}
+}
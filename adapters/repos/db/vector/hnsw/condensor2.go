@@ -19,6 +19,7 @@ import (
 	"os"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 	"github.com/sirupsen/logrus"
 )
 
@@ -87,6 +88,25 @@ func (c *MemoryCondensor2) Do(fileName string) error {
 		}
 	}
 
+	if res.Compressed {
+		if err := c.AddPQ(res.PQData); err != nil {
+			return errors.Wrap(err, "write product quantizer to commit log")
+		}
+	}
+
+	if res.ScalarQuantized {
+		if err := c.AddSQ(res.SQData); err != nil {
+			return errors.Wrap(err, "write scalar quantizer to commit log")
+		}
+	}
+
+	if res.ConstructionParamsSet {
+		if err := c.SetConstructionParams(res.MaxConnections,
+			res.EFConstruction); err != nil {
+			return errors.Wrap(err, "write construction parameters to commit log")
+		}
+	}
+
 	for ts := range res.Tombstones {
 		if err := c.AddTombstone(ts); err != nil {
 			return errors.Wrapf(err,
@@ -226,6 +246,47 @@ func (c *MemoryCondensor2) AddTombstone(nodeid uint64) error {
 	return ec.toError()
 }
 
+func (c *MemoryCondensor2) AddPQ(data compressionhelpers.PQData) error {
+	toWrite := make([]byte, 7)
+	toWrite[0] = byte(AddPQ)
+	binary.LittleEndian.PutUint16(toWrite[1:3], data.Dimensions)
+	binary.LittleEndian.PutUint16(toWrite[3:5], data.Segments)
+	binary.LittleEndian.PutUint16(toWrite[5:7], data.Centroids)
+	for _, centroid := range data.Codebooks {
+		for _, v := range centroid {
+			toWrite = append(toWrite, 0, 0, 0, 0)
+			binary.LittleEndian.PutUint32(toWrite[len(toWrite)-4:], math.Float32bits(v))
+		}
+	}
+	_, err := c.newLog.Write(toWrite)
+	return err
+}
+
+func (c *MemoryCondensor2) AddSQ(data compressionhelpers.SQData) error {
+	toWrite := make([]byte, 4)
+	toWrite[0] = byte(AddSQ)
+	toWrite[1] = data.Kind
+	binary.LittleEndian.PutUint16(toWrite[2:4], data.Dimensions)
+	for _, values := range [][]float32{data.Mins, data.Scales} {
+		for _, v := range values {
+			toWrite = append(toWrite, 0, 0, 0, 0)
+			binary.LittleEndian.PutUint32(toWrite[len(toWrite)-4:], math.Float32bits(v))
+		}
+	}
+	_, err := c.newLog.Write(toWrite)
+	return err
+}
+
+func (c *MemoryCondensor2) SetConstructionParams(maxConnections,
+	efConstruction int) error {
+	toWrite := make([]byte, 9)
+	toWrite[0] = byte(SetConstructionParams)
+	binary.LittleEndian.PutUint32(toWrite[1:5], uint32(maxConnections))
+	binary.LittleEndian.PutUint32(toWrite[5:9], uint32(efConstruction))
+	_, err := c.newLog.Write(toWrite)
+	return err
+}
+
 func NewMemoryCondensor2(logger logrus.FieldLogger) *MemoryCondensor2 {
 	return &MemoryCondensor2{logger: logger}
 }
@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/sirupsen/logrus"
@@ -33,6 +34,10 @@ type Config struct {
 	VectorForIDThunk      VectorForID
 	Logger                logrus.FieldLogger
 	DistanceProvider      distancer.Provider
+
+	// CacheBudget is shared by the vector caches of all indexes on a node. If
+	// it is nil, the index uses its own unlimited budget.
+	CacheBudget *CacheBudget
 }
 
 func (c Config) Validate() error {
@@ -100,17 +105,84 @@ const (
 	DefaultVectorCacheMaxObjects  = 2000000
 	DefaultSkip                   = false
 	DefaultFlatSearchCutoff       = 40000
+	DefaultDistanceMetric         = DistanceCosine
+	DefaultPQEnabled              = false
+	DefaultPQSegments             = 0 // indicates "let Weaviate pick"
+	DefaultPQCentroids            = 256
+	DefaultPQTrainingLimit        = 100000
+	DefaultSQEnabled              = false
+	DefaultSQType                 = compressionhelpers.ScalarQuantizationFloat16
+	DefaultSQRescore              = true
+	DefaultSQTrainingLimit        = 100000
+	DefaultFilterStrategy         = FilterStrategyAcorn
+	DefaultAcornFlatSearchCutoff  = 0
+)
+
+const (
+	// FilterStrategySweeping traverses the graph as if there was no filter and
+	// simply ignores all nodes which are not allowed when collecting results.
+	// With restrictive filters this requires a very large search to fill the
+	// results.
+	FilterStrategySweeping = "sweeping"
+
+	// FilterStrategyAcorn only considers allowed nodes as candidates, but walks
+	// through nodes which are not allowed to reach their (allowed) neighbors, so
+	// the traversal stays connected even with highly restrictive filters. It
+	// replaces the flat search of the sweeping strategy, so flatSearchCutoff
+	// does not apply. Only allow lists which are not larger than ef, or smaller
+	// than acornFlatSearchCutoff, are searched flat.
+	FilterStrategyAcorn = "acorn"
+)
+
+const (
+	DistanceCosine    = "cosine"
+	DistanceDot       = "dot"
+	DistanceL2Squared = "l2-squared"
+	DistanceManhattan = "manhattan"
+	DistanceHamming   = "hamming"
 )
 
 // UserConfig bundles all values settable by a user in the per-class settings
 type UserConfig struct {
-	Skip                   bool `json:"skip"`
-	CleanupIntervalSeconds int  `json:"cleanupIntervalSeconds"`
-	MaxConnections         int  `json:"maxConnections"`
-	EFConstruction         int  `json:"efConstruction"`
-	EF                     int  `json:"ef"`
-	VectorCacheMaxObjects  int  `json:"vectorCacheMaxObjects"`
-	FlatSearchCutoff       int  `json:"flatSearchCutoff"`
+	Skip                   bool     `json:"skip"`
+	CleanupIntervalSeconds int      `json:"cleanupIntervalSeconds"`
+	MaxConnections         int      `json:"maxConnections"`
+	EFConstruction         int      `json:"efConstruction"`
+	EF                     int      `json:"ef"`
+	VectorCacheMaxObjects  int      `json:"vectorCacheMaxObjects"`
+	FlatSearchCutoff       int      `json:"flatSearchCutoff"`
+	Distance               string   `json:"distance"`
+	PQ                     PQConfig `json:"pq"`
+	SQ                     SQConfig `json:"sq"`
+	FilterStrategy         string   `json:"filterStrategy"`
+	AcornFlatSearchCutoff  int      `json:"acornFlatSearchCutoff"`
+}
+
+// PQConfig controls the optional product quantization of the vectors held in
+// memory. Compression can be turned on at any time, the codebooks are then
+// trained on a sample of the vectors that are already present.
+type PQConfig struct {
+	Enabled       bool `json:"enabled"`
+	Segments      int  `json:"segments"`
+	Centroids     int  `json:"centroids"`
+	TrainingLimit int  `json:"trainingLimit"`
+}
+
+// SQConfig controls the optional scalar quantization of the vectors held in
+// memory. It keeps considerably more precision than product quantization:
+// float16 halves the memory of a vector, int8 quarters it. The value ranges
+// required by int8 are learned from a sample of the vectors that are already
+// present. With Rescore the final candidates are compared using the full
+// vectors read from disk.
+//
+// The quantizer is persisted in the commit log and the codes in a file next to
+// it, so the cache is refilled from the codes after a restart. The full
+// vectors are kept with the objects, as Rescore compares against them.
+type SQConfig struct {
+	Enabled       bool   `json:"enabled"`
+	Type          string `json:"type"`
+	Rescore       bool   `json:"rescore"`
+	TrainingLimit int    `json:"trainingLimit"`
 }
 
 // IndexType returns the type of the underlying vector index, thus making sure
@@ -119,6 +191,12 @@ func (u UserConfig) IndexType() string {
 	return "hnsw"
 }
 
+// DistanceName returns the distance metric configured for this index, thus
+// making sure the schema.VectorIndexConfig interface is implemented
+func (u UserConfig) DistanceName() string {
+	return u.Distance
+}
+
 // SetDefaults in the user-specifyable part of the config
 func (c *UserConfig) SetDefaults() {
 	c.MaxConnections = DefaultMaxConnections
@@ -128,6 +206,21 @@ func (c *UserConfig) SetDefaults() {
 	c.EF = DefaultEF
 	c.Skip = DefaultSkip
 	c.FlatSearchCutoff = DefaultFlatSearchCutoff
+	c.Distance = DefaultDistanceMetric
+	c.PQ = PQConfig{
+		Enabled:       DefaultPQEnabled,
+		Segments:      DefaultPQSegments,
+		Centroids:     DefaultPQCentroids,
+		TrainingLimit: DefaultPQTrainingLimit,
+	}
+	c.SQ = SQConfig{
+		Enabled:       DefaultSQEnabled,
+		Type:          DefaultSQType,
+		Rescore:       DefaultSQRescore,
+		TrainingLimit: DefaultSQTrainingLimit,
+	}
+	c.FilterStrategy = DefaultFilterStrategy
+	c.AcornFlatSearchCutoff = DefaultAcornFlatSearchCutoff
 }
 
 // ParseUserConfig from an unknown input value, as this is not further
@@ -187,7 +280,182 @@ func ParseUserConfig(input interface{}) (schema.VectorIndexConfig, error) {
 		return uc, err
 	}
 
-	return uc, nil
+	if err := optionalStringFromMap(asMap, "distance", func(v string) {
+		uc.Distance = v
+	}); err != nil {
+		return uc, err
+	}
+
+	if err := parsePQMap(asMap, &uc.PQ); err != nil {
+		return uc, err
+	}
+
+	if err := parseSQMap(asMap, &uc.SQ); err != nil {
+		return uc, err
+	}
+
+	if err := optionalStringFromMap(asMap, "filterStrategy", func(v string) {
+		uc.FilterStrategy = v
+	}); err != nil {
+		return uc, err
+	}
+
+	if err := optionalIntFromMap(asMap, "acornFlatSearchCutoff", func(v int) {
+		uc.AcornFlatSearchCutoff = v
+	}); err != nil {
+		return uc, err
+	}
+
+	return uc, uc.validate()
+}
+
+func parsePQMap(in map[string]interface{}, pq *PQConfig) error {
+	value, ok := in["pq"]
+	if !ok {
+		return nil
+	}
+
+	pqMap, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("pq must be an object, got %T", value)
+	}
+
+	if err := optionalBoolFromMap(pqMap, "enabled", func(v bool) {
+		pq.Enabled = v
+	}); err != nil {
+		return err
+	}
+
+	if err := optionalIntFromMap(pqMap, "segments", func(v int) {
+		pq.Segments = v
+	}); err != nil {
+		return err
+	}
+
+	if err := optionalIntFromMap(pqMap, "centroids", func(v int) {
+		pq.Centroids = v
+	}); err != nil {
+		return err
+	}
+
+	if err := optionalIntFromMap(pqMap, "trainingLimit", func(v int) {
+		pq.TrainingLimit = v
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func parseSQMap(in map[string]interface{}, sq *SQConfig) error {
+	value, ok := in["sq"]
+	if !ok {
+		return nil
+	}
+
+	sqMap, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("sq must be an object, got %T", value)
+	}
+
+	if err := optionalBoolFromMap(sqMap, "enabled", func(v bool) {
+		sq.Enabled = v
+	}); err != nil {
+		return err
+	}
+
+	if err := optionalStringFromMap(sqMap, "type", func(v string) {
+		sq.Type = v
+	}); err != nil {
+		return err
+	}
+
+	if err := optionalBoolFromMap(sqMap, "rescore", func(v bool) {
+		sq.Rescore = v
+	}); err != nil {
+		return err
+	}
+
+	if err := optionalIntFromMap(sqMap, "trainingLimit", func(v int) {
+		sq.TrainingLimit = v
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *UserConfig) validate() error {
+	switch u.Distance {
+	case DistanceCosine, DistanceDot, DistanceL2Squared, DistanceManhattan,
+		DistanceHamming:
+	default:
+		return errors.Errorf("distance %q is not supported, must be one of "+
+			"[%s, %s, %s, %s, %s]", u.Distance, DistanceCosine, DistanceDot,
+			DistanceL2Squared, DistanceManhattan, DistanceHamming)
+	}
+
+	switch u.FilterStrategy {
+	case FilterStrategySweeping, FilterStrategyAcorn:
+	default:
+		return errors.Errorf("filterStrategy %q is not supported, must be one of "+
+			"[%s, %s]", u.FilterStrategy, FilterStrategySweeping, FilterStrategyAcorn)
+	}
+
+	if err := u.PQ.validate(); err != nil {
+		return err
+	}
+
+	if err := u.SQ.validate(); err != nil {
+		return err
+	}
+
+	if u.PQ.Enabled && u.SQ.Enabled {
+		return errors.Errorf("pq and sq cannot be enabled at the same time")
+	}
+
+	if u.SQ.Enabled && u.Distance == DistanceHamming {
+		return errors.Errorf("sq does not support the %s distance", DistanceHamming)
+	}
+
+	return nil
+}
+
+func (pq PQConfig) validate() error {
+	if pq.Segments < 0 {
+		return errors.Errorf("pq.segments must be 0 or greater, got %d",
+			pq.Segments)
+	}
+
+	if pq.Centroids < 1 || pq.Centroids > compressionhelpers.MaxCentroids {
+		return errors.Errorf("pq.centroids must be between 1 and %d, got %d",
+			compressionhelpers.MaxCentroids, pq.Centroids)
+	}
+
+	if pq.TrainingLimit < pq.Centroids {
+		return errors.Errorf("pq.trainingLimit must be at least as large as "+
+			"pq.centroids (%d), got %d", pq.Centroids, pq.TrainingLimit)
+	}
+
+	return nil
+}
+
+func (sq SQConfig) validate() error {
+	switch sq.Type {
+	case compressionhelpers.ScalarQuantizationFloat16,
+		compressionhelpers.ScalarQuantizationInt8:
+	default:
+		return errors.Errorf("sq.type %q is not supported, must be one of "+
+			"[%s, %s]", sq.Type, compressionhelpers.ScalarQuantizationFloat16,
+			compressionhelpers.ScalarQuantizationInt8)
+	}
+
+	if sq.TrainingLimit < 1 {
+		return errors.Errorf("sq.trainingLimit must be at least 1, got %d",
+			sq.TrainingLimit)
+	}
+
+	return nil
 }
 
 func optionalIntFromMap(in map[string]interface{}, name string,
@@ -232,6 +500,22 @@ func optionalBoolFromMap(in map[string]interface{}, name string,
 	return nil
 }
 
+func optionalStringFromMap(in map[string]interface{}, name string,
+	setFn func(v string)) error {
+	value, ok := in[name]
+	if !ok {
+		return nil
+	}
+
+	asString, ok := value.(string)
+	if !ok {
+		return errors.Errorf("%s must be a string, got %T", name, value)
+	}
+
+	setFn(asString)
+	return nil
+}
+
 func NewDefaultUserConfig() UserConfig {
 	uc := UserConfig{}
 	uc.SetDefaults()
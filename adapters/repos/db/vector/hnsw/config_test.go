@@ -19,6 +19,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_ValidConfig(t *testing.T) {
@@ -104,6 +105,20 @@ func Test_UserConfig(t *testing.T) {
 				EF:                     DefaultEF,
 				Skip:                   DefaultSkip,
 				FlatSearchCutoff:       DefaultFlatSearchCutoff,
+				Distance:               DefaultDistanceMetric,
+				PQ: PQConfig{
+					Enabled:       DefaultPQEnabled,
+					Segments:      DefaultPQSegments,
+					Centroids:     DefaultPQCentroids,
+					TrainingLimit: DefaultPQTrainingLimit,
+				},
+				SQ: SQConfig{
+					Enabled:       DefaultSQEnabled,
+					Type:          DefaultSQType,
+					Rescore:       DefaultSQRescore,
+					TrainingLimit: DefaultSQTrainingLimit,
+				},
+				FilterStrategy: DefaultFilterStrategy,
 			},
 		},
 
@@ -119,6 +134,20 @@ func Test_UserConfig(t *testing.T) {
 				VectorCacheMaxObjects:  DefaultVectorCacheMaxObjects,
 				EF:                     DefaultEF,
 				FlatSearchCutoff:       DefaultFlatSearchCutoff,
+				Distance:               DefaultDistanceMetric,
+				PQ: PQConfig{
+					Enabled:       DefaultPQEnabled,
+					Segments:      DefaultPQSegments,
+					Centroids:     DefaultPQCentroids,
+					TrainingLimit: DefaultPQTrainingLimit,
+				},
+				SQ: SQConfig{
+					Enabled:       DefaultSQEnabled,
+					Type:          DefaultSQType,
+					Rescore:       DefaultSQRescore,
+					TrainingLimit: DefaultSQTrainingLimit,
+				},
+				FilterStrategy: DefaultFilterStrategy,
 			},
 		},
 
@@ -132,6 +161,21 @@ func Test_UserConfig(t *testing.T) {
 				"ef":                     json.Number("15"),
 				"flatSearchCutoff":       json.Number("16"),
 				"skip":                   true,
+				"distance":               "l2-squared",
+				"pq": map[string]interface{}{
+					"enabled":       true,
+					"segments":      json.Number("17"),
+					"centroids":     json.Number("18"),
+					"trainingLimit": json.Number("19"),
+				},
+				"sq": map[string]interface{}{
+					"enabled":       false,
+					"type":          "int8",
+					"rescore":       false,
+					"trainingLimit": json.Number("20"),
+				},
+				"filterStrategy":        "sweeping",
+				"acornFlatSearchCutoff": json.Number("21"),
 			},
 			expected: UserConfig{
 				CleanupIntervalSeconds: 11,
@@ -141,6 +185,19 @@ func Test_UserConfig(t *testing.T) {
 				EF:                     15,
 				FlatSearchCutoff:       16,
 				Skip:                   true,
+				Distance:               DistanceL2Squared,
+				PQ: PQConfig{
+					Enabled:       true,
+					Segments:      17,
+					Centroids:     18,
+					TrainingLimit: 19,
+				},
+				SQ: SQConfig{
+					Type:          "int8",
+					TrainingLimit: 20,
+				},
+				FilterStrategy:        FilterStrategySweeping,
+				AcornFlatSearchCutoff: 21,
 			},
 		},
 
@@ -163,6 +220,20 @@ func Test_UserConfig(t *testing.T) {
 				VectorCacheMaxObjects:  14,
 				EF:                     15,
 				FlatSearchCutoff:       16,
+				Distance:               DefaultDistanceMetric,
+				PQ: PQConfig{
+					Enabled:       DefaultPQEnabled,
+					Segments:      DefaultPQSegments,
+					Centroids:     DefaultPQCentroids,
+					TrainingLimit: DefaultPQTrainingLimit,
+				},
+				SQ: SQConfig{
+					Enabled:       DefaultSQEnabled,
+					Type:          DefaultSQType,
+					Rescore:       DefaultSQRescore,
+					TrainingLimit: DefaultSQTrainingLimit,
+				},
+				FilterStrategy: DefaultFilterStrategy,
 			},
 		},
 	}
@@ -176,3 +247,147 @@ func Test_UserConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_UserConfigDistance(t *testing.T) {
+	t.Run("with each supported distance", func(t *testing.T) {
+		for _, dist := range []string{DistanceCosine, DistanceDot,
+			DistanceL2Squared, DistanceManhattan, DistanceHamming} {
+			cfg, err := ParseUserConfig(map[string]interface{}{
+				"distance": dist,
+			})
+			require.Nil(t, err)
+			assert.Equal(t, dist, cfg.(UserConfig).Distance)
+			assert.Equal(t, dist, cfg.DistanceName())
+		}
+	})
+
+	t.Run("with an unsupported distance", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"distance": "euclidean-ish",
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "distance \"euclidean-ish\" is not supported")
+	})
+
+	t.Run("with a distance that is not a string", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"distance": json.Number("7"),
+		})
+		require.NotNil(t, err)
+	})
+}
+
+func Test_UserConfigPQ(t *testing.T) {
+	t.Run("with pq not being an object", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"pq": true,
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "pq must be an object")
+	})
+
+	t.Run("with too many centroids", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"pq": map[string]interface{}{
+				"centroids": json.Number("300"),
+			},
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "pq.centroids must be between 1 and 256")
+	})
+
+	t.Run("with negative segments", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"pq": map[string]interface{}{
+				"segments": json.Number("-1"),
+			},
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "pq.segments must be 0 or greater")
+	})
+
+	t.Run("with a training limit below the centroids", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"pq": map[string]interface{}{
+				"trainingLimit": json.Number("100"),
+			},
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "pq.trainingLimit must be at least")
+	})
+}
+
+func Test_UserConfigSQ(t *testing.T) {
+	t.Run("with each supported type", func(t *testing.T) {
+		for _, typ := range []string{"float16", "int8"} {
+			cfg, err := ParseUserConfig(map[string]interface{}{
+				"distance": "l2-squared",
+				"sq": map[string]interface{}{
+					"enabled": true,
+					"type":    typ,
+				},
+			})
+			require.Nil(t, err)
+			assert.Equal(t, typ, cfg.(UserConfig).SQ.Type)
+			assert.True(t, cfg.(UserConfig).SQ.Enabled)
+			assert.True(t, cfg.(UserConfig).SQ.Rescore)
+		}
+	})
+
+	t.Run("with sq not being an object", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"sq": "int8",
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "sq must be an object")
+	})
+
+	t.Run("with an unsupported type", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"sq": map[string]interface{}{
+				"type": "int4",
+			},
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "sq.type \"int4\" is not supported")
+	})
+
+	t.Run("with pq enabled as well", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"pq": map[string]interface{}{"enabled": true},
+			"sq": map[string]interface{}{"enabled": true},
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "pq and sq cannot be enabled at the same time")
+	})
+
+	t.Run("with the hamming distance", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"distance": "hamming",
+			"sq":       map[string]interface{}{"enabled": true},
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "sq does not support the hamming distance")
+	})
+}
+
+func Test_UserConfigFilterStrategy(t *testing.T) {
+	t.Run("with each supported strategy", func(t *testing.T) {
+		for _, strategy := range []string{FilterStrategySweeping,
+			FilterStrategyAcorn} {
+			cfg, err := ParseUserConfig(map[string]interface{}{
+				"filterStrategy": strategy,
+			})
+			require.Nil(t, err)
+			assert.Equal(t, strategy, cfg.(UserConfig).FilterStrategy)
+		}
+	})
+
+	t.Run("with an unsupported strategy", func(t *testing.T) {
+		_, err := ParseUserConfig(map[string]interface{}{
+			"filterStrategy": "bruteforce",
+		})
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "filterStrategy \"bruteforce\" is not supported")
+	})
+}
@@ -29,15 +29,9 @@ func ValidateUserConfigUpdate(initial, updated schema.VectorIndexConfig) error {
 		return errors.Errorf("updated is not UserConfig, but %T", updated)
 	}
 
+	// efConstruction and maxConnections are not contained in this list, changing
+	// them triggers a rebuild of the graph, see startRebuild
 	immutableFields := []immutableInt{
-		{
-			name:     "efConstruction",
-			accessor: func(c UserConfig) int { return c.EFConstruction },
-		},
-		{
-			name:     "maxConnections",
-			accessor: func(c UserConfig) int { return c.MaxConnections },
-		},
 		{
 			// NOTE: There isn't a technical reason for this to be immutable, it
 			// simply hasn't been implemented yet. It would require to stop the
@@ -54,6 +48,66 @@ func ValidateUserConfigUpdate(initial, updated schema.VectorIndexConfig) error {
 		}
 	}
 
+	// the distance metric is baked into the graph structure, changing it would
+	// require a full rebuild of the index
+	if initialParsed.Distance != updatedParsed.Distance {
+		return errors.Errorf("distance is immutable: "+
+			"attempted change from \"%s\" to \"%s\"",
+			initialParsed.Distance, updatedParsed.Distance)
+	}
+
+	if err := validatePQConfigUpdate(initialParsed.PQ, updatedParsed.PQ); err != nil {
+		return err
+	}
+
+	return validateSQConfigUpdate(initialParsed.SQ, updatedParsed.SQ)
+}
+
+// validatePQConfigUpdate allows enabling compression at any time, but once
+// enabled the codebooks are persisted and neither their shape can change nor
+// can the compression be turned off again
+func validatePQConfigUpdate(initial, updated PQConfig) error {
+	if !initial.Enabled {
+		return nil
+	}
+
+	if !updated.Enabled {
+		return errors.Errorf("pq cannot be disabled after it has been enabled")
+	}
+
+	if initial.Segments != updated.Segments {
+		return errors.Errorf("pq.segments is immutable once pq is enabled: "+
+			"attempted change from \"%d\" to \"%d\"",
+			initial.Segments, updated.Segments)
+	}
+
+	if initial.Centroids != updated.Centroids {
+		return errors.Errorf("pq.centroids is immutable once pq is enabled: "+
+			"attempted change from \"%d\" to \"%d\"",
+			initial.Centroids, updated.Centroids)
+	}
+
+	return nil
+}
+
+// validateSQConfigUpdate allows enabling scalar quantization at any time and
+// changing whether results are rescored, but the type of quantization cannot
+// change once it is enabled
+func validateSQConfigUpdate(initial, updated SQConfig) error {
+	if !initial.Enabled {
+		return nil
+	}
+
+	if !updated.Enabled {
+		return errors.Errorf("sq cannot be disabled after it has been enabled")
+	}
+
+	if initial.Type != updated.Type {
+		return errors.Errorf("sq.type is immutable once sq is enabled: "+
+			"attempted change from \"%s\" to \"%s\"",
+			initial.Type, updated.Type)
+	}
+
 	return nil
 }
 
@@ -84,8 +138,44 @@ func (h *hnsw) UpdateUserConfig(updated schema.VectorIndexConfig) error {
 	// read on every single user-facing search, which can be highly concurrent
 	atomic.StoreInt64(&h.ef, int64(parsed.EF))
 	atomic.StoreInt64(&h.flatSearchCutoff, int64(parsed.FlatSearchCutoff))
+	atomic.StoreInt64(&h.acornFlatSearchCutoff, int64(parsed.AcornFlatSearchCutoff))
+	atomic.StoreInt32(&h.acornSearch, acornSearchFromStrategy(parsed.FilterStrategy))
+	atomic.StoreInt32(&h.rescoreCompressed, rescoreCompressedFromConfig(parsed))
 
 	h.cache.updateMaxSize(int64(parsed.VectorCacheMaxObjects))
+	if h.isCompressed() {
+		h.compressedVectorsCache.updateMaxSize(int64(parsed.VectorCacheMaxObjects))
+	} else if parsed.PQ.Enabled {
+		// compression on an existing index trains on the vectors already
+		// imported, no re-import is required
+		h.startCompression(parsed.PQ)
+	} else if parsed.SQ.Enabled {
+		h.startScalarQuantization(parsed.SQ)
+	}
+
+	if h.constructionParamsChanged(parsed) {
+		h.startRebuild(parsed)
+	}
 
 	return nil
 }
+
+// constructionParamsChanged is true if the graph needs to be rebuilt to
+// reflect the updated config. A rebuild that is already running counts as
+// the current graph.
+func (h *hnsw) constructionParamsChanged(updated UserConfig) bool {
+	h.rebuildLock.Lock()
+	job := h.rebuild
+	h.rebuildLock.Unlock()
+
+	current := h
+	if job != nil && job.isRunning() {
+		current = job.graph
+	}
+
+	current.Lock()
+	defer current.Unlock()
+
+	return current.maximumConnections != updated.MaxConnections ||
+		current.efConstruction != updated.EFConstruction
+}
@@ -31,20 +31,14 @@ func TestUserConfigUpdates(t *testing.T) {
 
 		tests := []test{
 			{
-				name:    "attempting to change ef construction",
+				name:    "changing ef construction",
 				initial: UserConfig{EFConstruction: 64},
 				update:  UserConfig{EFConstruction: 128},
-				expectedError: errors.Errorf(
-					"efConstruction is immutable: " +
-						"attempted change from \"64\" to \"128\""),
 			},
 			{
-				name:    "attempting to change ef construction",
+				name:    "changing max connections",
 				initial: UserConfig{MaxConnections: 10},
 				update:  UserConfig{MaxConnections: 15},
-				expectedError: errors.Errorf(
-					"maxConnections is immutable: " +
-						"attempted change from \"10\" to \"15\""),
 			},
 			{
 				name:    "attempting to change cleanup interval seconds",
@@ -54,6 +48,82 @@ func TestUserConfigUpdates(t *testing.T) {
 					"cleanupIntervalSeconds is immutable: " +
 						"attempted change from \"60\" to \"90\""),
 			},
+			{
+				name:    "attempting to change the distance",
+				initial: UserConfig{Distance: "cosine"},
+				update:  UserConfig{Distance: "l2-squared"},
+				expectedError: errors.Errorf(
+					"distance is immutable: " +
+						"attempted change from \"cosine\" to \"l2-squared\""),
+			},
+			{
+				name:    "enabling pq",
+				initial: UserConfig{PQ: PQConfig{Enabled: false}},
+				update:  UserConfig{PQ: PQConfig{Enabled: true, Centroids: 256}},
+			},
+			{
+				name:    "attempting to disable pq",
+				initial: UserConfig{PQ: PQConfig{Enabled: true}},
+				update:  UserConfig{PQ: PQConfig{Enabled: false}},
+				expectedError: errors.Errorf(
+					"pq cannot be disabled after it has been enabled"),
+			},
+			{
+				name:    "attempting to change pq segments",
+				initial: UserConfig{PQ: PQConfig{Enabled: true, Segments: 96}},
+				update:  UserConfig{PQ: PQConfig{Enabled: true, Segments: 192}},
+				expectedError: errors.Errorf(
+					"pq.segments is immutable once pq is enabled: " +
+						"attempted change from \"96\" to \"192\""),
+			},
+			{
+				name:    "attempting to change pq centroids",
+				initial: UserConfig{PQ: PQConfig{Enabled: true, Centroids: 256}},
+				update:  UserConfig{PQ: PQConfig{Enabled: true, Centroids: 128}},
+				expectedError: errors.Errorf(
+					"pq.centroids is immutable once pq is enabled: " +
+						"attempted change from \"256\" to \"128\""),
+			},
+			{
+				name:    "changing the pq training limit",
+				initial: UserConfig{PQ: PQConfig{Enabled: true, TrainingLimit: 1000}},
+				update:  UserConfig{PQ: PQConfig{Enabled: true, TrainingLimit: 2000}},
+			},
+			{
+				name:    "enabling sq",
+				initial: UserConfig{SQ: SQConfig{Enabled: false, Type: "int8"}},
+				update:  UserConfig{SQ: SQConfig{Enabled: true, Type: "int8"}},
+			},
+			{
+				name:    "attempting to disable sq",
+				initial: UserConfig{SQ: SQConfig{Enabled: true, Type: "int8"}},
+				update:  UserConfig{SQ: SQConfig{Enabled: false, Type: "int8"}},
+				expectedError: errors.Errorf(
+					"sq cannot be disabled after it has been enabled"),
+			},
+			{
+				name:    "attempting to change the sq type",
+				initial: UserConfig{SQ: SQConfig{Enabled: true, Type: "int8"}},
+				update:  UserConfig{SQ: SQConfig{Enabled: true, Type: "float16"}},
+				expectedError: errors.Errorf(
+					"sq.type is immutable once sq is enabled: " +
+						"attempted change from \"int8\" to \"float16\""),
+			},
+			{
+				name:    "toggling sq rescoring",
+				initial: UserConfig{SQ: SQConfig{Enabled: true, Type: "int8", Rescore: true}},
+				update:  UserConfig{SQ: SQConfig{Enabled: true, Type: "int8", Rescore: false}},
+			},
+			{
+				name:    "changing ef",
+				initial: UserConfig{EF: 100, Distance: "dot"},
+				update:  UserConfig{EF: 150, Distance: "dot"},
+			},
+			{
+				name:    "changing the filter strategy",
+				initial: UserConfig{FilterStrategy: FilterStrategyAcorn},
+				update:  UserConfig{FilterStrategy: FilterStrategySweeping},
+			},
 		}
 
 		for _, test := range tests {
@@ -23,6 +23,18 @@ import (
 // Delete attaches a tombstone to an item so it can be periodically cleaned up
 // later and the edges reassigned
 func (h *hnsw) Delete(id uint64) error {
+	h.swapLock.RLock()
+	defer h.swapLock.RUnlock()
+
+	if err := h.delete(id); err != nil {
+		return err
+	}
+
+	h.forwardDeleteToRebuild(id)
+	return nil
+}
+
+func (h *hnsw) delete(id uint64) error {
 	h.deleteLock.Lock()
 	defer h.deleteLock.Unlock()
 
@@ -116,13 +128,32 @@ func (h *hnsw) copyTombstonesToAllowList() helpers.AllowList {
 // CleanUpTombstonedNodes removes nodes with a tombstone and reassignes edges
 // that were previously pointing to the tombstoned nodes
 func (h *hnsw) CleanUpTombstonedNodes() error {
+	h.cleanupLock.Lock()
+	defer h.cleanupLock.Unlock()
+
+	h.swapLock.RLock()
+	defer h.swapLock.RUnlock()
+
+	if h.runningRebuild() != nil {
+		// the tombstones are contained in the new graph as well, they are
+		// cleaned up once it has replaced the current one
+		return nil
+	}
+
+	_, err := h.cleanUpTombstonedNodes()
+	return err
+}
+
+// cleanUpTombstonedNodes returns the number of removed nodes. The caller must
+// hold the cleanupLock and the swapLock.
+func (h *hnsw) cleanUpTombstonedNodes() (int, error) {
 	deleteList := h.copyTombstonesToAllowList()
 	if len(deleteList) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	if err := h.reassignNeighborsOf(deleteList); err != nil {
-		return errors.Wrap(err, "reassign neighbor edges")
+		return 0, errors.Wrap(err, "reassign neighbor edges")
 	}
 
 	for id := range deleteList {
@@ -137,7 +168,7 @@ func (h *hnsw) CleanUpTombstonedNodes() error {
 			node := h.nodes[id]
 			h.Unlock()
 			if err := h.deleteEntrypoint(node, deleteList); err != nil {
-				return errors.Wrap(err, "delete entrypoint")
+				return 0, errors.Wrap(err, "delete entrypoint")
 			}
 		}
 	}
@@ -149,21 +180,21 @@ func (h *hnsw) CleanUpTombstonedNodes() error {
 		h.tombstoneLock.Unlock()
 
 		if err := h.commitLog.DeleteNode(id); err != nil {
-			return err
+			return 0, err
 		}
 
 		if err := h.commitLog.RemoveTombstone(id); err != nil {
-			return err
+			return 0, err
 		}
 	}
 
 	if h.isEmpty() {
 		if err := h.reset(); err != nil {
-			return err
+			return 0, err
 		}
 	}
 
-	return nil
+	return len(deleteList), nil
 }
 
 func (h *hnsw) reassignNeighborsOf(deleteList helpers.AllowList) error {
@@ -182,7 +213,7 @@ func (h *hnsw) reassignNeighborsOf(deleteList helpers.AllowList) error {
 			continue
 		}
 
-		neighborVec, err := h.vectorForID(context.Background(), neighbor)
+		neighborVec, err := h.nodeVector(context.Background(), neighbor)
 		if err != nil {
 			var e storobj.ErrNotFound
 			if errors.As(err, &e) {
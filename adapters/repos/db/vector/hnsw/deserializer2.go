@@ -15,8 +15,10 @@ import (
 	"bufio"
 	"encoding/binary"
 	"io"
+	"math"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 	"github.com/sirupsen/logrus"
 )
 
@@ -34,6 +36,23 @@ type DeserializationResult struct {
 	// If there is no entry for the links at a level to be replaced, we must
 	// assume that all links were appended and prior stat must exist
 	LinksReplaced map[uint64]map[uint16]struct{}
+
+	// Compressed is set if a product quantizer was trained for this index, its
+	// codebooks are contained in PQData
+	Compressed bool
+	PQData     compressionhelpers.PQData
+
+	// ScalarQuantized is set if a scalar quantizer was fitted for this index,
+	// the data required to restore it is contained in SQData
+	ScalarQuantized bool
+	SQData          compressionhelpers.SQData
+
+	// ConstructionParamsSet is set if the maxConnections and efConstruction
+	// the graph was built with are known. Graphs persisted before they were
+	// recorded don't contain them.
+	ConstructionParamsSet bool
+	MaxConnections        int
+	EFConstruction        int
 }
 
 func (dr DeserializationResult) ReplaceLinks(node uint64, level uint16) bool {
@@ -112,6 +131,18 @@ func (c *Deserializer2) Do(fd *bufio.Reader,
 			out.Entrypoint = 0
 			out.Level = 0
 			out.Nodes = make([]*vertex, initialSize)
+			out.Compressed = false
+			out.PQData = compressionhelpers.PQData{}
+			out.ScalarQuantized = false
+			out.SQData = compressionhelpers.SQData{}
+			out.ConstructionParamsSet = false
+		case AddPQ:
+			readThisRound, err = c.ReadPQ(fd, out)
+		case SetConstructionParams:
+			err = c.ReadConstructionParams(fd, out)
+			readThisRound = 8
+		case AddSQ:
+			readThisRound, err = c.ReadSQ(fd, out)
 		default:
 			err = errors.Errorf("unrecognized commit type %d", ct)
 		}
@@ -378,6 +409,109 @@ func (c *Deserializer2) ReadDeleteNode(r io.Reader, res *DeserializationResult)
 	return nil
 }
 
+func (c *Deserializer2) ReadPQ(r io.Reader,
+	res *DeserializationResult) (int, error) {
+	dims, err := c.readUint16(r)
+	if err != nil {
+		return 0, err
+	}
+
+	segments, err := c.readUint16(r)
+	if err != nil {
+		return 0, err
+	}
+
+	centroids, err := c.readUint16(r)
+	if err != nil {
+		return 0, err
+	}
+
+	if segments == 0 || dims%segments != 0 {
+		return 0, errors.Errorf("invalid product quantizer: %d segments "+
+			"for %d dimensions", segments, dims)
+	}
+
+	ds := int(dims / segments)
+	codebooks := make([][]float32, int(segments)*int(centroids))
+	tmpBuf := make([]byte, 4*ds)
+	for i := range codebooks {
+		if _, err := io.ReadFull(r, tmpBuf); err != nil {
+			return 0, errors.Wrap(err, "failed to read centroid")
+		}
+
+		centroid := make([]float32, ds)
+		for j := range centroid {
+			centroid[j] = math.Float32frombits(
+				binary.LittleEndian.Uint32(tmpBuf[j*4 : (j+1)*4]))
+		}
+		codebooks[i] = centroid
+	}
+
+	res.Compressed = true
+	res.PQData = compressionhelpers.PQData{
+		Dimensions: dims,
+		Segments:   segments,
+		Centroids:  centroids,
+		Codebooks:  codebooks,
+	}
+
+	return 6 + len(codebooks)*ds*4, nil
+}
+
+func (c *Deserializer2) ReadSQ(r io.Reader,
+	res *DeserializationResult) (int, error) {
+	var kind [1]byte
+	if _, err := io.ReadFull(r, kind[:]); err != nil {
+		return 0, err
+	}
+
+	dims, err := c.readUint16(r)
+	if err != nil {
+		return 0, err
+	}
+
+	data := compressionhelpers.SQData{Kind: kind[0], Dimensions: dims}
+	read := 3
+	if data.Kind == compressionhelpers.SQKindInt8 {
+		tmpBuf := make([]byte, 4*int(dims))
+		for _, values := range []*[]float32{&data.Mins, &data.Scales} {
+			if _, err := io.ReadFull(r, tmpBuf); err != nil {
+				return 0, errors.Wrap(err, "failed to read value ranges")
+			}
+
+			*values = make([]float32, dims)
+			for j := range *values {
+				(*values)[j] = math.Float32frombits(
+					binary.LittleEndian.Uint32(tmpBuf[j*4 : (j+1)*4]))
+			}
+			read += len(tmpBuf)
+		}
+	}
+
+	res.ScalarQuantized = true
+	res.SQData = data
+
+	return read, nil
+}
+
+func (c *Deserializer2) ReadConstructionParams(r io.Reader,
+	res *DeserializationResult) error {
+	maxConnections, err := c.readUint32(r)
+	if err != nil {
+		return err
+	}
+
+	efConstruction, err := c.readUint32(r)
+	if err != nil {
+		return err
+	}
+
+	res.ConstructionParamsSet = true
+	res.MaxConnections = int(maxConnections)
+	res.EFConstruction = int(efConstruction)
+	return nil
+}
+
 func (c *Deserializer2) readUint64(r io.Reader) (uint64, error) {
 	var value uint64
 	tmpBuf := make([]byte, 8)
@@ -391,6 +525,16 @@ func (c *Deserializer2) readUint64(r io.Reader) (uint64, error) {
 	return value, nil
 }
 
+func (c *Deserializer2) readUint32(r io.Reader) (uint32, error) {
+	tmpBuf := make([]byte, 4)
+	_, err := io.ReadFull(r, tmpBuf)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read uint32")
+	}
+
+	return binary.LittleEndian.Uint32(tmpBuf), nil
+}
+
 func (c *Deserializer2) readUint16(r io.Reader) (uint16, error) {
 	var value uint16
 	tmpBuf := make([]byte, 2)
@@ -0,0 +1,83 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// DiskUsage is the size of the files which persist the graph of an index and
+// the codes of a scalar quantized index
+type DiskUsage struct {
+	CommitLogs         int
+	CommitLogBytes     int64
+	Snapshots          int
+	SnapshotBytes      int64
+	QuantizedCodeBytes int64
+}
+
+// DiskUsage measures the commit logs, snapshots and codes of the index. Files
+// which are written concurrently, such as the active commit log or a
+// condensed log in progress, are included with their current size.
+func (h *hnsw) DiskUsage() (DiskUsage, error) {
+	var out DiskUsage
+	var err error
+
+	out.CommitLogs, out.CommitLogBytes, err = dirUsage(
+		commitLogDirectory(h.rootPath, h.id))
+	if err != nil {
+		return out, errors.Wrap(err, "commit logs")
+	}
+
+	out.Snapshots, out.SnapshotBytes, err = dirUsage(
+		snapshotDirectory(h.rootPath, h.id))
+	if err != nil {
+		return out, errors.Wrap(err, "snapshots")
+	}
+
+	info, err := os.Stat(codeStorePath(h.rootPath, h.id))
+	if err != nil && !os.IsNotExist(err) {
+		return out, errors.Wrap(err, "quantized codes")
+	}
+	if err == nil {
+		out.QuantizedCodeBytes = info.Size()
+	}
+
+	return out, nil
+}
+
+// dirUsage returns the number and total size of the files in dir, a missing
+// dir is empty
+func dirUsage(dir string) (int, int64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+
+	count, size := 0, int64(0)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		count++
+		size += file.Size()
+	}
+
+	return count, size, nil
+}
@@ -24,6 +24,8 @@ package distancer
 import (
 	"fmt"
 	"math"
+
+	"github.com/pkg/errors"
 )
 
 func cosineSim(a, b []float32) (float32, error) {
@@ -117,3 +119,45 @@ func (p CosineProvider) Type() string {
 func NewCosineProvider() Provider {
 	return CosineProvider{}
 }
+
+// CosineDistance relies on the vectors being normalized, in which case the
+// cosine distance is identical to 1 - the dot product. This is considerably
+// cheaper than calculating the full cosine similarity on every comparison.
+type CosineDistance struct {
+	a []float32
+}
+
+func (d *CosineDistance) Distance(b []float32) (float32, bool, error) {
+	if len(d.a) != len(b) {
+		return 0, false, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(d.a), len(b))
+	}
+
+	dist := 1 - dotProductImplementation(d.a, b)
+	return dist, true, nil
+}
+
+type CosineDistanceProvider struct{}
+
+func NewCosineDistanceProvider() CosineDistanceProvider {
+	return CosineDistanceProvider{}
+}
+
+func (d CosineDistanceProvider) SingleDist(a, b []float32) (float32, bool, error) {
+	if len(a) != len(b) {
+		return 0, false, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(a), len(b))
+	}
+
+	prod := 1 - dotProductImplementation(a, b)
+
+	return prod, true, nil
+}
+
+func (d CosineDistanceProvider) Type() string {
+	return "cosine-dot"
+}
+
+func (d CosineDistanceProvider) New(a []float32) Distancer {
+	return &CosineDistance{a: a}
+}
@@ -0,0 +1,77 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer/asm"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/cpu"
+)
+
+func TestCompareDistanceImplementations(t *testing.T) {
+	if !cpu.X86.HasAVX2 {
+		t.Skip("AVX2 not present, assembly implementations are not used")
+	}
+
+	rand.Seed(time.Now().UnixNano())
+
+	type implementation struct {
+		name    string
+		pureGo  func(a, b []float32) float32
+		asm     func(a, b []float32) float32
+		relaxed bool // float rounding differs between the two
+	}
+
+	implementations := []implementation{
+		{name: "l2-squared", pureGo: L2SquaredGo, asm: asm.L2, relaxed: true},
+		{name: "manhattan", pureGo: ManhattanGo, asm: asm.Manhattan, relaxed: true},
+		{name: "hamming", pureGo: HammingGo, asm: asm.Hamming},
+	}
+
+	// include sizes which are not a multiple of the unrolled block size, so
+	// that the tail loop is covered as well
+	sizes := []int{1, 3, 8, 31, 32, 33, 100, 128, 300, 768}
+
+	for _, impl := range implementations {
+		for _, size := range sizes {
+			t.Run(fmt.Sprintf("%s with size %d", impl.name, size), func(t *testing.T) {
+				for i := 0; i < 100; i++ {
+					vec1 := make([]float32, size)
+					vec2 := make([]float32, size)
+					for j := range vec1 {
+						// use a coarse value range, so that the hamming distance
+						// sees both equal and differing dimensions
+						vec1[j] = float32(rand.Intn(4)) - 2
+						vec2[j] = float32(rand.Intn(4)) - 2
+						if impl.relaxed {
+							vec1[j] *= rand.Float32()
+							vec2[j] *= rand.Float32()
+						}
+					}
+
+					control := impl.pureGo(vec1, vec2)
+					res := impl.asm(vec1, vec2)
+					if impl.relaxed {
+						assert.InEpsilon(t, control+1, res+1, 0.0001)
+					} else {
+						assert.Equal(t, control, res)
+					}
+				}
+			})
+		}
+	}
+}
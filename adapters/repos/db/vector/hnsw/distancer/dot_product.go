@@ -29,6 +29,9 @@ var dotProductImplementation func(a, b []float32) float32 = func(a, b []float32)
 	return sum
 }
 
+// DotProduct distance is the negative dot product, so that vectors with a
+// higher dot product are considered closer. Unlike the cosine-dot distance,
+// it does not require the vectors to be normalized.
 type DotProduct struct {
 	a []float32
 }
@@ -39,7 +42,7 @@ func (d *DotProduct) Distance(b []float32) (float32, bool, error) {
 			len(d.a), len(b))
 	}
 
-	dist := 1 - dotProductImplementation(d.a, b)
+	dist := -dotProductImplementation(d.a, b)
 	return dist, true, nil
 }
 
@@ -64,13 +67,13 @@ func (d DotProductProvider) SingleDist(a, b []float32) (float32, bool, error) {
 			len(a), len(b))
 	}
 
-	prod := 1 - dotProductImplementation(a, b)
+	prod := -dotProductImplementation(a, b)
 
 	return prod, true, nil
 }
 
 func (d DotProductProvider) Type() string {
-	return "cosine-dot"
+	return "dot"
 }
 
 func (d DotProductProvider) New(a []float32) Distancer {
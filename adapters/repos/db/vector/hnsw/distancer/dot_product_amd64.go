@@ -19,5 +19,8 @@ import (
 func init() {
 	if cpu.X86.HasAVX2 {
 		dotProductImplementation = asm.Dot
+		l2SquaredImplementation = asm.L2
+		manhattanImplementation = asm.Manhattan
+		hammingImplementation = asm.Hamming
 	}
 }
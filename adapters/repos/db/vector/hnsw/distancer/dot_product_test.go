@@ -0,0 +1,68 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDotDistancer(t *testing.T) {
+	t.Run("the higher the dot product, the lower the distance", func(t *testing.T) {
+		query := []float32{3, 4, 5}
+		close := []float32{3, 4, 5}
+		far := []float32{1, 1, 1}
+
+		dist := NewDotProductProvider().New(query)
+		distClose, ok, err := dist.Distance(close)
+		require.Nil(t, err)
+		require.True(t, ok)
+		distFar, ok, err := dist.Distance(far)
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		assert.Equal(t, float32(-50), distClose)
+		assert.Equal(t, float32(-12), distFar)
+		assert.Less(t, distClose, distFar)
+	})
+
+	t.Run("single dist matches reusable distancer", func(t *testing.T) {
+		vec1 := []float32{0.1, 0.3, 0.7}
+		vec2 := []float32{0.2, 0.2, 0.2}
+
+		dist, ok, err := NewDotProductProvider().New(vec1).Distance(vec2)
+		require.Nil(t, err)
+		require.True(t, ok)
+		control, ok, err := NewDotProductProvider().SingleDist(vec1, vec2)
+		require.True(t, ok)
+		require.Nil(t, err)
+		assert.Equal(t, control, dist)
+	})
+}
+
+func TestCosineDistanceDistancer(t *testing.T) {
+	vec1 := Normalize([]float32{0.1, 0.3, 0.7})
+	vec2 := Normalize([]float32{0.2, 0.2, 0.2})
+
+	dist, ok, err := NewCosineDistanceProvider().New(vec1).Distance(vec2)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	// on normalized vectors the cosine-dot distance must be identical to the
+	// regular cosine distance
+	control, ok, err := NewCosineProvider().SingleDist(vec1, vec2)
+	require.True(t, ok)
+	require.Nil(t, err)
+	assert.InDelta(t, control, dist, 0.0001)
+}
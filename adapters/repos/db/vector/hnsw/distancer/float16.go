@@ -0,0 +1,105 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import "math"
+
+// float16Table contains the float32 value of every possible half precision
+// float, so that decoding is a single lookup in the distance kernels
+var float16Table = func() []float32 {
+	table := make([]float32, 1<<16)
+	for i := range table {
+		table[i] = float16ToFloat32(uint16(i))
+	}
+	return table
+}()
+
+// Float32ToFloat16 converts to an IEEE 754 half precision float using round
+// to nearest even. Values outside of the half precision range become
+// infinite, values too small to be represented become zero.
+func Float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	rawExp := (bits >> 23) & 0xff
+	mant := bits & 0x7fffff
+
+	if rawExp == 0xff {
+		if mant != 0 {
+			// NaN
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+	}
+
+	exp := int32(rawExp) - 127 + 15
+	if exp >= 0x1f {
+		return sign | 0x7c00
+	}
+
+	if exp <= 0 {
+		if exp < -10 {
+			return sign
+		}
+
+		// subnormal half, the implicit leading bit becomes explicit
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := mant >> shift
+		rem := mant & (1<<shift - 1)
+		halfway := uint32(1) << (shift - 1)
+		if rem > halfway || (rem == halfway && half&1 == 1) {
+			half++
+		}
+		return sign | uint16(half)
+	}
+
+	half := uint32(exp)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && half&1 == 1) {
+		// a carry into the exponent is intended, it correctly rounds up to the
+		// next power of two or to infinity
+		half++
+	}
+	return sign | uint16(half)
+}
+
+// Float16ToFloat32 converts an IEEE 754 half precision float to float32,
+// which is always exact
+func Float16ToFloat32(h uint16) float32 {
+	return float16Table[h]
+}
+
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+
+		// subnormal half, normalize it for the float32 representation
+		e := uint32(127 - 15 + 1)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		mant &= 0x3ff
+		return math.Float32frombits(sign | e<<23 | mant<<13)
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	default:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+	}
+}
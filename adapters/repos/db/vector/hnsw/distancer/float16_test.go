@@ -0,0 +1,66 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloat16Conversion(t *testing.T) {
+	tests := []struct {
+		name string
+		in   float32
+		bits uint16
+	}{
+		{name: "zero", in: 0, bits: 0x0000},
+		{name: "negative zero", in: float32(math.Copysign(0, -1)), bits: 0x8000},
+		{name: "one", in: 1, bits: 0x3c00},
+		{name: "negative two", in: -2, bits: 0xc000},
+		{name: "largest half", in: 65504, bits: 0x7bff},
+		{name: "overflow", in: 70000, bits: 0x7c00},
+		{name: "smallest normal", in: float32(math.Pow(2, -14)), bits: 0x0400},
+		{name: "smallest subnormal", in: float32(math.Pow(2, -24)), bits: 0x0001},
+		{name: "underflow", in: float32(math.Pow(2, -26)), bits: 0x0000},
+		{name: "infinity", in: float32(math.Inf(1)), bits: 0x7c00},
+		// 1 + 2^-11 is exactly between two halfs, rounds to even
+		{name: "ties to even", in: 1 + float32(math.Pow(2, -11)), bits: 0x3c00},
+		{name: "rounds up", in: 1 + 3*float32(math.Pow(2, -12)), bits: 0x3c01},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.bits, Float32ToFloat16(test.in))
+		})
+	}
+
+	t.Run("every finite half survives a round trip", func(t *testing.T) {
+		for i := 0; i < 1<<16; i++ {
+			h := uint16(i)
+			if h&0x7c00 == 0x7c00 {
+				// infinity and NaN
+				continue
+			}
+
+			assert.Equal(t, h, Float32ToFloat16(Float16ToFloat32(h)))
+		}
+	})
+
+	t.Run("relative error stays within half precision", func(t *testing.T) {
+		for _, f := range []float32{0.1, 0.333, 3.14159, -123.456, 0.000123} {
+			decoded := Float16ToFloat32(Float32ToFloat16(f))
+			assert.InEpsilon(t, f, decoded, 1e-3)
+		}
+	})
+}
@@ -16,6 +16,10 @@ import (
 	"math"
 )
 
+// EarthRadius in meters, geo distances are measured on a sphere of this
+// radius
+const EarthRadius = float64(6371e3)
+
 func geoDist(a, b []float32) (float32, bool, error) {
 	if len(a) != 2 || len(b) != 2 {
 		return 0, false, fmt.Errorf("distance vectors must have len 2")
@@ -25,7 +29,7 @@ func geoDist(a, b []float32) (float32, bool, error) {
 	latB := b[0]
 	lonA := a[1]
 	lonB := b[1]
-	const R = float64(6371e3)
+	const R = EarthRadius
 
 	latARadian := float64(latA * math.Pi / 180)
 	latBRadian := float64(latB * math.Pi / 180)
@@ -0,0 +1,72 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"github.com/pkg/errors"
+)
+
+// hammingImplementation counts the dimensions in which two vectors differ. The
+// values are compared exactly and the count is returned as a float32 like any
+// other distance. With AVX2 it is replaced by asm.Hamming, which compares
+// eight dimensions at once and adds 1.0 for every lane that differs.
+var hammingImplementation func(a, b []float32) float32 = HammingGo
+
+type Hamming struct {
+	a []float32
+}
+
+func (h Hamming) Distance(b []float32) (float32, bool, error) {
+	if len(h.a) != len(b) {
+		return 0, false, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(h.a), len(b))
+	}
+
+	return hammingImplementation(h.a, b), true, nil
+}
+
+// HammingProvider is meant for vectors of discrete values, such as binary or
+// categorical features. Two values which only differ by a rounding error
+// count as a full difference, which makes it a poor fit for embeddings.
+type HammingProvider struct{}
+
+func NewHammingProvider() HammingProvider {
+	return HammingProvider{}
+}
+
+func HammingGo(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		if a[i] != b[i] {
+			sum += 1
+		}
+	}
+
+	return sum
+}
+
+func (h HammingProvider) SingleDist(a, b []float32) (float32, bool, error) {
+	if len(a) != len(b) {
+		return 0, false, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(a), len(b))
+	}
+
+	return hammingImplementation(a, b), true, nil
+}
+
+func (h HammingProvider) Type() string {
+	return "hamming"
+}
+
+func (h HammingProvider) New(a []float32) Distancer {
+	return Hamming{a: a}
+}
@@ -0,0 +1,39 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHammingDistancer(t *testing.T) {
+	vec1 := []float32{3, 4, 5, 6}
+	vec2 := []float32{3, 6, 5, 7}
+
+	dist, ok, err := NewHammingProvider().New(vec1).Distance(vec2)
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, float32(2), dist)
+
+	control, ok, err := NewHammingProvider().SingleDist(vec1, vec2)
+	require.True(t, ok)
+	require.Nil(t, err)
+	assert.Equal(t, control, dist)
+}
+
+func TestHammingDistancerDimensionMismatch(t *testing.T) {
+	_, _, err := NewHammingProvider().New([]float32{1, 2}).Distance([]float32{1, 2, 3})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,71 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"github.com/pkg/errors"
+)
+
+// l2SquaredImplementation is the euclidean distance without the final square
+// root. Leaving it out keeps the order of the distances, which is all the
+// graph needs, and saves a sqrt on every comparison. With AVX2 it is replaced
+// by asm.L2, which accumulates the squared differences using fused
+// multiply-adds.
+var l2SquaredImplementation func(a, b []float32) float32 = L2SquaredGo
+
+type L2Squared struct {
+	a []float32
+}
+
+func (l L2Squared) Distance(b []float32) (float32, bool, error) {
+	if len(l.a) != len(b) {
+		return 0, false, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(l.a), len(b))
+	}
+
+	return l2SquaredImplementation(l.a, b), true, nil
+}
+
+// L2SquaredProvider reports the squared distance as well, e.g. two vectors
+// which are 2 apart have a distance of 4.
+type L2SquaredProvider struct{}
+
+func NewL2SquaredProvider() L2SquaredProvider {
+	return L2SquaredProvider{}
+}
+
+func L2SquaredGo(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		diff := a[i] - b[i]
+		sum += diff * diff
+	}
+
+	return sum
+}
+
+func (l L2SquaredProvider) SingleDist(a, b []float32) (float32, bool, error) {
+	if len(a) != len(b) {
+		return 0, false, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(a), len(b))
+	}
+
+	return l2SquaredImplementation(a, b), true, nil
+}
+
+func (l L2SquaredProvider) Type() string {
+	return "l2-squared"
+}
+
+func (l L2SquaredProvider) New(a []float32) Distancer {
+	return L2Squared{a: a}
+}
@@ -0,0 +1,39 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL2SquaredDistancer(t *testing.T) {
+	vec1 := []float32{3, 4, 5}
+	vec2 := []float32{1, 2, 3}
+
+	dist, ok, err := NewL2SquaredProvider().New(vec1).Distance(vec2)
+	require.Nil(t, err)
+	require.True(t, ok)
+	assert.Equal(t, float32(12), dist)
+
+	control, ok, err := NewL2SquaredProvider().SingleDist(vec1, vec2)
+	require.True(t, ok)
+	require.Nil(t, err)
+	assert.Equal(t, control, dist)
+}
+
+func TestL2SquaredDistancerDimensionMismatch(t *testing.T) {
+	_, _, err := NewL2SquaredProvider().New([]float32{1, 2}).Distance([]float32{1, 2, 3})
+	assert.NotNil(t, err)
+}
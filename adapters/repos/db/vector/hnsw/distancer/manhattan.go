@@ -0,0 +1,73 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"github.com/pkg/errors"
+)
+
+// manhattanImplementation sums the absolute differences of two vectors. With
+// AVX2 it is replaced by asm.Manhattan, which takes the absolute values by
+// clearing the sign bits instead of branching.
+var manhattanImplementation func(a, b []float32) float32 = ManhattanGo
+
+type Manhattan struct {
+	a []float32
+}
+
+func (m Manhattan) Distance(b []float32) (float32, bool, error) {
+	if len(m.a) != len(b) {
+		return 0, false, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(m.a), len(b))
+	}
+
+	return manhattanImplementation(m.a, b), true, nil
+}
+
+// ManhattanProvider is the L1 distance. As the differences are not squared, a
+// large difference in a single dimension weighs less than with l2-squared,
+// which makes it less sensitive to outliers.
+type ManhattanProvider struct{}
+
+func NewManhattanProvider() ManhattanProvider {
+	return ManhattanProvider{}
+}
+
+func ManhattanGo(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		diff := a[i] - b[i]
+		if diff < 0 {
+			diff = -diff
+		}
+		sum += diff
+	}
+
+	return sum
+}
+
+func (m ManhattanProvider) SingleDist(a, b []float32) (float32, bool, error) {
+	if len(a) != len(b) {
+		return 0, false, errors.Errorf("vector lengths don't match: %d vs %d",
+			len(a), len(b))
+	}
+
+	return manhattanImplementation(a, b), true, nil
+}
+
+func (m ManhattanProvider) Type() string {
+	return "manhattan"
+}
+
+func (m ManhattanProvider) New(a []float32) Distancer {
+	return Manhattan{a: a}
+}
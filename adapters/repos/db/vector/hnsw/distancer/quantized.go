@@ -0,0 +1,155 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+// The kernels in this file calculate distances directly on scalar quantized
+// vectors without decoding them first.
+//
+// Float16 codes store each dimension as a half precision float in two little
+// endian bytes.
+//
+// Int8 codes store each dimension as a single byte, which is mapped linearly
+// onto the value range of that dimension, i.e. the value of dimension i is
+// mins[i] + code[i]*scales[i].
+//
+// Just like the dotProductImplementation, the DotProduct kernels return the
+// pure product, not the dot product distance. Length checks are the
+// responsibility of the caller.
+
+func float16At(code []byte, i int) float32 {
+	return float16Table[uint16(code[2*i])|uint16(code[2*i+1])<<8]
+}
+
+func L2SquaredFloat16(a, b []byte) float32 {
+	var sum float32
+	for i := 0; i < len(a)/2; i++ {
+		diff := float16At(a, i) - float16At(b, i)
+		sum += diff * diff
+	}
+
+	return sum
+}
+
+func L2SquaredFloat16Query(query []float32, code []byte) float32 {
+	var sum float32
+	for i := range query {
+		diff := query[i] - float16At(code, i)
+		sum += diff * diff
+	}
+
+	return sum
+}
+
+func DotProductFloat16(a, b []byte) float32 {
+	var sum float32
+	for i := 0; i < len(a)/2; i++ {
+		sum += float16At(a, i) * float16At(b, i)
+	}
+
+	return sum
+}
+
+func DotProductFloat16Query(query []float32, code []byte) float32 {
+	var sum float32
+	for i := range query {
+		sum += query[i] * float16At(code, i)
+	}
+
+	return sum
+}
+
+func ManhattanFloat16(a, b []byte) float32 {
+	var sum float32
+	for i := 0; i < len(a)/2; i++ {
+		sum += abs(float16At(a, i) - float16At(b, i))
+	}
+
+	return sum
+}
+
+func ManhattanFloat16Query(query []float32, code []byte) float32 {
+	var sum float32
+	for i := range query {
+		sum += abs(query[i] - float16At(code, i))
+	}
+
+	return sum
+}
+
+func L2SquaredInt8(a, b []byte, scales []float32) float32 {
+	var sum float32
+	for i := range a {
+		// the offsets of both values cancel out
+		diff := (float32(a[i]) - float32(b[i])) * scales[i]
+		sum += diff * diff
+	}
+
+	return sum
+}
+
+func L2SquaredInt8Query(query []float32, code []byte, mins,
+	scales []float32) float32 {
+	var sum float32
+	for i := range query {
+		diff := query[i] - (mins[i] + float32(code[i])*scales[i])
+		sum += diff * diff
+	}
+
+	return sum
+}
+
+func DotProductInt8(a, b []byte, mins, scales []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += (mins[i] + float32(a[i])*scales[i]) *
+			(mins[i] + float32(b[i])*scales[i])
+	}
+
+	return sum
+}
+
+func DotProductInt8Query(query []float32, code []byte, mins,
+	scales []float32) float32 {
+	var sum float32
+	for i := range query {
+		sum += query[i] * (mins[i] + float32(code[i])*scales[i])
+	}
+
+	return sum
+}
+
+func ManhattanInt8(a, b []byte, scales []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += abs((float32(a[i]) - float32(b[i])) * scales[i])
+	}
+
+	return sum
+}
+
+func ManhattanInt8Query(query []float32, code []byte, mins,
+	scales []float32) float32 {
+	var sum float32
+	for i := range query {
+		sum += abs(query[i] - (mins[i] + float32(code[i])*scales[i]))
+	}
+
+	return sum
+}
+
+func abs(in float32) float32 {
+	if in < 0 {
+		return -in
+	}
+
+	return in
+}
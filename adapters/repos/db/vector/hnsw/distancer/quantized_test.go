@@ -0,0 +1,102 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package distancer
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodeFloat16ForTest(vec []float32) ([]byte, []float32) {
+	code := make([]byte, 2*len(vec))
+	decoded := make([]float32, len(vec))
+	for i, v := range vec {
+		h := Float32ToFloat16(v)
+		code[2*i] = byte(h)
+		code[2*i+1] = byte(h >> 8)
+		decoded[i] = Float16ToFloat32(h)
+	}
+	return code, decoded
+}
+
+func encodeInt8ForTest(vec, mins, scales []float32) ([]byte, []float32) {
+	code := make([]byte, len(vec))
+	decoded := make([]float32, len(vec))
+	for i, v := range vec {
+		code[i] = byte((v - mins[i]) / scales[i])
+		decoded[i] = mins[i] + float32(code[i])*scales[i]
+	}
+	return code, decoded
+}
+
+// The quantized kernels must produce the same results as the float32
+// kernels applied to the decoded vectors
+func TestQuantizedKernels(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	dims := 48
+	randomVec := func() []float32 {
+		vec := make([]float32, dims)
+		for i := range vec {
+			vec[i] = r.Float32()*2 - 1
+		}
+		return vec
+	}
+
+	mins := make([]float32, dims)
+	scales := make([]float32, dims)
+	for i := range mins {
+		mins[i] = -1
+		scales[i] = 2.0 / 255
+	}
+
+	manhattan := func(a, b []float32) float32 {
+		var sum float32
+		for i := range a {
+			sum += abs(a[i] - b[i])
+		}
+		return sum
+	}
+
+	for i := 0; i < 20; i++ {
+		a, b, query := randomVec(), randomVec(), randomVec()
+
+		t.Run("float16", func(t *testing.T) {
+			codeA, decA := encodeFloat16ForTest(a)
+			codeB, decB := encodeFloat16ForTest(b)
+
+			assert.InDelta(t, L2SquaredGo(decA, decB), L2SquaredFloat16(codeA, codeB), 1e-4)
+			assert.InDelta(t, L2SquaredGo(query, decB), L2SquaredFloat16Query(query, codeB), 1e-4)
+			assert.InDelta(t, 1-DotProductGo(decA, decB), DotProductFloat16(codeA, codeB), 1e-4)
+			assert.InDelta(t, 1-DotProductGo(query, decB), DotProductFloat16Query(query, codeB), 1e-4)
+			assert.InDelta(t, manhattan(decA, decB), ManhattanFloat16(codeA, codeB), 1e-4)
+			assert.InDelta(t, manhattan(query, decB), ManhattanFloat16Query(query, codeB), 1e-4)
+		})
+
+		t.Run("int8", func(t *testing.T) {
+			codeA, decA := encodeInt8ForTest(a, mins, scales)
+			codeB, decB := encodeInt8ForTest(b, mins, scales)
+
+			assert.InDelta(t, L2SquaredGo(decA, decB), L2SquaredInt8(codeA, codeB, scales), 1e-4)
+			assert.InDelta(t, L2SquaredGo(query, decB),
+				L2SquaredInt8Query(query, codeB, mins, scales), 1e-4)
+			assert.InDelta(t, 1-DotProductGo(decA, decB),
+				DotProductInt8(codeA, codeB, mins, scales), 1e-4)
+			assert.InDelta(t, 1-DotProductGo(query, decB),
+				DotProductInt8Query(query, codeB, mins, scales), 1e-4)
+			assert.InDelta(t, manhattan(decA, decB), ManhattanInt8(codeA, codeB, scales), 1e-4)
+			assert.InDelta(t, manhattan(query, decB),
+				ManhattanInt8Query(query, codeB, mins, scales), 1e-4)
+		})
+	}
+}
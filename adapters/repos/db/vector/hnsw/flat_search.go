@@ -18,6 +18,14 @@ import (
 
 func (h *hnsw) flatSearch(queryVector []float32, limit int,
 	allowList helpers.AllowList) ([]uint64, []float32, error) {
+	// on a compressed index the candidates are compared using the approximate
+	// distances, so a larger candidate set is retrieved and then rescored
+	k := limit
+	rescore := h.shouldRescore()
+	if rescore {
+		limit = rescoreFactor * k
+	}
+
 	results := priorityqueue.NewMax(limit)
 
 	for candidate := range allowList {
@@ -46,6 +54,10 @@ func (h *hnsw) flatSearch(queryVector []float32, limit int,
 		}
 	}
 
+	if rescore {
+		return h.rescore(queryVector, results, k)
+	}
+
 	ids := make([]uint64, results.Len())
 	dists := make([]float32, results.Len())
 
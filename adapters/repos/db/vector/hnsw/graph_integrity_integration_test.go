@@ -60,7 +60,7 @@ func TestGraphIntegrity(t *testing.T) {
 			VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
 				return vectors[int(id)], nil
 			},
-			DistanceProvider: distancer.NewDotProductProvider(),
+			DistanceProvider: distancer.NewCosineDistanceProvider(),
 		}, UserConfig{
 			MaxConnections: maxNeighbors,
 			EFConstruction: efConstruction,
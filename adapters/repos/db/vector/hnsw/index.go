@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
 	"github.com/semi-technologies/weaviate/entities/storobj"
@@ -35,6 +36,9 @@ type hnsw struct {
 	// blocking the general usage of the hnsw index
 	deleteLock *sync.Mutex
 
+	// cleanupLock serializes the tombstone cleanup cycle and on-demand repairs
+	cleanupLock *sync.Mutex
+
 	tombstoneLock *sync.RWMutex
 
 	// make sure the very first insert happens just once, otherwise we
@@ -68,6 +72,14 @@ type hnsw struct {
 	// on filtered searches with less than n elements, perform flat search
 	flatSearchCutoff int64
 
+	// replaces flatSearchCutoff while the acorn traversal is used
+	acornFlatSearchCutoff int64
+
+	// 1 if filtered searches should use the filter-aware (acorn) traversal on
+	// the lowest level, 0 for the regular (sweeping) traversal. Read
+	// atomically, as it can be changed at runtime.
+	acornSearch int32
+
 	levelNormalizer float64
 
 	nodes []*vertex
@@ -76,6 +88,10 @@ type hnsw struct {
 
 	cache cache
 
+	// cacheBudget limits the memory of all vector caches on the node, the
+	// compressed cache is registered with it once it is created
+	cacheBudget *CacheBudget
+
 	commitLog CommitLogger
 
 	// a lookup of current tombstones (i.e. nodes that have received a tombstone,
@@ -101,6 +117,52 @@ type hnsw struct {
 	pools *pools
 
 	forbidFlat bool // mostly used in testing scenarios where we want to use the index even in scenarios where we typically wouldn't
+
+	// the uncached source of the full vectors, typically the objects bucket.
+	// When the index is compressed it is used to rescore the final candidates
+	vectorForIDThunk VectorForID
+
+	// compressed is set atomically once product or scalar quantization is in
+	// use. From then on distances are calculated on the codes held in the
+	// compressedVectorsCache. pq is only set for product quantization.
+	compressed             int32
+	quantizer              compressionhelpers.Quantizer
+	pq                     *compressionhelpers.ProductQuantizer
+	compressedVectorsCache *compressedShardedLockCache
+
+	// rescoreCompressed is set atomically and controls whether the final
+	// candidates of a compressed index are rescored using the full vectors.
+	// This is always the case for product quantization, but optional for
+	// scalar quantization.
+	rescoreCompressed int32
+
+	// compressLock serializes compression attempts and guards the
+	// pendingCompression that is waiting for more vectors to be imported
+	compressLock                *sync.Mutex
+	pendingCompression          func() error
+	pendingCompressionInserts   int
+	compressPending             int32
+	insertsSinceCompressAttempt int64
+
+	// the pq and sq configs the index was created with, compression is
+	// started on startup if either is enabled, but the index is not
+	// compressed yet
+	initialPQConfig PQConfig
+	initialSQConfig SQConfig
+
+	// swapLock is held for reading by every search and write and for writing
+	// while a rebuilt graph replaces the current one
+	swapLock *sync.RWMutex
+
+	// rebuildLock guards rebuild, the current or most recent rebuild of the
+	// graph, see startRebuild
+	rebuildLock *sync.Mutex
+	rebuild     *rebuildJob
+
+	// initialConstruction is the config the index was created with. The
+	// graph restored from disk may have been built with different
+	// construction parameters, if so it is rebuilt on startup.
+	initialConstruction UserConfig
 }
 
 type CommitLogger interface {
@@ -113,6 +175,10 @@ type CommitLogger interface {
 	DeleteNode(nodeid uint64) error
 	ClearLinks(nodeid uint64) error
 	ClearLinksAtLevel(nodeid uint64, level uint16) error
+	AddPQ(data compressionhelpers.PQData) error
+	AddSQ(data compressionhelpers.SQData) error
+	SetConstructionParams(maxConnections, efConstruction int) error
+	ReplaceState(state *DeserializationResult) error
 	Reset() error
 	Drop() error
 	Flush() error
@@ -150,8 +216,12 @@ func New(cfg Config, uc UserConfig) (*hnsw, error) {
 		normalizeOnRead = true
 	}
 
+	if cfg.CacheBudget == nil {
+		cfg.CacheBudget = NewCacheBudget(0, cfg.Logger)
+	}
+
 	vectorCache := newShardedLockCache(cfg.VectorForIDThunk, uc.VectorCacheMaxObjects,
-		cfg.Logger, normalizeOnRead)
+		cfg.CacheBudget, cfg.Logger, normalizeOnRead)
 
 	index := &hnsw{
 		maximumConnections: uc.MaxConnections,
@@ -160,23 +230,36 @@ func New(cfg Config, uc UserConfig) (*hnsw, error) {
 		maximumConnectionsLayerZero: 2 * uc.MaxConnections,
 
 		// inspired by c++ implementation
-		levelNormalizer:   1 / math.Log(float64(uc.MaxConnections)),
-		efConstruction:    uc.EFConstruction,
-		ef:                int64(uc.EF),
-		flatSearchCutoff:  int64(uc.FlatSearchCutoff),
-		nodes:             make([]*vertex, initialSize),
-		cache:             vectorCache,
-		vectorForID:       vectorCache.get,
-		id:                cfg.ID,
-		rootPath:          cfg.RootPath,
-		tombstones:        map[uint64]struct{}{},
-		logger:            cfg.Logger,
-		distancerProvider: cfg.DistanceProvider,
-		cancel:            make(chan struct{}),
-		deleteLock:        &sync.Mutex{},
-		tombstoneLock:     &sync.RWMutex{},
-		initialInsertOnce: &sync.Once{},
-		cleanupInterval:   time.Duration(uc.CleanupIntervalSeconds) * time.Second,
+		levelNormalizer:       1 / math.Log(float64(uc.MaxConnections)),
+		efConstruction:        uc.EFConstruction,
+		ef:                    int64(uc.EF),
+		flatSearchCutoff:      int64(uc.FlatSearchCutoff),
+		acornFlatSearchCutoff: int64(uc.AcornFlatSearchCutoff),
+		acornSearch:           acornSearchFromStrategy(uc.FilterStrategy),
+		nodes:                 make([]*vertex, initialSize),
+		cache:                 vectorCache,
+		cacheBudget:           cfg.CacheBudget,
+		vectorForID:           vectorCache.get,
+		id:                    cfg.ID,
+		rootPath:              cfg.RootPath,
+		tombstones:            map[uint64]struct{}{},
+		logger:                cfg.Logger,
+		distancerProvider:     cfg.DistanceProvider,
+		cancel:                make(chan struct{}),
+		deleteLock:            &sync.Mutex{},
+		cleanupLock:           &sync.Mutex{},
+		tombstoneLock:         &sync.RWMutex{},
+		initialInsertOnce:     &sync.Once{},
+		cleanupInterval:       time.Duration(uc.CleanupIntervalSeconds) * time.Second,
+		vectorForIDThunk:      cfg.VectorForIDThunk,
+		compressLock:          &sync.Mutex{},
+		rescoreCompressed:     rescoreCompressedFromConfig(uc),
+		initialPQConfig:       uc.PQ,
+		initialSQConfig:       uc.SQ,
+		swapLock:              &sync.RWMutex{},
+		rebuildLock:           &sync.Mutex{},
+
+		initialConstruction: uc,
 	}
 
 	if err := index.init(cfg); err != nil {
@@ -382,6 +465,10 @@ func min(a, b int) int {
 }
 
 func (h *hnsw) distBetweenNodes(a, b uint64) (float32, bool, error) {
+	if h.isCompressed() {
+		return h.distBetweenCompressedNodes(a, b)
+	}
+
 	// TODO: introduce single search/transaction context instead of spawning new
 	// ones
 	vecA, err := h.vectorForID(context.Background(), a)
@@ -422,6 +509,10 @@ func (h *hnsw) distBetweenNodes(a, b uint64) (float32, bool, error) {
 }
 
 func (h *hnsw) distBetweenNodeAndVec(node uint64, vecB []float32) (float32, bool, error) {
+	if h.isCompressed() {
+		return h.distBetweenCompressedNodeAndVec(node, vecB)
+	}
+
 	// TODO: introduce single search/transaction context instead of spawning new
 	// ones
 	vecA, err := h.vectorForID(context.Background(), node)
@@ -450,6 +541,67 @@ func (h *hnsw) distBetweenNodeAndVec(node uint64, vecB []float32) (float32, bool
 	return h.distancerProvider.SingleDist(vecA, vecB)
 }
 
+func (h *hnsw) distBetweenCompressedNodes(a, b uint64) (float32, bool, error) {
+	codeA, ok, err := h.compressedVectorForID(a)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+
+	codeB, ok, err := h.compressedVectorForID(b)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+
+	dist, err := h.quantizer.DistanceBetweenCompressedVectors(codeA, codeB)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return dist, true, nil
+}
+
+func (h *hnsw) distBetweenCompressedNodeAndVec(node uint64,
+	vecB []float32) (float32, bool, error) {
+	if len(vecB) == 0 {
+		return 0, false, fmt.Errorf(
+			"got a nil or zero-length vector as search vector")
+	}
+
+	codeA, ok, err := h.compressedVectorForID(node)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+
+	dist, err := h.quantizer.DistanceBetweenCompressedAndUncompressedVectors(vecB,
+		codeA)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return dist, true, nil
+}
+
+// compressedVectorForID returns false if the object has been deleted in the
+// underlying store
+func (h *hnsw) compressedVectorForID(id uint64) ([]byte, bool, error) {
+	// TODO: introduce single search/transaction context instead of spawning new
+	// ones
+	code, err := h.compressedVectorsCache.get(context.Background(), id)
+	if err != nil {
+		var e storobj.ErrNotFound
+		if errors.As(err, &e) {
+			h.handleDeletedNode(e.DocID)
+			return nil, false, nil
+		} else {
+			// not a typed error, we can recover from, return with err
+			return nil, false, errors.Wrapf(err,
+				"could not get vector of object at docID %d", id)
+		}
+	}
+
+	return code, true, nil
+}
+
 func (h *hnsw) Stats() {
 	fmt.Printf("levels: %d\n", h.currentMaximumLayer)
 
@@ -494,22 +646,51 @@ func (h *hnsw) nodeByID(id uint64) *vertex {
 	h.Lock()
 	defer h.Unlock()
 
+	if id >= uint64(len(h.nodes)) {
+		return nil
+	}
+
 	return h.nodes[id]
 }
 
+// ContainsNode returns true if the id is part of the graph, this includes
+// nodes which are tombstoned, but not cleaned up yet
+func (h *hnsw) ContainsNode(id uint64) bool {
+	return h.nodeByID(id) != nil
+}
+
 func (h *hnsw) Drop() error {
+	h.cancelRebuild()
+
 	// cancel commit log goroutine
 	err := h.commitLog.Drop()
 	if err != nil {
 		return errors.Wrap(err, "commit log drop")
 	}
-	// cancel vector cache goroutine
+	// release the memory of the vector caches
 	h.cache.drop()
+	if h.isCompressed() {
+		if err := h.compressedVectorsCache.drop(); err != nil {
+			return errors.Wrap(err, "compressed vector cache drop")
+		}
+	}
 	// cancel tombstone cleanup goroutine
 	h.cancel <- struct{}{}
 	return nil
 }
 
+// CacheStats returns the usage counters of the vector caches of the index,
+// i.e. the full vector cache and, if the index is compressed, the cache of
+// the compressed vectors
+func (h *hnsw) CacheStats() CacheStats {
+	stats := h.cache.stats()
+	if h.isCompressed() {
+		stats = stats.add(h.compressedVectorsCache.stats())
+	}
+
+	return stats
+}
+
 func (h *hnsw) Flush() error {
 	return h.commitLog.Flush()
 }
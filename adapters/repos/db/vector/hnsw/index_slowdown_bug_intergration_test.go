@@ -69,7 +69,7 @@ func TestSlowDownBugAtHighEF(t *testing.T) {
 			RootPath:              "doesnt-matter-as-committlogger-is-mocked-out",
 			ID:                    "recallbenchmark",
 			MakeCommitLoggerThunk: MakeNoopCommitLogger,
-			DistanceProvider:      distancer.NewDotProductProvider(),
+			DistanceProvider:      distancer.NewCosineDistanceProvider(),
 			// DistanceProvider: distancer.NewCosineProvider(),
 			VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
 				return nil, nil
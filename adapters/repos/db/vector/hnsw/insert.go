@@ -34,7 +34,16 @@ func (h *hnsw) Add(id uint64, vector []float32) error {
 		vector = distancer.Normalize(vector)
 	}
 
-	return h.insert(node, vector)
+	h.swapLock.RLock()
+	if err := h.insert(node, vector); err != nil {
+		h.swapLock.RUnlock()
+		return err
+	}
+	h.forwardAddToRebuild(id, vector)
+	h.swapLock.RUnlock()
+
+	h.compressIfDue()
+	return nil
 }
 
 func (h *hnsw) insertInitialElement(node *vertex, nodeVec []float32) error {
@@ -119,7 +128,14 @@ func (h *hnsw) insert(node *vertex, nodeVec []float32) error {
 
 	// // make sure this new vec is immediately present in the cache, so we don't
 	// // have to read it from disk again
-	h.cache.preload(node.id, nodeVec)
+	if h.isCompressed() {
+		if err := h.compressedVectorsCache.preload(node.id,
+			h.quantizer.Encode(nodeVec)); err != nil {
+			return errors.Wrapf(err, "persist code of node %d", node.id)
+		}
+	} else {
+		h.cache.preload(node.id, nodeVec)
+	}
 
 	h.Lock()
 	h.nodes[nodeId] = node
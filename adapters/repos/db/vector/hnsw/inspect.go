@@ -0,0 +1,251 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// maxReportedUnreachable limits the ids listed in a GraphReport, the total
+// number of unreachable nodes is always reported
+const maxReportedUnreachable = 100
+
+// GraphReport describes the structure of the graph. Unlike Dump and Stats it
+// is meant to be served by a running server to diagnose degraded recall.
+type GraphReport struct {
+	Nodes      int
+	Tombstones int
+	Entrypoint uint64
+	MaxLevel   int
+	Levels     []LevelReport
+
+	// Unreachable is the number of nodes without a tombstone that cannot be
+	// reached from the entrypoint, UnreachableIDs lists up to
+	// maxReportedUnreachable of them
+	Unreachable    int
+	UnreachableIDs []uint64
+}
+
+// LevelReport describes a single layer of the graph. Nodes counts every node
+// present on the layer, which includes the nodes of all higher layers.
+type LevelReport struct {
+	Level              int
+	Nodes              int
+	AverageConnections float64
+}
+
+// RepairReport describes the changes made by Repair
+type RepairReport struct {
+	TombstonesRemoved int
+	Reconnected       int
+}
+
+// Inspect analyzes the structure of the graph. It does not block inserts or
+// searches, so on an index that is being written to concurrently the report
+// is a close approximation.
+func (h *hnsw) Inspect() GraphReport {
+	h.swapLock.RLock()
+	defer h.swapLock.RUnlock()
+
+	report, unreachable := h.inspect()
+	report.Unreachable = len(unreachable)
+	if len(unreachable) > maxReportedUnreachable {
+		unreachable = unreachable[:maxReportedUnreachable]
+	}
+	report.UnreachableIDs = unreachable
+
+	return report
+}
+
+// Repair cleans up all tombstoned nodes and reconnects every node which can
+// no longer be reached from the entrypoint. Such nodes can never be part of
+// a search result.
+func (h *hnsw) Repair() (RepairReport, error) {
+	h.cleanupLock.Lock()
+	defer h.cleanupLock.Unlock()
+
+	h.swapLock.RLock()
+	defer h.swapLock.RUnlock()
+
+	if h.runningRebuild() != nil {
+		return RepairReport{}, errors.New("cannot repair the graph while it " +
+			"is being rebuilt")
+	}
+
+	var report RepairReport
+	removed, err := h.cleanUpTombstonedNodes()
+	if err != nil {
+		return report, errors.Wrap(err, "clean up tombstoned nodes")
+	}
+	report.TombstonesRemoved = removed
+
+	_, unreachable := h.inspect()
+	for _, id := range unreachable {
+		ok, err := h.reconnect(id)
+		if err != nil {
+			return report, errors.Wrapf(err, "reconnect node %d", id)
+		}
+
+		if ok {
+			report.Reconnected++
+		}
+	}
+
+	return report, nil
+}
+
+// inspect returns the report and the ids of all unreachable nodes. The
+// caller must hold the swapLock. Inserts write to and grow h.nodes
+// concurrently, so the nodes are copied while holding the lock and the
+// connections of each node are only read while holding the node's lock.
+func (h *hnsw) inspect() (GraphReport, []uint64) {
+	h.Lock()
+	nodes := make([]*vertex, len(h.nodes))
+	copy(nodes, h.nodes)
+	entrypoint := h.entryPointID
+	maxLevel := h.currentMaximumLayer
+	h.Unlock()
+
+	h.tombstoneLock.RLock()
+	tombstones := len(h.tombstones)
+	h.tombstoneLock.RUnlock()
+
+	report := GraphReport{
+		Tombstones: tombstones,
+		Entrypoint: entrypoint,
+		MaxLevel:   maxLevel,
+	}
+
+	nodesPerLevel := make([]int, maxLevel+1)
+	connectionsPerLevel := make([]int, maxLevel+1)
+	adjacent := make([][]uint64, len(nodes))
+	for i, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		report.Nodes++
+
+		node.Lock()
+		for level, conns := range node.connections {
+			if level < len(nodesPerLevel) {
+				nodesPerLevel[level]++
+				connectionsPerLevel[level] += len(conns)
+			}
+			adjacent[i] = append(adjacent[i], conns...)
+		}
+		node.Unlock()
+	}
+
+	if report.Nodes == 0 {
+		return report, nil
+	}
+
+	report.Levels = make([]LevelReport, maxLevel+1)
+	for level := range report.Levels {
+		report.Levels[level] = LevelReport{Level: level, Nodes: nodesPerLevel[level]}
+		if nodesPerLevel[level] > 0 {
+			report.Levels[level].AverageConnections =
+				float64(connectionsPerLevel[level]) / float64(nodesPerLevel[level])
+		}
+	}
+
+	// the edges of all layers are followed, as a search descends from the
+	// entrypoint through every layer
+	visited := make([]bool, len(nodes))
+	queue := []uint64{}
+	if entrypoint < uint64(len(nodes)) && nodes[entrypoint] != nil {
+		visited[entrypoint] = true
+		queue = append(queue, entrypoint)
+	}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range adjacent[id] {
+			if neighbor >= uint64(len(nodes)) || visited[neighbor] ||
+				nodes[neighbor] == nil {
+				continue
+			}
+			visited[neighbor] = true
+			queue = append(queue, neighbor)
+		}
+	}
+
+	var unreachable []uint64
+	for i, node := range nodes {
+		if node == nil || visited[i] || h.hasTombstone(uint64(i)) {
+			continue
+		}
+		unreachable = append(unreachable, uint64(i))
+	}
+
+	return report, unreachable
+}
+
+// reconnect replaces the connections of a node as if it was inserted anew,
+// which also adds edges from its new neighbors back to it. It returns false
+// if the node no longer exists.
+func (h *hnsw) reconnect(id uint64) (bool, error) {
+	h.Lock()
+	var node *vertex
+	if id < uint64(len(h.nodes)) {
+		node = h.nodes[id]
+	}
+	entryPointID := h.entryPointID
+	currentMaximumLayer := h.currentMaximumLayer
+	h.Unlock()
+
+	if node == nil || h.hasTombstone(id) {
+		return false, nil
+	}
+
+	vec, err := h.nodeVector(context.Background(), id)
+	if err != nil {
+		var e storobj.ErrNotFound
+		if errors.As(err, &e) {
+			h.handleDeletedNode(e.DocID)
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get vector")
+	}
+
+	node.Lock()
+	level := node.level
+	node.Unlock()
+
+	entryPointID, err = h.findBestEntrypointForNode(currentMaximumLayer, level,
+		entryPointID, vec)
+	if err != nil {
+		return false, errors.Wrap(err, "find best entrypoint")
+	}
+
+	node.markAsMaintenance()
+	defer node.unmarkAsMaintenance()
+
+	node.Lock()
+	node.connections = map[int][]uint64{}
+	node.Unlock()
+	if err := h.commitLog.ClearLinks(id); err != nil {
+		return false, errors.Wrap(err, "clear links")
+	}
+
+	if err := h.findAndConnectNeighbors(node, entryPointID, vec, level,
+		currentMaximumLayer, h.tombstonesAsDenyList()); err != nil {
+		return false, errors.Wrap(err, "find and connect neighbors")
+	}
+
+	return true, nil
+}
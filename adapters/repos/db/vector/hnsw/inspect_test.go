@@ -0,0 +1,162 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect_ConcurrentInserts(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	vectors := randomCompressionTestVectors(r, 2000, 16)
+	index := compressionTestIndex(t, distancer.NewL2SquaredProvider(), &vectors)
+
+	done := make(chan error)
+	go func() {
+		for i, vec := range vectors {
+			if err := index.Add(uint64(i), vec); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	var inserted bool
+	for !inserted {
+		select {
+		case err := <-done:
+			require.Nil(t, err)
+			inserted = true
+		default:
+			report := index.Inspect()
+			assert.LessOrEqual(t, report.Nodes, len(vectors))
+		}
+	}
+
+	report := index.Inspect()
+	assert.Equal(t, len(vectors), report.Nodes)
+	assert.Equal(t, 0, report.Unreachable)
+}
+
+func TestInspectAndRepair(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	vectors := randomCompressionTestVectors(r, 500, 16)
+	index := compressionTestIndex(t, distancer.NewL2SquaredProvider(), &vectors)
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	t.Run("inspect a healthy graph", func(t *testing.T) {
+		report := index.Inspect()
+		assert.Equal(t, 500, report.Nodes)
+		assert.Equal(t, 0, report.Tombstones)
+		assert.Equal(t, 0, report.Unreachable)
+		assert.Equal(t, index.entryPointID, report.Entrypoint)
+		require.Len(t, report.Levels, report.MaxLevel+1)
+		assert.Equal(t, 500, report.Levels[0].Nodes)
+		assert.Greater(t, report.Levels[0].AverageConnections, float64(1))
+		for i := 1; i < len(report.Levels); i++ {
+			assert.LessOrEqual(t, report.Levels[i].Nodes, report.Levels[i-1].Nodes)
+		}
+	})
+
+	orphan := uint64(123)
+	if orphan == index.entryPointID {
+		orphan++
+	}
+
+	t.Run("detect an orphaned node and tombstones", func(t *testing.T) {
+		// remove every edge pointing to the orphan
+		for _, node := range index.nodes {
+			if node == nil {
+				continue
+			}
+			for level, conns := range node.connections {
+				filtered := conns[:0]
+				for _, conn := range conns {
+					if conn != orphan {
+						filtered = append(filtered, conn)
+					}
+				}
+				node.connections[level] = filtered
+			}
+		}
+
+		for _, id := range []uint64{10, 20, 30} {
+			require.Nil(t, index.Delete(id))
+		}
+
+		report := index.Inspect()
+		assert.Equal(t, 3, report.Tombstones)
+		assert.Equal(t, 1, report.Unreachable)
+		assert.Equal(t, []uint64{orphan}, report.UnreachableIDs)
+
+		res, _, err := index.SearchByVector(vectors[orphan], 1, nil)
+		require.Nil(t, err)
+		assert.NotEqual(t, []uint64{orphan}, res)
+	})
+
+	t.Run("repair the graph", func(t *testing.T) {
+		report, err := index.Repair()
+		require.Nil(t, err)
+		assert.Equal(t, 3, report.TombstonesRemoved)
+		assert.Equal(t, 1, report.Reconnected)
+
+		graph := index.Inspect()
+		assert.Equal(t, 497, graph.Nodes)
+		assert.Equal(t, 0, graph.Tombstones)
+		assert.Equal(t, 0, graph.Unreachable)
+
+		res, _, err := index.SearchByVector(vectors[orphan], 1, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []uint64{orphan}, res)
+	})
+}
+
+// failingClearLinksLogger fails every attempt to clear the links of a node
+type failingClearLinksLogger struct {
+	NoopCommitLogger
+}
+
+func (l *failingClearLinksLogger) ClearLinks(nodeid uint64) error {
+	return errors.Errorf("clear links of node %d", nodeid)
+}
+
+func TestRepair_FailedReconnectReleasesMaintenance(t *testing.T) {
+	r := rand.New(rand.NewSource(53))
+	vectors := randomCompressionTestVectors(r, 100, 16)
+	index := compressionTestIndex(t, distancer.NewL2SquaredProvider(), &vectors)
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	id := uint64(42)
+	if id == index.entryPointID {
+		id++
+	}
+
+	index.commitLog = &failingClearLinksLogger{}
+
+	ok, err := index.reconnect(id)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "clear links")
+	assert.False(t, ok)
+	assert.False(t, index.nodeByID(id).isUnderMaintenance(),
+		"a failed reconnect must not leave the node under maintenance")
+}
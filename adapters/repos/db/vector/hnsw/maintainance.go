@@ -38,6 +38,9 @@ func (h *hnsw) growIndexToAccomodateNode(id uint64, logger logrus.FieldLogger) e
 	}
 
 	h.cache.grow(uint64(len(newIndex)))
+	if h.isCompressed() {
+		h.compressedVectorsCache.grow(uint64(len(newIndex)))
+	}
 
 	h.pools.visitedLists.Destroy()
 	h.pools.visitedLists = nil
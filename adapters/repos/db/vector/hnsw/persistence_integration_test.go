@@ -15,14 +15,19 @@
 package hnsw
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
@@ -472,3 +477,295 @@ func TestHnswPersistence_WithDeletion_WithTombstoneCleanup(t *testing.T) {
 		assert.Equal(t, expectedResults, res)
 	})
 }
+
+func TestHnswPersistence_WithCompression(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	indexID := "integrationtest_compression"
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	logger, _ := test.NewNullLogger()
+	cl, clErr := NewCommitLogger(dirName, indexID, 0, logger)
+	makeCL := func() (CommitLogger, error) {
+		return cl, clErr
+	}
+	index, err := New(Config{
+		RootPath:              dirName,
+		ID:                    indexID,
+		MakeCommitLoggerThunk: makeCL,
+		DistanceProvider:      distancer.NewCosineDistanceProvider(),
+		VectorForIDThunk:      testVectorForID,
+	}, UserConfig{
+		MaxConnections: 30,
+		EFConstruction: 60,
+	})
+	require.Nil(t, err)
+
+	for i, vec := range testVectors {
+		err := index.Add(uint64(i), vec)
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, index.compress(PQConfig{
+		Enabled:       true,
+		Segments:      2,
+		Centroids:     4,
+		TrainingLimit: 9,
+	}))
+	require.True(t, index.isCompressed())
+	require.Nil(t, index.Flush())
+
+	// see index_test.go for more context
+	expectedResults := []uint64{
+		3, 5, 4, // cluster 2
+		7, 8, 6, // cluster 3
+		2, 1, 0, // cluster 1
+	}
+
+	t.Run("verify that the results match originally", func(t *testing.T) {
+		position := 3
+		res, _, err := index.knnSearchByVector(testVectors[position], 50, 36, nil)
+		require.Nil(t, err)
+		assert.Equal(t, expectedResults, res)
+	})
+
+	pqData := index.pq.ExposeFields()
+
+	// destroy the index
+	index = nil
+
+	// build a new index from the (uncondensed) commit log
+	secondIndex, err := New(Config{
+		RootPath:              dirName,
+		ID:                    indexID,
+		MakeCommitLoggerThunk: makeCL,
+		DistanceProvider:      distancer.NewCosineDistanceProvider(),
+		VectorForIDThunk:      testVectorForID,
+	}, UserConfig{
+		MaxConnections: 30,
+		EFConstruction: 60,
+	})
+	require.Nil(t, err)
+
+	t.Run("verify the index is still compressed after rebuilding from disk",
+		func(t *testing.T) {
+			require.True(t, secondIndex.isCompressed())
+			assert.Equal(t, pqData, secondIndex.pq.ExposeFields())
+		})
+
+	t.Run("verify that the results match after rebuiling from disk",
+		func(t *testing.T) {
+			position := 3
+			res, _, err := secondIndex.knnSearchByVector(testVectors[position], 50, 36, nil)
+			require.Nil(t, err)
+			assert.Equal(t, expectedResults, res)
+		})
+}
+
+func TestHnswPersistence_WithScalarQuantization(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	indexID := "integrationtest_scalar_quantization"
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	sqConfig := SQConfig{
+		Enabled:       true,
+		Type:          compressionhelpers.ScalarQuantizationInt8,
+		Rescore:       false,
+		TrainingLimit: 9,
+	}
+
+	logger, _ := test.NewNullLogger()
+	cl, clErr := NewCommitLogger(dirName, indexID, 0, logger)
+	makeCL := func() (CommitLogger, error) {
+		return cl, clErr
+	}
+	index, err := New(Config{
+		RootPath:              dirName,
+		ID:                    indexID,
+		MakeCommitLoggerThunk: makeCL,
+		DistanceProvider:      distancer.NewCosineDistanceProvider(),
+		VectorForIDThunk:      testVectorForID,
+	}, UserConfig{
+		MaxConnections: 30,
+		EFConstruction: 60,
+		SQ:             sqConfig,
+	})
+	require.Nil(t, err)
+
+	for i, vec := range testVectors {
+		err := index.Add(uint64(i), vec)
+		require.Nil(t, err)
+	}
+
+	require.Nil(t, index.compressScalar(sqConfig))
+	require.True(t, index.isCompressed())
+	require.Nil(t, index.Flush())
+
+	position := 3
+	expectedResults, _, err := index.knnSearchByVector(testVectors[position],
+		50, 36, nil)
+	require.Nil(t, err)
+
+	sqData := index.quantizer.(*compressionhelpers.ScalarQuantizer).ExposeFields()
+
+	// destroy the index
+	index = nil
+
+	// the full vectors are unavailable, so all codes must be read from disk
+	vectorForID := func(ctx context.Context, id uint64) ([]float32, error) {
+		return nil, errors.Errorf("full vector of %d requested", id)
+	}
+
+	// build a new index from the (uncondensed) commit log
+	secondIndex, err := New(Config{
+		RootPath:              dirName,
+		ID:                    indexID,
+		MakeCommitLoggerThunk: makeCL,
+		DistanceProvider:      distancer.NewCosineDistanceProvider(),
+		VectorForIDThunk:      vectorForID,
+	}, UserConfig{
+		MaxConnections: 30,
+		EFConstruction: 60,
+		SQ:             sqConfig,
+	})
+	require.Nil(t, err)
+
+	t.Run("verify the quantizer is restored from disk", func(t *testing.T) {
+		require.True(t, secondIndex.isCompressed())
+		sq, ok := secondIndex.quantizer.(*compressionhelpers.ScalarQuantizer)
+		require.True(t, ok)
+		assert.Equal(t, sqData, sq.ExposeFields())
+	})
+
+	t.Run("verify that the results match using the persisted codes",
+		func(t *testing.T) {
+			res, _, err := secondIndex.knnSearchByVector(testVectors[position],
+				50, 36, nil)
+			require.Nil(t, err)
+			assert.Equal(t, expectedResults, res)
+		})
+
+	t.Run("the codes are part of the disk usage", func(t *testing.T) {
+		usage, err := secondIndex.DiskUsage()
+		require.Nil(t, err)
+		assert.Greater(t, usage.QuantizedCodeBytes, int64(0))
+	})
+}
+
+// TestHnswPersistence_TornWritesAtTheEndOfTheCommitLog simulates a crash while
+// the last insert was only partially persisted: the commit log is cut off at
+// every position within the changes of the last insert. All inserts
+// acknowledged by a Flush before must be recovered, the startup must not fail
+// and the recovered index must continue to work normally.
+func TestHnswPersistence_TornWritesAtTheEndOfTheCommitLog(t *testing.T) {
+	rand.Seed(time.Now().UnixNano())
+	dirName := fmt.Sprintf("./testdata/%d", rand.Intn(10000000))
+	os.MkdirAll(dirName, 0o777)
+	indexID := "integrationtest_torn"
+	defer func() {
+		err := os.RemoveAll(dirName)
+		fmt.Println(err)
+	}()
+
+	vectors := make([][]float32, 20)
+	for i := range vectors {
+		vectors[i] = []float32{rand.Float32(), rand.Float32(), rand.Float32(),
+			rand.Float32()}
+	}
+	acknowledged := len(vectors) - 2
+	torn := acknowledged
+	afterRecovery := acknowledged + 1
+
+	logger, _ := test.NewNullLogger()
+	policy := durability.Policy{Mode: durability.ModeEveryWrite}
+	newIndex := func(rootPath string) *hnsw {
+		cl, err := NewCommitLogger(rootPath, indexID, 0, logger,
+			WithSyncPolicy(policy))
+		require.Nil(t, err)
+
+		index, err := New(Config{
+			RootPath: rootPath,
+			ID:       indexID,
+			MakeCommitLoggerThunk: func() (CommitLogger, error) {
+				return cl, nil
+			},
+			DistanceProvider: distancer.NewL2SquaredProvider(),
+			VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
+				return vectors[id], nil
+			},
+			Logger: logger,
+		}, UserConfig{
+			MaxConnections: 30,
+			EFConstruction: 60,
+		})
+		require.Nil(t, err)
+		return index
+	}
+
+	assertContains := func(t *testing.T, index *hnsw, ids ...int) {
+		res, _, err := index.knnSearchByVector(vectors[0], len(vectors), 60, nil)
+		require.Nil(t, err)
+		for _, id := range ids {
+			assert.Contains(t, res, uint64(id))
+		}
+	}
+
+	var log []byte
+	var logName string
+	var lastInsertStart int
+
+	t.Run("insert into an index and capture its commit log", func(t *testing.T) {
+		rootPath := filepath.Join(dirName, "original")
+		index := newIndex(rootPath)
+
+		for i := 0; i < acknowledged; i++ {
+			require.Nil(t, index.Add(uint64(i), vectors[i]))
+			require.Nil(t, index.Flush())
+		}
+
+		cl := index.commitLog.(*hnswCommitLogger)
+		size, err := cl.commitLogger.FileSize()
+		require.Nil(t, err)
+		lastInsertStart = int(size)
+
+		require.Nil(t, index.Add(uint64(torn), vectors[torn]))
+		require.Nil(t, index.Flush())
+
+		// read the log without shutting down, as if the process had crashed
+		logName, err = cl.commitLogger.FileName()
+		require.Nil(t, err)
+		log, err = ioutil.ReadFile(commitLogFileName(rootPath, indexID, logName))
+		require.Nil(t, err)
+		require.Greater(t, len(log), lastInsertStart)
+	})
+
+	for cut := lastInsertStart; cut <= len(log); cut++ {
+		rootPath := filepath.Join(dirName, fmt.Sprintf("cut-%d", cut))
+		require.Nil(t, os.MkdirAll(commitLogDirectory(rootPath, indexID), 0o777))
+		require.Nil(t, ioutil.WriteFile(commitLogFileName(rootPath, indexID,
+			logName), log[:cut], 0o666))
+
+		index := newIndex(rootPath)
+		ids := make([]int, acknowledged)
+		for i := range ids {
+			ids[i] = i
+		}
+		assertContains(t, index, ids...)
+
+		// the recovered index accepts new inserts which survive a restart
+		require.Nil(t, index.Add(uint64(afterRecovery), vectors[afterRecovery]))
+		require.Nil(t, index.Flush())
+
+		index = newIndex(rootPath)
+		assertContains(t, index, append(ids, afterRecovery)...)
+	}
+}
@@ -0,0 +1,399 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+const (
+	RebuildStatusIdle      = "idle"
+	RebuildStatusRunning   = "running"
+	RebuildStatusCompleted = "completed"
+	RebuildStatusFailed    = "failed"
+	RebuildStatusCancelled = "cancelled"
+)
+
+// RebuildStatus describes the progress of the most recent rebuild of the
+// graph. Processed and Total refer to the nodes which were present when the
+// rebuild started, nodes imported while the rebuild is running are added to
+// both graphs and not counted.
+type RebuildStatus struct {
+	Status     string
+	Processed  int64
+	Total      int64
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Error      string
+}
+
+// rebuildJob builds a new graph with different construction parameters from
+// the nodes of the current graph. The new graph is held in a separate index
+// which shares the vector caches of the current one and never writes to disk.
+// Until the job is complete, all inserts and deletes of the current graph are
+// forwarded to the new one.
+type rebuildJob struct {
+	sync.Mutex
+	graph      *hnsw
+	status     string
+	err        error
+	startedAt  time.Time
+	finishedAt time.Time
+
+	// read and written atomically
+	processed int64
+	total     int64
+	cancelled int32
+}
+
+func (j *rebuildJob) isRunning() bool {
+	j.Lock()
+	defer j.Unlock()
+
+	return j.status == RebuildStatusRunning
+}
+
+func (j *rebuildJob) isCancelled() bool {
+	return atomic.LoadInt32(&j.cancelled) == 1
+}
+
+func (j *rebuildJob) cancel() {
+	atomic.StoreInt32(&j.cancelled, 1)
+}
+
+func (j *rebuildJob) finish(status string, err error) {
+	j.Lock()
+	defer j.Unlock()
+
+	j.status = status
+	j.err = err
+	j.finishedAt = time.Now()
+}
+
+func (j *rebuildJob) copyStatus() RebuildStatus {
+	j.Lock()
+	defer j.Unlock()
+
+	out := RebuildStatus{
+		Status:     j.status,
+		Processed:  atomic.LoadInt64(&j.processed),
+		Total:      atomic.LoadInt64(&j.total),
+		StartedAt:  j.startedAt,
+		FinishedAt: j.finishedAt,
+	}
+
+	if j.err != nil {
+		out.Error = j.err.Error()
+	}
+
+	return out
+}
+
+// RebuildStatus returns the progress of the current or most recent rebuild
+func (h *hnsw) RebuildStatus() RebuildStatus {
+	h.rebuildLock.Lock()
+	job := h.rebuild
+	h.rebuildLock.Unlock()
+
+	if job == nil {
+		return RebuildStatus{Status: RebuildStatusIdle}
+	}
+
+	return job.copyStatus()
+}
+
+// runningRebuild returns the job that writes need to be forwarded to or nil if
+// no rebuild is running. It must be called while holding the swapLock, so
+// that the job cannot complete before the write has been forwarded.
+func (h *hnsw) runningRebuild() *rebuildJob {
+	h.rebuildLock.Lock()
+	job := h.rebuild
+	h.rebuildLock.Unlock()
+
+	if job == nil || !job.isRunning() {
+		return nil
+	}
+
+	return job
+}
+
+func (h *hnsw) forwardAddToRebuild(id uint64, vector []float32) {
+	job := h.runningRebuild()
+	if job == nil {
+		return
+	}
+
+	if err := job.graph.Add(id, vector); err != nil {
+		job.cancel()
+		job.finish(RebuildStatusFailed, errors.Wrapf(err, "add node %d", id))
+	}
+}
+
+func (h *hnsw) forwardDeleteToRebuild(id uint64) {
+	job := h.runningRebuild()
+	if job == nil {
+		return
+	}
+
+	if err := job.graph.Delete(id); err != nil {
+		job.cancel()
+		job.finish(RebuildStatusFailed, errors.Wrapf(err, "delete node %d", id))
+	}
+}
+
+// startRebuild builds a new graph using the construction parameters of uc in
+// the background. Queries are served from the current graph until the new one
+// is complete, at which point the two are swapped and the commit logs of the
+// current graph are replaced. A rebuild that is still running is cancelled.
+//
+// The parameters the current graph was built with are persisted in its commit
+// logs. If the process stops before the swap, the rebuild is thus started
+// again by PostStartup.
+func (h *hnsw) startRebuild(uc UserConfig) {
+	h.cancelRebuild()
+
+	h.swapLock.Lock()
+
+	h.rebuildLock.Lock()
+	job := &rebuildJob{
+		graph:     h.newRebuildGraph(uc),
+		status:    RebuildStatusRunning,
+		startedAt: time.Now(),
+	}
+	h.rebuild = job
+	h.rebuildLock.Unlock()
+
+	// no writes can happen while the swapLock is held, every node that is not
+	// contained in ids is thus guaranteed to be forwarded to the new graph
+	ids := h.nodeIDs()
+	atomic.StoreInt64(&job.total, int64(len(ids)))
+	h.swapLock.Unlock()
+
+	go h.runRebuild(job, ids)
+}
+
+// newRebuildGraph creates an empty graph which shares everything but the
+// construction parameters with h, but does not persist anything
+func (h *hnsw) newRebuildGraph(uc UserConfig) *hnsw {
+	h.Lock()
+	defer h.Unlock()
+
+	return &hnsw{
+		maximumConnections:          uc.MaxConnections,
+		maximumConnectionsLayerZero: 2 * uc.MaxConnections,
+		levelNormalizer:             1 / math.Log(float64(uc.MaxConnections)),
+		efConstruction:              uc.EFConstruction,
+		ef:                          atomic.LoadInt64(&h.ef),
+		flatSearchCutoff:            atomic.LoadInt64(&h.flatSearchCutoff),
+		acornFlatSearchCutoff:       atomic.LoadInt64(&h.acornFlatSearchCutoff),
+		acornSearch:                 atomic.LoadInt32(&h.acornSearch),
+		nodes:                       make([]*vertex, initialSize),
+		cache:                       h.cache,
+		cacheBudget:                 h.cacheBudget,
+		vectorForID:                 h.vectorForID,
+		commitLog:                   &NoopCommitLogger{},
+		id:                          h.id,
+		rootPath:                    h.rootPath,
+		tombstones:                  map[uint64]struct{}{},
+		logger:                      h.logger,
+		distancerProvider:           h.distancerProvider,
+		cancel:                      make(chan struct{}),
+		deleteLock:                  &sync.Mutex{},
+		cleanupLock:                 &sync.Mutex{},
+		tombstoneLock:               &sync.RWMutex{},
+		initialInsertOnce:           &sync.Once{},
+		swapLock:                    &sync.RWMutex{},
+		rebuildLock:                 &sync.Mutex{},
+		pools:                       newPools(2 * uc.MaxConnections),
+		vectorForIDThunk:            h.vectorForIDThunk,
+		compressed:                  atomic.LoadInt32(&h.compressed),
+		quantizer:                   h.quantizer,
+		pq:                          h.pq,
+		rescoreCompressed:           atomic.LoadInt32(&h.rescoreCompressed),
+		compressedVectorsCache:      h.compressedVectorsCache,
+		compressLock:                &sync.Mutex{},
+	}
+}
+
+// nodeIDs returns the ids of all nodes without a tombstone
+func (h *hnsw) nodeIDs() []uint64 {
+	h.Lock()
+	defer h.Unlock()
+
+	h.tombstoneLock.RLock()
+	defer h.tombstoneLock.RUnlock()
+
+	var ids []uint64
+	for _, node := range h.nodes {
+		if node == nil {
+			continue
+		}
+
+		if _, ok := h.tombstones[node.id]; ok {
+			continue
+		}
+
+		ids = append(ids, node.id)
+	}
+
+	return ids
+}
+
+func (h *hnsw) runRebuild(job *rebuildJob, ids []uint64) {
+	before := time.Now()
+
+	for _, id := range ids {
+		if job.isCancelled() {
+			return
+		}
+
+		if err := h.addToRebuild(job, id); err != nil {
+			h.failRebuild(job, errors.Wrapf(err, "add node %d", id))
+			return
+		}
+
+		atomic.AddInt64(&job.processed, 1)
+	}
+
+	if err := h.swapRebuiltGraph(job); err != nil {
+		if job.isCancelled() {
+			return
+		}
+
+		h.failRebuild(job, errors.Wrap(err, "swap graphs"))
+		return
+	}
+
+	h.logger.WithField("action", "hnsw_rebuild").
+		WithField("index_id", h.id).
+		WithField("nodes", len(ids)).
+		WithField("took", time.Since(before)).
+		Info("rebuilt vector index graph")
+}
+
+func (h *hnsw) failRebuild(job *rebuildJob, err error) {
+	job.cancel()
+	job.finish(RebuildStatusFailed, err)
+
+	h.logger.WithField("action", "hnsw_rebuild").
+		WithField("index_id", h.id).
+		WithError(err).
+		Error("rebuild vector index graph, keeping the current graph")
+}
+
+// addToRebuild inserts a node of the current graph into the new one. Nodes
+// which were deleted in the meantime are skipped, a concurrent delete that
+// is not caught by this check is forwarded to the new graph and results in a
+// tombstone there.
+func (h *hnsw) addToRebuild(job *rebuildJob, id uint64) error {
+	if h.hasTombstone(id) {
+		return nil
+	}
+
+	vec, err := h.nodeVector(context.Background(), id)
+	if err != nil {
+		var e storobj.ErrNotFound
+		if errors.As(err, &e) {
+			// the object is gone, the node will be cleaned up in the current graph
+			return nil
+		}
+		return err
+	}
+
+	return job.graph.Add(id, vec)
+}
+
+// swapRebuiltGraph persists the new graph and replaces the current graph with
+// it. It blocks all reads and writes for the duration of the swap.
+func (h *hnsw) swapRebuiltGraph(job *rebuildJob) error {
+	// compression must neither start nor complete during the swap, as the
+	// persisted state contains the quantizer
+	h.compressLock.Lock()
+	defer h.compressLock.Unlock()
+
+	h.swapLock.Lock()
+	defer h.swapLock.Unlock()
+
+	if job.isCancelled() {
+		return errors.New("rebuild was cancelled")
+	}
+
+	graph := job.graph
+
+	h.Lock()
+	defer h.Unlock()
+
+	h.tombstoneLock.Lock()
+	defer h.tombstoneLock.Unlock()
+
+	state := &DeserializationResult{
+		Nodes:      graph.nodes,
+		Entrypoint: graph.entryPointID,
+		Level:      uint16(graph.currentMaximumLayer),
+		Tombstones: graph.tombstones,
+
+		ConstructionParamsSet: true,
+		MaxConnections:        graph.maximumConnections,
+		EFConstruction:        graph.efConstruction,
+	}
+
+	switch q := h.quantizer.(type) {
+	case *compressionhelpers.ProductQuantizer:
+		state.Compressed = true
+		state.PQData = q.ExposeFields()
+	case *compressionhelpers.ScalarQuantizer:
+		state.ScalarQuantized = true
+		state.SQData = q.ExposeFields()
+	}
+
+	if err := h.commitLog.ReplaceState(state); err != nil {
+		return errors.Wrap(err, "persist rebuilt graph")
+	}
+
+	h.nodes = graph.nodes
+	h.entryPointID = graph.entryPointID
+	h.currentMaximumLayer = graph.currentMaximumLayer
+	h.tombstones = graph.tombstones
+	h.initialInsertOnce = graph.initialInsertOnce
+	h.maximumConnections = graph.maximumConnections
+	h.maximumConnectionsLayerZero = graph.maximumConnectionsLayerZero
+	h.levelNormalizer = graph.levelNormalizer
+	h.efConstruction = graph.efConstruction
+	h.pools = graph.pools
+
+	h.cache.grow(uint64(len(h.nodes)))
+	if h.isCompressed() {
+		h.compressedVectorsCache.grow(uint64(len(h.nodes)))
+	}
+
+	job.finish(RebuildStatusCompleted, nil)
+	return nil
+}
+
+// cancelRebuild stops a running rebuild, the current graph is kept
+func (h *hnsw) cancelRebuild() {
+	h.rebuildLock.Lock()
+	defer h.rebuildLock.Unlock()
+
+	if h.rebuild != nil && h.rebuild.isRunning() {
+		h.rebuild.cancel()
+		h.rebuild.finish(RebuildStatusCancelled, nil)
+	}
+}
@@ -0,0 +1,270 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package hnsw
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuild(t *testing.T) {
+	dirName := t.TempDir()
+	indexID := "integrationtest_rebuild"
+	logger, _ := test.NewNullLogger()
+	provider := distancer.NewL2SquaredProvider()
+
+	r := rand.New(rand.NewSource(7))
+	initial := 1000
+	vectors := randomCompressionTestVectors(r, initial+200, 32)
+	var vectorsLock sync.Mutex
+	vectorForID := func(ctx context.Context, id uint64) ([]float32, error) {
+		vectorsLock.Lock()
+		defer vectorsLock.Unlock()
+		return vectors[int(id)], nil
+	}
+
+	uc := UserConfig{
+		MaxConnections:        8,
+		EFConstruction:        16,
+		EF:                    64,
+		VectorCacheMaxObjects: 1e6,
+	}
+
+	makeIndex := func(makeCL MakeCommitLogger, uc UserConfig) *hnsw {
+		index, err := New(Config{
+			RootPath:              dirName,
+			ID:                    indexID,
+			MakeCommitLoggerThunk: makeCL,
+			DistanceProvider:      provider,
+			VectorForIDThunk:      vectorForID,
+		}, uc)
+		require.Nil(t, err)
+		return index
+	}
+
+	cl, err := NewCommitLogger(dirName, indexID, 0, logger)
+	require.Nil(t, err)
+	index := makeIndex(func() (CommitLogger, error) { return cl, nil }, uc)
+
+	for i := 0; i < initial; i++ {
+		require.Nil(t, index.Add(uint64(i), vectors[i]))
+	}
+	require.Nil(t, index.Flush())
+
+	assert.Equal(t, RebuildStatusIdle, index.RebuildStatus().Status)
+
+	deleted := map[uint64]struct{}{}
+	t.Run("rebuild with concurrent inserts and deletes", func(t *testing.T) {
+		updated := uc
+		updated.MaxConnections = 16
+		updated.EFConstruction = 64
+		require.Nil(t, index.UpdateUserConfig(updated))
+
+		status := index.RebuildStatus()
+		assert.Equal(t, int64(initial), status.Total)
+
+		for i := initial; i < len(vectors); i++ {
+			require.Nil(t, index.Add(uint64(i), vectors[i]))
+		}
+
+		for i := 0; i < 100; i++ {
+			id := uint64(r.Intn(len(vectors)))
+			require.Nil(t, index.Delete(id))
+			deleted[id] = struct{}{}
+		}
+
+		waitForRebuild(t, index)
+
+		status = index.RebuildStatus()
+		require.Equal(t, RebuildStatusCompleted, status.Status, status.Error)
+		assert.Equal(t, status.Total, status.Processed)
+		assert.Equal(t, 16, index.maximumConnections)
+		assert.Equal(t, 64, index.efConstruction)
+	})
+
+	assertRecall := func(t *testing.T, index *hnsw) {
+		var relevant, retrieved int
+		for i := 0; i < 50; i++ {
+			query := vectors[r.Intn(len(vectors))]
+
+			var candidates [][]float32
+			var ids []uint64
+			for id, vec := range vectors {
+				if _, ok := deleted[uint64(id)]; ok {
+					continue
+				}
+				candidates = append(candidates, vec)
+				ids = append(ids, uint64(id))
+			}
+
+			truth := bruteForceWithProvider(provider, candidates, query, 10)
+			for j := range truth {
+				truth[j] = ids[truth[j]]
+			}
+
+			res, _, err := index.SearchByVector(query, 10, nil)
+			require.Nil(t, err)
+			for _, id := range res {
+				_, ok := deleted[id]
+				assert.False(t, ok, "deleted node %d must not be returned", id)
+			}
+
+			relevant += matchesInTruth(truth, res)
+			retrieved += len(truth)
+		}
+
+		recall := float32(relevant) / float32(retrieved)
+		assert.True(t, recall >= 0.9, "recall %f is too low", recall)
+	}
+
+	t.Run("the rebuilt graph contains all nodes", func(t *testing.T) {
+		assertRecall(t, index)
+	})
+
+	t.Run("the previous commit logs are replaced", func(t *testing.T) {
+		require.Nil(t, index.Flush())
+
+		fileNames, err := getCommitFileNames(dirName, indexID)
+		require.Nil(t, err)
+		assert.Len(t, fileNames, 1)
+
+		timestamps, err := getSnapshotTimestamps(dirName, indexID)
+		require.Nil(t, err)
+		assert.Len(t, timestamps, 1)
+	})
+
+	t.Run("the rebuilt graph is restored from disk", func(t *testing.T) {
+		updated := uc
+		updated.MaxConnections = 16
+		updated.EFConstruction = 64
+		restored := makeIndex(MakeNoopCommitLogger, updated)
+
+		assert.Equal(t, index.entryPointID, restored.entryPointID)
+		assert.Equal(t, index.currentMaximumLayer, restored.currentMaximumLayer)
+		assertRecall(t, restored)
+	})
+
+	t.Run("a running rebuild is cancelled by a newer one", func(t *testing.T) {
+		updated := uc
+		updated.MaxConnections = 12
+		require.Nil(t, index.UpdateUserConfig(updated))
+		updated.MaxConnections = 10
+		require.Nil(t, index.UpdateUserConfig(updated))
+
+		waitForRebuild(t, index)
+
+		status := index.RebuildStatus()
+		require.Equal(t, RebuildStatusCompleted, status.Status, status.Error)
+		assert.Equal(t, 10, index.maximumConnections)
+		assertRecall(t, index)
+	})
+
+	t.Run("no rebuild if the construction parameters are unchanged", func(t *testing.T) {
+		before := index.RebuildStatus()
+
+		updated := uc
+		updated.MaxConnections = 10
+		updated.EF = 128
+		require.Nil(t, index.UpdateUserConfig(updated))
+
+		assert.Equal(t, before, index.RebuildStatus())
+	})
+}
+
+func TestRebuild_ResumedAfterRestart(t *testing.T) {
+	dirName := t.TempDir()
+	indexID := "integrationtest_rebuild_restart"
+	logger, _ := test.NewNullLogger()
+
+	r := rand.New(rand.NewSource(7))
+	vectors := randomCompressionTestVectors(r, 300, 32)
+
+	makeIndex := func(uc UserConfig) *hnsw {
+		index, err := New(Config{
+			RootPath: dirName,
+			ID:       indexID,
+			MakeCommitLoggerThunk: func() (CommitLogger, error) {
+				return NewCommitLogger(dirName, indexID, 0, logger)
+			},
+			DistanceProvider: distancer.NewL2SquaredProvider(),
+			VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
+				return vectors[int(id)], nil
+			},
+		}, uc)
+		require.Nil(t, err)
+		return index
+	}
+
+	uc := UserConfig{
+		MaxConnections:        8,
+		EFConstruction:        16,
+		EF:                    64,
+		VectorCacheMaxObjects: 1e6,
+	}
+
+	index := makeIndex(uc)
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+	require.Nil(t, index.Flush())
+
+	// the updated config is persisted, but the process stops before the
+	// rebuild started by it completes
+	updated := uc
+	updated.MaxConnections = 16
+	updated.EFConstruction = 64
+
+	t.Run("the graph keeps the parameters it was built with", func(t *testing.T) {
+		index = makeIndex(updated)
+		assert.Equal(t, 8, index.maximumConnections)
+		assert.Equal(t, 16, index.efConstruction)
+	})
+
+	t.Run("the rebuild is started again", func(t *testing.T) {
+		index.PostStartup()
+		waitForRebuild(t, index)
+
+		status := index.RebuildStatus()
+		require.Equal(t, RebuildStatusCompleted, status.Status, status.Error)
+		assert.Equal(t, 16, index.maximumConnections)
+		assert.Equal(t, 64, index.efConstruction)
+		require.Nil(t, index.Flush())
+	})
+
+	t.Run("no rebuild once the parameters match", func(t *testing.T) {
+		index = makeIndex(updated)
+		assert.Equal(t, 16, index.maximumConnections)
+		assert.Equal(t, 64, index.efConstruction)
+
+		index.PostStartup()
+		assert.Equal(t, RebuildStatusIdle, index.RebuildStatus().Status)
+	})
+}
+
+func waitForRebuild(t *testing.T, index *hnsw) {
+	deadline := time.Now().Add(30 * time.Second)
+	for index.RebuildStatus().Status == RebuildStatusRunning {
+		require.True(t, time.Now().Before(deadline), "rebuild did not complete")
+		time.Sleep(10 * time.Millisecond)
+	}
+}
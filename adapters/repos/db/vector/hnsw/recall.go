@@ -0,0 +1,298 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+// RecallReport compares the results of searching the graph with the results
+// of an exact search over all nodes of the index. Unlike the offline recall
+// tests it is meant to be served by a running server, so that a change of ef
+// or of the graph configuration can be validated against the real data.
+type RecallReport struct {
+	Queries int
+	K       int
+	EF      int
+
+	// Recall is the mean recall@k over all queries, MinRecall the recall of
+	// the worst query
+	Recall    float64
+	MinRecall float64
+
+	// Latency is measured per query on the graph, the exact search visits
+	// every node once for all queries, so only its total duration is known
+	Latency   LatencyReport
+	ExactTook time.Duration
+}
+
+// LatencyReport contains the percentiles of the search latencies
+type LatencyReport struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// EvaluateRecall measures the recall@k of the graph. The queries are
+// searched on the graph and compared to an exact search over all nodes of
+// the index. If no queries are set, the vectors of sampleSize random nodes
+// are used as queries instead. If ef is set, it overrides the configured ef
+// for the duration of the evaluation.
+func (h *hnsw) EvaluateRecall(ctx context.Context, queries [][]float32,
+	sampleSize, k, ef int) (RecallReport, error) {
+	if k < 1 {
+		return RecallReport{}, errors.Errorf("k must be at least 1, got %d", k)
+	}
+
+	h.swapLock.RLock()
+	defer h.swapLock.RUnlock()
+
+	ids := h.liveNodeIDs()
+
+	if len(queries) == 0 {
+		if sampleSize < 1 {
+			return RecallReport{}, errors.Errorf("either queries or a sample size "+
+				"of at least 1 must be set, got %d", sampleSize)
+		}
+
+		sampled, err := h.sampleQueries(ctx, ids, sampleSize)
+		if err != nil {
+			return RecallReport{}, errors.Wrap(err, "sample queries")
+		}
+		queries = sampled
+	} else if h.distancerProvider.Type() == "cosine-dot" {
+		normalized := make([][]float32, len(queries))
+		for i, query := range queries {
+			normalized[i] = distancer.Normalize(query)
+		}
+		queries = normalized
+	}
+
+	if len(queries) == 0 {
+		return RecallReport{}, errors.New("the index is empty and no queries " +
+			"were set, there is nothing to evaluate")
+	}
+
+	if ef < 1 {
+		ef = h.searchTimeEF(k)
+	} else if ef < k {
+		ef = k
+	}
+
+	report := RecallReport{Queries: len(queries), K: k, EF: ef}
+
+	results := make([][]uint64, len(queries))
+	latencies := make([]time.Duration, len(queries))
+	for i, query := range queries {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		before := time.Now()
+		res, _, err := h.knnSearchByVector(query, k, ef, nil)
+		if err != nil {
+			return report, errors.Wrapf(err, "search query %d", i)
+		}
+		latencies[i] = time.Since(before)
+		results[i] = res
+	}
+
+	before := time.Now()
+	exact, err := h.exactSearch(ctx, queries, ids, k)
+	if err != nil {
+		return report, errors.Wrap(err, "exact search")
+	}
+	report.ExactTook = time.Since(before)
+
+	report.Recall, report.MinRecall = recall(results, exact)
+	report.Latency = latencyPercentiles(latencies)
+
+	return report, nil
+}
+
+// liveNodeIDs returns the ids of all nodes which are not tombstoned
+func (h *hnsw) liveNodeIDs() []uint64 {
+	h.Lock()
+	nodes := h.nodes
+	h.Unlock()
+
+	ids := make([]uint64, 0, len(nodes))
+	for i, node := range nodes {
+		if node == nil || h.hasTombstone(uint64(i)) {
+			continue
+		}
+
+		ids = append(ids, uint64(i))
+	}
+
+	return ids
+}
+
+func (h *hnsw) sampleQueries(ctx context.Context, ids []uint64,
+	sampleSize int) ([][]float32, error) {
+	candidates := make([]uint64, len(ids))
+	copy(candidates, ids)
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	queries := make([][]float32, 0, sampleSize)
+	for _, id := range candidates {
+		if len(queries) == sampleSize {
+			break
+		}
+
+		vec, err := h.nodeVector(ctx, id)
+		if err != nil {
+			var e storobj.ErrNotFound
+			if errors.As(err, &e) {
+				// deleted in the meantime
+				continue
+			}
+			return nil, errors.Wrapf(err, "get vector of docID %d", id)
+		}
+
+		queries = append(queries, vec)
+	}
+
+	return queries, nil
+}
+
+// exactSearch returns the ids of the k nearest nodes for every query. Each
+// vector is only read once and compared to all queries.
+func (h *hnsw) exactSearch(ctx context.Context, queries [][]float32,
+	ids []uint64, k int) ([][]uint64, error) {
+	heaps := make([]*priorityqueue.Queue, len(queries))
+	for i := range heaps {
+		heaps[i] = priorityqueue.NewMax(k)
+	}
+
+	for i, id := range ids {
+		if i%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		vec, err := h.nodeVector(ctx, id)
+		if err != nil {
+			var e storobj.ErrNotFound
+			if errors.As(err, &e) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "get vector of docID %d", id)
+		}
+
+		for q, query := range queries {
+			dist, ok, err := h.distancerProvider.SingleDist(query, vec)
+			if err != nil {
+				return nil, errors.Wrapf(err, "distance of query %d to docID %d", q, id)
+			}
+
+			if !ok {
+				continue
+			}
+
+			if heaps[q].Len() < k {
+				heaps[q].Insert(id, dist)
+			} else if heaps[q].Top().Dist > dist {
+				heaps[q].Pop()
+				heaps[q].Insert(id, dist)
+			}
+		}
+	}
+
+	out := make([][]uint64, len(queries))
+	for q, heap := range heaps {
+		out[q] = make([]uint64, heap.Len())
+		for i := len(out[q]) - 1; i >= 0; i-- {
+			out[q][i] = heap.Pop().ID
+		}
+	}
+
+	return out, nil
+}
+
+// recall returns the mean and the minimum recall of the results compared to
+// the exact results. Queries without any exact results are skipped.
+func recall(results, exact [][]uint64) (float64, float64) {
+	var sum float64
+	min := 1.0
+	evaluated := 0
+	for q := range exact {
+		if len(exact[q]) == 0 {
+			continue
+		}
+
+		relevant := make(map[uint64]struct{}, len(exact[q]))
+		for _, id := range exact[q] {
+			relevant[id] = struct{}{}
+		}
+
+		found := 0
+		for _, id := range results[q] {
+			if _, ok := relevant[id]; ok {
+				found++
+			}
+		}
+
+		r := float64(found) / float64(len(exact[q]))
+		sum += r
+		if r < min {
+			min = r
+		}
+		evaluated++
+	}
+
+	if evaluated == 0 {
+		return 0, 0
+	}
+
+	return sum / float64(evaluated), min
+}
+
+func latencyPercentiles(latencies []time.Duration) LatencyReport {
+	if len(latencies) == 0 {
+		return LatencyReport{}
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+
+	percentile := func(p float64) time.Duration {
+		pos := int(p*float64(len(sorted))+0.5) - 1
+		if pos < 0 {
+			pos = 0
+		}
+		if pos >= len(sorted) {
+			pos = len(sorted) - 1
+		}
+		return sorted[pos]
+	}
+
+	return LatencyReport{
+		P50: percentile(0.5),
+		P90: percentile(0.9),
+		P99: percentile(0.99),
+		Max: sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,106 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateRecall(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	vectors := randomCompressionTestVectors(r, 1000, 16)
+	index := compressionTestIndex(t, distancer.NewL2SquaredProvider(), &vectors)
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	ctx := context.Background()
+
+	t.Run("with sampled queries", func(t *testing.T) {
+		report, err := index.EvaluateRecall(ctx, nil, 50, 10, 0)
+		require.Nil(t, err)
+
+		assert.Equal(t, 50, report.Queries)
+		assert.Equal(t, 10, report.K)
+		assert.Equal(t, index.searchTimeEF(10), report.EF)
+		assert.Greater(t, report.Recall, 0.9)
+		assert.LessOrEqual(t, report.MinRecall, report.Recall)
+		assert.LessOrEqual(t, report.Latency.P50, report.Latency.P90)
+		assert.LessOrEqual(t, report.Latency.P90, report.Latency.P99)
+		assert.LessOrEqual(t, report.Latency.P99, report.Latency.Max)
+		assert.Greater(t, report.ExactTook, time.Duration(0))
+	})
+
+	t.Run("with explicit queries and ef", func(t *testing.T) {
+		queries := randomCompressionTestVectors(r, 20, 16)
+		report, err := index.EvaluateRecall(ctx, queries, 0, 10, 200)
+		require.Nil(t, err)
+
+		assert.Equal(t, 20, report.Queries)
+		assert.Equal(t, 200, report.EF)
+		assert.Greater(t, report.Recall, 0.95)
+	})
+
+	t.Run("ef is never lower than k", func(t *testing.T) {
+		report, err := index.EvaluateRecall(ctx, nil, 5, 10, 2)
+		require.Nil(t, err)
+		assert.Equal(t, 10, report.EF)
+	})
+
+	t.Run("deleted nodes are not expected in the results", func(t *testing.T) {
+		require.Nil(t, index.Delete(7))
+
+		report, err := index.EvaluateRecall(ctx, [][]float32{vectors[7]}, 0, 1, 100)
+		require.Nil(t, err)
+		assert.Equal(t, 1.0, report.Recall)
+	})
+
+	t.Run("without k", func(t *testing.T) {
+		_, err := index.EvaluateRecall(ctx, nil, 10, 0, 0)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("without queries or a sample size", func(t *testing.T) {
+		_, err := index.EvaluateRecall(ctx, nil, 0, 10, 0)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRecallOfResults(t *testing.T) {
+	results := [][]uint64{{1, 2, 3}, {4, 5, 6}, {}}
+	exact := [][]uint64{{1, 2, 3}, {4, 7, 8}, {}}
+
+	mean, min := recall(results, exact)
+	assert.InDelta(t, 2.0/3, mean, 0.0001)
+	assert.InDelta(t, 1.0/3, min, 0.0001)
+}
+
+func TestLatencyPercentiles(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		// in reverse order to make sure the latencies are sorted
+		latencies[i] = time.Duration(100-i) * time.Millisecond
+	}
+
+	report := latencyPercentiles(latencies)
+	assert.Equal(t, 50*time.Millisecond, report.P50)
+	assert.Equal(t, 90*time.Millisecond, report.P90)
+	assert.Equal(t, 99*time.Millisecond, report.P99)
+	assert.Equal(t, 100*time.Millisecond, report.Max)
+}
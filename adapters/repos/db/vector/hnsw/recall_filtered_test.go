@@ -0,0 +1,153 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build benchmarkRecall
+// +build benchmarkRecall
+
+package hnsw
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilteredRecall(t *testing.T) {
+	const (
+		size    = 10000
+		dims    = 32
+		queries = 50
+		k       = 10
+	)
+
+	r := rand.New(rand.NewSource(7))
+	vectors := randomCompressionTestVectors(r, size, dims)
+	provider := distancer.NewL2SquaredProvider()
+
+	index, err := New(Config{
+		RootPath:              "doesnt-matter-as-committlogger-is-mocked-out",
+		ID:                    "filtered-recall-test",
+		MakeCommitLoggerThunk: MakeNoopCommitLogger,
+		DistanceProvider:      provider,
+		VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
+			return vectors[int(id)], nil
+		},
+	}, UserConfig{
+		MaxConnections:        16,
+		EFConstruction:        64,
+		EF:                    64,
+		VectorCacheMaxObjects: 1e6,
+		FilterStrategy:        FilterStrategyAcorn,
+	})
+	require.Nil(t, err)
+
+	// the point of this test is to measure the graph traversal, so the flat
+	// search must never be used for small allow lists
+	index.forbidFlat = true
+
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	queryVectors := randomCompressionTestVectors(r, queries, dims)
+
+	type test struct {
+		name      string
+		allowList helpers.AllowList
+		minRecall float32
+	}
+
+	var tests []test
+	for _, selectivity := range []float32{0.01, 0.05, 0.1} {
+		tests = append(tests, test{
+			name: fmt.Sprintf("random filter with %.0f%% selectivity",
+				selectivity*100),
+			allowList: randomFilteredRecallAllowList(r, size, selectivity),
+			minRecall: 0.9,
+		})
+	}
+
+	// a filter which is correlated with the vectors: all allowed nodes are
+	// located in the same region of the vector space, which is far away from
+	// most queries
+	correlated := helpers.AllowList{}
+	for i, vec := range vectors {
+		if vec[0] > 0.9 && vec[1] > 0.5 {
+			correlated.Insert(uint64(i))
+		}
+	}
+	tests = append(tests, test{
+		name:      "correlated filter",
+		allowList: correlated,
+		minRecall: 0.9,
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var relevant, retrieved int
+			for _, query := range queryVectors {
+				truth := bruteForceWithAllowList(provider, vectors, query, k,
+					test.allowList)
+				results, _, err := index.SearchByVector(query, k, test.allowList)
+				require.Nil(t, err)
+
+				for _, id := range results {
+					assert.True(t, test.allowList.Contains(id),
+						"result %d must match the filter", id)
+				}
+
+				retrieved += len(truth)
+				relevant += matchesInTruth(truth, results)
+			}
+
+			recall := float32(relevant) / float32(retrieved)
+			t.Logf("allowed=%d recall=%f", len(test.allowList), recall)
+			assert.GreaterOrEqual(t, recall, test.minRecall)
+		})
+	}
+}
+
+func randomFilteredRecallAllowList(r *rand.Rand, size int,
+	selectivity float32) helpers.AllowList {
+	allow := helpers.AllowList{}
+	for i := 0; i < size; i++ {
+		if r.Float32() < selectivity {
+			allow.Insert(uint64(i))
+		}
+	}
+	return allow
+}
+
+func bruteForceWithAllowList(provider distancer.Provider, vectors [][]float32,
+	query []float32, k int, allowList helpers.AllowList) []uint64 {
+	ids := make([]uint64, 0, len(allowList))
+	dists := make([]float32, len(vectors))
+	for id := range allowList {
+		ids = append(ids, id)
+		dists[id], _, _ = provider.SingleDist(query, vectors[id])
+	}
+
+	sort.Slice(ids, func(a, b int) bool {
+		return dists[ids[a]] < dists[ids[b]]
+	})
+
+	if len(ids) > k {
+		ids = ids[:k]
+	}
+	return ids
+}
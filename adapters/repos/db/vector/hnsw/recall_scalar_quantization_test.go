@@ -0,0 +1,126 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build benchmarkRecall
+// +build benchmarkRecall
+
+package hnsw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecallScalarQuantization(t *testing.T) {
+	efConstruction := 256
+	maxNeighbors := 64
+
+	var vectors [][]float32
+	var queries [][]float32
+	var truths [][]uint64
+
+	t.Run("load recall fixtures", func(t *testing.T) {
+		vectorsJSON, err := ioutil.ReadFile("recall_vectors.json")
+		require.Nil(t, err)
+		require.Nil(t, json.Unmarshal(vectorsJSON, &vectors))
+
+		queriesJSON, err := ioutil.ReadFile("recall_queries.json")
+		require.Nil(t, err)
+		require.Nil(t, json.Unmarshal(queriesJSON, &queries))
+
+		truthsJSON, err := ioutil.ReadFile("recall_truths.json")
+		require.Nil(t, err)
+		require.Nil(t, json.Unmarshal(truthsJSON, &truths))
+	})
+
+	for _, sqType := range []string{
+		compressionhelpers.ScalarQuantizationFloat16,
+		compressionhelpers.ScalarQuantizationInt8,
+	} {
+		t.Run(sqType, func(t *testing.T) {
+			index, err := New(Config{
+				RootPath:              "doesnt-matter-as-committlogger-is-mocked-out",
+				ID:                    "recallbenchmark",
+				MakeCommitLoggerThunk: MakeNoopCommitLogger,
+				DistanceProvider:      distancer.NewCosineDistanceProvider(),
+				VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
+					return vectors[int(id)], nil
+				},
+			}, UserConfig{
+				MaxConnections:        maxNeighbors,
+				EFConstruction:        efConstruction,
+				EF:                    128,
+				VectorCacheMaxObjects: 1e12,
+			})
+			require.Nil(t, err)
+
+			before := time.Now()
+			workerCount := runtime.GOMAXPROCS(0)
+			wg := &sync.WaitGroup{}
+			for workerID := 0; workerID < workerCount; workerID++ {
+				wg.Add(1)
+				go func(workerID int) {
+					defer wg.Done()
+					for i := workerID; i < len(vectors); i += workerCount {
+						require.Nil(t, index.Add(uint64(i), vectors[i]))
+					}
+				}(workerID)
+			}
+			wg.Wait()
+			fmt.Printf("importing took %s\n", time.Since(before))
+
+			recall := func() float32 {
+				var relevant int
+				for i := range queries {
+					results, _, err := index.SearchByVector(queries[i], 1, nil)
+					require.Nil(t, err)
+					relevant += matchesInLists(truths[i], results)
+				}
+				return float32(relevant) / float32(len(queries))
+			}
+
+			// the quantized recall is compared to the recall of the same graph
+			// on the full vectors, so that only the loss caused by the
+			// quantization is measured
+			uncompressed := recall()
+			fmt.Printf("uncompressed recall is %f\n", uncompressed)
+
+			cfg := SQConfig{
+				Enabled:       true,
+				Type:          sqType,
+				TrainingLimit: DefaultSQTrainingLimit,
+			}
+			require.Nil(t, index.compressScalar(cfg))
+
+			atomic.StoreInt32(&index.rescoreCompressed, 1)
+			withRescore := recall()
+			fmt.Printf("%s recall with rescoring is %f\n", sqType, withRescore)
+			assert.GreaterOrEqual(t, withRescore, uncompressed-0.01)
+
+			atomic.StoreInt32(&index.rescoreCompressed, 0)
+			withoutRescore := recall()
+			fmt.Printf("%s recall without rescoring is %f\n", sqType, withoutRescore)
+			assert.GreaterOrEqual(t, withoutRescore, uncompressed-0.03)
+		})
+	}
+}
@@ -63,7 +63,7 @@ func TestRecall(t *testing.T) {
 			RootPath:              "doesnt-matter-as-committlogger-is-mocked-out",
 			ID:                    "recallbenchmark",
 			MakeCommitLoggerThunk: MakeNoopCommitLogger,
-			DistanceProvider:      distancer.NewDotProductProvider(),
+			DistanceProvider:      distancer.NewCosineDistanceProvider(),
 			VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
 				return vectors[int(id)], nil
 			},
@@ -106,7 +106,7 @@ func TestRecall(t *testing.T) {
 		hasDuplicates := 0
 
 		for _, vec := range queries {
-			results, err := vectorIndex.SearchByVector(vec, k, nil)
+			results, _, err := vectorIndex.SearchByVector(vec, k, nil)
 			require.Nil(t, err)
 			if containsDuplicates(results) {
 				hasDuplicates++
@@ -124,7 +124,7 @@ func TestRecall(t *testing.T) {
 		var retrieved int
 
 		for i := 0; i < len(queries); i++ {
-			results, err := vectorIndex.SearchByVector(queries[i], k, nil)
+			results, _, err := vectorIndex.SearchByVector(queries[i], k, nil)
 			require.Nil(t, err)
 
 			retrieved += k
@@ -162,7 +162,7 @@ func bruteForce(vectors [][]float32, query []float32, k int) []uint64 {
 
 	distances := make([]distanceAndIndex, len(vectors))
 
-	distancer := distancer.NewDotProductProvider().New(query)
+	distancer := distancer.NewCosineDistanceProvider().New(query)
 	for i, vec := range vectors {
 		dist, _, _ := distancer.Distance(vec)
 		distances[i] = distanceAndIndex{
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/visited"
@@ -54,29 +55,40 @@ func autoEfFromK(k int) int {
 }
 
 func (h *hnsw) SearchByVector(vector []float32, k int, allowList helpers.AllowList) ([]uint64, []float32, error) {
+	h.swapLock.RLock()
+	defer h.swapLock.RUnlock()
+
 	if h.distancerProvider.Type() == "cosine-dot" {
 		// cosine-dot requires normalized vectors, as the dot product and cosine
 		// similarity are only identical if the vector is normalized
 		vector = distancer.Normalize(vector)
 	}
 
-	flatSearchCutoff := int(atomic.LoadInt64(&h.flatSearchCutoff))
-	if allowList != nil && !h.forbidFlat && len(allowList) < flatSearchCutoff {
+	ef := h.searchTimeEF(k)
+	if allowList != nil && !h.forbidFlat && h.useFlatSearch(len(allowList), ef) {
 		return h.flatSearch(vector, k, allowList)
 	}
-	return h.knnSearchByVector(vector, k, h.searchTimeEF(k), allowList)
+	return h.knnSearchByVector(vector, k, ef, allowList)
 }
 
 func (h *hnsw) searchLayerByVector(queryVector []float32,
 	entrypoints *priorityqueue.Queue, ef int, level int,
 	allowList helpers.AllowList) (*priorityqueue.Queue, error) {
+	if level == 0 && allowList != nil && h.useAcornSearch() {
+		return h.searchLayerByVectorWithFilter(queryVector, entrypoints, ef,
+			allowList)
+	}
+
 	h.Lock()
 	visited := h.pools.visitedLists.Borrow()
 	h.Unlock()
 
 	candidates := h.pools.pqCandidates.GetMin(ef)
 	results := h.pools.pqResults.GetMax(ef)
-	distancer := h.distancerProvider.New(queryVector)
+	distancer, err := h.newQueryDistancer(queryVector)
+	if err != nil {
+		return nil, errors.Wrap(err, "create distancer for query")
+	}
 
 	h.insertViableEntrypointsAsCandidatesAndResults(entrypoints, candidates,
 		results, level, visited, allowList)
@@ -152,7 +164,7 @@ func (h *hnsw) searchLayerByVector(queryVector []float32,
 
 				results.Insert(neighborID, distance)
 
-				h.cache.prefetch(candidates.Top().ID)
+				h.prefetch(distancer, candidates.Top().ID)
 
 				// +1 because we have added one node size calculating the len
 				if results.Len() > ef {
@@ -203,7 +215,7 @@ func (h *hnsw) insertViableEntrypointsAsCandidatesAndResults(
 }
 
 func (h *hnsw) currentWorstResultDistance(results *priorityqueue.Queue,
-	distancer distancer.Distancer) (float32, error) {
+	distancer queryDistancer) (float32, error) {
 	if results.Len() > 0 {
 		id := results.Top().ID
 		d, ok, err := h.distanceToNode(distancer, id)
@@ -225,8 +237,47 @@ func (h *hnsw) currentWorstResultDistance(results *priorityqueue.Queue,
 	}
 }
 
-func (h *hnsw) distanceToNode(distancer distancer.Distancer,
+// queryDistancer calculates the distances between the query and the nodes
+// visited during a single search. On a compressed index it uses the
+// distancer of the quantizer on the codes, otherwise the full vectors.
+type queryDistancer struct {
+	full       distancer.Distancer
+	compressed compressionhelpers.QuantizerDistancer
+}
+
+func (h *hnsw) newQueryDistancer(queryVector []float32) (queryDistancer, error) {
+	if !h.isCompressed() {
+		return queryDistancer{full: h.distancerProvider.New(queryVector)}, nil
+	}
+
+	compressed, err := h.quantizer.NewQuantizerDistancer(queryVector)
+	if err != nil {
+		return queryDistancer{}, err
+	}
+
+	return queryDistancer{compressed: compressed}, nil
+}
+
+func (h *hnsw) prefetch(distancer queryDistancer, nodeID uint64) {
+	if distancer.compressed != nil {
+		h.compressedVectorsCache.prefetch(nodeID)
+		return
+	}
+
+	h.cache.prefetch(nodeID)
+}
+
+func (h *hnsw) distanceToNode(distancer queryDistancer,
 	nodeID uint64) (float32, bool, error) {
+	if distancer.compressed != nil {
+		code, ok, err := h.compressedVectorForID(nodeID)
+		if err != nil || !ok {
+			return 0, ok, err
+		}
+
+		return distancer.compressed.Distance(code)
+	}
+
 	candidateVec, err := h.vectorForID(context.Background(), nodeID)
 	if err != nil {
 		var e storobj.ErrNotFound
@@ -239,7 +290,7 @@ func (h *hnsw) distanceToNode(distancer distancer.Distancer,
 		}
 	}
 
-	dist, _, err := distancer.Distance(candidateVec)
+	dist, _, err := distancer.full.Distance(candidateVec)
 	if err != nil {
 		return 0, false, errors.Wrap(err, "calculate distance between candidate and query")
 	}
@@ -260,7 +311,7 @@ func (h *hnsw) handleDeletedNode(docID uint64) {
 	h.addTombstone(docID)
 	h.logger.WithField("action", "attach_tombstone_to_deleted_node").
 		WithField("node_id", docID).
-		Info("found a deleted node (%d) without a tombstone, "+
+		Infof("found a deleted node (%d) without a tombstone, "+
 			"tombstone was added", docID)
 }
 
@@ -270,6 +321,13 @@ func (h *hnsw) knnSearchByVector(searchVec []float32, k int,
 		return nil, nil, nil
 	}
 
+	// on a compressed index the graph is traversed using the approximate
+	// distances, so a larger candidate set is retrieved and then rescored
+	rescore := h.shouldRescore()
+	if rescore && ef < rescoreFactor*k {
+		ef = rescoreFactor * k
+	}
+
 	entryPointID := h.entryPointID
 	entryPointDistance, ok, err := h.distBetweenNodeAndVec(entryPointID, searchVec)
 	if err != nil {
@@ -317,6 +375,20 @@ func (h *hnsw) knnSearchByVector(searchVec []float32, k int,
 		return nil, nil, errors.Wrapf(err, "knn search: search layer at level %d", 0)
 	}
 
+	if rescore {
+		for res.Len() > rescoreFactor*k {
+			res.Pop()
+		}
+
+		ids, dists, err := h.rescore(searchVec, res, k)
+		h.pools.pqResults.Put(res)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "knn search: rescore")
+		}
+
+		return ids, dists, nil
+	}
+
 	for res.Len() > k {
 		res.Pop()
 	}
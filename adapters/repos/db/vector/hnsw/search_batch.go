@@ -0,0 +1,62 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+)
+
+// SearchByVectorBatch runs one search per query vector. All searches share
+// the same allow list and run in parallel. The results are returned in the
+// order of the query vectors.
+func (h *hnsw) SearchByVectorBatch(vectors [][]float32, k int,
+	allowList helpers.AllowList) ([][]uint64, [][]float32, error) {
+	ids := make([][]uint64, len(vectors))
+	dists := make([][]float32, len(vectors))
+	errs := make([]error, len(vectors))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(vectors) {
+		workers = len(vectors)
+	}
+
+	next := int64(-1)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(vectors) {
+					return
+				}
+
+				ids[i], dists[i], errs[i] = h.SearchByVector(vectors[i], k, allowList)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "query vector %d", i)
+		}
+	}
+
+	return ids, dists, nil
+}
@@ -0,0 +1,59 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchByVectorBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	vectors := randomCompressionTestVectors(r, 500, 16)
+	index := compressionTestIndex(t, distancer.NewL2SquaredProvider(), &vectors)
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	queries := randomCompressionTestVectors(r, 50, 16)
+
+	allow := helpers.AllowList{}
+	for i := uint64(0); i < 500; i += 2 {
+		allow.Insert(i)
+	}
+
+	for _, allowList := range []helpers.AllowList{nil, allow} {
+		ids, dists, err := index.SearchByVectorBatch(queries, 10, allowList)
+		require.Nil(t, err)
+		require.Len(t, ids, len(queries))
+		require.Len(t, dists, len(queries))
+
+		for i, query := range queries {
+			expectedIDs, expectedDists, err := index.SearchByVector(query, 10, allowList)
+			require.Nil(t, err)
+			assert.Equal(t, expectedIDs, ids[i])
+			assert.Equal(t, expectedDists, dists[i])
+		}
+	}
+
+	t.Run("without query vectors", func(t *testing.T) {
+		ids, dists, err := index.SearchByVectorBatch(nil, 10, nil)
+		require.Nil(t, err)
+		assert.Len(t, ids, 0)
+		assert.Len(t, dists, 0)
+	})
+}
@@ -0,0 +1,263 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/visited"
+)
+
+// filteredSearchSeeds is the number of allowed nodes which are added as
+// additional entrypoints if the regular entrypoint does not match the filter.
+// They make sure the search can still reach allowed nodes if the filter is
+// correlated with the vectors and no allowed node is close to the entrypoint.
+const filteredSearchSeeds = 8
+
+// useFlatSearch decides whether a filtered search with the given number of
+// allowed nodes is served by a flat search rather than by traversing the
+// graph. The acorn traversal calculates the distance to at least ef nodes, so
+// a flat search is used if there are no more allowed nodes than that, it is
+// then both exact and cheaper. acornFlatSearchCutoff can raise this limit.
+func (h *hnsw) useFlatSearch(allowed, ef int) bool {
+	if !h.useAcornSearch() {
+		return allowed < int(atomic.LoadInt64(&h.flatSearchCutoff))
+	}
+
+	return allowed <= ef ||
+		allowed < int(atomic.LoadInt64(&h.acornFlatSearchCutoff))
+}
+
+func acornSearchFromStrategy(strategy string) int32 {
+	if strategy == FilterStrategySweeping {
+		return 0
+	}
+
+	// acorn is the default, this includes configs which were created before
+	// the setting existed
+	return 1
+}
+
+func (h *hnsw) useAcornSearch() bool {
+	return atomic.LoadInt32(&h.acornSearch) == 1
+}
+
+// searchLayerByVectorWithFilter is the filter-aware alternative to
+// searchLayerByVector on the lowest level. The regular search treats every
+// node as a candidate and only skips disallowed nodes when collecting the
+// results. With a restrictive filter almost all visited nodes are disallowed,
+// so the search either terminates with too few results or has to visit a large
+// part of the graph.
+//
+// Instead only allowed nodes are considered as candidates. When a neighbor of a
+// candidate is not allowed, the search walks through it and evaluates its
+// (allowed) neighbors instead. The filtered subgraph is thus extended by its
+// two-hop connections, which keeps it connected at low selectivities without
+// having to calculate a distance to any disallowed node.
+func (h *hnsw) searchLayerByVectorWithFilter(queryVector []float32,
+	entrypoints *priorityqueue.Queue, ef int,
+	allowList helpers.AllowList) (*priorityqueue.Queue, error) {
+	h.Lock()
+	visited := h.pools.visitedLists.Borrow()
+	h.Unlock()
+
+	candidates := h.pools.pqCandidates.GetMin(ef)
+	results := h.pools.pqResults.GetMax(ef)
+	distancer, err := h.newQueryDistancer(queryVector)
+	if err != nil {
+		return nil, errors.Wrap(err, "create distancer for query")
+	}
+
+	h.insertViableEntrypointsAsCandidatesAndResults(entrypoints, candidates,
+		results, 0, visited, allowList)
+
+	if results.Len() == 0 {
+		if err := h.insertFilteredSeeds(distancer, candidates, results, ef,
+			visited, allowList); err != nil {
+			return nil, errors.Wrap(err, "insert seeds matching the filter")
+		}
+	}
+
+	worstResultDistance, err := h.currentWorstResultDistance(results, distancer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "calculate distance of current last result")
+	}
+
+	for candidates.Len() > 0 {
+		candidate := candidates.Pop()
+		if candidate.Dist > worstResultDistance && results.Len() >= ef {
+			break
+		}
+
+		neighbors := h.filteredNeighbors(candidate.ID, visited, allowList)
+		for _, neighborID := range neighbors {
+			distance, ok, err := h.distanceToNode(distancer, neighborID)
+			if err != nil {
+				return nil, errors.Wrap(err, "calculate distance between candidate and query")
+			}
+
+			if !ok {
+				// node was deleted in the underlying object store
+				continue
+			}
+
+			if distance >= worstResultDistance && results.Len() >= ef {
+				continue
+			}
+
+			candidates.Insert(neighborID, distance)
+			if h.hasTombstone(neighborID) {
+				continue
+			}
+
+			results.Insert(neighborID, distance)
+			if results.Len() > ef {
+				results.Pop()
+			}
+
+			worstResultDistance = results.Top().Dist
+		}
+	}
+
+	h.pools.pqCandidates.Put(candidates)
+
+	h.Lock()
+	h.pools.visitedLists.Return(visited)
+	h.Unlock()
+
+	// results are passed on, so it's in the callers responsibility to return the
+	// list to the pool after using it
+	return results, nil
+}
+
+// filteredNeighbors returns the unvisited, allowed neighbors of the node. For
+// each neighbor that is not allowed, its own allowed neighbors are used
+// instead. The list is capped at the maximum number of connections on the
+// lowest level, so that the cost per candidate does not grow with the number
+// of disallowed neighbors. All returned nodes are marked as visited.
+func (h *hnsw) filteredNeighbors(nodeID uint64, visited *visited.List,
+	allowList helpers.AllowList) []uint64 {
+	max := h.maximumConnectionsLayerZero
+	out := make([]uint64, 0, max)
+
+	connections := h.connectionsAtLowestLevel(nodeID)
+	var walkThrough []uint64
+	for _, neighborID := range connections {
+		if visited.Visited(neighborID) {
+			continue
+		}
+		visited.Visit(neighborID)
+
+		if !allowList.Contains(neighborID) {
+			walkThrough = append(walkThrough, neighborID)
+			continue
+		}
+
+		out = append(out, neighborID)
+		if len(out) >= max {
+			return out
+		}
+	}
+
+	for _, throughID := range walkThrough {
+		for _, neighborID := range h.connectionsAtLowestLevel(throughID) {
+			if !allowList.Contains(neighborID) {
+				// disallowed nodes on the second hop are not marked as visited, they
+				// can still be walked through from a different candidate
+				continue
+			}
+
+			if visited.Visited(neighborID) {
+				continue
+			}
+			visited.Visit(neighborID)
+
+			out = append(out, neighborID)
+			if len(out) >= max {
+				return out
+			}
+		}
+	}
+
+	return out
+}
+
+func (h *hnsw) connectionsAtLowestLevel(nodeID uint64) []uint64 {
+	h.Lock()
+	if nodeID >= uint64(len(h.nodes)) {
+		h.Unlock()
+		return nil
+	}
+	node := h.nodes[nodeID]
+	h.Unlock()
+
+	if node == nil {
+		// could have been a node that already had a tombstone attached and was
+		// just cleaned up while we were waiting for a read lock
+		return nil
+	}
+
+	node.Lock()
+	connections := make([]uint64, len(node.connections[0]))
+	copy(connections, node.connections[0])
+	node.Unlock()
+
+	return connections
+}
+
+// insertFilteredSeeds adds a few allowed nodes as additional candidates and
+// results. This is only required if the entrypoint does not match the filter,
+// otherwise the search starts from an allowed node anyway.
+func (h *hnsw) insertFilteredSeeds(distancer queryDistancer,
+	candidates, results *priorityqueue.Queue, ef int, visited *visited.List,
+	allowList helpers.AllowList) error {
+	seeds := 0
+	for id := range allowList {
+		if seeds >= filteredSearchSeeds {
+			break
+		}
+
+		if visited.Visited(id) || h.hasTombstone(id) {
+			continue
+		}
+
+		h.Lock()
+		exists := id < uint64(len(h.nodes)) && h.nodes[id] != nil
+		h.Unlock()
+		if !exists {
+			// the allow list can contain objects without a vector
+			continue
+		}
+
+		visited.Visit(id)
+		distance, ok, err := h.distanceToNode(distancer, id)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		candidates.Insert(id, distance)
+		results.Insert(id, distance)
+		if results.Len() > ef {
+			results.Pop()
+		}
+		seeds++
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseFlatSearch(t *testing.T) {
+	type test struct {
+		name                  string
+		strategy              string
+		flatSearchCutoff      int64
+		acornFlatSearchCutoff int64
+		allowed               int
+		expected              bool
+	}
+
+	tests := []test{
+		{
+			name:             "acorn searches allow lists up to ef flat",
+			strategy:         FilterStrategyAcorn,
+			flatSearchCutoff: DefaultFlatSearchCutoff,
+			allowed:          100,
+			expected:         true,
+		},
+		{
+			name:             "acorn traverses larger allow lists regardless of flatSearchCutoff",
+			strategy:         FilterStrategyAcorn,
+			flatSearchCutoff: DefaultFlatSearchCutoff,
+			allowed:          101,
+			expected:         false,
+		},
+		{
+			name:                  "acornFlatSearchCutoff raises the limit",
+			strategy:              FilterStrategyAcorn,
+			acornFlatSearchCutoff: 5000,
+			allowed:               4999,
+			expected:              true,
+		},
+		{
+			name:                  "acorn traverses allow lists past acornFlatSearchCutoff",
+			strategy:              FilterStrategyAcorn,
+			acornFlatSearchCutoff: 5000,
+			allowed:               5000,
+			expected:              false,
+		},
+		{
+			name:             "sweeping uses flatSearchCutoff",
+			strategy:         FilterStrategySweeping,
+			flatSearchCutoff: DefaultFlatSearchCutoff,
+			allowed:          DefaultFlatSearchCutoff - 1,
+			expected:         true,
+		},
+		{
+			name:             "sweeping traverses allow lists past flatSearchCutoff",
+			strategy:         FilterStrategySweeping,
+			flatSearchCutoff: DefaultFlatSearchCutoff,
+			allowed:          DefaultFlatSearchCutoff,
+			expected:         false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := &hnsw{
+				flatSearchCutoff:      test.flatSearchCutoff,
+				acornFlatSearchCutoff: test.acornFlatSearchCutoff,
+				acornSearch:           acornSearchFromStrategy(test.strategy),
+			}
+			assert.Equal(t, test.expected, h.useFlatSearch(test.allowed, 100))
+		})
+	}
+}
+
+// TestFilteredSearch is a small version of the recall benchmark of restrictive
+// filters in recall_filtered_test.go, with the default cutoffs the allow lists
+// are served by the acorn traversal
+func TestFilteredSearch(t *testing.T) {
+	const (
+		size = 2000
+		dims = 16
+		k    = 10
+		ef   = 64
+	)
+
+	r := rand.New(rand.NewSource(3))
+	vectors := randomCompressionTestVectors(r, size, dims)
+
+	index, err := New(Config{
+		RootPath:              "doesnt-matter-as-committlogger-is-mocked-out",
+		ID:                    "filtered-search-test",
+		MakeCommitLoggerThunk: MakeNoopCommitLogger,
+		DistanceProvider:      distancer.NewL2SquaredProvider(),
+		VectorForIDThunk: func(ctx context.Context, id uint64) ([]float32, error) {
+			return vectors[int(id)], nil
+		},
+	}, UserConfig{
+		MaxConnections:        16,
+		EFConstruction:        64,
+		EF:                    ef,
+		VectorCacheMaxObjects: 1e6,
+		FlatSearchCutoff:      DefaultFlatSearchCutoff,
+		FilterStrategy:        FilterStrategyAcorn,
+	})
+	require.Nil(t, err)
+
+	for i, vec := range vectors {
+		require.Nil(t, index.Add(uint64(i), vec))
+	}
+
+	queries := randomCompressionTestVectors(r, 20, dims)
+
+	for _, every := range []uint64{10, 20} {
+		t.Run(fmt.Sprintf("%d%% of the vectors allowed", 100/every), func(t *testing.T) {
+			allowList := helpers.AllowList{}
+			for i := uint64(0); i < size; i += every {
+				allowList.Insert(i)
+			}
+			require.False(t, index.useFlatSearch(len(allowList), ef))
+
+			var results, exact [][]uint64
+			for _, query := range queries {
+				res, _, err := index.SearchByVector(query, k, allowList)
+				require.Nil(t, err)
+				require.Len(t, res, k)
+				for _, id := range res {
+					assert.True(t, allowList.Contains(id))
+				}
+				results = append(results, res)
+
+				expected, _, err := index.flatSearch(query, k, allowList)
+				require.Nil(t, err)
+				exact = append(exact, expected)
+			}
+
+			mean, _ := recall(results, exact)
+			assert.GreaterOrEqual(t, mean, 0.9)
+		})
+	}
+}
@@ -12,23 +12,64 @@
 package hnsw
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/helpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/priorityqueue"
+	"github.com/semi-technologies/weaviate/entities/storobj"
 )
 
 func (h *hnsw) KnnSearchByVectorMaxDist(searchVec []float32, dist float32,
 	ef int, allowList helpers.AllowList) ([]uint64, error) {
+	h.swapLock.RLock()
+	defer h.swapLock.RUnlock()
+
+	entryPointID, entryPointDistance, err := h.entrypointAtLayerZero(searchVec)
+	if err != nil {
+		return nil, err
+	}
+
+	eps := priorityqueue.NewMin(1)
+	eps.Insert(entryPointID, entryPointDistance)
+	res, err := h.searchLayerByVector(searchVec, eps, ef, 0, allowList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "knn search: search layer at level %d", 0)
+	}
+
+	all := make([]priorityqueue.Item, res.Len())
+	i := res.Len() - 1
+	for res.Len() > 0 {
+		all[i] = res.Pop()
+		i--
+	}
+
+	out := make([]uint64, len(all))
+	i = 0
+	for _, elem := range all {
+		if elem.Dist > dist {
+			break
+		}
+		out[i] = elem.ID
+		i++
+	}
+
+	h.pools.pqResults.Put(res)
+	return out[:i], nil
+}
+
+// entrypointAtLayerZero descends the upper layers towards the query and
+// returns the node to start the search at layer 0 from
+func (h *hnsw) entrypointAtLayerZero(searchVec []float32) (uint64, float32, error) {
 	entryPointID := h.entryPointID
 	entryPointDistance, ok, err := h.distBetweenNodeAndVec(entryPointID, searchVec)
 	if err != nil {
-		return nil, errors.Wrap(err, "knn search: distance between entrypint and query node")
+		return 0, 0, errors.Wrap(err, "knn search: distance between entrypint and query node")
 	}
 
 	if !ok {
-		return nil, fmt.Errorf("entrypoint was deleted in the object store, " +
+		return 0, 0, fmt.Errorf("entrypoint was deleted in the object store, " +
 			"it has been flagged for cleanup and should be fixed in the next cleanup cycle")
 	}
 
@@ -39,7 +80,7 @@ func (h *hnsw) KnnSearchByVectorMaxDist(searchVec []float32, dist float32,
 		// ignore allowList on layers > 0
 		res, err := h.searchLayerByVector(searchVec, eps, 1, level, nil)
 		if err != nil {
-			return nil, errors.Wrapf(err, "knn search: search layer at level %d", level)
+			return 0, 0, errors.Wrapf(err, "knn search: search layer at level %d", level)
 		}
 		if res.Len() > 0 {
 			best := res.Pop()
@@ -50,30 +91,118 @@ func (h *hnsw) KnnSearchByVectorMaxDist(searchVec []float32, dist float32,
 		h.pools.pqResults.Put(res)
 	}
 
-	eps := priorityqueue.NewMin(1)
-	eps.Insert(entryPointID, entryPointDistance)
-	res, err := h.searchLayerByVector(searchVec, eps, ef, 0, allowList)
+	return entryPointID, entryPointDistance, nil
+}
+
+// SearchByVectorWithinDist returns all ids within the distance of the query,
+// together with their vectors as held by the vector cache. Unlike
+// KnnSearchByVectorMaxDist the number of results is not limited by ef:
+// starting at the node nearest to the query, layer 0 is traversed closest
+// first and the results grow with every node that is found within the
+// distance. ef limits how many nodes outside of the distance are expanded
+// before the traversal stops, so that nodes within the distance which are
+// only connected through nodes outside of it are still found.
+func (h *hnsw) SearchByVectorWithinDist(searchVec []float32, dist float32,
+	ef int) ([]uint64, [][]float32, error) {
+	h.swapLock.RLock()
+	defer h.swapLock.RUnlock()
+
+	if h.isCompressed() {
+		return nil, nil, errors.Errorf("search within distance is not " +
+			"supported on a compressed index")
+	}
+
+	if h.isEmpty() {
+		return nil, nil, nil
+	}
+
+	entryPointID, entryPointDistance, err := h.entrypointAtLayerZero(searchVec)
 	if err != nil {
-		return nil, errors.Wrapf(err, "knn search: search layer at level %d", 0)
+		return nil, nil, err
 	}
 
-	all := make([]priorityqueue.Item, res.Len())
-	i := res.Len() - 1
-	for res.Len() > 0 {
-		all[i] = res.Pop()
-		i--
+	h.Lock()
+	visited := h.pools.visitedLists.Borrow()
+	h.Unlock()
+	defer func() {
+		h.Lock()
+		h.pools.visitedLists.Return(visited)
+		h.Unlock()
+	}()
+
+	candidates := priorityqueue.NewMin(ef)
+	candidates.Insert(entryPointID, entryPointDistance)
+	visited.Visit(entryPointID)
+
+	var ids []uint64
+	var vectors [][]float32
+	consider := func(id uint64) (float32, bool, error) {
+		vec, err := h.vectorForID(context.Background(), id)
+		if err != nil {
+			var e storobj.ErrNotFound
+			if errors.As(err, &e) {
+				h.handleDeletedNode(e.DocID)
+				return 0, false, nil
+			}
+			return 0, false, errors.Wrapf(err,
+				"could not get vector of object at docID %d", id)
+		}
+
+		d, ok, err := h.distancerProvider.SingleDist(searchVec, vec)
+		if err != nil || !ok {
+			return 0, ok, err
+		}
+
+		if d <= dist && !h.hasTombstone(id) {
+			ids = append(ids, id)
+			vectors = append(vectors, vec)
+		}
+
+		return d, true, nil
 	}
 
-	out := make([]uint64, len(all))
-	i = 0
-	for _, elem := range all {
-		if elem.Dist > dist {
-			break
+	if _, _, err := consider(entryPointID); err != nil {
+		return nil, nil, err
+	}
+
+	outside := 0
+	for candidates.Len() > 0 {
+		candidate := candidates.Pop()
+		if candidate.Dist > dist {
+			outside++
+			if outside > ef {
+				break
+			}
+		}
+
+		h.Lock()
+		candidateNode := h.nodes[candidate.ID]
+		h.Unlock()
+		if candidateNode == nil {
+			continue
+		}
+
+		candidateNode.Lock()
+		connections := make([]uint64, len(candidateNode.connections[0]))
+		copy(connections, candidateNode.connections[0])
+		candidateNode.Unlock()
+
+		for _, neighborID := range connections {
+			if visited.Visited(neighborID) {
+				continue
+			}
+			visited.Visit(neighborID)
+
+			d, ok, err := consider(neighborID)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if ok {
+				candidates.Insert(neighborID, d)
+			}
 		}
-		out[i] = elem.ID
-		i++
 	}
 
-	h.pools.pqResults.Put(res)
-	return out[:i], nil
+	return ids, vectors, nil
 }
@@ -0,0 +1,766 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
+	"github.com/sirupsen/logrus"
+)
+
+// A snapshot contains the entire graph (nodes, levels, connections,
+// tombstones, entrypoint and the product or scalar quantizer if any) as it was after
+// applying all commit logs up to and including the one whose timestamp is
+// used as the snapshot's name. On startup the newest valid snapshot is loaded
+// and only the commit logs written after it are replayed.
+//
+// Once a newer snapshot is written, the commit logs covered by the older one
+// are deleted. The logs between the two are kept, so that the older snapshot
+// can still be used should the newer one turn out to be corrupt.
+//
+// Layout (little endian):
+//
+//	version       uint8
+//	entrypoint    uint64
+//	level         uint16
+//	nodesLength   uint64 (length of the nodes slice, not the node count)
+//	compressed    uint8, if 1 followed by the PQ data in the same layout as
+//	              in the commit log
+//	params        uint8, if 1 followed by maxConnections uint32 and
+//	              efConstruction uint32 (since version 2)
+//	scalar        uint8, if 1 followed by the SQ data in the same layout as
+//	              in the commit log (since version 3)
+//	tombstones    uint64 count, followed by count uint64 ids
+//	nodes         uint64 count, each node is:
+//	                id uint64, level uint16, levels uint16, and per level:
+//	                level uint16, length uint32, length uint64 ids
+//	checksum      uint32, crc32 (IEEE) of all preceding bytes
+const snapshotVersion uint8 = 3
+
+// maxSnapshots is the number of snapshots kept on disk. Older snapshots are
+// only used if the newer ones are corrupt.
+const maxSnapshots = 2
+
+// defaultSnapshotThreshold is the size of commit logs not covered by a
+// snapshot yet, above which a new snapshot is created. Creating a snapshot
+// means reading the previous snapshot and all new commit logs into memory, so
+// it should not happen too frequently. Like the commit log size limits it is
+// not user-configurable, as it only trades startup time against background
+// work.
+const defaultSnapshotThreshold = maxUncondensedCommitLogSize / 5
+
+var errSnapshotChecksum = errors.New("checksum mismatch")
+
+func snapshotDirectory(rootPath, name string) string {
+	return fmt.Sprintf("%s/%s.hnsw.snapshot.d", rootPath, name)
+}
+
+func snapshotFileName(rootPath, name string, ts int64) string {
+	return fmt.Sprintf("%s/%d.snapshot", snapshotDirectory(rootPath, name), ts)
+}
+
+// getSnapshotTimestamps returns the timestamps of all complete snapshots in
+// order, from old to new. The timestamp of a snapshot is the timestamp of the
+// last commit log it contains.
+func getSnapshotTimestamps(rootPath, name string) ([]int64, error) {
+	dir := snapshotDirectory(rootPath, name)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "browse snapshot directory")
+	}
+
+	var out []int64
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".snapshot.tmp") {
+			// a snapshot was being written when we crashed, it is incomplete
+			if err := os.Remove(filepath.Join(dir, file.Name())); err != nil {
+				return nil, errors.Wrap(err, "remove tmp snapshot file")
+			}
+			continue
+		}
+
+		if !strings.HasSuffix(file.Name(), ".snapshot") {
+			continue
+		}
+
+		ts, err := strconv.ParseInt(strings.TrimSuffix(file.Name(), ".snapshot"),
+			10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse snapshot name %q", file.Name())
+		}
+
+		out = append(out, ts)
+	}
+
+	sort.Slice(out, func(a, b int) bool { return out[a] < out[b] })
+	return out, nil
+}
+
+// loadNewestSnapshot returns the state of the newest snapshot which can be
+// read successfully together with its timestamp. If there is no valid
+// snapshot, the returned state is nil and all commit logs need to be
+// replayed.
+func loadNewestSnapshot(rootPath, name string,
+	logger logrus.FieldLogger) (*DeserializationResult, int64, error) {
+	timestamps, err := getSnapshotTimestamps(rootPath, name)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := len(timestamps) - 1; i >= 0; i-- {
+		fileName := snapshotFileName(rootPath, name, timestamps[i])
+		state, err := readSnapshot(fileName, logger)
+		if err == nil {
+			return state, timestamps[i], nil
+		}
+
+		logger.WithField("action", "hnsw_load_snapshot").
+			WithField("path", fileName).
+			WithError(err).
+			Warn("snapshot is corrupt, falling back to an older snapshot " +
+				"or a full commit log replay")
+	}
+
+	if len(timestamps) >= maxSnapshots {
+		// the commit logs contained in the oldest snapshot have been deleted
+		logger.WithField("action", "hnsw_load_snapshot").
+			Error("all snapshots are corrupt, the remaining commit logs do not " +
+				"contain the entire graph, some elements may not have been recovered")
+	}
+
+	return nil, 0, nil
+}
+
+// CommitLogSnapshotter periodically writes the state of all commit logs but
+// the currently active one into a snapshot
+type CommitLogSnapshotter struct {
+	rootPath  string
+	id        string
+	threshold int64
+	logger    logrus.FieldLogger
+}
+
+func NewCommitLogSnapshotter(rootPath, id string, threshold int64,
+	logger logrus.FieldLogger) *CommitLogSnapshotter {
+	return &CommitLogSnapshotter{
+		rootPath:  rootPath,
+		id:        id,
+		threshold: threshold,
+		logger:    logger,
+	}
+}
+
+// Do creates a new snapshot if the commit logs which are not yet contained in
+// the newest snapshot exceed the threshold. It returns true if a snapshot was
+// written.
+func (s *CommitLogSnapshotter) Do() (bool, error) {
+	fileNames, err := getCommitFileNames(s.rootPath, s.id)
+	if err != nil {
+		return false, errors.Wrap(err, "obtain files names")
+	}
+
+	if len(fileNames) <= 1 {
+		// the only file is still in use, there is nothing to snapshot
+		return false, nil
+	}
+
+	// cut off last element, as that's still being written to
+	candidates := fileNames[:len(fileNames)-1]
+
+	state, covered, err := loadNewestSnapshot(s.rootPath, s.id, s.logger)
+	if err != nil {
+		return false, errors.Wrap(err, "load previous snapshot")
+	}
+
+	var uncovered []string
+	var uncoveredSize int64
+	for _, fileName := range candidates {
+		isCovered, err := snapshotCovers(covered, fileName)
+		if err != nil {
+			return false, err
+		}
+
+		if state != nil && isCovered {
+			continue
+		}
+
+		stat, err := os.Stat(fileName)
+		if err != nil {
+			return false, errors.Wrapf(err, "stat file %q", fileName)
+		}
+
+		uncovered = append(uncovered, fileName)
+		uncoveredSize += stat.Size()
+	}
+
+	if len(uncovered) == 0 || uncoveredSize < s.threshold {
+		return false, nil
+	}
+
+	before := time.Now()
+	for _, fileName := range uncovered {
+		state, err = s.deserialize(fileName, state)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	ts, err := asTimeStamp(filepath.Base(uncovered[len(uncovered)-1]))
+	if err != nil {
+		return false, err
+	}
+
+	if err := writeSnapshot(snapshotFileName(s.rootPath, s.id, ts),
+		state); err != nil {
+		return false, errors.Wrap(err, "write snapshot")
+	}
+
+	if err := s.removeOldSnapshots(); err != nil {
+		return true, err
+	}
+
+	if err := s.removeCoveredLogs(); err != nil {
+		return true, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"action":      "hnsw_commit_log_snapshot",
+		"id":          s.id,
+		"commit_logs": len(uncovered),
+		"timestamp":   ts,
+		"took":        time.Since(before),
+	}).Info("successfully created snapshot of commit logs")
+
+	return true, nil
+}
+
+func (s *CommitLogSnapshotter) deserialize(fileName string,
+	state *DeserializationResult) (*DeserializationResult, error) {
+	fd, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open commit log %q for reading", fileName)
+	}
+	defer fd.Close()
+
+	fdBuf := bufio.NewReaderSize(fd, 256*1024)
+	state, _, err = NewDeserializer2(s.logger).Do(fdBuf, state, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "deserialize commit log %q", fileName)
+	}
+
+	return state, nil
+}
+
+func (s *CommitLogSnapshotter) removeOldSnapshots() error {
+	timestamps, err := getSnapshotTimestamps(s.rootPath, s.id)
+	if err != nil {
+		return err
+	}
+
+	if len(timestamps) <= maxSnapshots {
+		return nil
+	}
+
+	for _, ts := range timestamps[:len(timestamps)-maxSnapshots] {
+		if err := os.Remove(snapshotFileName(s.rootPath, s.id, ts)); err != nil {
+			return errors.Wrap(err, "remove old snapshot")
+		}
+	}
+
+	return nil
+}
+
+// removeCoveredLogs deletes the commit logs contained in the oldest snapshot
+// which is kept. It only runs once maxSnapshots exist, so that all logs which
+// are needed to fall back to an older snapshot are still there.
+func (s *CommitLogSnapshotter) removeCoveredLogs() error {
+	timestamps, err := getSnapshotTimestamps(s.rootPath, s.id)
+	if err != nil {
+		return err
+	}
+
+	if len(timestamps) < maxSnapshots {
+		return nil
+	}
+
+	fileNames, err := getCommitFileNames(s.rootPath, s.id)
+	if err != nil {
+		return errors.Wrap(err, "obtain files names")
+	}
+
+	for _, fileName := range fileNames {
+		covered, err := snapshotCovers(timestamps[0], fileName)
+		if err != nil {
+			return err
+		}
+
+		if !covered {
+			continue
+		}
+
+		if err := os.Remove(fileName); err != nil {
+			return errors.Wrap(err, "remove covered commit log")
+		}
+	}
+
+	return nil
+}
+
+// writeSnapshot writes into a temporary file first which is only renamed once
+// it is complete and synced, so that a crash can never leave a partial
+// snapshot behind. The directory is synced as well, as commit logs are deleted
+// based on the snapshot being there.
+func writeSnapshot(fileName string, state *DeserializationResult) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return errors.Wrap(err, "create snapshot directory")
+	}
+
+	tmpName := fileName + ".tmp"
+	fd, err := os.Create(tmpName)
+	if err != nil {
+		return errors.Wrapf(err, "create file %q", tmpName)
+	}
+	defer fd.Close()
+
+	checksum := crc32.NewIEEE()
+	w := &snapshotWriter{
+		w: bufio.NewWriterSize(io.MultiWriter(fd, checksum), 1024*1024),
+	}
+
+	w.writeSnapshot(state)
+
+	if w.err != nil {
+		return w.err
+	}
+
+	if err := w.w.Flush(); err != nil {
+		return errors.Wrap(err, "flush snapshot")
+	}
+
+	if err := binary.Write(fd, binary.LittleEndian, checksum.Sum32()); err != nil {
+		return errors.Wrap(err, "write checksum")
+	}
+
+	if err := fd.Sync(); err != nil {
+		return errors.Wrap(err, "sync snapshot")
+	}
+
+	if err := fd.Close(); err != nil {
+		return errors.Wrap(err, "close snapshot")
+	}
+
+	if err := os.Rename(tmpName, fileName); err != nil {
+		return errors.Wrap(err, "rename snapshot")
+	}
+
+	return durability.SyncDir(filepath.Dir(fileName))
+}
+
+// snapshotWriter remembers the first error, so that the individual writes
+// don't need to be checked
+type snapshotWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (w *snapshotWriter) write(in interface{}) {
+	if w.err != nil {
+		return
+	}
+
+	w.err = binary.Write(w.w, binary.LittleEndian, in)
+}
+
+func (w *snapshotWriter) writeSnapshot(state *DeserializationResult) {
+	w.write(snapshotVersion)
+	w.write(state.Entrypoint)
+	w.write(state.Level)
+	w.write(uint64(len(state.Nodes)))
+
+	if state.Compressed {
+		w.write(uint8(1))
+		w.write(state.PQData.Dimensions)
+		w.write(state.PQData.Segments)
+		w.write(state.PQData.Centroids)
+		for _, centroid := range state.PQData.Codebooks {
+			w.write(centroid)
+		}
+	} else {
+		w.write(uint8(0))
+	}
+
+	if state.ConstructionParamsSet {
+		w.write(uint8(1))
+		w.write(uint32(state.MaxConnections))
+		w.write(uint32(state.EFConstruction))
+	} else {
+		w.write(uint8(0))
+	}
+
+	if state.ScalarQuantized {
+		w.write(uint8(1))
+		w.write(state.SQData.Kind)
+		w.write(state.SQData.Dimensions)
+		w.write(state.SQData.Mins)
+		w.write(state.SQData.Scales)
+	} else {
+		w.write(uint8(0))
+	}
+
+	w.write(uint64(len(state.Tombstones)))
+	for id := range state.Tombstones {
+		w.write(id)
+	}
+
+	var count uint64
+	for _, node := range state.Nodes {
+		if node != nil {
+			count++
+		}
+	}
+
+	w.write(count)
+	for _, node := range state.Nodes {
+		if node == nil {
+			continue
+		}
+
+		w.write(node.id)
+		w.write(uint16(node.level))
+		w.write(uint16(len(node.connections)))
+		for level, links := range node.connections {
+			w.write(uint16(level))
+			w.write(uint32(len(links)))
+			w.write(links)
+		}
+	}
+}
+
+// readSnapshot reads and verifies the snapshot. Lengths are checked against
+// the remaining file size before allocating, so that a corrupt snapshot is
+// detected before it can cause huge allocations.
+func readSnapshot(fileName string,
+	logger logrus.FieldLogger) (*DeserializationResult, error) {
+	fd, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open snapshot %q", fileName)
+	}
+	defer fd.Close()
+
+	stat, err := fd.Stat()
+	if err != nil {
+		return nil, errors.Wrapf(err, "stat snapshot %q", fileName)
+	}
+
+	if stat.Size() < 4 {
+		return nil, errors.Errorf("snapshot %q is too short", fileName)
+	}
+
+	checksum := crc32.NewIEEE()
+	r := &snapshotReader{
+		r:         io.TeeReader(bufio.NewReaderSize(fd, 256*1024), checksum),
+		remaining: stat.Size() - 4,
+		checksum:  checksum,
+		logger:    logger,
+	}
+
+	state, err := r.readSnapshot()
+	if err != nil {
+		return nil, errors.Wrapf(err, "read snapshot %q", fileName)
+	}
+
+	return state, nil
+}
+
+type snapshotReader struct {
+	r         io.Reader
+	remaining int64
+	checksum  hash.Hash32
+	logger    logrus.FieldLogger
+}
+
+func (r *snapshotReader) read(out interface{}) error {
+	size := int64(binary.Size(out))
+	if size > r.remaining {
+		return io.ErrUnexpectedEOF
+	}
+
+	r.remaining -= size
+	return binary.Read(r.r, binary.LittleEndian, out)
+}
+
+// checkLength makes sure that count elements of the given size can still be
+// contained in the file
+func (r *snapshotReader) checkLength(count uint64, size int64) error {
+	if count > uint64(r.remaining/size) {
+		return errors.Errorf("length %d exceeds remaining file size", count)
+	}
+
+	return nil
+}
+
+func (r *snapshotReader) readSnapshot() (*DeserializationResult, error) {
+	var version uint8
+	if err := r.read(&version); err != nil {
+		return nil, err
+	}
+
+	if version < 1 || version > snapshotVersion {
+		return nil, errors.Errorf("unsupported snapshot version %d", version)
+	}
+
+	out := &DeserializationResult{
+		Tombstones:    make(map[uint64]struct{}),
+		LinksReplaced: make(map[uint64]map[uint16]struct{}),
+	}
+
+	var nodesLength uint64
+	var compressed uint8
+	for _, field := range []interface{}{
+		&out.Entrypoint, &out.Level, &nodesLength, &compressed,
+	} {
+		if err := r.read(field); err != nil {
+			return nil, err
+		}
+	}
+
+	if compressed == 1 {
+		before := r.remaining
+		read, err := NewDeserializer2(r.logger).ReadPQ(r.r, out)
+		if err != nil {
+			return nil, errors.Wrap(err, "read product quantizer")
+		}
+
+		r.remaining = before - int64(read)
+		if r.remaining < 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+	}
+
+	if version >= 2 {
+		if err := r.readConstructionParams(out); err != nil {
+			return nil, err
+		}
+	}
+
+	if version >= 3 {
+		if err := r.readScalarQuantizer(out); err != nil {
+			return nil, errors.Wrap(err, "read scalar quantizer")
+		}
+	}
+
+	var tombstones uint64
+	if err := r.read(&tombstones); err != nil {
+		return nil, err
+	}
+
+	if err := r.checkLength(tombstones, 8); err != nil {
+		return nil, errors.Wrap(err, "tombstones")
+	}
+
+	for i := uint64(0); i < tombstones; i++ {
+		var id uint64
+		if err := r.read(&id); err != nil {
+			return nil, err
+		}
+		out.Tombstones[id] = struct{}{}
+	}
+
+	var count uint64
+	if err := r.read(&count); err != nil {
+		return nil, err
+	}
+
+	// each node is at least 12 bytes long
+	if err := r.checkLength(count, 12); err != nil {
+		return nil, errors.Wrap(err, "nodes")
+	}
+
+	nodes := make([]*vertex, count)
+	for i := range nodes {
+		node, err := r.readNode()
+		if err != nil {
+			return nil, errors.Wrapf(err, "node %d", i)
+		}
+		nodes[i] = node
+	}
+
+	if r.remaining != 0 {
+		return nil, errors.Errorf("%d unexpected bytes before checksum",
+			r.remaining)
+	}
+
+	// the checksum itself must not be part of the calculation, so capture the
+	// sum before reading it
+	expected := r.checksum.Sum32()
+	var actual uint32
+	if err := binary.Read(r.r, binary.LittleEndian, &actual); err != nil {
+		return nil, errors.Wrap(err, "read checksum")
+	}
+
+	if actual != expected {
+		return nil, errSnapshotChecksum
+	}
+
+	// only now that the content is verified can we trust the length
+	out.Nodes = make([]*vertex, nodesLength)
+	for _, node := range nodes {
+		if node.id >= nodesLength {
+			return nil, errors.Errorf("node %d exceeds nodes length %d", node.id,
+				nodesLength)
+		}
+		out.Nodes[node.id] = node
+	}
+
+	return out, nil
+}
+
+func (r *snapshotReader) readScalarQuantizer(out *DeserializationResult) error {
+	var isSet uint8
+	if err := r.read(&isSet); err != nil {
+		return err
+	}
+
+	if isSet == 0 {
+		return nil
+	}
+
+	before := r.remaining
+	read, err := NewDeserializer2(r.logger).ReadSQ(r.r, out)
+	if err != nil {
+		return err
+	}
+
+	r.remaining = before - int64(read)
+	if r.remaining < 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	return nil
+}
+
+func (r *snapshotReader) readConstructionParams(out *DeserializationResult) error {
+	var isSet uint8
+	if err := r.read(&isSet); err != nil {
+		return err
+	}
+
+	if isSet == 0 {
+		return nil
+	}
+
+	var maxConnections, efConstruction uint32
+	if err := r.read(&maxConnections); err != nil {
+		return err
+	}
+
+	if err := r.read(&efConstruction); err != nil {
+		return err
+	}
+
+	out.ConstructionParamsSet = true
+	out.MaxConnections = int(maxConnections)
+	out.EFConstruction = int(efConstruction)
+	return nil
+}
+
+func (r *snapshotReader) readNode() (*vertex, error) {
+	var id uint64
+	var level, levels uint16
+	for _, field := range []interface{}{&id, &level, &levels} {
+		if err := r.read(field); err != nil {
+			return nil, err
+		}
+	}
+
+	node := &vertex{
+		id:          id,
+		level:       int(level),
+		connections: make(map[int][]uint64, levels),
+	}
+
+	for i := uint16(0); i < levels; i++ {
+		var connLevel uint16
+		var length uint32
+		if err := r.read(&connLevel); err != nil {
+			return nil, err
+		}
+
+		if err := r.read(&length); err != nil {
+			return nil, err
+		}
+
+		if err := r.checkLength(uint64(length), 8); err != nil {
+			return nil, errors.Wrap(err, "connections")
+		}
+
+		links := make([]uint64, length)
+		if err := r.read(links); err != nil {
+			return nil, err
+		}
+		node.connections[int(connLevel)] = links
+	}
+
+	return node, nil
+}
+
+// snapshotCovers returns true if the commit log with the given file name is
+// contained in the snapshot with the given timestamp
+func snapshotCovers(snapshotTs int64, fileName string) (bool, error) {
+	ts, err := asTimeStamp(filepath.Base(fileName))
+	if err != nil {
+		return false, err
+	}
+
+	return ts <= snapshotTs, nil
+}
+
+// straddlesSnapshot returns true if the two (consecutive) commit logs are on
+// different sides of any of the snapshots. Such logs must never be combined,
+// as the combined file would carry the name of the first file and the
+// snapshot would then appear to contain the second file as well.
+func straddlesSnapshot(snapshots []int64, first, second string) (bool, error) {
+	for _, snapshotTs := range snapshots {
+		firstCovered, err := snapshotCovers(snapshotTs, first)
+		if err != nil {
+			return false, err
+		}
+
+		secondCovered, err := snapshotCovers(snapshotTs, second)
+		if err != nil {
+			return false, err
+		}
+
+		if firstCovered != secondCovered {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,136 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+//go:build integrationTest
+// +build integrationTest
+
+package hnsw
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHnswPersistence_WithSnapshot(t *testing.T) {
+	dirName := t.TempDir()
+	indexID := "integrationtest_snapshot"
+	logger, _ := test.NewNullLogger()
+
+	cl, err := NewCommitLogger(dirName, indexID, 0, logger)
+	require.Nil(t, err)
+
+	makeIndex := func(makeCL MakeCommitLogger) *hnsw {
+		index, err := New(Config{
+			RootPath:              dirName,
+			ID:                    indexID,
+			MakeCommitLoggerThunk: makeCL,
+			DistanceProvider:      distancer.NewCosineProvider(),
+			VectorForIDThunk:      testVectorForID,
+		}, UserConfig{
+			MaxConnections: 30,
+			EFConstruction: 60,
+		})
+		require.Nil(t, err)
+		return index
+	}
+
+	index := makeIndex(func() (CommitLogger, error) { return cl, nil })
+
+	logDir := commitLogDirectory(dirName, indexID)
+	firstLog := filepath.Join(logDir, "1")
+
+	t.Run("import the first half into the first log", func(t *testing.T) {
+		for i, vec := range testVectors[:5] {
+			require.Nil(t, index.Add(uint64(i), vec))
+		}
+		require.Nil(t, index.Flush())
+
+		fileName, err := cl.commitLogger.FileName()
+		require.Nil(t, err)
+		// give the log an older timestamp, so that the switched log is
+		// guaranteed to be newer
+		require.Nil(t, os.Rename(filepath.Join(logDir, fileName), firstLog))
+
+		cl.maxSizeIndividual = 0
+		require.Nil(t, cl.maintenance())
+	})
+
+	t.Run("import the second half into the second log", func(t *testing.T) {
+		for i, vec := range testVectors[5:] {
+			require.Nil(t, index.Add(uint64(i+5), vec))
+		}
+		require.Nil(t, index.Flush())
+	})
+
+	t.Run("snapshot the first log", func(t *testing.T) {
+		ok, err := NewCommitLogSnapshotter(dirName, indexID, 0, logger).Do()
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		timestamps, err := getSnapshotTimestamps(dirName, indexID)
+		require.Nil(t, err)
+		assert.Equal(t, []int64{1}, timestamps)
+	})
+
+	// see index_test.go for more context
+	expectedResults := []uint64{
+		3, 5, 4, // cluster 2
+		7, 8, 6, // cluster 3
+		2, 1, 0, // cluster 1
+	}
+
+	res, _, err := index.knnSearchByVector(testVectors[3], 50, 36, nil)
+	require.Nil(t, err)
+	require.Equal(t, expectedResults, res)
+
+	originalFirstLog, err := ioutil.ReadFile(firstLog)
+	require.Nil(t, err)
+
+	restore := func(t *testing.T) {
+		restored := makeIndex(MakeNoopCommitLogger)
+		res, _, err := restored.knnSearchByVector(testVectors[3], 50, 36, nil)
+		require.Nil(t, err)
+		assert.Equal(t, expectedResults, res)
+		assert.Equal(t, index.entryPointID, restored.entryPointID)
+		assert.Equal(t, index.currentMaximumLayer, restored.currentMaximumLayer)
+	}
+
+	t.Run("logs covered by the snapshot are not replayed", func(t *testing.T) {
+		// if the first log were replayed, the index would not be restorable
+		require.Nil(t, ioutil.WriteFile(firstLog, []byte{0xff, 0xff}, 0o666))
+		restore(t)
+	})
+
+	t.Run("full replay if the snapshot is corrupt", func(t *testing.T) {
+		require.Nil(t, ioutil.WriteFile(firstLog, originalFirstLog, 0o666))
+
+		snapshot := snapshotFileName(dirName, indexID, 1)
+		content, err := ioutil.ReadFile(snapshot)
+		require.Nil(t, err)
+		content[len(content)/2] ^= 0xff
+		require.Nil(t, ioutil.WriteFile(snapshot, content, 0o666))
+
+		restore(t)
+	})
+
+	t.Run("dropping the commit log removes the snapshots", func(t *testing.T) {
+		require.Nil(t, cl.Drop())
+		_, err := os.Stat(snapshotDirectory(dirName, indexID))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
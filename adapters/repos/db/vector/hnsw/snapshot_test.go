@@ -0,0 +1,324 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/commitlog"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSnapshotTestLogs writes three commit logs with the timestamps 1000,
+// 2000 and 3000. The last one is considered to be the active log.
+func writeSnapshotTestLogs(t *testing.T, rootPath, id string) {
+	dir := commitLogDirectory(rootPath, id)
+	require.Nil(t, os.MkdirAll(dir, os.ModePerm))
+
+	r := rand.New(rand.NewSource(3))
+	data := randomCompressionTestVectors(r, 50, 8)
+	pq, err := compressionhelpers.NewProductQuantizer(2, 4,
+		distancer.NewL2SquaredProvider(), 8)
+	require.Nil(t, err)
+	require.Nil(t, pq.Fit(data))
+
+	l := commitlog.NewLogger(filepath.Join(dir, "1000"))
+	require.Nil(t, l.SetConstructionParams(8, 64))
+	require.Nil(t, l.AddNode(0, 0))
+	require.Nil(t, l.AddNode(1, 2))
+	require.Nil(t, l.SetEntryPointWithMaxLayer(1, 2))
+	require.Nil(t, l.AddLinksAtLevel(0, 0, []uint64{1}))
+	require.Nil(t, l.AddLinksAtLevel(1, 0, []uint64{0}))
+	require.Nil(t, l.AddLinkAtLevel(1, 2, 0))
+	require.Nil(t, l.Close())
+
+	l = commitlog.NewLogger(filepath.Join(dir, "2000"))
+	require.Nil(t, l.AddNode(12000, 1))
+	require.Nil(t, l.ReplaceLinksAtLevel(12000, 1, []uint64{1}))
+	require.Nil(t, l.AddLinkAtLevel(1, 0, 12000))
+	require.Nil(t, l.AddTombstone(0))
+	require.Nil(t, l.AddPQ(pq.ExposeFields()))
+	require.Nil(t, l.Close())
+
+	l = commitlog.NewLogger(filepath.Join(dir, "3000"))
+	require.Nil(t, l.AddNode(3, 0))
+	require.Nil(t, l.AddLinkAtLevel(3, 0, 1))
+	require.Nil(t, l.Close())
+}
+
+func deserializeTestLogs(t *testing.T, fileNames ...string) *DeserializationResult {
+	logger, _ := test.NewNullLogger()
+	var state *DeserializationResult
+	for _, fileName := range fileNames {
+		fd, err := os.Open(fileName)
+		require.Nil(t, err)
+
+		state, _, err = NewDeserializer2(logger).Do(bufio.NewReader(fd), state, false)
+		require.Nil(t, err)
+		fd.Close()
+	}
+
+	return state
+}
+
+func assertSameGraph(t *testing.T, expected, actual *DeserializationResult) {
+	assert.Equal(t, expected.Entrypoint, actual.Entrypoint)
+	assert.Equal(t, expected.Level, actual.Level)
+	assert.Equal(t, expected.Tombstones, actual.Tombstones)
+	assert.Equal(t, expected.Compressed, actual.Compressed)
+	assert.Equal(t, expected.PQData, actual.PQData)
+	assert.Equal(t, expected.ScalarQuantized, actual.ScalarQuantized)
+	assert.Equal(t, expected.SQData, actual.SQData)
+	assert.Equal(t, expected.ConstructionParamsSet, actual.ConstructionParamsSet)
+	assert.Equal(t, expected.MaxConnections, actual.MaxConnections)
+	assert.Equal(t, expected.EFConstruction, actual.EFConstruction)
+	require.Equal(t, len(expected.Nodes), len(actual.Nodes))
+	for i := range expected.Nodes {
+		if expected.Nodes[i] == nil {
+			assert.Nil(t, actual.Nodes[i], "node %d", i)
+			continue
+		}
+
+		require.NotNil(t, actual.Nodes[i], "node %d", i)
+		assert.Equal(t, expected.Nodes[i].id, actual.Nodes[i].id)
+		assert.Equal(t, expected.Nodes[i].level, actual.Nodes[i].level)
+		assert.Equal(t, expected.Nodes[i].connections, actual.Nodes[i].connections)
+	}
+}
+
+func TestSnapshot_CreateAndRead(t *testing.T) {
+	rootPath := t.TempDir()
+	id := "snapshot-test"
+	logger, _ := test.NewNullLogger()
+	writeSnapshotTestLogs(t, rootPath, id)
+	logDir := commitLogDirectory(rootPath, id)
+
+	t.Run("nothing happens below the threshold", func(t *testing.T) {
+		ok, err := NewCommitLogSnapshotter(rootPath, id, 1e9, logger).Do()
+		require.Nil(t, err)
+		assert.False(t, ok)
+
+		timestamps, err := getSnapshotTimestamps(rootPath, id)
+		require.Nil(t, err)
+		assert.Len(t, timestamps, 0)
+	})
+
+	t.Run("all but the active log are snapshotted", func(t *testing.T) {
+		ok, err := NewCommitLogSnapshotter(rootPath, id, 0, logger).Do()
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		timestamps, err := getSnapshotTimestamps(rootPath, id)
+		require.Nil(t, err)
+		assert.Equal(t, []int64{2000}, timestamps)
+
+		state, ts, err := loadNewestSnapshot(rootPath, id, logger)
+		require.Nil(t, err)
+		require.NotNil(t, state)
+		assert.Equal(t, int64(2000), ts)
+
+		expected := deserializeTestLogs(t, filepath.Join(logDir, "1000"),
+			filepath.Join(logDir, "2000"))
+		assertSameGraph(t, expected, state)
+		assert.True(t, state.Compressed)
+		assert.True(t, state.ConstructionParamsSet)
+	})
+
+	t.Run("no new snapshot without new logs", func(t *testing.T) {
+		ok, err := NewCommitLogSnapshotter(rootPath, id, 0, logger).Do()
+		require.Nil(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("a new snapshot builds on the previous one", func(t *testing.T) {
+		l := commitlog.NewLogger(filepath.Join(logDir, "4000"))
+		require.Nil(t, l.AddTombstone(3))
+		require.Nil(t, l.Close())
+
+		// the logs contained in the first snapshot are deleted by Do
+		expected := deserializeTestLogs(t, filepath.Join(logDir, "1000"),
+			filepath.Join(logDir, "2000"), filepath.Join(logDir, "3000"))
+
+		ok, err := NewCommitLogSnapshotter(rootPath, id, 0, logger).Do()
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		timestamps, err := getSnapshotTimestamps(rootPath, id)
+		require.Nil(t, err)
+		assert.Equal(t, []int64{2000, 3000}, timestamps)
+
+		state, ts, err := loadNewestSnapshot(rootPath, id, logger)
+		require.Nil(t, err)
+		assert.Equal(t, int64(3000), ts)
+		assertSameGraph(t, expected, state)
+	})
+
+	t.Run("logs contained in the older snapshot are deleted", func(t *testing.T) {
+		fileNames, err := getCommitFileNames(rootPath, id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(logDir, "3000"), filepath.Join(logDir, "4000"),
+		}, fileNames)
+	})
+
+	t.Run("only the newest snapshots are kept", func(t *testing.T) {
+		l := commitlog.NewLogger(filepath.Join(logDir, "5000"))
+		require.Nil(t, l.AddNode(4, 0))
+		require.Nil(t, l.Close())
+
+		ok, err := NewCommitLogSnapshotter(rootPath, id, 0, logger).Do()
+		require.Nil(t, err)
+		assert.True(t, ok)
+
+		timestamps, err := getSnapshotTimestamps(rootPath, id)
+		require.Nil(t, err)
+		assert.Equal(t, []int64{3000, 4000}, timestamps)
+
+		fileNames, err := getCommitFileNames(rootPath, id)
+		require.Nil(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(logDir, "4000"), filepath.Join(logDir, "5000"),
+		}, fileNames)
+	})
+}
+
+func TestSnapshot_ScalarQuantizer(t *testing.T) {
+	rootPath := t.TempDir()
+	id := "snapshot-test"
+	logger, _ := test.NewNullLogger()
+	logDir := commitLogDirectory(rootPath, id)
+	require.Nil(t, os.MkdirAll(logDir, 0o755))
+
+	r := rand.New(rand.NewSource(7))
+	data := make([][]float32, 20)
+	for i := range data {
+		data[i] = make([]float32, 8)
+		for j := range data[i] {
+			data[i][j] = r.Float32()
+		}
+	}
+
+	sq, err := compressionhelpers.NewScalarQuantizer(
+		compressionhelpers.ScalarQuantizationInt8,
+		distancer.NewL2SquaredProvider(), 8)
+	require.Nil(t, err)
+	require.Nil(t, sq.Fit(data))
+
+	l := commitlog.NewLogger(filepath.Join(logDir, "1000"))
+	require.Nil(t, l.AddNode(0, 0))
+	require.Nil(t, l.SetEntryPointWithMaxLayer(0, 0))
+	require.Nil(t, l.AddSQ(sq.ExposeFields()))
+	require.Nil(t, l.Close())
+
+	l = commitlog.NewLogger(filepath.Join(logDir, "2000"))
+	require.Nil(t, l.AddNode(1, 0))
+	require.Nil(t, l.Close())
+
+	ok, err := NewCommitLogSnapshotter(rootPath, id, 0, logger).Do()
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	state, _, err := loadNewestSnapshot(rootPath, id, logger)
+	require.Nil(t, err)
+	require.NotNil(t, state)
+	assert.True(t, state.ScalarQuantized)
+	assert.False(t, state.Compressed)
+	assert.Equal(t, sq.ExposeFields(), state.SQData)
+}
+
+func TestSnapshot_Corrupt(t *testing.T) {
+	rootPath := t.TempDir()
+	id := "snapshot-test"
+	logger, _ := test.NewNullLogger()
+	writeSnapshotTestLogs(t, rootPath, id)
+
+	ok, err := NewCommitLogSnapshotter(rootPath, id, 0, logger).Do()
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	fileName := snapshotFileName(rootPath, id, 2000)
+	original, err := ioutil.ReadFile(fileName)
+	require.Nil(t, err)
+
+	t.Run("a flipped bit fails the checksum", func(t *testing.T) {
+		corrupt := make([]byte, len(original))
+		copy(corrupt, original)
+		// flip a bit in the last link, so that the structure is still readable
+		corrupt[len(corrupt)-5] ^= 0x01
+		require.Nil(t, ioutil.WriteFile(fileName, corrupt, 0o666))
+
+		_, err := readSnapshot(fileName, logger)
+		assert.ErrorIs(t, err, errSnapshotChecksum)
+
+		state, _, err := loadNewestSnapshot(rootPath, id, logger)
+		require.Nil(t, err)
+		assert.Nil(t, state, "must fall back to a full replay")
+	})
+
+	t.Run("every truncation is detected", func(t *testing.T) {
+		for length := 0; length < len(original); length++ {
+			require.Nil(t, ioutil.WriteFile(fileName, original[:length], 0o666))
+			_, err := readSnapshot(fileName, logger)
+			assert.NotNil(t, err, fmt.Sprintf("truncated to %d bytes", length))
+		}
+	})
+
+	t.Run("a corrupt newer snapshot falls back to an older one", func(t *testing.T) {
+		require.Nil(t, ioutil.WriteFile(fileName, original, 0o666))
+		require.Nil(t, ioutil.WriteFile(snapshotFileName(rootPath, id, 2500),
+			original[:len(original)/2], 0o666))
+
+		state, ts, err := loadNewestSnapshot(rootPath, id, logger)
+		require.Nil(t, err)
+		require.NotNil(t, state)
+		assert.Equal(t, int64(2000), ts)
+	})
+
+	t.Run("incomplete tmp files are removed", func(t *testing.T) {
+		tmpName := snapshotFileName(rootPath, id, 9000) + ".tmp"
+		require.Nil(t, ioutil.WriteFile(tmpName, []byte("incomplete"), 0o666))
+
+		timestamps, err := getSnapshotTimestamps(rootPath, id)
+		require.Nil(t, err)
+		assert.Equal(t, []int64{2000, 2500}, timestamps)
+
+		_, err = os.Stat(tmpName)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestSnapshot_CombinerRespectsSnapshots(t *testing.T) {
+	straddles, err := straddlesSnapshot([]int64{2000}, "/a/1000.condensed",
+		"/a/2000.condensed")
+	require.Nil(t, err)
+	assert.False(t, straddles)
+
+	straddles, err = straddlesSnapshot([]int64{2000}, "/a/2000.condensed",
+		"/a/3000.condensed")
+	require.Nil(t, err)
+	assert.True(t, straddles)
+
+	straddles, err = straddlesSnapshot([]int64{1000, 5000}, "/a/2000.condensed",
+		"/a/3000.condensed")
+	require.Nil(t, err)
+	assert.False(t, straddles)
+}
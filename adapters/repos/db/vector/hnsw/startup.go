@@ -15,10 +15,12 @@ import (
 	"bufio"
 	"context"
 	"io"
+	"math"
 	"os"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/visited"
 )
 
@@ -36,6 +38,14 @@ func (h *hnsw) init(cfg Config) error {
 	}
 
 	h.commitLog = cl
+
+	// recorded on every startup, so that graphs persisted before the
+	// parameters were recorded have them from now on
+	if err := h.commitLog.SetConstructionParams(h.maximumConnections,
+		h.efConstruction); err != nil {
+		return errors.Wrap(err, "record construction parameters")
+	}
+
 	h.registerMaintainence()
 
 	return nil
@@ -59,8 +69,24 @@ func (h *hnsw) restoreFromDisk() error {
 		return errors.Wrap(err, "corrupted commit log fixer")
 	}
 
-	var state *DeserializationResult
+	state, snapshotTs, err := loadNewestSnapshot(h.rootPath, h.id, h.logger)
+	if err != nil {
+		return errors.Wrap(err, "load snapshot")
+	}
+
 	for _, fileName := range fileNames {
+		if state != nil {
+			covered, err := snapshotCovers(snapshotTs, fileName)
+			if err != nil {
+				return err
+			}
+
+			if covered {
+				// already contained in the snapshot
+				continue
+			}
+		}
+
 		fd, err := os.Open(fileName)
 		if err != nil {
 			return errors.Wrapf(err, "open commit log %q for reading", fileName)
@@ -104,6 +130,39 @@ func (h *hnsw) restoreFromDisk() error {
 	// make sure the cache fits the current size
 	h.cache.grow(uint64(len(h.nodes)))
 
+	if state.ConstructionParamsSet {
+		// a rebuild with the configured parameters may not have completed,
+		// until it does, the graph is extended with the parameters it was
+		// built with
+		h.setConstructionParams(state.MaxConnections, state.EFConstruction)
+	}
+
+	if state.Compressed {
+		pq, err := compressionhelpers.RestoreProductQuantizer(state.PQData,
+			h.distancerProvider)
+		if err != nil {
+			return errors.Wrap(err, "restore product quantizer")
+		}
+
+		h.switchToCompressed(pq, nil)
+	}
+
+	if state.ScalarQuantized {
+		sq, err := compressionhelpers.RestoreScalarQuantizer(state.SQData,
+			h.distancerProvider)
+		if err != nil {
+			return errors.Wrap(err, "restore scalar quantizer")
+		}
+
+		codes, err := openCodeStore(codeStorePath(h.rootPath, h.id),
+			sq.CodeLength(), false)
+		if err != nil {
+			return err
+		}
+
+		h.switchToCompressed(sq, codes)
+	}
+
 	// make sure the visited list pool fits the current size
 	h.pools.visitedLists.Destroy()
 	h.pools.visitedLists = nil
@@ -147,10 +206,47 @@ func (h *hnsw) registerTombstoneCleanup() {
 // vector cache, however, depend on the shard being ready as they will call
 // getVectorForID.
 func (h *hnsw) PostStartup() {
+	if h.initialPQConfig.Enabled && !h.isCompressed() {
+		// compression was enabled, but has not completed before the last
+		// shutdown or was enabled on a new class
+		h.startCompression(h.initialPQConfig)
+	}
+
+	if h.initialSQConfig.Enabled && !h.isCompressed() {
+		// scalar quantization was enabled, but the quantizer was not fitted
+		// before the last shutdown or it was enabled on a new class
+		h.startScalarQuantization(h.initialSQConfig)
+	}
+
+	if h.constructionParamsChanged(h.initialConstruction) {
+		// the config was updated, but the rebuild did not complete before the
+		// last shutdown
+		h.logger.WithField("action", "hnsw_rebuild").
+			WithField("index_id", h.id).
+			Info("graph was built with different construction parameters, " +
+				"restarting rebuild")
+		h.startRebuild(h.initialConstruction)
+	}
+
 	h.prefillCache()
 }
 
+// setConstructionParams must only be called before the index is in use
+func (h *hnsw) setConstructionParams(maxConnections, efConstruction int) {
+	h.maximumConnections = maxConnections
+	h.maximumConnectionsLayerZero = 2 * maxConnections
+	h.levelNormalizer = 1 / math.Log(float64(maxConnections))
+	h.efConstruction = efConstruction
+	h.pools = newPools(h.maximumConnectionsLayerZero)
+}
+
 func (h *hnsw) prefillCache() {
+	if h.isCompressed() || h.initialSQConfig.Enabled {
+		// codes are persisted or cheap to create on the fly and prefilling the
+		// cache with full vectors would defeat the purpose of compressing them
+		return
+	}
+
 	limit := int(h.cache.copyMaxSize())
 
 	go func() {
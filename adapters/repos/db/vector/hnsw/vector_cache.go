@@ -15,35 +15,53 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
-	"time"
 	"unsafe"
 
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
 	"github.com/sirupsen/logrus"
 )
 
+// shardedLockCache holds the full vectors of an index in memory. Its size is
+// limited both by the number of entries (vectorCacheMaxObjects) and by the
+// node-wide CacheBudget. When either limit is exceeded, entries are evicted
+// using the CLOCK algorithm: every read marks an entry as referenced and the
+// clock hand gives referenced entries a second chance before evicting them.
 type shardedLockCache struct {
 	shardedLocks    []sync.RWMutex
 	cache           [][]float32
+	referenced      []uint32
 	vectorForID     VectorForID
 	normalizeOnRead bool
 	maxSize         int64
 	count           int64
-	cancel          chan bool
+	sizeBytes       int64
+	hits            int64
+	misses          int64
+	evictions       int64
+	budget          *CacheBudget
+	sweepLock       sync.Mutex
+	hand            uint64
+	evicting        int32
 	logger          logrus.FieldLogger
 }
 
 var shardFactor = uint64(512)
 
+// sliceOverhead is the size of the slice header which is kept per cached
+// entry in addition to the vector itself
+const sliceOverhead = int64(unsafe.Sizeof([]float32(nil)))
+
 func newShardedLockCache(vecForID VectorForID, maxSize int,
-	logger logrus.FieldLogger, normalizeOnRead bool) *shardedLockCache {
+	budget *CacheBudget, logger logrus.FieldLogger,
+	normalizeOnRead bool) *shardedLockCache {
 	vc := &shardedLockCache{
 		vectorForID:     vecForID,
 		cache:           make([][]float32, initialSize),
+		referenced:      make([]uint32, initialSize),
 		normalizeOnRead: normalizeOnRead,
 		count:           0,
 		maxSize:         int64(maxSize),
-		cancel:          make(chan bool),
+		budget:          budget,
 		logger:          logger,
 		shardedLocks:    make([]sync.RWMutex, shardFactor),
 	}
@@ -51,19 +69,32 @@ func newShardedLockCache(vecForID VectorForID, maxSize int,
 	for i := uint64(0); i < shardFactor; i++ {
 		vc.shardedLocks[i] = sync.RWMutex{}
 	}
-	vc.watchForDeletion()
+	budget.register(vc)
 	return vc
 }
 
+func vectorSize(vec []float32) int64 {
+	if vec == nil {
+		return 0
+	}
+
+	return int64(len(vec))*4 + sliceOverhead
+}
+
 func (n *shardedLockCache) get(ctx context.Context, id uint64) ([]float32, error) {
 	n.shardedLocks[id%shardFactor].RLock()
 	vec := n.cache[id]
+	if vec != nil && atomic.LoadUint32(&n.referenced[id]) == 0 {
+		atomic.StoreUint32(&n.referenced[id], 1)
+	}
 	n.shardedLocks[id%shardFactor].RUnlock()
 
 	if vec != nil {
+		atomic.AddInt64(&n.hits, 1)
 		return vec, nil
 	}
 
+	atomic.AddInt64(&n.misses, 1)
 	vec, err := n.vectorForID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -73,11 +104,7 @@ func (n *shardedLockCache) get(ctx context.Context, id uint64) ([]float32, error
 		vec = distancer.Normalize(vec)
 	}
 
-	atomic.AddInt64(&n.count, 1)
-	n.shardedLocks[id%shardFactor].Lock()
-	n.cache[id] = vec
-	n.shardedLocks[id%shardFactor].Unlock()
-
+	n.store(id, vec)
 	return vec, nil
 }
 
@@ -91,56 +118,156 @@ func (n *shardedLockCache) prefetch(id uint64) {
 }
 
 func (n *shardedLockCache) preload(id uint64, vec []float32) {
-	n.shardedLocks[id%shardFactor].RLock()
-	defer n.shardedLocks[id%shardFactor].RUnlock()
+	n.store(id, vec)
+}
 
-	atomic.AddInt64(&n.count, 1)
+// store puts the vector into the cache, updates the accounting and evicts
+// other entries if a limit was exceeded as a result
+func (n *shardedLockCache) store(id uint64, vec []float32) {
+	n.shardedLocks[id%shardFactor].Lock()
+	prev := n.cache[id]
 	n.cache[id] = vec
+	atomic.StoreUint32(&n.referenced[id], 1)
+	n.shardedLocks[id%shardFactor].Unlock()
+
+	if prev == nil {
+		atomic.AddInt64(&n.count, 1)
+	}
+	delta := vectorSize(vec) - vectorSize(prev)
+	atomic.AddInt64(&n.sizeBytes, delta)
+	n.budget.add(delta)
+
+	n.enforceMaxSize()
 }
 
 func (n *shardedLockCache) grow(node uint64) {
 	n.obtainAllLocks()
 	defer n.releaseAllLocks()
 
+	if node < uint64(len(n.cache)) {
+		return
+	}
+
 	newSize := node + defaultIndexGrowthDelta
 	newCache := make([][]float32, newSize)
 	copy(newCache, n.cache)
 	n.cache = newCache
+
+	newReferenced := make([]uint32, newSize)
+	copy(newReferenced, n.referenced)
+	n.referenced = newReferenced
 }
 
 func (n *shardedLockCache) len() int32 {
 	return int32(len(n.cache))
 }
 
+// drop frees all entries and removes the cache from the budget
 func (n *shardedLockCache) drop() {
-	n.cancel <- true
-}
-
-func (c *shardedLockCache) watchForDeletion() {
-	go func() {
-		t := time.Tick(3 * time.Second)
-		for {
-			select {
-			case <-c.cancel:
-				return
-			case <-t:
-				c.replaceIfFull()
-			}
-		}
-	}()
+	n.purge()
+	n.budget.deregister(n)
 }
 
-func (c *shardedLockCache) replaceIfFull() {
-	if atomic.LoadInt64(&c.count) >= atomic.LoadInt64(&c.maxSize) {
-		c.obtainAllLocks()
-		c.logger.WithField("action", "hnsw_delete_vector_cache").
-			Debug("deleting full vector cache")
-		for i := range c.cache {
-			c.cache[i] = nil
+// enforceMaxSize evicts entries once the cache holds more than the
+// configured maximum number of objects. A maximum of 0 disables the limit.
+// If another goroutine is already evicting, it returns immediately.
+func (c *shardedLockCache) enforceMaxSize() {
+	maxSize := atomic.LoadInt64(&c.maxSize)
+	if maxSize <= 0 || atomic.LoadInt64(&c.count) <= maxSize {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&c.evicting, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&c.evicting, 0)
+
+	target := lowWatermark(maxSize)
+	c.sweep(func(freed int64) bool {
+		return atomic.LoadInt64(&c.count) <= target
+	})
+}
+
+func (c *shardedLockCache) evict(bytes int64) int64 {
+	return c.sweep(func(freed int64) bool {
+		return freed >= bytes
+	})
+}
+
+// sweep advances the clock hand and evicts every entry which has not been
+// referenced since the hand last passed it, until done reports that enough
+// has been freed. Referenced entries only lose their mark. The hand stops
+// after two full rotations, at which point every entry has been considered
+// for eviction at least once.
+func (c *shardedLockCache) sweep(done func(freed int64) bool) int64 {
+	c.sweepLock.Lock()
+	defer c.sweepLock.Unlock()
+
+	// the cache never shrinks, so the length can be read once. grow() holds
+	// all locks, so holding any single one is enough to read it safely.
+	c.shardedLocks[0].RLock()
+	length := uint64(len(c.cache))
+	c.shardedLocks[0].RUnlock()
+
+	freed := int64(0)
+	for steps := uint64(0); steps < 2*length && !done(freed); steps++ {
+		if c.hand >= length {
+			c.hand = 0
+		}
+		id := c.hand
+		c.hand++
+
+		c.shardedLocks[id%shardFactor].Lock()
+		vec := c.cache[id]
+		if vec == nil {
+			c.shardedLocks[id%shardFactor].Unlock()
+			continue
+		}
+
+		if atomic.LoadUint32(&c.referenced[id]) == 1 {
+			atomic.StoreUint32(&c.referenced[id], 0)
+			c.shardedLocks[id%shardFactor].Unlock()
+			continue
 		}
-		c.releaseAllLocks()
+
+		c.cache[id] = nil
+		c.shardedLocks[id%shardFactor].Unlock()
+
+		size := vectorSize(vec)
+		freed += size
+		atomic.AddInt64(&c.count, -1)
+		atomic.AddInt64(&c.sizeBytes, -size)
+		atomic.AddInt64(&c.evictions, 1)
+		c.budget.add(-size)
+	}
+
+	return freed
+}
+
+// purge removes all vectors from the cache, e.g. after the index has switched
+// to compressed vectors and the full vectors are no longer required
+func (c *shardedLockCache) purge() {
+	c.obtainAllLocks()
+	freed := int64(0)
+	for i := range c.cache {
+		freed += vectorSize(c.cache[i])
+		c.cache[i] = nil
 	}
+	c.releaseAllLocks()
+
 	atomic.StoreInt64(&c.count, 0)
+	atomic.AddInt64(&c.sizeBytes, -freed)
+	c.budget.add(-freed)
+}
+
+func (c *shardedLockCache) stats() CacheStats {
+	return CacheStats{
+		Entries:   atomic.LoadInt64(&c.count),
+		SizeBytes: atomic.LoadInt64(&c.sizeBytes),
+		Hits:      atomic.LoadInt64(&c.hits),
+		Misses:    atomic.LoadInt64(&c.misses),
+		Evictions: atomic.LoadInt64(&c.evictions),
+	}
 }
 
 func (c *shardedLockCache) obtainAllLocks() {
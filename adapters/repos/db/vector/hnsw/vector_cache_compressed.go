@@ -0,0 +1,328 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/compressionhelpers"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/distancer"
+	"github.com/sirupsen/logrus"
+)
+
+// compressedShardedLockCache mirrors the shardedLockCache, but instead of the
+// full vectors it holds their quantized codes. On a cache miss the code is
+// read from the codes store if there is one, otherwise the full vector is read
+// from the underlying store and encoded. It takes part in the same
+// CacheBudget and uses the same CLOCK eviction.
+type compressedShardedLockCache struct {
+	shardedLocks    []sync.RWMutex
+	cache           [][]byte
+	referenced      []uint32
+	vectorForID     VectorForID
+	quantizer       compressionhelpers.Quantizer
+	codes           *codeStore
+	normalizeOnRead bool
+	maxSize         int64
+	count           int64
+	sizeBytes       int64
+	hits            int64
+	misses          int64
+	evictions       int64
+	budget          *CacheBudget
+	sweepLock       sync.Mutex
+	hand            uint64
+	evicting        int32
+	logger          logrus.FieldLogger
+}
+
+// newCompressedShardedLockCache creates the cache, codes is nil if the codes
+// are not persisted
+func newCompressedShardedLockCache(vecForID VectorForID,
+	quantizer compressionhelpers.Quantizer, codes *codeStore, maxSize int,
+	budget *CacheBudget, logger logrus.FieldLogger,
+	normalizeOnRead bool) *compressedShardedLockCache {
+	vc := &compressedShardedLockCache{
+		vectorForID:     vecForID,
+		quantizer:       quantizer,
+		codes:           codes,
+		cache:           make([][]byte, initialSize),
+		referenced:      make([]uint32, initialSize),
+		normalizeOnRead: normalizeOnRead,
+		count:           0,
+		maxSize:         int64(maxSize),
+		budget:          budget,
+		logger:          logger,
+		shardedLocks:    make([]sync.RWMutex, shardFactor),
+	}
+
+	for i := uint64(0); i < shardFactor; i++ {
+		vc.shardedLocks[i] = sync.RWMutex{}
+	}
+	budget.register(vc)
+	return vc
+}
+
+func codeSize(code []byte) int64 {
+	if code == nil {
+		return 0
+	}
+
+	return int64(len(code)) + sliceOverhead
+}
+
+func (n *compressedShardedLockCache) get(ctx context.Context, id uint64) ([]byte, error) {
+	n.shardedLocks[id%shardFactor].RLock()
+	code := n.cache[id]
+	if code != nil && atomic.LoadUint32(&n.referenced[id]) == 0 {
+		atomic.StoreUint32(&n.referenced[id], 1)
+	}
+	n.shardedLocks[id%shardFactor].RUnlock()
+
+	if code != nil {
+		atomic.AddInt64(&n.hits, 1)
+		return code, nil
+	}
+
+	atomic.AddInt64(&n.misses, 1)
+	if n.codes != nil {
+		code, ok, err := n.codes.get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			n.store(id, code)
+			return code, nil
+		}
+	}
+
+	code, err := n.encode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	n.store(id, code)
+	return code, nil
+}
+
+// encode reads the full vector of id and persists its code if the codes are
+// persisted
+func (n *compressedShardedLockCache) encode(ctx context.Context,
+	id uint64) ([]byte, error) {
+	vec, err := n.vectorForID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.normalizeOnRead {
+		vec = distancer.Normalize(vec)
+	}
+
+	code := n.quantizer.Encode(vec)
+	if n.codes != nil {
+		if err := n.codes.put(id, code); err != nil {
+			return nil, err
+		}
+	}
+
+	return code, nil
+}
+
+func (n *compressedShardedLockCache) prefetch(id uint64) {
+	prefetchFunc(uintptr(unsafe.Pointer(&n.cache[id])))
+}
+
+// preload caches the code of a newly inserted vector and persists it if the
+// codes are persisted
+func (n *compressedShardedLockCache) preload(id uint64, code []byte) error {
+	if n.codes != nil {
+		if err := n.codes.put(id, code); err != nil {
+			return err
+		}
+	}
+
+	n.store(id, code)
+	return nil
+}
+
+func (n *compressedShardedLockCache) store(id uint64, code []byte) {
+	n.shardedLocks[id%shardFactor].Lock()
+	prev := n.cache[id]
+	n.cache[id] = code
+	atomic.StoreUint32(&n.referenced[id], 1)
+	n.shardedLocks[id%shardFactor].Unlock()
+
+	if prev == nil {
+		atomic.AddInt64(&n.count, 1)
+	}
+	delta := codeSize(code) - codeSize(prev)
+	atomic.AddInt64(&n.sizeBytes, delta)
+	n.budget.add(delta)
+
+	n.enforceMaxSize()
+}
+
+func (n *compressedShardedLockCache) grow(node uint64) {
+	n.obtainAllLocks()
+	defer n.releaseAllLocks()
+
+	if node < uint64(len(n.cache)) {
+		return
+	}
+
+	newSize := node + defaultIndexGrowthDelta
+	newCache := make([][]byte, newSize)
+	copy(newCache, n.cache)
+	n.cache = newCache
+
+	newReferenced := make([]uint32, newSize)
+	copy(newReferenced, n.referenced)
+	n.referenced = newReferenced
+}
+
+func (n *compressedShardedLockCache) len() int32 {
+	return int32(len(n.cache))
+}
+
+func (n *compressedShardedLockCache) drop() error {
+	n.purge()
+	n.budget.deregister(n)
+
+	if n.codes != nil {
+		return n.codes.drop()
+	}
+
+	return nil
+}
+
+func (c *compressedShardedLockCache) enforceMaxSize() {
+	maxSize := atomic.LoadInt64(&c.maxSize)
+	if maxSize <= 0 || atomic.LoadInt64(&c.count) <= maxSize {
+		return
+	}
+
+	if !atomic.CompareAndSwapInt32(&c.evicting, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&c.evicting, 0)
+
+	target := lowWatermark(maxSize)
+	c.sweep(func(freed int64) bool {
+		return atomic.LoadInt64(&c.count) <= target
+	})
+}
+
+func (c *compressedShardedLockCache) evict(bytes int64) int64 {
+	return c.sweep(func(freed int64) bool {
+		return freed >= bytes
+	})
+}
+
+// sweep is the CLOCK eviction, see shardedLockCache.sweep
+func (c *compressedShardedLockCache) sweep(done func(freed int64) bool) int64 {
+	c.sweepLock.Lock()
+	defer c.sweepLock.Unlock()
+
+	c.shardedLocks[0].RLock()
+	length := uint64(len(c.cache))
+	c.shardedLocks[0].RUnlock()
+
+	freed := int64(0)
+	for steps := uint64(0); steps < 2*length && !done(freed); steps++ {
+		if c.hand >= length {
+			c.hand = 0
+		}
+		id := c.hand
+		c.hand++
+
+		c.shardedLocks[id%shardFactor].Lock()
+		code := c.cache[id]
+		if code == nil {
+			c.shardedLocks[id%shardFactor].Unlock()
+			continue
+		}
+
+		if atomic.LoadUint32(&c.referenced[id]) == 1 {
+			atomic.StoreUint32(&c.referenced[id], 0)
+			c.shardedLocks[id%shardFactor].Unlock()
+			continue
+		}
+
+		c.cache[id] = nil
+		c.shardedLocks[id%shardFactor].Unlock()
+
+		size := codeSize(code)
+		freed += size
+		atomic.AddInt64(&c.count, -1)
+		atomic.AddInt64(&c.sizeBytes, -size)
+		atomic.AddInt64(&c.evictions, 1)
+		c.budget.add(-size)
+	}
+
+	return freed
+}
+
+func (c *compressedShardedLockCache) purge() {
+	c.obtainAllLocks()
+	freed := int64(0)
+	for i := range c.cache {
+		freed += codeSize(c.cache[i])
+		c.cache[i] = nil
+	}
+	c.releaseAllLocks()
+
+	atomic.StoreInt64(&c.count, 0)
+	atomic.AddInt64(&c.sizeBytes, -freed)
+	c.budget.add(-freed)
+}
+
+func (c *compressedShardedLockCache) stats() CacheStats {
+	return CacheStats{
+		Entries:   atomic.LoadInt64(&c.count),
+		SizeBytes: atomic.LoadInt64(&c.sizeBytes),
+		Hits:      atomic.LoadInt64(&c.hits),
+		Misses:    atomic.LoadInt64(&c.misses),
+		Evictions: atomic.LoadInt64(&c.evictions),
+	}
+}
+
+func (c *compressedShardedLockCache) obtainAllLocks() {
+	wg := &sync.WaitGroup{}
+	for i := uint64(0); i < shardFactor; i++ {
+		wg.Add(1)
+		go func(index uint64) {
+			defer wg.Done()
+			c.shardedLocks[index].Lock()
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func (c *compressedShardedLockCache) releaseAllLocks() {
+	for i := uint64(0); i < shardFactor; i++ {
+		c.shardedLocks[i].Unlock()
+	}
+}
+
+func (c *compressedShardedLockCache) updateMaxSize(size int64) {
+	atomic.StoreInt64(&c.maxSize, size)
+}
+
+func (c *compressedShardedLockCache) copyMaxSize() int64 {
+	sizeCopy := atomic.LoadInt64(&c.maxSize)
+	return sizeCopy
+}
@@ -33,6 +33,8 @@ type cache interface {
 	drop()
 	updateMaxSize(size int64)
 	copyMaxSize() int64
+	purge()
+	stats() CacheStats
 }
 
 func newVectorCachePrefiller(cache cache, index *hnsw,
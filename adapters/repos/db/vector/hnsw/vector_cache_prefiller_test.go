@@ -106,6 +106,14 @@ func (f *fakeCache) drop() {
 	panic("not implemented")
 }
 
+func (f *fakeCache) purge() {
+	panic("not implemented")
+}
+
+func (f *fakeCache) stats() CacheStats {
+	panic("not implemented")
+}
+
 func (f *fakeCache) copyMaxSize() int64 {
 	return 1e6
 }
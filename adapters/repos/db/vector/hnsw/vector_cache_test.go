@@ -0,0 +1,123 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package hnsw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorCacheClockEviction(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	vectorForID := func(ctx context.Context, id uint64) ([]float32, error) {
+		return []float32{float32(id), 1, 2, 3}, nil
+	}
+	cache := newShardedLockCache(vectorForID, 100,
+		NewCacheBudget(0, logger), logger, false)
+
+	isCached := func(id uint64) bool {
+		cache.shardedLocks[id%shardFactor].RLock()
+		defer cache.shardedLocks[id%shardFactor].RUnlock()
+		return cache.cache[id] != nil
+	}
+
+	t.Run("exceeding the max size evicts down to the low watermark", func(t *testing.T) {
+		for i := uint64(0); i <= 100; i++ {
+			_, err := cache.get(context.Background(), i)
+			require.Nil(t, err)
+		}
+
+		stats := cache.stats()
+		assert.Equal(t, int64(95), stats.Entries)
+		assert.Equal(t, int64(6), stats.Evictions)
+		assert.Equal(t, int64(101), stats.Misses)
+		assert.Equal(t, 95*vectorSize(make([]float32, 4)), stats.SizeBytes)
+
+		// the clock hand cleared all marks during the first rotation, so the
+		// oldest entries were evicted
+		for i := uint64(0); i < 6; i++ {
+			assert.False(t, isCached(i))
+		}
+	})
+
+	t.Run("referenced entries get a second chance", func(t *testing.T) {
+		for i := uint64(6); i <= 20; i++ {
+			_, err := cache.get(context.Background(), i)
+			require.Nil(t, err)
+		}
+		assert.Equal(t, int64(15), cache.stats().Hits)
+
+		for i := uint64(101); i <= 106; i++ {
+			_, err := cache.get(context.Background(), i)
+			require.Nil(t, err)
+		}
+
+		for i := uint64(6); i <= 20; i++ {
+			assert.True(t, isCached(i), "referenced entry %d was evicted", i)
+		}
+		for i := uint64(21); i <= 26; i++ {
+			assert.False(t, isCached(i), "unreferenced entry %d was not evicted", i)
+		}
+	})
+
+	t.Run("evicted vectors are read again on access", func(t *testing.T) {
+		misses := cache.stats().Misses
+		vec, err := cache.get(context.Background(), 0)
+		require.Nil(t, err)
+		assert.Equal(t, []float32{0, 1, 2, 3}, vec)
+		assert.Equal(t, misses+1, cache.stats().Misses)
+	})
+}
+
+func TestVectorCacheBudget(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	vectorForID := func(ctx context.Context, id uint64) ([]float32, error) {
+		return []float32{float32(id), 1, 2, 3}, nil
+	}
+
+	entrySize := vectorSize(make([]float32, 4))
+	budget := NewCacheBudget(100*entrySize, logger)
+	first := newShardedLockCache(vectorForID, 0, budget, logger, false)
+	second := newShardedLockCache(vectorForID, 0, budget, logger, false)
+
+	t.Run("the budget is shared by all caches", func(t *testing.T) {
+		for i := uint64(0); i < 100; i++ {
+			_, err := first.get(context.Background(), i)
+			require.Nil(t, err)
+		}
+		assert.Equal(t, 100*entrySize, budget.Stats().UsedBytes)
+
+		for i := uint64(0); i < 100; i++ {
+			_, err := second.get(context.Background(), i)
+			require.Nil(t, err)
+			assert.LessOrEqual(t, budget.Stats().UsedBytes, 100*entrySize)
+		}
+
+		assert.Greater(t, first.stats().Evictions, int64(0))
+		assert.Greater(t, second.stats().Entries, int64(0))
+		assert.Equal(t, first.stats().SizeBytes+second.stats().SizeBytes,
+			budget.Stats().UsedBytes)
+	})
+
+	t.Run("dropping a cache releases its memory", func(t *testing.T) {
+		first.drop()
+
+		stats := budget.Stats()
+		assert.Equal(t, 1, stats.Caches)
+		assert.Equal(t, second.stats().SizeBytes, stats.UsedBytes)
+		assert.Equal(t, int64(0), first.stats().Entries)
+	})
+}
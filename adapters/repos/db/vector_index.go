@@ -22,6 +22,7 @@ type VectorIndex interface {
 	Add(id uint64, vector []float32) error
 	Delete(id uint64) error
 	SearchByVector(vector []float32, k int, allow helpers.AllowList) ([]uint64, []float32, error)
+	SearchByVectorBatch(vectors [][]float32, k int, allow helpers.AllowList) ([][]uint64, [][]float32, error)
 	UpdateUserConfig(updated schema.VectorIndexConfig) error
 	Drop() error
 	Flush() error
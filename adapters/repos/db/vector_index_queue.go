@@ -0,0 +1,491 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/durability"
+	"github.com/sirupsen/logrus"
+)
+
+// vectorIndexQueueMaxPending limits how many operations can be waiting in
+// memory. Writers block once the limit is reached, so that an import can't
+// run arbitrarily far ahead of the vector index.
+const vectorIndexQueueMaxPending = 10000
+
+const (
+	vectorIndexQueueOpAdd    = byte(0)
+	vectorIndexQueueOpDelete = byte(1)
+)
+
+// nodeContainer is implemented by vector indexes which can tell if a doc id
+// is already part of the graph, such as hnsw. It is used to skip adds which
+// are replayed from the queue log, but were already applied before a restart.
+type nodeContainer interface {
+	ContainsNode(id uint64) bool
+}
+
+// vectorIndexQueue applies the operations of a vector index in the background,
+// so that writes can be acknowledged as soon as the object and the inverted
+// index are stored.
+//
+// Every operation is appended to a log on disk before it is handed to a
+// worker, so pending operations survive a restart. Operations are routed to
+// workers by doc id, which guarantees that all operations of the same doc id
+// are applied in the order they were queued. The log is truncated whenever
+// the queue runs empty and the vector index has been flushed. Whether a write
+// is fsynced before it is acknowledged depends on the sync policy, the same
+// one that applies to the WALs of the shard.
+type vectorIndexQueue struct {
+	sync.Mutex
+	cond *sync.Cond
+
+	index  VectorIndex
+	logger logrus.FieldLogger
+	path   string
+	file   *os.File
+	writer *bufio.Writer
+	syncer *durability.Syncer
+
+	workers []*vectorIndexQueueWorker
+	pending int64
+	closed  bool
+	wg      sync.WaitGroup
+
+	// appended counts the writes to the log, a truncation is only allowed if
+	// nothing was appended while the index was flushed
+	appended   uint64
+	truncating bool
+}
+
+type vectorIndexQueueWorker struct {
+	ops       []vectorIndexQueueOp
+	enqueued  uint64
+	processed uint64
+}
+
+type vectorIndexQueueOp struct {
+	delete bool
+	id     uint64
+	vector []float32
+
+	// replayed is set for operations read back from the log at startup, they
+	// might already be part of the index
+	replayed bool
+}
+
+func newVectorIndexQueue(path string, index VectorIndex,
+	syncPolicy durability.Policy, logger logrus.FieldLogger,
+) (*vectorIndexQueue, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o666)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open queue log %s", path)
+	}
+
+	q := &vectorIndexQueue{
+		index:   index,
+		logger:  logger,
+		path:    path,
+		file:    file,
+		workers: make([]*vectorIndexQueueWorker, runtime.GOMAXPROCS(0)),
+	}
+	q.cond = sync.NewCond(q)
+	for i := range q.workers {
+		q.workers[i] = &vectorIndexQueueWorker{}
+	}
+
+	if err := q.replay(); err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "replay queue log %s", path)
+	}
+
+	q.writer = bufio.NewWriter(file)
+	q.syncer = durability.NewSyncer(syncPolicy, file)
+
+	for _, w := range q.workers {
+		q.wg.Add(1)
+		go q.work(w)
+	}
+
+	return q, nil
+}
+
+// replay reads the operations which were queued, but possibly not applied
+// before the last shutdown. A partially written operation at the end of the
+// log is the result of a crash during a write which was never acknowledged,
+// so it is cut off.
+func (q *vectorIndexQueue) replay() error {
+	r := bufio.NewReader(q.file)
+	var valid int64
+
+	for {
+		op, n, err := readVectorIndexQueueOp(r)
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF {
+			q.logger.WithField("action", "vector_index_queue_replay").
+				WithField("path", q.path).
+				Warn("discarding partially written operation at end of queue log")
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		op.replayed = true
+		q.push(op)
+		valid += n
+	}
+
+	if err := q.file.Truncate(valid); err != nil {
+		return errors.Wrap(err, "truncate queue log")
+	}
+
+	if _, err := q.file.Seek(valid, io.SeekStart); err != nil {
+		return errors.Wrap(err, "seek end of queue log")
+	}
+
+	return nil
+}
+
+// Add queues the insertion of the vector, the index is updated asynchronously
+func (q *vectorIndexQueue) Add(id uint64, vector []float32) error {
+	return q.enqueue(vectorIndexQueueOp{id: id, vector: vector})
+}
+
+// Delete queues the deletion of the doc id, the index is updated
+// asynchronously
+func (q *vectorIndexQueue) Delete(id uint64) error {
+	return q.enqueue(vectorIndexQueueOp{id: id, delete: true})
+}
+
+// enqueue persists the operations and hands them to the workers. The lock is
+// held until all operations are assigned, so that concurrent writers can't
+// reorder the operations of a doc id. The log is fsynced after the lock is
+// released, so that concurrent writers can share an fsync.
+func (q *vectorIndexQueue) enqueue(ops ...vectorIndexQueueOp) error {
+	if err := q.write(ops); err != nil {
+		return err
+	}
+
+	if err := q.syncer.Commit(); err != nil {
+		return errors.Wrap(err, "sync queue log")
+	}
+
+	return nil
+}
+
+func (q *vectorIndexQueue) write(ops []vectorIndexQueueOp) error {
+	q.Lock()
+	defer q.Unlock()
+
+	for q.pending >= vectorIndexQueueMaxPending && !q.closed {
+		q.cond.Wait()
+	}
+
+	if q.closed {
+		return errors.Errorf("vector index queue is closed")
+	}
+
+	for _, op := range ops {
+		if err := writeVectorIndexQueueOp(q.writer, op); err != nil {
+			return errors.Wrap(err, "write to queue log")
+		}
+	}
+
+	if err := q.writer.Flush(); err != nil {
+		return errors.Wrap(err, "flush queue log")
+	}
+	q.appended++
+
+	for _, op := range ops {
+		q.push(op)
+	}
+	q.cond.Broadcast()
+
+	return nil
+}
+
+// push assigns the operation to its worker, must be called with the lock held
+func (q *vectorIndexQueue) push(op vectorIndexQueueOp) {
+	w := q.workers[op.id%uint64(len(q.workers))]
+	w.ops = append(w.ops, op)
+	w.enqueued++
+	q.pending++
+}
+
+func (q *vectorIndexQueue) work(w *vectorIndexQueueWorker) {
+	defer q.wg.Done()
+
+	for {
+		q.Lock()
+		for len(w.ops) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.closed {
+			q.Unlock()
+			return
+		}
+		batch := w.ops
+		w.ops = nil
+		q.Unlock()
+
+		for _, op := range batch {
+			q.apply(op)
+		}
+
+		q.Lock()
+		w.processed += uint64(len(batch))
+		q.pending -= int64(len(batch))
+		if q.pending == 0 && !q.truncating {
+			q.truncate()
+		}
+		q.cond.Broadcast()
+		q.Unlock()
+	}
+}
+
+func (q *vectorIndexQueue) apply(op vectorIndexQueueOp) {
+	var err error
+	if op.delete {
+		err = q.index.Delete(op.id)
+	} else {
+		if op.replayed {
+			if nc, ok := q.index.(nodeContainer); ok && nc.ContainsNode(op.id) {
+				return
+			}
+		}
+		err = q.index.Add(op.id, op.vector)
+	}
+
+	if err != nil {
+		q.logger.WithField("action", "vector_index_queue_apply").
+			WithField("path", q.path).
+			WithError(err).
+			Errorf("apply queued operation for doc id %d", op.id)
+	}
+}
+
+// truncate empties the log once all operations in it have been applied. The
+// vector index is flushed first, otherwise a crash could lose operations
+// which are neither in the log nor in the index. Must be called with the lock
+// held, the lock is released while the index is flushed. If operations were
+// queued in the meantime, the log is left as is and truncated once the queue
+// runs empty again.
+func (q *vectorIndexQueue) truncate() {
+	logger := q.logger.WithField("action", "vector_index_queue_truncate").
+		WithField("path", q.path)
+
+	appended := q.appended
+	q.truncating = true
+	q.Unlock()
+
+	err := q.index.Flush()
+
+	q.Lock()
+	q.truncating = false
+	if err != nil {
+		logger.WithError(err).Error("flush vector index")
+		return
+	}
+
+	if q.appended != appended || q.closed {
+		return
+	}
+
+	if err := q.file.Truncate(0); err != nil {
+		logger.WithError(err).Error("truncate queue log")
+		return
+	}
+
+	if _, err := q.file.Seek(0, io.SeekStart); err != nil {
+		logger.WithError(err).Error("seek start of queue log")
+	}
+}
+
+// Length returns the number of operations which are queued, but not yet
+// applied to the vector index
+func (q *vectorIndexQueue) Length() int64 {
+	q.Lock()
+	defer q.Unlock()
+
+	return q.pending
+}
+
+// Size returns the size of the queue log on disk
+func (q *vectorIndexQueue) Size() (int64, error) {
+	q.Lock()
+	defer q.Unlock()
+
+	info, err := q.file.Stat()
+	if err != nil {
+		return 0, errors.Wrapf(err, "stat queue log %s", q.path)
+	}
+
+	return info.Size(), nil
+}
+
+// Wait blocks until all operations which were queued before the call have
+// been applied. Operations queued while waiting are not waited for, so a
+// steady stream of writes can't delay the caller indefinitely.
+func (q *vectorIndexQueue) Wait(ctx context.Context) error {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			q.Lock()
+			q.cond.Broadcast()
+			q.Unlock()
+		case <-stop:
+		}
+	}()
+
+	q.Lock()
+	defer q.Unlock()
+
+	targets := make([]uint64, len(q.workers))
+	for i, w := range q.workers {
+		targets[i] = w.enqueued
+	}
+
+	for !q.reached(targets) {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, "wait for vector index queue")
+		}
+		if q.closed {
+			return errors.Errorf("vector index queue is closed")
+		}
+		q.cond.Wait()
+	}
+
+	return nil
+}
+
+func (q *vectorIndexQueue) reached(targets []uint64) bool {
+	for i, w := range q.workers {
+		if w.processed < targets[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Close stops the workers after their current batch. Operations which were
+// not applied yet remain in the log and are replayed on the next startup.
+func (q *vectorIndexQueue) Close() error {
+	q.Lock()
+	if q.closed {
+		q.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.cond.Broadcast()
+	q.Unlock()
+
+	q.wg.Wait()
+
+	if err := q.writer.Flush(); err != nil {
+		return errors.Wrap(err, "flush queue log")
+	}
+
+	if err := q.syncer.Close(); err != nil {
+		return errors.Wrap(err, "sync queue log")
+	}
+
+	return q.file.Close()
+}
+
+// Drop stops the workers and removes the log from disk
+func (q *vectorIndexQueue) Drop() error {
+	if err := q.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Remove(q.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "remove queue log %s", q.path)
+	}
+
+	return nil
+}
+
+// An operation is encoded as the op type (1 byte), the doc id (8 bytes), the
+// vector length (4 bytes) and the vector itself (4 bytes per dimension).
+// Deletes have a vector length of 0.
+func writeVectorIndexQueueOp(w io.Writer, op vectorIndexQueueOp) error {
+	buf := make([]byte, 13+4*len(op.vector))
+	if op.delete {
+		buf[0] = vectorIndexQueueOpDelete
+	} else {
+		buf[0] = vectorIndexQueueOpAdd
+	}
+	binary.LittleEndian.PutUint64(buf[1:9], op.id)
+	binary.LittleEndian.PutUint32(buf[9:13], uint32(len(op.vector)))
+	for i, v := range op.vector {
+		binary.LittleEndian.PutUint32(buf[13+4*i:], math.Float32bits(v))
+	}
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// readVectorIndexQueueOp returns io.EOF if the log ends before the op and
+// io.ErrUnexpectedEOF if it ends within the op
+func readVectorIndexQueueOp(r io.Reader) (vectorIndexQueueOp, int64, error) {
+	var op vectorIndexQueueOp
+
+	header := make([]byte, 13)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return op, 0, err
+	}
+
+	switch header[0] {
+	case vectorIndexQueueOpAdd:
+	case vectorIndexQueueOpDelete:
+		op.delete = true
+	default:
+		return op, 0, errors.Errorf("unknown queue operation %d", header[0])
+	}
+
+	op.id = binary.LittleEndian.Uint64(header[1:9])
+	dims := binary.LittleEndian.Uint32(header[9:13])
+	if dims == 0 {
+		return op, 13, nil
+	}
+
+	body := make([]byte, 4*dims)
+	if _, err := io.ReadFull(r, body); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return op, 0, err
+	}
+
+	op.vector = make([]float32, dims)
+	for i := range op.vector {
+		op.vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(body[4*i:]))
+	}
+
+	return op, int64(13 + 4*dims), nil
+}
@@ -42,6 +42,8 @@ type ClientService interface {
 
 	BatchReferencesCreate(params *BatchReferencesCreateParams, authInfo runtime.ClientAuthInfoWriter) (*BatchReferencesCreateOK, error)
 
+	BatchSearch(params *BatchSearchParams, authInfo runtime.ClientAuthInfoWriter) (*BatchSearchOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -119,6 +121,43 @@ func (a *Client) BatchReferencesCreate(params *BatchReferencesCreateParams, auth
 	panic(msg)
 }
 
+/*
+  BatchSearch runs many vector searches against the same class at once
+
+  Searches the class with each of the given vectors. All searches share the same filter and limit. The response contains one result list per query vector, in the order of the vectors.
+*/
+func (a *Client) BatchSearch(params *BatchSearchParams, authInfo runtime.ClientAuthInfoWriter) (*BatchSearchOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewBatchSearchParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "batch.search",
+		Method:             "POST",
+		PathPattern:        "/batch/search",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &BatchSearchReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*BatchSearchOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for batch.search: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
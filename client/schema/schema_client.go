@@ -48,8 +48,20 @@ type ClientService interface {
 
 	SchemaObjectsPropertiesAdd(params *SchemaObjectsPropertiesAddParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsPropertiesAddOK, error)
 
+	SchemaObjectsShardsGet(params *SchemaObjectsShardsGetParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsGetOK, error)
+
+	SchemaObjectsShardsScrub(params *SchemaObjectsShardsScrubParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsScrubOK, error)
+
+	SchemaObjectsShardsVectorIndexGet(params *SchemaObjectsShardsVectorIndexGetParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsVectorIndexGetOK, error)
+
+	SchemaObjectsShardsVectorIndexRecall(params *SchemaObjectsShardsVectorIndexRecallParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsVectorIndexRecallOK, error)
+
+	SchemaObjectsShardsVectorIndexRepair(params *SchemaObjectsShardsVectorIndexRepairParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsVectorIndexRepairOK, error)
+
 	SchemaObjectsUpdate(params *SchemaObjectsUpdateParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsUpdateOK, error)
 
+	SchemaStorage(params *SchemaStorageParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaStorageOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -228,6 +240,181 @@ func (a *Client) SchemaObjectsPropertiesAdd(params *SchemaObjectsPropertiesAddPa
 	panic(msg)
 }
 
+/*
+  SchemaObjectsShardsGet gets the status of the shards of an object class held by this node such as the progress of vector index rebuilds
+*/
+func (a *Client) SchemaObjectsShardsGet(params *SchemaObjectsShardsGetParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsGetOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewSchemaObjectsShardsGetParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "schema.objects.shards.get",
+		Method:             "GET",
+		PathPattern:        "/schema/{className}/shards",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &SchemaObjectsShardsGetReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*SchemaObjectsShardsGetOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for schema.objects.shards.get: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  SchemaObjectsShardsScrub verifies all disk segments of all buckets of a shard held by this node against their checksums corrupt segments can optionally be moved to quarantine
+*/
+func (a *Client) SchemaObjectsShardsScrub(params *SchemaObjectsShardsScrubParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsScrubOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewSchemaObjectsShardsScrubParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "schema.objects.shards.scrub",
+		Method:             "POST",
+		PathPattern:        "/schema/{className}/shards/{shardName}/scrub",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &SchemaObjectsShardsScrubReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*SchemaObjectsShardsScrubOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for schema.objects.shards.scrub: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  SchemaObjectsShardsVectorIndexGet inspects the graph of a vector index of a shard held by this node such as the level distribution and the nodes which cannot be reached from the entrypoint
+*/
+func (a *Client) SchemaObjectsShardsVectorIndexGet(params *SchemaObjectsShardsVectorIndexGetParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsVectorIndexGetOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewSchemaObjectsShardsVectorIndexGetParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "schema.objects.shards.vectorIndex.get",
+		Method:             "GET",
+		PathPattern:        "/schema/{className}/shards/{shardName}/vector-index",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &SchemaObjectsShardsVectorIndexGetReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*SchemaObjectsShardsVectorIndexGetOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for schema.objects.shards.vectorIndex.get: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  SchemaObjectsShardsVectorIndexRecall evaluates the recall of the vector index of a shard held by this node by comparing its results to an exact search over all vectors of the shard
+*/
+func (a *Client) SchemaObjectsShardsVectorIndexRecall(params *SchemaObjectsShardsVectorIndexRecallParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsVectorIndexRecallOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewSchemaObjectsShardsVectorIndexRecallParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "schema.objects.shards.vectorIndex.recall",
+		Method:             "POST",
+		PathPattern:        "/schema/{className}/shards/{shardName}/vector-index/recall",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &SchemaObjectsShardsVectorIndexRecallReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*SchemaObjectsShardsVectorIndexRecallOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for schema.objects.shards.vectorIndex.recall: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+  SchemaObjectsShardsVectorIndexRepair repairs the graph of a vector index of a shard held by this node deleted nodes are cleaned up and nodes which cannot be reached from the entrypoint are connected again
+*/
+func (a *Client) SchemaObjectsShardsVectorIndexRepair(params *SchemaObjectsShardsVectorIndexRepairParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaObjectsShardsVectorIndexRepairOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewSchemaObjectsShardsVectorIndexRepairParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "schema.objects.shards.vectorIndex.repair",
+		Method:             "POST",
+		PathPattern:        "/schema/{className}/shards/{shardName}/vector-index/repair",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &SchemaObjectsShardsVectorIndexRepairReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*SchemaObjectsShardsVectorIndexRepairOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for schema.objects.shards.vectorIndex.repair: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
   SchemaObjectsUpdate updates settings of an existing schema class
 
@@ -265,6 +452,41 @@ func (a *Client) SchemaObjectsUpdate(params *SchemaObjectsUpdateParams, authInfo
 	panic(msg)
 }
 
+/*
+  SchemaStorage gets the memory and disk usage of the classes shards and lsmkv buckets of all nodes aggregated per class shard and node
+*/
+func (a *Client) SchemaStorage(params *SchemaStorageParams, authInfo runtime.ClientAuthInfoWriter) (*SchemaStorageOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewSchemaStorageParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "schema.storage",
+		Method:             "GET",
+		PathPattern:        "/schema/storage",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &SchemaStorageReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*SchemaStorageOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for schema.storage: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -0,0 +1,145 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
+)
+
+const commitLogDirSuffix = ".hnsw.commitlog.d"
+
+// commitLogIndex splits a commit log directory into the root path and the
+// id of its index. The directory must exist, a missing one would otherwise
+// look like an empty index.
+func commitLogIndex(dir string) (string, string, error) {
+	dir = filepath.Clean(dir)
+	base := filepath.Base(dir)
+	if !strings.HasSuffix(base, commitLogDirSuffix) {
+		return "", "", errors.Errorf("%q is not an hnsw commit log directory, "+
+			"its name must end in %q", dir, commitLogDirSuffix)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", "", err
+	}
+	if !info.IsDir() {
+		return "", "", errors.Errorf("%q is not a directory", dir)
+	}
+
+	return filepath.Dir(dir), strings.TrimSuffix(base, commitLogDirSuffix), nil
+}
+
+type hnswSummaryCommand struct {
+	Args dirArgs `positional-args:"yes" required:"yes"`
+}
+
+func (c *hnswSummaryCommand) Execute(args []string) error {
+	rootPath, id, err := commitLogIndex(c.Args.Dir)
+	if err != nil {
+		return err
+	}
+
+	fileNames, err := hnsw.ListCommitLogs(rootPath, id)
+	if err != nil {
+		return err
+	}
+
+	w := newTable("COMMIT LOG", "BYTES", "VALID BYTES", "NODES", "LINKS",
+		"TOMBSTONES", "MAX LEVEL", "ENTRYPOINT", "COMPRESSED")
+	for _, fileName := range fileNames {
+		stats, err := hnsw.SummarizeCommitLog(fileName, newLogger())
+		if err != nil {
+			return err
+		}
+
+		w.row(filepath.Base(fileName), stats.Size, stats.ValidSize, stats.Nodes,
+			stats.Links, stats.Tombstones, stats.MaxLevel, stats.Entrypoint,
+			stats.Compressed)
+	}
+
+	return w.Flush()
+}
+
+type hnswReplayCommand struct {
+	Nodes bool    `long:"nodes" description:"print every node with its connections"`
+	Args  dirArgs `positional-args:"yes" required:"yes"`
+}
+
+func (c *hnswReplayCommand) Execute(args []string) error {
+	rootPath, id, err := commitLogIndex(c.Args.Dir)
+	if err != nil {
+		return err
+	}
+
+	replay, err := hnsw.ReplayCommitLogs(rootPath, id, newLogger())
+	if err != nil {
+		return err
+	}
+
+	if replay.Snapshot > 0 {
+		fmt.Fprintf(stdout, "snapshot:     %d\n", replay.Snapshot)
+	} else {
+		fmt.Fprintf(stdout, "snapshot:     none\n")
+	}
+
+	fmt.Fprintf(stdout, "commit logs:  %d\n", len(replay.Files))
+	for _, fileName := range replay.Files {
+		fmt.Fprintf(stdout, "              %s\n", filepath.Base(fileName))
+	}
+
+	stats := replay.Stats
+	fmt.Fprintf(stdout, "bytes:        %d\n", stats.Size)
+	if stats.Torn() {
+		fmt.Fprintf(stdout, "valid bytes:  %d (a commit log ends in a torn write)\n",
+			stats.ValidSize)
+	}
+	fmt.Fprintf(stdout, "nodes:        %d\n", stats.Nodes)
+	fmt.Fprintf(stdout, "links:        %d\n", stats.Links)
+	fmt.Fprintf(stdout, "tombstones:   %d\n", stats.Tombstones)
+	fmt.Fprintf(stdout, "max level:    %d\n", stats.MaxLevel)
+	fmt.Fprintf(stdout, "entrypoint:   %d\n", stats.Entrypoint)
+	fmt.Fprintf(stdout, "compressed:   %t\n", stats.Compressed)
+
+	if !c.Nodes {
+		return nil
+	}
+
+	fmt.Fprintln(stdout)
+	w := newTable("NODE", "LEVEL", "TOMBSTONE", "CONNECTIONS")
+	if err := replay.ForEachNode(func(node hnsw.ReplayedNode) error {
+		levels := make([]int, 0, len(node.Connections))
+		for level := range node.Connections {
+			levels = append(levels, level)
+		}
+		sort.Ints(levels)
+
+		conns := make([]string, len(levels))
+		for i, level := range levels {
+			conns[i] = fmt.Sprintf("%d:%v", level, node.Connections[level])
+		}
+
+		w.row(node.ID, node.Level, node.Tombstone, strings.Join(conns, " "))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,443 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/storobj"
+)
+
+type dirArgs struct {
+	Dir string `positional-arg-name:"dir"`
+}
+
+type shardCommand struct {
+	Args dirArgs `positional-args:"yes" required:"yes"`
+}
+
+func (c *shardCommand) Execute(args []string) error {
+	bucketDirs, err := subDirs(c.Args.Dir)
+	if err != nil {
+		return err
+	}
+
+	w := newTable("BUCKET", "STRATEGY", "SEGMENTS", "SEGMENT BYTES", "KEYS",
+		"WALS", "WAL BYTES")
+	for _, dir := range bucketDirs {
+		info, err := lsmkv.InspectBucket(dir, newLogger())
+		if err != nil {
+			return errors.Wrapf(err, "bucket %s", filepath.Base(dir))
+		}
+
+		var segmentBytes, walBytes int64
+		var keys int
+		for _, seg := range info.Segments {
+			segmentBytes += seg.Size
+			keys += seg.Keys
+		}
+		for _, wal := range info.WALs {
+			walBytes += wal.Size
+		}
+
+		w.row(filepath.Base(dir), info.Strategy, len(info.Segments), segmentBytes,
+			keys, len(info.WALs), walBytes)
+	}
+
+	return w.Flush()
+}
+
+type bucketCommand struct {
+	Args dirArgs `positional-args:"yes" required:"yes"`
+}
+
+func (c *bucketCommand) Execute(args []string) error {
+	info, err := lsmkv.InspectBucket(c.Args.Dir, newLogger())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "strategy: %s\n\n", info.Strategy)
+
+	w := newTable("SEGMENT", "LEVEL", "STRATEGY", "BYTES", "DATA BYTES",
+		"INDEX BYTES", "KEYS", "SECONDARY INDEXES", "COMPRESSED", "CHECKSUMMED",
+		"BLOOM FILTER BYTES")
+	for _, seg := range info.Segments {
+		w.row(filepath.Base(seg.Path), seg.Level, seg.Strategy, seg.Size,
+			seg.DataSize, seg.IndexSize, seg.Keys, seg.SecondaryIndices,
+			seg.Compressed, seg.Checksummed, seg.BloomFilterBytes)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout)
+	w = newTable("WAL", "BYTES")
+	for _, wal := range info.WALs {
+		w.row(filepath.Base(wal.Path), wal.Size)
+	}
+
+	return w.Flush()
+}
+
+type bucketCopyOptions struct {
+	ScratchDir string `long:"scratch-dir" description:"directory for the scratch copy of the bucket, defaults to the system temp dir"`
+	Strategy   string `long:"strategy" description:"override the detected strategy, e.g. for buckets which only consist of commit logs"`
+}
+
+// openBucketCopy loads the bucket in dir from a scratch copy. The returned
+// function shuts the bucket down and removes the copy.
+func (o bucketCopyOptions) openBucketCopy(dir string,
+	bucketOpts ...lsmkv.BucketOption) (*lsmkv.Bucket, func(), error) {
+	scratch, err := ioutil.TempDir(o.ScratchDir, "weaviate-inspect-")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "create scratch dir")
+	}
+
+	if o.Strategy != "" {
+		bucketOpts = append(bucketOpts, lsmkv.WithStrategy(o.Strategy))
+	}
+
+	ctx := context.Background()
+	b, err := lsmkv.OpenBucketCopy(ctx, dir, filepath.Join(scratch, "bucket"),
+		newLogger(), bucketOpts...)
+	if err != nil {
+		os.RemoveAll(scratch)
+		return nil, nil, err
+	}
+
+	return b, func() {
+		b.Shutdown(ctx)
+		os.RemoveAll(scratch)
+	}, nil
+}
+
+type dumpCommand struct {
+	bucketCopyOptions
+	Limit     int     `long:"limit" description:"stop after this many keys, 0 means no limit"`
+	Prefix    string  `long:"prefix" description:"only dump keys starting with this prefix"`
+	PrefixHex string  `long:"prefix-hex" description:"like --prefix, but hex-encoded"`
+	KeysOnly  bool    `long:"keys-only" description:"do not print the values"`
+	Format    string  `long:"format" choice:"auto" choice:"string" choice:"hex" default:"auto" description:"how keys and values are printed, auto prints printable values as strings and everything else hex-encoded"`
+	Args      dirArgs `positional-args:"yes" required:"yes"`
+}
+
+func (c *dumpCommand) Execute(args []string) error {
+	prefix := []byte(c.Prefix)
+	if c.PrefixHex != "" {
+		var err error
+		if prefix, err = hex.DecodeString(c.PrefixHex); err != nil {
+			return errors.Wrap(err, "decode --prefix-hex")
+		}
+	}
+
+	b, done, err := c.openBucketCopy(c.Args.Dir)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	f := formatter(c.Format)
+	n := 0
+	more := func() bool {
+		n++
+		return c.Limit <= 0 || n <= c.Limit
+	}
+
+	switch b.Strategy() {
+	case lsmkv.StrategyReplace:
+		cursor := b.Cursor()
+		defer cursor.Close()
+		cursor.SetPrefix(prefix)
+		for k, v := cursor.First(); k != nil && more(); k, v = cursor.Next() {
+			c.print(f, k, f.bytes(v))
+		}
+
+	case lsmkv.StrategySetCollection:
+		cursor := b.SetCursor()
+		defer cursor.Close()
+		cursor.SetPrefix(prefix)
+		for k, vs := cursor.First(); k != nil && more(); k, vs = cursor.Next() {
+			values := make([]string, len(vs))
+			for i, v := range vs {
+				values[i] = f.bytes(v)
+			}
+			c.print(f, k, "["+strings.Join(values, ", ")+"]")
+		}
+
+	case lsmkv.StrategyMapCollection:
+		cursor := b.MapCursor()
+		defer cursor.Close()
+		cursor.SetPrefix(prefix)
+		for k, pairs := cursor.First(); k != nil && more(); k, pairs = cursor.Next() {
+			values := make([]string, len(pairs))
+			for i, pair := range pairs {
+				values[i] = f.bytes(pair.Key) + ": " + f.bytes(pair.Value)
+			}
+			c.print(f, k, "{"+strings.Join(values, ", ")+"}")
+		}
+
+	case lsmkv.StrategyRoaringSet:
+		cursor := b.CursorRoaringSet()
+		defer cursor.Close()
+		cursor.SetPrefix(prefix)
+		for k, bm := cursor.First(); k != nil && more(); k, bm = cursor.Next() {
+			c.print(f, k, fmt.Sprint(bm.ToArray()))
+		}
+
+	default:
+		return errors.Errorf("unsupported strategy %q", b.Strategy())
+	}
+
+	return nil
+}
+
+func (c *dumpCommand) print(f formatter, key []byte, value string) {
+	if c.KeysOnly {
+		fmt.Fprintln(stdout, f.bytes(key))
+		return
+	}
+
+	fmt.Fprintf(stdout, "%s\t%s\n", f.bytes(key), value)
+}
+
+type objectsCommand struct {
+	bucketCopyOptions
+	Limit   int     `long:"limit" description:"stop after this many objects, 0 means no limit"`
+	ID      string  `long:"id" description:"only print the object with this uuid"`
+	DocID   int64   `long:"doc-id" default:"-1" description:"only print the object with this doc id"`
+	Vectors bool    `long:"vectors" description:"include the vectors, otherwise only their dimensions are printed"`
+	Args    dirArgs `positional-args:"yes" required:"yes"`
+}
+
+type objectJSON struct {
+	DocID            uint64               `json:"docID"`
+	Object           models.Object        `json:"object"`
+	VectorDimensions int                  `json:"vectorDimensions"`
+	Vector           []float32            `json:"vector,omitempty"`
+	Vectors          map[string][]float32 `json:"vectors,omitempty"`
+}
+
+func (c *objectsCommand) Execute(args []string) error {
+	b, done, err := c.openBucketCopy(c.Args.Dir)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if b.Strategy() != lsmkv.StrategyReplace {
+		return errors.Errorf("objects are stored in a bucket with strategy %q, "+
+			"got %q", lsmkv.StrategyReplace, b.Strategy())
+	}
+
+	enc := json.NewEncoder(stdout)
+
+	if c.ID != "" || c.DocID >= 0 {
+		data, err := c.lookup(b)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			return errors.Errorf("object not found")
+		}
+
+		return c.print(enc, data)
+	}
+
+	cursor := b.Cursor()
+	defer cursor.Close()
+
+	n, failed := 0, 0
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		if c.Limit > 0 && n >= c.Limit {
+			break
+		}
+		n++
+
+		if err := c.print(enc, v); err != nil {
+			fmt.Fprintf(os.Stderr, "key %x: %v\n", k, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d objects could not be decoded", failed, n)
+	}
+
+	return nil
+}
+
+func (c *objectsCommand) lookup(b *lsmkv.Bucket) ([]byte, error) {
+	if c.ID != "" {
+		id, err := uuid.Parse(c.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse --id")
+		}
+
+		key, _ := id.MarshalBinary() // cannot error
+		return b.Get(key)
+	}
+
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint64(key, uint64(c.DocID))
+	return b.GetBySecondary(0, key)
+}
+
+func (c *objectsCommand) print(enc *json.Encoder, data []byte) error {
+	obj, err := storobj.FromBinary(data)
+	if err != nil {
+		return err
+	}
+
+	out := objectJSON{
+		DocID:            obj.DocID(),
+		Object:           obj.Object,
+		VectorDimensions: len(obj.Vector),
+	}
+	if c.Vectors {
+		out.Vector = obj.Vector
+		out.Vectors = obj.Vectors
+	}
+
+	return enc.Encode(out)
+}
+
+type verifyCommand struct {
+	Args dirArgs `positional-args:"yes" required:"yes"`
+}
+
+// Execute accepts a bucket or a shard directory, in the latter case all of
+// its buckets are verified
+func (c *verifyCommand) Execute(args []string) error {
+	segments, err := filepath.Glob(filepath.Join(c.Args.Dir, "*.db"))
+	if err != nil {
+		return err
+	}
+
+	if len(segments) == 0 {
+		bucketDirs, err := subDirs(c.Args.Dir)
+		if err != nil {
+			return err
+		}
+
+		for _, dir := range bucketDirs {
+			bucketSegments, err := filepath.Glob(filepath.Join(dir, "*.db"))
+			if err != nil {
+				return err
+			}
+			segments = append(segments, bucketSegments...)
+		}
+	}
+
+	w := newTable("SEGMENT", "CHECKSUMS", "BLOOM FILTER KEYS", "ERROR")
+	corrupt := 0
+	for _, path := range segments {
+		res := lsmkv.VerifySegment(path, newLogger())
+		errMsg := ""
+		if !res.OK() {
+			corrupt++
+			errMsg = res.Error.Error()
+		}
+
+		rel, err := filepath.Rel(c.Args.Dir, path)
+		if err != nil {
+			rel = path
+		}
+		w.row(rel, res.Checksums, res.BloomFilterKeys, errMsg)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if corrupt > 0 {
+		return errors.Errorf("%d of %d segments are corrupt", corrupt,
+			len(segments))
+	}
+
+	return nil
+}
+
+func subDirs(dir string) ([]string, error) {
+	list, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, info := range list {
+		if info.IsDir() {
+			out = append(out, filepath.Join(dir, info.Name()))
+		}
+	}
+
+	sort.Strings(out)
+	return out, nil
+}
+
+type formatter string
+
+func (f formatter) bytes(in []byte) string {
+	switch f {
+	case "string":
+		return string(in)
+	case "hex":
+		return hex.EncodeToString(in)
+	default:
+		if isPrintable(in) {
+			return string(in)
+		}
+		return hex.EncodeToString(in)
+	}
+}
+
+func isPrintable(in []byte) bool {
+	for _, r := range string(in) {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type table struct {
+	*tabwriter.Writer
+}
+
+func newTable(columns ...string) table {
+	t := table{tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)}
+	fmt.Fprintln(t, strings.Join(columns, "\t"))
+	return t
+}
+
+func (t table) row(values ...interface{}) {
+	cells := make([]string, len(values))
+	for i, v := range values {
+		cells[i] = fmt.Sprint(v)
+	}
+	fmt.Fprintln(t, strings.Join(cells, "\t"))
+}
@@ -0,0 +1,104 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+// Command weaviate-inspect looks at the files of a data directory without
+// starting a server. It never modifies the inspected files, commands which
+// need to load a bucket do so from a scratch copy. Still, point it at a copy
+// of the data directory if the server might be running, otherwise files can
+// change or disappear while they are being read.
+package main
+
+import (
+	"io"
+	"os"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/sirupsen/logrus"
+)
+
+type globalOptions struct {
+	Verbose bool `short:"v" long:"verbose" description:"log what is happening while files are loaded"`
+}
+
+var (
+	opts globalOptions
+
+	// stdout receives the output of all commands
+	stdout io.Writer = os.Stdout
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run executes the command in args and returns the exit code
+func run(args []string) int {
+	opts = globalOptions{}
+	parser := flags.NewParser(&opts, flags.Default)
+	parser.ShortDescription = "Weaviate offline inspection"
+	parser.LongDescription = "Inspect the LSM buckets and HNSW commit logs of " +
+		"a Weaviate data directory without starting a server"
+
+	addCommand(parser, "shard", "List the buckets of a shard",
+		"List the buckets of a shard with their segments and commit logs. "+
+			"The argument is the LSM directory of the shard, e.g. "+
+			"<data>/<index>_<shard>_lsm.", &shardCommand{})
+	addCommand(parser, "bucket", "List the segments of a bucket",
+		"List the segments and commit logs of a single bucket.", &bucketCommand{})
+	addCommand(parser, "dump", "Dump the keys and values of a bucket",
+		"Dump the keys and values of a bucket, including the contents of its "+
+			"commit logs, using the bucket cursors.", &dumpCommand{})
+	addCommand(parser, "objects", "Decode the objects of an objects bucket",
+		"Decode the objects stored in the objects bucket of a shard and print "+
+			"them as JSON, one object per line.", &objectsCommand{})
+	addCommand(parser, "verify", "Verify segment checksums and bloom filters",
+		"Verify all segments of a bucket or of all buckets of a shard against "+
+			"their checksums and make sure their bloom filters contain all keys. "+
+			"Exits with a non-zero code if a segment is corrupt.", &verifyCommand{})
+	addCommand(parser, "hnsw-summary", "Summarize each HNSW commit log",
+		"Deserialize each commit log of an HNSW index on its own and summarize "+
+			"the changes it contains. The argument is the commit log directory, "+
+			"e.g. <data>/<index>_<shard>.hnsw.commitlog.d.", &hnswSummaryCommand{})
+	addCommand(parser, "hnsw-replay", "Replay the HNSW commit logs into a graph",
+		"Replay the newest snapshot and all commit logs of an HNSW index like a "+
+			"startup would and summarize the resulting graph.", &hnswReplayCommand{})
+
+	if _, err := parser.ParseArgs(args); err != nil {
+		if fe, ok := err.(*flags.Error); ok {
+			if fe.Type == flags.ErrHelp {
+				return 0
+			}
+		}
+		return 1
+	}
+
+	return 0
+}
+
+func addCommand(parser *flags.Parser, name, short, long string,
+	data interface{}) {
+	if _, err := parser.AddCommand(name, short, long, data); err != nil {
+		panic(err)
+	}
+}
+
+func newLogger() logrus.FieldLogger {
+	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
+	// recovering the WALs of the scratch copy warns about a crash, which is
+	// expected offline and only noise for the user
+	logger.SetLevel(logrus.ErrorLevel)
+	if opts.Verbose {
+		logger.SetLevel(logrus.DebugLevel)
+	}
+
+	return logger
+}
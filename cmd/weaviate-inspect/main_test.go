@@ -0,0 +1,217 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/semi-technologies/weaviate/adapters/repos/db/lsmkv"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw/commitlog"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCommand runs the command line args and returns its exit code and output
+func runCommand(t *testing.T, args ...string) (int, string) {
+	var out bytes.Buffer
+	stdout = &out
+	defer func() { stdout = os.Stdout }()
+
+	code := run(args)
+	return code, out.String()
+}
+
+// writeShardFixture writes a shard with a single replace bucket of ten keys
+// in two segments
+func writeShardFixture(t *testing.T) string {
+	shardDir := filepath.Join(t.TempDir(), "shard_lsm")
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+
+	b, err := lsmkv.NewBucket(ctx, filepath.Join(shardDir, "fixture"), logger,
+		lsmkv.WithStrategy(lsmkv.StrategyReplace))
+	require.Nil(t, err)
+
+	for i := 0; i < 10; i++ {
+		require.Nil(t, b.Put([]byte(fmt.Sprintf("key-%02d", i)),
+			[]byte(fmt.Sprintf("value-%02d", i))))
+		if i == 4 {
+			require.Nil(t, b.FlushAndSwitch())
+		}
+	}
+	require.Nil(t, b.FlushAndSwitch())
+	require.Nil(t, b.Shutdown(ctx))
+
+	return shardDir
+}
+
+// writeCommitLogFixture writes a commit log of an index with three connected
+// nodes, one of which is deleted
+func writeCommitLogFixture(t *testing.T) string {
+	dir := filepath.Join(t.TempDir(), "fixture.hnsw.commitlog.d")
+	require.Nil(t, os.MkdirAll(dir, 0o755))
+
+	l := commitlog.NewLogger(filepath.Join(dir, "1000"))
+	require.Nil(t, l.AddNode(0, 0))
+	require.Nil(t, l.AddNode(1, 1))
+	require.Nil(t, l.AddNode(2, 0))
+	require.Nil(t, l.SetEntryPointWithMaxLayer(1, 1))
+	require.Nil(t, l.AddLinksAtLevel(0, 0, []uint64{1, 2}))
+	require.Nil(t, l.AddLinksAtLevel(1, 0, []uint64{0, 2}))
+	require.Nil(t, l.AddLinksAtLevel(2, 0, []uint64{0, 1}))
+	require.Nil(t, l.AddTombstone(2))
+	require.Nil(t, l.Close())
+
+	return dir
+}
+
+func TestLSMCommands(t *testing.T) {
+	shardDir := writeShardFixture(t)
+	bucketDir := filepath.Join(shardDir, "fixture")
+
+	t.Run("shard", func(t *testing.T) {
+		code, out := runCommand(t, "shard", shardDir)
+		require.Equal(t, 0, code)
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{
+			"BUCKET", "STRATEGY", "SEGMENTS", "SEGMENT", "BYTES", "KEYS", "WALS",
+			"WAL", "BYTES",
+		}, strings.Fields(lines[0]))
+		row := strings.Fields(lines[1])
+		require.Len(t, row, 7)
+		assert.Equal(t, []string{"fixture", lsmkv.StrategyReplace, "2"}, row[:3])
+		assert.Equal(t, "10", row[4])
+	})
+
+	t.Run("bucket", func(t *testing.T) {
+		code, out := runCommand(t, "bucket", bucketDir)
+		require.Equal(t, 0, code)
+		assert.True(t, strings.HasPrefix(out,
+			"strategy: "+lsmkv.StrategyReplace+"\n"))
+		assert.Equal(t, 2, strings.Count(out, ".db "))
+	})
+
+	t.Run("dump", func(t *testing.T) {
+		code, out := runCommand(t, "dump", "--prefix", "key-0", "--limit", "3",
+			bucketDir)
+		require.Equal(t, 0, code)
+		assert.Equal(t, "key-00\tvalue-00\nkey-01\tvalue-01\nkey-02\tvalue-02\n",
+			out)
+	})
+
+	t.Run("dump keys only as hex", func(t *testing.T) {
+		code, out := runCommand(t, "dump", "--keys-only", "--format", "hex",
+			"--limit", "1", bucketDir)
+		require.Equal(t, 0, code)
+		assert.Equal(t, "6b65792d3030\n", out)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		code, out := runCommand(t, "verify", shardDir)
+		require.Equal(t, 0, code)
+		assert.Equal(t, 2, strings.Count(out, lsmkv.ScrubStatusOK))
+	})
+
+	t.Run("verify a corrupt segment", func(t *testing.T) {
+		segments, err := filepath.Glob(filepath.Join(bucketDir, "*.db"))
+		require.Nil(t, err)
+		require.Len(t, segments, 2)
+
+		f, err := os.OpenFile(segments[0], os.O_RDWR, 0o666)
+		require.Nil(t, err)
+		info, err := f.Stat()
+		require.Nil(t, err)
+		_, err = f.WriteAt([]byte{0xff, 0xff, 0xff, 0xff}, info.Size()/2)
+		require.Nil(t, err)
+		require.Nil(t, f.Close())
+
+		code, out := runCommand(t, "verify", bucketDir)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, out, lsmkv.ScrubStatusCorrupt)
+	})
+
+	t.Run("bad paths", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing")
+		for _, args := range [][]string{
+			{"shard", missing},
+			{"bucket", missing},
+			{"dump", missing},
+			{"objects", missing},
+			{"verify", missing},
+		} {
+			code, _ := runCommand(t, args...)
+			assert.Equal(t, 1, code, "%v", args)
+		}
+	})
+}
+
+func TestHNSWCommands(t *testing.T) {
+	dir := writeCommitLogFixture(t)
+
+	t.Run("hnsw-summary", func(t *testing.T) {
+		code, out := runCommand(t, "hnsw-summary", dir)
+		require.Equal(t, 0, code)
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		require.Len(t, lines, 2)
+		row := strings.Fields(lines[1])
+		require.Len(t, row, 9)
+		assert.Equal(t, "1000", row[0])
+		// nodes, links, tombstones, max level, entrypoint, compressed
+		assert.Equal(t, []string{"3", "6", "1", "1", "1", "false"}, row[3:])
+	})
+
+	t.Run("hnsw-replay", func(t *testing.T) {
+		code, out := runCommand(t, "hnsw-replay", "--nodes", dir)
+		require.Equal(t, 0, code)
+		assert.Contains(t, out, "snapshot:     none\n")
+		assert.Contains(t, out, "commit logs:  1\n")
+		assert.Contains(t, out, "nodes:        3\n")
+		assert.Contains(t, out, "tombstones:   1\n")
+		assert.Contains(t, out, "entrypoint:   1\n")
+		assert.Contains(t, out, "2     0      true       0:[0 1]")
+	})
+
+	t.Run("bad paths", func(t *testing.T) {
+		notALog := t.TempDir()
+		missing := filepath.Join(t.TempDir(), "missing.hnsw.commitlog.d")
+		for _, args := range [][]string{
+			{"hnsw-summary", notALog},
+			{"hnsw-replay", notALog},
+			{"hnsw-summary", missing},
+			{"hnsw-replay", missing},
+		} {
+			code, _ := runCommand(t, args...)
+			assert.Equal(t, 1, code, "%v", args)
+		}
+	})
+}
+
+func TestUsage(t *testing.T) {
+	code, _ := runCommand(t, "--help")
+	assert.Equal(t, 0, code)
+
+	code, _ = runCommand(t, "unknown-command")
+	assert.Equal(t, 1, code)
+
+	code, _ = runCommand(t, "shard")
+	assert.Equal(t, 1, code, "the directory is required")
+}
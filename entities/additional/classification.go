@@ -26,6 +26,7 @@ type Properties struct {
 	RefMeta        bool                   `json:"refMeta"`
 	Vector         bool                   `json:"vector"`
 	Certainty      bool                   `json:"certainty"`
+	Distance       bool                   `json:"distance"`
 	ID             bool                   `json:"id"`
 	ModuleParams   map[string]interface{} `json:"moduleParams"`
 }
@@ -21,17 +21,19 @@ import (
 type Operator int
 
 const (
-	OperatorEqual            Operator = 1
-	OperatorNotEqual         Operator = 2
-	OperatorGreaterThan      Operator = 3
-	OperatorGreaterThanEqual Operator = 4
-	OperatorLessThan         Operator = 5
-	OperatorLessThanEqual    Operator = 6
-	OperatorAnd              Operator = 7
-	OperatorOr               Operator = 8
-	OperatorNot              Operator = 9
-	OperatorWithinGeoRange   Operator = 10
-	OperatorLike             Operator = 11
+	OperatorEqual                Operator = 1
+	OperatorNotEqual             Operator = 2
+	OperatorGreaterThan          Operator = 3
+	OperatorGreaterThanEqual     Operator = 4
+	OperatorLessThan             Operator = 5
+	OperatorLessThanEqual        Operator = 6
+	OperatorAnd                  Operator = 7
+	OperatorOr                   Operator = 8
+	OperatorNot                  Operator = 9
+	OperatorWithinGeoRange       Operator = 10
+	OperatorLike                 Operator = 11
+	OperatorWithinGeoBoundingBox Operator = 12
+	OperatorWithinGeoPolygon     Operator = 13
 )
 
 func (o Operator) OnValue() bool {
@@ -43,6 +45,8 @@ func (o Operator) OnValue() bool {
 		OperatorLessThan,
 		OperatorLessThanEqual,
 		OperatorWithinGeoRange,
+		OperatorWithinGeoBoundingBox,
+		OperatorWithinGeoPolygon,
 		OperatorLike:
 		return true
 	default:
@@ -50,6 +54,19 @@ func (o Operator) OnValue() bool {
 	}
 }
 
+// OnGeo is true for the operators which are served by the geo index of a
+// geoCoordinates property, rather than by the inverted index
+func (o Operator) OnGeo() bool {
+	switch o {
+	case OperatorWithinGeoRange,
+		OperatorWithinGeoBoundingBox,
+		OperatorWithinGeoPolygon:
+		return true
+	default:
+		return false
+	}
+}
+
 func (o Operator) Name() string {
 	switch o {
 	case OperatorEqual:
@@ -74,6 +91,10 @@ func (o Operator) Name() string {
 		return "WithinGeoRange"
 	case OperatorLike:
 		return "Like"
+	case OperatorWithinGeoBoundingBox:
+		return "WithinGeoBoundingBox"
+	case OperatorWithinGeoPolygon:
+		return "WithinGeoPolygon"
 	default:
 		panic("Unknown operator")
 	}
@@ -122,3 +143,19 @@ type GeoRange struct {
 	*models.GeoCoordinates
 	Distance float32 `json:"distance"`
 }
+
+// GeoBoundingBox to be used with fields of type GeoCoordinates. Identifies a
+// rectangle by its top left (north west) and bottom right (south east)
+// corners. A box whose left longitude is larger than its right longitude
+// crosses the antimeridian.
+type GeoBoundingBox struct {
+	TopLeft     *models.GeoCoordinates `json:"topLeft"`
+	BottomRight *models.GeoCoordinates `json:"bottomRight"`
+}
+
+// GeoPolygon to be used with fields of type GeoCoordinates. Identifies an
+// area by the vertices of its outline. The polygon is closed implicitly, i.e.
+// the last vertex is connected to the first one.
+type GeoPolygon struct {
+	Vertices []*models.GeoCoordinates `json:"vertices"`
+}
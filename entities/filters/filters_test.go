@@ -22,6 +22,7 @@ func TestOperators(t *testing.T) {
 		op              Operator
 		expectedName    string
 		expectedOnValue bool
+		expectedOnGeo   bool
 	}
 
 	tests := []test{
@@ -31,7 +32,9 @@ func TestOperators(t *testing.T) {
 		test{op: OperatorGreaterThanEqual, expectedName: "GreaterThanEqual", expectedOnValue: true},
 		test{op: OperatorLessThanEqual, expectedName: "LessThanEqual", expectedOnValue: true},
 		test{op: OperatorLessThan, expectedName: "LessThan", expectedOnValue: true},
-		test{op: OperatorWithinGeoRange, expectedName: "WithinGeoRange", expectedOnValue: true},
+		test{op: OperatorWithinGeoRange, expectedName: "WithinGeoRange", expectedOnValue: true, expectedOnGeo: true},
+		test{op: OperatorWithinGeoBoundingBox, expectedName: "WithinGeoBoundingBox", expectedOnValue: true, expectedOnGeo: true},
+		test{op: OperatorWithinGeoPolygon, expectedName: "WithinGeoPolygon", expectedOnValue: true, expectedOnGeo: true},
 		test{op: OperatorLike, expectedName: "Like", expectedOnValue: true},
 		test{op: OperatorAnd, expectedName: "And", expectedOnValue: false},
 		test{op: OperatorOr, expectedName: "Or", expectedOnValue: false},
@@ -42,6 +45,7 @@ func TestOperators(t *testing.T) {
 		t.Run(test.expectedName, func(t *testing.T) {
 			assert.Equal(t, test.expectedName, test.op.Name(), "name must match")
 			assert.Equal(t, test.expectedOnValue, test.op.OnValue(), "onValue must match")
+			assert.Equal(t, test.expectedOnGeo, test.op.OnGeo(), "onGeo must match")
 		})
 	}
 }
@@ -0,0 +1,22 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package filters
+
+import "github.com/semi-technologies/weaviate/entities/models"
+
+// GeoSort orders the results of a search by their distance to a point,
+// nearest first. The distance is measured on the geoCoordinates property
+// with the given name.
+type GeoSort struct {
+	Property       string                 `json:"property"`
+	GeoCoordinates *models.GeoCoordinates `json:"geoCoordinates"`
+}
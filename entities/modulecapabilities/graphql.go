@@ -29,6 +29,12 @@ type NearParam interface {
 	GetCertainty() float64
 }
 
+// TargetVectorParam defines params which can search a named vector of a
+// class rather than the class-level vector
+type TargetVectorParam interface {
+	GetTargetVector() string
+}
+
 // ValidateFn validates a given module param
 type ValidateFn = func(param interface{}) error
 
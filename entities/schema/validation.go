@@ -49,6 +49,18 @@ func ValidatePropertyName(name string) (PropertyName, error) {
 		"which must be “/[_A-Za-z][_0-9A-Za-z]*/”.", name)
 }
 
+// ValidateTargetVectorName validates the name of a named vector. The names
+// are used as GraphQL argument values and as part of file names, so the same
+// restrictions as for property names apply.
+func ValidateTargetVectorName(name string) error {
+	if validatePropertyNameRegex.MatchString(name) {
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid vector name. "+
+		"Vector names in Weaviate are restricted to valid GraphQL names, "+
+		"which must be “/[_A-Za-z][_0-9A-Za-z]*/”.", name)
+}
+
 // ValidateReservedPropertyName validates that a string is not a reserved property name
 func ValidateReservedPropertyName(name string) error {
 	for i := range reservedPropertyNames {
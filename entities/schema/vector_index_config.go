@@ -11,6 +11,44 @@
 
 package schema
 
+import "github.com/semi-technologies/weaviate/entities/models"
+
 type VectorIndexConfig interface {
 	IndexType() string
+	DistanceName() string
+}
+
+// NamedVectorIndexConfigs returns the vector index configs of all named vectors
+// of the class, keyed by the name of the vector. Configs which have not been
+// parsed yet are skipped.
+func NamedVectorIndexConfigs(class *models.Class) map[string]VectorIndexConfig {
+	if len(class.VectorConfig) == 0 {
+		return nil
+	}
+
+	out := make(map[string]VectorIndexConfig, len(class.VectorConfig))
+	for name, cfg := range class.VectorConfig {
+		if parsed, ok := cfg.VectorIndexConfig.(VectorIndexConfig); ok {
+			out[name] = parsed
+		}
+	}
+
+	return out
+}
+
+// NamedVectorizer returns the name of the vectorizer module of a named vector
+// as well as the module-specific settings. The vectorizer is either the string
+// "none" or an object with the module name as its only key.
+func NamedVectorizer(cfg models.VectorConfig) (string, map[string]interface{}) {
+	switch typed := cfg.Vectorizer.(type) {
+	case string:
+		return typed, nil
+	case map[string]interface{}:
+		for module, settings := range typed {
+			asMap, _ := settings.(map[string]interface{})
+			return module, asMap
+		}
+	}
+
+	return "", nil
 }
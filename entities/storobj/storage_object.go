@@ -17,6 +17,7 @@ import (
 	"encoding/json"
 	"io"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/go-openapi/strfmt"
@@ -32,7 +33,10 @@ type Object struct {
 	MarshallerVersion uint8
 	Object            models.Object `json:"object"`
 	Vector            []float32     `json:"vector"`
-	docID             uint64
+	// Vectors holds the optional named vectors, one per vector space defined
+	// in the vectorConfig of the class
+	Vectors map[string][]float32 `json:"vectors"`
+	docID   uint64
 }
 
 func New(docID uint64) *Object {
@@ -46,10 +50,35 @@ func FromObject(object *models.Object, vector []float32) *Object {
 	return &Object{
 		Object:            *object,
 		Vector:            vector,
+		Vectors:           vectorsFromModel(object.Vectors),
 		MarshallerVersion: 1,
 	}
 }
 
+func vectorsFromModel(in models.Vectors) map[string][]float32 {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make(map[string][]float32, len(in))
+	for name, vector := range in {
+		out[name] = vector
+	}
+	return out
+}
+
+func vectorsToModel(in map[string][]float32) models.Vectors {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make(models.Vectors, len(in))
+	for name, vector := range in {
+		out[name] = vector
+	}
+	return out
+}
+
 func FromBinary(data []byte) (*Object, error) {
 	ko := &Object{}
 	if err := ko.UnmarshalBinary(data); err != nil {
@@ -125,6 +154,11 @@ func FromBinaryOptional(data []byte,
 	_, err = r.Read(vectorWeights)
 	ec.add(err, "vector weights")
 
+	if addProp.Vector {
+		ko.Vectors, err = readNamedVectors(r)
+		ec.add(err, "named vectors")
+	}
+
 	if err := ec.toError(); err != nil {
 		return nil, errors.Wrap(err, "compound err")
 	}
@@ -255,6 +289,7 @@ func (ko *Object) SearchResult(additional additional.Properties) *search.Result
 		ClassName: ko.Class().String(),
 		Schema:    ko.Properties(),
 		Vector:    ko.Vector,
+		Vectors:   vectorsToModel(ko.Vectors),
 		// VectorWeights: ko.VectorWeights(), // TODO: add vector weights
 		Created:              ko.CreationTimeUnix(),
 		Updated:              ko.LastUpdateTimeUnix(),
@@ -331,6 +366,16 @@ func DocIDFromBinary(in []byte) (uint64, error) {
 // n          | []byte    | meta as json
 // 2          | uint32    | length of vectorweights json
 // n          | []byte    | vectorweights as json
+// 4          | uint32    | length of named vectors section, optional
+// n          | []byte    | named vectors, see marshalNamedVectors
+//
+// The named vectors section was added without a version bump. Objects
+// written before simply end after the vector weights, which is read as an
+// object without named vectors.
+//
+// Shards keep the vectors in vector buckets of their own and store objects
+// with a vector length of 0 and without named vectors. Objects written
+// before that still contain their vectors, but shards no longer read them.
 func (ko *Object) MarshalBinary() ([]byte, error) {
 	if ko.MarshallerVersion != 1 {
 		return nil, errors.Errorf("unsupported marshaller version %d", ko.MarshallerVersion)
@@ -366,6 +411,10 @@ func (ko *Object) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 	vectorWeightsLength := uint32(len(vectorWeights))
+	namedVectors, err := marshalNamedVectors(ko.Vectors)
+	if err != nil {
+		return nil, err
+	}
 
 	ec := &errorCompounder{}
 	buf := bytes.NewBuffer(nil)
@@ -391,6 +440,11 @@ func (ko *Object) MarshalBinary() ([]byte, error) {
 	ec.add(binary.Write(buf, le, vectorWeightsLength))
 	_, err = buf.Write(vectorWeights)
 	ec.add(err)
+	if len(namedVectors) > 0 {
+		ec.add(binary.Write(buf, le, uint32(len(namedVectors))))
+		_, err = buf.Write(namedVectors)
+		ec.add(err)
+	}
 
 	return buf.Bytes(), ec.toError()
 }
@@ -449,6 +503,8 @@ func (ko *Object) UnmarshalBinary(data []byte) error {
 	vectorWeights := make([]byte, vectorWeightsLength)
 	_, err = r.Read(vectorWeights)
 	ec.add(err)
+	ko.Vectors, err = readNamedVectors(r)
+	ec.add(err)
 
 	if err := ec.toError(); err != nil {
 		return err
@@ -500,6 +556,133 @@ func VectorFromBinary(in []byte) ([]float32, error) {
 	return out, nil
 }
 
+// NamedVectorFromBinary extracts a single named vector without unmarshalling
+// the remaining object. It returns nil if the object has no vector with that
+// name.
+func NamedVectorFromBinary(in []byte, name string) ([]float32, error) {
+	vectors, err := NamedVectorsFromBinary(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return vectors[name], nil
+}
+
+// NamedVectorsFromBinary extracts all named vectors without unmarshalling the
+// remaining object
+func NamedVectorsFromBinary(in []byte) (map[string][]float32, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	version := in[0]
+	if version != 1 {
+		return nil, errors.Errorf("unsupported marshaller version %d", version)
+	}
+
+	le := binary.LittleEndian
+
+	// skip over all the fields of the base object, see MarshalBinary for the
+	// layout
+	pos := 42
+	pos += 2 + int(le.Uint16(in[pos:pos+2]))*4 // vector
+	pos += 2 + int(le.Uint16(in[pos:pos+2]))   // class name
+	pos += 4 + int(le.Uint32(in[pos:pos+4]))   // schema
+	pos += 4 + int(le.Uint32(in[pos:pos+4]))   // meta
+	pos += 4 + int(le.Uint32(in[pos:pos+4]))   // vector weights
+
+	return readNamedVectors(bytes.NewReader(in[pos:]))
+}
+
+// marshalNamedVectors creates the named vectors section. The names are
+// sorted, so that the output is deterministic.
+//
+// No. of B   | Type      | Content
+// ------------------------------------------------
+// 2          | uint16    | number of named vectors
+// for each named vector:
+// 2          | uint16    | length of name
+// n          | []byte    | name
+// 2          | uint16    | vector length
+// n*4        | []float32 | vector of length n
+func marshalNamedVectors(vectors map[string][]float32) ([]byte, error) {
+	if len(vectors) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(vectors))
+	for name := range vectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ec := &errorCompounder{}
+	buf := bytes.NewBuffer(nil)
+	le := binary.LittleEndian
+	ec.add(binary.Write(buf, le, uint16(len(names))))
+	for _, name := range names {
+		ec.add(binary.Write(buf, le, uint16(len(name))))
+		_, err := buf.WriteString(name)
+		ec.add(err)
+		ec.add(binary.Write(buf, le, uint16(len(vectors[name]))))
+		ec.add(binary.Write(buf, le, vectors[name]))
+	}
+
+	return buf.Bytes(), ec.toError()
+}
+
+// readNamedVectors reads the optional named vectors section from the current
+// position of the reader, if the reader is exhausted there are no named
+// vectors
+func readNamedVectors(r *bytes.Reader) (map[string][]float32, error) {
+	if r.Len() == 0 {
+		return nil, nil
+	}
+
+	le := binary.LittleEndian
+	var sectionLength uint32
+	if err := binary.Read(r, le, &sectionLength); err != nil {
+		return nil, errors.Wrap(err, "named vectors length")
+	}
+
+	if int(sectionLength) > r.Len() {
+		return nil, errors.Errorf("named vectors length %d exceeds remaining %d bytes",
+			sectionLength, r.Len())
+	}
+
+	var count uint16
+	if err := binary.Read(r, le, &count); err != nil {
+		return nil, errors.Wrap(err, "number of named vectors")
+	}
+
+	out := make(map[string][]float32, count)
+	for i := 0; i < int(count); i++ {
+		var nameLength uint16
+		if err := binary.Read(r, le, &nameLength); err != nil {
+			return nil, errors.Wrap(err, "name length")
+		}
+
+		name := make([]byte, nameLength)
+		if _, err := io.ReadFull(r, name); err != nil {
+			return nil, errors.Wrap(err, "name")
+		}
+
+		var vectorLength uint16
+		if err := binary.Read(r, le, &vectorLength); err != nil {
+			return nil, errors.Wrapf(err, "length of vector %q", name)
+		}
+
+		vector := make([]float32, vectorLength)
+		if err := binary.Read(r, le, &vector); err != nil {
+			return nil, errors.Wrapf(err, "vector %q", name)
+		}
+
+		out[string(name)] = vector
+	}
+
+	return out, nil
+}
+
 func (ko *Object) parseObject(uuid strfmt.UUID, create, update int64, className string,
 	schemaB []byte, additionalB []byte, vectorWeightsB []byte) error {
 	var schema map[string]interface{}
@@ -563,9 +746,22 @@ func (ko *Object) DeepCopyDangerous() *Object {
 		docID:             ko.docID,
 		Object:            deepCopyObject(ko.Object),
 		Vector:            deepCopyVector(ko.Vector),
+		Vectors:           deepCopyVectors(ko.Vectors),
 	}
 }
 
+func deepCopyVectors(orig map[string][]float32) map[string][]float32 {
+	if orig == nil {
+		return nil
+	}
+
+	out := make(map[string][]float32, len(orig))
+	for name, vector := range orig {
+		out[name] = deepCopyVector(vector)
+	}
+	return out
+}
+
 func deepCopyVector(orig []float32) []float32 {
 	out := make([]float32, len(orig))
 	copy(out, orig)
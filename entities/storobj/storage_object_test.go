@@ -78,6 +78,89 @@ func TestStorageObjectMarshalling(t *testing.T) {
 	})
 }
 
+func TestStorageObjectMarshallingNamedVectors(t *testing.T) {
+	before := FromObject(
+		&models.Object{
+			Class:              "MyFavoriteClass",
+			CreationTimeUnix:   123456,
+			LastUpdateTimeUnix: 56789,
+			ID:                 strfmt.UUID("73f2eb5f-5abf-447a-81ca-74b1dd168247"),
+			Properties: map[string]interface{}{
+				"name": "MyName",
+			},
+			Vectors: models.Vectors{
+				"title": []float32{1, 2, 3},
+				"image": []float32{4, 5},
+			},
+		},
+		[]float32{1, 2, 0.7},
+	)
+	before.SetDocID(7)
+
+	asBinary, err := before.MarshalBinary()
+	require.Nil(t, err)
+
+	t.Run("full unmarshalling", func(t *testing.T) {
+		after, err := FromBinary(asBinary)
+		require.Nil(t, err)
+
+		assert.Equal(t, before.Vector, after.Vector)
+		assert.Equal(t, before.Vectors, after.Vectors)
+		assert.Equal(t, before.Properties(), after.Properties())
+	})
+
+	t.Run("optional unmarshalling with vectors", func(t *testing.T) {
+		after, err := FromBinaryOptional(asBinary,
+			additional.Properties{Vector: true})
+		require.Nil(t, err)
+
+		assert.Equal(t, before.Vectors, after.Vectors)
+		assert.Equal(t, models.Vectors{
+			"title": []float32{1, 2, 3},
+			"image": []float32{4, 5},
+		}, after.SearchResult(additional.Properties{}).Vectors)
+	})
+
+	t.Run("optional unmarshalling without vectors", func(t *testing.T) {
+		after, err := FromBinaryOptional(asBinary, additional.Properties{})
+		require.Nil(t, err)
+
+		assert.Nil(t, after.Vectors)
+	})
+
+	t.Run("extract a single named vector", func(t *testing.T) {
+		vec, err := NamedVectorFromBinary(asBinary, "image")
+		require.Nil(t, err)
+		assert.Equal(t, []float32{4, 5}, vec)
+
+		vec, err = NamedVectorFromBinary(asBinary, "unknown")
+		require.Nil(t, err)
+		assert.Nil(t, vec)
+
+		vec, err = VectorFromBinary(asBinary)
+		require.Nil(t, err)
+		assert.Equal(t, []float32{1, 2, 0.7}, vec)
+	})
+
+	t.Run("objects without named vectors are unchanged", func(t *testing.T) {
+		withoutNamed := FromObject(&models.Object{
+			Class: "MyFavoriteClass",
+			ID:    strfmt.UUID("73f2eb5f-5abf-447a-81ca-74b1dd168247"),
+		}, []float32{1, 2})
+
+		asBinary, err := withoutNamed.MarshalBinary()
+		require.Nil(t, err)
+
+		after, err := FromBinary(asBinary)
+		require.Nil(t, err)
+		assert.Nil(t, after.Vectors)
+
+		vec, err := NamedVectorFromBinary(asBinary, "title")
+		require.Nil(t, err)
+		assert.Nil(t, vec)
+	})
+}
+
 func TestStorageObjectUnmarshallingSpecificProps(t *testing.T) {
 	before := FromObject(
 		&models.Object{
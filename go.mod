@@ -1,6 +1,7 @@
 module github.com/semi-technologies/weaviate
 
 require (
+	github.com/RoaringBitmap/roaring v1.2.3
 	github.com/asaskevich/govalidator v0.0.0-20210307081110-f21760c49a8d // indirect
 	github.com/bmatcuk/doublestar v1.1.3
 	github.com/buger/jsonparser v1.1.1
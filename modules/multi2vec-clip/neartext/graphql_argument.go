@@ -28,11 +28,20 @@ func (g *GraphQLArgumentsProvider) exploreNearTextArgumentFn() *graphql.Argument
 
 func (g *GraphQLArgumentsProvider) nearTextArgument(prefix, className string) *graphql.ArgumentConfig {
 	prefixName := fmt.Sprintf("Txt2VecC11y%s%s", prefix, className)
+	fields := g.nearTextFields(prefixName)
+	if className != "" {
+		// named vectors are specific to a class, so they can only be targeted
+		// in Get, but not in Explore
+		fields["targetVector"] = &graphql.InputObjectFieldConfig{
+			Description: descriptions.TargetVector,
+			Type:        graphql.String,
+		}
+	}
 	return &graphql.ArgumentConfig{
 		Type: graphql.NewInputObject(
 			graphql.InputObjectConfig{
 				Name:        fmt.Sprintf("%sNearTextInpObj", prefixName),
-				Fields:      g.nearTextFields(prefixName),
+				Fields:      fields,
 				Description: descriptions.GetWhereInpObj,
 			},
 		),
@@ -47,14 +47,16 @@ func TestNearTextGraphQLArgument(t *testing.T) {
 		//          ],
 		//          force: 0.8
 		//   }
+		//   targetVector: "title"
 		// }
 		assert.NotNil(t, nearText)
 		assert.Equal(t, "Txt2VecC11yPrefixClassNearTextInpObj", nearText.Type.Name())
 		nearTextFields, ok := nearText.Type.(*graphql.InputObject)
 		assert.True(t, ok)
 		assert.NotNil(t, nearTextFields)
-		assert.Equal(t, 4, len(nearTextFields.Fields()))
+		assert.Equal(t, 5, len(nearTextFields.Fields()))
 		fields := nearTextFields.Fields()
+		assert.NotNil(t, fields["targetVector"])
 		concepts := fields["concepts"]
 		conceptsNonNull, conceptsNonNullOK := concepts.Type.(*graphql.NonNull)
 		assert.True(t, conceptsNonNullOK)
@@ -134,13 +136,14 @@ func TestNearTextGraphQLArgumentWithAutocorrect(t *testing.T) {
 		//          ],
 		//          force: 0.8
 		//   }
+		//   targetVector: "title"
 		// }
 		assert.NotNil(t, nearText)
 		assert.Equal(t, "Txt2VecC11yPrefixClassNearTextInpObj", nearText.Type.Name())
 		nearTextFields, ok := nearText.Type.(*graphql.InputObject)
 		assert.True(t, ok)
 		assert.NotNil(t, nearTextFields)
-		assert.Equal(t, 5, len(nearTextFields.Fields()))
+		assert.Equal(t, 6, len(nearTextFields.Fields()))
 		fields := nearTextFields.Fields()
 		concepts := fields["concepts"]
 		conceptsNonNull, conceptsNonNullOK := concepts.Type.(*graphql.NonNull)
@@ -23,12 +23,17 @@ type NearTextParams struct {
 	Certainty    float64
 	Network      bool
 	Autocorrect  bool
+	TargetVector string
 }
 
 func (n NearTextParams) GetCertainty() float64 {
 	return n.Certainty
 }
 
+func (n NearTextParams) GetTargetVector() string {
+	return n.TargetVector
+}
+
 // ExploreMove moves an existing Search Vector closer (or further away from) a specific other search term
 type ExploreMove struct {
 	Values  []string
@@ -50,6 +50,12 @@ func (g *GraphQLArgumentsProvider) extractNearTextFn(source map[string]interface
 		args.Certainty = certainty.(float64)
 	}
 
+	// targetVector is an optional arg, so it could be nil
+	targetVector, ok := source["targetVector"]
+	if ok {
+		args.TargetVector = targetVector.(string)
+	}
+
 	// moveTo is an optional arg, so it could be nil
 	moveTo, ok := source["moveTo"]
 	if ok {
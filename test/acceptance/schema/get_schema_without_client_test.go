@@ -45,6 +45,15 @@ func testGetSchemaWithoutClient(t *testing.T) {
 					"ef":                     float64(-1),
 					"maxConnections":         float64(64),
 					"vectorCacheMaxObjects":  float64(2e6),
+					"distance":               "cosine",
+					"filterStrategy":         "acorn",
+					"acornFlatSearchCutoff":  float64(0),
+					"pq": map[string]interface{}{
+						"enabled":       false,
+						"segments":      float64(0),
+						"centroids":     float64(256),
+						"trainingLimit": float64(100000),
+					},
 				},
 				"shardingConfig": map[string]interface{}{
 					"actualCount":         float64(1),
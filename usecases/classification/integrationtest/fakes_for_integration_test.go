@@ -333,11 +333,21 @@ func (f *fakeRemoteClient) Exists(ctx context.Context, hostName, indexName,
 }
 
 func (f *fakeRemoteClient) SearchShard(ctx context.Context, hostName, indexName,
-	shardName string, vector []float32, limit int, filters *filters.LocalFilter,
+	shardName string, vector []float32, targetVector string,
+	waitForIndexing bool, limit int,
+	filters *filters.LocalFilter, geoSort *filters.GeoSort,
 	additional additional.Properties) ([]*storobj.Object, []float32, error) {
 	return nil, nil, nil
 }
 
+func (f *fakeRemoteClient) BatchSearchShard(ctx context.Context, hostName, indexName,
+	shardName string, vectors [][]float32, targetVector string,
+	waitForIndexing bool, limit int,
+	filters *filters.LocalFilter,
+	additional additional.Properties) ([][]*storobj.Object, [][]float32, error) {
+	return nil, nil, nil
+}
+
 func (f *fakeRemoteClient) BatchPutObjects(ctx context.Context, hostName, indexName,
 	shardName string, obj []*storobj.Object) []error {
 	return nil
@@ -358,6 +368,11 @@ func (f *fakeRemoteClient) Aggregate(ctx context.Context, hostName, indexName,
 	return nil, nil
 }
 
+func (f *fakeRemoteClient) ShardStorageStatistics(ctx context.Context,
+	hostName, indexName, shardName string) (*models.ShardStorageStatistics, error) {
+	return nil, nil
+}
+
 type fakeNodeResolver struct{}
 
 func (f *fakeNodeResolver) NodeHostname(string) (string, bool) {
@@ -108,6 +108,39 @@ type Contextionary struct {
 
 type Persistence struct {
 	DataPath string `json:"dataPath" yaml:"dataPath"`
+
+	// VectorCacheMaxBytes limits the memory used by the vector caches of all
+	// classes and shards on this node combined. 0 means unlimited.
+	VectorCacheMaxBytes int64 `json:"vectorCacheMaxBytes" yaml:"vectorCacheMaxBytes"`
+
+	// AsyncVectorIndexing acknowledges writes before their vectors are part of
+	// the vector index. Vectors are indexed from a persisted per-shard queue
+	// in the background.
+	AsyncVectorIndexing bool `json:"asyncVectorIndexing" yaml:"asyncVectorIndexing"`
+
+	// CompressObjectSegments stores the disk segments of the objects buckets
+	// block-compressed. Existing segments are converted as they are compacted.
+	CompressObjectSegments bool `json:"compressObjectSegments" yaml:"compressObjectSegments"`
+
+	// LSMCompactionPolicy selects how the disk segments of all buckets are
+	// compacted, either "samelevel" (default) or "sizetiered"
+	LSMCompactionPolicy string `json:"lsmCompactionPolicy" yaml:"lsmCompactionPolicy"`
+
+	// LSMCompactionMaxBytesPerSecond limits the disk writes of all compactions
+	// on this node combined. 0 means unlimited.
+	LSMCompactionMaxBytesPerSecond int64 `json:"lsmCompactionMaxBytesPerSecond" yaml:"lsmCompactionMaxBytesPerSecond"`
+
+	// WALSyncMode decides when the lsmkv write-ahead-logs and the hnsw commit
+	// logs are fsynced: "none" (default) leaves it to the operating system, an
+	// acknowledged write survives a crash of the process, but not necessarily
+	// a power loss. "interval" fsyncs every WALSyncIntervalMilliseconds,
+	// "every-write" before a write is acknowledged, concurrent writes share
+	// an fsync.
+	WALSyncMode string `json:"walSyncMode" yaml:"walSyncMode"`
+
+	// WALSyncIntervalMilliseconds is the fsync interval of the "interval"
+	// WALSyncMode. 0 selects the default of one second.
+	WALSyncIntervalMilliseconds int64 `json:"walSyncIntervalMilliseconds" yaml:"walSyncIntervalMilliseconds"`
 }
 
 func (p Persistence) Validate() error {
@@ -115,6 +148,28 @@ func (p Persistence) Validate() error {
 		return fmt.Errorf("persistence.dataPath must be set")
 	}
 
+	switch p.LSMCompactionPolicy {
+	case "", "samelevel", "sizetiered":
+	default:
+		return fmt.Errorf("persistence.lsmCompactionPolicy must be one of "+
+			"\"samelevel\" or \"sizetiered\", got %q", p.LSMCompactionPolicy)
+	}
+
+	if p.LSMCompactionMaxBytesPerSecond < 0 {
+		return fmt.Errorf("persistence.lsmCompactionMaxBytesPerSecond must not be negative")
+	}
+
+	switch p.WALSyncMode {
+	case "", "none", "interval", "every-write":
+	default:
+		return fmt.Errorf("persistence.walSyncMode must be one of "+
+			"\"none\", \"interval\" or \"every-write\", got %q", p.WALSyncMode)
+	}
+
+	if p.WALSyncIntervalMilliseconds < 0 {
+		return fmt.Errorf("persistence.walSyncIntervalMilliseconds must not be negative")
+	}
+
 	return nil
 }
 
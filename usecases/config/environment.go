@@ -87,6 +87,59 @@ func FromEnv(config *Config) error {
 		config.Persistence.DataPath = v
 	}
 
+	if v := os.Getenv("PERSISTENCE_VECTOR_CACHE_MAX_BYTES"); v != "" {
+		asInt, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parse PERSISTENCE_VECTOR_CACHE_MAX_BYTES as int")
+		}
+
+		config.Persistence.VectorCacheMaxBytes = asInt
+	}
+
+	if v := os.Getenv("PERSISTENCE_ASYNC_VECTOR_INDEXING"); v != "" {
+		asBool, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Wrapf(err, "parse PERSISTENCE_ASYNC_VECTOR_INDEXING as bool")
+		}
+
+		config.Persistence.AsyncVectorIndexing = asBool
+	}
+
+	if v := os.Getenv("PERSISTENCE_COMPRESS_OBJECT_SEGMENTS"); v != "" {
+		asBool, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Wrapf(err, "parse PERSISTENCE_COMPRESS_OBJECT_SEGMENTS as bool")
+		}
+
+		config.Persistence.CompressObjectSegments = asBool
+	}
+
+	if v := os.Getenv("PERSISTENCE_LSM_COMPACTION_POLICY"); v != "" {
+		config.Persistence.LSMCompactionPolicy = v
+	}
+
+	if v := os.Getenv("PERSISTENCE_LSM_COMPACTION_MAX_BYTES_PER_SECOND"); v != "" {
+		asInt, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parse PERSISTENCE_LSM_COMPACTION_MAX_BYTES_PER_SECOND as int")
+		}
+
+		config.Persistence.LSMCompactionMaxBytesPerSecond = asInt
+	}
+
+	if v := os.Getenv("PERSISTENCE_WAL_SYNC_MODE"); v != "" {
+		config.Persistence.WALSyncMode = v
+	}
+
+	if v := os.Getenv("PERSISTENCE_WAL_SYNC_INTERVAL_MILLISECONDS"); v != "" {
+		asInt, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parse PERSISTENCE_WAL_SYNC_INTERVAL_MILLISECONDS as int")
+		}
+
+		config.Persistence.WALSyncIntervalMilliseconds = asInt
+	}
+
 	if v := os.Getenv("ORIGIN"); v != "" {
 		config.Origin = v
 	}
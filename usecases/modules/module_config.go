@@ -17,8 +17,9 @@ import (
 )
 
 type ClassBasedModuleConfig struct {
-	class      *models.Class
-	moduleName string
+	class        *models.Class
+	moduleName   string
+	targetVector string
 }
 
 func NewClassBasedModuleConfig(class *models.Class,
@@ -29,8 +30,30 @@ func NewClassBasedModuleConfig(class *models.Class,
 	}
 }
 
+// NewTargetVectorModuleConfig creates a module config for a named vector of
+// the class. The class-level settings are read from the vectorizer of the
+// named vector rather than the moduleConfig of the class.
+func NewTargetVectorModuleConfig(class *models.Class, moduleName,
+	targetVector string) *ClassBasedModuleConfig {
+	return &ClassBasedModuleConfig{
+		class:        class,
+		moduleName:   moduleName,
+		targetVector: targetVector,
+	}
+}
+
 func (cbmc *ClassBasedModuleConfig) Class() map[string]interface{} {
 	defaultConf := map[string]interface{}{}
+	if cbmc.targetVector != "" {
+		moduleName, settings := schema.NamedVectorizer(
+			cbmc.class.VectorConfig[cbmc.targetVector])
+		if moduleName != cbmc.moduleName || settings == nil {
+			return defaultConf
+		}
+
+		return settings
+	}
+
 	asMap, ok := cbmc.class.ModuleConfig.(map[string]interface{})
 	if !ok {
 		return defaultConf
@@ -21,6 +21,8 @@ import (
 )
 
 func (p *Provider) SetClassDefaults(class *models.Class) {
+	p.setNamedVectorDefaults(class)
+
 	if class.Vectorizer == "none" {
 		// the class does not use a vectorizer, nothing to do for us
 		return
@@ -36,7 +38,39 @@ func (p *Provider) SetClassDefaults(class *models.Class) {
 	cfg := NewClassBasedModuleConfig(class, class.Vectorizer)
 
 	p.setPerClassConfigDefaults(class, cfg, cc)
-	p.setPerPropertyConfigDefaults(class, cfg, cc)
+	p.setPerPropertyConfigDefaults(class, class.Vectorizer, cfg, cc)
+}
+
+// setNamedVectorDefaults merges the module defaults into the vectorizer
+// settings of each named vector. Property-level defaults are stored in the
+// moduleConfig of the properties, just like for the class-level vectorizer.
+func (p *Provider) setNamedVectorDefaults(class *models.Class) {
+	for name, vectorConfig := range class.VectorConfig {
+		moduleName, userSpecified := schema.NamedVectorizer(vectorConfig)
+		if moduleName == "" || moduleName == "none" {
+			continue
+		}
+
+		mod := p.GetByName(moduleName)
+		cc, ok := mod.(modulecapabilities.ClassConfigurator)
+		if !ok {
+			continue
+		}
+
+		mergedConfig := map[string]interface{}{}
+		for key, value := range cc.ClassConfigDefaults() {
+			mergedConfig[key] = value
+		}
+		for key, value := range userSpecified {
+			mergedConfig[key] = value
+		}
+
+		vectorConfig.Vectorizer = map[string]interface{}{moduleName: mergedConfig}
+		class.VectorConfig[name] = vectorConfig
+
+		cfg := NewTargetVectorModuleConfig(class, moduleName, name)
+		p.setPerPropertyConfigDefaults(class, moduleName, cfg, cc)
+	}
 }
 
 func (p *Provider) setPerClassConfigDefaults(class *models.Class,
@@ -60,7 +94,8 @@ func (p *Provider) setPerClassConfigDefaults(class *models.Class,
 }
 
 func (p *Provider) setPerPropertyConfigDefaults(class *models.Class,
-	cfg *ClassBasedModuleConfig, cc modulecapabilities.ClassConfigurator) {
+	moduleName string, cfg *ClassBasedModuleConfig,
+	cc modulecapabilities.ClassConfigurator) {
 	for _, prop := range class.Properties {
 		dt, _ := schema.GetPropertyDataType(class, prop.Name)
 		modDefaults := cc.PropertyConfigDefaults(dt)
@@ -78,11 +113,25 @@ func (p *Provider) setPerPropertyConfigDefaults(class *models.Class,
 			prop.ModuleConfig = map[string]interface{}{}
 		}
 
-		prop.ModuleConfig.(map[string]interface{})[class.Vectorizer] = mergedConfig
+		prop.ModuleConfig.(map[string]interface{})[moduleName] = mergedConfig
 	}
 }
 
 func (p *Provider) ValidateClass(ctx context.Context, class *models.Class) error {
+	for name, vectorConfig := range class.VectorConfig {
+		moduleName, _ := schema.NamedVectorizer(vectorConfig)
+		cc, ok := p.GetByName(moduleName).(modulecapabilities.ClassConfigurator)
+		if !ok {
+			// no vectorizer or not a class configurator, nothing to do for us
+			continue
+		}
+
+		cfg := NewTargetVectorModuleConfig(class, moduleName, name)
+		if err := cc.ValidateClass(ctx, class, cfg); err != nil {
+			return errors.Wrapf(err, "vector %q: module '%s'", name, moduleName)
+		}
+	}
+
 	if class.Vectorizer == "none" {
 		// the class does not use a vectorizer, nothing to do for us
 		return nil
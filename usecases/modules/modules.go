@@ -28,7 +28,7 @@ import (
 
 var (
 	internalSearchers            = []string{"nearObject", "nearVector", "where", "group", "limit"}
-	internalAdditionalProperties = []string{"classification", "certainty", "id"}
+	internalAdditionalProperties = []string{"classification", "certainty", "distance", "id"}
 )
 
 type Provider struct {
@@ -190,7 +190,17 @@ func (m *Provider) isDefaultModule(module string) bool {
 }
 
 func (m *Provider) shouldIncludeClassArgument(class *models.Class, module string) bool {
-	return class.Vectorizer == module || m.isDefaultModule(module)
+	if class.Vectorizer == module || m.isDefaultModule(module) {
+		return true
+	}
+
+	for _, vectorConfig := range class.VectorConfig {
+		if moduleName, _ := schema.NamedVectorizer(vectorConfig); moduleName == module {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (m *Provider) shouldCrossClassIncludeClassArgument(class *models.Class, module string) bool {
@@ -500,19 +510,32 @@ func (m *Provider) RestApiAdditionalProperties(includeProp string, class *models
 // VectorFromSearchParam gets a vector for a given argument. This is used in
 // Get { Class() } for example
 func (m *Provider) VectorFromSearchParam(ctx context.Context,
-	className string, param string, params interface{},
+	className, targetVector string, param string, params interface{},
 	findVectorFn modulecapabilities.FindVectorFn) ([]float32, error) {
 	class, err := m.getClass(className)
 	if err != nil {
 		return nil, err
 	}
 
+	targetModule := ""
+	if targetVector != "" {
+		targetModule, _ = schema.NamedVectorizer(class.VectorConfig[targetVector])
+	}
+
 	for _, mod := range m.GetAll() {
+		if targetVector != "" && mod.Name() != targetModule {
+			// the vector has to be produced by the vectorizer of the named vector
+			continue
+		}
+
 		if m.shouldIncludeClassArgument(class, mod.Name()) {
 			if searcher, ok := mod.(modulecapabilities.Searcher); ok {
 				if vectorSearches := searcher.VectorSearches(); vectorSearches != nil {
 					if searchVectorFn := vectorSearches[param]; searchVectorFn != nil {
 						cfg := NewClassBasedModuleConfig(class, mod.Name())
+						if targetVector != "" {
+							cfg = NewTargetVectorModuleConfig(class, mod.Name(), targetVector)
+						}
 						vector, err := searchVectorFn(ctx, params, findVectorFn, cfg)
 						if err != nil {
 							return nil, errors.Errorf("vectorize params: %v", err)
@@ -524,6 +547,11 @@ func (m *Provider) VectorFromSearchParam(ctx context.Context,
 		}
 	}
 
+	if targetVector != "" {
+		return nil, errors.Errorf("vectorizer %q of vector %q does not support "+
+			"the %s search", targetModule, targetVector, param)
+	}
+
 	panic("VectorFromParams was called without any known params present")
 }
 
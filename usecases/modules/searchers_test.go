@@ -66,7 +66,7 @@ func TestModulesWithSearchers(t *testing.T) {
 		)
 		p.Init(context.Background(), nil, logger)
 
-		res, err := p.VectorFromSearchParam(context.Background(), "MyClass",
+		res, err := p.VectorFromSearchParam(context.Background(), "MyClass", "",
 			"nearGrape", nil, fakeFindVector)
 
 		require.Nil(t, err)
@@ -103,6 +103,65 @@ func TestModulesWithSearchers(t *testing.T) {
 		require.Nil(t, err)
 		assert.Equal(t, []float32{1, 2, 3, 4}, res)
 	})
+
+	t.Run("get a vector for a named vector of a class", func(t *testing.T) {
+		sch := schema.Schema{
+			Objects: &models.Schema{
+				Classes: []*models.Class{
+					{
+						Class:      "MyClass",
+						Vectorizer: "mod",
+						VectorConfig: map[string]models.VectorConfig{
+							"title": {
+								Vectorizer: map[string]interface{}{
+									"othermod": map[string]interface{}{
+										"some-config": "title-config-value",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		p := NewProvider()
+		p.SetSchemaGetter(&fakeSchemaGetter{
+			schema: sch,
+		})
+		p.Register(newSearcherModule("mod").
+			withArg("nearGrape").
+			withSearcher("nearGrape", func(ctx context.Context, params interface{},
+				findVectorFn modulecapabilities.FindVectorFn,
+				cfg moduletools.ClassConfig) ([]float32, error) {
+				t.Fatal("the vectorizer of the class must not be used for a named vector")
+				return nil, nil
+			}),
+		)
+		p.Register(newSearcherModule("othermod").
+			withArg("nearPlum").
+			withSearcher("nearPlum", func(ctx context.Context, params interface{},
+				findVectorFn modulecapabilities.FindVectorFn,
+				cfg moduletools.ClassConfig) ([]float32, error) {
+				// the config must be read from the vectorizer of the named vector
+				require.NotNil(t, cfg)
+				assert.Equal(t, "title-config-value", cfg.Class()["some-config"])
+
+				initial, _ := findVectorFn(ctx, "123")
+				return append(initial, 5), nil
+			}),
+		)
+		require.Nil(t, p.Init(context.Background(), nil, logger))
+
+		res, err := p.VectorFromSearchParam(context.Background(), "MyClass",
+			"title", "nearPlum", nil, fakeFindVector)
+		require.Nil(t, err)
+		assert.Equal(t, []float32{1, 2, 3, 5}, res)
+
+		_, err = p.VectorFromSearchParam(context.Background(), "MyClass",
+			"title", "nearGrape", nil, fakeFindVector)
+		assert.NotNil(t, err)
+	})
 }
 
 func fakeFindVector(ctx context.Context, id strfmt.UUID) ([]float32, error) {
@@ -36,7 +36,10 @@ func (m *Provider) ValidateVectorizer(moduleName string) error {
 	return nil
 }
 
-func (m *Provider) Vectorizer(moduleName, className string) (objects.Vectorizer, error) {
+// Vectorizer returns the vectorizer for the class-level vector if targetVector
+// is empty and for the named vector with that name otherwise
+func (m *Provider) Vectorizer(moduleName, className,
+	targetVector string) (objects.Vectorizer, error) {
 	mod := m.GetByName(moduleName)
 	if mod == nil {
 		return nil, errors.Errorf("no module with name %q present", moduleName)
@@ -54,6 +57,11 @@ func (m *Provider) Vectorizer(moduleName, className string) (objects.Vectorizer,
 		return nil, errors.Errorf("class %q not found in schema", className)
 	}
 
+	if targetVector != "" {
+		return NewObjectsVectorizer(vec,
+			NewTargetVectorModuleConfig(class, moduleName, targetVector)), nil
+	}
+
 	cfg := NewClassBasedModuleConfig(class, moduleName)
 	return NewObjectsVectorizer(vec, cfg), nil
 }
@@ -26,7 +26,7 @@ import (
 func TestVectorizer(t *testing.T) {
 	t.Run("when there are no models registered", func(t *testing.T) {
 		p := NewProvider()
-		_, err := p.Vectorizer("some-module", "MyClass")
+		_, err := p.Vectorizer("some-module", "MyClass", "")
 		require.NotNil(t, err)
 		assert.Equal(t, "no module with name \"some-module\" present", err.Error())
 	})
@@ -34,7 +34,7 @@ func TestVectorizer(t *testing.T) {
 	t.Run("module exist, but doesn't provide vectorizer", func(t *testing.T) {
 		p := NewProvider()
 		p.Register(dummyModuleNoCapabilities{name: "some-module"})
-		_, err := p.Vectorizer("some-module", "MyClass")
+		_, err := p.Vectorizer("some-module", "MyClass", "")
 		require.NotNil(t, err)
 		assert.Equal(t, "module \"some-module\" exists, but does not provide the "+
 			"Vectorizer capability", err.Error())
@@ -43,7 +43,7 @@ func TestVectorizer(t *testing.T) {
 		p := NewProvider()
 		p.SetSchemaGetter(&fakeSchemaGetter{schema.Schema{}})
 		p.Register(dummyVectorizerModule{dummyModuleNoCapabilities{name: "some-module"}})
-		_, err := p.Vectorizer("some-module", "MyClass")
+		_, err := p.Vectorizer("some-module", "MyClass", "")
 		require.NotNil(t, err)
 		assert.Equal(t, "class \"MyClass\" not found in schema", err.Error())
 	})
@@ -61,7 +61,7 @@ func TestVectorizer(t *testing.T) {
 		}
 		p.SetSchemaGetter(&fakeSchemaGetter{sch})
 		p.Register(dummyVectorizerModule{dummyModuleNoCapabilities{name: "some-module"}})
-		vec, err := p.Vectorizer("some-module", "MyClass")
+		vec, err := p.Vectorizer("some-module", "MyClass", "")
 		require.Nil(t, err)
 
 		obj := &models.Object{Class: "Test"}
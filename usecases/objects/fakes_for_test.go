@@ -108,7 +108,8 @@ type fakeVectorizerProvider struct {
 	vectorizer *fakeVectorizer
 }
 
-func (f *fakeVectorizerProvider) Vectorizer(modName, className string) (Vectorizer, error) {
+func (f *fakeVectorizerProvider) Vectorizer(modName, className,
+	targetVector string) (Vectorizer, error) {
 	return f.vectorizer, nil
 }
 
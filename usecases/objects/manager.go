@@ -50,7 +50,7 @@ type timeSource interface {
 }
 
 type VectorizerProvider interface {
-	Vectorizer(moduleName, className string) (Vectorizer, error)
+	Vectorizer(moduleName, className, targetVector string) (Vectorizer, error)
 }
 
 type Vectorizer interface {
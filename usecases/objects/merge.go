@@ -29,6 +29,7 @@ type MergeDocument struct {
 	PrimitiveSchema      map[string]interface{}
 	References           BatchReferences
 	Vector               []float32
+	Vectors              models.Vectors
 	UpdateTime           int64
 	AdditionalProperties models.AdditionalProperties
 }
@@ -63,6 +64,7 @@ func (m *Manager) MergeObject(ctx context.Context, principal *models.Principal,
 		PrimitiveSchema: primitive,
 		References:      refs,
 		Vector:          objWithVec.Vector,
+		Vectors:         objWithVec.Vectors,
 		UpdateTime:      m.timeSource.Now(),
 	}
 
@@ -84,7 +86,8 @@ func (m *Manager) retrievePreviousAndValidateMergeObject(ctx context.Context, pr
 		return nil, fmt.Errorf("class is a required (and immutable) field")
 	}
 
-	object, err := m.vectorRepo.ObjectByID(ctx, id, nil, additional.Properties{})
+	object, err := m.vectorRepo.ObjectByID(ctx, id, nil,
+		additional.Properties{Vector: true})
 	if err != nil {
 		return nil, err
 	}
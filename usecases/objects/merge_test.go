@@ -270,14 +270,14 @@ func Test_MergeObject(t *testing.T) {
 			manager.timeSource = fakeTimeSource{}
 
 			if test.previous != nil {
-				vectorRepo.On("ObjectByID", test.id, search.SelectProperties(nil), additional.Properties{}).
+				vectorRepo.On("ObjectByID", test.id, search.SelectProperties(nil), additional.Properties{Vector: true}).
 					Return(&search.Result{
 						Schema:    test.previous.Properties,
 						ClassName: test.previous.Class,
 						Vector:    test.previous.Vector,
 					}, nil)
 			} else {
-				vectorRepo.On("ObjectByID", test.id, search.SelectProperties(nil), additional.Properties{}).
+				vectorRepo.On("ObjectByID", test.id, search.SelectProperties(nil), additional.Properties{Vector: true}).
 					Return((*search.Result)(nil), nil)
 			}
 
@@ -469,13 +469,13 @@ func Test_MergeThing(t *testing.T) {
 			manager.timeSource = fakeTimeSource{}
 
 			if test.previous != nil {
-				vectorRepo.On("ObjectByID", test.id, search.SelectProperties(nil), additional.Properties{}).
+				vectorRepo.On("ObjectByID", test.id, search.SelectProperties(nil), additional.Properties{Vector: true}).
 					Return(&search.Result{
 						Schema:    test.previous.Properties,
 						ClassName: test.previous.Class,
 					}, nil)
 			} else {
-				vectorRepo.On("ObjectByID", test.id, search.SelectProperties(nil), additional.Properties{}).
+				vectorRepo.On("ObjectByID", test.id, search.SelectProperties(nil), additional.Properties{Vector: true}).
 					Return((*search.Result)(nil), nil)
 			}
 
@@ -15,6 +15,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/flat"
 	"github.com/semi-technologies/weaviate/adapters/repos/db/vector/hnsw"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
@@ -41,23 +42,32 @@ func newVectorObtainer(vectorizerProvider VectorizerProvider,
 // *models.Object. (This method mutates its paremeter)
 func (vo *vectorObtainer) Do(ctx context.Context, obj *models.Object,
 	principal *models.Principal) error {
-	vectorizerName, cfg, err := vo.getVectorizerOfClass(obj.Class, principal)
+	class, err := vo.getClass(obj.Class, principal)
 	if err != nil {
 		return err
 	}
 
-	hnswConfig, ok := cfg.(hnsw.UserConfig)
-	if !ok {
-		return errors.Errorf("vector index config (%T) is not of type HNSW, "+
-			"but objects manager is restricted to HNSW", cfg)
+	if err := vo.obtainClassVector(ctx, obj, class); err != nil {
+		return err
+	}
+
+	return vo.obtainNamedVectors(ctx, obj, class)
+}
+
+func (vo *vectorObtainer) obtainClassVector(ctx context.Context,
+	obj *models.Object, class *models.Class) error {
+	vectorizerName := class.Vectorizer
+	skip, err := skipVectorIndex(class.VectorIndexConfig)
+	if err != nil {
+		return err
 	}
 
 	if vectorizerName == config.VectorizerModuleNone {
-		if err := vo.validateVectorPresent(obj, hnswConfig); err != nil {
+		if err := vo.validateVectorPresent(obj, skip); err != nil {
 			return NewErrInvalidUserInput("%v", err)
 		}
 	} else {
-		if hnswConfig.Skip {
+		if skip {
 			vo.logger.WithField("className", obj.Class).
 				WithField("vectorizer", vectorizerName).
 				Warningf("this class is configured to skip vector indexing, "+
@@ -67,7 +77,7 @@ func (vo *vectorObtainer) Do(ctx context.Context, obj *models.Object,
 					"setting is correct, make sure you set vectorizer to 'none' in the schema and "+
 					"provide a null-vector (i.e. no vector) at import time.", vectorizerName)
 		}
-		vectorizer, err := vo.vectorizerProvider.Vectorizer(vectorizerName, obj.Class)
+		vectorizer, err := vo.vectorizerProvider.Vectorizer(vectorizerName, obj.Class, "")
 		if err != nil {
 			return err
 		}
@@ -80,32 +90,109 @@ func (vo *vectorObtainer) Do(ctx context.Context, obj *models.Object,
 	return nil
 }
 
-func (vo *vectorObtainer) getVectorizerOfClass(className string,
-	principal *models.Principal) (string, interface{}, error) {
+// obtainNamedVectors sets every named vector of the class on the object.
+// Vectors of named vectors with the vectorizer "none" have to be provided by
+// the user, all others are produced by the configured module.
+func (vo *vectorObtainer) obtainNamedVectors(ctx context.Context,
+	obj *models.Object, class *models.Class) error {
+	for name := range obj.Vectors {
+		if _, ok := class.VectorConfig[name]; !ok {
+			return NewErrInvalidUserInput("class %s has no vector named %q",
+				class.Class, name)
+		}
+	}
+
+	for name, vectorConfig := range class.VectorConfig {
+		skip, err := skipVectorIndex(vectorConfig.VectorIndexConfig)
+		if err != nil {
+			return errors.Wrapf(err, "vector %q", name)
+		}
+
+		vectorizerName, _ := schema.NamedVectorizer(vectorConfig)
+		if vectorizerName == config.VectorizerModuleNone {
+			if !skip && len(obj.Vectors[name]) == 0 {
+				return NewErrInvalidUserInput("vector %q is configured to use "+
+					"vectorizer 'none' thus a vector must be present when importing, "+
+					"got: field 'vectors.%s' is empty or contains a zero-length vector",
+					name, name)
+			}
+			continue
+		}
+
+		vectorizer, err := vo.vectorizerProvider.Vectorizer(vectorizerName,
+			obj.Class, name)
+		if err != nil {
+			return err
+		}
+
+		if err := vo.vectorizeNamedVector(ctx, obj, name, vectorizer); err != nil {
+			return NewErrInternal("vector %q: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// vectorizeNamedVector runs the vectorizer with the named vector temporarily
+// set as the object's vector, as modules only ever read and write obj.Vector
+func (vo *vectorObtainer) vectorizeNamedVector(ctx context.Context,
+	obj *models.Object, name string, vectorizer Vectorizer) error {
+	classVector := obj.Vector
+	defer func() { obj.Vector = classVector }()
+
+	obj.Vector = models.C11yVector(obj.Vectors[name])
+	if err := vectorizer.UpdateObject(ctx, obj); err != nil {
+		return err
+	}
+
+	if obj.Vectors == nil {
+		obj.Vectors = models.Vectors{}
+	}
+	obj.Vectors[name] = obj.Vector
+
+	return nil
+}
+
+func (vo *vectorObtainer) getClass(className string,
+	principal *models.Principal) (*models.Class, error) {
 	s, err := vo.schemaManager.GetSchema(principal)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
 	class := s.FindClassByName(schema.ClassName(className))
 	if class == nil {
 		// this should be impossible by the time this method gets called, but let's
 		// be 100% certain
-		return "", nil, errors.Errorf("class %s not present", className)
+		return nil, errors.Errorf("class %s not present", className)
 	}
 
-	return class.Vectorizer, class.VectorIndexConfig, nil
+	return class, nil
+}
+
+// skipVectorIndex returns whether the class is configured to not index any
+// vectors, this is only possible with hnsw
+func skipVectorIndex(cfg interface{}) (bool, error) {
+	switch typed := cfg.(type) {
+	case hnsw.UserConfig:
+		return typed.Skip, nil
+	case flat.UserConfig:
+		return false, nil
+	default:
+		return false, errors.Errorf("vector index config (%T) is not of type "+
+			"HNSW or flat, but objects manager is restricted to those", cfg)
+	}
 }
 
 func (vo *vectorObtainer) validateVectorPresent(obj *models.Object,
-	hnswConfig hnsw.UserConfig) error {
-	if !hnswConfig.Skip && len(obj.Vector) == 0 {
+	skip bool) error {
+	if !skip && len(obj.Vector) == 0 {
 		return errors.Errorf("this class is configured to use vectorizer 'none' " +
 			"thus a vector must be present when importing, got: field 'vector' is empty " +
 			"or contains a zero-length vector")
 	}
 
-	if hnswConfig.Skip && len(obj.Vector) > 0 {
+	if skip && len(obj.Vector) > 0 {
 		vo.logger.WithField("className", obj.Class).
 			Warningf("this class is configured to skip vector indexing, " +
 				"but a vector was explicitly provided. " +
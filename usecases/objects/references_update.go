@@ -44,7 +44,8 @@ func (m *Manager) UpdateObjectReferences(ctx context.Context, principal *models.
 func (m *Manager) updateObjectReferenceToConnectorAndSchema(ctx context.Context, principal *models.Principal,
 	id strfmt.UUID, propertyName string, refs models.MultipleRef) error {
 	// get object to see if it exists
-	objectRes, err := m.getObjectFromRepo(ctx, id, additional.Properties{})
+	objectRes, err := m.getObjectFromRepo(ctx, id,
+		additional.Properties{Vector: true})
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/usecases/config"
 	"github.com/semi-technologies/weaviate/usecases/sharding"
 )
@@ -103,6 +104,18 @@ func (m *Manager) setClassDefaults(class *models.Class) {
 		class.VectorIndexType = "hnsw"
 	}
 
+	for name, vectorConfig := range class.VectorConfig {
+		if vectorConfig.VectorIndexType == "" {
+			vectorConfig.VectorIndexType = "hnsw"
+		}
+
+		if vectorConfig.Vectorizer == nil {
+			vectorConfig.Vectorizer = config.VectorizerModuleNone
+		}
+
+		class.VectorConfig[name] = vectorConfig
+	}
+
 	if class.InvertedIndexConfig == nil {
 		class.InvertedIndexConfig = &models.InvertedIndexConfig{}
 	}
@@ -174,22 +187,45 @@ func (m *Manager) validateCanAddClass(ctx context.Context, principal *models.Pri
 
 func (m *Manager) parseVectorIndexConfig(ctx context.Context,
 	class *models.Class) error {
-	if class.VectorIndexType != "hnsw" {
-		return errors.Errorf(
-			"parse vector index config: unsupported vector index type: %q",
-			class.VectorIndexType)
-	}
-
-	parsed, err := m.hnswConfigParser(class.VectorIndexConfig)
+	parsed, err := m.parseSingleVectorIndexConfig(class.VectorIndexType,
+		class.VectorIndexConfig)
 	if err != nil {
-		return errors.Wrap(err, "parse vector index config")
+		return err
 	}
 
 	class.VectorIndexConfig = parsed
 
+	for name, vectorConfig := range class.VectorConfig {
+		parsed, err := m.parseSingleVectorIndexConfig(vectorConfig.VectorIndexType,
+			vectorConfig.VectorIndexConfig)
+		if err != nil {
+			return errors.Wrapf(err, "vector %q", name)
+		}
+
+		vectorConfig.VectorIndexConfig = parsed
+		class.VectorConfig[name] = vectorConfig
+	}
+
 	return nil
 }
 
+func (m *Manager) parseSingleVectorIndexConfig(indexType string,
+	in interface{}) (schema.VectorIndexConfig, error) {
+	parse, ok := m.vectorConfigParsers[indexType]
+	if !ok {
+		return nil, errors.Errorf(
+			"parse vector index config: unsupported vector index type: %q",
+			indexType)
+	}
+
+	parsed, err := parse(in)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse vector index config")
+	}
+
+	return parsed, nil
+}
+
 func (m *Manager) parseShardingConfig(ctx context.Context,
 	class *models.Class) error {
 	parsed, err := sharding.ParseConfig(class.ShardingConfig,
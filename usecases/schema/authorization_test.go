@@ -48,6 +48,42 @@ func Test_Schema_Authorization(t *testing.T) {
 			expectedVerb:     "list",
 			expectedResource: "schema/*",
 		},
+		testCase{
+			methodName:       "GetShardsStatus",
+			additionalArgs:   []interface{}{"classname"},
+			expectedVerb:     "list",
+			expectedResource: "schema/*",
+		},
+		testCase{
+			methodName:       "InspectVectorIndex",
+			additionalArgs:   []interface{}{"classname", "shardname", ""},
+			expectedVerb:     "list",
+			expectedResource: "schema/*",
+		},
+		testCase{
+			methodName:       "EvaluateVectorIndexRecall",
+			additionalArgs:   []interface{}{"classname", "shardname", "", models.VectorIndexRecallRequest{}},
+			expectedVerb:     "list",
+			expectedResource: "schema/*",
+		},
+		testCase{
+			methodName:       "RepairVectorIndex",
+			additionalArgs:   []interface{}{"classname", "shardname", ""},
+			expectedVerb:     "update",
+			expectedResource: "schema/objects",
+		},
+		testCase{
+			methodName:       "GetStorageStatistics",
+			additionalArgs:   []interface{}{"classname"},
+			expectedVerb:     "list",
+			expectedResource: "schema/*",
+		},
+		testCase{
+			methodName:       "ScrubShard",
+			additionalArgs:   []interface{}{"classname", "shardname", false},
+			expectedVerb:     "update",
+			expectedResource: "schema/objects",
+		},
 		testCase{
 			methodName:       "AddClass",
 			additionalArgs:   []interface{}{&models.Class{}},
@@ -115,7 +151,8 @@ func Test_Schema_Authorization(t *testing.T) {
 				authorizer := &authDenier{}
 				manager, err := NewManager(&NilMigrator{}, newFakeRepo(),
 					logger, authorizer, config.Config{},
-					dummyParseVectorConfig, &fakeVectorizerValidator{}, &fakeModuleConfig{},
+					map[string]VectorConfigParser{"hnsw": dummyParseVectorConfig},
+					&fakeVectorizerValidator{}, &fakeModuleConfig{},
 					&fakeClusterState{}, &fakeTxClient{})
 				require.Nil(t, err)
 
@@ -69,6 +69,137 @@ func (m *Manager) GetClass(ctx context.Context, principal *models.Principal,
 	return m.getClassByName(name), nil
 }
 
+// GetShardsStatus reports the state of the local shards of a class, such as
+// the progress of a vector index rebuild
+func (m *Manager) GetShardsStatus(ctx context.Context, principal *models.Principal,
+	className string) (models.ShardStatusList, error) {
+	err := m.authorizer.Authorize(principal, "list", "schema/*")
+	if err != nil {
+		return nil, err
+	}
+
+	if m.getClassByName(className) == nil {
+		return nil, ErrNotFound
+	}
+
+	return m.migrator.GetShardsStatus(ctx, className)
+}
+
+// GetStorageStatistics reports the memory and disk usage of the shards of
+// className, or of all classes if it is empty. Shards held by other nodes are
+// included.
+func (m *Manager) GetStorageStatistics(ctx context.Context,
+	principal *models.Principal,
+	className string) (*models.StorageStatistics, error) {
+	err := m.authorizer.Authorize(principal, "list", "schema/*")
+	if err != nil {
+		return nil, err
+	}
+
+	if className != "" && m.getClassByName(className) == nil {
+		return nil, ErrNotFound
+	}
+
+	return m.migrator.GetStorageStatistics(ctx, className)
+}
+
+// InspectVectorIndex reports the structure of the graph of a vector index of
+// a local shard, such as the number of nodes unreachable from the entrypoint
+func (m *Manager) InspectVectorIndex(ctx context.Context,
+	principal *models.Principal, className, shardName,
+	targetVector string) (*models.VectorIndexGraphReport, error) {
+	err := m.authorizer.Authorize(principal, "list", "schema/*")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.validateVectorIndexTarget(className, shardName,
+		targetVector); err != nil {
+		return nil, err
+	}
+
+	return m.migrator.InspectVectorIndex(ctx, className, shardName, targetVector)
+}
+
+const (
+	// DefaultRecallK is the number of results per query used to evaluate
+	// the recall of a vector index if none is set
+	DefaultRecallK = 10
+
+	// DefaultRecallSampleSize is the number of objects sampled as queries to
+	// evaluate the recall of a vector index if neither queries nor a sample
+	// size are set
+	DefaultRecallSampleSize = 100
+)
+
+// EvaluateVectorIndexRecall compares the results of the vector index of a
+// local shard with an exact search over all of its vectors. This is an
+// expensive operation, as every vector of the shard is read.
+func (m *Manager) EvaluateVectorIndexRecall(ctx context.Context,
+	principal *models.Principal, className, shardName, targetVector string,
+	params models.VectorIndexRecallRequest) (*models.VectorIndexRecallReport, error) {
+	err := m.authorizer.Authorize(principal, "list", "schema/*")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.validateVectorIndexTarget(className, shardName,
+		targetVector); err != nil {
+		return nil, err
+	}
+
+	if params.K == 0 {
+		params.K = DefaultRecallK
+	}
+
+	if len(params.Queries) == 0 && params.SampleSize == 0 {
+		params.SampleSize = DefaultRecallSampleSize
+	}
+
+	return m.migrator.EvaluateVectorIndexRecall(ctx, className, shardName,
+		targetVector, &params)
+}
+
+// validateVectorIndexTarget returns ErrNotFound if the class, the shard or the
+// named vector does not exist
+func (m *Manager) validateVectorIndexTarget(className, shardName,
+	targetVector string) error {
+	class, err := m.validateShard(className, shardName)
+	if err != nil {
+		return err
+	}
+
+	if targetVector == "" {
+		return nil
+	}
+
+	if _, ok := class.VectorConfig[targetVector]; !ok {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// validateShard returns ErrNotFound if the class or the shard does not exist
+func (m *Manager) validateShard(className,
+	shardName string) (*models.Class, error) {
+	class := m.getClassByName(className)
+	if class == nil {
+		return nil, ErrNotFound
+	}
+
+	shardState := m.ShardingState(className)
+	if shardState == nil {
+		return nil, ErrNotFound
+	}
+
+	if _, ok := shardState.Physical[shardName]; !ok {
+		return nil, ErrNotFound
+	}
+
+	return class, nil
+}
+
 func (m *Manager) getClassByName(name string) *models.Class {
 	s := schema.Schema{
 		Objects: m.state.ObjectSchema,
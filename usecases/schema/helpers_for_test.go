@@ -51,6 +51,10 @@ func (f fakeVectorConfig) IndexType() string {
 	return "fake"
 }
 
+func (f fakeVectorConfig) DistanceName() string {
+	return "fake"
+}
+
 func dummyParseVectorConfig(in interface{}) (schema.VectorIndexConfig, error) {
 	return fakeVectorConfig{raw: in}, nil
 }
@@ -42,7 +42,7 @@ type Manager struct {
 	clusterState        clusterState
 	sync.Mutex
 
-	hnswConfigParser VectorConfigParser
+	vectorConfigParsers map[string]VectorConfigParser
 }
 
 type VectorConfigParser func(in interface{}) (schema.VectorIndexConfig, error)
@@ -86,7 +86,8 @@ type clusterState interface {
 // NewManager creates a new manager
 func NewManager(migrator migrate.Migrator, repo Repo,
 	logger logrus.FieldLogger, authorizer authorizer, config config.Config,
-	hnswConfigParser VectorConfigParser, vectorizerValidator VectorizerValidator,
+	vectorConfigParsers map[string]VectorConfigParser,
+	vectorizerValidator VectorizerValidator,
 	moduleConfig ModuleConfig, clusterState clusterState,
 	txClient cluster.Client) (*Manager, error) {
 	m := &Manager{
@@ -96,7 +97,7 @@ func NewManager(migrator migrate.Migrator, repo Repo,
 		state:               State{},
 		logger:              logger,
 		authorizer:          authorizer,
-		hnswConfigParser:    hnswConfigParser,
+		vectorConfigParsers: vectorConfigParsers,
 		vectorizerValidator: vectorizerValidator,
 		moduleConfig:        moduleConfig,
 		cluster:             cluster.NewTxManager(cluster.NewTxBroadcaster(clusterState, txClient)),
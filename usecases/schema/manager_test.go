@@ -66,6 +66,34 @@ func (n *NilMigrator) UpdateVectorIndexConfig(ctx context.Context, className str
 	return nil
 }
 
+func (n *NilMigrator) UpdateNamedVectorIndexConfigs(ctx context.Context, className string, updated map[string]schema.VectorIndexConfig) error {
+	return nil
+}
+
+func (n *NilMigrator) GetShardsStatus(ctx context.Context, className string) (models.ShardStatusList, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) InspectVectorIndex(ctx context.Context, className, shardName, targetVector string) (*models.VectorIndexGraphReport, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) RepairVectorIndex(ctx context.Context, className, shardName, targetVector string) (*models.VectorIndexRepairResult, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) EvaluateVectorIndexRecall(ctx context.Context, className, shardName, targetVector string, params *models.VectorIndexRecallRequest) (*models.VectorIndexRecallReport, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) ScrubShard(ctx context.Context, className, shardName string, quarantine bool) (*models.ShardScrubReport, error) {
+	return nil, nil
+}
+
+func (n *NilMigrator) GetStorageStatistics(ctx context.Context, className string) (*models.StorageStatistics, error) {
+	return nil, nil
+}
+
 var schemaTests = []struct {
 	name string
 	fn   func(*testing.T, *Manager)
@@ -76,6 +104,7 @@ var schemaTests = []struct {
 	{name: "AddObjectClassWithImplicitVectorizer", fn: testAddObjectClassImplicitVectorizer},
 	{name: "AddObjectClassWithWrongVectorizer", fn: testAddObjectClassWrongVectorizer},
 	{name: "AddObjectClassWithWrongIndexType", fn: testAddObjectClassWrongIndexType},
+	{name: "AddObjectClassWithFlatIndexType", fn: testAddObjectClassFlatIndexType},
 	{name: "RemoveObjectClass", fn: testRemoveObjectClass},
 	{name: "CantAddSameClassTwice", fn: testCantAddSameClassTwice},
 	{name: "CantAddSameClassTwiceDifferentKind", fn: testCantAddSameClassTwiceDifferentKinds},
@@ -225,6 +254,28 @@ func testAddObjectClassWrongIndexType(t *testing.T, lsm *Manager) {
 		"\"vector-index-2-million\"", err.Error())
 }
 
+func testAddObjectClassFlatIndexType(t *testing.T, lsm *Manager) {
+	t.Parallel()
+
+	err := lsm.AddClass(context.Background(), nil, &models.Class{
+		Class:             "Car",
+		VectorIndexType:   "flat",
+		VectorIndexConfig: map[string]interface{}{"distance": "dot"},
+		Properties: []*models.Property{{
+			DataType: []string{"string"},
+			Name:     "dummy",
+		}},
+	})
+	require.Nil(t, err)
+
+	objectClasses := testGetClasses(lsm)
+	require.Len(t, objectClasses, 1)
+	assert.Equal(t, "flat", objectClasses[0].VectorIndexType)
+	assert.Equal(t, fakeVectorConfig{
+		raw: map[string]interface{}{"distance": "dot"},
+	}, objectClasses[0].VectorIndexConfig)
+}
+
 func testRemoveObjectClass(t *testing.T, lsm *Manager) {
 	t.Parallel()
 
@@ -453,7 +504,10 @@ func newSchemaManager() *Manager {
 	}
 	sm, err := NewManager(&NilMigrator{}, newFakeRepo(), logger, &fakeAuthorizer{},
 		config.Config{DefaultVectorizerModule: config.VectorizerModuleNone},
-		dummyParseVectorConfig, // only option for now
+		map[string]VectorConfigParser{
+			"hnsw": dummyParseVectorConfig,
+			"flat": dummyParseVectorConfig,
+		},
 		vectorizerValidator, &fakeModuleConfig{}, &fakeClusterState{},
 		&fakeTxClient{},
 	)
@@ -500,7 +554,7 @@ func Test_ParseVectorConfigOnDiskLoad(t *testing.T) {
 	}
 	sm, err := NewManager(&NilMigrator{}, repo, logger, &fakeAuthorizer{},
 		config.Config{DefaultVectorizerModule: config.VectorizerModuleNone},
-		dummyParseVectorConfig, // only option for now
+		map[string]VectorConfigParser{"hnsw": dummyParseVectorConfig},
 		&fakeVectorizerValidator{}, &fakeModuleConfig{}, &fakeClusterState{},
 		&fakeTxClient{},
 	)
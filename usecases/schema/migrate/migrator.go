@@ -37,4 +37,19 @@ type Migrator interface {
 		old, updated schema.VectorIndexConfig) error
 	UpdateVectorIndexConfig(ctx context.Context, className string,
 		updated schema.VectorIndexConfig) error
+	UpdateNamedVectorIndexConfigs(ctx context.Context, className string,
+		updated map[string]schema.VectorIndexConfig) error
+	GetShardsStatus(ctx context.Context,
+		className string) (models.ShardStatusList, error)
+	InspectVectorIndex(ctx context.Context, className, shardName,
+		targetVector string) (*models.VectorIndexGraphReport, error)
+	RepairVectorIndex(ctx context.Context, className, shardName,
+		targetVector string) (*models.VectorIndexRepairResult, error)
+	EvaluateVectorIndexRecall(ctx context.Context, className, shardName,
+		targetVector string,
+		params *models.VectorIndexRecallRequest) (*models.VectorIndexRecallReport, error)
+	ScrubShard(ctx context.Context, className, shardName string,
+		quarantine bool) (*models.ShardScrubReport, error)
+	GetStorageStatistics(ctx context.Context,
+		className string) (*models.StorageStatistics, error)
 }
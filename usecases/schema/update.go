@@ -58,6 +58,14 @@ func (m *Manager) UpdateClass(ctx context.Context, principal *models.Principal,
 		return errors.Wrap(err, "vector index config")
 	}
 
+	initialNamed := schema.NamedVectorIndexConfigs(initial)
+	for name, updatedConfig := range schema.NamedVectorIndexConfigs(updated) {
+		if err := m.migrator.ValidateVectorIndexConfigUpdate(ctx,
+			initialNamed[name], updatedConfig); err != nil {
+			return errors.Wrapf(err, "vector index config of vector %q", name)
+		}
+	}
+
 	if err := sharding.ValidateConfigUpdate(initial.ShardingConfig.(sharding.Config),
 		updated.ShardingConfig.(sharding.Config)); err != nil {
 		return errors.Wrap(err, "sharding config")
@@ -86,6 +94,13 @@ func (m *Manager) updateClassApplyChanges(ctx context.Context, className string,
 		return errors.Wrap(err, "vector index config")
 	}
 
+	if named := schema.NamedVectorIndexConfigs(updated); len(named) > 0 {
+		if err := m.migrator.UpdateNamedVectorIndexConfigs(ctx,
+			className, named); err != nil {
+			return errors.Wrap(err, "named vector index configs")
+		}
+	}
+
 	initial := m.getClassByName(className)
 	if initial == nil {
 		return ErrNotFound
@@ -135,6 +150,37 @@ func (m *Manager) validateImmutableFields(initial, updated *models.Class) error
 		return errors.Errorf("module config is immutable")
 	}
 
+	return validateImmutableNamedVectors(initial, updated)
+}
+
+// validateImmutableNamedVectors makes sure that named vectors are neither
+// added nor removed and that their vectorizer and index type stay the same.
+// Only the vector index config of a named vector can be updated.
+func validateImmutableNamedVectors(initial, updated *models.Class) error {
+	if len(initial.VectorConfig) != len(updated.VectorConfig) {
+		return errors.Errorf("named vectors are immutable: attempted change "+
+			"from %d to %d vectors", len(initial.VectorConfig),
+			len(updated.VectorConfig))
+	}
+
+	for name, initialConfig := range initial.VectorConfig {
+		updatedConfig, ok := updated.VectorConfig[name]
+		if !ok {
+			return errors.Errorf("named vectors are immutable: vector %q "+
+				"is missing", name)
+		}
+
+		if !reflect.DeepEqual(initialConfig.Vectorizer, updatedConfig.Vectorizer) {
+			return errors.Errorf("vectorizer of vector %q is immutable", name)
+		}
+
+		if initialConfig.VectorIndexType != updatedConfig.VectorIndexType {
+			return errors.Errorf("vector index type of vector %q is immutable: "+
+				"attempted change from %q to %q", name,
+				initialConfig.VectorIndexType, updatedConfig.VectorIndexType)
+		}
+	}
+
 	return nil
 }
 
@@ -155,6 +201,42 @@ func (m *Manager) validateImmutableTextField(u immutableText,
 	return nil
 }
 
+// RepairVectorIndex removes the tombstoned nodes from the graph of a vector
+// index of a local shard and reconnects all nodes which can no longer be
+// reached from the entrypoint
+func (m *Manager) RepairVectorIndex(ctx context.Context,
+	principal *models.Principal, className, shardName,
+	targetVector string) (*models.VectorIndexRepairResult, error) {
+	err := m.authorizer.Authorize(principal, "update", "schema/objects")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.validateVectorIndexTarget(className, shardName,
+		targetVector); err != nil {
+		return nil, err
+	}
+
+	return m.migrator.RepairVectorIndex(ctx, className, shardName, targetVector)
+}
+
+// ScrubShard verifies all disk segments of a local shard against their
+// checksums. If quarantine is set, corrupt segments are moved out of the way,
+// otherwise they are only reported.
+func (m *Manager) ScrubShard(ctx context.Context, principal *models.Principal,
+	className, shardName string, quarantine bool) (*models.ShardScrubReport, error) {
+	err := m.authorizer.Authorize(principal, "update", "schema/objects")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := m.validateShard(className, shardName); err != nil {
+		return nil, err
+	}
+
+	return m.migrator.ScrubShard(ctx, className, shardName, quarantine)
+}
+
 // Below here is old - to be deleted
 
 // UpdateObject which exists
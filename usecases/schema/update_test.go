@@ -296,6 +296,63 @@ func TestClassUpdates(t *testing.T) {
 		})
 	})
 
+	t.Run("update named vector index configs", func(t *testing.T) {
+		sm := newSchemaManager()
+		migrator := &configMigrator{}
+		sm.migrator = migrator
+
+		newClass := func(ef interface{}, vectorizer interface{}) *models.Class {
+			return &models.Class{
+				Class: "ClassWithNamedVectors",
+				VectorConfig: map[string]models.VectorConfig{
+					"title": {
+						Vectorizer:        vectorizer,
+						VectorIndexConfig: map[string]interface{}{"ef": ef},
+					},
+				},
+			}
+		}
+
+		t.Run("create an initial class", func(t *testing.T) {
+			err := sm.AddClass(context.Background(), nil, newClass(100, "none"))
+			require.Nil(t, err)
+		})
+
+		t.Run("attempt to change the vectorizer of a named vector", func(t *testing.T) {
+			err := sm.UpdateClass(context.Background(), nil, "ClassWithNamedVectors",
+				newClass(100, map[string]interface{}{"model1": map[string]interface{}{}}))
+			require.NotNil(t, err)
+			assert.Equal(t, "vectorizer of vector \"title\" is immutable", err.Error())
+		})
+
+		t.Run("attempt to remove a named vector", func(t *testing.T) {
+			class := newClass(100, "none")
+			class.VectorConfig = nil
+			err := sm.UpdateClass(context.Background(), nil, "ClassWithNamedVectors", class)
+			require.NotNil(t, err)
+			assert.Equal(t, "named vectors are immutable: attempted change "+
+				"from 1 to 0 vectors", err.Error())
+		})
+
+		t.Run("update the index config of a named vector", func(t *testing.T) {
+			err := sm.UpdateClass(context.Background(), nil, "ClassWithNamedVectors",
+				newClass(200, "none"))
+			require.Nil(t, err)
+
+			expected := map[string]schema.VectorIndexConfig{
+				"title": fakeVectorConfig{raw: map[string]interface{}{"ef": 200}},
+			}
+			assert.Equal(t, expected, migrator.namedVectorConfigsUpdateCalledWith)
+		})
+
+		t.Run("the update is reflected", func(t *testing.T) {
+			class := sm.getClassByName("ClassWithNamedVectors")
+			require.NotNil(t, class)
+			assert.Equal(t, fakeVectorConfig{raw: map[string]interface{}{"ef": 200}},
+				class.VectorConfig["title"].VectorIndexConfig)
+		})
+	})
+
 	t.Run("update sharding config", func(t *testing.T) {
 		t.Run("with a validation error (immutable field)", func(t *testing.T) {
 			sm := newSchemaManager()
@@ -332,6 +389,8 @@ type configMigrator struct {
 	vectorConfigValidateCalledWith schema.VectorIndexConfig
 	vectorConfigUpdateCalled       bool
 	vectorConfigUpdateCalledWith   schema.VectorIndexConfig
+
+	namedVectorConfigsUpdateCalledWith map[string]schema.VectorIndexConfig
 }
 
 func (m *configMigrator) ValidateVectorIndexConfigUpdate(ctx context.Context,
@@ -346,3 +405,9 @@ func (m *configMigrator) UpdateVectorIndexConfig(ctx context.Context,
 	m.vectorConfigUpdateCalled = true
 	return nil
 }
+
+func (m *configMigrator) UpdateNamedVectorIndexConfigs(ctx context.Context,
+	className string, updated map[string]schema.VectorIndexConfig) error {
+	m.namedVectorConfigsUpdateCalledWith = updated
+	return nil
+}
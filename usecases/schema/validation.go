@@ -67,9 +67,42 @@ func (m *Manager) validateVectorSettings(ctx context.Context, class *models.Clas
 		return err
 	}
 
+	for name, vectorConfig := range class.VectorConfig {
+		if err := m.validateNamedVector(ctx, name, vectorConfig); err != nil {
+			return errors.Wrapf(err, "vector %q", name)
+		}
+	}
+
 	return nil
 }
 
+func (m *Manager) validateNamedVector(ctx context.Context, name string,
+	vectorConfig models.VectorConfig) error {
+	if err := schema.ValidateTargetVectorName(name); err != nil {
+		return err
+	}
+
+	if asMap, ok := vectorConfig.Vectorizer.(map[string]interface{}); ok &&
+		len(asMap) != 1 {
+		return errors.Errorf("vectorizer must contain exactly one module, "+
+			"got %d", len(asMap))
+	}
+
+	module, _ := schema.NamedVectorizer(vectorConfig)
+	if module == "" {
+		return errors.Errorf("vectorizer must either be %q or an object with "+
+			"the module name as its only key", config.VectorizerModuleNone)
+	}
+
+	if module != config.VectorizerModuleNone {
+		if err := m.vectorizerValidator.ValidateVectorizer(module); err != nil {
+			return errors.Wrap(err, "vectorizer")
+		}
+	}
+
+	return validateVectorIndexType(vectorConfig.VectorIndexType)
+}
+
 func (m *Manager) validateVectorizer(ctx context.Context, class *models.Class) error {
 	if class.Vectorizer == config.VectorizerModuleNone {
 		return nil
@@ -83,11 +116,15 @@ func (m *Manager) validateVectorizer(ctx context.Context, class *models.Class) e
 }
 
 func (m *Manager) validateVectorIndex(ctx context.Context, class *models.Class) error {
-	switch class.VectorIndexType {
-	case "hnsw":
+	return validateVectorIndexType(class.VectorIndexType)
+}
+
+func validateVectorIndexType(indexType string) error {
+	switch indexType {
+	case "hnsw", "flat":
 		return nil
 	default:
 		return errors.Errorf("unrecognized or unsupported vectorIndexType %q",
-			class.VectorIndexType)
+			indexType)
 	}
 }
@@ -368,3 +368,98 @@ func Test_Validation_PropertyNames(t *testing.T) {
 		})
 	})
 }
+
+func Test_Validation_NamedVectors(t *testing.T) {
+	type testCase struct {
+		name          string
+		vectorConfig  map[string]models.VectorConfig
+		expectedError string
+	}
+
+	tests := []testCase{
+		{
+			name: "vector without vectorizer and with default index type",
+			vectorConfig: map[string]models.VectorConfig{
+				"title": {},
+			},
+		},
+		{
+			name: "several vectors with different vectorizers and index types",
+			vectorConfig: map[string]models.VectorConfig{
+				"title": {
+					Vectorizer: map[string]interface{}{
+						"model1": map[string]interface{}{"vectorizeClassName": false},
+					},
+					VectorIndexType: "hnsw",
+				},
+				"description": {
+					Vectorizer:      "none",
+					VectorIndexType: "flat",
+				},
+			},
+		},
+		{
+			name: "invalid vector name",
+			vectorConfig: map[string]models.VectorConfig{
+				"my-vector": {},
+			},
+			expectedError: "vector \"my-vector\": ",
+		},
+		{
+			name: "vectorizer with more than one module",
+			vectorConfig: map[string]models.VectorConfig{
+				"title": {
+					Vectorizer: map[string]interface{}{
+						"model1": map[string]interface{}{},
+						"model2": map[string]interface{}{},
+					},
+				},
+			},
+			expectedError: "vector \"title\": vectorizer must contain exactly " +
+				"one module, got 2",
+		},
+		{
+			name: "unknown vectorizer module",
+			vectorConfig: map[string]models.VectorConfig{
+				"title": {
+					Vectorizer: map[string]interface{}{
+						"unknown-module": map[string]interface{}{},
+					},
+				},
+			},
+			expectedError: "vector \"title\": vectorizer: ",
+		},
+		{
+			name: "unsupported vector index type",
+			vectorConfig: map[string]models.VectorConfig{
+				"title": {VectorIndexType: "ivf"},
+			},
+			expectedError: "vector \"title\": unrecognized or unsupported " +
+				"vectorIndexType \"ivf\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newSchemaManager()
+			err := m.AddClass(context.Background(), nil, &models.Class{
+				Class:        "ClassWithNamedVectors",
+				VectorConfig: test.vectorConfig,
+			})
+
+			if test.expectedError == "" {
+				require.Nil(t, err)
+				class := m.getClassByName("ClassWithNamedVectors")
+				require.NotNil(t, class)
+				for name := range test.vectorConfig {
+					assert.NotEmpty(t, class.VectorConfig[name].VectorIndexType)
+					assert.NotNil(t, class.VectorConfig[name].Vectorizer)
+				}
+				return
+			}
+
+			require.NotNil(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/storobj"
 	"github.com/semi-technologies/weaviate/usecases/objects"
@@ -65,10 +66,17 @@ type RemoteIndexClient interface {
 	MultiGetObjects(ctx context.Context, hostname, indexName, shardName string,
 		ids []strfmt.UUID) ([]*storobj.Object, error)
 	SearchShard(ctx context.Context, hostname, indexName, shardName string,
-		searchVector []float32, limit int, filters *filters.LocalFilter,
+		searchVector []float32, targetVector string, waitForIndexing bool, limit int,
+		filters *filters.LocalFilter, geoSort *filters.GeoSort,
 		additional additional.Properties) ([]*storobj.Object, []float32, error)
+	BatchSearchShard(ctx context.Context, hostname, indexName, shardName string,
+		searchVectors [][]float32, targetVector string, waitForIndexing bool,
+		limit int, filters *filters.LocalFilter,
+		additional additional.Properties) ([][]*storobj.Object, [][]float32, error)
 	Aggregate(ctx context.Context, hostname, indexName, shardName string,
 		params aggregation.Params) (*aggregation.Result, error)
+	ShardStorageStatistics(ctx context.Context, hostname, indexName,
+		shardName string) (*models.ShardStorageStatistics, error)
 }
 
 func (ri *RemoteIndex) PutObject(ctx context.Context, shardName string,
@@ -177,7 +185,8 @@ func (ri *RemoteIndex) MultiGetObjects(ctx context.Context, shardName string,
 }
 
 func (ri *RemoteIndex) SearchShard(ctx context.Context, shardName string,
-	searchVector []float32, limit int, filters *filters.LocalFilter,
+	searchVector []float32, targetVector string, waitForIndexing bool, limit int,
+	filters *filters.LocalFilter, geoSort *filters.GeoSort,
 	additional additional.Properties) ([]*storobj.Object, []float32, error) {
 	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
 	if !ok {
@@ -189,8 +198,26 @@ func (ri *RemoteIndex) SearchShard(ctx context.Context, shardName string,
 		return nil, nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
 	}
 
-	return ri.client.SearchShard(ctx, host, ri.class, shardName, searchVector, limit,
-		filters, additional)
+	return ri.client.SearchShard(ctx, host, ri.class, shardName, searchVector,
+		targetVector, waitForIndexing, limit, filters, geoSort, additional)
+}
+
+func (ri *RemoteIndex) BatchSearchShard(ctx context.Context, shardName string,
+	searchVectors [][]float32, targetVector string, waitForIndexing bool,
+	limit int, filters *filters.LocalFilter,
+	additional additional.Properties) ([][]*storobj.Object, [][]float32, error) {
+	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
+	if !ok {
+		return nil, nil, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
+	}
+
+	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
+	if !ok {
+		return nil, nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
+	}
+
+	return ri.client.BatchSearchShard(ctx, host, ri.class, shardName,
+		searchVectors, targetVector, waitForIndexing, limit, filters, additional)
 }
 
 func (ri *RemoteIndex) Aggregate(ctx context.Context, shardName string,
@@ -207,3 +234,18 @@ func (ri *RemoteIndex) Aggregate(ctx context.Context, shardName string,
 
 	return ri.client.Aggregate(ctx, host, ri.class, shardName, params)
 }
+
+func (ri *RemoteIndex) ShardStorageStatistics(ctx context.Context,
+	shardName string) (*models.ShardStorageStatistics, error) {
+	shard, ok := ri.stateGetter.ShardingState(ri.class).Physical[shardName]
+	if !ok {
+		return nil, errors.Errorf("class %s has no physical shard %q", ri.class, shardName)
+	}
+
+	host, ok := ri.nodeResolver.NodeHostname(shard.BelongsToNode)
+	if !ok {
+		return nil, errors.Errorf("resolve node name %q to host", shard.BelongsToNode)
+	}
+
+	return ri.client.ShardStorageStatistics(ctx, host, ri.class, shardName)
+}
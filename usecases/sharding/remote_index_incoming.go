@@ -19,6 +19,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/aggregation"
 	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/entities/storobj"
@@ -44,10 +45,17 @@ type RemoteIndexIncomingRepo interface {
 	IncomingMultiGetObjects(ctx context.Context, shardName string,
 		ids []strfmt.UUID) ([]*storobj.Object, error)
 	IncomingSearch(ctx context.Context, shardName string,
-		vector []float32, limit int, filters *filters.LocalFilter,
+		vector []float32, targetVector string, waitForIndexing bool, limit int,
+		filters *filters.LocalFilter, geoSort *filters.GeoSort,
 		additional additional.Properties) ([]*storobj.Object, []float32, error)
+	IncomingBatchSearch(ctx context.Context, shardName string,
+		vectors [][]float32, targetVector string, waitForIndexing bool, limit int,
+		filters *filters.LocalFilter,
+		additional additional.Properties) ([][]*storobj.Object, [][]float32, error)
 	IncomingAggregate(ctx context.Context, shardName string,
 		params aggregation.Params) (*aggregation.Result, error)
+	IncomingShardStorageStatistics(ctx context.Context,
+		shardName string) (*models.ShardStorageStatistics, error)
 }
 
 type RemoteIndexIncoming struct {
@@ -124,14 +132,29 @@ func (rii *RemoteIndexIncoming) MultiGetObjects(ctx context.Context, indexName,
 }
 
 func (rii *RemoteIndexIncoming) Search(ctx context.Context, indexName, shardName string,
-	vector []float32, limit int, filters *filters.LocalFilter,
+	vector []float32, targetVector string, waitForIndexing bool, limit int,
+	filters *filters.LocalFilter, geoSort *filters.GeoSort,
 	additional additional.Properties) ([]*storobj.Object, []float32, error) {
 	index := rii.repo.GetIndexForIncoming(schema.ClassName(indexName))
 	if index == nil {
 		return nil, nil, errors.Errorf("local index %q not found", indexName)
 	}
 
-	return index.IncomingSearch(ctx, shardName, vector, limit, filters, additional)
+	return index.IncomingSearch(ctx, shardName, vector, targetVector,
+		waitForIndexing, limit, filters, geoSort, additional)
+}
+
+func (rii *RemoteIndexIncoming) BatchSearch(ctx context.Context, indexName,
+	shardName string, vectors [][]float32, targetVector string,
+	waitForIndexing bool, limit int, filters *filters.LocalFilter,
+	additional additional.Properties) ([][]*storobj.Object, [][]float32, error) {
+	index := rii.repo.GetIndexForIncoming(schema.ClassName(indexName))
+	if index == nil {
+		return nil, nil, errors.Errorf("local index %q not found", indexName)
+	}
+
+	return index.IncomingBatchSearch(ctx, shardName, vectors, targetVector,
+		waitForIndexing, limit, filters, additional)
 }
 
 func (rii *RemoteIndexIncoming) Aggregate(ctx context.Context, indexName, shardName string,
@@ -143,3 +166,13 @@ func (rii *RemoteIndexIncoming) Aggregate(ctx context.Context, indexName, shardN
 
 	return index.IncomingAggregate(ctx, shardName, params)
 }
+
+func (rii *RemoteIndexIncoming) ShardStorageStatistics(ctx context.Context,
+	indexName, shardName string) (*models.ShardStorageStatistics, error) {
+	index := rii.repo.GetIndexForIncoming(schema.ClassName(indexName))
+	if index == nil {
+		return nil, errors.Errorf("local index %q not found", indexName)
+	}
+
+	return index.IncomingShardStorageStatistics(ctx, shardName)
+}
@@ -52,6 +52,13 @@ func Test_Traverser_Authorization(t *testing.T) {
 			expectedResource: "traversal/*",
 		},
 
+		testCase{
+			methodName:       "BatchSearch",
+			additionalArgs:   []interface{}{BatchSearchParams{}},
+			expectedVerb:     "get",
+			expectedResource: "traversal/*",
+		},
+
 		testCase{
 			methodName:       "Explore",
 			additionalArgs:   []interface{}{ExploreParams{}},
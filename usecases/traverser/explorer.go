@@ -21,6 +21,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/additional"
 	"github.com/semi-technologies/weaviate/entities/filters"
 	"github.com/semi-technologies/weaviate/entities/modulecapabilities"
+	entschema "github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/schema/crossref"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/semi-technologies/weaviate/usecases/schema"
@@ -42,8 +43,9 @@ type Explorer struct {
 type ModulesProvider interface {
 	ValidateSearchParam(name string, value interface{}, className string) error
 	CrossClassValidateSearchParam(name string, value interface{}) error
-	VectorFromSearchParam(ctx context.Context, className string, param string,
-		params interface{}, findVectorFn modulecapabilities.FindVectorFn) ([]float32, error)
+	VectorFromSearchParam(ctx context.Context, className, targetVector string,
+		param string, params interface{},
+		findVectorFn modulecapabilities.FindVectorFn) ([]float32, error)
 	CrossClassVectorFromSearchParam(ctx context.Context, param string,
 		params interface{}, findVectorFn modulecapabilities.FindVectorFn) ([]float32, error)
 	GetExploreAdditionalExtend(ctx context.Context, in []search.Result,
@@ -59,6 +61,8 @@ type distancer func(a, b []float32) (float32, error)
 type vectorClassSearch interface {
 	ClassSearch(ctx context.Context, params GetParams) ([]search.Result, error)
 	VectorClassSearch(ctx context.Context, params GetParams) ([]search.Result, error)
+	BatchVectorClassSearch(ctx context.Context,
+		params BatchSearchParams) ([][]search.Result, error)
 	VectorSearch(ctx context.Context, vector []float32, offset, limit int,
 		filters *filters.LocalFilter) ([]search.Result, error)
 	ObjectByID(ctx context.Context, id strfmt.UUID,
@@ -90,6 +94,10 @@ func (e *Explorer) GetClass(ctx context.Context,
 		return nil, errors.Wrap(err, "invalid 'where' filter")
 	}
 
+	if err := e.validateGeoSort(params); err != nil {
+		return nil, errors.Wrap(err, "invalid 'geoSort'")
+	}
+
 	if params.NearVector != nil || params.NearObject != nil || len(params.ModuleParams) > 0 {
 		return e.getClassExploration(ctx, params)
 	}
@@ -99,6 +107,11 @@ func (e *Explorer) GetClass(ctx context.Context,
 
 func (e *Explorer) getClassExploration(ctx context.Context,
 	params GetParams) ([]interface{}, error) {
+	params.TargetVector = e.targetVectorFromParams(params)
+	if err := e.validateTargetVector(params.ClassName, params.TargetVector); err != nil {
+		return nil, errors.Errorf("explorer: get class: %v", err)
+	}
+
 	searchVector, err := e.vectorFromParams(ctx, params)
 	if err != nil {
 		return nil, errors.Errorf("explorer: get class: vectorize params: %v", err)
@@ -113,7 +126,7 @@ func (e *Explorer) getClassExploration(ctx context.Context,
 		params.AdditionalProperties.Vector = true
 	}
 
-	res, err := e.search.VectorClassSearch(ctx, params)
+	res, err := e.search.VectorClassSearch(ctx, searchParamsFor(params))
 	if err != nil {
 		return nil, errors.Errorf("explorer: get class: vector search: %v", err)
 	}
@@ -140,7 +153,7 @@ func (e *Explorer) getClassExploration(ctx context.Context,
 
 func (e *Explorer) getClassList(ctx context.Context,
 	params GetParams) ([]interface{}, error) {
-	res, err := e.search.ClassSearch(ctx, params)
+	res, err := e.search.ClassSearch(ctx, searchParamsFor(params))
 	if err != nil {
 		return nil, errors.Errorf("explorer: list class: search: %v", err)
 	}
@@ -165,11 +178,33 @@ func (e *Explorer) getClassList(ctx context.Context,
 	return e.searchResultsToGetResponse(ctx, res, nil, params)
 }
 
+// searchParamsFor returns the params to search with. Grouping compares the
+// vectors of the results, so they are loaded even if they weren't requested.
+// The original params still decide whether the vector is part of the response.
+func searchParamsFor(params GetParams) GetParams {
+	if params.Group != nil {
+		params.AdditionalProperties.Vector = true
+	}
+
+	return params
+}
+
 func (e *Explorer) searchResultsToGetResponse(ctx context.Context,
 	input []search.Result,
 	searchVector []float32, params GetParams) ([]interface{}, error) {
 	output := make([]interface{}, 0, len(input))
 
+	var certainty float64
+	if searchVector != nil {
+		certainty = e.extractCertaintyFromParams(params)
+		if certainty > 0 || params.AdditionalProperties.Certainty {
+			if err := e.checkCertaintyCompatibility(params.ClassName,
+				params.TargetVector); err != nil {
+				return nil, errors.Errorf("explorer: %v", err)
+			}
+		}
+	}
+
 	for _, res := range input {
 		additionalProperties := make(map[string]interface{})
 
@@ -184,14 +219,22 @@ func (e *Explorer) searchResultsToGetResponse(ctx context.Context,
 		if searchVector != nil {
 			// Dist is between 0..2, we need to reduce to the user space of 0..1
 			normalizedDist := res.Dist / 2
-			certainty := e.extractCertaintyFromParams(params)
-			if 1-(normalizedDist) < float32(certainty) {
+			if certainty > 0 && 1-(normalizedDist) < float32(certainty) {
 				continue
 			}
 
 			if params.AdditionalProperties.Certainty {
 				additionalProperties["certainty"] = 1 - normalizedDist
 			}
+
+			if params.AdditionalProperties.Distance {
+				additionalProperties["distance"] = res.Dist
+			}
+		}
+
+		if params.GeoSort != nil && params.AdditionalProperties.Distance {
+			// the distance in meters to the point of the geo sort
+			additionalProperties["distance"] = res.Dist
 		}
 
 		if params.AdditionalProperties.ID {
@@ -265,6 +308,42 @@ func (e *Explorer) exctractAdditionalPropertiesFromRef(ref interface{},
 	}
 }
 
+// checkCertaintyCompatibility makes sure that certainty is only used on
+// classes with a cosine distance. Certainty is derived from the distance
+// under the assumption that it is bound to 0..2, which does not hold true for
+// any of the other distance metrics. If a target vector is set, the distance
+// of its vector index is checked instead of the class-level one.
+func (e *Explorer) checkCertaintyCompatibility(className,
+	targetVector string) error {
+	if e.schemaGetter == nil {
+		return nil
+	}
+
+	s := e.schemaGetter.GetSchemaSkipAuth()
+	class := s.GetClass(entschema.ClassName(className))
+	if class == nil {
+		return errors.Errorf("failed to get class: %s", className)
+	}
+
+	vectorIndexConfig := class.VectorIndexConfig
+	if targetVector != "" {
+		vectorIndexConfig = class.VectorConfig[targetVector].VectorIndexConfig
+	}
+
+	vectorConfig, ok := vectorIndexConfig.(entschema.VectorIndexConfig)
+	if !ok {
+		// no parsed vector index config present, assume the default distance
+		return nil
+	}
+
+	if dn := vectorConfig.DistanceName(); dn != "" && dn != "cosine" {
+		return errors.Errorf("can't compute and return certainty when vector "+
+			"index is configured with %s distance, use distance instead", dn)
+	}
+
+	return nil
+}
+
 func (e *Explorer) extractCertaintyFromParams(params GetParams) float64 {
 	if params.NearVector != nil {
 		return params.NearVector.Certainty
@@ -358,7 +437,8 @@ func (e *Explorer) vectorFromParams(ctx context.Context,
 
 	if len(params.ModuleParams) == 1 {
 		for name, value := range params.ModuleParams {
-			return e.vectorFromModules(ctx, params.ClassName, name, value)
+			return e.vectorFromModules(ctx, params.ClassName, params.TargetVector,
+				name, value)
 		}
 	}
 
@@ -367,7 +447,8 @@ func (e *Explorer) vectorFromParams(ctx context.Context,
 	}
 
 	if params.NearObject != nil {
-		vector, err := e.vectorFromNearObjectParams(ctx, params.NearObject)
+		vector, err := e.vectorFromNearObjectParams(ctx, params.NearObject,
+			params.TargetVector)
 		if err != nil {
 			return nil, errors.Errorf("nearObject params: %v", err)
 		}
@@ -398,7 +479,7 @@ func (e *Explorer) vectorFromExploreParams(ctx context.Context,
 	}
 
 	if params.NearObject != nil {
-		vector, err := e.vectorFromNearObjectParams(ctx, params.NearObject)
+		vector, err := e.vectorFromNearObjectParams(ctx, params.NearObject, "")
 		if err != nil {
 			return nil, errors.Errorf("nearObject params: %v", err)
 		}
@@ -412,10 +493,12 @@ func (e *Explorer) vectorFromExploreParams(ctx context.Context,
 }
 
 func (e *Explorer) vectorFromModules(ctx context.Context,
-	className, paramName string, paramValue interface{}) ([]float32, error) {
+	className, targetVector, paramName string,
+	paramValue interface{}) ([]float32, error) {
 	if e.modulesProvider != nil {
 		vector, err := e.modulesProvider.VectorFromSearchParam(ctx,
-			className, paramName, paramValue, e.findVector,
+			className, targetVector, paramName, paramValue,
+			e.findVectorFn(targetVector),
 		)
 		if err != nil {
 			return nil, errors.Errorf("vectorize params: %v", err)
@@ -491,7 +574,7 @@ func (e *Explorer) validateNearParams(nearVector *NearVectorParams, nearObject *
 }
 
 func (e *Explorer) vectorFromNearObjectParams(ctx context.Context,
-	params *NearObjectParams) ([]float32, error) {
+	params *NearObjectParams, targetVector string) ([]float32, error) {
 	if len(params.ID) == 0 && len(params.Beacon) == 0 {
 		return nil, errors.New("empty id and beacon")
 	}
@@ -507,19 +590,77 @@ func (e *Explorer) vectorFromNearObjectParams(ctx context.Context,
 		id = ref.TargetID
 	}
 
-	return e.findVector(ctx, id)
+	return e.findVectorFn(targetVector)(ctx, id)
 }
 
 func (e *Explorer) findVector(ctx context.Context, id strfmt.UUID) ([]float32, error) {
-	res, err := e.search.ObjectByID(ctx, id, search.SelectProperties{}, additional.Properties{})
-	if err != nil {
-		return nil, err
+	return e.findVectorFn("")(ctx, id)
+}
+
+// findVectorFn returns a lookup for the vector of an object. With a target
+// vector set, the named vector is returned instead of the class-level one.
+func (e *Explorer) findVectorFn(targetVector string) modulecapabilities.FindVectorFn {
+	return func(ctx context.Context, id strfmt.UUID) ([]float32, error) {
+		res, err := e.search.ObjectByID(ctx, id, search.SelectProperties{},
+			additional.Properties{Vector: true})
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return nil, errors.New("vector not found")
+		}
+
+		if targetVector == "" {
+			return res.Vector, nil
+		}
+
+		vector, ok := res.Vectors[targetVector]
+		if !ok {
+			return nil, errors.Errorf("object %s has no vector named %q",
+				id, targetVector)
+		}
+
+		return vector, nil
+	}
+}
+
+// targetVectorFromParams returns the name of the vector the params should be
+// searched with. An empty string refers to the class-level vector.
+func (e *Explorer) targetVectorFromParams(params GetParams) string {
+	if params.NearVector != nil {
+		return params.NearVector.TargetVector
+	}
+
+	if params.NearObject != nil {
+		return params.NearObject.TargetVector
+	}
+
+	for _, value := range params.ModuleParams {
+		if targetVectorParam, ok := value.(modulecapabilities.TargetVectorParam); ok {
+			return targetVectorParam.GetTargetVector()
+		}
 	}
-	if res == nil {
-		return nil, errors.New("vector not found")
+
+	return ""
+}
+
+func (e *Explorer) validateTargetVector(className, targetVector string) error {
+	if targetVector == "" || e.schemaGetter == nil {
+		return nil
+	}
+
+	s := e.schemaGetter.GetSchemaSkipAuth()
+	class := s.GetClass(entschema.ClassName(className))
+	if class == nil {
+		return errors.Errorf("failed to get class: %s", className)
+	}
+
+	if _, ok := class.VectorConfig[targetVector]; !ok {
+		return errors.Errorf("class %s has no vector named %q", className,
+			targetVector)
 	}
 
-	return res.Vector, nil
+	return nil
 }
 
 func beacon(res search.Result) string {
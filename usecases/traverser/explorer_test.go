@@ -21,6 +21,7 @@ import (
 	"github.com/semi-technologies/weaviate/entities/filters"
 	"github.com/semi-technologies/weaviate/entities/models"
 	"github.com/semi-technologies/weaviate/entities/modulecapabilities"
+	"github.com/semi-technologies/weaviate/entities/schema"
 	"github.com/semi-technologies/weaviate/entities/search"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
@@ -1956,7 +1957,7 @@ type fakeModulesProvider struct {
 }
 
 func (p *fakeModulesProvider) VectorFromSearchParam(ctx context.Context, className,
-	param string, params interface{},
+	targetVector, param string, params interface{},
 	findVectorFn modulecapabilities.FindVectorFn) ([]float32, error) {
 	txt2vec := p.getFakeT2Vec()
 	vectorForParams := txt2vec.VectorSearches()["nearCustomText"]
@@ -2074,3 +2075,274 @@ func getFakeModulesProviderWithCustomExtenders(
 func getFakeModulesProvider() ModulesProvider {
 	return &fakeModulesProvider{}
 }
+
+func Test_Explorer_GetClass_WithDistance(t *testing.T) {
+	l2Schema := schema.Schema{
+		Objects: &models.Schema{
+			Classes: []*models.Class{
+				{
+					Class:             "BestClass",
+					VectorIndexConfig: fakeVectorIndexConfig{distance: "l2-squared"},
+				},
+			},
+		},
+	}
+
+	searchResults := []search.Result{
+		{
+			ID: "id1",
+			Schema: map[string]interface{}{
+				"name": "Foo",
+			},
+			Dist: 7.5,
+		},
+	}
+
+	t.Run("when the distance prop is set", func(t *testing.T) {
+		params := GetParams{
+			ClassName: "BestClass",
+			NearVector: &NearVectorParams{
+				Vector: []float32{0.8, 0.2, 0.7},
+			},
+			Pagination: &filters.Pagination{Limit: 100},
+			AdditionalProperties: additional.Properties{
+				Distance: true,
+			},
+		}
+
+		search := &fakeVectorSearcher{}
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(search, newFakeDistancer(), log, getFakeModulesProvider())
+		explorer.SetSchemaGetter(&fakeSchemaGetter{l2Schema})
+		expectedParamsToSearch := params
+		expectedParamsToSearch.SearchVector = []float32{0.8, 0.2, 0.7}
+		search.
+			On("VectorClassSearch", expectedParamsToSearch).
+			Return(searchResults, nil)
+
+		res, err := explorer.GetClass(context.Background(), params)
+		require.Nil(t, err)
+		require.Len(t, res, 1)
+
+		// the raw distance is returned as is, regardless of the metric
+		additionalMap := res[0].(map[string]interface{})["_additional"]
+		assert.Equal(t, float32(7.5), additionalMap.(map[string]interface{})["distance"])
+	})
+
+	t.Run("when certainty is requested on a non-cosine class", func(t *testing.T) {
+		params := GetParams{
+			ClassName: "BestClass",
+			NearVector: &NearVectorParams{
+				Vector: []float32{0.8, 0.2, 0.7},
+			},
+			Pagination: &filters.Pagination{Limit: 100},
+			AdditionalProperties: additional.Properties{
+				Certainty: true,
+			},
+		}
+
+		search := &fakeVectorSearcher{}
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(search, newFakeDistancer(), log, getFakeModulesProvider())
+		explorer.SetSchemaGetter(&fakeSchemaGetter{l2Schema})
+		expectedParamsToSearch := params
+		expectedParamsToSearch.SearchVector = []float32{0.8, 0.2, 0.7}
+		search.
+			On("VectorClassSearch", expectedParamsToSearch).
+			Return(searchResults, nil)
+
+		_, err := explorer.GetClass(context.Background(), params)
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "l2-squared distance")
+	})
+}
+
+func Test_Explorer_GetClass_WithGeoSort(t *testing.T) {
+	geoSchema := schema.Schema{
+		Objects: &models.Schema{
+			Classes: []*models.Class{
+				{
+					Class: "BestClass",
+					Properties: []*models.Property{
+						{Name: "location", DataType: []string{"geoCoordinates"}},
+						{Name: "name", DataType: []string{"string"}},
+					},
+				},
+			},
+		},
+	}
+
+	lat, lon := float32(52.366667), float32(4.9)
+	geoSort := &filters.GeoSort{
+		Property: "location",
+		GeoCoordinates: &models.GeoCoordinates{
+			Latitude:  &lat,
+			Longitude: &lon,
+		},
+	}
+
+	t.Run("when the distance prop is set", func(t *testing.T) {
+		params := GetParams{
+			ClassName:  "BestClass",
+			GeoSort:    geoSort,
+			Pagination: &filters.Pagination{Limit: 100},
+			AdditionalProperties: additional.Properties{
+				Distance: true,
+			},
+		}
+
+		searchResults := []search.Result{
+			{
+				ID:     "id1",
+				Schema: map[string]interface{}{"name": "Foo"},
+				Dist:   1200,
+			},
+			{
+				ID:     "id2",
+				Schema: map[string]interface{}{"name": "Bar"},
+				Dist:   5300,
+			},
+		}
+
+		search := &fakeVectorSearcher{}
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(search, newFakeDistancer(), log, getFakeModulesProvider())
+		explorer.SetSchemaGetter(&fakeSchemaGetter{geoSchema})
+		search.
+			On("ClassSearch", params).
+			Return(searchResults, nil)
+
+		res, err := explorer.GetClass(context.Background(), params)
+		require.Nil(t, err)
+		search.AssertExpectations(t)
+
+		// the order of the search is kept, the distance is returned in meters
+		require.Len(t, res, 2)
+		assert.Equal(t, map[string]interface{}{
+			"name":        "Foo",
+			"_additional": map[string]interface{}{"distance": float32(1200)},
+		}, res[0])
+		assert.Equal(t, map[string]interface{}{
+			"name":        "Bar",
+			"_additional": map[string]interface{}{"distance": float32(5300)},
+		}, res[1])
+	})
+
+	t.Run("on a property that is not a geo property", func(t *testing.T) {
+		params := GetParams{
+			ClassName: "BestClass",
+			GeoSort: &filters.GeoSort{
+				Property:       "name",
+				GeoCoordinates: geoSort.GeoCoordinates,
+			},
+			Pagination: &filters.Pagination{Limit: 100},
+		}
+
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(&fakeVectorSearcher{}, newFakeDistancer(), log,
+			getFakeModulesProvider())
+		explorer.SetSchemaGetter(&fakeSchemaGetter{geoSchema})
+
+		_, err := explorer.GetClass(context.Background(), params)
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "is not of type \"geoCoordinates\"")
+	})
+
+	t.Run("when combined with a vector search", func(t *testing.T) {
+		params := GetParams{
+			ClassName: "BestClass",
+			GeoSort:   geoSort,
+			NearVector: &NearVectorParams{
+				Vector: []float32{0.8, 0.2, 0.7},
+			},
+			Pagination: &filters.Pagination{Limit: 100},
+		}
+
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(&fakeVectorSearcher{}, newFakeDistancer(), log,
+			getFakeModulesProvider())
+		explorer.SetSchemaGetter(&fakeSchemaGetter{geoSchema})
+
+		_, err := explorer.GetClass(context.Background(), params)
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "cannot be combined with a vector search")
+	})
+}
+
+func Test_Explorer_GetClass_WithGroup(t *testing.T) {
+	// the vectors are loaded for grouping only, so none of the results are
+	// expected to show them in the response
+	searchResults := []search.Result{
+		{
+			ID:     "id1",
+			Schema: map[string]interface{}{"name": "A1"},
+			Vector: []float32{0.1, 0.1, 0.98},
+		},
+		{
+			ID:     "id2",
+			Schema: map[string]interface{}{"name": "A2"},
+			Vector: []float32{0.1, 0.1, 0.96},
+		},
+		{
+			ID:     "id3",
+			Schema: map[string]interface{}{"name": "B1"},
+			Vector: []float32{0.98, 0.1, 0.1},
+		},
+	}
+
+	group := &GroupParams{Strategy: "closest", Force: 0.1}
+
+	t.Run("with a vector search, without requesting the vector", func(t *testing.T) {
+		params := GetParams{
+			ClassName: "BestClass",
+			NearVector: &NearVectorParams{
+				Vector: []float32{0.8, 0.2, 0.7},
+			},
+			Pagination: &filters.Pagination{Limit: 100},
+			Group:      group,
+		}
+
+		search := &fakeVectorSearcher{}
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(search, newFakeDistancer(), log, getFakeModulesProvider())
+		expectedParamsToSearch := params
+		expectedParamsToSearch.SearchVector = []float32{0.8, 0.2, 0.7}
+		expectedParamsToSearch.AdditionalProperties.Vector = true
+		search.
+			On("VectorClassSearch", expectedParamsToSearch).
+			Return(searchResults, nil)
+
+		res, err := explorer.GetClass(context.Background(), params)
+		require.Nil(t, err)
+		search.AssertExpectations(t)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "A1"},
+			map[string]interface{}{"name": "B1"},
+		}, res)
+	})
+
+	t.Run("with a list search, without requesting the vector", func(t *testing.T) {
+		params := GetParams{
+			ClassName:  "BestClass",
+			Pagination: &filters.Pagination{Limit: 100},
+			Group:      group,
+		}
+
+		search := &fakeVectorSearcher{}
+		log, _ := test.NewNullLogger()
+		explorer := NewExplorer(search, newFakeDistancer(), log, getFakeModulesProvider())
+		expectedParamsToSearch := params
+		expectedParamsToSearch.AdditionalProperties.Vector = true
+		search.
+			On("ClassSearch", expectedParamsToSearch).
+			Return(searchResults, nil)
+
+		res, err := explorer.GetClass(context.Background(), params)
+		require.Nil(t, err)
+		search.AssertExpectations(t)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "A1"},
+			map[string]interface{}{"name": "B1"},
+		}, res)
+	})
+}
@@ -0,0 +1,51 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package traverser
+
+import (
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/schema"
+)
+
+func (e *Explorer) validateGeoSort(params GetParams) error {
+	geoSort := params.GeoSort
+	if geoSort == nil {
+		return nil
+	}
+
+	if params.NearVector != nil || params.NearObject != nil ||
+		len(params.ModuleParams) > 0 {
+		return errors.Errorf("cannot be combined with a vector search, " +
+			"results are already ordered by their vector distance")
+	}
+
+	if geoSort.GeoCoordinates == nil ||
+		geoSort.GeoCoordinates.Latitude == nil ||
+		geoSort.GeoCoordinates.Longitude == nil {
+		return errors.Errorf("latitude and longitude must be set")
+	}
+
+	sch := e.schemaGetter.GetSchemaSkipAuth()
+	prop, err := sch.GetProperty(schema.ClassName(params.ClassName),
+		schema.PropertyName(geoSort.Property))
+	if err != nil {
+		return err
+	}
+
+	if len(prop.DataType) != 1 ||
+		prop.DataType[0] != string(schema.DataTypeGeoCoordinates) {
+		return errors.Errorf("property %q is not of type %q", geoSort.Property,
+			schema.DataTypeGeoCoordinates)
+	}
+
+	return nil
+}
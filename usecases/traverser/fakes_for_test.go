@@ -103,6 +103,12 @@ func (f *fakeVectorSearcher) VectorClassSearch(ctx context.Context,
 	return args.Get(0).([]search.Result), args.Error(1)
 }
 
+func (f *fakeVectorSearcher) BatchVectorClassSearch(ctx context.Context,
+	params BatchSearchParams) ([][]search.Result, error) {
+	args := f.Called(params)
+	return args.Get(0).([][]search.Result), args.Error(1)
+}
+
 func (f *fakeVectorSearcher) ClassSearch(ctx context.Context,
 	params GetParams) ([]search.Result, error) {
 	args := f.Called(params)
@@ -154,6 +160,10 @@ func (f *fakeExplorer) GetClass(ctx context.Context, p GetParams) ([]interface{}
 	return nil, nil
 }
 
+func (f *fakeExplorer) BatchSearch(ctx context.Context, p BatchSearchParams) ([][]search.Result, error) {
+	return nil, nil
+}
+
 func (f *fakeExplorer) Concepts(ctx context.Context, p ExploreParams) ([]search.Result, error) {
 	return nil, nil
 }
@@ -170,6 +180,18 @@ func (f *fakeSchemaGetter) ShardingState(class string) *sharding.State {
 	panic("not implemented")
 }
 
+type fakeVectorIndexConfig struct {
+	distance string
+}
+
+func (f fakeVectorIndexConfig) IndexType() string {
+	return "fake"
+}
+
+func (f fakeVectorIndexConfig) DistanceName() string {
+	return f.distance
+}
+
 type fakeInterpretation struct{}
 
 func (f *fakeInterpretation) AdditionalPropertyFn(ctx context.Context,
@@ -52,6 +52,7 @@ type VectorSearcher interface {
 type explorer interface {
 	GetClass(ctx context.Context, params GetParams) ([]interface{}, error)
 	Concepts(ctx context.Context, params ExploreParams) ([]search.Result, error)
+	BatchSearch(ctx context.Context, params BatchSearchParams) ([][]search.Result, error)
 }
 
 // NewTraverser to traverse the knowledge graph
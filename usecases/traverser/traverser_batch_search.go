@@ -0,0 +1,81 @@
+//                           _       _
+// __      _____  __ ___   ___  __ _| |_ ___
+// \ \ /\ / / _ \/ _` \ \ / / |/ _` | __/ _ \
+//  \ V  V /  __/ (_| |\ V /| | (_| | ||  __/
+//   \_/\_/ \___|\__,_| \_/ |_|\__,_|\__\___|
+//
+//  Copyright © 2016 - 2021 SeMI Technologies B.V. All rights reserved.
+//
+//  CONTACT: hello@semi.technology
+//
+
+package traverser
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/semi-technologies/weaviate/entities/additional"
+	"github.com/semi-technologies/weaviate/entities/filters"
+	"github.com/semi-technologies/weaviate/entities/models"
+	"github.com/semi-technologies/weaviate/entities/search"
+)
+
+// BatchSearchParams describe one vector search per search vector against the
+// same class. All searches share the filter and the limit.
+type BatchSearchParams struct {
+	ClassName            string
+	SearchVectors        [][]float32
+	TargetVector         string
+	Filters              *filters.LocalFilter
+	Limit                int // -1 means the default query limit
+	WaitForIndexing      bool
+	AdditionalProperties additional.Properties
+}
+
+// BatchSearch runs all searches of the params and returns one result list per
+// search vector, in the order of the search vectors
+func (t *Traverser) BatchSearch(ctx context.Context, principal *models.Principal,
+	params BatchSearchParams) ([][]search.Result, error) {
+	err := t.authorizer.Authorize(principal, "get", "traversal/*")
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := t.locks.LockConnector()
+	if err != nil {
+		return nil, fmt.Errorf("could not acquire lock: %v", err)
+	}
+	defer unlock()
+
+	return t.explorer.BatchSearch(ctx, params)
+}
+
+func (e *Explorer) BatchSearch(ctx context.Context,
+	params BatchSearchParams) ([][]search.Result, error) {
+	if len(params.SearchVectors) == 0 {
+		return nil, errors.Errorf("explorer: batch search: no search vectors")
+	}
+
+	for i, vector := range params.SearchVectors {
+		if len(vector) == 0 {
+			return nil, errors.Errorf("explorer: batch search: search vector %d is empty", i)
+		}
+	}
+
+	if err := e.validateFilters(params.Filters); err != nil {
+		return nil, errors.Wrap(err, "invalid 'where' filter")
+	}
+
+	if err := e.validateTargetVector(params.ClassName, params.TargetVector); err != nil {
+		return nil, errors.Errorf("explorer: batch search: %v", err)
+	}
+
+	res, err := e.search.BatchVectorClassSearch(ctx, params)
+	if err != nil {
+		return nil, errors.Errorf("explorer: batch search: vector search: %v", err)
+	}
+
+	return res, nil
+}
@@ -34,14 +34,16 @@ func (t *Traverser) Explore(ctx context.Context,
 }
 
 type NearVectorParams struct {
-	Vector    []float32
-	Certainty float64
+	Vector       []float32
+	Certainty    float64
+	TargetVector string
 }
 
 type NearObjectParams struct {
-	ID        string
-	Beacon    string
-	Certainty float64
+	ID           string
+	Beacon       string
+	Certainty    float64
+	TargetVector string
 }
 
 // ExploreParams are the parameters used by the GraphQL `Explore { }` API
@@ -25,6 +25,9 @@ type GetParams struct {
 	NearVector           *NearVectorParams
 	NearObject           *NearObjectParams
 	SearchVector         []float32
+	TargetVector         string
+	WaitForIndexing      bool
+	GeoSort              *filters.GeoSort
 	Group                *GroupParams
 	ModuleParams         map[string]interface{}
 	AdditionalProperties additional.Properties